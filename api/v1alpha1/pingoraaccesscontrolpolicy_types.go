@@ -0,0 +1,107 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PingoraAccessControlPolicyKind is the kind of PingoraAccessControlPolicy.
+const PingoraAccessControlPolicyKind = "PingoraAccessControlPolicy"
+
+// AccessAction is what happens to a request.
+// +kubebuilder:validation:Enum=Allow;Deny
+type AccessAction string
+
+const (
+	// AccessActionAllow lets the request through.
+	AccessActionAllow AccessAction = "Allow"
+
+	// AccessActionDeny answers the request with 403.
+	AccessActionDeny AccessAction = "Deny"
+)
+
+// PingoraAccessControlPolicySpec defines which client addresses may send
+// requests to the targets of the policy.
+//
+// A request from an address in Deny is denied. Otherwise a request from an
+// address in Allow is allowed, and any other request gets DefaultAction.
+type PingoraAccessControlPolicySpec struct {
+	// TargetRefs are the HTTPRoutes and Gateways in the policy's namespace
+	// that the policy applies to. sectionName selects a named HTTPRoute rule
+	// or Gateway listener.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.group == 'gateway.networking.k8s.io' && (ref.kind == 'HTTPRoute' || ref.kind == 'Gateway'))",message="targetRefs must reference HTTPRoutes or Gateways"
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// Allow lists the client addresses that are allowed, as CIDRs such as
+	// "10.0.0.0/8" or single IP addresses.
+	// +optional
+	// +kubebuilder:validation:MaxItems=256
+	// +kubebuilder:validation:items:MaxLength=64
+	Allow []string `json:"allow,omitempty"`
+
+	// Deny lists the client addresses that are denied, as CIDRs or single IP
+	// addresses. Deny takes precedence over Allow.
+	// +optional
+	// +kubebuilder:validation:MaxItems=256
+	// +kubebuilder:validation:items:MaxLength=64
+	Deny []string `json:"deny,omitempty"`
+
+	// DefaultAction applies to requests from addresses in neither list.
+	// +optional
+	// +kubebuilder:default=Allow
+	DefaultAction AccessAction `json:"defaultAction,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgacl
+// +kubebuilder:metadata:labels="gateway.networking.k8s.io/policy=Direct"
+// +kubebuilder:printcolumn:name="Default",type=string,JSONPath=`.spec.defaultAction`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraAccessControlPolicy is the Schema for the pingoraaccesscontrolpolicies
+// API. It restricts client addresses of HTTPRoutes and Gateway listeners with
+// Gateway API policy attachment.
+type PingoraAccessControlPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec   PingoraAccessControlPolicySpec `json:"spec,omitempty"`   //nolint:modernize // kubebuilder standard
+	Status gatewayv1.PolicyStatus         `json:"status,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraAccessControlPolicyList contains a list of PingoraAccessControlPolicy.
+type PingoraAccessControlPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraAccessControlPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraAccessControlPolicy{}, &PingoraAccessControlPolicyList{})
+}
+
+// GetDefaultAction returns the default action, defaulting to
+// AccessActionAllow.
+func (s *PingoraAccessControlPolicySpec) GetDefaultAction() AccessAction {
+	if s.DefaultAction == "" {
+		return AccessActionAllow
+	}
+
+	return s.DefaultAction
+}
+
+// GetTargetRefs returns the resources the policy is attached to.
+func (p *PingoraAccessControlPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// GetPolicyStatus returns the policy status for in-place updates.
+func (p *PingoraAccessControlPolicy) GetPolicyStatus() *gatewayv1.PolicyStatus {
+	return &p.Status
+}
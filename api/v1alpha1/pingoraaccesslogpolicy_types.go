@@ -0,0 +1,69 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PingoraAccessLogPolicyKind is the kind of PingoraAccessLogPolicy.
+const PingoraAccessLogPolicyKind = "PingoraAccessLogPolicy"
+
+// PingoraAccessLogPolicySpec defines the access log sampling of the targets
+// of the policy. It replaces the sampling of spec.defaults.accessLog of the
+// PingoraConfig; its path filters still apply.
+type PingoraAccessLogPolicySpec struct {
+	// TargetRefs are the HTTPRoutes in the policy's namespace whose requests
+	// are sampled. sectionName selects a single named rule.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.group == 'gateway.networking.k8s.io' && ref.kind == 'HTTPRoute')",message="targetRefs must reference HTTPRoutes"
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// SamplePercent is the percentage of requests logged. 0 logs no
+	// requests of the targets.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplePercent int32 `json:"samplePercent"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgaccesslog
+// +kubebuilder:metadata:labels="gateway.networking.k8s.io/policy=Direct"
+// +kubebuilder:printcolumn:name="Sample",type=integer,JSONPath=`.spec.samplePercent`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraAccessLogPolicy is the Schema for the pingoraaccesslogpolicies API.
+// It changes the access log sampling of HTTPRoutes with Gateway API policy
+// attachment.
+type PingoraAccessLogPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec   PingoraAccessLogPolicySpec `json:"spec,omitempty"`   //nolint:modernize // kubebuilder standard
+	Status gatewayv1.PolicyStatus     `json:"status,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraAccessLogPolicyList contains a list of PingoraAccessLogPolicy.
+type PingoraAccessLogPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraAccessLogPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraAccessLogPolicy{}, &PingoraAccessLogPolicyList{})
+}
+
+// GetTargetRefs returns the resources the policy is attached to.
+func (p *PingoraAccessLogPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// GetPolicyStatus returns the policy status for in-place updates.
+func (p *PingoraAccessLogPolicy) GetPolicyStatus() *gatewayv1.PolicyStatus {
+	return &p.Status
+}
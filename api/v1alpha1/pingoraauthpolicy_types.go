@@ -0,0 +1,214 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PingoraAuthPolicyKind is the kind of PingoraAuthPolicy.
+const PingoraAuthPolicyKind = "PingoraAuthPolicy"
+
+// DefaultJWKSKey is the key of a Secret or ConfigMap that holds the JSON Web
+// Key Set when none is given.
+const DefaultJWKSKey = "jwks.json"
+
+// KeyReference references a key of a Secret or ConfigMap in the policy's
+// namespace.
+type KeyReference struct {
+	// Name is the name of the Secret or ConfigMap.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name"`
+
+	// Key is the key holding the JSON Web Key Set.
+	// +optional
+	// +kubebuilder:default="jwks.json"
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key,omitempty"`
+}
+
+// GetKey returns the referenced key, defaulting to DefaultJWKSKey.
+func (r *KeyReference) GetKey() string {
+	if r.Key == "" {
+		return DefaultJWKSKey
+	}
+
+	return r.Key
+}
+
+// JWKSSource defines where the keys that sign tokens are read from.
+// +kubebuilder:validation:XValidation:rule="[has(self.uri), has(self.secretRef), has(self.configMapRef)].filter(x, x).size() == 1",message="exactly one of uri, secretRef and configMapRef must be set"
+type JWKSSource struct {
+	// URI is an HTTPS URL that the proxy fetches the JSON Web Key Set from.
+	// +optional
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https://`
+	URI string `json:"uri,omitempty"`
+
+	// SecretRef references a Secret holding the JSON Web Key Set.
+	// +optional
+	SecretRef *KeyReference `json:"secretRef,omitempty"`
+
+	// ConfigMapRef references a ConfigMap holding the JSON Web Key Set.
+	// +optional
+	ConfigMapRef *KeyReference `json:"configMapRef,omitempty"`
+}
+
+// ClaimToHeader copies a token claim to a request header.
+type ClaimToHeader struct {
+	// Claim is the name of a top-level claim of the token. String, number
+	// and boolean claims are copied as is; other claims are sent as JSON.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Claim string `json:"claim"`
+
+	// Header is the name of the request header the claim is sent in. The
+	// header is removed from requests without the claim, so clients cannot
+	// set it themselves.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+\-.^_\x60|~]+$`
+	Header string `json:"header"`
+}
+
+// JWTAuth defines how bearer tokens are validated.
+type JWTAuth struct {
+	// Issuers are the accepted values of the iss claim. Tokens of any issuer
+	// are accepted if empty.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Issuers []string `json:"issuers,omitempty"`
+
+	// Audiences are the accepted values of the aud claim. A token is accepted
+	// if its audience contains one of them. Tokens for any audience are
+	// accepted if empty.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Audiences []string `json:"audiences,omitempty"`
+
+	// JWKS is the source of the keys that tokens must be signed with.
+	JWKS JWKSSource `json:"jwks"`
+
+	// ClaimsToHeaders copies claims of valid tokens to request headers sent
+	// to the backends.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	ClaimsToHeaders []ClaimToHeader `json:"claimsToHeaders,omitempty"`
+}
+
+// ExternalAuthProtocol is the protocol spoken with an external auth service.
+// +kubebuilder:validation:Enum=GRPC;HTTP
+type ExternalAuthProtocol string
+
+const (
+	// ExternalAuthProtocolGRPC calls the Check method of the Envoy
+	// envoy.service.auth.v3.Authorization gRPC service.
+	ExternalAuthProtocolGRPC ExternalAuthProtocol = "GRPC"
+
+	// ExternalAuthProtocolHTTP sends the request headers to the service in an
+	// HTTP request with the original method and path.
+	ExternalAuthProtocolHTTP ExternalAuthProtocol = "HTTP"
+)
+
+// ExternalAuth defines an external authorization service that the proxy
+// consults before forwarding a request.
+// +kubebuilder:validation:XValidation:rule="self.protocol == 'HTTP' || !has(self.pathPrefix)",message="pathPrefix is only supported for the HTTP protocol"
+type ExternalAuth struct {
+	// Protocol is the protocol spoken with the service.
+	Protocol ExternalAuthProtocol `json:"protocol"`
+
+	// BackendRef is the Service of the auth service in the policy's
+	// namespace. The port is required.
+	// +kubebuilder:validation:XValidation:rule="(!has(self.group) || self.group == '') && (!has(self.kind) || self.kind == 'Service')",message="backendRef must reference a Service"
+	// +kubebuilder:validation:XValidation:rule="!has(self.__namespace__)",message="backendRef must be in the policy's namespace"
+	// +kubebuilder:validation:XValidation:rule="has(self.port)",message="backendRef port is required"
+	BackendRef gatewayv1.BackendObjectReference `json:"backendRef"`
+
+	// PathPrefix is prepended to the request path in HTTP auth requests.
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^/`
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// Timeout bounds the time the proxy waits for the auth service.
+	// Defaults to the proxy default.
+	// +optional
+	Timeout *gatewayv1.Duration `json:"timeout,omitempty"`
+
+	// FailOpen forwards requests when the auth service cannot be reached or
+	// times out. By default such requests are answered with 503.
+	// +optional
+	FailOpen bool `json:"failOpen,omitempty"`
+
+	// HeadersToBackend are the headers of an allowing auth response that are
+	// added to the request forwarded to the backends.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	HeadersToBackend []string `json:"headersToBackend,omitempty"`
+}
+
+// PingoraAuthPolicySpec defines the authentication required by the targets
+// of the policy. At least one of JWT and External must be set; when both are,
+// the token is validated first.
+// +kubebuilder:validation:XValidation:rule="has(self.jwt) || has(self.external)",message="at least one of jwt and external must be set"
+type PingoraAuthPolicySpec struct {
+	// TargetRefs are the HTTPRoutes in the policy's namespace that require
+	// authentication. sectionName selects a single named rule.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.group == 'gateway.networking.k8s.io' && ref.kind == 'HTTPRoute')",message="targetRefs must reference HTTPRoutes"
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// JWT requires requests to carry a valid JSON Web Token in the
+	// Authorization header as a bearer token. Other requests are answered
+	// with 401.
+	// +optional
+	JWT *JWTAuth `json:"jwt,omitempty"`
+
+	// External requires requests to be allowed by an external authorization
+	// service. Denied requests are answered with the service's response.
+	// +optional
+	External *ExternalAuth `json:"external,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgauth
+// +kubebuilder:metadata:labels="gateway.networking.k8s.io/policy=Direct"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraAuthPolicy is the Schema for the pingoraauthpolicies API.
+// It requires authentication for HTTPRoutes with Gateway API policy
+// attachment.
+type PingoraAuthPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec   PingoraAuthPolicySpec  `json:"spec,omitempty"`   //nolint:modernize // kubebuilder standard
+	Status gatewayv1.PolicyStatus `json:"status,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraAuthPolicyList contains a list of PingoraAuthPolicy.
+type PingoraAuthPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraAuthPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraAuthPolicy{}, &PingoraAuthPolicyList{})
+}
+
+// GetTargetRefs returns the resources the policy is attached to.
+func (p *PingoraAuthPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// GetPolicyStatus returns the policy status for in-place updates.
+func (p *PingoraAuthPolicy) GetPolicyStatus() *gatewayv1.PolicyStatus {
+	return &p.Status
+}
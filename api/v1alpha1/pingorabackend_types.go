@@ -0,0 +1,80 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PingoraBackendKind is the kind of PingoraBackend.
+const PingoraBackendKind = "PingoraBackend"
+
+// BackendEndpoint is an upstream of a PingoraBackend.
+type BackendEndpoint struct {
+	// Host is the IP address or the DNS name of the upstream.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9.:-]+$`
+	Host string `json:"host"`
+
+	// Port is the port of the upstream.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// BackendTLS configures TLS for connections to the endpoints of a
+// PingoraBackend.
+type BackendTLS struct {
+	// SNI is the server name sent in the TLS handshake and verified against
+	// the certificate of the upstream. The host of each endpoint is used if
+	// empty.
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	SNI string `json:"sni,omitempty"`
+
+	// InsecureSkipVerify disables the verification of upstream
+	// certificates. Use it only for testing.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// PingoraBackendSpec defines the upstreams outside the cluster that routes
+// reach through the PingoraBackend.
+type PingoraBackendSpec struct {
+	// Endpoints are the upstreams that requests are balanced across.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	Endpoints []BackendEndpoint `json:"endpoints"`
+
+	// TLS enables TLS for connections to the endpoints.
+	// +optional
+	TLS *BackendTLS `json:"tls,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=pgb
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraBackend is the Schema for the pingorabackends API.
+// It describes upstreams outside the cluster, such as static IP addresses
+// and external hostnames, that HTTPRoute and GRPCRoute backendRefs can
+// reference instead of a Service.
+type PingoraBackend struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec PingoraBackendSpec `json:"spec,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraBackendList contains a list of PingoraBackend.
+type PingoraBackendList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraBackend `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraBackend{}, &PingoraBackendList{})
+}
@@ -0,0 +1,240 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PingoraBackendPolicyKind is the kind of PingoraBackendPolicy.
+const PingoraBackendPolicyKind = "PingoraBackendPolicy"
+
+// CircuitBreaker defines when the proxy stops sending requests to an
+// endpoint of a backend that keeps failing, known as outlier detection.
+type CircuitBreaker struct {
+	// Consecutive5xx is the number of consecutive 5xx responses or
+	// connection errors after which an endpoint is ejected.
+	// +optional
+	// +kubebuilder:default=5
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	Consecutive5xx *int32 `json:"consecutive5xx,omitempty"`
+
+	// EjectionDuration is how long an ejected endpoint receives no requests.
+	// The endpoint is tried again afterwards, and ejected again on the next
+	// failures.
+	// +optional
+	// +kubebuilder:default="30s"
+	EjectionDuration *gatewayv1.Duration `json:"ejectionDuration,omitempty"`
+
+	// MaxEjectionPercent is the largest share of the backend's endpoints
+	// that may be ejected at the same time, so that a backend failing as a
+	// whole keeps receiving requests.
+	// +optional
+	// +kubebuilder:default=50
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxEjectionPercent *int32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// Defaults of CircuitBreaker fields, for objects created without defaulting.
+const (
+	DefaultConsecutive5xx     int32 = 5
+	DefaultEjectionDuration         = gatewayv1.Duration("30s")
+	DefaultMaxEjectionPercent int32 = 50
+)
+
+// GetConsecutive5xx returns the failure threshold, defaulting to
+// DefaultConsecutive5xx.
+func (c *CircuitBreaker) GetConsecutive5xx() int32 {
+	if c.Consecutive5xx == nil {
+		return DefaultConsecutive5xx
+	}
+
+	return *c.Consecutive5xx
+}
+
+// GetEjectionDuration returns the ejection duration, defaulting to
+// DefaultEjectionDuration.
+func (c *CircuitBreaker) GetEjectionDuration() gatewayv1.Duration {
+	if c.EjectionDuration == nil {
+		return DefaultEjectionDuration
+	}
+
+	return *c.EjectionDuration
+}
+
+// GetMaxEjectionPercent returns the ejection cap, defaulting to
+// DefaultMaxEjectionPercent.
+func (c *CircuitBreaker) GetMaxEjectionPercent() int32 {
+	if c.MaxEjectionPercent == nil {
+		return DefaultMaxEjectionPercent
+	}
+
+	return *c.MaxEjectionPercent
+}
+
+// HealthCheck defines how the proxy actively probes the endpoints of a
+// backend. Endpoints that fail are not sent requests until they pass again.
+type HealthCheck struct {
+	// Path is the HTTP path that probes request with GET.
+	// +optional
+	// +kubebuilder:default="/"
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+
+	// Interval is the time between probes of an endpoint.
+	// +optional
+	// +kubebuilder:default="10s"
+	Interval *gatewayv1.Duration `json:"interval,omitempty"`
+
+	// Timeout bounds the time a probe waits for a response.
+	// +optional
+	// +kubebuilder:default="1s"
+	Timeout *gatewayv1.Duration `json:"timeout,omitempty"`
+
+	// HealthyThreshold is the number of consecutive successful probes after
+	// which an unhealthy endpoint is sent requests again.
+	// +optional
+	// +kubebuilder:default=2
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	HealthyThreshold *int32 `json:"healthyThreshold,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed probes after
+	// which an endpoint is no longer sent requests.
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	UnhealthyThreshold *int32 `json:"unhealthyThreshold,omitempty"`
+
+	// ExpectedStatuses are the response statuses of successful probes. Any
+	// 2xx status succeeds if empty.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Minimum=100
+	// +kubebuilder:validation:items:Maximum=599
+	ExpectedStatuses []int32 `json:"expectedStatuses,omitempty"`
+}
+
+// Defaults of HealthCheck fields, for objects created without defaulting.
+const (
+	DefaultHealthCheckPath           = "/"
+	DefaultHealthCheckInterval       = gatewayv1.Duration("10s")
+	DefaultHealthCheckTimeout        = gatewayv1.Duration("1s")
+	DefaultHealthyThreshold    int32 = 2
+	DefaultUnhealthyThreshold  int32 = 3
+)
+
+// GetPath returns the probe path, defaulting to DefaultHealthCheckPath.
+func (h *HealthCheck) GetPath() string {
+	if h.Path == "" {
+		return DefaultHealthCheckPath
+	}
+
+	return h.Path
+}
+
+// GetInterval returns the probe interval, defaulting to
+// DefaultHealthCheckInterval.
+func (h *HealthCheck) GetInterval() gatewayv1.Duration {
+	if h.Interval == nil {
+		return DefaultHealthCheckInterval
+	}
+
+	return *h.Interval
+}
+
+// GetTimeout returns the probe timeout, defaulting to
+// DefaultHealthCheckTimeout.
+func (h *HealthCheck) GetTimeout() gatewayv1.Duration {
+	if h.Timeout == nil {
+		return DefaultHealthCheckTimeout
+	}
+
+	return *h.Timeout
+}
+
+// GetHealthyThreshold returns the healthy threshold, defaulting to
+// DefaultHealthyThreshold.
+func (h *HealthCheck) GetHealthyThreshold() int32 {
+	if h.HealthyThreshold == nil {
+		return DefaultHealthyThreshold
+	}
+
+	return *h.HealthyThreshold
+}
+
+// GetUnhealthyThreshold returns the unhealthy threshold, defaulting to
+// DefaultUnhealthyThreshold.
+func (h *HealthCheck) GetUnhealthyThreshold() int32 {
+	if h.UnhealthyThreshold == nil {
+		return DefaultUnhealthyThreshold
+	}
+
+	return *h.UnhealthyThreshold
+}
+
+// PingoraBackendPolicySpec defines how the proxy treats the endpoints of the
+// targeted Services.
+// +kubebuilder:validation:XValidation:rule="has(self.circuitBreaker) || has(self.healthCheck)",message="at least one of circuitBreaker and healthCheck must be set"
+type PingoraBackendPolicySpec struct {
+	// TargetRefs are the Services in the policy's namespace that the policy
+	// applies to, for every route that uses them as a backend.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.group == '' && ref.kind == 'Service')",message="targetRefs must reference Services"
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, !has(ref.sectionName))",message="sectionName is not supported"
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// CircuitBreaker ejects failing endpoints from load balancing.
+	// +optional
+	CircuitBreaker *CircuitBreaker `json:"circuitBreaker,omitempty"`
+
+	// HealthCheck probes endpoints and stops sending requests to those that
+	// fail.
+	// +optional
+	HealthCheck *HealthCheck `json:"healthCheck,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgbackend
+// +kubebuilder:metadata:labels="gateway.networking.k8s.io/policy=Direct"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraBackendPolicy is the Schema for the pingorabackendpolicies API.
+// It configures how the proxy balances requests across the endpoints of
+// Services with Gateway API policy attachment.
+type PingoraBackendPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec   PingoraBackendPolicySpec `json:"spec,omitempty"`   //nolint:modernize // kubebuilder standard
+	Status gatewayv1.PolicyStatus   `json:"status,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraBackendPolicyList contains a list of PingoraBackendPolicy.
+type PingoraBackendPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraBackendPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraBackendPolicy{}, &PingoraBackendPolicyList{})
+}
+
+// GetTargetRefs returns the resources the policy is attached to.
+func (p *PingoraBackendPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// GetPolicyStatus returns the policy status for in-place updates.
+func (p *PingoraBackendPolicy) GetPolicyStatus() *gatewayv1.PolicyStatus {
+	return &p.Status
+}
@@ -0,0 +1,142 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PingoraCachePolicyKind is the kind of PingoraCachePolicy.
+const PingoraCachePolicyKind = "PingoraCachePolicy"
+
+// CacheKey defines the parts of a request that identify a cached response in
+// addition to its method, host and path.
+// +kubebuilder:validation:XValidation:rule="!(has(self.ignoreQuery) && self.ignoreQuery && has(self.queryParameters))",message="queryParameters cannot be set when ignoreQuery is true"
+type CacheKey struct {
+	// IgnoreQuery leaves the query string out of the key, so requests that
+	// differ only in their query share a cached response.
+	// +optional
+	IgnoreQuery bool `json:"ignoreQuery,omitempty"`
+
+	// QueryParameters limits the query string in the key to the listed
+	// parameters. The whole query string is used if empty.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	QueryParameters []string `json:"queryParameters,omitempty"`
+
+	// Headers are request headers whose values are added to the key.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Headers []gatewayv1.HTTPHeaderName `json:"headers,omitempty"`
+
+	// Cookies are request cookies whose values are added to the key.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Cookies []string `json:"cookies,omitempty"`
+}
+
+// CacheBypassType is the part of a request that a bypass rule matches.
+// +kubebuilder:validation:Enum=Header;Cookie
+type CacheBypassType string
+
+const (
+	// CacheBypassTypeHeader matches a request header.
+	CacheBypassTypeHeader CacheBypassType = "Header"
+
+	// CacheBypassTypeCookie matches a request cookie.
+	CacheBypassTypeCookie CacheBypassType = "Cookie"
+)
+
+// CacheBypassRule selects requests that skip the cache.
+type CacheBypassRule struct {
+	// Type is the part of the request that is matched.
+	Type CacheBypassType `json:"type"`
+
+	// Name is the name of the header or cookie.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+
+	// Value is the exact value to match. Any request with the header or
+	// cookie matches if empty.
+	// +optional
+	// +kubebuilder:validation:MaxLength=4096
+	Value string `json:"value,omitempty"`
+}
+
+// PingoraCachePolicySpec defines how responses of the targets of the policy
+// are cached by the proxy.
+//
+// Only responses to GET and HEAD requests with a cacheable status are
+// cached. Responses with Cache-Control no-store or private are never cached.
+type PingoraCachePolicySpec struct {
+	// TargetRefs are the HTTPRoutes in the policy's namespace whose responses
+	// are cached. sectionName selects a single named rule.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.group == 'gateway.networking.k8s.io' && ref.kind == 'HTTPRoute')",message="targetRefs must reference HTTPRoutes"
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// TTL is how long a response is served from the cache. A shorter
+	// Cache-Control max-age or s-maxage of the response takes precedence.
+	TTL gatewayv1.Duration `json:"ttl"`
+
+	// Key defines the parts of a request that identify a cached response.
+	// By default the method, host, path and query string are used.
+	// +optional
+	Key *CacheKey `json:"key,omitempty"`
+
+	// VaryHeaders are request headers whose values select between variants
+	// of a cached response, in addition to those in the Vary header of the
+	// response.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	VaryHeaders []gatewayv1.HTTPHeaderName `json:"varyHeaders,omitempty"`
+
+	// Bypass lists rules for requests that are neither served from nor
+	// stored in the cache. A request matching any rule bypasses the cache.
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Bypass []CacheBypassRule `json:"bypass,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgcache
+// +kubebuilder:metadata:labels="gateway.networking.k8s.io/policy=Direct"
+// +kubebuilder:printcolumn:name="TTL",type=string,JSONPath=`.spec.ttl`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraCachePolicy is the Schema for the pingoracachepolicies API.
+// It enables response caching for HTTPRoutes with Gateway API policy
+// attachment.
+type PingoraCachePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec   PingoraCachePolicySpec `json:"spec,omitempty"`   //nolint:modernize // kubebuilder standard
+	Status gatewayv1.PolicyStatus `json:"status,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraCachePolicyList contains a list of PingoraCachePolicy.
+type PingoraCachePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraCachePolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraCachePolicy{}, &PingoraCachePolicyList{})
+}
+
+// GetTargetRefs returns the resources the policy is attached to.
+func (p *PingoraCachePolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// GetPolicyStatus returns the policy status for in-place updates.
+func (p *PingoraCachePolicy) GetPolicyStatus() *gatewayv1.PolicyStatus {
+	return &p.Status
+}
@@ -1,7 +1,9 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 // Default gRPC connection values.
@@ -14,6 +16,35 @@ const (
 	DefaultRetryBackoff   = 1000
 )
 
+// PingoraConfig condition types.
+const (
+	// ConditionTypeReady indicates whether the controller is connected to the proxy.
+	ConditionTypeReady = "Ready"
+	// ConditionTypeDegraded indicates that the last route sync failed and the
+	// proxy may be serving an outdated configuration.
+	ConditionTypeDegraded = "Degraded"
+)
+
+// PingoraConfig condition reasons.
+const (
+	// ReasonConnected is used when the controller is connected to the proxy.
+	ReasonConnected = "Connected"
+	// ReasonConnectionFailed is used when the proxy cannot be reached.
+	ReasonConnectionFailed = "ConnectionFailed"
+	// ReasonControllerStopped is used when the controller shut down and
+	// closed its connection to the proxy.
+	ReasonControllerStopped = "ControllerStopped"
+	// ReasonDryRun is used when the controller runs in dry-run mode and
+	// never sends routes to the proxy.
+	ReasonDryRun = "DryRun"
+	// ReasonConfigurationInvalid is used when the PingoraConfig fails validation.
+	ReasonConfigurationInvalid = "ConfigurationInvalid"
+	// ReasonSynced is used when the last route sync succeeded.
+	ReasonSynced = "Synced"
+	// ReasonSyncFailed is used when the last route sync failed.
+	ReasonSyncFailed = "SyncFailed"
+)
+
 // SecretReference contains the reference to a Secret.
 type SecretReference struct {
 	// Name is the name of the Secret.
@@ -49,6 +80,33 @@ type TLSConfig struct {
 	// ServerName overrides the server name used for TLS verification.
 	// +optional
 	ServerName string `json:"serverName,omitempty"`
+
+	// SPIFFE sources the client certificate and the trust bundle from a
+	// SPIFFE Workload API, such as a SPIRE agent, instead of a Secret.
+	// Mutually exclusive with SecretRef.
+	// +optional
+	SPIFFE *SPIFFEConfig `json:"spiffe,omitempty"`
+}
+
+// SPIFFEConfig configures the SPIFFE Workload API that provides the X.509
+// SVID of the controller and the trust bundle used to verify the proxy.
+// SVIDs are rotated by the Workload API without reconnecting.
+type SPIFFEConfig struct {
+	// SocketPath is the absolute path of the Workload API Unix socket in the
+	// controller container. The SPIFFE_ENDPOINT_SOCKET environment variable
+	// is used if empty.
+	// +optional
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:Pattern=`^/`
+	SocketPath string `json:"socketPath,omitempty"`
+
+	// ServerID is the SPIFFE ID the proxy must present, e.g.
+	// "spiffe://example.org/ns/pingora-system/sa/pingora-proxy". Any ID in
+	// the trust domain of the controller is accepted if empty.
+	// +optional
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^spiffe://`
+	ServerID string `json:"serverID,omitempty"`
 }
 
 // ConnectionConfig configures the gRPC connection parameters.
@@ -84,6 +142,203 @@ type ConnectionConfig struct {
 	RetryBackoffMs *int32 `json:"retryBackoffMs,omitempty"`
 }
 
+// AccessLogFormat is the format of the access log of the proxy.
+// +kubebuilder:validation:Enum=Text;JSON;Disabled
+type AccessLogFormat string
+
+// Access log formats.
+const (
+	AccessLogFormatText     AccessLogFormat = "Text"
+	AccessLogFormatJSON     AccessLogFormat = "JSON"
+	AccessLogFormatDisabled AccessLogFormat = "Disabled"
+)
+
+// AccessLogField is a field of JSON access log entries.
+// +kubebuilder:validation:Enum=timestamp;client_ip;method;host;path;query;protocol;status;duration_ms;bytes_received;bytes_sent;user_agent;referer;request_id;trace_id;route_id;backend
+type AccessLogField string
+
+// AccessLogConfig configures the access log of the proxy. The sampling of
+// single routes can be changed with PingoraAccessLogPolicy.
+type AccessLogConfig struct {
+	// Fields are the fields of JSON access log entries, in order. The proxy
+	// logs its default fields if empty.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=32
+	Fields []AccessLogField `json:"fields,omitempty"`
+
+	// SamplePercent is the percentage of requests logged. All requests are
+	// logged if unset.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplePercent *int32 `json:"samplePercent,omitempty"`
+
+	// IncludePaths are path prefixes of requests that are logged. Requests
+	// for all paths are logged if empty.
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^/`
+	// +kubebuilder:validation:items:MaxLength=1024
+	IncludePaths []string `json:"includePaths,omitempty"`
+
+	// ExcludePaths are path prefixes of requests that are never logged,
+	// such as health check endpoints. They take precedence over
+	// IncludePaths.
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^/`
+	// +kubebuilder:validation:items:MaxLength=1024
+	ExcludePaths []string `json:"excludePaths,omitempty"`
+}
+
+// ProxyDefaults configures proxy behavior shared by all listeners and
+// routes of the GatewayClass. Unset fields keep the proxy defaults.
+type ProxyDefaults struct {
+	// RequestTimeout is the timeout of requests matched by route rules
+	// without a timeout of their own, e.g. "30s".
+	// +optional
+	RequestTimeout *gatewayv1.Duration `json:"requestTimeout,omitempty"`
+
+	// MaxRequestBodySize is the largest request body accepted on listeners
+	// without a PingoraTrafficPolicy limit, e.g. "10Mi". Larger requests
+	// are answered with 413.
+	// +optional
+	MaxRequestBodySize *resource.Quantity `json:"maxRequestBodySize,omitempty"`
+
+	// AccessLogFormat is the format of the access log.
+	// +optional
+	AccessLogFormat AccessLogFormat `json:"accessLogFormat,omitempty"`
+
+	// AccessLog selects the requests written to the access log and the
+	// fields of JSON entries.
+	// +optional
+	AccessLog *AccessLogConfig `json:"accessLog,omitempty"`
+
+	// HTTP2 allows clients to use HTTP/2.
+	// +optional
+	HTTP2 *bool `json:"http2,omitempty"`
+
+	// HTTP3 configures HTTP/3 over QUIC on HTTPS listeners.
+	// +optional
+	HTTP3 *HTTP3Config `json:"http3,omitempty"`
+
+	// ListenerTLS configures how certificates are selected on HTTPS
+	// listeners.
+	// +optional
+	ListenerTLS *ListenerTLSConfig `json:"listenerTLS,omitempty"`
+
+	// TrustedProxies are the networks in CIDR notation of proxies in front
+	// of the gateway, such as load balancers. The client address is taken
+	// from X-Forwarded-For only for requests from these networks.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	TrustedProxies []string `json:"trustedProxies,omitempty"`
+
+	// ForwardedForDepth is the number of proxies in front of the gateway
+	// that append to X-Forwarded-For. The client address is the entry this
+	// many positions from the right. Unset skips trusted proxy addresses
+	// from the right instead.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	ForwardedForDepth *int32 `json:"forwardedForDepth,omitempty"`
+
+	// RealIPHeader is a header that trusted proxies set to the client
+	// address, such as X-Real-IP or CF-Connecting-IP. It is used instead of
+	// X-Forwarded-For.
+	// +optional
+	RealIPHeader *gatewayv1.HTTPHeaderName `json:"realIPHeader,omitempty"`
+}
+
+// HTTP3Config configures HTTP/3 over QUIC on the HTTPS listeners of the
+// GatewayClass. Clients discover it from the Alt-Svc header of HTTP/1.1 and
+// HTTP/2 responses, so the UDP port must be reachable as well.
+type HTTP3Config struct {
+	// Enabled accepts HTTP/3 on the UDP port of every HTTPS listener, next
+	// to its TCP port.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+
+	// AdvertisedPort is the UDP port announced in the Alt-Svc header, for
+	// load balancers that expose HTTP/3 on another port than the listener.
+	// Defaults to the listener port.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	AdvertisedPort *int32 `json:"advertisedPort,omitempty"`
+
+	// AltSvcMaxAge is how long clients may remember the Alt-Svc
+	// announcement, e.g. "24h". Defaults to the proxy default.
+	// +optional
+	AltSvcMaxAge *gatewayv1.Duration `json:"altSvcMaxAge,omitempty"`
+}
+
+// CertificateSelection is the order in which the certificates of the HTTPS
+// listeners on a port are matched against the server name of a client.
+// +kubebuilder:validation:Enum=MostSpecific;ListenerOrder
+type CertificateSelection string
+
+// Certificate selection orders.
+const (
+	// CertificateSelectionMostSpecific prefers certificates for the exact
+	// server name over wildcard certificates, and longer wildcards over
+	// shorter ones.
+	CertificateSelectionMostSpecific CertificateSelection = "MostSpecific"
+
+	// CertificateSelectionListenerOrder uses the certificate of the first
+	// matching listener, with Gateways ordered by creation time.
+	CertificateSelectionListenerOrder CertificateSelection = "ListenerOrder"
+)
+
+// ListenerTLSConfig configures the certificates of the HTTPS listeners of
+// the GatewayClass, which the proxy selects by the server name (SNI) sent
+// by clients.
+type ListenerTLSConfig struct {
+	// FallbackCertificateRef references a Secret of type kubernetes.io/tls
+	// whose certificate is presented to clients that send no server name,
+	// or one that no listener certificate on the port matches. Their
+	// handshakes fail without it. The Secret is looked up in the namespace
+	// of the controller if the namespace is empty.
+	// +optional
+	FallbackCertificateRef *SecretReference `json:"fallbackCertificateRef,omitempty"`
+
+	// CertificateSelection is the order in which listener certificates are
+	// matched against the server name. Defaults to MostSpecific.
+	// +optional
+	CertificateSelection CertificateSelection `json:"certificateSelection,omitempty"`
+}
+
+// ProxyDiscovery selects the proxy instances that routes are synced to.
+// The instances are the addresses the host of Address resolves to, if
+// ResolveAddress is set, or Address itself, followed by Addresses.
+type ProxyDiscovery struct {
+	// ResolveAddress resolves the host of Address, typically a headless
+	// Service, to the IP addresses of all proxy pods. It is resolved again
+	// periodically, so scaled proxies are configured without a route change.
+	// +optional
+	ResolveAddress bool `json:"resolveAddress,omitempty"`
+
+	// Addresses are further proxy instances in "host:port" format.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=64
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// ProxyReference selects the pods of the proxy.
+type ProxyReference struct {
+	// Namespace of the proxy pods.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+
+	// Selector matches the labels of the proxy pods. It must not be empty.
+	// +kubebuilder:validation:Required
+	Selector metav1.LabelSelector `json:"selector"`
+}
+
 // PingoraConfigSpec defines the desired state of PingoraConfig.
 type PingoraConfigSpec struct {
 	// Address is the gRPC endpoint address of the Pingora proxy.
@@ -92,6 +347,17 @@ type PingoraConfigSpec struct {
 	// +kubebuilder:validation:MinLength=1
 	Address string `json:"address"`
 
+	// Discovery syncs routes to every instance of a proxy running with
+	// multiple replicas, instead of only the one Address connects to.
+	// +optional
+	Discovery *ProxyDiscovery `json:"discovery,omitempty"`
+
+	// ProxyRef selects the proxy pods. When one of them becomes ready, for
+	// example after a restart, the full route config is pushed right away
+	// instead of on the next route change or version check.
+	// +optional
+	ProxyRef *ProxyReference `json:"proxyRef,omitempty"`
+
 	// TLS configures TLS for the gRPC connection.
 	// +optional
 	TLS *TLSConfig `json:"tls,omitempty"`
@@ -99,6 +365,17 @@ type PingoraConfigSpec struct {
 	// Connection configures the gRPC connection parameters.
 	// +optional
 	Connection *ConnectionConfig `json:"connection,omitempty"`
+
+	// ClusterDomain overrides the cluster domain of the controller for the
+	// backend addresses of this GatewayClass, e.g. "cluster.local".
+	// +optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// Defaults configures gateway-wide proxy behavior.
+	// +optional
+	Defaults *ProxyDefaults `json:"defaults,omitempty"`
 }
 
 // PingoraConfigStatus defines the observed state of PingoraConfig.
@@ -120,6 +397,27 @@ type PingoraConfigStatus struct {
 	// ConfigVersion is the current configuration version applied to the proxy.
 	// +optional
 	ConfigVersion uint64 `json:"configVersion,omitempty"`
+
+	// Proxies reports every proxy instance found by spec.discovery.
+	// +optional
+	// +listType=map
+	// +listMapKey=address
+	Proxies []ProxyInstanceStatus `json:"proxies,omitempty"`
+}
+
+// ProxyInstanceStatus is the sync state of one proxy instance.
+type ProxyInstanceStatus struct {
+	// Address is the gRPC endpoint address of the instance.
+	Address string `json:"address"`
+
+	// ConfigVersion is the configuration version the instance applied last.
+	// +optional
+	ConfigVersion uint64 `json:"configVersion,omitempty"`
+
+	// Error describes why the last update of the instance failed. It is
+	// empty if the instance applied the last update.
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,254 @@
+package v1alpha1
+
+import (
+	"net"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// Minimum connection values, matching the CRD schema.
+const (
+	MinConnectTimeout = 1
+	MinRequestTimeout = 1
+	MinKeepaliveTime  = 10
+	MinMaxRetries     = 0
+	MinRetryBackoff   = 100
+)
+
+// Default sets defaults on the PingoraConfig spec. See PingoraConfigSpec.Default.
+func (c *PingoraConfig) Default() {
+	c.Spec.Default()
+}
+
+// Validate validates the PingoraConfig spec. See PingoraConfigSpec.Validate.
+func (c *PingoraConfig) Validate() field.ErrorList {
+	return c.Spec.Validate(field.NewPath("spec"))
+}
+
+// Default fills unset connection parameters with their default values.
+// It is idempotent and never overrides values that are already set.
+func (c *PingoraConfigSpec) Default() {
+	if c.Connection == nil {
+		c.Connection = &ConnectionConfig{}
+	}
+
+	conn := c.Connection
+	conn.ConnectTimeoutSeconds = defaultInt32(conn.ConnectTimeoutSeconds, DefaultConnectTimeout)
+	conn.RequestTimeoutSeconds = defaultInt32(conn.RequestTimeoutSeconds, DefaultRequestTimeout)
+	conn.KeepaliveTimeSeconds = defaultInt32(conn.KeepaliveTimeSeconds, DefaultKeepaliveTime)
+	conn.MaxRetries = defaultInt32(conn.MaxRetries, DefaultMaxRetries)
+	conn.RetryBackoffMs = defaultInt32(conn.RetryBackoffMs, DefaultRetryBackoff)
+}
+
+// Validate checks the spec and returns all problems found.
+// Unset connection parameters are validated with their default values,
+// so the result is the same before and after Default.
+func (c *PingoraConfigSpec) Validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	errs = append(errs, validateAddress(c.Address, path.Child("address"))...)
+
+	if c.Discovery != nil {
+		for i, address := range c.Discovery.Addresses {
+			errs = append(errs, validateAddress(address, path.Child("discovery", "addresses").Index(i))...)
+		}
+	}
+
+	if c.ProxyRef != nil {
+		errs = append(errs, c.ProxyRef.validate(path.Child("proxyRef"))...)
+	}
+
+	if c.TLS != nil {
+		errs = append(errs, c.TLS.validate(path.Child("tls"))...)
+	}
+
+	errs = append(errs, c.validateConnection(path.Child("connection"))...)
+
+	if c.ClusterDomain != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(c.ClusterDomain) {
+			errs = append(errs, field.Invalid(path.Child("clusterDomain"), c.ClusterDomain, msg))
+		}
+	}
+
+	if c.Defaults != nil {
+		errs = append(errs, c.Defaults.validate(path.Child("defaults"))...)
+	}
+
+	return errs
+}
+
+func (d *ProxyDefaults) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if d.RequestTimeout != nil {
+		timeout, err := time.ParseDuration(string(*d.RequestTimeout))
+		if err != nil || timeout <= 0 {
+			errs = append(errs, field.Invalid(path.Child("requestTimeout"), *d.RequestTimeout,
+				"must be a positive duration"))
+		}
+	}
+
+	if d.MaxRequestBodySize != nil && d.MaxRequestBodySize.Sign() <= 0 {
+		errs = append(errs, field.Invalid(path.Child("maxRequestBodySize"), d.MaxRequestBodySize.String(),
+			"must be positive"))
+	}
+
+	for i, cidr := range d.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, field.Invalid(path.Child("trustedProxies").Index(i), cidr,
+				"must be a network in CIDR notation"))
+		}
+	}
+
+	if d.HTTP3 != nil && d.HTTP3.AltSvcMaxAge != nil {
+		maxAge, err := time.ParseDuration(string(*d.HTTP3.AltSvcMaxAge))
+		if err != nil || maxAge < time.Second {
+			errs = append(errs, field.Invalid(path.Child("http3", "altSvcMaxAge"), *d.HTTP3.AltSvcMaxAge,
+				"must be a duration of at least 1s"))
+		}
+	}
+
+	return errs
+}
+
+func (r *ProxyReference) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	for _, msg := range validation.IsDNS1123Label(r.Namespace) {
+		errs = append(errs, field.Invalid(path.Child("namespace"), r.Namespace, msg))
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&r.Selector)
+
+	switch {
+	case err != nil:
+		errs = append(errs, field.Invalid(path.Child("selector"), metav1.FormatLabelSelector(&r.Selector), err.Error()))
+	case selector.Empty():
+		errs = append(errs, field.Required(path.Child("selector"), "must select the proxy pods"))
+	}
+
+	return errs
+}
+
+func (c *TLSConfig) validate(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if c.SecretRef != nil && c.SecretRef.Name == "" {
+		errs = append(errs, field.Required(path.Child("secretRef", "name"), "secret name must not be empty"))
+	}
+
+	if c.SPIFFE != nil {
+		errs = append(errs, c.validateSPIFFE(path)...)
+	}
+
+	return errs
+}
+
+func (c *TLSConfig) validateSPIFFE(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	spiffePath := path.Child("spiffe")
+
+	if !c.Enabled {
+		errs = append(errs, field.Invalid(path.Child("enabled"), c.Enabled, "must be true when spiffe is set"))
+	}
+
+	if c.SecretRef != nil {
+		errs = append(errs, field.Forbidden(path.Child("secretRef"), "must not be set together with spiffe"))
+	}
+
+	if c.InsecureSkipVerify {
+		errs = append(errs, field.Forbidden(path.Child("insecureSkipVerify"),
+			"must not be set together with spiffe"))
+	}
+
+	if c.SPIFFE.SocketPath != "" && !filepath.IsAbs(c.SPIFFE.SocketPath) {
+		errs = append(errs, field.Invalid(spiffePath.Child("socketPath"), c.SPIFFE.SocketPath,
+			"must be an absolute path"))
+	}
+
+	if c.SPIFFE.ServerID != "" {
+		if _, err := spiffeid.FromString(c.SPIFFE.ServerID); err != nil {
+			errs = append(errs, field.Invalid(spiffePath.Child("serverID"), c.SPIFFE.ServerID, err.Error()))
+		}
+	}
+
+	return errs
+}
+
+func (c *PingoraConfigSpec) validateConnection(path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	minimums := []struct {
+		name    string
+		value   int32
+		minimum int32
+	}{
+		{name: "connectTimeoutSeconds", value: c.GetConnectTimeout(), minimum: MinConnectTimeout},
+		{name: "requestTimeoutSeconds", value: c.GetRequestTimeout(), minimum: MinRequestTimeout},
+		{name: "keepaliveTimeSeconds", value: c.GetKeepaliveTime(), minimum: MinKeepaliveTime},
+		{name: "maxRetries", value: c.GetMaxRetries(), minimum: MinMaxRetries},
+		{name: "retryBackoffMs", value: c.GetRetryBackoff(), minimum: MinRetryBackoff},
+	}
+
+	for _, m := range minimums {
+		if m.value < m.minimum {
+			errs = append(errs, field.Invalid(path.Child(m.name), m.value,
+				"must be at least "+strconv.Itoa(int(m.minimum))))
+		}
+	}
+
+	if c.GetConnectTimeout() > c.GetRequestTimeout() {
+		errs = append(errs, field.Invalid(path.Child("connectTimeoutSeconds"), c.GetConnectTimeout(),
+			"must not exceed requestTimeoutSeconds"))
+	}
+
+	// Retries that cannot complete within the request timeout are never attempted
+	totalBackoffMs := int64(c.GetMaxRetries()) * int64(c.GetRetryBackoff())
+	if totalBackoffMs >= int64(c.GetRequestTimeout())*1000 {
+		errs = append(errs, field.Invalid(path.Child("maxRetries"), c.GetMaxRetries(),
+			"maxRetries * retryBackoffMs ("+strconv.FormatInt(totalBackoffMs, 10)+
+				"ms) must be less than requestTimeoutSeconds"))
+	}
+
+	return errs
+}
+
+// validateAddress checks that address has the "host:port" form with a valid port.
+func validateAddress(address string, path *field.Path) field.ErrorList {
+	if address == "" {
+		return field.ErrorList{field.Required(path, "address is required")}
+	}
+
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, address, "must be in host:port format")}
+	}
+
+	var errs field.ErrorList
+
+	if host == "" {
+		errs = append(errs, field.Invalid(path, address, "host must not be empty"))
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		errs = append(errs, field.Invalid(path, address, "port must be a number between 1 and 65535"))
+	}
+
+	return errs
+}
+
+func defaultInt32(value *int32, def int32) *int32 {
+	if value != nil {
+		return value
+	}
+
+	return &def
+}
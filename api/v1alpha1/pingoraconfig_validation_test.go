@@ -0,0 +1,301 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func durationPtr(d gatewayv1.Duration) *gatewayv1.Duration {
+	return &d
+}
+
+func quantityPtr(value string) *resource.Quantity {
+	quantity := resource.MustParse(value)
+
+	return &quantity
+}
+
+func TestPingoraConfigSpec_Default(t *testing.T) {
+	t.Parallel()
+
+	spec := v1alpha1.PingoraConfigSpec{
+		Address:    "pingora-proxy:50051",
+		Connection: &v1alpha1.ConnectionConfig{MaxRetries: int32Ptr(0)},
+	}
+
+	spec.Default()
+
+	require.NotNil(t, spec.Connection)
+	assert.Equal(t, int32Ptr(v1alpha1.DefaultConnectTimeout), spec.Connection.ConnectTimeoutSeconds)
+	assert.Equal(t, int32Ptr(v1alpha1.DefaultRequestTimeout), spec.Connection.RequestTimeoutSeconds)
+	assert.Equal(t, int32Ptr(v1alpha1.DefaultKeepaliveTime), spec.Connection.KeepaliveTimeSeconds)
+	assert.Equal(t, int32Ptr(v1alpha1.DefaultRetryBackoff), spec.Connection.RetryBackoffMs)
+	assert.Equal(t, int32Ptr(0), spec.Connection.MaxRetries, "explicit values must be kept")
+
+	// Defaulting is idempotent
+	defaulted := spec.DeepCopy()
+	spec.Default()
+	assert.Equal(t, defaulted, &spec)
+
+	// Getters report the same values before and after defaulting
+	empty := v1alpha1.PingoraConfigSpec{}
+	assert.Equal(t, empty.GetRequestTimeout(), *spec.Connection.RequestTimeoutSeconds)
+}
+
+func TestPingoraConfigSpec_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		spec           v1alpha1.PingoraConfigSpec
+		expectedFields []string
+	}{
+		{
+			name: "valid minimal spec",
+			spec: v1alpha1.PingoraConfigSpec{Address: "pingora-proxy.pingora-system.svc.cluster.local:50051"},
+		},
+		{
+			name: "valid IPv6 address",
+			spec: v1alpha1.PingoraConfigSpec{Address: "[fd00::1]:50051"},
+		},
+		{
+			name: "valid cluster domain",
+			spec: v1alpha1.PingoraConfigSpec{Address: "pingora-proxy:50051", ClusterDomain: "cluster-b.local"},
+		},
+		{
+			name:           "invalid cluster domain",
+			spec:           v1alpha1.PingoraConfigSpec{Address: "pingora-proxy:50051", ClusterDomain: "Cluster_B."},
+			expectedFields: []string{"spec.clusterDomain"},
+		},
+		{
+			name: "valid defaults",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				Defaults: &v1alpha1.ProxyDefaults{
+					RequestTimeout:     durationPtr("1m30s"),
+					MaxRequestBodySize: quantityPtr("10Mi"),
+					AccessLogFormat:    v1alpha1.AccessLogFormatJSON,
+					TrustedProxies:     []string{"10.0.0.0/8", "2001:db8::/32"},
+					HTTP3:              &v1alpha1.HTTP3Config{Enabled: true, AltSvcMaxAge: durationPtr("24h")},
+				},
+			},
+		},
+		{
+			name: "invalid defaults",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				Defaults: &v1alpha1.ProxyDefaults{
+					RequestTimeout:     durationPtr("0s"),
+					MaxRequestBodySize: quantityPtr("0"),
+					TrustedProxies:     []string{"10.0.0.0/8", "10.0.0.1"},
+					HTTP3:              &v1alpha1.HTTP3Config{Enabled: true, AltSvcMaxAge: durationPtr("500ms")},
+				},
+			},
+			expectedFields: []string{
+				"spec.defaults.requestTimeout",
+				"spec.defaults.maxRequestBodySize",
+				"spec.defaults.trustedProxies[1]",
+				"spec.defaults.http3.altSvcMaxAge",
+			},
+		},
+		{
+			name: "valid discovery",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy-headless:50051",
+				Discovery: &v1alpha1.ProxyDiscovery{
+					ResolveAddress: true,
+					Addresses:      []string{"10.0.0.5:50051"},
+				},
+			},
+		},
+		{
+			name: "invalid discovery address",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address:   "pingora-proxy:50051",
+				Discovery: &v1alpha1.ProxyDiscovery{Addresses: []string{"10.0.0.5:50051", "10.0.0.6"}},
+			},
+			expectedFields: []string{"spec.discovery.addresses[1]"},
+		},
+		{
+			name: "valid proxy reference",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				ProxyRef: &v1alpha1.ProxyReference{
+					Namespace: "pingora-system",
+					Selector: metav1.LabelSelector{
+						MatchLabels: map[string]string{"app.kubernetes.io/component": "proxy"},
+					},
+				},
+			},
+		},
+		{
+			name: "invalid proxy reference",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address:  "pingora-proxy:50051",
+				ProxyRef: &v1alpha1.ProxyReference{Namespace: "Pingora_System"},
+			},
+			expectedFields: []string{"spec.proxyRef.namespace", "spec.proxyRef.selector"},
+		},
+		{
+			name: "invalid proxy selector",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				ProxyRef: &v1alpha1.ProxyReference{
+					Namespace: "pingora-system",
+					Selector: metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "app", Operator: metav1.LabelSelectorOpIn},
+						},
+					},
+				},
+			},
+			expectedFields: []string{"spec.proxyRef.selector"},
+		},
+		{
+			name:           "missing address",
+			spec:           v1alpha1.PingoraConfigSpec{},
+			expectedFields: []string{"spec.address"},
+		},
+		{
+			name:           "address without port",
+			spec:           v1alpha1.PingoraConfigSpec{Address: "pingora-proxy"},
+			expectedFields: []string{"spec.address"},
+		},
+		{
+			name:           "address with invalid port",
+			spec:           v1alpha1.PingoraConfigSpec{Address: "pingora-proxy:70000"},
+			expectedFields: []string{"spec.address"},
+		},
+		{
+			name:           "address without host",
+			spec:           v1alpha1.PingoraConfigSpec{Address: ":50051"},
+			expectedFields: []string{"spec.address"},
+		},
+		{
+			name: "empty TLS secret name",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				TLS: &v1alpha1.TLSConfig{
+					Enabled:   true,
+					SecretRef: &v1alpha1.SecretReference{},
+				},
+			},
+			expectedFields: []string{"spec.tls.secretRef.name"},
+		},
+		{
+			name: "valid SPIFFE",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				TLS: &v1alpha1.TLSConfig{
+					Enabled: true,
+					SPIFFE: &v1alpha1.SPIFFEConfig{
+						SocketPath: "/run/spire/sockets/agent.sock",
+						ServerID:   "spiffe://example.org/ns/pingora-system/sa/pingora-proxy",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid SPIFFE",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				TLS: &v1alpha1.TLSConfig{
+					SecretRef:          &v1alpha1.SecretReference{Name: "pingora-tls"},
+					InsecureSkipVerify: true,
+					SPIFFE: &v1alpha1.SPIFFEConfig{
+						SocketPath: "agent.sock",
+						ServerID:   "spiffe://example.org/../proxy",
+					},
+				},
+			},
+			expectedFields: []string{
+				"spec.tls.enabled",
+				"spec.tls.secretRef",
+				"spec.tls.insecureSkipVerify",
+				"spec.tls.spiffe.socketPath",
+				"spec.tls.spiffe.serverID",
+			},
+		},
+		{
+			name: "connection values below minimum",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				Connection: &v1alpha1.ConnectionConfig{
+					KeepaliveTimeSeconds: int32Ptr(5),
+					MaxRetries:           int32Ptr(-1),
+					RetryBackoffMs:       int32Ptr(10),
+				},
+			},
+			expectedFields: []string{
+				"spec.connection.keepaliveTimeSeconds",
+				"spec.connection.maxRetries",
+				"spec.connection.retryBackoffMs",
+			},
+		},
+		{
+			name: "connect timeout exceeds request timeout",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				Connection: &v1alpha1.ConnectionConfig{
+					ConnectTimeoutSeconds: int32Ptr(60),
+					RequestTimeoutSeconds: int32Ptr(10),
+				},
+			},
+			expectedFields: []string{"spec.connection.connectTimeoutSeconds"},
+		},
+		{
+			name: "retries exceed request timeout",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				Connection: &v1alpha1.ConnectionConfig{
+					RequestTimeoutSeconds: int32Ptr(5),
+					MaxRetries:            int32Ptr(5),
+					RetryBackoffMs:        int32Ptr(1000),
+				},
+			},
+			expectedFields: []string{"spec.connection.maxRetries"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := tt.spec.Validate(field.NewPath("spec"))
+
+			fields := make([]string, 0, len(errs))
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+
+			assert.ElementsMatch(t, tt.expectedFields, fields)
+
+			// Defaulting must not change the validation result
+			defaulted := tt.spec.DeepCopy()
+			defaulted.Default()
+			assert.Len(t, defaulted.Validate(field.NewPath("spec")), len(errs))
+		})
+	}
+}
+
+func TestPingoraConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	config := &v1alpha1.PingoraConfig{}
+
+	errs := config.Validate()
+	require.Len(t, errs, 1)
+	assert.Equal(t, "spec.address", errs[0].Field)
+}
@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PingoraCORSPolicyKind is the kind referenced by HTTPRoute ExtensionRef filters.
+const PingoraCORSPolicyKind = "PingoraCORSPolicy"
+
+// DefaultCORSMaxAge is the default time in seconds that browsers may cache
+// the result of a preflight request.
+const DefaultCORSMaxAge = 5
+
+// PingoraCORSPolicySpec defines the CORS behavior of the HTTPRoute rules
+// referencing the policy.
+type PingoraCORSPolicySpec struct {
+	// AllowOrigins lists the origins allowed to make cross-origin requests.
+	// An origin is "scheme://host[:port]", the host may start with a "*."
+	// wildcard label. A single "*" allows any origin.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MaxLength=253
+	// +kubebuilder:validation:items:Pattern=`^(\*|https?://(\*\.)?[a-zA-Z0-9.-]+(:[0-9]{1,5})?)$`
+	AllowOrigins []string `json:"allowOrigins"`
+
+	// AllowMethods lists the methods allowed in cross-origin requests.
+	// A single "*" allows any method. GET, HEAD and POST are always allowed.
+	// +optional
+	// +kubebuilder:validation:MaxItems=9
+	// +kubebuilder:validation:items:Pattern=`^(\*|[A-Z]+)$`
+	AllowMethods []string `json:"allowMethods,omitempty"`
+
+	// AllowHeaders lists the request headers allowed in cross-origin requests.
+	// A single "*" allows any header.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+
+	// ExposeHeaders lists the response headers exposed to client scripts.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=256
+	ExposeHeaders []string `json:"exposeHeaders,omitempty"`
+
+	// AllowCredentials allows cross-origin requests to include credentials
+	// such as cookies. Wildcards are answered with the request's origin,
+	// method and headers when credentials are allowed, as browsers reject
+	// wildcard responses to credentialed requests.
+	// +optional
+	// +kubebuilder:default=false
+	AllowCredentials bool `json:"allowCredentials,omitempty"`
+
+	// MaxAgeSeconds is how long browsers may cache a preflight response.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=5
+	MaxAgeSeconds *int32 `json:"maxAgeSeconds,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=pgcors
+// +kubebuilder:printcolumn:name="Origins",type=string,JSONPath=`.spec.allowOrigins`
+// +kubebuilder:printcolumn:name="Credentials",type=boolean,JSONPath=`.spec.allowCredentials`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraCORSPolicy is the Schema for the pingoracorspolicies API.
+// HTTPRoute rules attach it with an ExtensionRef filter in the same namespace.
+type PingoraCORSPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec PingoraCORSPolicySpec `json:"spec,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraCORSPolicyList contains a list of PingoraCORSPolicy.
+type PingoraCORSPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraCORSPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraCORSPolicy{}, &PingoraCORSPolicyList{})
+}
+
+// GetMaxAgeSeconds returns the preflight cache duration, defaulting to DefaultCORSMaxAge.
+func (s *PingoraCORSPolicySpec) GetMaxAgeSeconds() int32 {
+	if s.MaxAgeSeconds == nil {
+		return DefaultCORSMaxAge
+	}
+
+	return *s.MaxAgeSeconds
+}
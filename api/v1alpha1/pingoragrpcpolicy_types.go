@@ -0,0 +1,85 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PingoraGRPCPolicyKind is the kind of PingoraGRPCPolicy.
+const PingoraGRPCPolicyKind = "PingoraGRPCPolicy"
+
+// GRPCWeb defines how the proxy bridges gRPC-Web requests of browsers to the
+// gRPC backends of a route.
+type GRPCWeb struct {
+	// AllowText also accepts the base64 encoded application/grpc-web-text
+	// content type, which clients without binary streaming support use.
+	// +optional
+	// +kubebuilder:default=true
+	AllowText *bool `json:"allowText,omitempty"`
+}
+
+// GetAllowText returns whether text encoded requests are accepted,
+// defaulting to true.
+func (g *GRPCWeb) GetAllowText() bool {
+	if g.AllowText == nil {
+		return true
+	}
+
+	return *g.AllowText
+}
+
+// PingoraGRPCPolicySpec defines proxy options of the GRPCRoutes targeted by
+// the policy.
+type PingoraGRPCPolicySpec struct {
+	// TargetRefs are the GRPCRoutes in the policy's namespace that the
+	// options apply to. sectionName selects a single named rule.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.group == 'gateway.networking.k8s.io' && ref.kind == 'GRPCRoute')",message="targetRefs must reference GRPCRoutes"
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// GRPCWeb enables gRPC-Web, so that browser clients can call the gRPC
+	// services of the targets.
+	GRPCWeb GRPCWeb `json:"grpcWeb"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pggrpc
+// +kubebuilder:metadata:labels="gateway.networking.k8s.io/policy=Direct"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraGRPCPolicy is the Schema for the pingoragrpcpolicies API.
+// It configures proxy options of GRPCRoutes, such as gRPC-Web bridging,
+// with Gateway API policy attachment.
+type PingoraGRPCPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec   PingoraGRPCPolicySpec  `json:"spec,omitempty"`   //nolint:modernize // kubebuilder standard
+	Status gatewayv1.PolicyStatus `json:"status,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraGRPCPolicyList contains a list of PingoraGRPCPolicy.
+type PingoraGRPCPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraGRPCPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraGRPCPolicy{}, &PingoraGRPCPolicyList{})
+}
+
+// GetTargetRefs returns the resources the policy is attached to.
+func (p *PingoraGRPCPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// GetPolicyStatus returns the policy status for in-place updates.
+func (p *PingoraGRPCPolicy) GetPolicyStatus() *gatewayv1.PolicyStatus {
+	return &p.Status
+}
@@ -0,0 +1,135 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PingoraRateLimitPolicyKind is the kind of PingoraRateLimitPolicy.
+const PingoraRateLimitPolicyKind = "PingoraRateLimitPolicy"
+
+// RateLimitUnit is the period that a rate limit's requests are counted over.
+// +kubebuilder:validation:Enum=Second;Minute
+type RateLimitUnit string
+
+const (
+	// RateLimitUnitSecond counts requests per second.
+	RateLimitUnitSecond RateLimitUnit = "Second"
+
+	// RateLimitUnitMinute counts requests per minute.
+	RateLimitUnitMinute RateLimitUnit = "Minute"
+)
+
+// RateLimitKeyType selects what requests are grouped by when counted.
+// +kubebuilder:validation:Enum=ClientIP;Header
+type RateLimitKeyType string
+
+const (
+	// RateLimitKeyClientIP counts requests per client IP address.
+	RateLimitKeyClientIP RateLimitKeyType = "ClientIP"
+
+	// RateLimitKeyHeader counts requests per value of a request header.
+	RateLimitKeyHeader RateLimitKeyType = "Header"
+)
+
+// RateLimitKey defines what requests are grouped by when counted.
+// +kubebuilder:validation:XValidation:rule="self.type == 'Header' ? has(self.header) : !has(self.header)",message="header must be set if and only if type is Header"
+type RateLimitKey struct {
+	// Type selects the request attribute used as the key.
+	// +kubebuilder:default=ClientIP
+	Type RateLimitKeyType `json:"type"`
+
+	// Header is the name of the request header used as the key when Type is
+	// Header. Requests without the header share a single budget.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9!#$%&'*+\-.^_\x60|~]+$`
+	Header string `json:"header,omitempty"`
+}
+
+// PingoraRateLimitPolicySpec defines the rate limit applied to the targets of
+// the policy.
+type PingoraRateLimitPolicySpec struct {
+	// TargetRefs are the HTTPRoutes and Gateways in the policy's namespace
+	// that the rate limit applies to. An HTTPRoute target may select a single
+	// named rule with sectionName. A Gateway target applies to every HTTPRoute
+	// attached to the Gateway.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.group == 'gateway.networking.k8s.io' && (ref.kind == 'HTTPRoute' || ref.kind == 'Gateway'))",message="targetRefs must reference HTTPRoutes or Gateways"
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.kind != 'Gateway' || !has(ref.sectionName))",message="sectionName is not supported for Gateway targets"
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// Requests is the number of requests allowed per Unit.
+	// +kubebuilder:validation:Minimum=1
+	Requests int32 `json:"requests"`
+
+	// Unit is the period that Requests are counted over.
+	// +optional
+	// +kubebuilder:default=Second
+	Unit RateLimitUnit `json:"unit,omitempty"`
+
+	// Burst is the number of requests allowed above the rate in a short
+	// burst.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Burst int32 `json:"burst,omitempty"`
+
+	// Key defines what requests are grouped by when counted.
+	// Defaults to the client IP address.
+	// +optional
+	Key *RateLimitKey `json:"key,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgratelimit
+// +kubebuilder:metadata:labels="gateway.networking.k8s.io/policy=Direct"
+// +kubebuilder:printcolumn:name="Requests",type=integer,JSONPath=`.spec.requests`
+// +kubebuilder:printcolumn:name="Unit",type=string,JSONPath=`.spec.unit`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraRateLimitPolicy is the Schema for the pingoraratelimitpolicies API.
+// It attaches a rate limit to HTTPRoutes and Gateways with Gateway API policy
+// attachment.
+type PingoraRateLimitPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec   PingoraRateLimitPolicySpec `json:"spec,omitempty"`   //nolint:modernize // kubebuilder standard
+	Status gatewayv1.PolicyStatus     `json:"status,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraRateLimitPolicyList contains a list of PingoraRateLimitPolicy.
+type PingoraRateLimitPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraRateLimitPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraRateLimitPolicy{}, &PingoraRateLimitPolicyList{})
+}
+
+// GetUnit returns the rate limit unit, defaulting to RateLimitUnitSecond.
+func (s *PingoraRateLimitPolicySpec) GetUnit() RateLimitUnit {
+	if s.Unit == "" {
+		return RateLimitUnitSecond
+	}
+
+	return s.Unit
+}
+
+// GetTargetRefs returns the resources the policy is attached to.
+func (p *PingoraRateLimitPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// GetPolicyStatus returns the policy status for in-place updates.
+func (p *PingoraRateLimitPolicy) GetPolicyStatus() *gatewayv1.PolicyStatus {
+	return &p.Status
+}
@@ -0,0 +1,159 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// PingoraTrafficPolicyKind is the kind of PingoraTrafficPolicy.
+const PingoraTrafficPolicyKind = "PingoraTrafficPolicy"
+
+// ProxyProtocolVersion is a version of the HAProxy PROXY protocol.
+// +kubebuilder:validation:Enum=V1;V2;Any
+type ProxyProtocolVersion string
+
+// PROXY protocol versions.
+const (
+	ProxyProtocolVersionV1  ProxyProtocolVersion = "V1"
+	ProxyProtocolVersionV2  ProxyProtocolVersion = "V2"
+	ProxyProtocolVersionAny ProxyProtocolVersion = "Any"
+)
+
+// ProxyProtocolConfig configures the HAProxy PROXY protocol on a listener.
+type ProxyProtocolConfig struct {
+	// Enabled makes the listener expect a PROXY protocol header at the start
+	// of every connection. Connections without one are closed.
+	Enabled bool `json:"enabled"`
+
+	// Version is the PROXY protocol version accepted. Any accepts both.
+	// +optional
+	// +kubebuilder:default=Any
+	Version ProxyProtocolVersion `json:"version,omitempty"`
+}
+
+// ClientIPConfig configures how a listener finds the client address of
+// requests that passed proxies in front of the gateway, such as load
+// balancers or CDNs.
+type ClientIPConfig struct {
+	// TrustedProxies are the networks in CIDR notation or IP addresses of
+	// the proxies in front of the listener. The client address is taken from
+	// request headers only for requests from these networks.
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	// +kubebuilder:validation:items:MaxLength=64
+	TrustedProxies []string `json:"trustedProxies,omitempty"`
+
+	// ForwardedForDepth is the number of proxies in front of the listener
+	// that append to X-Forwarded-For. The client address is the entry this
+	// many positions from the right. Unset skips trusted proxy addresses
+	// from the right instead.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	ForwardedForDepth *int32 `json:"forwardedForDepth,omitempty"`
+
+	// RealIPHeader is a header that trusted proxies set to the client
+	// address, such as X-Real-IP or CF-Connecting-IP. It is used instead of
+	// X-Forwarded-For.
+	// +optional
+	RealIPHeader *gatewayv1.HTTPHeaderName `json:"realIPHeader,omitempty"`
+}
+
+// PingoraTrafficPolicySpec defines request size limits, client timeouts,
+// client address detection and the PROXY protocol of the Gateway listeners
+// targeted by the policy. Unset fields keep the proxy defaults.
+type PingoraTrafficPolicySpec struct {
+	// TargetRefs are the Gateways in the policy's namespace that the limits
+	// apply to. sectionName selects a single listener of the Gateway.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:XValidation:rule="self.all(ref, ref.group == 'gateway.networking.k8s.io' && ref.kind == 'Gateway')",message="targetRefs must reference Gateways"
+	TargetRefs []gatewayv1.LocalPolicyTargetReferenceWithSectionName `json:"targetRefs"`
+
+	// MaxRequestBodySize is the largest request body accepted, e.g. "10Mi".
+	// Larger requests are answered with 413.
+	// +optional
+	MaxRequestBodySize *resource.Quantity `json:"maxRequestBodySize,omitempty"`
+
+	// MaxRequestHeadersSize is the largest total size of the request line and
+	// headers accepted, e.g. "32Ki". Larger requests are answered with 431.
+	// +optional
+	MaxRequestHeadersSize *resource.Quantity `json:"maxRequestHeadersSize,omitempty"`
+
+	// MaxRequestHeaders is the largest number of request headers accepted.
+	// Requests with more headers are answered with 431.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	MaxRequestHeaders *int32 `json:"maxRequestHeaders,omitempty"`
+
+	// RequestHeaderTimeout bounds the time a client may take to send the
+	// request line and headers, which protects the proxy from slow clients.
+	// +optional
+	RequestHeaderTimeout *gatewayv1.Duration `json:"requestHeaderTimeout,omitempty"`
+
+	// RequestBodyTimeout bounds the time the proxy waits for the next chunk
+	// of a request body.
+	// +optional
+	RequestBodyTimeout *gatewayv1.Duration `json:"requestBodyTimeout,omitempty"`
+
+	// IdleTimeout bounds the time a keep-alive connection may stay idle
+	// between requests.
+	// +optional
+	IdleTimeout *gatewayv1.Duration `json:"idleTimeout,omitempty"`
+
+	// ProxyProtocol makes the listener read the client address from a
+	// HAProxy PROXY protocol header sent by a load balancer in front of the
+	// proxy, so the address survives the hop.
+	// +optional
+	ProxyProtocol *ProxyProtocolConfig `json:"proxyProtocol,omitempty"`
+
+	// ClientIP replaces the client address detection configured in the
+	// defaults of the PingoraConfig for the listener.
+	// +optional
+	ClientIP *ClientIPConfig `json:"clientIP,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pgtraffic
+// +kubebuilder:metadata:labels="gateway.networking.k8s.io/policy=Direct"
+// +kubebuilder:printcolumn:name="Body",type=string,JSONPath=`.spec.maxRequestBodySize`
+// +kubebuilder:printcolumn:name="Headers",type=string,JSONPath=`.spec.maxRequestHeadersSize`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// PingoraTrafficPolicy is the Schema for the pingoratrafficpolicies API.
+// It attaches request size limits, client timeouts and the PROXY protocol to
+// Gateway listeners with Gateway API policy attachment.
+type PingoraTrafficPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Spec   PingoraTrafficPolicySpec `json:"spec,omitempty"`   //nolint:modernize // kubebuilder standard
+	Status gatewayv1.PolicyStatus   `json:"status,omitempty"` //nolint:modernize // kubebuilder standard
+}
+
+// +kubebuilder:object:root=true
+
+// PingoraTrafficPolicyList contains a list of PingoraTrafficPolicy.
+type PingoraTrafficPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"` //nolint:modernize // kubebuilder standard
+
+	Items []PingoraTrafficPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PingoraTrafficPolicy{}, &PingoraTrafficPolicyList{})
+}
+
+// GetTargetRefs returns the resources the policy is attached to.
+func (p *PingoraTrafficPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// GetPolicyStatus returns the policy status for in-place updates.
+func (p *PingoraTrafficPolicy) GetPolicyStatus() *gatewayv1.PolicyStatus {
+	return &p.Status
+}
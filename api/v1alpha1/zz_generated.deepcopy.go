@@ -11,52 +11,1369 @@ Licensed under the BSD 3-Clause License.
 package v1alpha1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/gateway-api/apis/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogConfig) DeepCopyInto(out *AccessLogConfig) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]AccessLogField, len(*in))
+		copy(*out, *in)
+	}
+	if in.SamplePercent != nil {
+		in, out := &in.SamplePercent, &out.SamplePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IncludePaths != nil {
+		in, out := &in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePaths != nil {
+		in, out := &in.ExcludePaths, &out.ExcludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogConfig.
+func (in *AccessLogConfig) DeepCopy() *AccessLogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendEndpoint) DeepCopyInto(out *BackendEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendEndpoint.
+func (in *BackendEndpoint) DeepCopy() *BackendEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendTLS) DeepCopyInto(out *BackendTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTLS.
+func (in *BackendTLS) DeepCopy() *BackendTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheBypassRule) DeepCopyInto(out *CacheBypassRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheBypassRule.
+func (in *CacheBypassRule) DeepCopy() *CacheBypassRule {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheBypassRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheKey) DeepCopyInto(out *CacheKey) {
+	*out = *in
+	if in.QueryParameters != nil {
+		in, out := &in.QueryParameters, &out.QueryParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]v1.HTTPHeaderName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheKey.
+func (in *CacheKey) DeepCopy() *CacheKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
+	*out = *in
+	if in.Consecutive5xx != nil {
+		in, out := &in.Consecutive5xx, &out.Consecutive5xx
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EjectionDuration != nil {
+		in, out := &in.EjectionDuration, &out.EjectionDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreaker.
+func (in *CircuitBreaker) DeepCopy() *CircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimToHeader) DeepCopyInto(out *ClaimToHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimToHeader.
+func (in *ClaimToHeader) DeepCopy() *ClaimToHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimToHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientIPConfig) DeepCopyInto(out *ClientIPConfig) {
+	*out = *in
+	if in.TrustedProxies != nil {
+		in, out := &in.TrustedProxies, &out.TrustedProxies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForwardedForDepth != nil {
+		in, out := &in.ForwardedForDepth, &out.ForwardedForDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RealIPHeader != nil {
+		in, out := &in.RealIPHeader, &out.RealIPHeader
+		*out = new(v1.HTTPHeaderName)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientIPConfig.
+func (in *ClientIPConfig) DeepCopy() *ClientIPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientIPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionConfig) DeepCopyInto(out *ConnectionConfig) {
 	*out = *in
-	if in.ConnectTimeoutSeconds != nil {
-		in, out := &in.ConnectTimeoutSeconds, &out.ConnectTimeoutSeconds
-		*out = new(int32)
-		**out = **in
+	if in.ConnectTimeoutSeconds != nil {
+		in, out := &in.ConnectTimeoutSeconds, &out.ConnectTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequestTimeoutSeconds != nil {
+		in, out := &in.RequestTimeoutSeconds, &out.RequestTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeepaliveTimeSeconds != nil {
+		in, out := &in.KeepaliveTimeSeconds, &out.KeepaliveTimeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RetryBackoffMs != nil {
+		in, out := &in.RetryBackoffMs, &out.RetryBackoffMs
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionConfig.
+func (in *ConnectionConfig) DeepCopy() *ConnectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalAuth) DeepCopyInto(out *ExternalAuth) {
+	*out = *in
+	in.BackendRef.DeepCopyInto(&out.BackendRef)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HeadersToBackend != nil {
+		in, out := &in.HeadersToBackend, &out.HeadersToBackend
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalAuth.
+func (in *ExternalAuth) DeepCopy() *ExternalAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCWeb) DeepCopyInto(out *GRPCWeb) {
+	*out = *in
+	if in.AllowText != nil {
+		in, out := &in.AllowText, &out.AllowText
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCWeb.
+func (in *GRPCWeb) DeepCopy() *GRPCWeb {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCWeb)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTP3Config) DeepCopyInto(out *HTTP3Config) {
+	*out = *in
+	if in.AdvertisedPort != nil {
+		in, out := &in.AdvertisedPort, &out.AdvertisedPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AltSvcMaxAge != nil {
+		in, out := &in.AltSvcMaxAge, &out.AltSvcMaxAge
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP3Config.
+func (in *HTTP3Config) DeepCopy() *HTTP3Config {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTP3Config)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExpectedStatuses != nil {
+		in, out := &in.ExpectedStatuses, &out.ExpectedStatuses
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheck.
+func (in *HealthCheck) DeepCopy() *HealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWKSSource) DeepCopyInto(out *JWKSSource) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(KeyReference)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(KeyReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWKSSource.
+func (in *JWKSSource) DeepCopy() *JWKSSource {
+	if in == nil {
+		return nil
+	}
+	out := new(JWKSSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTAuth) DeepCopyInto(out *JWTAuth) {
+	*out = *in
+	if in.Issuers != nil {
+		in, out := &in.Issuers, &out.Issuers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.JWKS.DeepCopyInto(&out.JWKS)
+	if in.ClaimsToHeaders != nil {
+		in, out := &in.ClaimsToHeaders, &out.ClaimsToHeaders
+		*out = make([]ClaimToHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTAuth.
+func (in *JWTAuth) DeepCopy() *JWTAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyReference) DeepCopyInto(out *KeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyReference.
+func (in *KeyReference) DeepCopy() *KeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerTLSConfig) DeepCopyInto(out *ListenerTLSConfig) {
+	*out = *in
+	if in.FallbackCertificateRef != nil {
+		in, out := &in.FallbackCertificateRef, &out.FallbackCertificateRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerTLSConfig.
+func (in *ListenerTLSConfig) DeepCopy() *ListenerTLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerTLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAccessControlPolicy) DeepCopyInto(out *PingoraAccessControlPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAccessControlPolicy.
+func (in *PingoraAccessControlPolicy) DeepCopy() *PingoraAccessControlPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAccessControlPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraAccessControlPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAccessControlPolicyList) DeepCopyInto(out *PingoraAccessControlPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraAccessControlPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAccessControlPolicyList.
+func (in *PingoraAccessControlPolicyList) DeepCopy() *PingoraAccessControlPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAccessControlPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraAccessControlPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAccessControlPolicySpec) DeepCopyInto(out *PingoraAccessControlPolicySpec) {
+	*out = *in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deny != nil {
+		in, out := &in.Deny, &out.Deny
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAccessControlPolicySpec.
+func (in *PingoraAccessControlPolicySpec) DeepCopy() *PingoraAccessControlPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAccessControlPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAccessLogPolicy) DeepCopyInto(out *PingoraAccessLogPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAccessLogPolicy.
+func (in *PingoraAccessLogPolicy) DeepCopy() *PingoraAccessLogPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAccessLogPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraAccessLogPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAccessLogPolicyList) DeepCopyInto(out *PingoraAccessLogPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraAccessLogPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAccessLogPolicyList.
+func (in *PingoraAccessLogPolicyList) DeepCopy() *PingoraAccessLogPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAccessLogPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraAccessLogPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAccessLogPolicySpec) DeepCopyInto(out *PingoraAccessLogPolicySpec) {
+	*out = *in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAccessLogPolicySpec.
+func (in *PingoraAccessLogPolicySpec) DeepCopy() *PingoraAccessLogPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAccessLogPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAuthPolicy) DeepCopyInto(out *PingoraAuthPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAuthPolicy.
+func (in *PingoraAuthPolicy) DeepCopy() *PingoraAuthPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAuthPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraAuthPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAuthPolicyList) DeepCopyInto(out *PingoraAuthPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraAuthPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAuthPolicyList.
+func (in *PingoraAuthPolicyList) DeepCopy() *PingoraAuthPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAuthPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraAuthPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraAuthPolicySpec) DeepCopyInto(out *PingoraAuthPolicySpec) {
+	*out = *in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.JWT != nil {
+		in, out := &in.JWT, &out.JWT
+		*out = new(JWTAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalAuth)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraAuthPolicySpec.
+func (in *PingoraAuthPolicySpec) DeepCopy() *PingoraAuthPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraAuthPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraBackend) DeepCopyInto(out *PingoraBackend) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraBackend.
+func (in *PingoraBackend) DeepCopy() *PingoraBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraBackend) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraBackendList) DeepCopyInto(out *PingoraBackendList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraBackendList.
+func (in *PingoraBackendList) DeepCopy() *PingoraBackendList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraBackendList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraBackendList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraBackendPolicy) DeepCopyInto(out *PingoraBackendPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraBackendPolicy.
+func (in *PingoraBackendPolicy) DeepCopy() *PingoraBackendPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraBackendPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraBackendPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraBackendPolicyList) DeepCopyInto(out *PingoraBackendPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraBackendPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraBackendPolicyList.
+func (in *PingoraBackendPolicyList) DeepCopy() *PingoraBackendPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraBackendPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraBackendPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraBackendPolicySpec) DeepCopyInto(out *PingoraBackendPolicySpec) {
+	*out = *in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreaker)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraBackendPolicySpec.
+func (in *PingoraBackendPolicySpec) DeepCopy() *PingoraBackendPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraBackendPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraBackendSpec) DeepCopyInto(out *PingoraBackendSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]BackendEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(BackendTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraBackendSpec.
+func (in *PingoraBackendSpec) DeepCopy() *PingoraBackendSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraBackendSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraCORSPolicy) DeepCopyInto(out *PingoraCORSPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraCORSPolicy.
+func (in *PingoraCORSPolicy) DeepCopy() *PingoraCORSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraCORSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraCORSPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraCORSPolicyList) DeepCopyInto(out *PingoraCORSPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraCORSPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraCORSPolicyList.
+func (in *PingoraCORSPolicyList) DeepCopy() *PingoraCORSPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraCORSPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraCORSPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraCORSPolicySpec) DeepCopyInto(out *PingoraCORSPolicySpec) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExposeHeaders != nil {
+		in, out := &in.ExposeHeaders, &out.ExposeHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxAgeSeconds != nil {
+		in, out := &in.MaxAgeSeconds, &out.MaxAgeSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraCORSPolicySpec.
+func (in *PingoraCORSPolicySpec) DeepCopy() *PingoraCORSPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraCORSPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraCachePolicy) DeepCopyInto(out *PingoraCachePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraCachePolicy.
+func (in *PingoraCachePolicy) DeepCopy() *PingoraCachePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraCachePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraCachePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraCachePolicyList) DeepCopyInto(out *PingoraCachePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraCachePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraCachePolicyList.
+func (in *PingoraCachePolicyList) DeepCopy() *PingoraCachePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraCachePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraCachePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraCachePolicySpec) DeepCopyInto(out *PingoraCachePolicySpec) {
+	*out = *in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(CacheKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VaryHeaders != nil {
+		in, out := &in.VaryHeaders, &out.VaryHeaders
+		*out = make([]v1.HTTPHeaderName, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bypass != nil {
+		in, out := &in.Bypass, &out.Bypass
+		*out = make([]CacheBypassRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraCachePolicySpec.
+func (in *PingoraCachePolicySpec) DeepCopy() *PingoraCachePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraCachePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraConfig) DeepCopyInto(out *PingoraConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraConfig.
+func (in *PingoraConfig) DeepCopy() *PingoraConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraConfigList) DeepCopyInto(out *PingoraConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraConfigList.
+func (in *PingoraConfigList) DeepCopy() *PingoraConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraConfigSpec) DeepCopyInto(out *PingoraConfigSpec) {
+	*out = *in
+	if in.Discovery != nil {
+		in, out := &in.Discovery, &out.Discovery
+		*out = new(ProxyDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyRef != nil {
+		in, out := &in.ProxyRef, &out.ProxyRef
+		*out = new(ProxyReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = new(ConnectionConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(ProxyDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraConfigSpec.
+func (in *PingoraConfigSpec) DeepCopy() *PingoraConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraConfigStatus) DeepCopyInto(out *PingoraConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Proxies != nil {
+		in, out := &in.Proxies, &out.Proxies
+		*out = make([]ProxyInstanceStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraConfigStatus.
+func (in *PingoraConfigStatus) DeepCopy() *PingoraConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraGRPCPolicy) DeepCopyInto(out *PingoraGRPCPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraGRPCPolicy.
+func (in *PingoraGRPCPolicy) DeepCopy() *PingoraGRPCPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraGRPCPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraGRPCPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraGRPCPolicyList) DeepCopyInto(out *PingoraGRPCPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraGRPCPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.RequestTimeoutSeconds != nil {
-		in, out := &in.RequestTimeoutSeconds, &out.RequestTimeoutSeconds
-		*out = new(int32)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraGRPCPolicyList.
+func (in *PingoraGRPCPolicyList) DeepCopy() *PingoraGRPCPolicyList {
+	if in == nil {
+		return nil
 	}
-	if in.KeepaliveTimeSeconds != nil {
-		in, out := &in.KeepaliveTimeSeconds, &out.KeepaliveTimeSeconds
-		*out = new(int32)
-		**out = **in
+	out := new(PingoraGRPCPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraGRPCPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.MaxRetries != nil {
-		in, out := &in.MaxRetries, &out.MaxRetries
-		*out = new(int32)
-		**out = **in
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraGRPCPolicySpec) DeepCopyInto(out *PingoraGRPCPolicySpec) {
+	*out = *in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.RetryBackoffMs != nil {
-		in, out := &in.RetryBackoffMs, &out.RetryBackoffMs
-		*out = new(int32)
+	in.GRPCWeb.DeepCopyInto(&out.GRPCWeb)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraGRPCPolicySpec.
+func (in *PingoraGRPCPolicySpec) DeepCopy() *PingoraGRPCPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraGRPCPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraRateLimitPolicy) DeepCopyInto(out *PingoraRateLimitPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraRateLimitPolicy.
+func (in *PingoraRateLimitPolicy) DeepCopy() *PingoraRateLimitPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraRateLimitPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraRateLimitPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraRateLimitPolicyList) DeepCopyInto(out *PingoraRateLimitPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PingoraRateLimitPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraRateLimitPolicyList.
+func (in *PingoraRateLimitPolicyList) DeepCopy() *PingoraRateLimitPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraRateLimitPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PingoraRateLimitPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PingoraRateLimitPolicySpec) DeepCopyInto(out *PingoraRateLimitPolicySpec) {
+	*out = *in
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(RateLimitKey)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionConfig.
-func (in *ConnectionConfig) DeepCopy() *ConnectionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraRateLimitPolicySpec.
+func (in *PingoraRateLimitPolicySpec) DeepCopy() *PingoraRateLimitPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ConnectionConfig)
+	out := new(PingoraRateLimitPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PingoraConfig) DeepCopyInto(out *PingoraConfig) {
+func (in *PingoraTrafficPolicy) DeepCopyInto(out *PingoraTrafficPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -64,18 +1381,18 @@ func (in *PingoraConfig) DeepCopyInto(out *PingoraConfig) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraConfig.
-func (in *PingoraConfig) DeepCopy() *PingoraConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraTrafficPolicy.
+func (in *PingoraTrafficPolicy) DeepCopy() *PingoraTrafficPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(PingoraConfig)
+	out := new(PingoraTrafficPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PingoraConfig) DeepCopyObject() runtime.Object {
+func (in *PingoraTrafficPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -83,31 +1400,31 @@ func (in *PingoraConfig) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PingoraConfigList) DeepCopyInto(out *PingoraConfigList) {
+func (in *PingoraTrafficPolicyList) DeepCopyInto(out *PingoraTrafficPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PingoraConfig, len(*in))
+		*out = make([]PingoraTrafficPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraConfigList.
-func (in *PingoraConfigList) DeepCopy() *PingoraConfigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraTrafficPolicyList.
+func (in *PingoraTrafficPolicyList) DeepCopy() *PingoraTrafficPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PingoraConfigList)
+	out := new(PingoraTrafficPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PingoraConfigList) DeepCopyObject() runtime.Object {
+func (in *PingoraTrafficPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -115,52 +1432,219 @@ func (in *PingoraConfigList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PingoraConfigSpec) DeepCopyInto(out *PingoraConfigSpec) {
+func (in *PingoraTrafficPolicySpec) DeepCopyInto(out *PingoraTrafficPolicySpec) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(TLSConfig)
+	if in.TargetRefs != nil {
+		in, out := &in.TargetRefs, &out.TargetRefs
+		*out = make([]v1.LocalPolicyTargetReferenceWithSectionName, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxRequestBodySize != nil {
+		in, out := &in.MaxRequestBodySize, &out.MaxRequestBodySize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxRequestHeadersSize != nil {
+		in, out := &in.MaxRequestHeadersSize, &out.MaxRequestHeadersSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxRequestHeaders != nil {
+		in, out := &in.MaxRequestHeaders, &out.MaxRequestHeaders
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequestHeaderTimeout != nil {
+		in, out := &in.RequestHeaderTimeout, &out.RequestHeaderTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RequestBodyTimeout != nil {
+		in, out := &in.RequestBodyTimeout, &out.RequestBodyTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.IdleTimeout != nil {
+		in, out := &in.IdleTimeout, &out.IdleTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ProxyProtocol != nil {
+		in, out := &in.ProxyProtocol, &out.ProxyProtocol
+		*out = new(ProxyProtocolConfig)
+		**out = **in
+	}
+	if in.ClientIP != nil {
+		in, out := &in.ClientIP, &out.ClientIP
+		*out = new(ClientIPConfig)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Connection != nil {
-		in, out := &in.Connection, &out.Connection
-		*out = new(ConnectionConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraTrafficPolicySpec.
+func (in *PingoraTrafficPolicySpec) DeepCopy() *PingoraTrafficPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PingoraTrafficPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDefaults) DeepCopyInto(out *ProxyDefaults) {
+	*out = *in
+	if in.RequestTimeout != nil {
+		in, out := &in.RequestTimeout, &out.RequestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxRequestBodySize != nil {
+		in, out := &in.MaxRequestBodySize, &out.MaxRequestBodySize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AccessLog != nil {
+		in, out := &in.AccessLog, &out.AccessLog
+		*out = new(AccessLogConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTP2 != nil {
+		in, out := &in.HTTP2, &out.HTTP2
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HTTP3 != nil {
+		in, out := &in.HTTP3, &out.HTTP3
+		*out = new(HTTP3Config)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ListenerTLS != nil {
+		in, out := &in.ListenerTLS, &out.ListenerTLS
+		*out = new(ListenerTLSConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TrustedProxies != nil {
+		in, out := &in.TrustedProxies, &out.TrustedProxies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForwardedForDepth != nil {
+		in, out := &in.ForwardedForDepth, &out.ForwardedForDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RealIPHeader != nil {
+		in, out := &in.RealIPHeader, &out.RealIPHeader
+		*out = new(v1.HTTPHeaderName)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraConfigSpec.
-func (in *PingoraConfigSpec) DeepCopy() *PingoraConfigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDefaults.
+func (in *ProxyDefaults) DeepCopy() *ProxyDefaults {
 	if in == nil {
 		return nil
 	}
-	out := new(PingoraConfigSpec)
+	out := new(ProxyDefaults)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PingoraConfigStatus) DeepCopyInto(out *PingoraConfigStatus) {
+func (in *ProxyDiscovery) DeepCopyInto(out *ProxyDiscovery) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.LastSyncTime != nil {
-		in, out := &in.LastSyncTime, &out.LastSyncTime
-		*out = (*in).DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDiscovery.
+func (in *ProxyDiscovery) DeepCopy() *ProxyDiscovery {
+	if in == nil {
+		return nil
 	}
+	out := new(ProxyDiscovery)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PingoraConfigStatus.
-func (in *PingoraConfigStatus) DeepCopy() *PingoraConfigStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyInstanceStatus) DeepCopyInto(out *ProxyInstanceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyInstanceStatus.
+func (in *ProxyInstanceStatus) DeepCopy() *ProxyInstanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PingoraConfigStatus)
+	out := new(ProxyInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyProtocolConfig) DeepCopyInto(out *ProxyProtocolConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyProtocolConfig.
+func (in *ProxyProtocolConfig) DeepCopy() *ProxyProtocolConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyProtocolConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyReference) DeepCopyInto(out *ProxyReference) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyReference.
+func (in *ProxyReference) DeepCopy() *ProxyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RateLimitKey) DeepCopyInto(out *RateLimitKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RateLimitKey.
+func (in *RateLimitKey) DeepCopy() *RateLimitKey {
+	if in == nil {
+		return nil
+	}
+	out := new(RateLimitKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SPIFFEConfig) DeepCopyInto(out *SPIFFEConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SPIFFEConfig.
+func (in *SPIFFEConfig) DeepCopy() *SPIFFEConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SPIFFEConfig)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -188,6 +1672,11 @@ func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 		*out = new(SecretReference)
 		**out = **in
 	}
+	if in.SPIFFE != nil {
+		in, out := &in.SPIFFE, &out.SPIFFE
+		*out = new(SPIFFEConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
@@ -110,3 +110,14 @@ Proxy labels
 {{ include "pingora-gw-ctrl.labels" . }}
 app.kubernetes.io/component: proxy
 {{- end }}
+
+{{/*
+Create the name of the webhook serving certificate Secret
+*/}}
+{{- define "pingora-gw-ctrl.webhookCertSecretName" -}}
+{{- if .Values.webhook.certSecretName }}
+{{- .Values.webhook.certSecretName }}
+{{- else }}
+{{- printf "%s-webhook-cert" (include "pingora-gw-ctrl.fullname" .) | trunc 63 | trimSuffix "-" }}
+{{- end }}
+{{- end }}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+var errReadOnly = errors.New("manifests are read-only")
+
+var _ client.Client = (*manifestClient)(nil)
+
+// manifestClient serves the objects decoded from manifests to the route
+// builder and the route checks of the offline commands. Reads support
+// namespace and label selectors; writes fail with errReadOnly.
+type manifestClient struct {
+	scheme  *runtime.Scheme
+	mapper  *meta.DefaultRESTMapper
+	objects map[schema.GroupVersionKind][]client.Object
+}
+
+// newManifestClient returns a client reading objects. Objects defined twice
+// are an error, as the cluster would reject the second one.
+func newManifestClient(scheme *runtime.Scheme, objects []client.Object) (*manifestClient, error) {
+	c := &manifestClient{
+		scheme:  scheme,
+		mapper:  meta.NewDefaultRESTMapper(scheme.PrioritizedVersionsAllGroups()),
+		objects: make(map[schema.GroupVersionKind][]client.Object),
+	}
+
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to determine object kind")
+		}
+
+		key := client.ObjectKeyFromObject(obj)
+		if c.find(gvk, key) != nil {
+			return nil, errors.Newf("%s %s is defined more than once", gvk.Kind, key)
+		}
+
+		c.objects[gvk] = append(c.objects[gvk], obj)
+	}
+
+	for gvk := range scheme.AllKnownTypes() {
+		scope := meta.RESTScopeNamespace
+		if slices.Contains(clusterScopedKinds, gvk.Kind) {
+			scope = meta.RESTScopeRoot
+		}
+
+		c.mapper.Add(gvk, scope)
+	}
+
+	return c, nil
+}
+
+func (c *manifestClient) find(gvk schema.GroupVersionKind, key client.ObjectKey) client.Object {
+	for _, obj := range c.objects[gvk] {
+		if client.ObjectKeyFromObject(obj) == key {
+			return obj
+		}
+	}
+
+	return nil
+}
+
+// Get copies the object with the key into obj.
+func (c *manifestClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to determine object kind")
+	}
+
+	found := c.find(gvk, key)
+	if found == nil {
+		resource, _ := meta.UnsafeGuessKindToResource(gvk)
+
+		return apierrors.NewNotFound(resource.GroupResource(), key.Name)
+	}
+
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(found.DeepCopyObject()).Elem())
+
+	return nil
+}
+
+// List copies the objects of the list kind matching opts into list, sorted
+// by namespace and name.
+func (c *manifestClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	gvk, err := apiutil.GVKForObject(list, c.scheme)
+	if err != nil {
+		return errors.Wrap(err, "failed to determine list kind")
+	}
+
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+
+	if listOpts.FieldSelector != nil && !listOpts.FieldSelector.Empty() {
+		return errors.Newf("field selectors are not supported for %s in manifests", gvk.Kind)
+	}
+
+	var items []runtime.Object
+
+	for _, obj := range c.objects[gvk] {
+		if listOpts.Namespace != "" && obj.GetNamespace() != listOpts.Namespace {
+			continue
+		}
+
+		if listOpts.LabelSelector != nil && !listOpts.LabelSelector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+
+		items = append(items, obj.DeepCopyObject())
+	}
+
+	slices.SortFunc(items, func(a, b runtime.Object) int {
+		return strings.Compare(
+			client.ObjectKeyFromObject(a.(client.Object)).String(),
+			client.ObjectKeyFromObject(b.(client.Object)).String(),
+		)
+	})
+
+	return errors.Wrap(meta.SetList(list, items), "failed to set list items")
+}
+
+func (c *manifestClient) Apply(context.Context, runtime.ApplyConfiguration, ...client.ApplyOption) error {
+	return errReadOnly
+}
+
+func (c *manifestClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return errReadOnly
+}
+
+func (c *manifestClient) Delete(context.Context, client.Object, ...client.DeleteOption) error {
+	return errReadOnly
+}
+
+func (c *manifestClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return errReadOnly
+}
+
+func (c *manifestClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return errReadOnly
+}
+
+func (c *manifestClient) DeleteAllOf(context.Context, client.Object, ...client.DeleteAllOfOption) error {
+	return errReadOnly
+}
+
+func (c *manifestClient) Status() client.SubResourceWriter {
+	return readOnlySubResource{}
+}
+
+func (c *manifestClient) SubResource(string) client.SubResourceClient {
+	return readOnlySubResource{}
+}
+
+func (c *manifestClient) Scheme() *runtime.Scheme {
+	return c.scheme
+}
+
+func (c *manifestClient) RESTMapper() meta.RESTMapper {
+	return c.mapper
+}
+
+func (c *manifestClient) GroupVersionKindFor(obj runtime.Object) (schema.GroupVersionKind, error) {
+	//nolint:wrapcheck // same contract as the controller-runtime client
+	return apiutil.GVKForObject(obj, c.scheme)
+}
+
+func (c *manifestClient) IsObjectNamespaced(obj runtime.Object) (bool, error) {
+	//nolint:wrapcheck // same contract as the controller-runtime client
+	return apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+}
+
+// readOnlySubResource is the status and subresource client of manifests,
+// which have no subresources to read.
+type readOnlySubResource struct{}
+
+func (readOnlySubResource) Get(context.Context, client.Object, client.Object, ...client.SubResourceGetOption) error {
+	return errReadOnly
+}
+
+func (readOnlySubResource) Create(context.Context, client.Object, client.Object, ...client.SubResourceCreateOption) error {
+	return errReadOnly
+}
+
+func (readOnlySubResource) Update(context.Context, client.Object, ...client.SubResourceUpdateOption) error {
+	return errReadOnly
+}
+
+func (readOnlySubResource) Patch(context.Context, client.Object, client.Patch, ...client.SubResourcePatchOption) error {
+	return errReadOnly
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestManifestClient(t *testing.T) {
+	t.Parallel()
+
+	scheme, err := renderScheme()
+	require.NoError(t, err)
+
+	route := func(namespace, name string, labels map[string]string) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}}
+	}
+
+	c, err := newManifestClient(scheme, []client.Object{
+		route("team-b", "web", nil),
+		route("team-a", "web", map[string]string{"tier": "canary"}),
+		route("team-a", "api", nil),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	var got gatewayv1.HTTPRoute
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "team-a", Name: "web"}, &got))
+	assert.Equal(t, "canary", got.Labels["tier"])
+
+	// Reads return copies
+	got.Labels["tier"] = "stable"
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Namespace: "team-a", Name: "web"}, &got))
+	assert.Equal(t, "canary", got.Labels["tier"])
+
+	err = c.Get(ctx, client.ObjectKey{Namespace: "team-c", Name: "web"}, &got)
+	assert.True(t, apierrors.IsNotFound(err))
+
+	var namespace corev1.Namespace
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "team-a"}, &namespace))
+
+	names := func(list *gatewayv1.HTTPRouteList) []string {
+		var keys []string
+		for i := range list.Items {
+			keys = append(keys, client.ObjectKeyFromObject(&list.Items[i]).String())
+		}
+
+		return keys
+	}
+
+	var routes gatewayv1.HTTPRouteList
+	require.NoError(t, c.List(ctx, &routes))
+	assert.Equal(t, []string{"team-a/api", "team-a/web", "team-b/web"}, names(&routes))
+
+	require.NoError(t, c.List(ctx, &routes, client.InNamespace("team-b")))
+	assert.Equal(t, []string{"team-b/web"}, names(&routes))
+
+	require.NoError(t, c.List(ctx, &routes, client.MatchingLabels{"tier": "canary"}))
+	assert.Equal(t, []string{"team-a/web"}, names(&routes))
+
+	require.Error(t, c.List(ctx, &routes, client.MatchingFields{"spec.hostnames": "example.com"}))
+
+	var grpcRoutes gatewayv1.GRPCRouteList
+	require.NoError(t, c.List(ctx, &grpcRoutes))
+	assert.Empty(t, grpcRoutes.Items)
+
+	namespaced, err := c.IsObjectNamespaced(&namespace)
+	require.NoError(t, err)
+	assert.False(t, namespaced)
+
+	require.ErrorIs(t, c.Update(ctx, &got), errReadOnly)
+	require.ErrorIs(t, c.Status().Update(ctx, &got), errReadOnly)
+}
+
+func TestManifestClient_Duplicates(t *testing.T) {
+	t.Parallel()
+
+	scheme, err := renderScheme()
+	require.NoError(t, err)
+
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}}
+
+	_, err = newManifestClient(scheme, []client.Object{route, route.DeepCopy()})
+	require.ErrorContains(t, err, "HTTPRoute default/web is defined more than once")
+}
@@ -0,0 +1,366 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/controller"
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// stdinFilename reads manifests from standard input.
+const stdinFilename = "-"
+
+// clusterScopedKinds are the kinds read by the route builder that have no
+// namespace. Manifests of other kinds without a namespace get the default one.
+//
+//nolint:gochecknoglobals // constant lookup table
+var clusterScopedKinds = []string{"GatewayClass", "Namespace", config.PingoraParametersRefKind}
+
+//nolint:gochecknoglobals // cobra command pattern
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Print the route config the controller would send to the proxy",
+	Long: `Build the route config from Gateway API resources and print it in the
+protobuf JSON mapping of UpdateRoutesRequest, without connecting to the proxy.
+
+Resources are read from the manifests given with --filename, or from the
+cluster selected by KUBECONFIG or the in-cluster configuration. Manifests
+may hold several documents and v1 Lists; kinds the controller does not read
+are ignored. Use it to debug routing and to validate route manifests in CI.`,
+	Args:          cobra.NoArgs,
+	RunE:          runRender,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	renderCmd.Flags().StringSliceP("filename", "f", nil,
+		"Manifest files or directories to read instead of the cluster (- reads standard input)")
+	renderCmd.Flags().String("namespace", "default", "Namespace of manifests without one, and for Secret lookups")
+	renderCmd.Flags().String("gateway-class-name", "pingora", "GatewayClass whose routes are rendered")
+	renderCmd.Flags().String("cluster-domain", dns.DefaultClusterDomain, "Kubernetes cluster domain for backend addresses")
+	renderCmd.Flags().String("route-id-scheme", string(ingress.DefaultRouteIDScheme),
+		"Scheme for route IDs sent to the proxy (name, uid, hash)")
+	renderCmd.Flags().String("output", outputJSON, "Output encoding (json, yaml)")
+
+	rootCmd.AddCommand(renderCmd)
+}
+
+func runRender(cmd *cobra.Command, _ []string) error {
+	filenames, _ := cmd.Flags().GetStringSlice("filename")
+	namespace, _ := cmd.Flags().GetString("namespace")
+	output, _ := cmd.Flags().GetString("output")
+
+	opts := renderOptions{namespace: namespace}
+	opts.gatewayClassName, _ = cmd.Flags().GetString("gateway-class-name")
+	opts.clusterDomain, _ = cmd.Flags().GetString("cluster-domain")
+
+	scheme, err := renderScheme()
+	if err != nil {
+		return err
+	}
+
+	routeIDScheme, _ := cmd.Flags().GetString("route-id-scheme")
+
+	opts.routeIDScheme, err = ingress.ParseRouteIDScheme(routeIDScheme)
+	if err != nil {
+		return errors.Wrap(err, "invalid route ID scheme")
+	}
+
+	var k8sClient client.Client
+
+	if len(filenames) > 0 {
+		objects, loadErr := loadManifests(filenames, cmd.InOrStdin(), scheme, namespace)
+		if loadErr != nil {
+			return loadErr
+		}
+
+		k8sClient, err = newManifestClient(scheme, objects)
+		if err != nil {
+			return err
+		}
+	} else {
+		restConfig, configErr := ctrl.GetConfig()
+		if configErr != nil {
+			return errors.Wrap(configErr, "failed to load kubeconfig")
+		}
+
+		k8sClient, err = client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			return errors.Wrap(err, "failed to create client")
+		}
+	}
+
+	req, err := renderRoutes(cmd.Context(), k8sClient, scheme, opts, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+
+	data, err := encodeRoutes(req, output)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.OutOrStdout().Write(data)
+
+	return errors.Wrap(err, "failed to write route config")
+}
+
+// renderOptions configures the route builder of the render command.
+type renderOptions struct {
+	namespace        string
+	gatewayClassName string
+	clusterDomain    string
+	routeIDScheme    ingress.RouteIDScheme
+}
+
+// renderRoutes builds the route config from the resources c reads.
+// Builder warnings, such as dropped rules, are logged to logOutput.
+func renderRoutes(
+	ctx context.Context,
+	c client.Client,
+	scheme *runtime.Scheme,
+	opts renderOptions,
+	logOutput io.Writer,
+) (*routingv1.UpdateRoutesRequest, error) {
+	logger := slog.New(slog.NewTextHandler(logOutput, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	syncer := controller.NewPingoraRouteSyncer(
+		c,
+		scheme,
+		dns.NewClusterDomainProvider(opts.clusterDomain),
+		opts.gatewayClassName,
+		config.NewPingoraResolver(c, opts.namespace),
+		metrics.NewNoopCollector(),
+		0,
+		logger,
+	)
+	syncer.SetRouteIDScheme(opts.routeIDScheme)
+
+	req, err := syncer.RenderRoutes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build route config")
+	}
+
+	return req, nil
+}
+
+// encodeRoutes encodes the route config in the protobuf JSON mapping, as
+// JSON or YAML.
+func encodeRoutes(req *routingv1.UpdateRoutesRequest, output string) ([]byte, error) {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode route config")
+	}
+
+	switch output {
+	case outputJSON:
+		return append(data, '\n'), nil
+	case outputYAML:
+		data, err = yaml.JSONToYAML(data)
+
+		return data, errors.Wrap(err, "failed to encode route config")
+	default:
+		return nil, errors.Newf("unsupported output encoding %q", output)
+	}
+}
+
+// renderScheme returns a scheme with every type the route builder reads.
+func renderScheme() (*runtime.Scheme, error) {
+	scheme := runtime.NewScheme()
+
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		clientgoscheme.AddToScheme,
+		gatewayv1.Install,
+		gatewayv1beta1.Install,
+		v1alpha1.AddToScheme,
+	} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, errors.Wrap(err, "failed to build scheme")
+		}
+	}
+
+	return scheme, nil
+}
+
+// loadManifests decodes the objects in the given files and directories.
+// Objects of kinds unknown to scheme are skipped, and namespaced objects
+// without a namespace get namespace.
+func loadManifests(filenames []string, stdin io.Reader, scheme *runtime.Scheme, namespace string) ([]client.Object, error) {
+	paths, err := manifestPaths(filenames)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var objects []client.Object
+
+	for _, path := range paths {
+		var data []byte
+
+		if path == stdinFilename {
+			data, err = io.ReadAll(stdin)
+		} else {
+			data, err = os.ReadFile(path) //nolint:gosec // reading user-selected manifests is the point
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", path)
+		}
+
+		decoded, decodeErr := decodeManifest(data, decoder, namespace)
+		if decodeErr != nil {
+			return nil, errors.Wrapf(decodeErr, "failed to decode %s", path)
+		}
+
+		objects = append(objects, decoded...)
+	}
+
+	return objects, nil
+}
+
+// manifestPaths expands directories to the YAML and JSON files in them.
+func manifestPaths(filenames []string) ([]string, error) {
+	var paths []string
+
+	for _, filename := range filenames {
+		if filename == stdinFilename {
+			paths = append(paths, filename)
+
+			continue
+		}
+
+		info, err := os.Stat(filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", filename)
+		}
+
+		if !info.IsDir() {
+			paths = append(paths, filename)
+
+			continue
+		}
+
+		entries, err := os.ReadDir(filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", filename)
+		}
+
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					paths = append(paths, filepath.Join(filename, entry.Name()))
+				}
+			}
+		}
+	}
+
+	return paths, nil
+}
+
+// decodeManifest decodes every document of a multi-document manifest,
+// including the items of v1 Lists.
+func decodeManifest(data []byte, decoder runtime.Decoder, namespace string) ([]client.Object, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+
+	var objects []client.Object
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return objects, nil
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to split documents")
+		}
+
+		decoded, err := decodeDocument(doc, decoder, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, decoded...)
+	}
+}
+
+// decodeDocument decodes a single YAML or JSON document. Empty documents
+// and kinds unknown to the decoder yield no objects.
+func decodeDocument(doc []byte, decoder runtime.Decoder, namespace string) ([]client.Object, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+		return nil, errors.Wrap(err, "failed to decode document")
+	}
+
+	if typeMeta.Kind == "" {
+		return nil, nil
+	}
+
+	if typeMeta.Kind == "List" {
+		var list metav1.List
+		if err := yaml.Unmarshal(doc, &list); err != nil {
+			return nil, errors.Wrap(err, "failed to decode list")
+		}
+
+		var objects []client.Object
+
+		for _, item := range list.Items {
+			decoded, err := decodeDocument(item.Raw, decoder, namespace)
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, decoded...)
+		}
+
+		return objects, nil
+	}
+
+	obj, _, err := decoder.Decode(doc, nil, nil)
+	if runtime.IsNotRegisteredError(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", typeMeta.Kind)
+	}
+
+	object, ok := obj.(client.Object)
+	if !ok {
+		return nil, nil
+	}
+
+	if object.GetNamespace() == "" && !slices.Contains(clusterScopedKinds, typeMeta.Kind) {
+		object.SetNamespace(namespace)
+	}
+
+	return []client.Object{object}, nil
+}
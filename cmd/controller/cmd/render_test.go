@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+const renderTestManifests = `apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: web
+spec:
+  gatewayClassName: pingora
+  listeners:
+    - name: http
+      protocol: HTTP
+      port: 80
+---
+# Kinds the controller does not read are skipped
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: gateway.networking.k8s.io/v1
+    kind: HTTPRoute
+    metadata:
+      name: app
+    spec:
+      parentRefs:
+        - name: web
+      hostnames:
+        - app.example.com
+      rules:
+        - backendRefs:
+            - name: app
+              port: 8080
+`
+
+func TestLoadManifests(t *testing.T) {
+	scheme, err := renderScheme()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(renderTestManifests), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a manifest"), 0o600))
+
+	objects, err := loadManifests([]string{dir}, nil, scheme, "team-a")
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+
+	assert.Equal(t, "web", objects[0].GetName())
+	assert.Equal(t, "team-a", objects[0].GetNamespace())
+	assert.Equal(t, "app", objects[1].GetName())
+	assert.Equal(t, "team-a", objects[1].GetNamespace())
+
+	stdin := strings.NewReader("apiVersion: pingora.k8s.lex.la/v1alpha1\nkind: PingoraConfig\nmetadata:\n  name: pingora\n")
+
+	objects, err = loadManifests([]string{stdinFilename}, stdin, scheme, "team-a")
+	require.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Empty(t, objects[0].GetNamespace(), "cluster-scoped kinds get no namespace")
+
+	_, err = loadManifests([]string{filepath.Join(dir, "missing.yaml")}, nil, scheme, "team-a")
+	require.Error(t, err)
+}
+
+func TestRenderRoutes(t *testing.T) {
+	scheme, err := renderScheme()
+	require.NoError(t, err)
+
+	objects, err := loadManifests([]string{stdinFilename}, strings.NewReader(renderTestManifests), scheme, "default")
+	require.NoError(t, err)
+
+	k8sClient, err := newManifestClient(scheme, objects)
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+
+	req, err := renderRoutes(context.Background(), k8sClient, scheme, renderOptions{
+		namespace:        "default",
+		gatewayClassName: "pingora",
+		clusterDomain:    "cluster.local",
+		routeIDScheme:    ingress.RouteIDSchemeName,
+	}, &logs)
+	require.NoError(t, err)
+	require.Len(t, req.GetHttpRoutes(), 1)
+
+	route := req.GetHttpRoutes()[0]
+	assert.Equal(t, "default/app", route.GetId())
+	assert.Equal(t, []string{"app.example.com"}, route.GetHostnames())
+	assert.Zero(t, req.GetVersion())
+
+	for _, output := range []string{outputJSON, outputYAML} {
+		data, encodeErr := encodeRoutes(req, output)
+		require.NoError(t, encodeErr)
+
+		var decoded map[string]any
+		if output == outputYAML {
+			require.NoError(t, yaml.Unmarshal(data, &decoded))
+		} else {
+			require.NoError(t, json.Unmarshal(data, &decoded))
+		}
+
+		assert.Contains(t, decoded, "httpRoutes")
+	}
+
+	_, err = encodeRoutes(req, "toml")
+	require.Error(t, err)
+}
+
+func TestRenderCmd_Registered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"render"})
+	require.NoError(t, err)
+	assert.Equal(t, renderCmd, cmd)
+
+	flag := cmd.Flags().ShorthandLookup("f")
+	require.NotNil(t, flag)
+	assert.Equal(t, "filename", flag.Name)
+}
@@ -5,17 +5,24 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/lexfrei/pingora-gateway-controller/internal/controller"
 	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
 	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
+	"github.com/lexfrei/pingora-gateway-controller/internal/smoketest"
+	"github.com/lexfrei/pingora-gateway-controller/internal/tracing"
+	"github.com/lexfrei/pingora-gateway-controller/internal/webhook"
 )
 
 //nolint:gochecknoglobals // set by SetVersion from main
@@ -29,6 +36,9 @@ func SetVersion(ver, sha string) {
 	gitsha = sha
 }
 
+// tracingShutdownTimeout bounds flushing pending spans on shutdown.
+const tracingShutdownTimeout = 5 * time.Second
+
 //nolint:gochecknoglobals // cobra command pattern
 var rootCmd = &cobra.Command{
 	Use:   "pingora-gateway-controller",
@@ -56,6 +66,56 @@ func init() {
 	rootCmd.Flags().String("controller-name", "pingora.k8s.lex.la/gateway-controller", "Controller name for GatewayClass")
 	rootCmd.Flags().String("metrics-addr", ":8080", "Address for metrics endpoint")
 	rootCmd.Flags().String("health-addr", ":8081", "Address for health probe endpoint")
+	rootCmd.Flags().Duration("sync-debounce", controller.DefaultSyncDebounce,
+		"Delay for coalescing route changes into a single proxy sync (0 disables)")
+	rootCmd.Flags().Duration("proxy-version-check-interval", controller.DefaultProxyVersionCheckInterval,
+		"Interval for checking the proxy config version to detect lost routes (0 disables)")
+	rootCmd.Flags().Duration("proxy-health-interval", controller.DefaultProxyHealthInterval,
+		"Interval for polling the proxy health for PingoraConfig and Gateway status (0 disables)")
+	rootCmd.Flags().Duration("proxy-unreachable-threshold", controller.DefaultProxyUnreachableThreshold,
+		"Time the proxy must be unreachable before it is reported down in status")
+	rootCmd.Flags().Duration("liveness-timeout", controller.DefaultLivenessTimeout,
+		"Time route syncs may make no progress before the liveness check fails (0 disables)")
+	rootCmd.Flags().Bool("binding-debug-annotations", false,
+		"Annotate routes with per-parent binding results for troubleshooting")
+	rootCmd.Flags().Bool("route-diagnostics-annotations", false,
+		"Annotate routes with the rules programmed into the proxy and the elements dropped from them")
+	rootCmd.Flags().Bool("route-deletion-finalizer", false,
+		"Keep deleted routes until they were removed from the proxy, using a finalizer")
+	rootCmd.Flags().Bool("route-traffic-metrics", false,
+		"Export the traffic the proxy counts per route and listener as metrics labeled with the routes")
+	rootCmd.Flags().StringSlice("adopt-controller-names", nil,
+		"Previous controller names whose route status entries are claimed once at startup")
+	rootCmd.Flags().StringSlice("watch-namespaces", nil,
+		"Namespaces to watch Gateways and routes in (empty watches all namespaces)")
+	rootCmd.Flags().String("route-label-selector", "",
+		"Label selector restricting the routes to reconcile, e.g. tier=canary (empty selects all routes)")
+	rootCmd.Flags().Bool("require-parent-ref-grants", false,
+		"Attach routes to Gateways in other namespaces only if a ReferenceGrant permits it")
+	rootCmd.Flags().StringSlice("feature-gates", nil,
+		"Optional Gateway API resources to enable, e.g. BackendTLSPolicy=true (all disabled by default)")
+	rootCmd.Flags().String("route-id-scheme", string(ingress.DefaultRouteIDScheme),
+		"Scheme for route IDs sent to the proxy (name, uid, hash)")
+	rootCmd.Flags().Bool("dry-run", false,
+		"Build and validate route configs and update statuses without sending routes to the proxy")
+
+	// Smoke test flags
+	rootCmd.Flags().String("smoke-test-url", "", "Canary URL requested through the proxy after each sync (empty disables)")
+	rootCmd.Flags().String("smoke-test-address", "", "Proxy data plane address (host:port) for smoke test requests")
+	rootCmd.Flags().Duration("smoke-test-timeout", smoketest.DefaultTimeout, "Timeout for a single smoke test request")
+
+	// Tracing flags
+	rootCmd.Flags().String("tracing-endpoint", "", "OTLP/gRPC collector address (host:port) for traces (empty disables)")
+	rootCmd.Flags().Bool("tracing-insecure", false, "Send traces to the collector without TLS")
+	rootCmd.Flags().Float64("tracing-sample-ratio", tracing.DefaultSampleRatio,
+		"Fraction of new traces that are sampled, from 0 to 1")
+
+	// Admission webhook flags
+	rootCmd.Flags().Bool("enable-webhook", false, "Serve the PingoraConfig validating admission webhook")
+	rootCmd.Flags().Int("webhook-port", webhook.DefaultPort, "Port for the admission webhook server")
+	rootCmd.Flags().Int("webhook-max-route-matches", webhook.DefaultMaxMatchesPerRule,
+		"Maximum matches per route rule accepted by the route webhooks (0 disables)")
+	rootCmd.Flags().String("webhook-cert-dir", "", "Directory with the webhook serving certificate (tls.crt, tls.key)")
 
 	// Leader election flags
 	rootCmd.Flags().Bool("leader-elect", false, "Enable leader election for high availability")
@@ -78,6 +138,23 @@ func initConfig() {
 	viper.SetDefault("log-format", "json")
 	viper.SetDefault("leader-elect", false)
 	viper.SetDefault("leader-election-name", "pingora-gateway-controller-leader")
+	viper.SetDefault("sync-debounce", controller.DefaultSyncDebounce)
+	viper.SetDefault("proxy-version-check-interval", controller.DefaultProxyVersionCheckInterval)
+	viper.SetDefault("proxy-health-interval", controller.DefaultProxyHealthInterval)
+	viper.SetDefault("proxy-unreachable-threshold", controller.DefaultProxyUnreachableThreshold)
+	viper.SetDefault("liveness-timeout", controller.DefaultLivenessTimeout)
+	viper.SetDefault("binding-debug-annotations", false)
+	viper.SetDefault("route-diagnostics-annotations", false)
+	viper.SetDefault("route-deletion-finalizer", false)
+	viper.SetDefault("route-traffic-metrics", false)
+	viper.SetDefault("require-parent-ref-grants", false)
+	viper.SetDefault("route-id-scheme", string(ingress.DefaultRouteIDScheme))
+	viper.SetDefault("dry-run", false)
+	viper.SetDefault("smoke-test-timeout", smoketest.DefaultTimeout)
+	viper.SetDefault("tracing-sample-ratio", tracing.DefaultSampleRatio)
+	viper.SetDefault("enable-webhook", false)
+	viper.SetDefault("webhook-port", webhook.DefaultPort)
+	viper.SetDefault("webhook-max-route-matches", webhook.DefaultMaxMatchesPerRule)
 }
 
 func Execute() error {
@@ -122,12 +199,57 @@ func runController(_ *cobra.Command, _ []string) error {
 	logger.Info("starting pingora-gateway-controller",
 		"version", version, "gitsha", gitsha)
 
+	routeIDScheme, err := ingress.ParseRouteIDScheme(viper.GetString("route-id-scheme"))
+	if err != nil {
+		return errors.Wrap(err, "invalid route ID scheme")
+	}
+
+	routeSelector, err := routeLabelSelector()
+	if err != nil {
+		return errors.Wrap(err, "invalid route label selector")
+	}
+
+	featureGates, err := controller.ParseFeatureGates(listValues("feature-gates"))
+	if err != nil {
+		return errors.Wrap(err, "invalid feature gates")
+	}
+
 	cfg := controller.Config{
 		ClusterDomain:    resolveClusterDomain(logger),
 		GatewayClassName: viper.GetString("gateway-class-name"),
 		ControllerName:   viper.GetString("controller-name"),
 		MetricsAddr:      viper.GetString("metrics-addr"),
 		HealthAddr:       viper.GetString("health-addr"),
+		SyncDebounce:     viper.GetDuration("sync-debounce"),
+
+		ProxyVersionCheckInterval:   viper.GetDuration("proxy-version-check-interval"),
+		ProxyHealthInterval:         viper.GetDuration("proxy-health-interval"),
+		ProxyUnreachableThreshold:   viper.GetDuration("proxy-unreachable-threshold"),
+		LivenessTimeout:             viper.GetDuration("liveness-timeout"),
+		BindingDebugAnnotations:     viper.GetBool("binding-debug-annotations"),
+		RouteDiagnosticsAnnotations: viper.GetBool("route-diagnostics-annotations"),
+		RouteDeletionFinalizer:      viper.GetBool("route-deletion-finalizer"),
+		RouteTrafficMetrics:         viper.GetBool("route-traffic-metrics"),
+		AdoptControllerNames:        adoptControllerNames(),
+		WatchNamespaces:             listValues("watch-namespaces"),
+		RouteLabelSelector:          routeSelector,
+		RequireParentRefGrants:      viper.GetBool("require-parent-ref-grants"),
+		FeatureGates:                featureGates,
+		RouteIDScheme:               routeIDScheme,
+		DryRun:                      viper.GetBool("dry-run"),
+
+		SmokeTestURL:     viper.GetString("smoke-test-url"),
+		SmokeTestAddress: viper.GetString("smoke-test-address"),
+		SmokeTestTimeout: viper.GetDuration("smoke-test-timeout"),
+
+		WebhookEnabled: viper.GetBool("enable-webhook"),
+		WebhookPort:    viper.GetInt("webhook-port"),
+		WebhookCertDir: viper.GetString("webhook-cert-dir"),
+
+		WebhookMaxRouteMatches: viper.GetInt("webhook-max-route-matches"),
+
+		// Keep detecting the domain at runtime unless it was configured explicitly
+		ClusterDomainAutoDetect: viper.GetString("cluster-domain") == "",
 
 		LeaderElect:     viper.GetBool("leader-elect"),
 		LeaderElectNS:   viper.GetString("leader-election-namespace"),
@@ -137,6 +259,26 @@ func runController(_ *cobra.Command, _ []string) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Endpoint:    viper.GetString("tracing-endpoint"),
+		Insecure:    viper.GetBool("tracing-insecure"),
+		SampleRatio: viper.GetFloat64("tracing-sample-ratio"),
+		Version:     version,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to set up tracing")
+	}
+
+	defer func() {
+		// Flush pending spans after the manager stopped
+		flushCtx, flushCancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer flushCancel()
+
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	if err := controller.Run(ctx, &cfg); err != nil {
 		return errors.Wrap(err, "failed to run controller")
 	}
@@ -144,6 +286,44 @@ func runController(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// adoptControllerNames returns the controller names to adopt.
+func adoptControllerNames() []string {
+	return listValues("adopt-controller-names")
+}
+
+// listValues returns the values of a list setting. Values may be
+// comma-separated, as environment variables such as
+// PINGORA_ADOPT_CONTROLLER_NAMES are read as a single string.
+func listValues(key string) []string {
+	var values []string
+
+	for _, value := range viper.GetStringSlice(key) {
+		for item := range strings.SplitSeq(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+
+	return values
+}
+
+// routeLabelSelector parses the route label selector. It returns nil if
+// no selector is configured, so that all routes are selected.
+func routeLabelSelector() (labels.Selector, error) {
+	value := strings.TrimSpace(viper.GetString("route-label-selector"))
+	if value == "" {
+		return nil, nil //nolint:nilnil // no selector means all routes
+	}
+
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %q", value)
+	}
+
+	return selector, nil
+}
+
 // resolveClusterDomain determines the cluster domain to use.
 // User-configured value takes precedence, then auto-detection,
 // finally falls back to default.
@@ -5,6 +5,11 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/controller"
+	"github.com/lexfrei/pingora-gateway-controller/internal/smoketest"
+	"github.com/lexfrei/pingora-gateway-controller/internal/webhook"
 )
 
 func TestSetVersion(t *testing.T) {
@@ -47,6 +52,21 @@ func TestInitConfig_Defaults(t *testing.T) {
 	assert.Equal(t, "json", viper.GetString("log-format"))
 	assert.False(t, viper.GetBool("leader-elect"))
 	assert.Equal(t, "pingora-gateway-controller-leader", viper.GetString("leader-election-name"))
+	assert.Equal(t, controller.DefaultSyncDebounce, viper.GetDuration("sync-debounce"))
+	assert.Equal(t, controller.DefaultProxyVersionCheckInterval, viper.GetDuration("proxy-version-check-interval"))
+	assert.Equal(t, controller.DefaultProxyHealthInterval, viper.GetDuration("proxy-health-interval"))
+	assert.Equal(t, controller.DefaultProxyUnreachableThreshold, viper.GetDuration("proxy-unreachable-threshold"))
+	assert.Equal(t, controller.DefaultLivenessTimeout, viper.GetDuration("liveness-timeout"))
+	assert.False(t, viper.GetBool("binding-debug-annotations"))
+	assert.False(t, viper.GetBool("route-diagnostics-annotations"))
+	assert.False(t, viper.GetBool("route-deletion-finalizer"))
+	assert.False(t, viper.GetBool("route-traffic-metrics"))
+	assert.False(t, viper.GetBool("require-parent-ref-grants"))
+	assert.Equal(t, "name", viper.GetString("route-id-scheme"))
+	assert.Equal(t, smoketest.DefaultTimeout, viper.GetDuration("smoke-test-timeout"))
+	assert.False(t, viper.GetBool("enable-webhook"))
+	assert.Equal(t, webhook.DefaultPort, viper.GetInt("webhook-port"))
+	assert.Equal(t, webhook.DefaultMaxMatchesPerRule, viper.GetInt("webhook-max-route-matches"))
 }
 
 func TestInitConfig_EnvPrefix(t *testing.T) {
@@ -140,6 +160,78 @@ func TestResolveClusterDomain_AutoDetect(t *testing.T) {
 	assert.NotEmpty(t, domain)
 }
 
+func TestAdoptControllerNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected []string
+	}{
+		{name: "unset", value: nil, expected: nil},
+		{name: "flag list", value: []string{"old.example.com/a", "old.example.com/b"},
+			expected: []string{"old.example.com/a", "old.example.com/b"}},
+		{name: "comma-separated env", value: "old.example.com/a, old.example.com/b,",
+			expected: []string{"old.example.com/a", "old.example.com/b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+
+			if tt.value != nil {
+				viper.Set("adopt-controller-names", tt.value)
+			}
+
+			assert.Equal(t, tt.expected, adoptControllerNames())
+		})
+	}
+}
+
+func TestWatchNamespaces(t *testing.T) {
+	viper.Reset()
+	viper.Set("watch-namespaces", "team-a,team-b")
+
+	assert.Equal(t, []string{"team-a", "team-b"}, listValues("watch-namespaces"))
+}
+
+func TestRouteLabelSelector(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectNil   bool
+		expectError bool
+	}{
+		{name: "unset", expectNil: true},
+		{name: "equality", value: "tier=canary", expected: "tier=canary"},
+		{name: "set based", value: " tenant in (a,b),!legacy ", expected: "!legacy,tenant in (a,b)"},
+		{name: "invalid", value: "tier in canary", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("route-label-selector", tt.value)
+
+			selector, err := routeLabelSelector()
+			if tt.expectError {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.expectNil {
+				assert.Nil(t, selector)
+
+				return
+			}
+
+			assert.Equal(t, tt.expected, selector.String())
+		})
+	}
+}
+
 func TestRootCmd_Flags(t *testing.T) {
 	// Test that all expected flags are registered
 	flags := rootCmd.Flags()
@@ -165,6 +257,18 @@ func TestRootCmd_Flags(t *testing.T) {
 	assert.NotNil(t, flag)
 	assert.Equal(t, ":8081", flag.DefValue)
 
+	flag = flags.Lookup("watch-namespaces")
+	assert.NotNil(t, flag)
+	assert.Equal(t, "[]", flag.DefValue)
+
+	flag = flags.Lookup("tracing-endpoint")
+	assert.NotNil(t, flag)
+	assert.Empty(t, flag.DefValue)
+
+	flag = flags.Lookup("tracing-sample-ratio")
+	assert.NotNil(t, flag)
+	assert.Equal(t, "1", flag.DefValue)
+
 	flag = flags.Lookup("leader-elect")
 	assert.NotNil(t, flag)
 	assert.Equal(t, "false", flag.DefValue)
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/protoschema"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// Output encodings for the schema command.
+const (
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+//nolint:gochecknoglobals // cobra command pattern
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the routing API schema",
+	Long: `Print the schema of the routing API between the controller and the proxy.
+
+The schema is generated from the compiled protobuf descriptors and describes
+the protobuf JSON mapping of every message, so routing configs can be
+validated offline by proxy implementations and tooling.`,
+	Args:          cobra.NoArgs,
+	RunE:          runSchema,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	schemaCmd.Flags().String("format", protoschema.FormatJSONSchema, "Schema format (jsonschema, openapi)")
+	schemaCmd.Flags().String("output", outputJSON, "Output encoding (json, yaml)")
+
+	rootCmd.AddCommand(schemaCmd)
+}
+
+func runSchema(cmd *cobra.Command, _ []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+
+	data, err := renderSchema(format, output)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.OutOrStdout().Write(data)
+
+	return errors.Wrap(err, "failed to write schema")
+}
+
+// renderSchema builds the routing API schema in the given format and encoding.
+func renderSchema(format, output string) ([]byte, error) {
+	var schema protoschema.Schema
+
+	switch format {
+	case protoschema.FormatJSONSchema:
+		schema = protoschema.JSONSchema(routingv1.File_routing_v1_routing_proto)
+	case protoschema.FormatOpenAPI:
+		schema = protoschema.OpenAPI(routingv1.File_routing_v1_routing_proto, version)
+	default:
+		return nil, errors.Newf("unsupported schema format %q", format)
+	}
+
+	switch output {
+	case outputJSON:
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode schema")
+		}
+
+		return append(data, '\n'), nil
+	case outputYAML:
+		data, err := yaml.Marshal(schema)
+
+		return data, errors.Wrap(err, "failed to encode schema")
+	default:
+		return nil, errors.Newf("unsupported output encoding %q", output)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestRenderSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		output   string
+		topLevel string
+	}{
+		{name: "json schema as json", format: "jsonschema", output: "json", topLevel: "$defs"},
+		{name: "json schema as yaml", format: "jsonschema", output: "yaml", topLevel: "$defs"},
+		{name: "openapi as json", format: "openapi", output: "json", topLevel: "components"},
+		{name: "openapi as yaml", format: "openapi", output: "yaml", topLevel: "components"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := renderSchema(tt.format, tt.output)
+			require.NoError(t, err)
+
+			var decoded map[string]any
+			if tt.output == "yaml" {
+				require.NoError(t, yaml.Unmarshal(data, &decoded))
+			} else {
+				require.NoError(t, json.Unmarshal(data, &decoded))
+			}
+
+			assert.Contains(t, decoded, tt.topLevel)
+		})
+	}
+}
+
+func TestRenderSchema_Invalid(t *testing.T) {
+	_, err := renderSchema("protobuf", "json")
+	require.Error(t, err)
+
+	_, err = renderSchema("jsonschema", "toml")
+	require.Error(t, err)
+}
+
+func TestSchemaCmd_Registered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"schema"})
+	require.NoError(t, err)
+	assert.Equal(t, schemaCmd, cmd)
+
+	flag := cmd.Flags().Lookup("format")
+	require.NotNil(t, flag)
+	assert.Equal(t, "jsonschema", flag.DefValue)
+}
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+var (
+	errProxyDrift       = errors.New("proxy routes differ from the cluster")
+	errProxyUnavailable = errors.New("proxy state could not be read")
+)
+
+//nolint:gochecknoglobals // cobra command pattern
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Compare the routes served by the proxy with the cluster",
+	Long: `Connect to the proxy configured by the PingoraConfig of the GatewayClass,
+read its health and routes, and compare them with the route config built from
+the cluster, as the render command prints it.
+
+The report lists routes missing on the proxy, routes that differ and routes
+the cluster no longer has. The command fails if the proxy cannot be read or
+its routes differ, so it can gate CI jobs and rollouts.
+
+The cluster is selected from KUBECONFIG or the in-cluster configuration. The
+proxy address of the PingoraConfig usually resolves inside the cluster only;
+use --address with a port-forward to reach it from elsewhere.`,
+	Args:          cobra.NoArgs,
+	RunE:          runStatus,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	statusCmd.Flags().String("address", "", "Proxy gRPC address (host:port) overriding the PingoraConfig address")
+	statusCmd.Flags().String("namespace", "default", "Namespace for Secret lookups of the PingoraConfig")
+	statusCmd.Flags().String("gateway-class-name", "pingora", "GatewayClass whose proxy is checked")
+	statusCmd.Flags().String("cluster-domain", dns.DefaultClusterDomain, "Kubernetes cluster domain for backend addresses")
+	statusCmd.Flags().String("route-id-scheme", string(ingress.DefaultRouteIDScheme),
+		"Scheme for route IDs sent to the proxy (name, uid, hash)")
+
+	rootCmd.AddCommand(statusCmd)
+}
+
+func runStatus(cmd *cobra.Command, _ []string) error {
+	ctx := cmd.Context()
+	address, _ := cmd.Flags().GetString("address")
+
+	opts := renderOptions{}
+	opts.namespace, _ = cmd.Flags().GetString("namespace")
+	opts.gatewayClassName, _ = cmd.Flags().GetString("gateway-class-name")
+	opts.clusterDomain, _ = cmd.Flags().GetString("cluster-domain")
+
+	routeIDScheme, _ := cmd.Flags().GetString("route-id-scheme")
+
+	var err error
+
+	opts.routeIDScheme, err = ingress.ParseRouteIDScheme(routeIDScheme)
+	if err != nil {
+		return errors.Wrap(err, "invalid route ID scheme")
+	}
+
+	scheme, err := renderScheme()
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load kubeconfig")
+	}
+
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.Wrap(err, "failed to create client")
+	}
+
+	desired, err := renderRoutes(ctx, k8sClient, scheme, opts, cmd.ErrOrStderr())
+	if err != nil {
+		return err
+	}
+
+	resolver := config.NewPingoraResolver(k8sClient, opts.namespace)
+
+	resolved, err := resolver.ResolveFromGatewayClassName(ctx, opts.gatewayClassName)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve Pingora config")
+	}
+
+	if address != "" {
+		resolved.Address = address
+	}
+
+	conn, err := resolver.CreateGRPCConnection(ctx, resolved)
+	if err != nil {
+		return errors.Wrap(err, "failed to create gRPC connection")
+	}
+
+	defer func() { _ = conn.Close() }()
+
+	report := collectStatus(ctx, resolver.CreateRoutingClient(conn), resolved.RequestTimeout, desired)
+	report.configName = resolved.ConfigName
+	report.address = resolved.Address
+	report.tls = resolved.TLSEnabled || resolved.SPIFFEEnabled
+
+	var pingoraConfig v1alpha1.PingoraConfig
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: resolved.ConfigName}, &pingoraConfig); err == nil {
+		report.controllerVersion = pingoraConfig.Status.ConfigVersion
+	}
+
+	writeStatusReport(cmd.OutOrStdout(), report)
+
+	return report.err()
+}
+
+// statusReport is the state of the proxy compared with the cluster.
+type statusReport struct {
+	configName string
+	address    string
+	tls        bool
+
+	// controllerVersion is the last version sent by the controller, from
+	// the PingoraConfig status.
+	controllerVersion uint64
+
+	health    *routingv1.HealthResponse
+	healthErr error
+
+	routes    *routingv1.GetRoutesResponse
+	routesErr error
+
+	desired *routingv1.UpdateRoutesRequest
+	drift   routeDrift
+}
+
+// err returns an error if the proxy could not be read or its routes
+// differ from the desired ones.
+func (r *statusReport) err() error {
+	switch {
+	case r.healthErr != nil || r.routesErr != nil:
+		return errProxyUnavailable
+	case !r.drift.empty():
+		return errProxyDrift
+	default:
+		return nil
+	}
+}
+
+// collectStatus reads the health and routes of the proxy and compares the
+// routes with desired. Each call is bounded by timeout, zero leaves them
+// unbounded.
+func collectStatus(
+	ctx context.Context,
+	routingClient routingv1.RoutingServiceClient,
+	timeout time.Duration,
+	desired *routingv1.UpdateRoutesRequest,
+) statusReport {
+	report := statusReport{desired: desired}
+
+	call := func(fn func(ctx context.Context) error) error {
+		if timeout <= 0 {
+			return fn(ctx)
+		}
+
+		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return fn(callCtx)
+	}
+
+	report.healthErr = call(func(ctx context.Context) error {
+		var err error
+
+		report.health, err = routingClient.Health(ctx, &routingv1.HealthRequest{})
+
+		return err //nolint:wrapcheck // reported as is
+	})
+
+	report.routesErr = call(func(ctx context.Context) error {
+		var err error
+
+		report.routes, err = routingClient.GetRoutes(ctx, &routingv1.GetRoutesRequest{})
+
+		return err //nolint:wrapcheck // reported as is
+	})
+
+	if report.routesErr == nil {
+		report.drift = diffRoutes(desired, report.routes)
+	}
+
+	return report
+}
+
+// routeDrift lists the differences between the desired routes and the
+// routes served by the proxy, as "Kind namespace/name" entries.
+type routeDrift struct {
+	// missing routes are desired but not served by the proxy.
+	missing []string
+	// changed routes are served with a different config.
+	changed []string
+	// extra routes are served by the proxy but no longer desired.
+	extra []string
+	// listeners is true if the listener settings differ.
+	listeners bool
+}
+
+func (d *routeDrift) empty() bool {
+	return len(d.missing) == 0 && len(d.changed) == 0 && len(d.extra) == 0 && !d.listeners
+}
+
+// diffRoutes compares the desired routes with the routes of the proxy by
+// route ID.
+func diffRoutes(desired *routingv1.UpdateRoutesRequest, actual *routingv1.GetRoutesResponse) routeDrift {
+	var drift routeDrift
+
+	diffByID(&drift, "HTTPRoute", desired.GetHttpRoutes(), actual.GetHttpRoutes())
+	diffByID(&drift, "GRPCRoute", desired.GetGrpcRoutes(), actual.GetGrpcRoutes())
+
+	drift.listeners = !slices.EqualFunc(desired.GetListeners(), actual.GetListeners(),
+		func(a, b *routingv1.Listener) bool { return proto.Equal(a, b) })
+
+	return drift
+}
+
+// identifiedRoute is a route message with an ID.
+type identifiedRoute interface {
+	proto.Message
+	GetId() string
+}
+
+func diffByID[T identifiedRoute](drift *routeDrift, kind string, desired, actual []T) {
+	served := make(map[string]T, len(actual))
+	for _, route := range actual {
+		served[route.GetId()] = route
+	}
+
+	for _, route := range desired {
+		id := route.GetId()
+
+		servedRoute, ok := served[id]
+
+		switch {
+		case !ok:
+			drift.missing = append(drift.missing, kind+" "+id)
+		case !proto.Equal(route, servedRoute):
+			drift.changed = append(drift.changed, kind+" "+id)
+		}
+
+		delete(served, id)
+	}
+
+	extra := make([]string, 0, len(served))
+	for id := range served {
+		extra = append(extra, kind+" "+id)
+	}
+
+	slices.Sort(extra)
+	drift.extra = append(drift.extra, extra...)
+}
+
+// writeStatusReport prints the report for humans.
+func writeStatusReport(w io.Writer, r statusReport) {
+	transport := "plaintext"
+	if r.tls {
+		transport = "TLS"
+	}
+
+	fmt.Fprintf(w, "PingoraConfig:   %s\n", r.configName)
+	fmt.Fprintf(w, "Proxy address:   %s (%s)\n", r.address, transport)
+
+	switch {
+	case r.healthErr != nil:
+		fmt.Fprintf(w, "Health:          unreachable: %v\n", r.healthErr)
+	case r.health.GetHealthy():
+		fmt.Fprintf(w, "Health:          healthy (%s)\n", r.health.GetStatus())
+	default:
+		fmt.Fprintf(w, "Health:          unhealthy (%s)\n", r.health.GetStatus())
+	}
+
+	fmt.Fprintf(w, "Config version:  proxy %d, controller %d\n", r.routes.GetVersion(), r.controllerVersion)
+	fmt.Fprintf(w, "Desired routes:  %d HTTP, %d gRPC\n",
+		len(r.desired.GetHttpRoutes()), len(r.desired.GetGrpcRoutes()))
+
+	if r.routesErr != nil {
+		fmt.Fprintf(w, "Proxy routes:    unavailable: %v\n", r.routesErr)
+
+		return
+	}
+
+	fmt.Fprintf(w, "Proxy routes:    %d HTTP, %d gRPC\n",
+		len(r.routes.GetHttpRoutes()), len(r.routes.GetGrpcRoutes()))
+
+	if r.drift.empty() {
+		fmt.Fprintln(w, "\nIn sync: the proxy serves the desired routes")
+
+		return
+	}
+
+	fmt.Fprintln(w, "\nDrift:")
+
+	for _, route := range r.drift.missing {
+		fmt.Fprintf(w, "  missing  %s (not synced to the proxy)\n", route)
+	}
+
+	for _, route := range r.drift.changed {
+		fmt.Fprintf(w, "  changed  %s\n", route)
+	}
+
+	for _, route := range r.drift.extra {
+		fmt.Fprintf(w, "  extra    %s (not in the cluster)\n", route)
+	}
+
+	if r.drift.listeners {
+		fmt.Fprintln(w, "  changed  listener settings")
+	}
+}
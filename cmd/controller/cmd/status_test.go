@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+func TestDiffRoutes(t *testing.T) {
+	desired := &routingv1.UpdateRoutesRequest{
+		HttpRoutes: []*routingv1.HTTPRoute{
+			{Id: "default/app", Hostnames: []string{"app.example.com"}},
+			{Id: "default/new"},
+			{Id: "default/web", Hostnames: []string{"web.example.com"}},
+		},
+		GrpcRoutes: []*routingv1.GRPCRoute{{Id: "default/grpc"}},
+	}
+
+	tests := []struct {
+		name     string
+		actual   *routingv1.GetRoutesResponse
+		expected routeDrift
+	}{
+		{
+			name: "in sync",
+			actual: &routingv1.GetRoutesResponse{
+				HttpRoutes: []*routingv1.HTTPRoute{
+					{Id: "default/web", Hostnames: []string{"web.example.com"}},
+					{Id: "default/new"},
+					{Id: "default/app", Hostnames: []string{"app.example.com"}},
+				},
+				GrpcRoutes: []*routingv1.GRPCRoute{{Id: "default/grpc"}},
+			},
+		},
+		{
+			name: "drift",
+			actual: &routingv1.GetRoutesResponse{
+				HttpRoutes: []*routingv1.HTTPRoute{
+					{Id: "default/app", Hostnames: []string{"old.example.com"}},
+					{Id: "default/web", Hostnames: []string{"web.example.com"}},
+					{Id: "default/old"},
+				},
+				GrpcRoutes: []*routingv1.GRPCRoute{{Id: "default/grpc"}},
+				Listeners:  []*routingv1.Listener{{Port: 443}},
+			},
+			expected: routeDrift{
+				missing:   []string{"HTTPRoute default/new"},
+				changed:   []string{"HTTPRoute default/app"},
+				extra:     []string{"HTTPRoute default/old"},
+				listeners: true,
+			},
+		},
+		{
+			name:   "empty proxy",
+			actual: &routingv1.GetRoutesResponse{},
+			expected: routeDrift{
+				missing: []string{
+					"HTTPRoute default/app",
+					"HTTPRoute default/new",
+					"HTTPRoute default/web",
+					"GRPCRoute default/grpc",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drift := diffRoutes(desired, tt.actual)
+			assert.Equal(t, tt.expected, drift)
+		})
+	}
+}
+
+func TestCollectStatus(t *testing.T) {
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	routingClient := routingv1.NewRoutingServiceClient(conn)
+	ctx := context.Background()
+
+	desired := &routingv1.UpdateRoutesRequest{
+		HttpRoutes: []*routingv1.HTTPRoute{{Id: "default/app"}, {Id: "default/web"}},
+	}
+
+	_, err = routingClient.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+		Version:    7,
+		HttpRoutes: []*routingv1.HTTPRoute{{Id: "default/app"}},
+	})
+	require.NoError(t, err)
+
+	report := collectStatus(ctx, routingClient, time.Second, desired)
+	report.configName = "pingora"
+	report.address = addr
+	report.controllerVersion = 7
+
+	require.NoError(t, report.healthErr)
+	require.NoError(t, report.routesErr)
+	assert.Equal(t, []string{"HTTPRoute default/web"}, report.drift.missing)
+	require.ErrorIs(t, report.err(), errProxyDrift)
+
+	var out bytes.Buffer
+
+	writeStatusReport(&out, report)
+	assert.Contains(t, out.String(), "Proxy address:   "+addr+" (plaintext)")
+	assert.Contains(t, out.String(), "Health:          healthy (ok)")
+	assert.Contains(t, out.String(), "Config version:  proxy 7, controller 7")
+	assert.Contains(t, out.String(), "missing  HTTPRoute default/web (not synced to the proxy)")
+
+	// A synced proxy reports no drift
+	_, err = routingClient.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 8, HttpRoutes: desired.GetHttpRoutes()})
+	require.NoError(t, err)
+
+	report = collectStatus(ctx, routingClient, time.Second, desired)
+	require.NoError(t, report.err())
+
+	out.Reset()
+	writeStatusReport(&out, report)
+	assert.Contains(t, out.String(), "In sync")
+
+	// An unreachable proxy fails the command
+	proxy.Stop()
+
+	report = collectStatus(ctx, routingClient, time.Second, desired)
+	require.ErrorIs(t, report.err(), errProxyUnavailable)
+
+	out.Reset()
+	writeStatusReport(&out, report)
+	assert.Contains(t, out.String(), "Health:          unreachable")
+	assert.Contains(t, out.String(), "Proxy routes:    unavailable")
+}
+
+func TestStatusCmd_Registered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"status"})
+	require.NoError(t, err)
+	assert.Equal(t, statusCmd, cmd)
+	assert.NotNil(t, cmd.Flags().Lookup("address"))
+}
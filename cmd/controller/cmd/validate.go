@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	"github.com/lexfrei/pingora-gateway-controller/internal/webhook"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+var (
+	errInvalidRoutes = errors.New("route manifests are invalid")
+	errRouteWarnings = errors.New("route manifests have warnings")
+)
+
+//nolint:gochecknoglobals // cobra command pattern
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check route manifests for routes the proxy cannot serve",
+	Long: `Check HTTPRoutes and GRPCRoutes in manifests without a cluster, using the
+checks of the validating webhook and the route binding of the controller.
+
+Routes are checked against the Gateways in the same manifests. A route fails
+if it does not bind to a listener of its parent Gateway, has an invalid
+regular expression, too many matches in a rule or invalid timeouts. Filters
+the proxy ignores produce warnings. Routes without a parent Gateway of the
+GatewayClass in the manifests are reported and skipped.
+
+The command fails if any route fails, or with --strict if any route has a
+warning, so it can gate CI pipelines before merge.`,
+	Args:          cobra.NoArgs,
+	RunE:          runValidate,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	validateCmd.Flags().StringSliceP("filename", "f", nil,
+		"Manifest files or directories to check (- reads standard input)")
+	validateCmd.Flags().String("namespace", "default", "Namespace of manifests without one")
+	validateCmd.Flags().String("gateway-class-name", "pingora", "GatewayClass whose routes are checked")
+	validateCmd.Flags().Int("max-route-matches", webhook.DefaultMaxMatchesPerRule,
+		"Maximum matches in a single route rule (0 disables the limit)")
+	validateCmd.Flags().Bool("strict", false, "Fail on warnings too")
+
+	_ = validateCmd.MarkFlagRequired("filename")
+
+	rootCmd.AddCommand(validateCmd)
+}
+
+func runValidate(cmd *cobra.Command, _ []string) error {
+	filenames, _ := cmd.Flags().GetStringSlice("filename")
+	namespace, _ := cmd.Flags().GetString("namespace")
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	opts := validateOptions{}
+	opts.gatewayClassName, _ = cmd.Flags().GetString("gateway-class-name")
+	opts.maxMatchesPerRule, _ = cmd.Flags().GetInt("max-route-matches")
+
+	scheme, err := renderScheme()
+	if err != nil {
+		return err
+	}
+
+	objects, err := loadManifests(filenames, cmd.InOrStdin(), scheme, namespace)
+	if err != nil {
+		return err
+	}
+
+	k8sClient, err := newManifestClient(scheme, objects)
+	if err != nil {
+		return err
+	}
+
+	results, err := validateRoutes(cmd.Context(), k8sClient, objects, opts)
+	if err != nil {
+		return err
+	}
+
+	writeValidationResults(cmd.OutOrStdout(), results)
+
+	return validationError(results, strict)
+}
+
+// validateOptions configures the route checks of the validate command.
+type validateOptions struct {
+	gatewayClassName  string
+	maxMatchesPerRule int
+}
+
+// routeFinding is a problem found in a route.
+type routeFinding struct {
+	severity string
+	message  string
+}
+
+// routeValidation is the result of checking a single route.
+type routeValidation struct {
+	// route is the "Kind namespace/name" of the route.
+	route string
+	// skipped is true if the route has no parent Gateway of the class.
+	skipped  bool
+	findings []routeFinding
+}
+
+func (r *routeValidation) add(severity, message string) {
+	r.findings = append(r.findings, routeFinding{severity: severity, message: message})
+}
+
+func (r *routeValidation) count(severity string) int {
+	count := 0
+
+	for _, finding := range r.findings {
+		if finding.severity == severity {
+			count++
+		}
+	}
+
+	return count
+}
+
+// validateRoutes checks the HTTPRoutes and GRPCRoutes in objects. Gateways
+// and other resources the checks need are read from c.
+func validateRoutes(
+	ctx context.Context,
+	c client.Client,
+	objects []client.Object,
+	opts validateOptions,
+) ([]routeValidation, error) {
+	validator := &webhook.RouteValidator{
+		Client:            c,
+		GatewayClassName:  opts.gatewayClassName,
+		MaxMatchesPerRule: opts.maxMatchesPerRule,
+	}
+	binder := routebinding.NewValidator(c)
+
+	var results []routeValidation
+
+	for _, obj := range objects {
+		var (
+			info    routebinding.RouteInfo
+			parents []gatewayv1.ParentReference
+		)
+
+		switch route := obj.(type) {
+		case *gatewayv1.HTTPRoute:
+			info.Kind, info.Hostnames, parents = routebinding.KindHTTPRoute, route.Spec.Hostnames, route.Spec.ParentRefs
+		case *gatewayv1.GRPCRoute:
+			info.Kind, info.Hostnames, parents = routebinding.KindGRPCRoute, route.Spec.Hostnames, route.Spec.ParentRefs
+		default:
+			continue
+		}
+
+		info.Name, info.Namespace = obj.GetName(), obj.GetNamespace()
+
+		result := routeValidation{route: string(info.Kind) + " " + info.Namespace + "/" + info.Name}
+
+		attached, err := checkBindings(ctx, c, binder, &result, info, parents, opts.gatewayClassName)
+		if err != nil {
+			return nil, err
+		}
+
+		if !attached {
+			result.skipped = true
+			results = append(results, result)
+
+			continue
+		}
+
+		warnings, err := validator.ValidateCreate(ctx, obj)
+		for _, warning := range warnings {
+			result.add(severityWarning, warning)
+		}
+
+		if err != nil {
+			addValidationErrors(&result, err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// checkBindings checks the binding of the route to every parent Gateway of
+// the class and reports whether there is one.
+func checkBindings(
+	ctx context.Context,
+	c client.Reader,
+	binder *routebinding.Validator,
+	result *routeValidation,
+	info routebinding.RouteInfo,
+	parents []gatewayv1.ParentReference,
+	gatewayClassName string,
+) (bool, error) {
+	attached := false
+
+	for _, ref := range parents {
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+
+		namespace := info.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		gatewayName := namespace + "/" + string(ref.Name)
+
+		var gateway gatewayv1.Gateway
+
+		err := c.Get(ctx, client.ObjectKey{Name: string(ref.Name), Namespace: namespace}, &gateway)
+		if apierrors.IsNotFound(err) {
+			result.add(severityWarning, "parent Gateway "+gatewayName+" is not in the manifests")
+
+			continue
+		}
+
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to get Gateway %s", gatewayName)
+		}
+
+		if string(gateway.Spec.GatewayClassName) != gatewayClassName {
+			continue
+		}
+
+		attached = true
+		info.SectionName = ref.SectionName
+
+		binding, err := binder.ValidateBinding(ctx, &gateway, &info)
+		if err != nil {
+			result.add(severityError, fmt.Sprintf("binding to Gateway %s could not be checked: %v", gatewayName, err))
+
+			continue
+		}
+
+		if !binding.Accepted {
+			result.add(severityError, fmt.Sprintf("not accepted by Gateway %s: %s: %s",
+				gatewayName, binding.Reason, binding.Message))
+		}
+	}
+
+	return attached, nil
+}
+
+// addValidationErrors records the field errors of an Invalid error from the
+// webhook validator, or the error itself.
+func addValidationErrors(result *routeValidation, err error) {
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil || len(status.Status().Details.Causes) == 0 {
+		result.add(severityError, err.Error())
+
+		return
+	}
+
+	for _, cause := range status.Status().Details.Causes {
+		result.add(severityError, cause.Field+": "+cause.Message)
+	}
+}
+
+// writeValidationResults prints the results for humans.
+func writeValidationResults(w io.Writer, results []routeValidation) {
+	var invalid, warned, skipped int
+
+	for i := range results {
+		result := &results[i]
+
+		switch {
+		case result.skipped:
+			skipped++
+
+			fmt.Fprintf(w, "%s: skipped, no parent Gateway of the GatewayClass\n", result.route)
+		case len(result.findings) == 0:
+			fmt.Fprintf(w, "%s: valid\n", result.route)
+		case result.count(severityError) > 0:
+			invalid++
+
+			fmt.Fprintf(w, "%s: invalid\n", result.route)
+		default:
+			warned++
+
+			fmt.Fprintf(w, "%s: valid with warnings\n", result.route)
+		}
+
+		for _, finding := range result.findings {
+			fmt.Fprintf(w, "  %-8s %s\n", finding.severity, finding.message)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d routes checked: %d invalid, %d with warnings, %d skipped\n",
+		len(results), invalid, warned, skipped)
+}
+
+// validationError returns an error if any route is invalid, or with strict
+// if any route has a warning.
+func validationError(results []routeValidation, strict bool) error {
+	warned := false
+
+	for i := range results {
+		if results[i].count(severityError) > 0 {
+			return errInvalidRoutes
+		}
+
+		if results[i].count(severityWarning) > 0 {
+			warned = true
+		}
+	}
+
+	if strict && warned {
+		return errRouteWarnings
+	}
+
+	return nil
+}
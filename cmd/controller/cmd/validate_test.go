@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validateTestManifests = `apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: web
+spec:
+  gatewayClassName: pingora
+  listeners:
+    - name: http
+      protocol: HTTP
+      port: 80
+      hostname: "*.example.com"
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: other
+spec:
+  gatewayClassName: istio
+  listeners:
+    - name: http
+      protocol: HTTP
+      port: 80
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: valid
+spec:
+  parentRefs:
+    - name: web
+  hostnames:
+    - app.example.com
+  rules:
+    - backendRefs:
+        - name: app
+          port: 8080
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: bad-regex
+spec:
+  parentRefs:
+    - name: web
+  rules:
+    - matches:
+        - path:
+            type: RegularExpression
+            value: "/(a"
+      filters:
+        - type: ExtensionRef
+          extensionRef:
+            group: example.com
+            kind: Filter
+            name: custom
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: wrong-host
+spec:
+  parentRefs:
+    - name: web
+  hostnames:
+    - app.example.org
+---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: foreign
+spec:
+  parentRefs:
+    - name: other
+    - name: missing
+`
+
+func TestValidateRoutes(t *testing.T) {
+	scheme, err := renderScheme()
+	require.NoError(t, err)
+
+	objects, err := loadManifests([]string{stdinFilename}, strings.NewReader(validateTestManifests), scheme, "default")
+	require.NoError(t, err)
+
+	k8sClient, err := newManifestClient(scheme, objects)
+	require.NoError(t, err)
+
+	results, err := validateRoutes(context.Background(), k8sClient, objects, validateOptions{
+		gatewayClassName:  "pingora",
+		maxMatchesPerRule: 32,
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	tests := []struct {
+		route            string
+		expectedSkipped  bool
+		expectedErrors   int
+		expectedWarnings int
+		expectedMessage  string
+	}{
+		{route: "HTTPRoute default/valid"},
+		{
+			route:            "HTTPRoute default/bad-regex",
+			expectedErrors:   1,
+			expectedWarnings: 1,
+			expectedMessage:  "invalid regular expression",
+		},
+		{
+			route:           "HTTPRoute default/wrong-host",
+			expectedErrors:  1,
+			expectedMessage: "not accepted by Gateway default/web: NoMatchingListenerHostname",
+		},
+		{
+			route:            "HTTPRoute default/foreign",
+			expectedSkipped:  true,
+			expectedWarnings: 1,
+			expectedMessage:  "parent Gateway default/missing is not in the manifests",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			result := results[i]
+
+			assert.Equal(t, tt.route, result.route)
+			assert.Equal(t, tt.expectedSkipped, result.skipped)
+			assert.Equal(t, tt.expectedErrors, result.count(severityError))
+			assert.Equal(t, tt.expectedWarnings, result.count(severityWarning))
+
+			if tt.expectedMessage != "" {
+				var messages []string
+				for _, finding := range result.findings {
+					messages = append(messages, finding.message)
+				}
+
+				assert.Contains(t, strings.Join(messages, "\n"), tt.expectedMessage)
+			}
+		})
+	}
+
+	require.ErrorIs(t, validationError(results, false), errInvalidRoutes)
+	require.NoError(t, validationError(results[:1], true))
+	require.NoError(t, validationError(results[3:], false))
+	require.ErrorIs(t, validationError(results[3:], true), errRouteWarnings)
+
+	var out bytes.Buffer
+
+	writeValidationResults(&out, results)
+	assert.Contains(t, out.String(), "HTTPRoute default/valid: valid\n")
+	assert.Contains(t, out.String(), "HTTPRoute default/bad-regex: invalid\n")
+	assert.Contains(t, out.String(), "  error    spec.rules[0].matches[0].path.value: ")
+	assert.Contains(t, out.String(), "HTTPRoute default/foreign: skipped")
+	assert.Contains(t, out.String(), "4 routes checked: 2 invalid, 0 with warnings, 1 skipped")
+}
+
+func TestValidateCmd_Registered(t *testing.T) {
+	cmd, _, err := rootCmd.Find([]string{"validate"})
+	require.NoError(t, err)
+	assert.Equal(t, validateCmd, cmd)
+
+	flag := cmd.Flags().ShorthandLookup("f")
+	require.NotNil(t, flag)
+	assert.Equal(t, "filename", flag.Name)
+}
@@ -0,0 +1,119 @@
+// Command loadgen soak-tests the controller by churning synthetic HTTPRoutes
+// in a cluster and asserting on the convergence lag of their status.
+//
+// It is a development tool and is not shipped in the controller image.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/loadgen"
+)
+
+const defaultControllerName = "pingora.k8s.lex.la/gateway-controller"
+
+func main() {
+	if err := newCommand().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func newCommand() *cobra.Command {
+	var (
+		cfg        loadgen.Config
+		thresholds loadgen.Thresholds
+	)
+
+	cmd := &cobra.Command{
+		Use:   "loadgen",
+		Short: "Soak-test the controller with HTTPRoute churn",
+		Long: `Create, update and delete synthetic HTTPRoutes at a fixed rate and measure
+how long the controller takes to report each written generation in the route
+status. The run fails when the measured lag or error counts exceed the
+configured thresholds.
+
+The cluster is selected from KUBECONFIG or the in-cluster configuration.`,
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return run(cmd.Context(), cfg, thresholds)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&cfg.Namespace, "namespace", "pingora-loadgen", "Namespace for generated routes")
+	flags.StringVar(&cfg.GatewayName, "gateway", "", "Name of the parent Gateway (required)")
+	flags.StringVar(&cfg.GatewayNamespace, "gateway-namespace", "", "Namespace of the parent Gateway (defaults to --namespace)")
+	flags.StringVar(&cfg.ControllerName, "controller-name", defaultControllerName, "Controller name reported in route status")
+	flags.IntVar(&cfg.Routes, "routes", loadgen.DefaultRoutes, "Maximum number of generated routes at once")
+	flags.Float64Var(&cfg.Rate, "rate", loadgen.DefaultRate, "Write operations per second")
+	flags.DurationVar(&cfg.Duration, "duration", loadgen.DefaultDuration, "How long to generate churn")
+	flags.DurationVar(&cfg.PollInterval, "poll-interval", loadgen.DefaultPollInterval, "Interval for checking route status")
+	flags.DurationVar(&cfg.SettleTimeout, "settle-timeout", loadgen.DefaultSettleTimeout,
+		"How long to wait for pending routes after churn stops")
+	flags.StringVar(&cfg.BackendService, "backend-service", "", "Service referenced by generated routes (required)")
+	flags.Int32Var(&cfg.BackendPort, "backend-port", loadgen.DefaultBackendPort, "Service port referenced by generated routes")
+	flags.StringVar(&cfg.HostSuffix, "host-suffix", loadgen.DefaultHostSuffix, "Suffix of generated hostnames")
+	flags.Uint64Var(&cfg.Seed, "seed", 0, "Seed for the operation sequence (0 picks a random seed)")
+	flags.BoolVar(&cfg.Cleanup, "cleanup", true, "Delete generated routes at the end of the run")
+
+	flags.DurationVar(&thresholds.MaxP99Lag, "max-p99-lag", loadgen.DefaultMaxP99Lag,
+		"Fail if the p99 convergence lag exceeds this value (0 disables)")
+	flags.IntVar(&thresholds.MaxUnconverged, "max-unconverged", 0, "Fail if more routes did not converge")
+	flags.IntVar(&thresholds.MaxErrors, "max-errors", 0, "Fail if more API calls failed")
+
+	_ = cmd.MarkFlagRequired("gateway")
+	_ = cmd.MarkFlagRequired("backend-service")
+
+	return cmd
+}
+
+func run(ctx context.Context, cfg loadgen.Config, thresholds loadgen.Thresholds) error {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	scheme := runtime.NewScheme()
+	if err := gatewayv1.Install(scheme); err != nil {
+		return errors.Wrap(err, "failed to register gateway api types")
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return errors.Wrap(err, "failed to load kubeconfig")
+	}
+
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return errors.Wrap(err, "failed to create client")
+	}
+
+	generator, err := loadgen.New(k8sClient, cfg, logger)
+	if err != nil {
+		return errors.Wrap(err, "invalid configuration")
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	report, runErr := generator.Run(ctx)
+	logger.Info("soak test finished", "report", report.String())
+
+	if runErr != nil {
+		return runErr
+	}
+
+	return report.Check(thresholds)
+}
@@ -3,20 +3,23 @@ package config
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
 	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
 )
 
@@ -27,11 +30,27 @@ const (
 	PingoraParametersRefKind = "PingoraConfig"
 )
 
+// ErrInvalidConfig marks errors caused by a PingoraConfig that fails validation.
+var ErrInvalidConfig = errors.New("invalid PingoraConfig")
+
+// ErrInvalidCertificate marks errors caused by TLS certificates in the
+// PingoraConfig Secret that cannot be loaded.
+var ErrInvalidCertificate = errors.New("invalid TLS certificate")
+
 // ResolvedPingoraConfig contains all configuration resolved from PingoraConfig and Secrets.
 type ResolvedPingoraConfig struct {
 	// gRPC endpoint address
 	Address string
 
+	// Proxy instances to fan out to, see ResolveInstances
+	DiscoveryEnabled        bool
+	DiscoveryResolveAddress bool
+	DiscoveryAddresses      []string
+
+	// Proxy pods to watch for restarts, nil selector if not set
+	ProxyNamespace string
+	ProxySelector  labels.Selector
+
 	// TLS configuration
 	TLSEnabled            bool
 	TLSCert               []byte
@@ -40,6 +59,11 @@ type ResolvedPingoraConfig struct {
 	TLSInsecureSkipVerify bool
 	TLSServerName         string
 
+	// SPIFFE Workload API, replacing the TLS certificates if enabled
+	SPIFFEEnabled    bool
+	SPIFFESocketPath string
+	SPIFFEServerID   string
+
 	// Connection parameters
 	ConnectTimeout time.Duration
 	RequestTimeout time.Duration
@@ -53,8 +77,14 @@ type ResolvedPingoraConfig struct {
 
 // PingoraResolver resolves PingoraConfig from GatewayClass parametersRef.
 type PingoraResolver struct {
+	// Metrics, if set, records retries of calls on created connections.
+	Metrics metrics.Collector
+
 	client           client.Client
 	defaultNamespace string
+
+	// lookupHost resolves proxy instances, net.DefaultResolver.LookupHost if nil.
+	lookupHost func(ctx context.Context, host string) ([]string, error)
 }
 
 // NewPingoraResolver creates a new PingoraResolver.
@@ -112,9 +142,12 @@ func (r *PingoraResolver) ResolveFromGatewayClassName(
 
 //nolint:funcorder // private helper
 func (r *PingoraResolver) resolveConfig(ctx context.Context, config *v1alpha1.PingoraConfig) (*ResolvedPingoraConfig, error) {
-	// Validate required address
-	if config.Spec.Address == "" {
-		return nil, errors.New("address is required in PingoraConfig")
+	// Apply the same defaults and validation as admission, without mutating the cached object
+	config = config.DeepCopy()
+	config.Default()
+
+	if errs := config.Validate(); len(errs) > 0 {
+		return nil, errors.Mark(errors.Wrapf(errs.ToAggregate(), "invalid PingoraConfig %s", config.Name), ErrInvalidConfig)
 	}
 
 	resolved := &ResolvedPingoraConfig{
@@ -128,12 +161,34 @@ func (r *PingoraResolver) resolveConfig(ctx context.Context, config *v1alpha1.Pi
 		ConfigName:     config.Name,
 	}
 
+	if discovery := config.Spec.Discovery; discovery != nil {
+		resolved.DiscoveryEnabled = true
+		resolved.DiscoveryResolveAddress = discovery.ResolveAddress
+		resolved.DiscoveryAddresses = discovery.Addresses
+	}
+
+	if ref := config.Spec.ProxyRef; ref != nil {
+		selector, err := metav1.LabelSelectorAsSelector(&ref.Selector)
+		if err != nil {
+			return nil, errors.Mark(errors.Wrap(err, "invalid proxy pod selector"), ErrInvalidConfig)
+		}
+
+		resolved.ProxyNamespace = ref.Namespace
+		resolved.ProxySelector = selector
+	}
+
 	// Resolve TLS configuration if enabled
 	//nolint:nestif // TLS configuration requires checking multiple optional fields
 	if resolved.TLSEnabled && config.Spec.TLS != nil {
 		resolved.TLSInsecureSkipVerify = config.Spec.TLS.InsecureSkipVerify
 		resolved.TLSServerName = config.Spec.TLS.ServerName
 
+		if spiffe := config.Spec.TLS.SPIFFE; spiffe != nil {
+			resolved.SPIFFEEnabled = true
+			resolved.SPIFFESocketPath = spiffe.SocketPath
+			resolved.SPIFFEServerID = spiffe.ServerID
+		}
+
 		if config.Spec.TLS.SecretRef != nil {
 			secretRef := config.Spec.TLS.SecretRef
 
@@ -162,9 +217,7 @@ func (r *PingoraResolver) resolveConfig(ctx context.Context, config *v1alpha1.Pi
 
 //nolint:funcorder // private helper
 func (r *PingoraResolver) getSecret(ctx context.Context, name, namespace string) (*corev1.Secret, error) {
-	if namespace == "" {
-		namespace = r.defaultNamespace
-	}
+	namespace = r.SecretNamespace(namespace)
 
 	secret := &corev1.Secret{}
 
@@ -176,8 +229,32 @@ func (r *PingoraResolver) getSecret(ctx context.Context, name, namespace string)
 	return secret, nil
 }
 
+// SecretNamespace returns the namespace of a TLS Secret referenced by a
+// PingoraConfig, which defaults to the namespace of the controller.
+func (r *PingoraResolver) SecretNamespace(namespace string) string {
+	if namespace == "" {
+		return r.defaultNamespace
+	}
+
+	return namespace
+}
+
 // CreateGRPCConnection creates a gRPC connection to the Pingora proxy.
-func (r *PingoraResolver) CreateGRPCConnection(_ context.Context, resolved *ResolvedPingoraConfig) (*grpc.ClientConn, error) {
+func (r *PingoraResolver) CreateGRPCConnection(ctx context.Context, resolved *ResolvedPingoraConfig) (*grpc.ClientConn, error) {
+	return r.CreateGRPCConnectionWithCredentials(ctx, resolved, &TLSCredentials{})
+}
+
+// CreateGRPCConnectionWithCredentials is like CreateGRPCConnection, but
+// loads the TLS certificates of resolved into tlsCredentials and reads them
+// from there on every TLS handshake. Updating tlsCredentials rotates the client
+// certificate and the CA of the connection without closing it. With SPIFFE
+// enabled, it waits up to the connect timeout for the first SVID of the
+// Workload API. The caller must close tlsCredentials with the connection.
+func (r *PingoraResolver) CreateGRPCConnectionWithCredentials(
+	ctx context.Context,
+	resolved *ResolvedPingoraConfig,
+	tlsCredentials *TLSCredentials,
+) (*grpc.ClientConn, error) {
 	var opts []grpc.DialOption
 
 	// Set up keepalive
@@ -187,9 +264,15 @@ func (r *PingoraResolver) CreateGRPCConnection(_ context.Context, resolved *Reso
 		PermitWithoutStream: true,
 	}))
 
+	// Trace route updates as client spans of the active trace
+	opts = append(opts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+
+	// Bound and retry unary calls as configured in the PingoraConfig
+	opts = append(opts, grpc.WithUnaryInterceptor(RetryInterceptor(resolved, r.Metrics)))
+
 	// Set up TLS or insecure
 	if resolved.TLSEnabled {
-		tlsConfig, err := r.buildTLSConfig(resolved)
+		tlsConfig, err := r.buildTLSConfig(ctx, resolved, tlsCredentials)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to build TLS config")
 		}
@@ -214,34 +297,25 @@ func (r *PingoraResolver) CreateRoutingClient(conn *grpc.ClientConn) routingv1.R
 }
 
 //nolint:funcorder // private helper
-func (r *PingoraResolver) buildTLSConfig(resolved *ResolvedPingoraConfig) (*tls.Config, error) {
-	tlsConfig := &tls.Config{
-		MinVersion:         tls.VersionTLS12,
-		InsecureSkipVerify: resolved.TLSInsecureSkipVerify, //nolint:gosec // user-configurable
-	}
-
-	if resolved.TLSServerName != "" {
-		tlsConfig.ServerName = resolved.TLSServerName
+func (r *PingoraResolver) buildTLSConfig(
+	ctx context.Context,
+	resolved *ResolvedPingoraConfig,
+	tlsCredentials *TLSCredentials,
+) (*tls.Config, error) {
+	if _, err := tlsCredentials.Load(ctx, resolved); err != nil {
+		return nil, err
 	}
 
-	// Load client certificate if provided
-	if len(resolved.TLSCert) > 0 && len(resolved.TLSKey) > 0 {
-		cert, err := tls.X509KeyPair(resolved.TLSCert, resolved.TLSKey)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to load TLS certificate")
-		}
-
-		tlsConfig.Certificates = []tls.Certificate{cert}
+	tlsConfig := &tls.Config{
+		MinVersion:           tls.VersionTLS12,
+		ServerName:           resolved.TLSServerName,
+		GetClientCertificate: tlsCredentials.getClientCertificate,
+		// The proxy certificate is verified in VerifyConnection against the current CA
+		InsecureSkipVerify: true, //nolint:gosec // verified in VerifyConnection unless disabled by the user
 	}
 
-	// Load CA certificate if provided
-	if len(resolved.TLSCA) > 0 {
-		caPool := x509.NewCertPool()
-		if !caPool.AppendCertsFromPEM(resolved.TLSCA) {
-			return nil, errors.New("failed to parse CA certificate")
-		}
-
-		tlsConfig.RootCAs = caPool
+	if !resolved.TLSInsecureSkipVerify {
+		tlsConfig.VerifyConnection = tlsCredentials.verifyConnection
 	}
 
 	return tlsConfig, nil
@@ -0,0 +1,151 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+)
+
+func newGatewayClass(configName string) *gatewayv1.GatewayClass {
+	return &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: "pingora.k8s.lex.la/gateway-controller",
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group: config.PingoraParametersRefGroup,
+				Kind:  config.PingoraParametersRefKind,
+				Name:  configName,
+			},
+		},
+	}
+}
+
+func TestPingoraResolver_ResolveFromGatewayClass(t *testing.T) {
+	t.Parallel()
+
+	retryBackoff := int32(250)
+
+	tests := []struct {
+		name    string
+		spec    v1alpha1.PingoraConfigSpec
+		wantErr bool
+		check   func(t *testing.T, resolved *config.ResolvedPingoraConfig)
+	}{
+		{
+			name: "defaults applied",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address:    "pingora-proxy:50051",
+				Connection: &v1alpha1.ConnectionConfig{RetryBackoffMs: &retryBackoff},
+			},
+			check: func(t *testing.T, resolved *config.ResolvedPingoraConfig) {
+				t.Helper()
+
+				assert.Equal(t, "pingora-proxy:50051", resolved.Address)
+				assert.Equal(t, v1alpha1.DefaultConnectTimeout*time.Second, resolved.ConnectTimeout)
+				assert.Equal(t, v1alpha1.DefaultRequestTimeout*time.Second, resolved.RequestTimeout)
+				assert.Equal(t, int32(v1alpha1.DefaultMaxRetries), resolved.MaxRetries)
+				assert.Equal(t, 250*time.Millisecond, resolved.RetryBackoff)
+			},
+		},
+		{
+			name: "proxy reference",
+			spec: v1alpha1.PingoraConfigSpec{
+				Address: "pingora-proxy:50051",
+				ProxyRef: &v1alpha1.ProxyReference{
+					Namespace: "pingora-system",
+					Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "pingora-proxy"}},
+				},
+			},
+			check: func(t *testing.T, resolved *config.ResolvedPingoraConfig) {
+				t.Helper()
+
+				assert.Equal(t, "pingora-system", resolved.ProxyNamespace)
+				require.NotNil(t, resolved.ProxySelector)
+				assert.Equal(t, "app=pingora-proxy", resolved.ProxySelector.String())
+			},
+		},
+		{
+			name:    "missing address",
+			spec:    v1alpha1.PingoraConfigSpec{},
+			wantErr: true,
+		},
+		{
+			name:    "malformed address",
+			spec:    v1alpha1.PingoraConfigSpec{Address: "pingora-proxy"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+			pingoraConfig := &v1alpha1.PingoraConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "pingora-config"},
+				Spec:       tt.spec,
+			}
+
+			cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pingoraConfig).Build()
+			resolver := config.NewPingoraResolver(cli, "pingora-system")
+
+			resolved, err := resolver.ResolveFromGatewayClass(context.Background(), newGatewayClass("pingora-config"))
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, resolved)
+		})
+	}
+}
+
+func TestPingoraResolver_CreateGRPCConnection_InvalidCertificate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		resolved *config.ResolvedPingoraConfig
+	}{
+		{
+			name: "invalid client certificate",
+			resolved: &config.ResolvedPingoraConfig{
+				Address: "pingora:50051", TLSEnabled: true,
+				TLSCert: []byte("not a certificate"), TLSKey: []byte("not a key"),
+			},
+		},
+		{
+			name: "invalid CA certificate",
+			resolved: &config.ResolvedPingoraConfig{
+				Address: "pingora:50051", TLSEnabled: true, TLSCA: []byte("not a certificate"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resolver := config.NewPingoraResolver(fake.NewClientBuilder().Build(), "pingora-system")
+
+			_, err := resolver.CreateGRPCConnection(context.Background(), tt.resolved)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, config.ErrInvalidCertificate))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ResolveInstances returns the configuration of every proxy instance that
+// routes are synced to, ordered by address. Without discovery, this is the
+// resolved config itself. Instances resolved from the host of Address keep
+// the host as TLS server name, so that their certificates verify as for
+// Address.
+func (r *PingoraResolver) ResolveInstances(
+	ctx context.Context,
+	resolved *ResolvedPingoraConfig,
+) ([]*ResolvedPingoraConfig, error) {
+	if !resolved.DiscoveryEnabled {
+		return []*ResolvedPingoraConfig{resolved}, nil
+	}
+
+	host, port, err := net.SplitHostPort(resolved.Address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid proxy address %s", resolved.Address)
+	}
+
+	addresses := []string{resolved.Address}
+	serverName := resolved.TLSServerName
+
+	if resolved.DiscoveryResolveAddress {
+		ips, lookupErr := r.lookup(ctx, host)
+		if lookupErr != nil {
+			return nil, errors.Wrapf(lookupErr, "failed to resolve proxy instances of %s", host)
+		}
+
+		addresses = addresses[:0]
+		for _, ip := range ips {
+			addresses = append(addresses, net.JoinHostPort(ip, port))
+		}
+
+		if serverName == "" {
+			serverName = host
+		}
+	}
+
+	instances := make([]*ResolvedPingoraConfig, 0, len(addresses)+len(resolved.DiscoveryAddresses))
+
+	for _, address := range addresses {
+		instance := *resolved
+		instance.Address = address
+		instance.TLSServerName = serverName
+		instances = append(instances, &instance)
+	}
+
+	for _, address := range resolved.DiscoveryAddresses {
+		instance := *resolved
+		instance.Address = address
+		instances = append(instances, &instance)
+	}
+
+	slices.SortFunc(instances, func(a, b *ResolvedPingoraConfig) int {
+		return strings.Compare(a.Address, b.Address)
+	})
+
+	// An address listed twice is one instance
+	instances = slices.CompactFunc(instances, func(a, b *ResolvedPingoraConfig) bool {
+		return a.Address == b.Address
+	})
+
+	if len(instances) == 0 {
+		return nil, errors.Newf("no proxy instances found for %s", resolved.Address)
+	}
+
+	return instances, nil
+}
+
+//nolint:funcorder // private helper
+func (r *PingoraResolver) lookup(ctx context.Context, host string) ([]string, error) {
+	if r.lookupHost != nil {
+		return r.lookupHost(ctx, host)
+	}
+
+	return net.DefaultResolver.LookupHost(ctx, host) //nolint:wrapcheck // wrapped by caller
+}
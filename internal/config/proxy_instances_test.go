@@ -0,0 +1,94 @@
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPingoraResolver_ResolveInstances(t *testing.T) {
+	t.Parallel()
+
+	resolver := NewPingoraResolver(nil, "pingora-system")
+	resolver.lookupHost = func(_ context.Context, host string) ([]string, error) {
+		if host != "pingora-proxy-headless" {
+			return nil, errors.Newf("no such host %s", host)
+		}
+
+		return []string{"10.0.0.2", "10.0.0.1", "fd00::1"}, nil
+	}
+
+	tests := []struct {
+		name            string
+		resolved        ResolvedPingoraConfig
+		wantAddresses   []string
+		wantServerNames []string
+		wantErr         bool
+	}{
+		{
+			name:            "without discovery",
+			resolved:        ResolvedPingoraConfig{Address: "pingora-proxy-headless:50051"},
+			wantAddresses:   []string{"pingora-proxy-headless:50051"},
+			wantServerNames: []string{""},
+		},
+		{
+			name: "resolved address",
+			resolved: ResolvedPingoraConfig{
+				Address:                 "pingora-proxy-headless:50051",
+				DiscoveryEnabled:        true,
+				DiscoveryResolveAddress: true,
+			},
+			wantAddresses:   []string{"10.0.0.1:50051", "10.0.0.2:50051", "[fd00::1]:50051"},
+			wantServerNames: []string{"pingora-proxy-headless", "pingora-proxy-headless", "pingora-proxy-headless"},
+		},
+		{
+			name: "explicit addresses keep the server name",
+			resolved: ResolvedPingoraConfig{
+				Address:            "proxy-a:50051",
+				TLSServerName:      "pingora",
+				DiscoveryEnabled:   true,
+				DiscoveryAddresses: []string{"proxy-b:50051", "proxy-a:50051"},
+			},
+			wantAddresses:   []string{"proxy-a:50051", "proxy-b:50051"},
+			wantServerNames: []string{"pingora", "pingora"},
+		},
+		{
+			name: "lookup failure",
+			resolved: ResolvedPingoraConfig{
+				Address:                 "missing:50051",
+				DiscoveryEnabled:        true,
+				DiscoveryResolveAddress: true,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			instances, err := resolver.ResolveInstances(context.Background(), &tt.resolved)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			addresses := make([]string, 0, len(instances))
+			serverNames := make([]string, 0, len(instances))
+
+			for _, instance := range instances {
+				addresses = append(addresses, instance.Address)
+				serverNames = append(serverNames, instance.TLSServerName)
+			}
+
+			assert.Equal(t, tt.wantAddresses, addresses)
+			assert.Equal(t, tt.wantServerNames, serverNames)
+		})
+	}
+}
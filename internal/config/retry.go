@@ -0,0 +1,113 @@
+package config
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+// retryableCodes are the status codes of failed attempts that are retried.
+// They mean the proxy was unreachable, overloaded or too slow, not that it
+// rejected the request, so sending it again may succeed.
+var retryableCodes = map[codes.Code]bool{
+	codes.Unavailable:       true,
+	codes.ResourceExhausted: true,
+	codes.DeadlineExceeded:  true,
+}
+
+// RetryInterceptor returns a unary client interceptor that bounds every
+// attempt by the request timeout of resolved and retries attempts failing
+// with a retryable status code up to MaxRetries times.
+//
+// The delay before a retry starts at RetryBackoff and doubles with every
+// retry, capped at the request timeout. Retries stop as soon as the context
+// of the call is done. Every retry is recorded in collector.
+func RetryInterceptor(resolved *ResolvedPingoraConfig, collector metrics.Collector) grpc.UnaryClientInterceptor {
+	if collector == nil {
+		collector = metrics.NewNoopCollector()
+	}
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		conn *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		for attempt := 0; ; attempt++ {
+			err := invokeAttempt(ctx, resolved.RequestTimeout, method, req, reply, conn, invoker, opts...)
+			if err == nil || attempt >= int(resolved.MaxRetries) || ctx.Err() != nil {
+				return err
+			}
+
+			code := status.Code(err)
+			if !retryableCodes[code] {
+				return err
+			}
+
+			collector.RecordGRPCRetry(ctx, path.Base(method), code.String())
+
+			if !sleep(ctx, retryDelay(resolved.RetryBackoff, resolved.RequestTimeout, attempt)) {
+				return err
+			}
+		}
+	}
+}
+
+// invokeAttempt invokes a single attempt of a call, bounded by timeout.
+func invokeAttempt(
+	ctx context.Context,
+	timeout time.Duration,
+	method string,
+	req, reply any,
+	conn *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return invoker(ctx, method, req, reply, conn, opts...)
+}
+
+// retryDelay returns the delay before the retry following the given attempt.
+func retryDelay(backoff, maxDelay time.Duration, attempt int) time.Duration {
+	delay := backoff
+	for range attempt {
+		if maxDelay > 0 && delay >= maxDelay {
+			break
+		}
+
+		delay *= 2
+	}
+
+	if maxDelay > 0 && delay > maxDelay {
+		return maxDelay
+	}
+
+	return delay
+}
+
+// sleep waits for delay and reports whether it elapsed before ctx was done.
+func sleep(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
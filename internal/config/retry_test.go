@@ -0,0 +1,126 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+type retryCollector struct {
+	metrics.NoopCollector
+
+	retries []string
+}
+
+func (c *retryCollector) RecordGRPCRetry(_ context.Context, method, code string) {
+	c.retries = append(c.retries, method+":"+code)
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	invalid := status.Error(codes.InvalidArgument, "bad route")
+
+	tests := []struct {
+		name         string
+		maxRetries   int32
+		results      []error
+		wantErr      error
+		wantAttempts int
+		wantRetries  []string
+	}{
+		{
+			name:         "succeeds first time",
+			maxRetries:   3,
+			results:      []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "retries unavailable proxy",
+			maxRetries:   3,
+			results:      []error{unavailable, unavailable, nil},
+			wantAttempts: 3,
+			wantRetries:  []string{"UpdateRoutes:Unavailable", "UpdateRoutes:Unavailable"},
+		},
+		{
+			name:         "gives up after max retries",
+			maxRetries:   1,
+			results:      []error{unavailable, unavailable, nil},
+			wantErr:      unavailable,
+			wantAttempts: 2,
+			wantRetries:  []string{"UpdateRoutes:Unavailable"},
+		},
+		{
+			name:         "does not retry rejected request",
+			maxRetries:   3,
+			results:      []error{invalid, nil},
+			wantErr:      invalid,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collector := &retryCollector{}
+			interceptor := config.RetryInterceptor(&config.ResolvedPingoraConfig{
+				RequestTimeout: time.Second,
+				MaxRetries:     tt.maxRetries,
+				RetryBackoff:   time.Millisecond,
+			}, collector)
+
+			attempts := 0
+			invoker := func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+				_, hasDeadline := ctx.Deadline()
+				assert.True(t, hasDeadline)
+
+				err := tt.results[attempts]
+				attempts++
+
+				return err
+			}
+
+			err := interceptor(context.Background(), "/routing.v1.RoutingService/UpdateRoutes", nil, nil, nil, invoker)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.Equal(t, tt.wantRetries, collector.retries)
+		})
+	}
+}
+
+func TestRetryInterceptor_StopsOnCanceledContext(t *testing.T) {
+	t.Parallel()
+
+	interceptor := config.RetryInterceptor(&config.ResolvedPingoraConfig{
+		RequestTimeout: time.Second,
+		MaxRetries:     3,
+		RetryBackoff:   time.Hour,
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		attempts++
+
+		cancel()
+
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+
+	err := interceptor(ctx, "/routing.v1.RoutingService/Health", nil, nil, nil, invoker)
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, attempts)
+}
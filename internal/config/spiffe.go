@@ -0,0 +1,139 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/cockroachdb/errors"
+	"github.com/spiffe/go-spiffe/v2/spiffeid"
+	"github.com/spiffe/go-spiffe/v2/spiffetls/tlsconfig"
+	"github.com/spiffe/go-spiffe/v2/workloadapi"
+)
+
+// spiffeCredentials presents the X.509 SVID of a SPIFFE Workload API as
+// client certificate and verifies the proxy against its trust bundles. The
+// source follows SVID and bundle rotations of the Workload API.
+type spiffeCredentials struct {
+	socketPath string
+	serverID   string
+
+	source                *workloadapi.X509Source
+	getClientCertificate  func(*tls.CertificateRequestInfo) (*tls.Certificate, error)
+	verifyPeerCertificate func([][]byte, [][]*x509.Certificate) error
+}
+
+// newSPIFFECredentials connects to the Workload API at socketPath, or at the
+// SPIFFE_ENDPOINT_SOCKET address if empty, and waits until it provides the
+// first SVID. The proxy must present serverID, or any ID in the trust domain
+// of the controller if empty.
+func newSPIFFECredentials(ctx context.Context, socketPath, serverID string) (*spiffeCredentials, error) {
+	var authorizeServer tlsconfig.Authorizer
+
+	if serverID != "" {
+		id, err := spiffeid.FromString(serverID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid SPIFFE server ID %q", serverID)
+		}
+
+		authorizeServer = tlsconfig.AuthorizeID(id)
+	}
+
+	var clientOptions []workloadapi.ClientOption
+	if socketPath != "" {
+		clientOptions = append(clientOptions, workloadapi.WithAddr("unix://"+socketPath))
+	}
+
+	source, err := workloadapi.NewX509Source(ctx, workloadapi.WithClientOptions(clientOptions...))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch X.509 SVID from the SPIFFE Workload API")
+	}
+
+	if authorizeServer == nil {
+		svid, svidErr := source.GetX509SVID()
+		if svidErr != nil {
+			_ = source.Close()
+
+			return nil, errors.Wrap(svidErr, "failed to get X.509 SVID")
+		}
+
+		authorizeServer = tlsconfig.AuthorizeMemberOf(svid.ID.TrustDomain())
+	}
+
+	return &spiffeCredentials{
+		socketPath:            socketPath,
+		serverID:              serverID,
+		source:                source,
+		getClientCertificate:  tlsconfig.GetClientCertificate(source),
+		verifyPeerCertificate: tlsconfig.VerifyPeerCertificate(source, authorizeServer),
+	}, nil
+}
+
+// verifyConnection verifies the certificate chain and the SPIFFE ID of the
+// proxy.
+func (s *spiffeCredentials) verifyConnection(state tls.ConnectionState) error {
+	rawCerts := make([][]byte, 0, len(state.PeerCertificates))
+	for _, cert := range state.PeerCertificates {
+		rawCerts = append(rawCerts, cert.Raw)
+	}
+
+	if err := s.verifyPeerCertificate(rawCerts, nil); err != nil {
+		return errors.Wrap(err, "failed to verify proxy SVID")
+	}
+
+	return nil
+}
+
+// close stops watching the Workload API.
+func (s *spiffeCredentials) close() error {
+	return errors.Wrap(s.source.Close(), "failed to close SPIFFE X.509 source")
+}
+
+// UseSPIFFE switches the credentials to the SVIDs and trust bundles of the
+// SPIFFE Workload API at socketPath, replacing any certificates loaded with
+// Update. The proxy must present serverID, or any ID in the trust domain of
+// the controller if empty. If the Workload API cannot be reached before ctx
+// is done, the current credentials are kept and an error is returned.
+// Returns true if the credentials changed.
+func (c *TLSCredentials) UseSPIFFE(ctx context.Context, socketPath, serverID string) (bool, error) {
+	c.mu.RLock()
+	unchanged := c.spiffe != nil && c.spiffe.socketPath == socketPath && c.spiffe.serverID == serverID
+	c.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	spiffe, err := newSPIFFECredentials(ctx, socketPath, serverID)
+	if err != nil {
+		return false, err
+	}
+
+	c.mu.Lock()
+	previous := c.spiffe
+	c.spiffe = spiffe
+	// Certificates from a later Update must be loaded again
+	c.loaded = false
+	c.mu.Unlock()
+
+	if previous != nil {
+		_ = previous.close()
+	}
+
+	return true, nil
+}
+
+// Close releases the SPIFFE Workload API connection, if any. Credentials
+// must not be used after Close.
+func (c *TLSCredentials) Close() error {
+	c.mu.Lock()
+	spiffe := c.spiffe
+	c.spiffe = nil
+	c.mu.Unlock()
+
+	if spiffe == nil {
+		return nil
+	}
+
+	return spiffe.close()
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/go-spiffe/v2/proto/spiffe/workload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// issueSVID creates an X.509 SVID for the SPIFFE ID spiffeID signed by ca.
+// The common and DNS names are set to name, so that startTLSServer can report
+// it and the SVID also verifies as a regular certificate.
+func issueSVID(t *testing.T, name, spiffeID string, ca *testCertificate) *testCertificate {
+	t.Helper()
+
+	id, err := url.Parse(spiffeID)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		URIs:         []*url.URL{id},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		cert:    cert,
+		key:     key,
+	}
+}
+
+// fakeWorkloadAPI serves a single X.509 SVID of the SPIFFE Workload API.
+type fakeWorkloadAPI struct {
+	workload.UnimplementedSpiffeWorkloadAPIServer
+
+	response *workload.X509SVIDResponse
+}
+
+func (f *fakeWorkloadAPI) FetchX509SVID(
+	_ *workload.X509SVIDRequest,
+	stream grpc.ServerStreamingServer[workload.X509SVIDResponse],
+) error {
+	if err := stream.Send(f.response); err != nil {
+		return err //nolint:wrapcheck // test server
+	}
+
+	<-stream.Context().Done()
+
+	return nil
+}
+
+// startWorkloadAPI serves svid with the trust bundle ca on a Unix socket and
+// returns its path.
+func startWorkloadAPI(t *testing.T, svid, ca *testCertificate) string {
+	t.Helper()
+
+	key, err := x509.MarshalPKCS8PrivateKey(svid.key)
+	require.NoError(t, err)
+
+	socketPath := filepath.Join(t.TempDir(), "agent.sock")
+
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	workload.RegisterSpiffeWorkloadAPIServer(server, &fakeWorkloadAPI{
+		response: &workload.X509SVIDResponse{Svids: []*workload.X509SVID{{
+			SpiffeId:    svid.cert.URIs[0].String(),
+			X509Svid:    svid.cert.Raw,
+			X509SvidKey: key,
+			Bundle:      ca.cert.Raw,
+		}}},
+	})
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	return socketPath
+}
+
+func TestTLSCredentials_SPIFFE(t *testing.T) {
+	t.Parallel()
+
+	ca := issueCertificate(t, "spiffe-ca", nil)
+	proxy := issueSVID(t, "pingora-proxy", "spiffe://example.org/ns/pingora-system/sa/pingora-proxy", ca)
+	controller := issueSVID(t, "controller", "spiffe://example.org/ns/pingora-system/sa/controller", ca)
+	client := issueCertificate(t, "controller-secret", ca)
+
+	addr, clients := startTLSServer(t, ca, proxy)
+	socketPath := startWorkloadAPI(t, controller, ca)
+
+	credentials := &TLSCredentials{}
+	t.Cleanup(func() { _ = credentials.Close() })
+
+	resolver := NewPingoraResolver(nil, "pingora-system")
+
+	tlsConfig, err := resolver.buildTLSConfig(t.Context(), &ResolvedPingoraConfig{
+		TLSEnabled:       true,
+		SPIFFEEnabled:    true,
+		SPIFFESocketPath: socketPath,
+		SPIFFEServerID:   "spiffe://example.org/ns/pingora-system/sa/pingora-proxy",
+		TLSServerName:    "pingora-proxy",
+		ConnectTimeout:   5 * time.Second,
+	}, credentials)
+	require.NoError(t, err)
+
+	handshake := func() error {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", addr, tlsConfig)
+		if err != nil {
+			return err //nolint:wrapcheck // test helper
+		}
+
+		return conn.Close() //nolint:wrapcheck // test helper
+	}
+
+	// The SVID of the Workload API is presented and the proxy ID is verified
+	require.NoError(t, handshake())
+	assert.Equal(t, "controller", <-clients)
+
+	changed, err := credentials.UseSPIFFE(t.Context(), socketPath,
+		"spiffe://example.org/ns/pingora-system/sa/pingora-proxy")
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Without a server ID, any ID in the trust domain of the controller is accepted
+	changed, err = credentials.UseSPIFFE(t.Context(), socketPath, "")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.NoError(t, handshake())
+	assert.Equal(t, "controller", <-clients)
+
+	changed, err = credentials.UseSPIFFE(t.Context(), socketPath, "spiffe://example.org/other")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.Error(t, handshake())
+
+	// An unreachable Workload API keeps the current credentials
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = credentials.UseSPIFFE(ctx, filepath.Join(t.TempDir(), "missing.sock"), "")
+	require.Error(t, err)
+	require.Error(t, handshake())
+
+	// Certificates from a Secret replace the SPIFFE credentials
+	changed, err = credentials.Update(client.certPEM, client.keyPEM, ca.certPEM)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.NoError(t, handshake())
+	assert.Equal(t, "controller-secret", <-clients)
+}
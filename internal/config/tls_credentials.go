@@ -0,0 +1,149 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+)
+
+// TLSCredentials holds the client certificate and the CA of a connection to
+// the proxy. Connections read them on every TLS handshake, so rotated
+// credentials are used for new handshakes without closing the connection.
+// The zero value holds no credentials and uses the system roots.
+type TLSCredentials struct {
+	mu    sync.RWMutex
+	cert  *tls.Certificate
+	roots *x509.CertPool
+
+	// spiffe, if set, replaces cert and roots. See UseSPIFFE.
+	spiffe *spiffeCredentials
+
+	// PEM data of the current credentials, to detect rotation. loaded is
+	// false until the first Update.
+	certPEM, keyPEM, caPEM []byte
+	loaded                 bool
+}
+
+// Update replaces the credentials with the given PEM data. An empty key
+// pair removes the client certificate, and an empty CA selects the system
+// roots. Credentials from a SPIFFE Workload API are released. If any of the
+// data cannot be parsed, the current credentials are kept and an error
+// marked with ErrInvalidCertificate is returned.
+// Returns true if the credentials changed.
+func (c *TLSCredentials) Update(certPEM, keyPEM, caPEM []byte) (bool, error) {
+	c.mu.RLock()
+	unchanged := c.loaded && c.spiffe == nil &&
+		bytes.Equal(c.certPEM, certPEM) && bytes.Equal(c.keyPEM, keyPEM) && bytes.Equal(c.caPEM, caPEM)
+	c.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	var cert *tls.Certificate
+
+	if len(certPEM) > 0 && len(keyPEM) > 0 {
+		pair, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return false, errors.Mark(errors.Wrap(err, "failed to load TLS certificate"), ErrInvalidCertificate)
+		}
+
+		cert = &pair
+	}
+
+	var roots *x509.CertPool
+
+	if len(caPEM) > 0 {
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			return false, errors.Mark(errors.New("failed to parse CA certificate"), ErrInvalidCertificate)
+		}
+	}
+
+	c.mu.Lock()
+	spiffe := c.spiffe
+	c.spiffe = nil
+	c.cert, c.roots = cert, roots
+	c.certPEM, c.keyPEM, c.caPEM = bytes.Clone(certPEM), bytes.Clone(keyPEM), bytes.Clone(caPEM)
+	c.loaded = true
+	c.mu.Unlock()
+
+	if spiffe != nil {
+		_ = spiffe.close()
+	}
+
+	return true, nil
+}
+
+// Load updates the credentials from a resolved PingoraConfig: from its
+// SPIFFE Workload API if enabled, waiting up to the connect timeout, or from
+// the certificates of its Secret. Returns true if the credentials changed.
+func (c *TLSCredentials) Load(ctx context.Context, resolved *ResolvedPingoraConfig) (bool, error) {
+	if !resolved.SPIFFEEnabled {
+		return c.Update(resolved.TLSCert, resolved.TLSKey, resolved.TLSCA)
+	}
+
+	if resolved.ConnectTimeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, resolved.ConnectTimeout)
+		defer cancel()
+	}
+
+	return c.UseSPIFFE(ctx, resolved.SPIFFESocketPath, resolved.SPIFFEServerID)
+}
+
+// getClientCertificate implements tls.Config.GetClientCertificate.
+func (c *TLSCredentials) getClientCertificate(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.spiffe != nil {
+		return c.spiffe.getClientCertificate(info)
+	}
+
+	if c.cert == nil {
+		// An empty certificate tells the server that none is available
+		return &tls.Certificate{}, nil
+	}
+
+	return c.cert, nil
+}
+
+// verifyConnection implements tls.Config.VerifyConnection. It verifies the
+// certificate chain of the proxy against the current CA, as the standard
+// verification would against tls.Config.RootCAs, or against the trust
+// bundles of the SPIFFE Workload API.
+func (c *TLSCredentials) verifyConnection(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("proxy presented no certificate")
+	}
+
+	c.mu.RLock()
+	roots, spiffe := c.roots, c.spiffe
+	c.mu.RUnlock()
+
+	if spiffe != nil {
+		return spiffe.verifyConnection(state)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to verify proxy certificate")
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCertificate is a certificate with its key, in PEM and parsed form.
+type testCertificate struct {
+	certPEM, keyPEM []byte
+	cert            *x509.Certificate
+	key             *ecdsa.PrivateKey
+}
+
+// issueCertificate creates a certificate for commonName signed by issuer,
+// or a self-signed CA certificate if issuer is nil.
+func issueCertificate(t *testing.T, commonName string, issuer *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	parent, signer := template, key
+	if issuer == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		parent, signer = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return &testCertificate{
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+		cert:    cert,
+		key:     key,
+	}
+}
+
+// startTLSServer accepts TLS connections that present a client certificate
+// signed by ca and reports the common name of every client certificate.
+func startTLSServer(t *testing.T, ca, server *testCertificate) (string, <-chan string) {
+	t.Helper()
+
+	pair, err := tls.X509KeyPair(server.certPEM, server.keyPEM)
+	require.NoError(t, err)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{pair},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	clients := make(chan string, 8)
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			tlsConn, _ := conn.(*tls.Conn)
+			if tlsConn.Handshake() == nil {
+				clients <- tlsConn.ConnectionState().PeerCertificates[0].Subject.CommonName
+			}
+
+			_ = conn.Close()
+		}
+	}()
+
+	return listener.Addr().String(), clients
+}
+
+func TestTLSCredentials_Rotation(t *testing.T) {
+	t.Parallel()
+
+	ca := issueCertificate(t, "pingora-ca", nil)
+	server := issueCertificate(t, "pingora-proxy", ca)
+	clientA := issueCertificate(t, "controller-a", ca)
+	clientB := issueCertificate(t, "controller-b", ca)
+
+	addr, clients := startTLSServer(t, ca, server)
+
+	credentials := &TLSCredentials{}
+	resolver := NewPingoraResolver(nil, "pingora-system")
+
+	tlsConfig, err := resolver.buildTLSConfig(t.Context(), &ResolvedPingoraConfig{
+		TLSEnabled:    true,
+		TLSCert:       clientA.certPEM,
+		TLSKey:        clientA.keyPEM,
+		TLSCA:         ca.certPEM,
+		TLSServerName: "pingora-proxy",
+	}, credentials)
+	require.NoError(t, err)
+
+	handshake := func() error {
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 5 * time.Second}, "tcp", addr, tlsConfig)
+		if err != nil {
+			return err //nolint:wrapcheck // test helper
+		}
+
+		return conn.Close() //nolint:wrapcheck // test helper
+	}
+
+	require.NoError(t, handshake())
+	assert.Equal(t, "controller-a", <-clients)
+
+	// The same config presents the rotated certificate on the next handshake
+	changed, err := credentials.Update(clientB.certPEM, clientB.keyPEM, ca.certPEM)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	require.NoError(t, handshake())
+	assert.Equal(t, "controller-b", <-clients)
+
+	changed, err = credentials.Update(clientB.certPEM, clientB.keyPEM, ca.certPEM)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// Invalid certificates keep the current ones
+	_, err = credentials.Update([]byte("not a certificate"), clientB.keyPEM, ca.certPEM)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidCertificate))
+
+	require.NoError(t, handshake())
+	assert.Equal(t, "controller-b", <-clients)
+
+	// A rotated CA that did not sign the proxy certificate fails verification
+	otherCA := issueCertificate(t, "other-ca", nil)
+
+	changed, err = credentials.Update(clientB.certPEM, clientB.keyPEM, otherCA.certPEM)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	require.Error(t, handshake())
+}
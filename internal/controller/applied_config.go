@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// AppliedConfig describes the last route configuration accepted by the Pingora proxy.
+// It is kept for debugging and to skip syncs that would not change anything.
+type AppliedConfig struct {
+	// Hash is the hex-encoded SHA-256 of the applied route configuration.
+	Hash string
+
+	// Version is the configuration version reported by the proxy.
+	Version uint64
+
+	// AppliedAt is the time the proxy confirmed the update.
+	AppliedAt time.Time
+
+	// HTTPRouteCount is the number of HTTP routes in the applied configuration.
+	HTTPRouteCount int
+
+	// GRPCRouteCount is the number of gRPC routes in the applied configuration.
+	GRPCRouteCount int
+}
+
+// AppliedConfigPath is the path on the metrics server that serves the
+// applied configuration as JSON.
+const AppliedConfigPath = "/debug/applied-config"
+
+// appliedConfigResponse is the JSON form of an AppliedConfig. AppliedAt is
+// left out until the proxy confirmed a configuration.
+type appliedConfigResponse struct {
+	Hash           string     `json:"hash"`
+	Version        uint64     `json:"version"`
+	AppliedAt      *time.Time `json:"appliedAt,omitempty"`
+	HTTPRouteCount int        `json:"httpRouteCount"`
+	GRPCRouteCount int        `json:"grpcRouteCount"`
+}
+
+// AppliedConfigHandler serves the last route configuration confirmed by the
+// proxy as JSON, so that it can be compared with the proxy and the status
+// subcommand. Controllers that are not the leader sync nothing and report
+// an empty configuration.
+func (s *PingoraRouteSyncer) AppliedConfigHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		applied := s.GetAppliedConfig()
+		resp := appliedConfigResponse{
+			Hash:           applied.Hash,
+			Version:        applied.Version,
+			HTTPRouteCount: applied.HTTPRouteCount,
+			GRPCRouteCount: applied.GRPCRouteCount,
+		}
+
+		if !applied.AppliedAt.IsZero() {
+			resp.AppliedAt = &applied.AppliedAt
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// hashRouteConfig returns a stable content hash of the built route configuration.
+// The version field is excluded so that identical routes always hash the same.
+func hashRouteConfig(
+	httpRoutes []*routingv1.HTTPRoute,
+	grpcRoutes []*routingv1.GRPCRoute,
+	listeners []*routingv1.Listener,
+) (string, error) {
+	req := &routingv1.UpdateRoutesRequest{
+		HttpRoutes: httpRoutes,
+		GrpcRoutes: grpcRoutes,
+		Listeners:  listeners,
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal route config")
+	}
+
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,432 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestHashRouteConfig(t *testing.T) {
+	t.Parallel()
+
+	newRoutes := func(address string) []*routingv1.HTTPRoute {
+		return []*routingv1.HTTPRoute{
+			{
+				Id:        "default/app",
+				Hostnames: []string{"app.example.com"},
+				Rules: []*routingv1.HTTPRouteRule{
+					{Backends: []*routingv1.Backend{{Address: address, Weight: 1}}},
+				},
+			},
+		}
+	}
+
+	first, err := hashRouteConfig(newRoutes("app.default.svc.cluster.local:80"), nil, nil)
+	require.NoError(t, err)
+
+	second, err := hashRouteConfig(newRoutes("app.default.svc.cluster.local:80"), nil, nil)
+	require.NoError(t, err)
+
+	changed, err := hashRouteConfig(newRoutes("app.default.svc.cluster.local:8080"), nil, nil)
+	require.NoError(t, err)
+
+	empty, err := hashRouteConfig(nil, nil, nil)
+	require.NoError(t, err)
+
+	withListener, err := hashRouteConfig(newRoutes("app.default.svc.cluster.local:80"), nil, []*routingv1.Listener{
+		{Port: 80, Limits: &routingv1.ListenerLimits{MaxRequestBodyBytes: 1024}},
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, first, 64)
+	assert.Equal(t, first, second, "identical configs must hash the same")
+	assert.NotEqual(t, first, changed, "changed backend must change the hash")
+	assert.NotEqual(t, first, empty)
+	assert.NotEqual(t, first, withListener, "listener settings must change the hash")
+}
+
+func TestPingoraRouteSyncer_AppliedConfig(t *testing.T) {
+	t.Parallel()
+
+	syncer := &PingoraRouteSyncer{}
+	assert.Empty(t, syncer.GetAppliedConfig().Hash)
+
+	syncer.setAppliedConfig(AppliedConfig{Hash: "abc", Version: 3, HTTPRouteCount: 1})
+	assert.Equal(t, "abc", syncer.GetAppliedConfig().Hash)
+	assert.Equal(t, uint64(3), syncer.GetAppliedConfig().Version)
+
+	syncer.resetAppliedConfig()
+	assert.Equal(t, AppliedConfig{}, syncer.GetAppliedConfig())
+}
+
+func TestPingoraRouteSyncer_AppliedConfigHandler(t *testing.T) {
+	t.Parallel()
+
+	syncer := &PingoraRouteSyncer{}
+	handler := syncer.AppliedConfigHandler()
+
+	get := func() map[string]any {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, AppliedConfigPath, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+
+		return body
+	}
+
+	// Nothing applied yet
+	body := get()
+	assert.Empty(t, body["hash"])
+	assert.NotContains(t, body, "appliedAt")
+
+	appliedAt := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	syncer.setAppliedConfig(AppliedConfig{
+		Hash:           "abc",
+		Version:        7,
+		AppliedAt:      appliedAt,
+		HTTPRouteCount: 2,
+		GRPCRouteCount: 1,
+	})
+
+	assert.Equal(t, map[string]any{
+		"hash":           "abc",
+		"version":        float64(7),
+		"appliedAt":      appliedAt.Format(time.RFC3339),
+		"httpRouteCount": float64(2),
+		"grpcRouteCount": float64(1),
+	}, get())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, AppliedConfigPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+// fakeRoutingClient is a minimal RoutingServiceClient for syncer unit tests.
+type fakeRoutingClient struct {
+	routingv1.RoutingServiceClient
+
+	health    *routingv1.HealthResponse
+	healthErr error
+
+	backendHealth    *routingv1.GetBackendHealthResponse
+	backendHealthErr error
+}
+
+func (f *fakeRoutingClient) Health(
+	_ context.Context,
+	_ *routingv1.HealthRequest,
+	_ ...grpc.CallOption,
+) (*routingv1.HealthResponse, error) {
+	return f.health, f.healthErr
+}
+
+func (f *fakeRoutingClient) GetBackendHealth(
+	_ context.Context,
+	_ *routingv1.GetBackendHealthRequest,
+	_ ...grpc.CallOption,
+) (*routingv1.GetBackendHealthResponse, error) {
+	return f.backendHealth, f.backendHealthErr
+}
+
+// backendHealthCollector records the last reported backend health.
+type backendHealthCollector struct {
+	metrics.NoopCollector
+
+	reports  int
+	backends []metrics.BackendHealth
+}
+
+func (c *backendHealthCollector) RecordBackendHealth(_ context.Context, backends []metrics.BackendHealth) {
+	c.reports++
+	c.backends = backends
+}
+
+func TestPingoraRouteSyncer_IsConfigApplied(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		applied   AppliedConfig
+		hash      string
+		health    *routingv1.HealthResponse
+		healthErr error
+		expected  bool
+	}{
+		{
+			name:     "nothing applied yet",
+			applied:  AppliedConfig{},
+			hash:     "abc",
+			health:   &routingv1.HealthResponse{ConfigVersion: 0},
+			expected: false,
+		},
+		{
+			name:     "hash differs",
+			applied:  AppliedConfig{Hash: "abc", Version: 2},
+			hash:     "def",
+			health:   &routingv1.HealthResponse{ConfigVersion: 2},
+			expected: false,
+		},
+		{
+			name:     "hash and proxy version match",
+			applied:  AppliedConfig{Hash: "abc", Version: 2},
+			hash:     "abc",
+			health:   &routingv1.HealthResponse{ConfigVersion: 2},
+			expected: true,
+		},
+		{
+			name:     "proxy restarted with empty config",
+			applied:  AppliedConfig{Hash: "abc", Version: 2},
+			hash:     "abc",
+			health:   &routingv1.HealthResponse{ConfigVersion: 0},
+			expected: false,
+		},
+		{
+			name:      "health check fails",
+			applied:   AppliedConfig{Hash: "abc", Version: 2},
+			hash:      "abc",
+			healthErr: errors.New("unavailable"),
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			syncer := &PingoraRouteSyncer{
+				Metrics:    metrics.NewNoopCollector(),
+				grpcClient: &fakeRoutingClient{health: tt.health, healthErr: tt.healthErr},
+			}
+			syncer.setAppliedConfig(tt.applied)
+
+			assert.Equal(t, tt.expected, syncer.isConfigApplied(context.Background(), tt.hash))
+		})
+	}
+}
+
+// hungRoutingClient is a RoutingServiceClient of a proxy that never answers.
+type hungRoutingClient struct {
+	routingv1.RoutingServiceClient
+}
+
+func (hungRoutingClient) Health(
+	ctx context.Context,
+	_ *routingv1.HealthRequest,
+	_ ...grpc.CallOption,
+) (*routingv1.HealthResponse, error) {
+	<-ctx.Done()
+
+	return nil, ctx.Err()
+}
+
+func TestPingoraRouteSyncer_IsConfigApplied_HungProxy(t *testing.T) {
+	t.Parallel()
+
+	syncer := &PingoraRouteSyncer{
+		Metrics:        metrics.NewNoopCollector(),
+		grpcClient:     hungRoutingClient{},
+		requestTimeout: 10 * time.Millisecond,
+	}
+	syncer.setAppliedConfig(AppliedConfig{Hash: "abc", Version: 2})
+
+	done := make(chan bool)
+
+	go func() {
+		done <- syncer.isConfigApplied(context.Background(), "abc")
+	}()
+
+	select {
+	case applied := <-done:
+		assert.False(t, applied)
+	case <-time.After(5 * time.Second):
+		t.Fatal("health check was not bounded by the request timeout")
+	}
+}
+
+func TestPingoraRouteSyncer_CheckProxyVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		applied        AppliedConfig
+		proxyVersion   uint64
+		expectedResync bool
+		// expectedMinVersion is the lowest version counter after the check.
+		expectedMinVersion uint64
+	}{
+		{
+			name:           "proxy up to date",
+			applied:        AppliedConfig{Hash: "abc", Version: 5},
+			proxyVersion:   5,
+			expectedResync: false,
+		},
+		{
+			name:           "proxy restarted and lost routes",
+			applied:        AppliedConfig{Hash: "abc", Version: 5},
+			proxyVersion:   0,
+			expectedResync: true,
+		},
+		{
+			name:           "proxy lags behind",
+			applied:        AppliedConfig{Hash: "abc", Version: 5},
+			proxyVersion:   4,
+			expectedResync: true,
+		},
+		{
+			name:           "nothing applied yet",
+			applied:        AppliedConfig{},
+			proxyVersion:   0,
+			expectedResync: false,
+		},
+		{
+			name:               "previous leader applied a later version",
+			applied:            AppliedConfig{Hash: "abc", Version: 5},
+			proxyVersion:       6,
+			expectedResync:     true,
+			expectedMinVersion: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			resyncs := 0
+
+			syncer := &PingoraRouteSyncer{
+				Metrics:    metrics.NewNoopCollector(),
+				Logger:     slog.Default(),
+				grpcClient: &fakeRoutingClient{health: &routingv1.HealthResponse{ConfigVersion: tt.proxyVersion}},
+			}
+			syncer.coordinator = NewSyncCoordinator(func(context.Context) (ctrl.Result, *SyncResult, error) {
+				resyncs++
+
+				return ctrl.Result{}, nil, nil
+			}, 0, nil)
+			syncer.setAppliedConfig(tt.applied)
+
+			syncer.checkProxyVersion(context.Background())
+
+			if tt.expectedResync {
+				assert.Equal(t, 1, resyncs)
+				assert.Empty(t, syncer.GetAppliedConfig().Hash, "applied config must be reset")
+			} else {
+				assert.Zero(t, resyncs)
+				assert.Equal(t, tt.applied, syncer.GetAppliedConfig())
+			}
+
+			assert.GreaterOrEqual(t, syncer.GetVersion(), tt.expectedMinVersion,
+				"the next version must not reuse one the proxy applied")
+		})
+	}
+}
+
+func TestPingoraRouteSyncer_StartHandlesStreamHealth(t *testing.T) {
+	t.Parallel()
+
+	resynced := make(chan struct{})
+
+	syncer := &PingoraRouteSyncer{
+		Metrics:              metrics.NewNoopCollector(),
+		Logger:               slog.Default(),
+		VersionCheckInterval: time.Hour,
+		proxyVersions:        make(chan uint64, 1),
+	}
+	syncer.coordinator = NewSyncCoordinator(func(context.Context) (ctrl.Result, *SyncResult, error) {
+		close(resynced)
+
+		return ctrl.Result{}, nil, nil
+	}, 0, nil)
+	syncer.setAppliedConfig(AppliedConfig{Hash: "abc", Version: 7})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = syncer.Start(ctx)
+	}()
+
+	syncer.reportProxyHealth(&routingv1.HealthResponse{Healthy: true, ConfigVersion: 0})
+
+	select {
+	case <-resynced:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stream health report with lagging version did not trigger a resync")
+	}
+}
+
+func TestPingoraRouteSyncer_CheckBackendHealth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		client          *fakeRoutingClient
+		expectedReports int
+		expected        []metrics.BackendHealth
+	}{
+		{
+			name: "backends reported",
+			client: &fakeRoutingClient{backendHealth: &routingv1.GetBackendHealthResponse{
+				Backends: []*routingv1.BackendHealth{
+					{Address: "api.default.svc.cluster.local:80", HealthyEndpoints: 2, UnhealthyEndpoints: 1},
+				},
+			}},
+			expectedReports: 1,
+			expected: []metrics.BackendHealth{
+				{Backend: "api.default.svc.cluster.local:80", Healthy: 2, Unhealthy: 1},
+			},
+		},
+		{
+			name:            "no health checked backends",
+			client:          &fakeRoutingClient{backendHealth: &routingv1.GetBackendHealthResponse{}},
+			expectedReports: 1,
+			expected:        []metrics.BackendHealth{},
+		},
+		{
+			name:            "proxy without health checking",
+			client:          &fakeRoutingClient{backendHealthErr: status.Error(codes.Unimplemented, "unknown method")},
+			expectedReports: 0,
+		},
+		{
+			name:            "proxy unavailable",
+			client:          &fakeRoutingClient{backendHealthErr: status.Error(codes.Unavailable, "connection refused")},
+			expectedReports: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collector := &backendHealthCollector{}
+			syncer := &PingoraRouteSyncer{
+				Metrics:    collector,
+				Logger:     slog.Default(),
+				grpcClient: tt.client,
+			}
+
+			syncer.checkBackendHealth(context.Background())
+
+			assert.Equal(t, tt.expectedReports, collector.reports)
+			assert.Equal(t, tt.expected, collector.backends)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+// errEmptyJWKS is returned when a referenced JSON Web Key Set has no keys.
+var errEmptyJWKS = errors.New("JWKS has no keys")
+
+// resolveJWKS returns the JSON Web Key Set referenced by a PingoraAuthPolicy.
+// It returns an empty string for policies without JWT validation and for
+// policies that fetch the key set from a URI.
+func resolveJWKS(ctx context.Context, c client.Reader, policy *v1alpha1.PingoraAuthPolicy) (string, error) {
+	if policy.Spec.JWT == nil {
+		return "", nil
+	}
+
+	source := &policy.Spec.JWT.JWKS
+
+	var data string
+
+	switch {
+	case source.SecretRef != nil:
+		var secret corev1.Secret
+
+		key := client.ObjectKey{Namespace: policy.Namespace, Name: source.SecretRef.Name}
+		if err := c.Get(ctx, key, &secret); err != nil {
+			return "", errors.Wrapf(err, "failed to get JWKS secret %s", key)
+		}
+
+		value, ok := secret.Data[source.SecretRef.GetKey()]
+		if !ok {
+			return "", errors.Newf("secret %s has no key %q", key, source.SecretRef.GetKey())
+		}
+
+		data = string(value)
+	case source.ConfigMapRef != nil:
+		var configMap corev1.ConfigMap
+
+		key := client.ObjectKey{Namespace: policy.Namespace, Name: source.ConfigMapRef.Name}
+		if err := c.Get(ctx, key, &configMap); err != nil {
+			return "", errors.Wrapf(err, "failed to get JWKS configmap %s", key)
+		}
+
+		value, ok := configMap.Data[source.ConfigMapRef.GetKey()]
+		if !ok {
+			return "", errors.Newf("configmap %s has no key %q", key, source.ConfigMapRef.GetKey())
+		}
+
+		data = value
+	default:
+		return "", nil
+	}
+
+	var jwks struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+
+	if err := json.Unmarshal([]byte(data), &jwks); err != nil {
+		return "", errors.Wrap(err, "failed to parse JWKS")
+	}
+
+	if len(jwks.Keys) == 0 {
+		return "", errEmptyJWKS
+	}
+
+	return data, nil
+}
+
+// validateExternalAuth checks that the Service of a PingoraAuthPolicy's
+// external auth service exists and exposes the referenced port.
+func validateExternalAuth(ctx context.Context, c client.Reader, policy *v1alpha1.PingoraAuthPolicy) error {
+	external := policy.Spec.External
+	if external == nil || external.BackendRef.Port == nil {
+		return nil
+	}
+
+	var service corev1.Service
+
+	key := client.ObjectKey{Namespace: policy.Namespace, Name: string(external.BackendRef.Name)}
+	if err := c.Get(ctx, key, &service); err != nil {
+		return errors.Wrapf(err, "failed to get auth service %s", key)
+	}
+
+	port := int32(*external.BackendRef.Port)
+	if !slices.ContainsFunc(service.Spec.Ports, func(servicePort corev1.ServicePort) bool {
+		return servicePort.Port == port
+	}) {
+		return errors.Newf("auth service %s has no port %d", key, port)
+	}
+
+	return nil
+}
+
+// validateAuthPolicy reports why a PingoraAuthPolicy cannot be applied, nil
+// if it can.
+func validateAuthPolicy(ctx context.Context, c client.Reader, policy *v1alpha1.PingoraAuthPolicy) error {
+	if _, err := resolveJWKS(ctx, c, policy); err != nil {
+		return err
+	}
+
+	return validateExternalAuth(ctx, c, policy)
+}
+
+// resolveAuthPolicies resolves the key sets of PingoraAuthPolicies. Policies
+// whose key set cannot be resolved are kept with an empty key set, so that
+// their targets are not served unauthenticated.
+func resolveAuthPolicies(
+	ctx context.Context,
+	c client.Reader,
+	policies []v1alpha1.PingoraAuthPolicy,
+) []ingress.ResolvedAuthPolicy {
+	resolved := make([]ingress.ResolvedAuthPolicy, 0, len(policies))
+
+	for i := range policies {
+		policy := &policies[i]
+
+		// Resolution errors are reported in the policy status
+		jwks, _ := resolveJWKS(ctx, c, policy)
+
+		resolved = append(resolved, ingress.ResolvedAuthPolicy{Policy: policy, JWKS: jwks})
+	}
+
+	return resolved
+}
+
+// authPolicyReferences reports whether a PingoraAuthPolicy reads its key set
+// from the Secret or ConfigMap, or uses the Service as its external auth
+// service.
+func authPolicyReferences(policy *v1alpha1.PingoraAuthPolicy, obj client.Object) bool {
+	if policy.Namespace != obj.GetNamespace() {
+		return false
+	}
+
+	switch obj.(type) {
+	case *corev1.Secret:
+		return policy.Spec.JWT != nil && policy.Spec.JWT.JWKS.SecretRef != nil &&
+			policy.Spec.JWT.JWKS.SecretRef.Name == obj.GetName()
+	case *corev1.ConfigMap:
+		return policy.Spec.JWT != nil && policy.Spec.JWT.JWKS.ConfigMapRef != nil &&
+			policy.Spec.JWT.JWKS.ConfigMapRef.Name == obj.GetName()
+	case *corev1.Service:
+		return policy.Spec.External != nil && string(policy.Spec.External.BackendRef.Name) == obj.GetName()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+)
+
+const testJWKS = `{"keys":[{"kty":"oct","kid":"test","k":"c2VjcmV0"}]}`
+
+func authPolicy(name string, jwks v1alpha1.JWKSSource, refs ...gatewayv1.LocalPolicyTargetReferenceWithSectionName) *v1alpha1.PingoraAuthPolicy {
+	return &v1alpha1.PingoraAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gateway-system"},
+		Spec: v1alpha1.PingoraAuthPolicySpec{
+			TargetRefs: refs,
+			JWT:        &v1alpha1.JWTAuth{JWKS: jwks},
+		},
+	}
+}
+
+func TestResolveJWKS(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: "gateway-system"},
+			Data: map[string][]byte{
+				"jwks.json":  []byte(testJWKS),
+				"empty.json": []byte(`{"keys":[]}`),
+				"bad.json":   []byte(`not json`),
+			},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: "gateway-system"},
+			Data:       map[string]string{"keys": testJWKS},
+		},
+	).Build()
+
+	tests := []struct {
+		name     string
+		source   v1alpha1.JWKSSource
+		expected string
+		wantErr  string
+	}{
+		{
+			name:   "URI",
+			source: v1alpha1.JWKSSource{URI: "https://issuer.example.com/jwks"},
+		},
+		{
+			name:     "secret with default key",
+			source:   v1alpha1.JWKSSource{SecretRef: &v1alpha1.KeyReference{Name: "jwks"}},
+			expected: testJWKS,
+		},
+		{
+			name:     "configmap key",
+			source:   v1alpha1.JWKSSource{ConfigMapRef: &v1alpha1.KeyReference{Name: "jwks", Key: "keys"}},
+			expected: testJWKS,
+		},
+		{
+			name:    "missing secret",
+			source:  v1alpha1.JWKSSource{SecretRef: &v1alpha1.KeyReference{Name: "missing"}},
+			wantErr: "failed to get JWKS secret",
+		},
+		{
+			name:    "missing key",
+			source:  v1alpha1.JWKSSource{ConfigMapRef: &v1alpha1.KeyReference{Name: "jwks"}},
+			wantErr: `has no key "jwks.json"`,
+		},
+		{
+			name:    "no keys",
+			source:  v1alpha1.JWKSSource{SecretRef: &v1alpha1.KeyReference{Name: "jwks", Key: "empty.json"}},
+			wantErr: "JWKS has no keys",
+		},
+		{
+			name:    "invalid JSON",
+			source:  v1alpha1.JWKSSource{SecretRef: &v1alpha1.KeyReference{Name: "jwks", Key: "bad.json"}},
+			wantErr: "failed to parse JWKS",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			jwks, err := resolveJWKS(context.Background(), fakeClient, authPolicy("auth", tt.source))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, jwks)
+		})
+	}
+}
+
+func TestAuthPolicyReferences(t *testing.T) {
+	t.Parallel()
+
+	policy := authPolicy("auth", v1alpha1.JWKSSource{SecretRef: &v1alpha1.KeyReference{Name: "jwks"}})
+
+	secret := func(name, namespace string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	assert.True(t, authPolicyReferences(policy, secret("jwks", "gateway-system")))
+	assert.False(t, authPolicyReferences(policy, secret("other", "gateway-system")))
+	assert.False(t, authPolicyReferences(policy, secret("jwks", "team-a")))
+	assert.False(t, authPolicyReferences(policy, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: "gateway-system"},
+	}))
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "authz", Namespace: "gateway-system"}}
+	assert.False(t, authPolicyReferences(policy, service))
+	assert.True(t, authPolicyReferences(externalAuthPolicy("authz", 9001), service))
+}
+
+func externalAuthPolicy(service string, port gatewayv1.PortNumber) *v1alpha1.PingoraAuthPolicy {
+	return &v1alpha1.PingoraAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "gateway-system"},
+		Spec: v1alpha1.PingoraAuthPolicySpec{
+			External: &v1alpha1.ExternalAuth{
+				Protocol:   v1alpha1.ExternalAuthProtocolHTTP,
+				BackendRef: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(service), Port: &port},
+			},
+		},
+	}
+}
+
+func TestValidateExternalAuth(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "authz", Namespace: "gateway-system"},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 9001}}},
+		},
+	).Build()
+
+	ctx := context.Background()
+
+	require.NoError(t, validateExternalAuth(ctx, fakeClient, externalAuthPolicy("authz", 9001)))
+
+	err := validateExternalAuth(ctx, fakeClient, externalAuthPolicy("authz", 8080))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no port 8080")
+
+	err = validateExternalAuth(ctx, fakeClient, externalAuthPolicy("missing", 9001))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get auth service")
+
+	require.NoError(t, validateExternalAuth(ctx, fakeClient, authPolicy("jwt", v1alpha1.JWKSSource{URI: "https://issuer.example.com/jwks"})))
+}
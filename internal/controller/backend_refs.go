@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/referencegrant"
+)
+
+// resolveBackendRefs reports whether the backendRefs of a route of routeKind
+// can be used by the builder. In addition to ingress.CheckBackendRefs,
+// references to other namespaces must be permitted by a ReferenceGrant, and
+// Service and PingoraBackend references must name an existing object;
+// lookups failing for other reasons do not change the status.
+func resolveBackendRefs(
+	ctx context.Context,
+	cli client.Client,
+	routeKind gatewayv1.Kind,
+	routeNamespace string,
+	refs []gatewayv1.BackendRef,
+) ingress.BackendRefsStatus {
+	status := ingress.CheckBackendRefs(refs)
+	if !status.Resolved {
+		return status
+	}
+
+	for i := range refs {
+		ref := &refs[i]
+		key := client.ObjectKey{Namespace: backendNamespace(routeNamespace, ref), Name: string(ref.Name)}
+
+		// CheckBackendRefs leaves only Services and PingoraBackends
+		var (
+			obj   client.Object = &corev1.Service{}
+			group               = ""
+			kind                = "Service"
+		)
+
+		if ingress.IsPingoraBackendRef(ref) {
+			obj, group, kind = &v1alpha1.PingoraBackend{}, v1alpha1.GroupVersion.Group, v1alpha1.PingoraBackendKind
+		}
+
+		allowed, err := referencegrant.NewValidator(cli).IsReferenceAllowed(ctx,
+			referencegrant.Reference{Group: gatewayv1.GroupName, Kind: string(routeKind), Namespace: routeNamespace},
+			referencegrant.Reference{Group: group, Kind: kind, Namespace: key.Namespace, Name: key.Name},
+		)
+		if err == nil && !allowed {
+			return ingress.BackendRefsStatus{
+				Resolved: false,
+				Reason:   gatewayv1.RouteReasonRefNotPermitted,
+				Message:  fmt.Sprintf("%s %s is not permitted by any ReferenceGrant", kind, key),
+			}
+		}
+
+		if err := cli.Get(ctx, key, obj); apierrors.IsNotFound(err) {
+			return ingress.BackendRefsStatus{
+				Resolved: false,
+				Reason:   gatewayv1.RouteReasonBackendNotFound,
+				Message:  fmt.Sprintf("%s %s not found", kind, key),
+			}
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+)
+
+func TestResolveBackendRefs(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&v1alpha1.PingoraBackend{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "granted"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "private"}},
+			&gatewayv1beta1.ReferenceGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "routes", Namespace: "granted"},
+				Spec: gatewayv1beta1.ReferenceGrantSpec{
+					From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "default"}},
+					To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Service"}},
+				},
+			},
+		).
+		Build()
+
+	group := gatewayv1.Group(v1alpha1.GroupVersion.Group)
+	kind := gatewayv1.Kind(v1alpha1.PingoraBackendKind)
+	bucket := gatewayv1.Kind("S3Bucket")
+	port := gatewayv1.PortNumber(80)
+
+	backendRef := func(name string) gatewayv1.BackendRef {
+		return gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: &group,
+			Kind:  &kind,
+			Name:  gatewayv1.ObjectName(name),
+		}}
+	}
+
+	service := gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "app", Port: &port}}
+	missingService := service
+	missingService.Name = "missing"
+	unsupported := service
+	unsupported.Kind = &bucket
+
+	inNamespace := func(ref gatewayv1.BackendRef, namespace string) gatewayv1.BackendRef {
+		ns := gatewayv1.Namespace(namespace)
+		ref.Namespace = &ns
+
+		return ref
+	}
+
+	tests := []struct {
+		name             string
+		refs             []gatewayv1.BackendRef
+		expectedResolved bool
+		expectedReason   gatewayv1.RouteConditionReason
+	}{
+		{
+			name:             "existing PingoraBackend is resolved",
+			refs:             []gatewayv1.BackendRef{service, backendRef("external")},
+			expectedResolved: true,
+			expectedReason:   gatewayv1.RouteReasonResolvedRefs,
+		},
+		{
+			name:             "missing PingoraBackend is not found",
+			refs:             []gatewayv1.BackendRef{backendRef("external"), backendRef("missing")},
+			expectedResolved: false,
+			expectedReason:   gatewayv1.RouteReasonBackendNotFound,
+		},
+		{
+			name:             "missing Service is not found",
+			refs:             []gatewayv1.BackendRef{service, missingService},
+			expectedResolved: false,
+			expectedReason:   gatewayv1.RouteReasonBackendNotFound,
+		},
+		{
+			name:             "cross-namespace Service permitted by a ReferenceGrant",
+			refs:             []gatewayv1.BackendRef{service, inNamespace(service, "granted")},
+			expectedResolved: true,
+			expectedReason:   gatewayv1.RouteReasonResolvedRefs,
+		},
+		{
+			name:             "cross-namespace Service without a ReferenceGrant is not permitted",
+			refs:             []gatewayv1.BackendRef{service, inNamespace(service, "private")},
+			expectedResolved: false,
+			expectedReason:   gatewayv1.RouteReasonRefNotPermitted,
+		},
+		{
+			name:             "invalid kind takes precedence",
+			refs:             []gatewayv1.BackendRef{backendRef("missing"), unsupported},
+			expectedResolved: false,
+			expectedReason:   gatewayv1.RouteReasonInvalidKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := resolveBackendRefs(context.Background(), fakeClient, routebinding.KindHTTPRoute, "default", tt.refs)
+			assert.Equal(t, tt.expectedResolved, status.Resolved)
+			assert.Equal(t, tt.expectedReason, status.Reason)
+			assert.NotEmpty(t, status.Message)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+// resolveBackendTLSPolicies reads the CA certificates of BackendTLSPolicies.
+// A policy is invalid if a caCertificateRef cannot be used, or if it
+// verifies subjectAltNames, which the proxy does not support; the Services
+// it targets then get no traffic rather than traffic the policy does not
+// protect.
+func resolveBackendTLSPolicies(
+	ctx context.Context,
+	cli client.Client,
+	policies []gatewayv1.BackendTLSPolicy,
+) ([]ingress.ResolvedBackendTLSPolicy, error) {
+	resolved := make([]ingress.ResolvedBackendTLSPolicy, 0, len(policies))
+
+	for i := range policies {
+		policy := &policies[i]
+
+		certificates, valid, err := backendCACertificates(ctx, cli, policy)
+		if err != nil {
+			return nil, err
+		}
+
+		resolved = append(resolved, ingress.ResolvedBackendTLSPolicy{
+			Policy:         policy,
+			CACertificates: certificates,
+			Invalid:        !valid || len(policy.Spec.Validation.SubjectAltNames) > 0,
+		})
+	}
+
+	return resolved, nil
+}
+
+// backendCACertificates returns the CA certificates a BackendTLSPolicy
+// verifies backends against, or none for the system roots. The boolean is
+// false if the CA certificates cannot be used.
+func backendCACertificates(
+	ctx context.Context,
+	cli client.Client,
+	policy *gatewayv1.BackendTLSPolicy,
+) ([]byte, bool, error) {
+	validation := policy.Spec.Validation
+
+	if validation.WellKnownCACertificates != nil {
+		return nil, *validation.WellKnownCACertificates == gatewayv1.WellKnownCACertificatesSystem &&
+			len(validation.CACertificateRefs) == 0, nil
+	}
+
+	if len(validation.CACertificateRefs) == 0 {
+		return nil, false, nil
+	}
+
+	var bundle bytes.Buffer
+
+	for _, ref := range validation.CACertificateRefs {
+		if ref.Group != "" || (ref.Kind != "ConfigMap" && ref.Kind != "Secret") {
+			return nil, false, nil
+		}
+
+		key := client.ObjectKey{Namespace: policy.Namespace, Name: string(ref.Name)}
+
+		data, err := caCertificateData(ctx, cli, string(ref.Kind), key)
+		if apierrors.IsNotFound(err) {
+			return nil, false, nil
+		}
+
+		if err != nil {
+			return nil, false, err
+		}
+
+		certificates, err := ingress.ParseCACertificates(fmt.Sprintf("%s %s", ref.Kind, key), data)
+		if err != nil {
+			return nil, false, nil //nolint:nilerr // an unusable certificate invalidates the policy
+		}
+
+		bundle.Write(certificates)
+
+		if !bytes.HasSuffix(certificates, []byte("\n")) {
+			bundle.WriteByte('\n')
+		}
+	}
+
+	return bundle.Bytes(), true, nil
+}
+
+// backendTLSPolicyServices maps a BackendTLSPolicy, or a ConfigMap or Secret
+// holding the CA certificates of BackendTLSPolicies, to the Services the
+// policies are attached to.
+func backendTLSPolicyServices(ctx context.Context, cli client.Reader, obj client.Object) []client.Object {
+	var policies []gatewayv1.BackendTLSPolicy
+
+	if policy, ok := obj.(*gatewayv1.BackendTLSPolicy); ok {
+		policies = []gatewayv1.BackendTLSPolicy{*policy}
+	} else {
+		var policyList gatewayv1.BackendTLSPolicyList
+		if err := cli.List(ctx, &policyList, client.InNamespace(obj.GetNamespace())); err != nil {
+			return nil
+		}
+
+		for i := range policyList.Items {
+			if backendTLSPolicyReferences(&policyList.Items[i], obj) {
+				policies = append(policies, policyList.Items[i])
+			}
+		}
+	}
+
+	var services []client.Object
+
+	for i := range policies {
+		for _, ref := range policies[i].Spec.TargetRefs {
+			if ref.Group != "" || ref.Kind != ingress.PolicyTargetService {
+				continue
+			}
+
+			services = append(services, &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Namespace: policies[i].Namespace,
+				Name:      string(ref.Name),
+			}})
+		}
+	}
+
+	return services
+}
+
+// backendTLSPolicyReferences reports whether a BackendTLSPolicy reads CA
+// certificates from the ConfigMap or Secret.
+func backendTLSPolicyReferences(policy *gatewayv1.BackendTLSPolicy, obj client.Object) bool {
+	if policy.Namespace != obj.GetNamespace() {
+		return false
+	}
+
+	kind := "ConfigMap"
+	if _, ok := obj.(*corev1.Secret); ok {
+		kind = "Secret"
+	}
+
+	for _, ref := range policy.Spec.Validation.CACertificateRefs {
+		if ref.Group == "" && string(ref.Kind) == kind && string(ref.Name) == obj.GetName() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// routesForServices maps Services to routes with a Service mapper, such as
+// findRoutesForService of a route reconciler.
+func routesForServices(
+	ctx context.Context,
+	services []client.Object,
+	findRoutes func(context.Context, client.Object) []reconcile.Request,
+) []reconcile.Request {
+	var requests []reconcile.Request
+
+	for _, service := range services {
+		requests = append(requests, findRoutes(ctx, service)...)
+	}
+
+	return requests
+}
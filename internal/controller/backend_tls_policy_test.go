@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func backendTLSPolicy(name string, validation gatewayv1.BackendTLSPolicyValidation) gatewayv1.BackendTLSPolicy {
+	return gatewayv1.BackendTLSPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: gatewayv1.BackendTLSPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{{
+				LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{Kind: "Service", Name: "api"},
+			}},
+			Validation: validation,
+		},
+	}
+}
+
+func TestResolveBackendTLSPolicies(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	rootCA, _ := generateKeyPair(t, "Root CA")
+	partnerCA, _ := generateKeyPair(t, "Partner CA")
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "root-ca"},
+				Data:       map[string]string{"ca.crt": string(rootCA)},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "partner-ca"},
+				Data:       map[string][]byte{"ca.crt": partnerCA},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "empty-ca"},
+				Data:       map[string]string{"ca.crt": ""},
+			},
+		).
+		Build()
+
+	system := gatewayv1.WellKnownCACertificatesSystem
+
+	tests := []struct {
+		name            string
+		validation      gatewayv1.BackendTLSPolicyValidation
+		expectedCA      []byte
+		expectedInvalid bool
+	}{
+		{
+			name:       "system roots",
+			validation: gatewayv1.BackendTLSPolicyValidation{WellKnownCACertificates: &system},
+		},
+		{
+			name: "configmap and secret",
+			validation: gatewayv1.BackendTLSPolicyValidation{CACertificateRefs: []gatewayv1.LocalObjectReference{
+				{Kind: "ConfigMap", Name: "root-ca"},
+				{Kind: "Secret", Name: "partner-ca"},
+			}},
+			expectedCA: append(append([]byte{}, rootCA...), partnerCA...),
+		},
+		{
+			name: "missing configmap",
+			validation: gatewayv1.BackendTLSPolicyValidation{CACertificateRefs: []gatewayv1.LocalObjectReference{
+				{Kind: "ConfigMap", Name: "missing-ca"},
+			}},
+			expectedInvalid: true,
+		},
+		{
+			name: "no certificate",
+			validation: gatewayv1.BackendTLSPolicyValidation{CACertificateRefs: []gatewayv1.LocalObjectReference{
+				{Kind: "ConfigMap", Name: "empty-ca"},
+			}},
+			expectedInvalid: true,
+		},
+		{
+			name: "unsupported kind",
+			validation: gatewayv1.BackendTLSPolicyValidation{CACertificateRefs: []gatewayv1.LocalObjectReference{
+				{Group: "example.com", Kind: "Bundle", Name: "root-ca"},
+			}},
+			expectedInvalid: true,
+		},
+		{
+			name: "subject alt names",
+			validation: gatewayv1.BackendTLSPolicyValidation{
+				WellKnownCACertificates: &system,
+				SubjectAltNames:         []gatewayv1.SubjectAltName{{Type: gatewayv1.HostnameSubjectAltNameType, Hostname: "api"}},
+			},
+			expectedInvalid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			validation := tt.validation
+			validation.Hostname = "api.example.com"
+
+			resolved, err := resolveBackendTLSPolicies(context.Background(), cli,
+				[]gatewayv1.BackendTLSPolicy{backendTLSPolicy("tls", validation)})
+			require.NoError(t, err)
+			require.Len(t, resolved, 1)
+
+			assert.Equal(t, tt.expectedInvalid, resolved[0].Invalid)
+
+			if !tt.expectedInvalid {
+				assert.Equal(t, tt.expectedCA, resolved[0].CACertificates)
+			}
+		})
+	}
+}
+
+func TestBackendTLSPolicyServices(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	policy := backendTLSPolicy("tls", gatewayv1.BackendTLSPolicyValidation{
+		CACertificateRefs: []gatewayv1.LocalObjectReference{{Kind: "ConfigMap", Name: "root-ca"}},
+	})
+	policy.Spec.TargetRefs = append(policy.Spec.TargetRefs, gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+			Group: "example.com", Kind: "Backend", Name: "external",
+		},
+	})
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&policy).Build()
+	ctx := context.Background()
+
+	names := func(objects []client.Object) []string {
+		var keys []string
+		for _, obj := range objects {
+			keys = append(keys, obj.GetNamespace()+"/"+obj.GetName())
+		}
+
+		return keys
+	}
+
+	assert.Equal(t, []string{"default/api"}, names(backendTLSPolicyServices(ctx, cli, &policy)))
+
+	assert.Equal(t, []string{"default/api"}, names(backendTLSPolicyServices(ctx, cli, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "root-ca"},
+	})))
+
+	// A Secret with the name of the ConfigMap is not referenced
+	assert.Empty(t, backendTLSPolicyServices(ctx, cli, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "root-ca"},
+	}))
+
+	assert.Empty(t, backendTLSPolicyServices(ctx, cli, &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "other", Name: "root-ca"},
+	}))
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+)
+
+// versionedObject is a route whose identity and spec generation key its
+// cached binding results.
+type versionedObject interface {
+	GetNamespace() string
+	GetName() string
+	GetUID() types.UID
+	GetGeneration() int64
+}
+
+// bindingKey identifies a parentRef of a route.
+type bindingKey struct {
+	kind   gatewayv1.Kind
+	route  types.NamespacedName
+	parent int
+}
+
+// cachedBinding is the binding result of a parentRef for the given route
+// and Gateway specs.
+type cachedBinding struct {
+	routeUID          types.UID
+	routeGeneration   int64
+	gatewayUID        types.UID
+	gatewayGeneration int64
+	epoch             uint64
+	result            routebinding.BindingResult
+}
+
+// BindingCache keeps the binding results of route parentRefs, so that the
+// route syncer and the Gateway reconciler validate a binding once instead of
+// on every sync and every Gateway reconcile.
+//
+// A result is reused while the spec generations of the route and its Gateway
+// are unchanged. Binding also depends on Namespace labels, for listeners
+// selecting route namespaces, and on ReferenceGrants, for
+// RequireParentRefGrants; changes to either drop every result.
+//
+// The cache is safe for concurrent use.
+type BindingCache struct {
+	validator *routebinding.Validator
+
+	mu      sync.Mutex
+	epoch   uint64
+	entries map[bindingKey]cachedBinding
+
+	// hits and validations count the results reused and validated.
+	hits, validations uint64
+}
+
+// NewBindingCache creates a BindingCache validating bindings with validator.
+func NewBindingCache(validator *routebinding.Validator) *BindingCache {
+	return &BindingCache{
+		validator: validator,
+		entries:   make(map[bindingKey]cachedBinding),
+	}
+}
+
+// Validator returns the validator of the cache. Options set on it must be
+// set before the first binding is validated.
+func (c *BindingCache) Validator() *routebinding.Validator {
+	return c.validator
+}
+
+// Binding returns the binding result of the parentRef at index parent of
+// route to gateway, validating it only if the route, the Gateway, a
+// Namespace or a ReferenceGrant changed since it was cached.
+func (c *BindingCache) Binding(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+	route versionedObject,
+	parent int,
+	info *routebinding.RouteInfo,
+) (routebinding.BindingResult, error) {
+	key := bindingKey{
+		kind:   info.Kind,
+		route:  types.NamespacedName{Namespace: route.GetNamespace(), Name: route.GetName()},
+		parent: parent,
+	}
+
+	c.mu.Lock()
+	epoch := c.epoch
+	entry, ok := c.entries[key]
+
+	if ok && entry.epoch == epoch &&
+		entry.routeUID == route.GetUID() && entry.routeGeneration == route.GetGeneration() &&
+		entry.gatewayUID == gateway.UID && entry.gatewayGeneration == gateway.Generation {
+		c.hits++
+		c.mu.Unlock()
+
+		return entry.result, nil
+	}
+
+	c.mu.Unlock()
+
+	result, err := c.validator.ValidateBinding(ctx, gateway, info)
+	if err != nil {
+		return routebinding.BindingResult{}, errors.Wrap(err, "failed to validate route binding")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.validations++
+
+	// A result validated before an invalidation is stored with the old
+	// epoch, so it is validated again on the next lookup.
+	c.entries[key] = cachedBinding{
+		routeUID:          route.GetUID(),
+		routeGeneration:   route.GetGeneration(),
+		gatewayUID:        gateway.UID,
+		gatewayGeneration: gateway.Generation,
+		epoch:             epoch,
+		result:            result,
+	}
+
+	return result, nil
+}
+
+// Retain drops the results of routes of the given kind that are not in
+// routes, the namespace/name keys of the existing routes.
+func (c *BindingCache) Retain(kind gatewayv1.Kind, routes map[types.NamespacedName]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	maps.DeleteFunc(c.entries, func(key bindingKey, _ cachedBinding) bool {
+		return key.kind == kind && !routes[key.route]
+	})
+}
+
+// Invalidate drops every result.
+func (c *BindingCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.epoch++
+	clear(c.entries)
+}
+
+// SetupWithManager invalidates the cache on Namespace label changes and
+// ReferenceGrant events seen by the manager cache. Without the ReferenceGrant
+// CRD there are no grants to watch.
+func (c *BindingCache) SetupWithManager(ctx context.Context, mgr ctrl.Manager, missing MissingResources) error {
+	namespaces, err := mgr.GetCache().GetInformer(ctx, &corev1.Namespace{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace informer")
+	}
+
+	_, err = namespaces.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj any) {
+			oldNamespace, oldOK := oldObj.(*corev1.Namespace)
+			newNamespace, newOK := newObj.(*corev1.Namespace)
+
+			if !oldOK || !newOK || !maps.Equal(oldNamespace.Labels, newNamespace.Labels) {
+				c.Invalidate()
+			}
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch namespaces for binding cache")
+	}
+
+	if missing.Has(OptionalResourceReferenceGrant) {
+		return nil
+	}
+
+	grants, err := mgr.GetCache().GetInformer(ctx, &gatewayv1beta1.ReferenceGrant{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get referencegrant informer")
+	}
+
+	invalidate := func(any) { c.Invalidate() }
+
+	_, err = grants.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    invalidate,
+		UpdateFunc: func(any, any) { c.Invalidate() },
+		DeleteFunc: invalidate,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to watch referencegrants for binding cache")
+	}
+
+	return nil
+}
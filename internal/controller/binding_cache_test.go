@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+)
+
+func TestBindingCache(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	team := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"gateway": "shared"}}}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(team).Build()
+
+	gateway := newGateway("shared", "pingora", gatewayv1.NamespacesFromSelector)
+	gateway.UID = "gateway-uid"
+	gateway.Generation = 1
+	gateway.Spec.Listeners[0].AllowedRoutes.Namespaces.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{"gateway": "shared"},
+	}
+
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+		Name: "web", Namespace: "team-a", UID: "route-uid", Generation: 1,
+	}}
+	info := &routebinding.RouteInfo{Name: "web", Namespace: "team-a", Kind: routebinding.KindHTTPRoute}
+
+	cache := NewBindingCache(routebinding.NewValidator(cli))
+	ctx := context.Background()
+
+	binding := func() routebinding.BindingResult {
+		t.Helper()
+
+		result, err := cache.Binding(ctx, gateway, route, 0, info)
+		require.NoError(t, err)
+
+		return result
+	}
+
+	assert.True(t, binding().Accepted)
+	assert.True(t, binding().Accepted)
+	assert.Equal(t, uint64(1), cache.hits)
+	assert.Equal(t, uint64(1), cache.validations)
+
+	// Namespace labels are only seen after an invalidation
+	team.Labels = nil
+	require.NoError(t, cli.Update(ctx, team))
+
+	assert.True(t, binding().Accepted)
+
+	cache.Invalidate()
+
+	assert.False(t, binding().Accepted)
+	assert.Equal(t, uint64(2), cache.validations)
+
+	// Spec changes of the route or the Gateway are validated again
+	route.Generation = 2
+	binding()
+
+	gateway.Generation = 2
+	binding()
+
+	// A recreated route is validated again
+	route.UID = "recreated-uid"
+	binding()
+
+	assert.Equal(t, uint64(5), cache.validations)
+
+	cache.Retain(routebinding.KindGRPCRoute, nil)
+	assert.Len(t, cache.entries, 1)
+
+	cache.Retain(routebinding.KindHTTPRoute, map[types.NamespacedName]bool{{Namespace: "team-a", Name: "other"}: true})
+	assert.Empty(t, cache.entries)
+}
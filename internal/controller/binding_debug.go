@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sort"
+
+	"github.com/cockroachdb/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// BindingDebugAnnotation is the route annotation holding the last binding
+// evaluation when binding debug annotations are enabled.
+const BindingDebugAnnotation = "pingora.k8s.lex.la/binding-debug"
+
+// bindingDebug is the compact JSON written to BindingDebugAnnotation.
+type bindingDebug struct {
+	Generation int64                `json:"generation"`
+	Parents    []parentBindingDebug `json:"parents"`
+}
+
+// parentBindingDebug describes the binding result for a single parentRef.
+type parentBindingDebug struct {
+	Index       int      `json:"index"`
+	Gateway     string   `json:"gateway"`
+	SectionName string   `json:"sectionName,omitempty"`
+	Accepted    bool     `json:"accepted"`
+	Reason      string   `json:"reason,omitempty"`
+	Message     string   `json:"message,omitempty"`
+	Listeners   []string `json:"listeners,omitempty"`
+	Rejected    []string `json:"rejectedListeners,omitempty"`
+}
+
+// bindingDebugValue renders the binding results of a route as compact JSON.
+// Parents are ordered by their position in spec.parentRefs.
+func bindingDebugValue(
+	route client.Object,
+	parentRefs []gatewayv1.ParentReference,
+	info routeBindingInfo,
+) (string, error) {
+	indexes := make([]int, 0, len(info.bindingResults))
+	for idx := range info.bindingResults {
+		indexes = append(indexes, idx)
+	}
+
+	sort.Ints(indexes)
+
+	debug := bindingDebug{
+		Generation: route.GetGeneration(),
+		Parents:    make([]parentBindingDebug, 0, len(indexes)),
+	}
+
+	for _, idx := range indexes {
+		result := info.bindingResults[idx]
+		ref := parentRefs[idx]
+
+		namespace := route.GetNamespace()
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		parent := parentBindingDebug{
+			Index:    idx,
+			Gateway:  namespace + "/" + string(ref.Name),
+			Accepted: result.Accepted,
+			Reason:   string(result.Reason),
+			Message:  result.Message,
+		}
+
+		if ref.SectionName != nil {
+			parent.SectionName = string(*ref.SectionName)
+		}
+
+		for _, listener := range result.MatchedListeners {
+			parent.Listeners = append(parent.Listeners, string(listener))
+		}
+
+		for _, rejection := range result.RejectedListeners {
+			parent.Rejected = append(parent.Rejected, rejectedListener(rejection))
+		}
+
+		debug.Parents = append(debug.Parents, parent)
+	}
+
+	data, err := json.Marshal(debug)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal binding debug annotation")
+	}
+
+	return string(data), nil
+}
+
+// annotateBindingDebug writes the binding results to the route's debug annotation.
+func (s *PingoraRouteSyncer) annotateBindingDebug(
+	ctx context.Context,
+	logger *slog.Logger,
+	route client.Object,
+	parentRefs []gatewayv1.ParentReference,
+	info routeBindingInfo,
+) {
+	value, err := bindingDebugValue(route, parentRefs, info)
+	if err != nil {
+		logger.Warn("failed to build binding debug annotation",
+			"route", route.GetNamespace()+"/"+route.GetName(), "error", err)
+
+		return
+	}
+
+	s.annotateRoute(ctx, logger, route, BindingDebugAnnotation, value)
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+)
+
+func debugTestRoute() *gatewayv1.HTTPRoute {
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+	sectionName := gatewayv1.SectionName("https")
+
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a", Generation: 3},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{
+					{Name: "other-class"},
+					{Name: "public", Namespace: &gatewayNamespace},
+					{Name: "internal", Namespace: &gatewayNamespace, SectionName: &sectionName},
+				},
+			},
+		},
+	}
+}
+
+func debugTestBindings() routeBindingInfo {
+	return routeBindingInfo{
+		bindingResults: map[int]routebinding.BindingResult{
+			2: {
+				Accepted: false,
+				Reason:   gatewayv1.RouteReasonNotAllowedByListeners,
+				Message:  "Route not allowed by listener allowedRoutes policy",
+			},
+			1: {
+				Accepted:         true,
+				Reason:           gatewayv1.RouteReasonAccepted,
+				Message:          "Route accepted",
+				MatchedListeners: []gatewayv1.SectionName{"http", "https"},
+				RejectedListeners: []routebinding.ListenerRejection{
+					{Name: "admin", Reason: gatewayv1.RouteReasonNotAllowedByListeners},
+				},
+			},
+		},
+	}
+}
+
+func TestBindingDebugValue(t *testing.T) {
+	t.Parallel()
+
+	route := debugTestRoute()
+
+	value, err := bindingDebugValue(route, route.Spec.ParentRefs, debugTestBindings())
+	require.NoError(t, err)
+
+	var debug bindingDebug
+	require.NoError(t, json.Unmarshal([]byte(value), &debug))
+
+	assert.Equal(t, int64(3), debug.Generation)
+	assert.Equal(t, []parentBindingDebug{
+		{
+			Index:     1,
+			Gateway:   "gateway-system/public",
+			Accepted:  true,
+			Reason:    "Accepted",
+			Message:   "Route accepted",
+			Listeners: []string{"http", "https"},
+			Rejected:  []string{"admin (NotAllowedByListeners)"},
+		},
+		{
+			Index:       2,
+			Gateway:     "gateway-system/internal",
+			SectionName: "https",
+			Accepted:    false,
+			Reason:      "NotAllowedByListeners",
+			Message:     "Route not allowed by listener allowedRoutes policy",
+		},
+	}, debug.Parents)
+}
+
+func TestAnnotateBindingDebug(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	route := debugTestRoute()
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(route).Build()
+	syncer := &PingoraRouteSyncer{Client: cli}
+	ctx := context.Background()
+
+	var current gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &current))
+
+	syncer.annotateBindingDebug(ctx, slog.Default(), &current, current.Spec.ParentRefs, debugTestBindings())
+
+	var annotated gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &annotated))
+
+	value := annotated.Annotations[BindingDebugAnnotation]
+	require.NotEmpty(t, value)
+	assert.Contains(t, value, `"gateway":"gateway-system/public"`)
+
+	// An unchanged evaluation must not patch the route again
+	syncer.annotateBindingDebug(ctx, slog.Default(), &annotated, annotated.Spec.ParentRefs, debugTestBindings())
+
+	var unchanged gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &unchanged))
+	assert.Equal(t, annotated.ResourceVersion, unchanged.ResourceVersion)
+}
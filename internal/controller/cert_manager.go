@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
+)
+
+// EventReasonCertificateFailed is emitted on a Gateway when a cert-manager
+// Certificate it asks for cannot be applied, such as when cert-manager is
+// not installed.
+const EventReasonCertificateFailed = "CertificateFailed"
+
+// EventReasonCertificateConflict is emitted on a Gateway when a
+// cert-manager Certificate it asks for already exists and was not created
+// by the controller for it, such as by the cert-manager gateway-shim.
+const EventReasonCertificateConflict = "CertificateConflict"
+
+// CertificateFieldManager is the field manager of the cert-manager
+// Certificates applied by the controller. Fields other managers set are not
+// taken over: applying them fails with a conflict instead.
+const CertificateFieldManager = "pingora-gateway-controller-certificates"
+
+// applyCertificates creates or updates the cert-manager Certificates the
+// issuer annotation of a Gateway asks for, skipping existing Certificates
+// the controller did not create for it. Failures are logged and reported
+// with an Event without failing the reconcile, so that the status of the
+// Gateway is still updated.
+func (r *PingoraGatewayReconciler) applyCertificates(ctx context.Context, gateway *gatewayv1.Gateway) {
+	logger := logging.FromContext(ctx)
+
+	for _, certificate := range ingress.CertManagerCertificates(gateway) {
+		owned, err := r.ownsCertificate(ctx, gateway, certificate)
+		if err == nil && !owned {
+			logger.Info("skipping cert-manager Certificate not created by the controller",
+				"gateway", gateway.Namespace+"/"+gateway.Name, "certificate", certificate.GetName())
+
+			if r.Recorder != nil {
+				r.Recorder.Eventf(gateway, corev1.EventTypeWarning, EventReasonCertificateConflict,
+					"cert-manager Certificate %s exists and was not created for this Gateway", certificate.GetName())
+			}
+
+			continue
+		}
+
+		if err == nil {
+			err = r.Patch(ctx, certificate, client.Apply, client.FieldOwner(CertificateFieldManager))
+		}
+
+		if err == nil {
+			continue
+		}
+
+		logger.Error("failed to apply cert-manager Certificate",
+			"gateway", gateway.Namespace+"/"+gateway.Name, "certificate", certificate.GetName(), "error", err)
+
+		if r.Recorder != nil {
+			r.Recorder.Eventf(gateway, corev1.EventTypeWarning, EventReasonCertificateFailed,
+				"Failed to apply cert-manager Certificate %s: %v", certificate.GetName(), err)
+		}
+	}
+}
+
+// ownsCertificate reports whether certificate may be applied for gateway:
+// it does not exist yet, or the controller created it for the Gateway.
+func (r *PingoraGatewayReconciler) ownsCertificate(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+	certificate *unstructured.Unstructured,
+) (bool, error) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(certificate.GroupVersionKind())
+
+	err := r.Get(ctx, client.ObjectKeyFromObject(certificate), existing)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get Certificate")
+	}
+
+	return ingress.OwnsCertManagerCertificate(gateway, existing), nil
+}
+
+// secretToGateways maps Secret events to the Gateways of our GatewayClass
+// whose listeners reference the Secret as certificate or CA certificate, so
+// that they become programmed as soon as a pending certificate is issued.
+func (r *PingoraGatewayReconciler) secretToGateways(ctx context.Context, obj client.Object) []reconcile.Request {
+	var gatewayList gatewayv1.GatewayList
+	if err := r.List(ctx, &gatewayList); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range gatewayList.Items {
+		gateway := &gatewayList.Items[i]
+		if string(gateway.Spec.GatewayClassName) != r.GatewayClassName {
+			continue
+		}
+
+		if ingress.ReferencesCACertificate(gateway, "Secret", obj.GetNamespace(), obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(gateway)})
+
+			continue
+		}
+
+		for _, ref := range ingress.ListenerCertificateRefs(gateway) {
+			if ref.Namespace == obj.GetNamespace() && ref.Name == obj.GetName() {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(gateway)})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+func newCertManagerTestGateway(className string) *gatewayv1.Gateway {
+	hostname := gatewayv1.Hostname("app.example.com")
+
+	return &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "web-" + className,
+			Annotations: map[string]string{ingress.CertManagerIssuerAnnotation: "internal-ca"},
+		},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayv1.ObjectName(className),
+			Listeners: []gatewayv1.Listener{{
+				Name:     "https",
+				Protocol: gatewayv1.HTTPSProtocolType,
+				Port:     443,
+				Hostname: &hostname,
+				TLS:      &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "app-tls"}}},
+			}},
+		},
+	}
+}
+
+func TestPingoraGatewayReconciler_ApplyCertificates(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	t.Run("applies the certificates", func(t *testing.T) {
+		t.Parallel()
+
+		cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &PingoraGatewayReconciler{Client: cli, GatewayClassName: "pingora", Recorder: recorder}
+
+		reconciler.applyCertificates(context.Background(), newCertManagerTestGateway("pingora"))
+
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(ingress.CertManagerCertificateGVK)
+		require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "app-tls"}, certificate))
+
+		issuer, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "name")
+		assert.Equal(t, "internal-ca", issuer)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("updates the certificates it created", func(t *testing.T) {
+		t.Parallel()
+
+		cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &PingoraGatewayReconciler{Client: cli, GatewayClassName: "pingora", Recorder: recorder}
+
+		gateway := newCertManagerTestGateway("pingora")
+		gateway.Annotations[ingress.CertManagerIssuerAnnotation] = "other-ca"
+		reconciler.applyCertificates(context.Background(), gateway)
+
+		gateway.Annotations[ingress.CertManagerIssuerAnnotation] = "internal-ca"
+		reconciler.applyCertificates(context.Background(), gateway)
+
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(ingress.CertManagerCertificateGVK)
+		require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "app-tls"}, certificate))
+
+		issuer, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "name")
+		assert.Equal(t, "internal-ca", issuer)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("does not take over fields of other managers", func(t *testing.T) {
+		t.Parallel()
+
+		gateway := newCertManagerTestGateway("pingora")
+
+		// Created for the Gateway, then edited by someone else
+		existing := ingress.CertManagerCertificates(gateway)[0]
+		_ = unstructured.SetNestedField(existing.Object, "other-ca", "spec", "issuerRef", "name")
+
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &PingoraGatewayReconciler{Client: cli, GatewayClassName: "pingora", Recorder: recorder}
+
+		reconciler.applyCertificates(context.Background(), gateway)
+
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(ingress.CertManagerCertificateGVK)
+		require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "app-tls"}, certificate))
+
+		issuer, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "name")
+		assert.Equal(t, "other-ca", issuer)
+
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, EventReasonCertificateFailed)
+	})
+
+	t.Run("skips certificates it did not create", func(t *testing.T) {
+		t.Parallel()
+
+		gateway := newCertManagerTestGateway("pingora")
+
+		// Created by the cert-manager gateway-shim: owned by the Gateway, without our label
+		existing := ingress.CertManagerCertificates(gateway)[0]
+		existing.SetLabels(nil)
+		_ = unstructured.SetNestedField(existing.Object, "other-ca", "spec", "issuerRef", "name")
+
+		cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &PingoraGatewayReconciler{Client: cli, GatewayClassName: "pingora", Recorder: recorder}
+
+		reconciler.applyCertificates(context.Background(), gateway)
+
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(ingress.CertManagerCertificateGVK)
+		require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "app-tls"}, certificate))
+
+		issuer, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "name")
+		assert.Equal(t, "other-ca", issuer)
+
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, EventReasonCertificateConflict)
+	})
+
+	t.Run("reports failures with an event", func(t *testing.T) {
+		t.Parallel()
+
+		cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+		recorder := record.NewFakeRecorder(10)
+		reconciler := &PingoraGatewayReconciler{
+			Client:           failingPatchClient{Client: cli},
+			GatewayClassName: "pingora",
+			Recorder:         recorder,
+		}
+
+		reconciler.applyCertificates(context.Background(), newCertManagerTestGateway("pingora"))
+
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, EventReasonCertificateFailed)
+	})
+}
+
+// failingPatchClient fails every patch, as when cert-manager is not installed.
+type failingPatchClient struct {
+	client.Client
+}
+
+func (failingPatchClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return &meta.NoKindMatchError{GroupKind: ingress.CertManagerCertificateGVK.GroupKind()}
+}
+
+func TestPingoraGatewayReconciler_SecretToGateways(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newCertManagerTestGateway("pingora"), newCertManagerTestGateway("other")).
+		Build()
+	reconciler := &PingoraGatewayReconciler{Client: cli, GatewayClassName: "pingora"}
+
+	requests := reconciler.secretToGateways(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app-tls"},
+	})
+	require.Len(t, requests, 1)
+	assert.Equal(t, "web-pingora", requests[0].Name)
+
+	assert.Empty(t, reconciler.secretToGateways(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unrelated"},
+	}))
+}
@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/referencegrant"
+)
+
+// ListenerReasonCertificatePending means the Secret of a certificateRef
+// does not exist yet, but the cert-manager issuer annotation of the Gateway
+// asks for it to be issued.
+const ListenerReasonCertificatePending = "CertificatePending"
+
+// certificateRefProblem is why a certificateRef of a listener cannot be
+// used, with the reasons of the ResolvedRefs and Programmed conditions.
+type certificateRefProblem struct {
+	resolvedReason   gatewayv1.ListenerConditionReason
+	programmedReason string
+	message          string
+}
+
+// listenerCertificateProblem checks the certificateRefs of a listener that
+// terminates TLS, and the caCertificateRefs of its frontend validation, and
+// returns the first that cannot be used, nil if all of them can.
+//
+//nolint:funlen // one check per way a reference can fail
+func listenerCertificateProblem(
+	ctx context.Context,
+	cli client.Client,
+	gateway *gatewayv1.Gateway,
+	listener *gatewayv1.Listener,
+) (*certificateRefProblem, error) {
+	if listener.TLS == nil || (listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1.TLSModeTerminate) {
+		return nil, nil //nolint:nilnil // no certificates to check
+	}
+
+	invalid := func(format string, args ...any) *certificateRefProblem {
+		return &certificateRefProblem{
+			resolvedReason:   gatewayv1.ListenerReasonInvalidCertificateRef,
+			programmedReason: string(gatewayv1.ListenerReasonInvalid),
+			message:          fmt.Sprintf(format, args...),
+		}
+	}
+
+	for _, ref := range listener.TLS.CertificateRefs {
+		if !ingress.IsSecretRef(ref) {
+			return invalid("certificateRef %s is not a Secret", ref.Name), nil
+		}
+
+		namespace := gateway.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		allowed, err := referencegrant.NewValidator(cli).IsReferenceAllowed(ctx,
+			referencegrant.Reference{
+				Group:     gatewayv1.GroupName,
+				Kind:      kindGateway,
+				Namespace: gateway.Namespace,
+				Name:      gateway.Name,
+			},
+			referencegrant.Reference{Kind: "Secret", Namespace: namespace, Name: string(ref.Name)},
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to check ReferenceGrants")
+		}
+
+		if !allowed {
+			return &certificateRefProblem{
+				resolvedReason:   gatewayv1.ListenerReasonRefNotPermitted,
+				programmedReason: string(gatewayv1.ListenerReasonInvalid),
+				message:          fmt.Sprintf("Secret %s/%s is not permitted by any ReferenceGrant", namespace, ref.Name),
+			}, nil
+		}
+
+		var secret corev1.Secret
+
+		err = cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: string(ref.Name)}, &secret)
+		if apierrors.IsNotFound(err) {
+			if _, issuer, ok := ingress.CertManagerIssuer(gateway); ok && namespace == gateway.Namespace {
+				problem := invalid("Secret %s/%s not found, waiting for cert-manager to issue it with %s",
+					namespace, ref.Name, issuer)
+				problem.programmedReason = ListenerReasonCertificatePending
+
+				return problem, nil
+			}
+
+			return invalid("Secret %s/%s not found", namespace, ref.Name), nil
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get secret %s/%s", namespace, ref.Name)
+		}
+
+		if _, err := ingress.CertificateFromSecret(&secret, nil); err != nil {
+			return invalid("%v", err), nil
+		}
+	}
+
+	if validation := ingress.FrontendValidation(gateway, listener); validation != nil {
+		_, problem, err := resolveFrontendValidation(ctx, cli, gateway, validation)
+
+		return problem, err
+	}
+
+	return nil, nil //nolint:nilnil // all certificates usable
+}
+
+// setCertificateProblem marks a listener neither programmed nor with
+// resolved references because of a certificateRef.
+func setCertificateProblem(conditions []metav1.Condition, problem *certificateRefProblem) {
+	for i := range conditions {
+		condition := &conditions[i]
+
+		switch condition.Type {
+		case string(gatewayv1.ListenerConditionProgrammed):
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = problem.programmedReason
+			condition.Message = problem.message
+		case string(gatewayv1.ListenerConditionResolvedRefs):
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = string(problem.resolvedReason)
+			condition.Message = problem.message
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+func TestListenerCertificateProblem(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cert, key := generateKeyPair(t, "app.example.com")
+	_, otherKey := generateKeyPair(t, "other.example.com")
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app-tls"},
+				Type:       corev1.SecretTypeTLS,
+				Data:       map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "broken-tls"},
+				Type:       corev1.SecretTypeTLS,
+				Data:       map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: otherKey},
+			},
+		).
+		Build()
+
+	certs := gatewayv1.Namespace("certs")
+	configMap := gatewayv1.Kind("ConfigMap")
+	passthrough := gatewayv1.TLSModePassthrough
+
+	tests := []struct {
+		name               string
+		annotations        map[string]string
+		tls                *gatewayv1.ListenerTLSConfig
+		frontendTLS        *gatewayv1.GatewayTLSConfig
+		expectedResolved   gatewayv1.ListenerConditionReason
+		expectedProgrammed string
+		expectedMessage    string
+	}{
+		{
+			name: "valid secret",
+			tls:  &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "app-tls"}}},
+		},
+		{
+			name: "passthrough",
+			tls: &gatewayv1.ListenerTLSConfig{
+				Mode:            &passthrough,
+				CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "missing-tls"}},
+			},
+		},
+		{
+			name:               "missing secret",
+			tls:                &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "missing-tls"}}},
+			expectedResolved:   gatewayv1.ListenerReasonInvalidCertificateRef,
+			expectedProgrammed: string(gatewayv1.ListenerReasonInvalid),
+			expectedMessage:    "Secret default/missing-tls not found",
+		},
+		{
+			name:               "secret pending in cert-manager",
+			annotations:        map[string]string{ingress.CertManagerClusterIssuerAnnotation: "letsencrypt"},
+			tls:                &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "missing-tls"}}},
+			expectedResolved:   gatewayv1.ListenerReasonInvalidCertificateRef,
+			expectedProgrammed: ListenerReasonCertificatePending,
+			expectedMessage:    "Secret default/missing-tls not found, waiting for cert-manager to issue it with letsencrypt",
+		},
+		{
+			name:               "mismatched key",
+			tls:                &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "broken-tls"}}},
+			expectedResolved:   gatewayv1.ListenerReasonInvalidCertificateRef,
+			expectedProgrammed: string(gatewayv1.ListenerReasonInvalid),
+			expectedMessage:    "secret default/broken-tls holds no valid certificate",
+		},
+		{
+			name: "not a secret",
+			tls: &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{
+				{Name: "app-tls", Kind: &configMap},
+			}},
+			expectedResolved:   gatewayv1.ListenerReasonInvalidCertificateRef,
+			expectedProgrammed: string(gatewayv1.ListenerReasonInvalid),
+			expectedMessage:    "certificateRef app-tls is not a Secret",
+		},
+		{
+			name: "other namespace without ReferenceGrant",
+			tls: &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{
+				{Name: "shared-tls", Namespace: &certs},
+			}},
+			expectedResolved:   gatewayv1.ListenerReasonRefNotPermitted,
+			expectedProgrammed: string(gatewayv1.ListenerReasonInvalid),
+			expectedMessage:    "Secret certs/shared-tls is not permitted by any ReferenceGrant",
+		},
+		{
+			name: "missing CA certificate",
+			tls:  &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "app-tls"}}},
+			frontendTLS: &gatewayv1.GatewayTLSConfig{Frontend: &gatewayv1.FrontendTLSConfig{
+				Default: gatewayv1.TLSConfig{Validation: &gatewayv1.FrontendTLSValidation{
+					CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "client-ca"}},
+				}},
+			}},
+			expectedResolved:   ListenerReasonInvalidCACertificateRef,
+			expectedProgrammed: string(gatewayv1.ListenerReasonInvalid),
+			expectedMessage:    "ConfigMap default/client-ca not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := &gatewayv1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Annotations: tt.annotations},
+				Spec:       gatewayv1.GatewaySpec{TLS: tt.frontendTLS},
+			}
+			listener := &gatewayv1.Listener{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443, TLS: tt.tls}
+
+			problem, err := listenerCertificateProblem(context.Background(), cli, gateway, listener)
+			require.NoError(t, err)
+
+			if tt.expectedResolved == "" {
+				assert.Nil(t, problem)
+
+				return
+			}
+
+			require.NotNil(t, problem)
+			assert.Equal(t, tt.expectedResolved, problem.resolvedReason)
+			assert.Equal(t, tt.expectedProgrammed, problem.programmedReason)
+			assert.Contains(t, problem.message, tt.expectedMessage)
+		})
+	}
+}
+
+func TestSetCertificateProblem(t *testing.T) {
+	t.Parallel()
+
+	conditions := []metav1.Condition{
+		{Type: string(gatewayv1.ListenerConditionAccepted), Status: metav1.ConditionTrue},
+		{Type: string(gatewayv1.ListenerConditionProgrammed), Status: metav1.ConditionTrue},
+		{Type: string(gatewayv1.ListenerConditionResolvedRefs), Status: metav1.ConditionTrue},
+	}
+
+	setCertificateProblem(conditions, &certificateRefProblem{
+		resolvedReason:   gatewayv1.ListenerReasonInvalidCertificateRef,
+		programmedReason: ListenerReasonCertificatePending,
+		message:          "Secret default/app-tls not found",
+	})
+
+	assert.Equal(t, metav1.ConditionTrue, conditions[0].Status)
+	assert.Equal(t, metav1.ConditionFalse, conditions[1].Status)
+	assert.Equal(t, ListenerReasonCertificatePending, conditions[1].Reason)
+	assert.Equal(t, metav1.ConditionFalse, conditions[2].Status)
+	assert.Equal(t, string(gatewayv1.ListenerReasonInvalidCertificateRef), conditions[2].Reason)
+	assert.Equal(t, "Secret default/app-tls not found", conditions[2].Message)
+}
@@ -0,0 +1,236 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	pingoraingress "github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/referencegrant"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// syncCertificates sends the certificates of the HTTPS listeners and the
+// fallback certificate of the PingoraConfig to the proxy unless the proxy
+// already accepted them. Like the global config, failures are logged and
+// retried on the next sync.
+func (s *PingoraRouteSyncer) syncCertificates(ctx context.Context, logger *slog.Logger) {
+	pingoraConfig, err := s.pingoraConfigForClass(ctx)
+	if err != nil {
+		logger.Debug("failed to get PingoraConfig for certificates", "error", err)
+
+		return
+	}
+
+	gateways, err := s.classGateways(ctx)
+	if err != nil {
+		logger.Debug("failed to list gateways for certificates", "error", err)
+
+		return
+	}
+
+	certificates := s.buildCertificates(ctx, logger, pingoraConfig, gateways)
+
+	s.appliedMu.RLock()
+	applied := s.appliedCertificates
+	s.appliedMu.RUnlock()
+
+	if applied != nil && proto.Equal(applied, certificates) {
+		return
+	}
+
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		return
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.UpdateCertificates(rpcCtx, certificates)
+	grpcDuration := time.Since(grpcStart)
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// Older proxies keep the certificates of their own configuration
+		if len(certificates.GetListeners()) > 0 || certificates.GetFallback() != nil {
+			logger.Warn("proxy does not support certificate updates, listener certificates are ignored")
+		}
+	case err != nil:
+		s.Metrics.RecordGRPCCall(ctx, "UpdateCertificates", "error", grpcDuration)
+		logger.Error("failed to update certificates", "error", err)
+
+		return
+	case !resp.GetSuccess():
+		s.Metrics.RecordGRPCCall(ctx, "UpdateCertificates", "failed", grpcDuration)
+		logger.Error("certificates update failed", "error", resp.GetError())
+
+		return
+	default:
+		s.Metrics.RecordGRPCCall(ctx, "UpdateCertificates", "success", grpcDuration)
+		logger.Info("successfully updated certificates in Pingora")
+	}
+
+	s.appliedMu.Lock()
+	s.appliedCertificates = certificates
+	s.appliedMu.Unlock()
+}
+
+// buildCertificates collects the certificates of the HTTPS listeners of the
+// given Gateways, in listener order with Gateways ordered by creation, and
+// the fallback certificate and selection order of the PingoraConfig.
+// Certificates that cannot be resolved are logged and left out, so that
+// they do not hold back the others. Listeners with a frontend validation
+// whose CA certificates cannot be resolved validate no client certificate.
+func (s *PingoraRouteSyncer) buildCertificates(
+	ctx context.Context,
+	logger *slog.Logger,
+	pingoraConfig *v1alpha1.PingoraConfig,
+	gateways []gatewayv1.Gateway,
+) *routingv1.UpdateCertificatesRequest {
+	gateways = slices.Clone(gateways)
+	pingoraingress.SortGatewaysByCreation(gateways)
+
+	certificates := &routingv1.UpdateCertificatesRequest{}
+
+	for i := range gateways {
+		gateway := &gateways[i]
+
+		for _, ref := range pingoraingress.ListenerCertificateRefs(gateway) {
+			certificate, err := s.listenerCertificate(ctx, gateway, ref)
+			if err != nil {
+				logger.Warn("skipping listener certificate",
+					"gateway", gateway.Namespace+"/"+gateway.Name, "port", ref.Port, "error", err)
+
+				continue
+			}
+
+			certificate.ClientValidation, err = s.clientValidation(ctx, logger, gateway, ref)
+			if err != nil {
+				logger.Warn("skipping listener certificate",
+					"gateway", gateway.Namespace+"/"+gateway.Name, "port", ref.Port, "error", err)
+
+				continue
+			}
+
+			certificates.Listeners = pingoraingress.AddListenerCertificate(certificates.Listeners, ref.Port, certificate)
+		}
+	}
+
+	var listenerTLS *v1alpha1.ListenerTLSConfig
+	if pingoraConfig != nil && pingoraConfig.Spec.Defaults != nil {
+		listenerTLS = pingoraConfig.Spec.Defaults.ListenerTLS
+	}
+
+	certificates.Selection = pingoraingress.CertificateSelectionFromConfig(listenerTLS)
+
+	if listenerTLS != nil && listenerTLS.FallbackCertificateRef != nil {
+		fallback, err := s.fallbackCertificate(ctx, listenerTLS.FallbackCertificateRef)
+		if err != nil {
+			logger.Warn("skipping fallback certificate", "error", err)
+		} else {
+			certificates.Fallback = fallback
+		}
+	}
+
+	return certificates
+}
+
+// listenerCertificate reads the Secret of a listener certificate. Secrets
+// in other namespaces than the Gateway need a ReferenceGrant.
+func (s *PingoraRouteSyncer) listenerCertificate(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+	ref pingoraingress.ListenerCertificateRef,
+) (*routingv1.Certificate, error) {
+	allowed, err := referencegrant.NewValidator(s.Client).IsReferenceAllowed(ctx,
+		referencegrant.Reference{
+			Group:     gatewayv1.GroupName,
+			Kind:      "Gateway",
+			Namespace: gateway.Namespace,
+			Name:      gateway.Name,
+		},
+		referencegrant.Reference{
+			Kind:      "Secret",
+			Namespace: ref.Namespace,
+			Name:      ref.Name,
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check ReferenceGrants")
+	}
+
+	if !allowed {
+		return nil, errors.Newf("secret %s/%s is not permitted by any ReferenceGrant", ref.Namespace, ref.Name)
+	}
+
+	var secret corev1.Secret
+	if err := s.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, &secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get secret %s/%s", ref.Namespace, ref.Name)
+	}
+
+	var hostnames []string
+	if ref.Hostname != nil && *ref.Hostname != "" {
+		hostnames = []string{string(*ref.Hostname)}
+	}
+
+	return pingoraingress.CertificateFromSecret(&secret, hostnames) //nolint:wrapcheck // names the secret
+}
+
+// clientValidation resolves the frontend validation of a listener
+// certificate, nil if the listener requests no client certificate.
+func (s *PingoraRouteSyncer) clientValidation(
+	ctx context.Context,
+	logger *slog.Logger,
+	gateway *gatewayv1.Gateway,
+	ref pingoraingress.ListenerCertificateRef,
+) (*routingv1.ClientValidation, error) {
+	if ref.FrontendValidation == nil {
+		return nil, nil //nolint:nilnil // no client certificate requested
+	}
+
+	validation, problem, err := resolveFrontendValidation(ctx, s.Client, gateway, ref.FrontendValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	if problem != nil {
+		logger.Warn("no client certificate validates on listener",
+			"gateway", gateway.Namespace+"/"+gateway.Name, "port", ref.Port, "reason", problem.message)
+	}
+
+	return validation, nil
+}
+
+// fallbackCertificate reads the fallback certificate of the PingoraConfig.
+func (s *PingoraRouteSyncer) fallbackCertificate(
+	ctx context.Context,
+	ref *v1alpha1.SecretReference,
+) (*routingv1.Certificate, error) {
+	namespace := ref.Namespace
+	if s.ConfigResolver != nil {
+		namespace = s.ConfigResolver.SecretNamespace(namespace)
+	}
+
+	var secret corev1.Secret
+	if err := s.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, &secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get secret %s/%s", namespace, ref.Name)
+	}
+
+	return pingoraingress.CertificateFromSecret(&secret, nil) //nolint:wrapcheck // names the secret
+}
@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+func TestPingoraRouteSyncer_SyncCertificates(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	tlsSecret := func(namespace, name string) *corev1.Secret {
+		cert, key := generateKeyPair(t, name)
+
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key},
+		}
+	}
+
+	https := func(name string, hostname string, refs ...gatewayv1.SecretObjectReference) gatewayv1.Listener {
+		listener := gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(name),
+			Protocol: gatewayv1.HTTPSProtocolType,
+			Port:     443,
+			TLS:      &gatewayv1.ListenerTLSConfig{CertificateRefs: refs},
+		}
+
+		if hostname != "" {
+			value := gatewayv1.Hostname(hostname)
+			listener.Hostname = &value
+		}
+
+		return listener
+	}
+
+	certs := gatewayv1.Namespace("certs")
+	created := time.Now().Add(-time.Hour)
+
+	// The newer Gateway is listed first, its certificate comes last
+	newer := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: "app", CreationTimestamp: metav1.NewTime(created.Add(time.Minute)),
+		},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "pingora",
+			Listeners:        []gatewayv1.Listener{https("app", "app.example.com", gatewayv1.SecretObjectReference{Name: "app-tls"})},
+		},
+	}
+	older := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "wildcard", CreationTimestamp: metav1.NewTime(created)},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: "pingora",
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				https("wildcard", "*.example.com", gatewayv1.SecretObjectReference{Name: "wildcard-tls"}),
+				// Not permitted without a ReferenceGrant
+				https("shared", "shared.example.com", gatewayv1.SecretObjectReference{Name: "shared-tls", Namespace: &certs}),
+				// Missing Secret
+				https("missing", "missing.example.com", gatewayv1.SecretObjectReference{Name: "missing-tls"}),
+			},
+		},
+	}
+
+	pingoraConfig := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec: v1alpha1.PingoraConfigSpec{
+			Address: "pingora-proxy:50051",
+			Defaults: &v1alpha1.ProxyDefaults{
+				ListenerTLS: &v1alpha1.ListenerTLSConfig{
+					FallbackCertificateRef: &v1alpha1.SecretReference{Name: "fallback-tls"},
+					CertificateSelection:   v1alpha1.CertificateSelectionListenerOrder,
+				},
+			},
+		},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			pingoraConfig, newPingoraGatewayClass("pingora"), newer, older,
+			tlsSecret("default", "app-tls"), tlsSecret("default", "wildcard-tls"),
+			tlsSecret("certs", "shared-tls"), tlsSecret("pingora-system", "fallback-tls"),
+		).
+		Build()
+
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	syncer := &PingoraRouteSyncer{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		ConfigResolver:   config.NewPingoraResolver(cli, "pingora-system"),
+		Metrics:          metrics.NewNoopCollector(),
+		Logger:           slog.Default(),
+		grpcClient:       routingv1.NewRoutingServiceClient(conn),
+	}
+	ctx := context.Background()
+
+	syncer.syncCertificates(ctx, syncer.Logger)
+
+	applied := proxy.Certificates()
+	require.NotNil(t, applied)
+	require.Len(t, applied.GetListeners(), 1)
+	assert.Equal(t, uint32(443), applied.GetListeners()[0].GetPort())
+
+	names := make([]string, 0, len(applied.GetListeners()[0].GetCertificates()))
+	for _, certificate := range applied.GetListeners()[0].GetCertificates() {
+		names = append(names, certificate.GetName())
+	}
+
+	assert.Equal(t, []string{"default/wildcard-tls", "default/app-tls"}, names)
+	assert.Equal(t, []string{"*.example.com"}, applied.GetListeners()[0].GetCertificates()[0].GetHostnames())
+	assert.NotEmpty(t, applied.GetListeners()[0].GetCertificates()[0].GetPrivateKey())
+	assert.Equal(t, "pingora-system/fallback-tls", applied.GetFallback().GetName())
+	assert.Empty(t, applied.GetFallback().GetHostnames())
+	assert.Equal(t, routingv1.CertificateSelection_CERTIFICATE_SELECTION_LISTENER_ORDER, applied.GetSelection())
+
+	// Accepted certificates are not sent again
+	proxy.Restart()
+	syncer.syncCertificates(ctx, syncer.Logger)
+	assert.Nil(t, proxy.Certificates())
+
+	// After a reconnect the certificates are resent
+	syncer.resetAppliedConfig()
+	syncer.syncCertificates(ctx, syncer.Logger)
+	require.NotNil(t, proxy.Certificates())
+
+	// A ReferenceGrant permits the Secret in another namespace
+	require.NoError(t, cli.Create(ctx, &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "gateways"},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "default"}},
+			To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Secret"}},
+		},
+	}))
+
+	syncer.syncCertificates(ctx, syncer.Logger)
+	assert.Len(t, proxy.Certificates().GetListeners()[0].GetCertificates(), 3)
+
+	// Proxies without certificate updates keep their own certificates
+	proxy.SetError(mockproxy.MethodUpdateCertificates, status.Error(codes.Unimplemented, "unknown method"))
+	syncer.resetAppliedConfig()
+	syncer.syncCertificates(ctx, syncer.Logger)
+
+	syncer.appliedMu.RLock()
+	assert.NotNil(t, syncer.appliedCertificates)
+	syncer.appliedMu.RUnlock()
+}
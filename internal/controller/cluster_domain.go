@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+)
+
+// classClusterDomain is the cluster domain of a GatewayClass: the
+// clusterDomain of its PingoraConfig if set, otherwise the domain of the
+// controller.
+type classClusterDomain struct {
+	global   *dns.ClusterDomainProvider
+	override atomic.Pointer[string]
+}
+
+// ClusterDomain returns the effective cluster domain.
+func (d *classClusterDomain) ClusterDomain() string {
+	if override := d.override.Load(); override != nil && *override != "" {
+		return *override
+	}
+
+	if d.global == nil {
+		return ""
+	}
+
+	return d.global.ClusterDomain()
+}
+
+// setOverride replaces the override, where an empty domain removes it.
+// Returns true if the override changed.
+func (d *classClusterDomain) setOverride(domain string) bool {
+	previous := d.override.Swap(&domain)
+
+	return (previous == nil && domain != "") || (previous != nil && *previous != domain)
+}
+
+// refreshClusterDomain applies the clusterDomain of the PingoraConfig of the
+// GatewayClass. Failed lookups keep the current override.
+func (s *PingoraRouteSyncer) refreshClusterDomain(ctx context.Context, logger *slog.Logger) {
+	if s.domain == nil {
+		return
+	}
+
+	pingoraConfig, err := s.pingoraConfigForClass(ctx)
+	if err != nil {
+		logger.Debug("failed to get PingoraConfig for cluster domain", "error", err)
+
+		return
+	}
+
+	var domain string
+	if pingoraConfig != nil {
+		domain = pingoraConfig.Spec.ClusterDomain
+	}
+
+	if s.domain.setOverride(domain) {
+		logger.Info("cluster domain override changed", "clusterDomain", s.domain.ClusterDomain())
+	}
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+func TestClassClusterDomain(t *testing.T) {
+	t.Parallel()
+
+	domain := &classClusterDomain{global: dns.NewClusterDomainProvider("cluster.local")}
+	assert.Equal(t, "cluster.local", domain.ClusterDomain())
+
+	assert.True(t, domain.setOverride("cluster-b.local"))
+	assert.False(t, domain.setOverride("cluster-b.local"))
+	assert.Equal(t, "cluster-b.local", domain.ClusterDomain())
+
+	// The global domain is used again once the override is removed
+	assert.True(t, domain.setOverride(""))
+	assert.False(t, domain.setOverride(""))
+	assert.Equal(t, "cluster.local", domain.ClusterDomain())
+}
+
+func TestPingoraRouteSyncer_RefreshClusterDomain(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	pingoraConfig := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec:       v1alpha1.PingoraConfigSpec{Address: "pingora-proxy:50051", ClusterDomain: "cluster-b.local"},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pingoraConfig, newPingoraGatewayClass("pingora")).
+		Build()
+
+	syncer := NewPingoraRouteSyncer(cli, scheme, dns.NewClusterDomainProvider("cluster.local"),
+		"pingora", nil, metrics.NewNoopCollector(), 0, slog.Default())
+	ctx := context.Background()
+
+	assert.Equal(t, "cluster.local", syncer.clusterDomain())
+
+	syncer.refreshClusterDomain(ctx, syncer.Logger)
+	assert.Equal(t, "cluster-b.local", syncer.clusterDomain())
+
+	// Routes are built with the domain of the PingoraConfig
+	port := gatewayv1.PortNumber(80)
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{
+			{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{Name: "app", Port: &port},
+			}}}},
+		}},
+	}
+
+	backends := syncer.builder.BuildHTTPRoute(route).GetRules()[0].GetBackends()
+	require.Len(t, backends, 1)
+	assert.Equal(t, "app.default.svc.cluster-b.local:80", backends[0].GetAddress())
+
+	// Removing the field falls back to the domain of the controller
+	pingoraConfig.Spec.ClusterDomain = ""
+	require.NoError(t, cli.Update(ctx, pingoraConfig))
+
+	syncer.refreshClusterDomain(ctx, syncer.Logger)
+	assert.Equal(t, "cluster.local", syncer.clusterDomain())
+}
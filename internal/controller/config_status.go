@@ -0,0 +1,256 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+)
+
+// syncAttempt describes the outcome of a sync attempt for the PingoraConfig status.
+type syncAttempt struct {
+	// connected is true if the proxy was reachable.
+	connected bool
+	// applied is true if the proxy accepted a new config.
+	applied bool
+	// version is the config version sent to the proxy, 0 if none was sent.
+	version uint64
+	// err is the sync error, nil on success.
+	err error
+	// proxies is the sync state of every proxy instance, nil without discovery.
+	proxies []v1alpha1.ProxyInstanceStatus
+	// dryRun is true if the routes were built but not sent to the proxy.
+	dryRun bool
+}
+
+// updateConfigStatus records a sync attempt in the status of the PingoraConfig
+// referenced by the GatewayClass. Unchanged status is not written, so syncs
+// skipped because the config is already applied do not cause API writes. The config version is never lowered,
+// which keeps it monotonic across leader failover.
+func (s *PingoraRouteSyncer) updateConfigStatus(ctx context.Context, attempt syncAttempt) error {
+	return s.patchConfigStatus(ctx, func(status *v1alpha1.PingoraConfigStatus, generation int64) {
+		applySyncAttempt(status, attempt, generation)
+	})
+}
+
+// patchConfigStatus applies apply to the status of the PingoraConfig
+// referenced by the GatewayClass and writes it if it changed.
+func (s *PingoraRouteSyncer) patchConfigStatus(
+	ctx context.Context,
+	apply func(status *v1alpha1.PingoraConfigStatus, generation int64),
+) error {
+	configName := s.GetConfigName()
+	if configName == "" {
+		// Not connected yet, so the config name comes from the GatewayClass
+		configName = s.configNameForClass(ctx)
+	}
+
+	if configName == "" {
+		return nil
+	}
+
+	//nolint:wrapcheck // wrapped inside the retry function
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var pingoraConfig v1alpha1.PingoraConfig
+
+		err := s.Get(ctx, client.ObjectKey{Name: configName}, &pingoraConfig)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get PingoraConfig %s", configName)
+		}
+
+		status := pingoraConfig.Status.DeepCopy()
+		apply(status, pingoraConfig.Generation)
+
+		if equality.Semantic.DeepEqual(status, &pingoraConfig.Status) {
+			return nil
+		}
+
+		pingoraConfig.Status = *status
+
+		err = s.Status().Update(ctx, &pingoraConfig)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update PingoraConfig %s status", configName)
+		}
+
+		return nil
+	})
+}
+
+// applySyncAttempt updates status fields and conditions from a sync attempt.
+func applySyncAttempt(status *v1alpha1.PingoraConfigStatus, attempt syncAttempt, generation int64) {
+	status.Connected = attempt.connected
+	status.Proxies = attempt.proxies
+
+	if attempt.version > status.ConfigVersion {
+		status.ConfigVersion = attempt.version
+	}
+
+	if attempt.applied {
+		now := metav1.Now()
+		status.LastSyncTime = &now
+	}
+
+	ready := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             v1alpha1.ReasonConnected,
+		Message:            "Connected to Pingora proxy",
+	}
+
+	degraded := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             v1alpha1.ReasonSynced,
+		Message:            "Routes synced to Pingora proxy",
+	}
+
+	if attempt.dryRun {
+		ready.Reason = v1alpha1.ReasonDryRun
+		ready.Message = "Dry run, routes are not sent to the Pingora proxy"
+		degraded.Message = "Routes built without sending them to the Pingora proxy"
+	}
+
+	switch {
+	case errors.Is(attempt.err, config.ErrInvalidConfig):
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = v1alpha1.ReasonConfigurationInvalid
+		ready.Message = attempt.err.Error()
+	case !attempt.connected && !attempt.dryRun:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = v1alpha1.ReasonConnectionFailed
+		ready.Message = "Failed to connect to Pingora proxy"
+
+		if attempt.err != nil {
+			ready.Message += ": " + attempt.err.Error()
+		}
+	}
+
+	if attempt.err != nil {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = v1alpha1.ReasonSyncFailed
+		degraded.Message = attempt.err.Error()
+	}
+
+	meta.SetStatusCondition(&status.Conditions, ready)
+	meta.SetStatusCondition(&status.Conditions, degraded)
+}
+
+// applyProxyHealth updates the connection state and the Ready condition
+// from a proxy health change. The Degraded condition is left to syncs.
+func applyProxyHealth(status *v1alpha1.PingoraConfigStatus, healthy bool, healthErr error, generation int64) {
+	status.Connected = healthy
+
+	ready := metav1.Condition{
+		Type:               v1alpha1.ConditionTypeReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             v1alpha1.ReasonConnected,
+		Message:            "Connected to Pingora proxy",
+	}
+
+	if !healthy {
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = v1alpha1.ReasonConnectionFailed
+		ready.Message = "Pingora proxy unreachable"
+
+		if healthErr != nil {
+			ready.Message += ": " + healthErr.Error()
+		}
+	}
+
+	meta.SetStatusCondition(&status.Conditions, ready)
+}
+
+// applyShutdown marks the connection as closed by a controller shutdown.
+// The Degraded condition is kept, as the proxy still serves the last sync.
+func applyShutdown(status *v1alpha1.PingoraConfigStatus, generation int64) {
+	status.Connected = false
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               v1alpha1.ConditionTypeReady,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             v1alpha1.ReasonControllerStopped,
+		Message:            "Controller shut down, the proxy keeps serving the last synced routes",
+	})
+}
+
+// recordProxyHealth updates the PingoraConfig status after the proxy went
+// down or recovered. Status errors are logged.
+func (s *PingoraRouteSyncer) recordProxyHealth(ctx context.Context, healthy bool, healthErr error) {
+	err := s.patchConfigStatus(ctx, func(status *v1alpha1.PingoraConfigStatus, generation int64) {
+		applyProxyHealth(status, healthy, healthErr, generation)
+	})
+	if err != nil {
+		s.Logger.Warn("failed to update PingoraConfig status", "error", err)
+	}
+}
+
+// recordSyncAttempt updates the PingoraConfig status and logs failures.
+// Status errors never fail the sync itself.
+func (s *PingoraRouteSyncer) recordSyncAttempt(ctx context.Context, attempt syncAttempt) {
+	s.connMu.RLock()
+	fanOut := s.fanOut
+	s.connMu.RUnlock()
+
+	if fanOut != nil {
+		attempt.proxies = fanOut.status()
+	}
+
+	err := s.updateConfigStatus(ctx, attempt)
+	if err != nil {
+		s.Logger.Warn("failed to update PingoraConfig status", "error", err)
+	}
+}
+
+// configNameForClass returns the PingoraConfig name referenced by the GatewayClass,
+// or an empty string if there is none.
+func (s *PingoraRouteSyncer) configNameForClass(ctx context.Context) string {
+	var gatewayClass gatewayv1.GatewayClass
+
+	err := s.Get(ctx, client.ObjectKey{Name: s.GatewayClassName}, &gatewayClass)
+	if err != nil {
+		return ""
+	}
+
+	ref := gatewayClass.Spec.ParametersRef
+	if ref == nil ||
+		string(ref.Group) != config.PingoraParametersRefGroup ||
+		string(ref.Kind) != config.PingoraParametersRefKind {
+		return ""
+	}
+
+	return ref.Name
+}
+
+// pingoraConfigForClass returns the PingoraConfig referenced by the
+// GatewayClass, or nil if there is none or it does not exist.
+func (s *PingoraRouteSyncer) pingoraConfigForClass(ctx context.Context) (*v1alpha1.PingoraConfig, error) {
+	name := s.configNameForClass(ctx)
+	if name == "" {
+		return nil, nil //nolint:nilnil // no PingoraConfig is not an error
+	}
+
+	var pingoraConfig v1alpha1.PingoraConfig
+
+	if err := s.Get(ctx, client.ObjectKey{Name: name}, &pingoraConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil //nolint:nilnil // no PingoraConfig is not an error
+		}
+
+		return nil, errors.Wrapf(err, "failed to get PingoraConfig %s", name)
+	}
+
+	return &pingoraConfig, nil
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+)
+
+func TestApplySyncAttempt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		attempt         syncAttempt
+		expectConnected bool
+		expectSyncTime  bool
+		expectVersion   uint64
+		readyStatus     metav1.ConditionStatus
+		readyReason     string
+		degradedStatus  metav1.ConditionStatus
+		degradedReason  string
+	}{
+		{
+			name:            "config applied",
+			attempt:         syncAttempt{connected: true, applied: true, version: 12},
+			expectConnected: true,
+			expectSyncTime:  true,
+			expectVersion:   12,
+			readyStatus:     metav1.ConditionTrue,
+			readyReason:     v1alpha1.ReasonConnected,
+			degradedStatus:  metav1.ConditionFalse,
+			degradedReason:  v1alpha1.ReasonSynced,
+		},
+		{
+			name:            "config unchanged",
+			attempt:         syncAttempt{connected: true},
+			expectConnected: true,
+			expectVersion:   10,
+			readyStatus:     metav1.ConditionTrue,
+			readyReason:     v1alpha1.ReasonConnected,
+			degradedStatus:  metav1.ConditionFalse,
+			degradedReason:  v1alpha1.ReasonSynced,
+		},
+		{
+			name:            "proxy rejected update",
+			attempt:         syncAttempt{connected: true, version: 11, err: errors.New("bad route")},
+			expectConnected: true,
+			expectVersion:   11,
+			readyStatus:     metav1.ConditionTrue,
+			readyReason:     v1alpha1.ReasonConnected,
+			degradedStatus:  metav1.ConditionTrue,
+			degradedReason:  v1alpha1.ReasonSyncFailed,
+		},
+		{
+			name:           "connection failed",
+			attempt:        syncAttempt{err: errors.New("connection refused")},
+			expectVersion:  10,
+			readyStatus:    metav1.ConditionFalse,
+			readyReason:    v1alpha1.ReasonConnectionFailed,
+			degradedStatus: metav1.ConditionTrue,
+			degradedReason: v1alpha1.ReasonSyncFailed,
+		},
+		{
+			name:           "invalid config",
+			attempt:        syncAttempt{err: errors.Mark(errors.New("spec.address: Required value"), config.ErrInvalidConfig)},
+			expectVersion:  10,
+			readyStatus:    metav1.ConditionFalse,
+			readyReason:    v1alpha1.ReasonConfigurationInvalid,
+			degradedStatus: metav1.ConditionTrue,
+			degradedReason: v1alpha1.ReasonSyncFailed,
+		},
+		{
+			name:            "stale version does not lower stored version",
+			attempt:         syncAttempt{connected: true, applied: true, version: 3},
+			expectConnected: true,
+			expectSyncTime:  true,
+			expectVersion:   10,
+			readyStatus:     metav1.ConditionTrue,
+			readyReason:     v1alpha1.ReasonConnected,
+			degradedStatus:  metav1.ConditionFalse,
+			degradedReason:  v1alpha1.ReasonSynced,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := &v1alpha1.PingoraConfigStatus{ConfigVersion: 10}
+
+			applySyncAttempt(status, tt.attempt, 2)
+
+			assert.Equal(t, tt.expectConnected, status.Connected)
+			assert.Equal(t, tt.expectSyncTime, status.LastSyncTime != nil)
+			assert.Equal(t, tt.expectVersion, status.ConfigVersion)
+
+			ready := meta.FindStatusCondition(status.Conditions, v1alpha1.ConditionTypeReady)
+			require.NotNil(t, ready)
+			assert.Equal(t, tt.readyStatus, ready.Status)
+			assert.Equal(t, tt.readyReason, ready.Reason)
+			assert.Equal(t, int64(2), ready.ObservedGeneration)
+
+			degraded := meta.FindStatusCondition(status.Conditions, v1alpha1.ConditionTypeDegraded)
+			require.NotNil(t, degraded)
+			assert.Equal(t, tt.degradedStatus, degraded.Status)
+			assert.Equal(t, tt.degradedReason, degraded.Reason)
+		})
+	}
+}
+
+func TestPingoraRouteSyncer_UpdateConfigStatus(t *testing.T) {
+	t.Parallel()
+
+	syncer, written := newVersionTestSyncer(t, 10)
+	ctx := context.Background()
+
+	// Not connected yet: the config is found through the GatewayClass
+	require.NoError(t, syncer.updateConfigStatus(ctx, syncAttempt{err: errors.New("connection refused")}))
+	require.Len(t, *written, 1)
+	assert.False(t, (*written)[0].Connected)
+	assert.Equal(t, uint64(10), (*written)[0].ConfigVersion)
+
+	syncer.configName = "pingora"
+
+	require.NoError(t, syncer.updateConfigStatus(ctx, syncAttempt{connected: true, applied: true, version: 11}))
+	require.Len(t, *written, 2)
+	assert.True(t, (*written)[1].Connected)
+	assert.Equal(t, uint64(11), (*written)[1].ConfigVersion)
+	assert.NotNil(t, (*written)[1].LastSyncTime)
+
+	syncer.configName = "missing"
+	require.Error(t, syncer.updateConfigStatus(ctx, syncAttempt{connected: true}))
+}
+
+func TestPingoraRouteSyncer_UpdateConfigStatusUnchanged(t *testing.T) {
+	t.Parallel()
+
+	status := v1alpha1.PingoraConfigStatus{ConfigVersion: 10}
+	applySyncAttempt(&status, syncAttempt{connected: true}, 0)
+
+	syncer, written := newStatusTestSyncer(t, status)
+	syncer.configName = "pingora"
+
+	// A skipped sync with the same outcome must not write the status again
+	require.NoError(t, syncer.updateConfigStatus(context.Background(), syncAttempt{connected: true}))
+	assert.Empty(t, *written)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// restoreVersion raises the version counter so that new updates never reuse a
+// version the proxy may already have applied. It takes the highest of the
+// current counter, the version persisted in the PingoraConfig status by this
+// or a previous leader, and the version the proxy reports.
+//
+// Both sources are best effort: if neither is available the counter keeps
+// its current value.
+//
+// On the first connect after becoming the leader, one more version is
+// skipped: the previous leader may still have a sync with the next version
+// in flight. The proxy then either rejects that sync as stale, or reports a
+// version behind the applied one, which forces a full resync.
+func (s *PingoraRouteSyncer) restoreVersion(
+	ctx context.Context,
+	configName string,
+	grpcClient routingv1.RoutingServiceClient,
+) {
+	var pingoraConfig v1alpha1.PingoraConfig
+
+	err := s.Get(ctx, client.ObjectKey{Name: configName}, &pingoraConfig)
+	if err != nil {
+		s.Logger.Debug("failed to read persisted config version", "config", configName, "error", err)
+	} else {
+		s.advanceVersion(pingoraConfig.Status.ConfigVersion)
+	}
+
+	// Called from Connect with connMu held, after requestTimeout is set
+	rpcCtx, cancel := withRequestTimeout(ctx, s.requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.Health(rpcCtx, &routingv1.HealthRequest{})
+	grpcDuration := time.Since(grpcStart)
+
+	if err != nil {
+		s.Metrics.RecordGRPCCall(ctx, "Health", "error", grpcDuration)
+		s.Logger.Debug("failed to read proxy config version", "error", err)
+	} else {
+		s.Metrics.RecordGRPCCall(ctx, "Health", "success", grpcDuration)
+		s.advanceVersion(resp.GetConfigVersion())
+		s.proxyVersion.Store(resp.GetConfigVersion())
+	}
+
+	if s.takeover.CompareAndSwap(true, false) {
+		s.advanceVersion(s.version.Load() + 1)
+		s.Logger.Info("took over proxy sync from previous leader", "version", s.version.Load())
+	}
+
+	s.Logger.Debug("restored config version", "version", s.version.Load())
+}
+
+// advanceVersion raises the version counter to at least version.
+func (s *PingoraRouteSyncer) advanceVersion(version uint64) {
+	for {
+		current := s.version.Load()
+		if current >= version || s.version.CompareAndSwap(current, version) {
+			return
+		}
+	}
+}
+
+// expectProxyVersion sets the expected version of req to the version the
+// proxy last reported, or clears it if none is known.
+func (s *PingoraRouteSyncer) expectProxyVersion(req *routingv1.UpdateRoutesRequest) {
+	req.ExpectedVersion = nil
+
+	if version := s.proxyVersion.Load(); version > 0 {
+		req.ExpectedVersion = proto.Uint64(version)
+	}
+}
@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+func newPingoraGatewayClass(configName string) *gatewayv1.GatewayClass {
+	return &gatewayv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec: gatewayv1.GatewayClassSpec{
+			ControllerName: "pingora.k8s.lex.la/gateway-controller",
+			ParametersRef: &gatewayv1.ParametersReference{
+				Group: config.PingoraParametersRefGroup,
+				Kind:  config.PingoraParametersRefKind,
+				Name:  configName,
+			},
+		},
+	}
+}
+
+// newVersionTestSyncer returns a syncer whose client holds a PingoraConfig with
+// the given persisted version and a GatewayClass referencing it.
+func newVersionTestSyncer(t *testing.T, persisted uint64) (*PingoraRouteSyncer, *[]v1alpha1.PingoraConfigStatus) {
+	t.Helper()
+
+	return newStatusTestSyncer(t, v1alpha1.PingoraConfigStatus{ConfigVersion: persisted})
+}
+
+// newStatusTestSyncer is like newVersionTestSyncer with a full initial status.
+// Status writes are captured in the returned slice instead of being stored,
+// because the fake client cannot store uint64 status fields.
+func newStatusTestSyncer(
+	t *testing.T,
+	status v1alpha1.PingoraConfigStatus,
+) (*PingoraRouteSyncer, *[]v1alpha1.PingoraConfigStatus) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	pingoraConfig := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec:       v1alpha1.PingoraConfigSpec{Address: "pingora-proxy:50051"},
+		Status:     status,
+	}
+
+	var written []v1alpha1.PingoraConfigStatus
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pingoraConfig, newPingoraGatewayClass("pingora")).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(
+				_ context.Context,
+				_ client.Client,
+				subResource string,
+				obj client.Object,
+				_ ...client.SubResourceUpdateOption,
+			) error {
+				if subResource == "status" {
+					written = append(written, obj.(*v1alpha1.PingoraConfig).Status)
+				}
+
+				return nil
+			},
+		}).
+		Build()
+
+	return &PingoraRouteSyncer{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		Metrics:          metrics.NewNoopCollector(),
+		Logger:           slog.Default(),
+	}, &written
+}
+
+func TestPingoraRouteSyncer_RestoreVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		persisted     uint64
+		health        *routingv1.HealthResponse
+		healthErr     error
+		current       uint64
+		takeover      bool
+		expected      uint64
+		expectedProxy uint64
+	}{
+		{
+			name:      "persisted version after restart",
+			persisted: 41,
+			health:    &routingv1.HealthResponse{ConfigVersion: 0},
+			expected:  41,
+		},
+		{
+			name:          "proxy is ahead of persisted version",
+			persisted:     41,
+			health:        &routingv1.HealthResponse{ConfigVersion: 57},
+			expected:      57,
+			expectedProxy: 57,
+		},
+		{
+			name:      "proxy unreachable",
+			persisted: 12,
+			healthErr: errors.New("unavailable"),
+			expected:  12,
+		},
+		{
+			name:          "counter never goes backwards",
+			persisted:     3,
+			health:        &routingv1.HealthResponse{ConfigVersion: 2},
+			current:       9,
+			expected:      9,
+			expectedProxy: 2,
+		},
+		{
+			name:          "leader takeover skips the version of an in-flight sync",
+			persisted:     41,
+			health:        &routingv1.HealthResponse{ConfigVersion: 41},
+			takeover:      true,
+			expected:      42,
+			expectedProxy: 41,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			syncer, _ := newVersionTestSyncer(t, tt.persisted)
+			syncer.version.Store(tt.current)
+			syncer.takeover.Store(tt.takeover)
+
+			syncer.restoreVersion(context.Background(), "pingora",
+				&fakeRoutingClient{health: tt.health, healthErr: tt.healthErr})
+
+			assert.Equal(t, tt.expected, syncer.GetVersion())
+			assert.Equal(t, tt.expectedProxy, syncer.proxyVersion.Load())
+			assert.False(t, syncer.takeover.Load(), "takeover must only apply to the first connect")
+		})
+	}
+}
+
+func TestPingoraRouteSyncer_RestoreVersionMissingConfig(t *testing.T) {
+	t.Parallel()
+
+	syncer, _ := newVersionTestSyncer(t, 0)
+
+	syncer.restoreVersion(context.Background(), "missing",
+		&fakeRoutingClient{health: &routingv1.HealthResponse{ConfigVersion: 5}})
+
+	assert.Equal(t, uint64(5), syncer.GetVersion())
+}
+
+func TestPingoraRouteSyncer_UpdateRoutesVersionConflict(t *testing.T) {
+	t.Parallel()
+
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	grpcClient := routingv1.NewRoutingServiceClient(conn)
+	syncer, _ := newVersionTestSyncer(t, 0)
+	ctx := context.Background()
+
+	req := updateRequest(syncer.version.Add(1), map[string]string{"default/a": "a:80"})
+	resp, _, err := syncer.updateRoutes(ctx, slog.Default(), grpcClient, nil, req)
+	require.NoError(t, err)
+	require.True(t, resp.GetSuccess())
+	assert.Nil(t, req.ExpectedVersion, "nothing is expected of a proxy without a known version")
+	assert.Equal(t, uint64(1), syncer.proxyVersion.Load())
+
+	// A late sync of a previous leader lands in between
+	_, err = grpcClient.UpdateRoutes(ctx, updateRequest(5, map[string]string{"default/a": "stale:80"}))
+	require.NoError(t, err)
+
+	req = updateRequest(syncer.version.Add(1), map[string]string{"default/a": "a:8080"})
+	resp, _, err = syncer.updateRoutes(ctx, slog.Default(), grpcClient, nil, req)
+	require.NoError(t, err)
+	require.True(t, resp.GetSuccess())
+	assert.Equal(t, uint64(5), req.GetExpectedVersion())
+	assert.Equal(t, []uint64{1, 5, 6}, proxy.AppliedVersions())
+	assert.Equal(t, "a:8080", proxy.HTTPRoutes()[0].GetRules()[0].GetBackends()[0].GetAddress())
+	assert.Equal(t, uint64(6), syncer.proxyVersion.Load())
+
+	// A replica that lost leadership does not retry
+	syncer.Elected = make(chan struct{})
+
+	_, err = grpcClient.UpdateRoutes(ctx, updateRequest(9, nil))
+	require.NoError(t, err)
+
+	req = updateRequest(syncer.version.Add(1), map[string]string{"default/a": "a:8080"})
+	resp, _, err = syncer.updateRoutes(ctx, slog.Default(), grpcClient, nil, req)
+	require.NoError(t, err)
+	assert.False(t, resp.GetSuccess())
+	assert.True(t, resp.GetVersionConflict())
+	assert.Equal(t, []uint64{1, 5, 6, 9}, proxy.AppliedVersions())
+	assert.Equal(t, uint64(9), syncer.proxyVersion.Load())
+}
@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ControllerNameAdopter migrates route status entries written under previous
+// controller names to the current controller name.
+//
+// Route status parents are keyed by controller name, so after the controller
+// name changes the entries of the old name would stay on routes forever. The
+// adopter runs once on the leader: entries of an adopted name are rewritten to
+// the current name, or dropped if the current name already reports the same
+// parent. Entries of other controllers are left untouched.
+//
+// Gateway status does not carry a controller name and is rewritten by the
+// Gateway reconciler, so only HTTPRoute and GRPCRoute status is migrated.
+type ControllerNameAdopter struct {
+	Client client.Client
+
+	// ControllerName is the current controller name.
+	ControllerName string
+
+	// AdoptedNames are previous controller names whose entries are claimed.
+	AdoptedNames []string
+
+	Logger *slog.Logger
+}
+
+// Start runs the adoption pass once. Failures are logged and do not stop the
+// manager, because the regular status updates still work without adoption.
+func (a *ControllerNameAdopter) Start(ctx context.Context) error {
+	logger := a.Logger.With("component", "controller-name-adoption")
+
+	httpRoutes, httpErr := a.adoptHTTPRoutes(ctx)
+	if httpErr != nil {
+		logger.Error("failed to adopt httproute status entries", "error", httpErr)
+	}
+
+	grpcRoutes, grpcErr := a.adoptGRPCRoutes(ctx)
+	if grpcErr != nil {
+		logger.Error("failed to adopt grpcroute status entries", "error", grpcErr)
+	}
+
+	logger.Info("controller name adoption completed",
+		"controllerName", a.ControllerName,
+		"adoptedNames", a.AdoptedNames,
+		"httpRoutes", httpRoutes,
+		"grpcRoutes", grpcRoutes,
+	)
+
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Only the leader writes route status.
+func (a *ControllerNameAdopter) NeedLeaderElection() bool {
+	return true
+}
+
+func (a *ControllerNameAdopter) adoptHTTPRoutes(ctx context.Context) (int, error) {
+	var routes gatewayv1.HTTPRouteList
+	if err := a.Client.List(ctx, &routes); err != nil {
+		return 0, errors.Wrap(err, "failed to list httproutes")
+	}
+
+	return adoptRoutes(ctx, a, routes.Items, func(route *gatewayv1.HTTPRoute) *gatewayv1.RouteStatus {
+		return &route.Status.RouteStatus
+	})
+}
+
+func (a *ControllerNameAdopter) adoptGRPCRoutes(ctx context.Context) (int, error) {
+	var routes gatewayv1.GRPCRouteList
+
+	err := a.Client.List(ctx, &routes)
+	if meta.IsNoMatchError(err) {
+		// Without the GRPCRoute CRD there are no routes to adopt
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list grpcroutes")
+	}
+
+	return adoptRoutes(ctx, a, routes.Items, func(route *gatewayv1.GRPCRoute) *gatewayv1.RouteStatus {
+		return &route.Status.RouteStatus
+	})
+}
+
+// adoptRoutes rewrites the status of every route with adopted entries and
+// returns the number of updated routes. A fresh copy of each route is read
+// before the update, retrying on conflicts with concurrent status updates.
+func adoptRoutes[R any, P interface {
+	*R
+	client.Object
+}](
+	ctx context.Context,
+	a *ControllerNameAdopter,
+	routes []R,
+	routeStatus func(P) *gatewayv1.RouteStatus,
+) (int, error) {
+	adopted := 0
+
+	var errs error
+
+	for i := range routes {
+		route := P(&routes[i])
+		if _, changed := adoptParentStatuses(routeStatus(route).Parents, a.ControllerName, a.AdoptedNames); !changed {
+			continue
+		}
+
+		key := client.ObjectKeyFromObject(route)
+
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			fresh := P(new(R))
+			if err := a.Client.Get(ctx, key, fresh); err != nil {
+				return errors.Wrapf(err, "failed to get route %s", key)
+			}
+
+			status := routeStatus(fresh)
+
+			parents, changed := adoptParentStatuses(status.Parents, a.ControllerName, a.AdoptedNames)
+			if !changed {
+				return nil
+			}
+
+			status.Parents = parents
+
+			return errors.Wrapf(a.Client.Status().Update(ctx, fresh), "failed to update route %s status", key)
+		})
+		if err != nil {
+			errs = errors.CombineErrors(errs, err)
+
+			continue
+		}
+
+		adopted++
+	}
+
+	return adopted, errs
+}
+
+// adoptParentStatuses rewrites entries of adopted controller names to
+// controllerName. An adopted entry is dropped when an entry for the same
+// parent already exists under controllerName. It reports whether anything changed.
+func adoptParentStatuses(
+	parents []gatewayv1.RouteParentStatus,
+	controllerName string,
+	adoptedNames []string,
+) ([]gatewayv1.RouteParentStatus, bool) {
+	isAdopted := func(parent gatewayv1.RouteParentStatus) bool {
+		name := string(parent.ControllerName)
+
+		return name != controllerName && slices.Contains(adoptedNames, name)
+	}
+
+	if !slices.ContainsFunc(parents, isAdopted) {
+		return parents, false
+	}
+
+	result := make([]gatewayv1.RouteParentStatus, 0, len(parents))
+
+	for _, parent := range parents {
+		if isAdopted(parent) {
+			continue
+		}
+
+		result = append(result, parent)
+	}
+
+	for _, parent := range parents {
+		if !isAdopted(parent) {
+			continue
+		}
+
+		claimed := slices.ContainsFunc(result, func(existing gatewayv1.RouteParentStatus) bool {
+			return string(existing.ControllerName) == controllerName &&
+				sameParentRef(existing.ParentRef, parent.ParentRef)
+		})
+		if claimed {
+			continue
+		}
+
+		parent.ControllerName = gatewayv1.GatewayController(controllerName)
+		result = append(result, parent)
+	}
+
+	return result, true
+}
+
+// sameParentRef reports whether two status parentRefs point to the same parent.
+// Group and Kind default to the Gateway kind, and status parentRefs written by
+// this controller always carry the namespace.
+func sameParentRef(a, b gatewayv1.ParentReference) bool {
+	return derefOr(a.Group, gatewayv1.GroupName) == derefOr(b.Group, gatewayv1.GroupName) &&
+		derefOr(a.Kind, kindGateway) == derefOr(b.Kind, kindGateway) &&
+		derefOr(a.Namespace, "") == derefOr(b.Namespace, "") &&
+		a.Name == b.Name &&
+		derefOr(a.SectionName, "") == derefOr(b.SectionName, "") &&
+		derefOr(a.Port, 0) == derefOr(b.Port, 0)
+}
+
+func derefOr[T any](v *T, def T) T {
+	if v == nil {
+		return def
+	}
+
+	return *v
+}
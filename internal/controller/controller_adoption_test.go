@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	adoptionControllerName = "pingora.k8s.lex.la/gateway-controller"
+	adoptionOldName        = "example.com/legacy-controller"
+	adoptionForeignName    = "example.com/other-controller"
+)
+
+func adoptionParent(controllerName, gateway, message string) gatewayv1.RouteParentStatus {
+	namespace := gatewayv1.Namespace("gateway-system")
+
+	return gatewayv1.RouteParentStatus{
+		ParentRef:      gatewayv1.ParentReference{Name: gatewayv1.ObjectName(gateway), Namespace: &namespace},
+		ControllerName: gatewayv1.GatewayController(controllerName),
+		Conditions: []metav1.Condition{{
+			Type:               string(gatewayv1.RouteConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			Reason:             string(gatewayv1.RouteReasonAccepted),
+			Message:            message,
+			LastTransitionTime: metav1.Now(),
+		}},
+	}
+}
+
+func TestAdoptParentStatuses(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		parents     []gatewayv1.RouteParentStatus
+		wantChanged bool
+		want        []gatewayv1.RouteParentStatus
+	}{
+		{
+			name:    "no adopted entries",
+			parents: []gatewayv1.RouteParentStatus{adoptionParent(adoptionControllerName, "public", "current")},
+		},
+		{
+			name:        "rewrites adopted entry",
+			parents:     []gatewayv1.RouteParentStatus{adoptionParent(adoptionOldName, "public", "old")},
+			wantChanged: true,
+			want:        []gatewayv1.RouteParentStatus{adoptionParent(adoptionControllerName, "public", "old")},
+		},
+		{
+			name: "drops adopted entry for a parent already reported",
+			parents: []gatewayv1.RouteParentStatus{
+				adoptionParent(adoptionOldName, "public", "old"),
+				adoptionParent(adoptionControllerName, "public", "current"),
+			},
+			wantChanged: true,
+			want:        []gatewayv1.RouteParentStatus{adoptionParent(adoptionControllerName, "public", "current")},
+		},
+		{
+			name: "keeps other controllers",
+			parents: []gatewayv1.RouteParentStatus{
+				adoptionParent(adoptionForeignName, "public", "foreign"),
+				adoptionParent(adoptionOldName, "internal", "old"),
+			},
+			wantChanged: true,
+			want: []gatewayv1.RouteParentStatus{
+				adoptionParent(adoptionForeignName, "public", "foreign"),
+				adoptionParent(adoptionControllerName, "internal", "old"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, changed := adoptParentStatuses(tt.parents, adoptionControllerName, []string{adoptionOldName})
+			assert.Equal(t, tt.wantChanged, changed)
+
+			if !tt.wantChanged {
+				assert.Equal(t, tt.parents, got)
+
+				return
+			}
+
+			require.Len(t, got, len(tt.want))
+
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].ControllerName, got[i].ControllerName)
+				assert.Equal(t, tt.want[i].ParentRef, got[i].ParentRef)
+				assert.Equal(t, tt.want[i].Conditions[0].Message, got[i].Conditions[0].Message)
+			}
+		})
+	}
+}
+
+func TestSameParentRef_Defaults(t *testing.T) {
+	t.Parallel()
+
+	group := gatewayv1.Group(gatewayv1.GroupName)
+	kind := gatewayv1.Kind(kindGateway)
+	section := gatewayv1.SectionName("https")
+
+	assert.True(t, sameParentRef(
+		gatewayv1.ParentReference{Name: "public"},
+		gatewayv1.ParentReference{Group: &group, Kind: &kind, Name: "public"},
+	))
+	assert.False(t, sameParentRef(
+		gatewayv1.ParentReference{Name: "public"},
+		gatewayv1.ParentReference{Name: "public", SectionName: &section},
+	))
+}
+
+func TestControllerNameAdopter_Start(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	httpRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a"},
+		Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{
+			Parents: []gatewayv1.RouteParentStatus{
+				adoptionParent(adoptionOldName, "public", "old"),
+				adoptionParent(adoptionForeignName, "public", "foreign"),
+			},
+		}},
+	}
+	grpcRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"},
+		Status: gatewayv1.GRPCRouteStatus{RouteStatus: gatewayv1.RouteStatus{
+			Parents: []gatewayv1.RouteParentStatus{adoptionParent(adoptionOldName, "public", "old")},
+		}},
+	}
+	untouched := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-b"},
+		Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{
+			Parents: []gatewayv1.RouteParentStatus{adoptionParent(adoptionForeignName, "public", "foreign")},
+		}},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(httpRoute, grpcRoute, untouched).
+		WithStatusSubresource(&gatewayv1.HTTPRoute{}, &gatewayv1.GRPCRoute{}).
+		Build()
+
+	adopter := &ControllerNameAdopter{
+		Client:         fakeClient,
+		ControllerName: adoptionControllerName,
+		AdoptedNames:   []string{adoptionOldName},
+		Logger:         slog.Default(),
+	}
+
+	assert.True(t, adopter.NeedLeaderElection())
+	require.NoError(t, adopter.Start(context.Background()))
+
+	var gotHTTP gatewayv1.HTTPRoute
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(httpRoute), &gotHTTP))
+	require.Len(t, gotHTTP.Status.Parents, 2)
+	assert.Equal(t, gatewayv1.GatewayController(adoptionForeignName), gotHTTP.Status.Parents[0].ControllerName)
+	assert.Equal(t, gatewayv1.GatewayController(adoptionControllerName), gotHTTP.Status.Parents[1].ControllerName)
+
+	var gotGRPC gatewayv1.GRPCRoute
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(grpcRoute), &gotGRPC))
+	require.Len(t, gotGRPC.Status.Parents, 1)
+	assert.Equal(t, gatewayv1.GatewayController(adoptionControllerName), gotGRPC.Status.Parents[0].ControllerName)
+
+	var gotUntouched gatewayv1.HTTPRoute
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(untouched), &gotUntouched))
+	assert.Equal(t, untouched.ResourceVersion, gotUntouched.ResourceVersion)
+}
+
+func TestControllerNameAdopter_WithoutGRPCRouteCRD(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, cli client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*gatewayv1.GRPCRouteList); ok {
+					return &meta.NoKindMatchError{GroupKind: gatewayv1.SchemeGroupVersion.WithKind("GRPCRoute").GroupKind()}
+				}
+
+				return cli.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+
+	adopter := &ControllerNameAdopter{
+		Client:         fakeClient,
+		ControllerName: adoptionControllerName,
+		AdoptedNames:   []string{adoptionOldName},
+		Logger:         slog.Default(),
+	}
+
+	adopted, err := adopter.adoptGRPCRoutes(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, adopted)
+}
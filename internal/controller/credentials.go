@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+)
+
+// refreshCredentials loads rotated TLS certificates from the Secret of the
+// PingoraConfig into the open connection, or switches it to the SPIFFE
+// Workload API configured there. They are used from the next TLS handshake
+// on, so the connection is not closed. Credentials that cannot be loaded are
+// reported and the current ones are kept.
+func (s *PingoraRouteSyncer) refreshCredentials(ctx context.Context, logger *slog.Logger) {
+	s.connMu.RLock()
+	credentials := s.credentials
+	s.connMu.RUnlock()
+
+	if credentials == nil || s.ConfigResolver == nil {
+		return
+	}
+
+	resolved, err := s.ConfigResolver.ResolveFromGatewayClassName(ctx, s.GatewayClassName)
+	if err != nil {
+		logger.Debug("failed to resolve Pingora config for TLS credentials", "error", err)
+
+		return
+	}
+
+	// Enabling or disabling TLS takes a new connection
+	if !resolved.TLSEnabled {
+		return
+	}
+
+	changed, err := credentials.Load(ctx, resolved)
+	if err != nil {
+		logger.Error("failed to load rotated TLS credentials, keeping the current ones", "error", err)
+
+		if errors.Is(err, config.ErrInvalidCertificate) {
+			s.recordCertificateInvalid(ctx, err)
+		}
+
+		return
+	}
+
+	if changed {
+		logger.Info("loaded rotated TLS credentials for the Pingora proxy connection",
+			"spiffe", resolved.SPIFFEEnabled)
+	}
+}
+
+// closeCredentials releases the TLS credentials of a dropped connection.
+// Must be called with connMu held.
+func (s *PingoraRouteSyncer) closeCredentials() {
+	if s.credentials == nil {
+		return
+	}
+
+	if err := s.credentials.Close(); err != nil {
+		s.Logger.Error("failed to close TLS credentials", "error", err)
+	}
+
+	s.credentials = nil
+}
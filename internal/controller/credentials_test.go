@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+)
+
+// generateKeyPair returns a self-signed certificate and its key in PEM form.
+func generateKeyPair(t *testing.T, commonName string) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestPingoraRouteSyncer_RefreshCredentials(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	oldCert, oldKey := generateKeyPair(t, "controller-old")
+	newCert, newKey := generateKeyPair(t, "controller-new")
+
+	pingoraConfig := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec: v1alpha1.PingoraConfigSpec{
+			Address: "pingora-proxy:50051",
+			TLS: &v1alpha1.TLSConfig{
+				Enabled:   true,
+				SecretRef: &v1alpha1.SecretReference{Name: "pingora-tls"},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora-tls", Namespace: "pingora-system"},
+		Data:       map[string][]byte{"tls.crt": oldCert, "tls.key": oldKey},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pingoraConfig, secret, newPingoraGatewayClass("pingora")).
+		Build()
+
+	credentials := &config.TLSCredentials{}
+	_, err := credentials.Update(oldCert, oldKey, nil)
+	require.NoError(t, err)
+
+	recorder := record.NewFakeRecorder(10)
+	syncer := &PingoraRouteSyncer{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		ConfigResolver:   config.NewPingoraResolver(cli, "pingora-system"),
+		Logger:           slog.Default(),
+		Recorder:         recorder,
+		credentials:      credentials,
+	}
+	ctx := context.Background()
+
+	// A rotated Secret is loaded into the open connection
+	secret.Data = map[string][]byte{"tls.crt": newCert, "tls.key": newKey}
+	require.NoError(t, cli.Update(ctx, secret))
+
+	syncer.refreshCredentials(ctx, syncer.Logger)
+
+	changed, err := credentials.Update(newCert, newKey, nil)
+	require.NoError(t, err)
+	assert.False(t, changed, "rotated certificate must already be loaded")
+
+	// An invalid Secret keeps the current certificate and is reported
+	secret.Data = map[string][]byte{"tls.crt": []byte("not a certificate"), "tls.key": newKey}
+	require.NoError(t, cli.Update(ctx, secret))
+
+	syncer.refreshCredentials(ctx, syncer.Logger)
+
+	changed, err = credentials.Update(newCert, newKey, nil)
+	require.NoError(t, err)
+	assert.False(t, changed, "invalid certificate must not replace the current one")
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, EventReasonCertificateInvalid)
+}
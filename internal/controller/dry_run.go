@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cockroachdb/errors"
+)
+
+// resolveDryRunConfig validates the PingoraConfig of the GatewayClass in
+// place of connecting to the proxy, and remembers its name for status
+// updates.
+func (s *PingoraRouteSyncer) resolveDryRunConfig(ctx context.Context) error {
+	resolved, err := s.ConfigResolver.ResolveFromGatewayClassName(ctx, s.GatewayClassName)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve Pingora config")
+	}
+
+	s.connMu.Lock()
+	s.configName = resolved.ConfigName
+	s.connMu.Unlock()
+
+	return nil
+}
+
+// recordDryRun records a built route config as if the proxy applied it, so
+// that unchanged configs are skipped and the controller becomes ready.
+// Nothing is sent to the proxy and the config version is not advanced.
+func (s *PingoraRouteSyncer) recordDryRun(ctx context.Context, logger *slog.Logger, applied AppliedConfig) {
+	logger.Info("dry run, not sending routes to Pingora",
+		"hash", applied.Hash,
+		"httpRouteCount", applied.HTTPRouteCount,
+		"grpcRouteCount", applied.GRPCRouteCount,
+	)
+
+	s.setAppliedConfig(applied)
+	s.recordSyncAttempt(ctx, syncAttempt{dryRun: true})
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+func TestPingoraRouteSyncer_DryRun(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	pingoraConfig := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec:       v1alpha1.PingoraConfigSpec{Address: "pingora-proxy:50051"},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pingoraConfig, newPingoraGatewayClass("pingora")).
+		WithStatusSubresource(pingoraConfig).
+		Build()
+
+	syncer := NewPingoraRouteSyncer(cli, scheme, dns.NewClusterDomainProvider(dns.DefaultClusterDomain), "pingora",
+		config.NewPingoraResolver(cli, "pingora-system"), metrics.NewNoopCollector(), 0, slog.Default())
+	syncer.DryRun = true
+
+	_, syncResult, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, syncResult)
+
+	// The config counts as applied without ever connecting to the proxy
+	assert.False(t, syncer.IsConnected())
+	assert.True(t, syncer.StartupSynced())
+	assert.NotEmpty(t, syncer.GetAppliedConfig().Hash)
+	assert.Zero(t, syncer.GetVersion())
+	assert.Equal(t, "pingora", syncer.GetConfigName())
+
+	var updated v1alpha1.PingoraConfig
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Name: "pingora"}, &updated))
+
+	assert.False(t, updated.Status.Connected)
+	assert.Zero(t, updated.Status.ConfigVersion)
+
+	ready := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionTypeReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, metav1.ConditionTrue, ready.Status)
+	assert.Equal(t, v1alpha1.ReasonDryRun, ready.Reason)
+
+	degraded := meta.FindStatusCondition(updated.Status.Conditions, v1alpha1.ConditionTypeDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionFalse, degraded.Status)
+
+	// An unchanged config is skipped as usual
+	applied := syncer.GetAppliedConfig()
+
+	_, _, err = syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, applied, syncer.GetAppliedConfig())
+	assert.False(t, syncer.IsConnected())
+}
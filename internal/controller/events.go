@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+)
+
+// EventSource is the component name of the Events emitted by the controller.
+const EventSource = "pingora-gateway-controller"
+
+// Reasons of the Kubernetes Events emitted by the controller.
+const (
+	// EventReasonSyncSucceeded is emitted on a route once the proxy runs its
+	// latest generation.
+	EventReasonSyncSucceeded = "SyncSucceeded"
+
+	// EventReasonSyncFailed is emitted on a route when syncing it to the
+	// proxy failed.
+	EventReasonSyncFailed = "SyncFailed"
+
+	// EventReasonRouteRejected is emitted on a route when a parent Gateway
+	// does not accept it or its references cannot be resolved.
+	EventReasonRouteRejected = "RouteRejected"
+
+	// EventReasonProxyUnreachable is emitted on a Gateway when it is no longer
+	// programmed because the proxy is down.
+	EventReasonProxyUnreachable = GatewayReasonProxyUnreachable
+
+	// EventReasonCertificateInvalid is emitted on a PingoraConfig when the TLS
+	// certificates for the proxy connection cannot be loaded.
+	EventReasonCertificateInvalid = "CertificateInvalid"
+)
+
+// recordRouteEvents emits Events for the parent statuses written by
+// controllerName that changed since previous, the own conditions keyed by
+// ancestorKey. Unchanged parents emit nothing, so that every reconcile does
+// not repeat the Events. A nil recorder emits nothing.
+func recordRouteEvents(
+	recorder record.EventRecorder,
+	route runtime.Object,
+	controllerName string,
+	previous map[string][]metav1.Condition,
+	parents []gatewayv1.RouteParentStatus,
+) {
+	if recorder == nil {
+		return
+	}
+
+	for _, parent := range parents {
+		if string(parent.ControllerName) != controllerName {
+			continue
+		}
+
+		old := previous[ancestorKey(parent.ParentRef)]
+		gateway := parentName(parent.ParentRef)
+
+		if accepted := meta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionAccepted)); accepted != nil &&
+			conditionChanged(meta.FindStatusCondition(old, accepted.Type), accepted) {
+			switch {
+			case accepted.Status == metav1.ConditionTrue:
+				recorder.Eventf(route, corev1.EventTypeNormal, EventReasonSyncSucceeded,
+					"Generation %d programmed in Pingora proxy for Gateway %s", accepted.ObservedGeneration, gateway)
+			case accepted.Reason == string(gatewayv1.RouteReasonPending):
+				recorder.Eventf(route, corev1.EventTypeWarning, EventReasonSyncFailed,
+					"Sync to Pingora proxy failed: %s", accepted.Message)
+			default:
+				recorder.Eventf(route, corev1.EventTypeWarning, EventReasonRouteRejected,
+					"Rejected by Gateway %s: %s: %s", gateway, accepted.Reason, accepted.Message)
+			}
+		}
+
+		if resolved := meta.FindStatusCondition(parent.Conditions, string(gatewayv1.RouteConditionResolvedRefs)); resolved != nil &&
+			resolved.Status == metav1.ConditionFalse &&
+			conditionChanged(meta.FindStatusCondition(old, resolved.Type), resolved) {
+			recorder.Eventf(route, corev1.EventTypeWarning, EventReasonRouteRejected,
+				"References not resolved: %s: %s", resolved.Reason, resolved.Message)
+		}
+	}
+}
+
+// recordGatewayEvents emits Events for the Gateway conditions that changed
+// since previous. A nil recorder emits nothing.
+func recordGatewayEvents(recorder record.EventRecorder, gateway runtime.Object, previous, status *gatewayv1.GatewayStatus) {
+	if recorder == nil {
+		return
+	}
+
+	programmed := meta.FindStatusCondition(status.Conditions, string(gatewayv1.GatewayConditionProgrammed))
+	if programmed == nil || programmed.Reason != GatewayReasonProxyUnreachable {
+		return
+	}
+
+	if conditionChanged(meta.FindStatusCondition(previous.Conditions, programmed.Type), programmed) {
+		recorder.Event(gateway, corev1.EventTypeWarning, EventReasonProxyUnreachable, programmed.Message)
+	}
+}
+
+// recordCertificateInvalid emits a CertificateInvalid Event on the
+// PingoraConfig of the GatewayClass. A nil recorder emits nothing.
+func (s *PingoraRouteSyncer) recordCertificateInvalid(ctx context.Context, err error) {
+	if s.Recorder == nil {
+		return
+	}
+
+	name := s.configNameForClass(ctx)
+	if name == "" {
+		return
+	}
+
+	var pingoraConfig v1alpha1.PingoraConfig
+	if getErr := s.Get(ctx, client.ObjectKey{Name: name}, &pingoraConfig); getErr != nil {
+		return
+	}
+
+	s.Recorder.Eventf(&pingoraConfig, corev1.EventTypeWarning, EventReasonCertificateInvalid,
+		"TLS certificate for the Pingora proxy connection is invalid: %v", err)
+}
+
+// conditionChanged reports whether a condition differs from its previous
+// version in a way worth an Event.
+func conditionChanged(old, condition *metav1.Condition) bool {
+	return old == nil ||
+		old.Status != condition.Status ||
+		old.Reason != condition.Reason ||
+		old.ObservedGeneration != condition.ObservedGeneration
+}
+
+// parentName formats a parent reference as "namespace/name".
+func parentName(ref gatewayv1.ParentReference) string {
+	if ref.Namespace == nil {
+		return string(ref.Name)
+	}
+
+	return fmt.Sprintf("%s/%s", *ref.Namespace, ref.Name)
+}
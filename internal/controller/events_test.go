@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// drainEvents returns the Events recorded so far.
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestRecordRouteEvents(t *testing.T) {
+	t.Parallel()
+
+	namespace := gatewayv1.Namespace("gateway-system")
+	parentRef := gatewayv1.ParentReference{Name: "public", Namespace: &namespace}
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+
+	parent := func(controller string, conditions ...metav1.Condition) gatewayv1.RouteParentStatus {
+		return gatewayv1.RouteParentStatus{
+			ParentRef:      parentRef,
+			ControllerName: gatewayv1.GatewayController(controller),
+			Conditions:     conditions,
+		}
+	}
+	accepted := func(status metav1.ConditionStatus, reason string, generation int64) metav1.Condition {
+		return metav1.Condition{
+			Type: string(gatewayv1.RouteConditionAccepted), Status: status, Reason: reason,
+			Message: "message", ObservedGeneration: generation,
+		}
+	}
+	resolvedRefs := metav1.Condition{
+		Type: string(gatewayv1.RouteConditionResolvedRefs), Status: metav1.ConditionFalse,
+		Reason: string(gatewayv1.RouteReasonInvalidKind), Message: "unsupported kind",
+	}
+
+	tests := []struct {
+		name     string
+		previous []metav1.Condition
+		parent   gatewayv1.RouteParentStatus
+		expected []string
+	}{
+		{
+			name:     "first programming",
+			parent:   parent(adoptionControllerName, accepted(metav1.ConditionTrue, "Accepted", 1)),
+			expected: []string{"Normal SyncSucceeded Generation 1 programmed in Pingora proxy for Gateway gateway-system/public"},
+		},
+		{
+			name:     "new generation",
+			previous: []metav1.Condition{accepted(metav1.ConditionTrue, "Accepted", 1)},
+			parent:   parent(adoptionControllerName, accepted(metav1.ConditionTrue, "Accepted", 2)),
+			expected: []string{"Normal SyncSucceeded Generation 2 programmed in Pingora proxy for Gateway gateway-system/public"},
+		},
+		{
+			name:     "unchanged",
+			previous: []metav1.Condition{accepted(metav1.ConditionTrue, "Accepted", 1)},
+			parent:   parent(adoptionControllerName, accepted(metav1.ConditionTrue, "Accepted", 1)),
+		},
+		{
+			name:     "sync failed",
+			previous: []metav1.Condition{accepted(metav1.ConditionTrue, "Accepted", 1)},
+			parent:   parent(adoptionControllerName, accepted(metav1.ConditionFalse, "Pending", 1)),
+			expected: []string{"Warning SyncFailed Sync to Pingora proxy failed: message"},
+		},
+		{
+			name:   "rejected with unresolved refs",
+			parent: parent(adoptionControllerName, accepted(metav1.ConditionFalse, "NotAllowedByListeners", 1), resolvedRefs),
+			expected: []string{
+				"Warning RouteRejected Rejected by Gateway gateway-system/public: NotAllowedByListeners: message",
+				"Warning RouteRejected References not resolved: InvalidKind: unsupported kind",
+			},
+		},
+		{
+			name:   "other controller",
+			parent: parent("example.com/other", accepted(metav1.ConditionTrue, "Accepted", 1)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := record.NewFakeRecorder(10)
+			previous := map[string][]metav1.Condition{ancestorKey(parentRef): tt.previous}
+
+			recordRouteEvents(recorder, route, adoptionControllerName, previous, []gatewayv1.RouteParentStatus{tt.parent})
+
+			assert.Equal(t, tt.expected, drainEvents(recorder))
+		})
+	}
+
+	// Without a recorder nothing is emitted
+	recordRouteEvents(nil, route, adoptionControllerName, nil,
+		[]gatewayv1.RouteParentStatus{parent(adoptionControllerName, accepted(metav1.ConditionTrue, "Accepted", 1))})
+}
+
+func TestRecordGatewayEvents(t *testing.T) {
+	t.Parallel()
+
+	gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "gateway-system"}}
+
+	programmed := metav1.Condition{
+		Type: string(gatewayv1.GatewayConditionProgrammed), Status: metav1.ConditionTrue,
+		Reason: string(gatewayv1.GatewayReasonProgrammed),
+	}
+	unreachable := metav1.Condition{
+		Type: string(gatewayv1.GatewayConditionProgrammed), Status: metav1.ConditionFalse,
+		Reason: GatewayReasonProxyUnreachable, Message: "Pingora proxy unreachable",
+	}
+
+	recorder := record.NewFakeRecorder(10)
+
+	down := &gatewayv1.GatewayStatus{Conditions: []metav1.Condition{unreachable}}
+	recordGatewayEvents(recorder, gateway, &gatewayv1.GatewayStatus{Conditions: []metav1.Condition{programmed}}, down)
+	assert.Equal(t, []string{"Warning ProxyUnreachable Pingora proxy unreachable"}, drainEvents(recorder))
+
+	// Staying down and recovering emit nothing
+	recordGatewayEvents(recorder, gateway, down, down)
+	recordGatewayEvents(recorder, gateway, down, &gatewayv1.GatewayStatus{Conditions: []metav1.Condition{programmed}})
+	assert.Empty(t, drainEvents(recorder))
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// FeatureGate names an optional Gateway API resource whose CRD is not
+// installed by every Gateway API channel or release.
+type FeatureGate string
+
+const (
+	// FeatureGateBackendTLSPolicy watches BackendTLSPolicies and connects to
+	// the Services they target with TLS.
+	FeatureGateBackendTLSPolicy FeatureGate = "BackendTLSPolicy"
+)
+
+// unsupportedFeatureGates are Gateway API resources the proxy cannot serve.
+// They are rejected with a clearer message than unknown gates.
+//
+//nolint:gochecknoglobals // read-only list of resource names
+var unsupportedFeatureGates = []FeatureGate{"TLSRoute", "TCPRoute", "UDPRoute"}
+
+// FeatureGates are the enabled optional Gateway API resources. Gates
+// default to disabled, so that the controller never watches resources whose
+// CRDs may be missing unless asked to.
+type FeatureGates map[FeatureGate]bool
+
+// ParseFeatureGates parses gates given as Name=bool pairs, for example
+// BackendTLSPolicy=true. A name without a value enables the gate.
+func ParseFeatureGates(values []string) (FeatureGates, error) {
+	gates := make(FeatureGates, len(values))
+
+	for _, value := range values {
+		name, enabled, hasValue := strings.Cut(strings.TrimSpace(value), "=")
+		gate := FeatureGate(strings.TrimSpace(name))
+
+		if gate != FeatureGateBackendTLSPolicy {
+			if slices.Contains(unsupportedFeatureGates, gate) {
+				//nolint:wrapcheck // Newf creates new error, not wrapping
+				return nil, errors.Newf("feature gate %q is not supported by the Pingora proxy", gate)
+			}
+
+			//nolint:wrapcheck // Newf creates new error, not wrapping
+			return nil, errors.Newf("unknown feature gate %q (valid: %s)", gate, FeatureGateBackendTLSPolicy)
+		}
+
+		if !hasValue {
+			gates[gate] = true
+
+			continue
+		}
+
+		on, err := strconv.ParseBool(strings.TrimSpace(enabled))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for feature gate %q", gate)
+		}
+
+		gates[gate] = on
+	}
+
+	return gates, nil
+}
+
+// Enabled reports whether a gate is enabled.
+func (g FeatureGates) Enabled(gate FeatureGate) bool {
+	return g[gate]
+}
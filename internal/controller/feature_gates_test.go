@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeatureGates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		values           []string
+		expected         FeatureGates
+		expectedContains string
+	}{
+		{
+			name:     "none",
+			expected: FeatureGates{},
+		},
+		{
+			name:     "enabled",
+			values:   []string{"BackendTLSPolicy=true"},
+			expected: FeatureGates{FeatureGateBackendTLSPolicy: true},
+		},
+		{
+			name:     "without value",
+			values:   []string{" BackendTLSPolicy "},
+			expected: FeatureGates{FeatureGateBackendTLSPolicy: true},
+		},
+		{
+			name:     "disabled",
+			values:   []string{"BackendTLSPolicy=false"},
+			expected: FeatureGates{FeatureGateBackendTLSPolicy: false},
+		},
+		{
+			name:             "invalid value",
+			values:           []string{"BackendTLSPolicy=yes please"},
+			expectedContains: `invalid value for feature gate "BackendTLSPolicy"`,
+		},
+		{
+			name:             "unsupported",
+			values:           []string{"TLSRoute=true"},
+			expectedContains: `feature gate "TLSRoute" is not supported`,
+		},
+		{
+			name:             "unknown",
+			values:           []string{"Everything=true"},
+			expectedContains: `unknown feature gate "Everything"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gates, err := ParseFeatureGates(tt.values)
+			if tt.expectedContains != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedContains)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, gates)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+// Feature categories reported at startup.
+const (
+	FeatureCategoryRouteKind = "route_kind"
+	FeatureCategoryFilter    = "filter"
+	FeatureCategoryPolicy    = "policy"
+	FeatureCategoryOption    = "option"
+	FeatureCategoryCRD       = "crd"
+)
+
+// Feature is a capability of the running controller.
+type Feature struct {
+	Category string
+	Name     string
+	Enabled  bool
+}
+
+// Features returns the capabilities of a controller started with cfg:
+// the route kinds it reconciles, the HTTPRoute filters the proxy programs,
+// the attachable policies, the optional behaviors turned on by flags and
+// the optional CRDs it found installed. Route kinds and policies without
+// their CRD are disabled.
+func Features(cfg *Config, missing MissingResources) []Feature {
+	routeIDScheme := cfg.RouteIDScheme
+	if routeIDScheme == "" {
+		routeIDScheme = ingress.DefaultRouteIDScheme
+	}
+
+	features := []Feature{
+		{Category: FeatureCategoryRouteKind, Name: "HTTPRoute", Enabled: true},
+		{Category: FeatureCategoryRouteKind, Name: "GRPCRoute", Enabled: !missing.Has(OptionalResourceGRPCRoute)},
+
+		{Category: FeatureCategoryFilter, Name: string(gatewayv1.HTTPRouteFilterCORS), Enabled: true},
+		{Category: FeatureCategoryFilter, Name: string(gatewayv1.HTTPRouteFilterExtensionRef), Enabled: true},
+		{Category: FeatureCategoryFilter, Name: string(gatewayv1.HTTPRouteFilterRequestHeaderModifier)},
+		{Category: FeatureCategoryFilter, Name: string(gatewayv1.HTTPRouteFilterResponseHeaderModifier)},
+		{Category: FeatureCategoryFilter, Name: string(gatewayv1.HTTPRouteFilterRequestRedirect)},
+		{Category: FeatureCategoryFilter, Name: string(gatewayv1.HTTPRouteFilterURLRewrite)},
+		{Category: FeatureCategoryFilter, Name: string(gatewayv1.HTTPRouteFilterRequestMirror)},
+
+		{Category: FeatureCategoryPolicy, Name: v1alpha1.PingoraRateLimitPolicyKind, Enabled: true},
+		{Category: FeatureCategoryPolicy, Name: v1alpha1.PingoraTrafficPolicyKind, Enabled: true},
+		{Category: FeatureCategoryPolicy, Name: v1alpha1.PingoraAuthPolicyKind, Enabled: true},
+		{Category: FeatureCategoryPolicy, Name: v1alpha1.PingoraAccessControlPolicyKind, Enabled: true},
+		{Category: FeatureCategoryPolicy, Name: v1alpha1.PingoraCachePolicyKind, Enabled: true},
+		{Category: FeatureCategoryPolicy, Name: v1alpha1.PingoraAccessLogPolicyKind, Enabled: true},
+		{Category: FeatureCategoryPolicy, Name: v1alpha1.PingoraBackendPolicyKind, Enabled: true},
+		{Category: FeatureCategoryPolicy, Name: v1alpha1.PingoraGRPCPolicyKind, Enabled: true},
+		{Category: FeatureCategoryPolicy, Name: string(FeatureGateBackendTLSPolicy),
+			Enabled: cfg.FeatureGates.Enabled(FeatureGateBackendTLSPolicy) &&
+				!missing.Has(OptionalResourceBackendTLSPolicy)},
+
+		{Category: FeatureCategoryOption, Name: "webhook", Enabled: cfg.WebhookEnabled},
+		{Category: FeatureCategoryOption, Name: "leader-election", Enabled: cfg.LeaderElect},
+		{Category: FeatureCategoryOption, Name: "sync-debounce", Enabled: cfg.SyncDebounce > 0},
+		{Category: FeatureCategoryOption, Name: "proxy-version-check", Enabled: cfg.ProxyVersionCheckInterval > 0},
+		{Category: FeatureCategoryOption, Name: "sync-watchdog", Enabled: cfg.LivenessTimeout > 0},
+		{Category: FeatureCategoryOption, Name: "proxy-health-monitor", Enabled: cfg.ProxyHealthInterval > 0},
+		{Category: FeatureCategoryOption, Name: "cluster-domain-auto-detect", Enabled: cfg.ClusterDomainAutoDetect},
+		{Category: FeatureCategoryOption, Name: "binding-debug-annotations", Enabled: cfg.BindingDebugAnnotations},
+		{Category: FeatureCategoryOption, Name: "route-diagnostics-annotations", Enabled: cfg.RouteDiagnosticsAnnotations},
+		{Category: FeatureCategoryOption, Name: "route-deletion-finalizer", Enabled: cfg.RouteDeletionFinalizer},
+		{Category: FeatureCategoryOption, Name: "route-traffic-metrics", Enabled: cfg.RouteTrafficMetrics},
+		{Category: FeatureCategoryOption, Name: "controller-name-adoption", Enabled: len(cfg.AdoptControllerNames) > 0},
+		{Category: FeatureCategoryOption, Name: "smoke-test", Enabled: cfg.SmokeTestURL != ""},
+		{Category: FeatureCategoryOption, Name: "watch-namespaces", Enabled: len(cfg.WatchNamespaces) > 0},
+		{Category: FeatureCategoryOption, Name: "route-label-selector", Enabled: cfg.RouteLabelSelector != nil},
+		{Category: FeatureCategoryOption, Name: "require-parent-ref-grants", Enabled: cfg.RequireParentRefGrants},
+		{Category: FeatureCategoryOption, Name: "dry-run", Enabled: cfg.DryRun},
+		{Category: FeatureCategoryOption, Name: "route-id-scheme-" + string(routeIDScheme), Enabled: true},
+	}
+
+	for _, resource := range optionalResources(cfg.FeatureGates) {
+		features = append(features, Feature{
+			Category: FeatureCategoryCRD,
+			Name:     string(resource),
+			Enabled:  !missing.Has(resource),
+		})
+	}
+
+	return features
+}
+
+// featureLogValues groups features by category for a structured log entry.
+// Each category lists its enabled features, and disabled ones are listed
+// together under "disabled".
+func featureLogValues(features []Feature) []any {
+	byCategory := make(map[string][]string)
+	categories := make([]string, 0, len(features))
+
+	var disabled []string
+
+	for _, feature := range features {
+		if !feature.Enabled {
+			disabled = append(disabled, feature.Category+"/"+feature.Name)
+
+			continue
+		}
+
+		if _, ok := byCategory[feature.Category]; !ok {
+			categories = append(categories, feature.Category)
+		}
+
+		byCategory[feature.Category] = append(byCategory[feature.Category], feature.Name)
+	}
+
+	values := make([]any, 0, 2*len(categories)+2)
+	for _, category := range categories {
+		values = append(values, category, byCategory[category])
+	}
+
+	return append(values, "disabled", disabled)
+}
+
+// recordFeatures reports every feature as a metric.
+func recordFeatures(ctx context.Context, collector metrics.Collector, features []Feature) {
+	for _, feature := range features {
+		collector.RecordFeature(ctx, feature.Category, feature.Name, feature.Enabled)
+	}
+}
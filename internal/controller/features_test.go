@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+func enabledFeatures(features []Feature) []string {
+	var names []string
+
+	for _, feature := range features {
+		if feature.Enabled {
+			names = append(names, feature.Category+"/"+feature.Name)
+		}
+	}
+
+	return names
+}
+
+func TestFeatures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cfg      *Config
+		missing  MissingResources
+		expected []string
+	}{
+		{
+			name: "defaults",
+			cfg:  &Config{},
+			expected: []string{
+				"route_kind/HTTPRoute",
+				"route_kind/GRPCRoute",
+				"filter/CORS",
+				"filter/ExtensionRef",
+				"policy/PingoraRateLimitPolicy",
+				"policy/PingoraTrafficPolicy",
+				"policy/PingoraAuthPolicy",
+				"policy/PingoraAccessControlPolicy",
+				"policy/PingoraCachePolicy",
+				"policy/PingoraAccessLogPolicy",
+				"policy/PingoraBackendPolicy",
+				"policy/PingoraGRPCPolicy",
+				"option/route-id-scheme-name",
+				"crd/GRPCRoute",
+				"crd/ReferenceGrant",
+			},
+		},
+		{
+			name: "all options",
+			cfg: &Config{
+				WebhookEnabled:              true,
+				LeaderElect:                 true,
+				SyncDebounce:                time.Second,
+				ProxyVersionCheckInterval:   time.Minute,
+				LivenessTimeout:             5 * time.Minute,
+				ProxyHealthInterval:         15 * time.Second,
+				ClusterDomainAutoDetect:     true,
+				BindingDebugAnnotations:     true,
+				RouteDiagnosticsAnnotations: true,
+				RouteDeletionFinalizer:      true,
+				RouteTrafficMetrics:         true,
+				AdoptControllerNames:        []string{"example.com/old"},
+				SmokeTestURL:                "http://canary.example.com/healthz",
+				WatchNamespaces:             []string{"team-a"},
+				RouteLabelSelector:          labels.SelectorFromSet(labels.Set{"tier": "canary"}),
+				RequireParentRefGrants:      true,
+				FeatureGates:                FeatureGates{FeatureGateBackendTLSPolicy: true},
+				RouteIDScheme:               ingress.RouteIDSchemeUID,
+				DryRun:                      true,
+			},
+			expected: []string{
+				"route_kind/HTTPRoute",
+				"route_kind/GRPCRoute",
+				"filter/CORS",
+				"filter/ExtensionRef",
+				"policy/PingoraRateLimitPolicy",
+				"policy/PingoraTrafficPolicy",
+				"policy/PingoraAuthPolicy",
+				"policy/PingoraAccessControlPolicy",
+				"policy/PingoraCachePolicy",
+				"policy/PingoraAccessLogPolicy",
+				"policy/PingoraBackendPolicy",
+				"policy/PingoraGRPCPolicy",
+				"policy/BackendTLSPolicy",
+				"option/webhook",
+				"option/leader-election",
+				"option/sync-debounce",
+				"option/proxy-version-check",
+				"option/sync-watchdog",
+				"option/proxy-health-monitor",
+				"option/cluster-domain-auto-detect",
+				"option/binding-debug-annotations",
+				"option/route-diagnostics-annotations",
+				"option/route-deletion-finalizer",
+				"option/route-traffic-metrics",
+				"option/controller-name-adoption",
+				"option/smoke-test",
+				"option/watch-namespaces",
+				"option/route-label-selector",
+				"option/require-parent-ref-grants",
+				"option/dry-run",
+				"option/route-id-scheme-uid",
+				"crd/GRPCRoute",
+				"crd/ReferenceGrant",
+				"crd/BackendTLSPolicy",
+			},
+		},
+		{
+			name: "missing crds",
+			cfg:  &Config{FeatureGates: FeatureGates{FeatureGateBackendTLSPolicy: true}},
+			missing: MissingResources{
+				OptionalResourceGRPCRoute:        true,
+				OptionalResourceBackendTLSPolicy: true,
+			},
+			expected: []string{
+				"route_kind/HTTPRoute",
+				"filter/CORS",
+				"filter/ExtensionRef",
+				"policy/PingoraRateLimitPolicy",
+				"policy/PingoraTrafficPolicy",
+				"policy/PingoraAuthPolicy",
+				"policy/PingoraAccessControlPolicy",
+				"policy/PingoraCachePolicy",
+				"policy/PingoraAccessLogPolicy",
+				"policy/PingoraBackendPolicy",
+				"policy/PingoraGRPCPolicy",
+				"option/route-id-scheme-name",
+				"crd/ReferenceGrant",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, enabledFeatures(Features(tt.cfg, tt.missing)))
+		})
+	}
+}
+
+func TestFeatureLogValues(t *testing.T) {
+	t.Parallel()
+
+	values := featureLogValues([]Feature{
+		{Category: FeatureCategoryRouteKind, Name: "HTTPRoute", Enabled: true},
+		{Category: FeatureCategoryFilter, Name: "CORS", Enabled: true},
+		{Category: FeatureCategoryFilter, Name: "URLRewrite"},
+		{Category: FeatureCategoryRouteKind, Name: "GRPCRoute", Enabled: true},
+	})
+
+	assert.Equal(t, []any{
+		FeatureCategoryRouteKind, []string{"HTTPRoute", "GRPCRoute"},
+		FeatureCategoryFilter, []string{"CORS"},
+		"disabled", []string{"filter/URLRewrite"},
+	}, values)
+}
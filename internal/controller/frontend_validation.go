@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/referencegrant"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// Listener reasons for caCertificateRefs of the frontend validation that
+// cannot be used.
+const (
+	ListenerReasonInvalidCACertificateRef  gatewayv1.ListenerConditionReason = "InvalidCACertificateRef"
+	ListenerReasonInvalidCACertificateKind gatewayv1.ListenerConditionReason = "InvalidCACertificateKind"
+)
+
+// resolveFrontendValidation reads the CA certificates of the frontend
+// validation of a listener. If a caCertificateRef cannot be used, the
+// returned validation holds no CA certificates, so that no client
+// certificate validates instead of the listener accepting every client,
+// and the problem tells why.
+func resolveFrontendValidation(
+	ctx context.Context,
+	cli client.Client,
+	gateway *gatewayv1.Gateway,
+	validation *gatewayv1.FrontendTLSValidation,
+) (*routingv1.ClientValidation, *certificateRefProblem, error) {
+	resolved := &routingv1.ClientValidation{Mode: ingress.ClientValidationModeFromGateway(validation.Mode)}
+
+	invalid := func(reason gatewayv1.ListenerConditionReason, format string, args ...any) *certificateRefProblem {
+		return &certificateRefProblem{
+			resolvedReason:   reason,
+			programmedReason: string(gatewayv1.ListenerReasonInvalid),
+			message:          fmt.Sprintf(format, args...),
+		}
+	}
+
+	var bundle bytes.Buffer
+
+	for _, ref := range validation.CACertificateRefs {
+		if !ingress.IsCACertificateKind(ref) {
+			return resolved, invalid(ListenerReasonInvalidCACertificateKind,
+				"caCertificateRef %s is not a ConfigMap or Secret", ref.Name), nil
+		}
+
+		namespace := gateway.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		allowed, err := referencegrant.NewValidator(cli).IsReferenceAllowed(ctx,
+			referencegrant.Reference{
+				Group:     gatewayv1.GroupName,
+				Kind:      kindGateway,
+				Namespace: gateway.Namespace,
+				Name:      gateway.Name,
+			},
+			referencegrant.Reference{Kind: string(ref.Kind), Namespace: namespace, Name: string(ref.Name)},
+		)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to check ReferenceGrants")
+		}
+
+		if !allowed {
+			return resolved, invalid(gatewayv1.ListenerReasonRefNotPermitted,
+				"%s %s/%s is not permitted by any ReferenceGrant", ref.Kind, namespace, ref.Name), nil
+		}
+
+		data, err := caCertificateData(ctx, cli, string(ref.Kind), client.ObjectKey{Namespace: namespace, Name: string(ref.Name)})
+		if apierrors.IsNotFound(err) {
+			return resolved, invalid(ListenerReasonInvalidCACertificateRef,
+				"%s %s/%s not found", ref.Kind, namespace, ref.Name), nil
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		certificates, err := ingress.ParseCACertificates(fmt.Sprintf("%s %s/%s", ref.Kind, namespace, ref.Name), data)
+		if err != nil {
+			return resolved, invalid(ListenerReasonInvalidCACertificateRef, "%v", err), nil
+		}
+
+		bundle.Write(certificates)
+
+		if !bytes.HasSuffix(certificates, []byte("\n")) {
+			bundle.WriteByte('\n')
+		}
+	}
+
+	resolved.CaCertificates = bundle.Bytes()
+
+	return resolved, nil, nil
+}
+
+// caCertificateData returns the data of the ConfigMap or Secret a
+// caCertificateRef references.
+func caCertificateData(ctx context.Context, cli client.Client, kind string, key client.ObjectKey) (map[string][]byte, error) {
+	if kind == "Secret" {
+		var secret corev1.Secret
+		if err := cli.Get(ctx, key, &secret); err != nil {
+			return nil, errors.Wrapf(err, "failed to get secret %s", key)
+		}
+
+		return secret.Data, nil
+	}
+
+	var configMap corev1.ConfigMap
+	if err := cli.Get(ctx, key, &configMap); err != nil {
+		return nil, errors.Wrapf(err, "failed to get configmap %s", key)
+	}
+
+	data := make(map[string][]byte, len(configMap.Data))
+	for name, value := range configMap.Data {
+		data[name] = []byte(value)
+	}
+
+	return data, nil
+}
+
+// configMapToGateways maps ConfigMap events to the Gateways of our
+// GatewayClass whose listeners validate client certificates with the CA
+// certificates of the ConfigMap.
+func (r *PingoraGatewayReconciler) configMapToGateways(ctx context.Context, obj client.Object) []reconcile.Request {
+	var gatewayList gatewayv1.GatewayList
+	if err := r.List(ctx, &gatewayList); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range gatewayList.Items {
+		gateway := &gatewayList.Items[i]
+		if string(gateway.Spec.GatewayClassName) != r.GatewayClassName {
+			continue
+		}
+
+		if ingress.ReferencesCACertificate(gateway, "ConfigMap", obj.GetNamespace(), obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(gateway)})
+		}
+	}
+
+	return requests
+}
@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestResolveFrontendValidation(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	rootCA, _ := generateKeyPair(t, "Root CA")
+	partnerCA, _ := generateKeyPair(t, "Partner CA")
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "root-ca"},
+				Data:       map[string]string{"ca.crt": string(rootCA)},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "partner-ca"},
+				Data:       map[string][]byte{"ca.crt": partnerCA},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "empty-ca"},
+				Data:       map[string]string{"ca.crt": ""},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "shared-ca"},
+				Data:       map[string]string{"ca.crt": string(rootCA)},
+			},
+		).
+		Build()
+
+	gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"}}
+	certs := gatewayv1.Namespace("certs")
+
+	tests := []struct {
+		name             string
+		refs             []gatewayv1.ObjectReference
+		mode             gatewayv1.FrontendValidationModeType
+		expectedCA       []byte
+		expectedMode     routingv1.ClientValidationMode
+		expectedReason   gatewayv1.ListenerConditionReason
+		expectedContains string
+	}{
+		{
+			name:         "configmap",
+			refs:         []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "root-ca"}},
+			expectedCA:   rootCA,
+			expectedMode: routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY,
+		},
+		{
+			name: "configmap and secret with insecure fallback",
+			refs: []gatewayv1.ObjectReference{
+				{Kind: "ConfigMap", Name: "root-ca"},
+				{Kind: "Secret", Name: "partner-ca"},
+			},
+			mode:         gatewayv1.AllowInsecureFallback,
+			expectedCA:   append(append([]byte{}, rootCA...), partnerCA...),
+			expectedMode: routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_INSECURE_FALLBACK,
+		},
+		{
+			name:             "unsupported kind",
+			refs:             []gatewayv1.ObjectReference{{Kind: "Service", Name: "root-ca"}},
+			expectedMode:     routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY,
+			expectedReason:   ListenerReasonInvalidCACertificateKind,
+			expectedContains: "not a ConfigMap or Secret",
+		},
+		{
+			name:             "missing configmap",
+			refs:             []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "missing-ca"}},
+			expectedMode:     routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY,
+			expectedReason:   ListenerReasonInvalidCACertificateRef,
+			expectedContains: "ConfigMap default/missing-ca not found",
+		},
+		{
+			name:             "no certificate",
+			refs:             []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "empty-ca"}},
+			expectedMode:     routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY,
+			expectedReason:   ListenerReasonInvalidCACertificateRef,
+			expectedContains: "holds no PEM-encoded CA certificate",
+		},
+		{
+			name: "valid and missing",
+			refs: []gatewayv1.ObjectReference{
+				{Kind: "ConfigMap", Name: "root-ca"},
+				{Kind: "Secret", Name: "missing-ca"},
+			},
+			mode:             gatewayv1.AllowInsecureFallback,
+			expectedMode:     routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_INSECURE_FALLBACK,
+			expectedReason:   ListenerReasonInvalidCACertificateRef,
+			expectedContains: "Secret default/missing-ca not found",
+		},
+		{
+			name:             "cross namespace without grant",
+			refs:             []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "shared-ca", Namespace: &certs}},
+			expectedMode:     routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY,
+			expectedReason:   gatewayv1.ListenerReasonRefNotPermitted,
+			expectedContains: "ConfigMap certs/shared-ca is not permitted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			validation, problem, err := resolveFrontendValidation(context.Background(), cli, gateway,
+				&gatewayv1.FrontendTLSValidation{CACertificateRefs: tt.refs, Mode: tt.mode})
+			require.NoError(t, err)
+			require.NotNil(t, validation)
+			assert.Equal(t, tt.expectedMode, validation.GetMode())
+
+			if tt.expectedReason == "" {
+				assert.Nil(t, problem)
+				assert.Equal(t, tt.expectedCA, validation.GetCaCertificates())
+
+				return
+			}
+
+			require.NotNil(t, problem)
+			assert.Equal(t, tt.expectedReason, problem.resolvedReason)
+			assert.Equal(t, string(gatewayv1.ListenerReasonInvalid), problem.programmedReason)
+			assert.Contains(t, problem.message, tt.expectedContains)
+			// Unresolved CA certificates validate no client certificate
+			assert.Empty(t, validation.GetCaCertificates())
+		})
+	}
+}
+
+func TestResolveFrontendValidation_ReferenceGrant(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	rootCA, _ := generateKeyPair(t, "Root CA")
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "shared-ca"},
+				Data:       map[string]string{"ca.crt": string(rootCA)},
+			},
+			&gatewayv1beta1.ReferenceGrant{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "certs", Name: "gateways"},
+				Spec: gatewayv1beta1.ReferenceGrantSpec{
+					From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "Gateway", Namespace: "default"}},
+					To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "ConfigMap"}},
+				},
+			},
+		).
+		Build()
+
+	gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"}}
+	certs := gatewayv1.Namespace("certs")
+
+	validation, problem, err := resolveFrontendValidation(context.Background(), cli, gateway,
+		&gatewayv1.FrontendTLSValidation{
+			CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "shared-ca", Namespace: &certs}},
+		})
+	require.NoError(t, err)
+	assert.Nil(t, problem)
+	assert.Equal(t, rootCA, validation.GetCaCertificates())
+}
+
+func TestPingoraGatewayReconciler_ConfigMapToGateways(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	gateway := func(name, className string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gatewayv1.GatewaySpec{
+				GatewayClassName: gatewayv1.ObjectName(className),
+				Listeners: []gatewayv1.Listener{{
+					Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443,
+					TLS: &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "app-tls"}}},
+				}},
+				TLS: &gatewayv1.GatewayTLSConfig{Frontend: &gatewayv1.FrontendTLSConfig{
+					Default: gatewayv1.TLSConfig{Validation: &gatewayv1.FrontendTLSValidation{
+						CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "client-ca"}},
+					}},
+				}},
+			},
+		}
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gateway("web", "pingora"), gateway("other", "other")).
+		Build()
+	reconciler := &PingoraGatewayReconciler{Client: cli, GatewayClassName: "pingora"}
+
+	requests := reconciler.configMapToGateways(context.Background(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "client-ca"},
+	})
+	require.Len(t, requests, 1)
+	assert.Equal(t, "web", requests[0].Name)
+
+	assert.Empty(t, reconciler.configMapToGateways(context.Background(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "unrelated"},
+	}))
+}
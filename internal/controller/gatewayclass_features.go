@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/gateway-api/pkg/features"
+)
+
+// GatewayClass condition reported while optional CRDs are missing.
+const (
+	GatewayClassConditionDegraded = "Degraded"
+	GatewayClassReasonMissingCRDs = "MissingCRDs"
+)
+
+// baseGatewayClassFeatures are the Gateway API features the controller
+// supports regardless of feature gates. Keep in sync with the conformance
+// test suite.
+//
+//nolint:gochecknoglobals // read-only list of feature names
+var baseGatewayClassFeatures = []features.FeatureName{
+	features.SupportGateway,
+	features.SupportHTTPRoute,
+	features.SupportHTTPRouteQueryParamMatching,
+	features.SupportHTTPRouteMethodMatching,
+	features.SupportHTTPRouteRequestTimeout,
+	features.SupportHTTPRouteBackendTimeout,
+	features.SupportHTTPRouteNamedRouteRule,
+	features.SupportHTTPRouteCORS,
+}
+
+// gatewayClassFeatures returns the features reported in the GatewayClass
+// status, sorted by name as Gateway API requires. Features of resources
+// without a CRD are left out.
+func gatewayClassFeatures(gates FeatureGates, missing MissingResources) []gatewayv1.SupportedFeature {
+	names := slices.Clone(baseGatewayClassFeatures)
+
+	if !missing.Has(OptionalResourceReferenceGrant) {
+		names = append(names, features.SupportReferenceGrant)
+	}
+
+	if !missing.Has(OptionalResourceGRPCRoute) {
+		names = append(names, features.SupportGRPCRoute, features.SupportGRPCRouteNamedRouteRule)
+	}
+
+	if gates.Enabled(FeatureGateBackendTLSPolicy) && !missing.Has(OptionalResourceBackendTLSPolicy) {
+		names = append(names, features.SupportBackendTLSPolicy)
+	}
+
+	supported := make([]gatewayv1.SupportedFeature, 0, len(names))
+	for _, name := range names {
+		supported = append(supported, gatewayv1.SupportedFeature{Name: gatewayv1.FeatureName(name)})
+	}
+
+	slices.SortFunc(supported, func(a, b gatewayv1.SupportedFeature) int {
+		return strings.Compare(string(a.Name), string(b.Name))
+	})
+
+	return supported
+}
+
+// GatewayClassFeaturesReconciler reports the features the controller
+// supports in status.supportedFeatures of its GatewayClass, and a Degraded
+// condition while optional CRDs are missing. Other conditions are left
+// untouched.
+type GatewayClassFeaturesReconciler struct {
+	client.Client
+
+	GatewayClassName string
+	ControllerName   string
+	FeatureGates     FeatureGates
+	MissingResources MissingResources
+}
+
+// Reconcile writes the supported features and the Degraded condition if
+// they differ from the status.
+func (r *GatewayClassFeaturesReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var gatewayClass gatewayv1.GatewayClass
+
+	if err := r.Get(ctx, req.NamespacedName, &gatewayClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, errors.Wrap(err, "failed to get gatewayclass")
+	}
+
+	if string(gatewayClass.Spec.ControllerName) != r.ControllerName {
+		return ctrl.Result{}, nil
+	}
+
+	original := gatewayClass.DeepCopy()
+	supported := gatewayClassFeatures(r.FeatureGates, r.MissingResources)
+	featuresChanged := !slices.Equal(gatewayClass.Status.SupportedFeatures, supported)
+	gatewayClass.Status.SupportedFeatures = supported
+
+	if !r.setDegradedCondition(&gatewayClass) && !featuresChanged {
+		return ctrl.Result{}, nil
+	}
+
+	// The conditions list is replaced as a whole, so a concurrent update of
+	// another condition is retried rather than overwritten
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+	if err := r.Status().Patch(ctx, &gatewayClass, patch); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to update gatewayclass status")
+	}
+
+	log.FromContext(ctx).Info("updated gatewayclass status",
+		"name", gatewayClass.Name, "features", len(supported), "missingCRDs", r.MissingResources.Names())
+
+	return ctrl.Result{}, nil
+}
+
+// setDegradedCondition sets the Degraded condition while optional CRDs are
+// missing and removes it otherwise. It reports whether the status changed.
+func (r *GatewayClassFeaturesReconciler) setDegradedCondition(gatewayClass *gatewayv1.GatewayClass) bool {
+	missing := r.MissingResources.Names()
+	if len(missing) == 0 {
+		return meta.RemoveStatusCondition(&gatewayClass.Status.Conditions, GatewayClassConditionDegraded)
+	}
+
+	return meta.SetStatusCondition(&gatewayClass.Status.Conditions, metav1.Condition{
+		Type:               GatewayClassConditionDegraded,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: gatewayClass.Generation,
+		Reason:             GatewayClassReasonMissingCRDs,
+		Message: "CRDs not installed, their resources are ignored: " + strings.Join(missing, ", ") +
+			"; restart the controller after installing them",
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GatewayClassFeaturesReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	//nolint:wrapcheck // controller-runtime builder pattern
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gatewayv1.GatewayClass{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetName() == r.GatewayClassName
+		}))).
+		Complete(r)
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func featureNames(supported []gatewayv1.SupportedFeature) []string {
+	names := make([]string, 0, len(supported))
+	for _, feature := range supported {
+		names = append(names, string(feature.Name))
+	}
+
+	return names
+}
+
+func TestGatewayClassFeatures(t *testing.T) {
+	t.Parallel()
+
+	base := featureNames(gatewayClassFeatures(nil, nil))
+	assert.IsNonDecreasing(t, base)
+	assert.Contains(t, base, "HTTPRoute")
+	assert.Contains(t, base, "GRPCRoute")
+	assert.NotContains(t, base, "BackendTLSPolicy")
+
+	gates := FeatureGates{FeatureGateBackendTLSPolicy: true}
+
+	gated := featureNames(gatewayClassFeatures(gates, nil))
+	assert.IsNonDecreasing(t, gated)
+	assert.Equal(t, "BackendTLSPolicy", gated[0])
+	assert.Len(t, gated, len(base)+1)
+
+	degraded := featureNames(gatewayClassFeatures(gates, MissingResources{
+		OptionalResourceGRPCRoute:        true,
+		OptionalResourceReferenceGrant:   true,
+		OptionalResourceBackendTLSPolicy: true,
+	}))
+	assert.IsNonDecreasing(t, degraded)
+	assert.Contains(t, degraded, "HTTPRoute")
+	assert.NotContains(t, degraded, "GRPCRoute")
+	assert.NotContains(t, degraded, "GRPCRouteNamedRouteRule")
+	assert.NotContains(t, degraded, "ReferenceGrant")
+	assert.NotContains(t, degraded, "BackendTLSPolicy")
+}
+
+func TestGatewayClassFeaturesReconciler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	accepted := metav1.Condition{
+		Type:   string(gatewayv1.GatewayClassConditionStatusAccepted),
+		Status: metav1.ConditionTrue,
+		Reason: string(gatewayv1.GatewayClassReasonAccepted),
+	}
+
+	gatewayClass := func(name, controllerName string) *gatewayv1.GatewayClass {
+		return &gatewayv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       gatewayv1.GatewayClassSpec{ControllerName: gatewayv1.GatewayController(controllerName)},
+			Status:     gatewayv1.GatewayClassStatus{Conditions: []metav1.Condition{accepted}},
+		}
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gatewayClass("pingora", "example.com/pingora"), gatewayClass("other", "example.com/other")).
+		WithStatusSubresource(&gatewayv1.GatewayClass{}).
+		Build()
+
+	reconciler := &GatewayClassFeaturesReconciler{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		ControllerName:   "example.com/pingora",
+		FeatureGates:     FeatureGates{FeatureGateBackendTLSPolicy: true},
+	}
+
+	ctx := context.Background()
+
+	for _, name := range []string{"pingora", "other", "missing"} {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name}})
+		require.NoError(t, err)
+	}
+
+	var stored gatewayv1.GatewayClass
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: "pingora"}, &stored))
+	assert.Equal(t, gatewayClassFeatures(reconciler.FeatureGates, nil), stored.Status.SupportedFeatures)
+	// Conditions are left to the GatewayClass owner
+	require.Len(t, stored.Status.Conditions, 1)
+	assert.Equal(t, accepted.Type, stored.Status.Conditions[0].Type)
+
+	// GatewayClasses of other controllers are left untouched
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: "other"}, &stored))
+	assert.Empty(t, stored.Status.SupportedFeatures)
+
+	// Missing CRDs degrade the GatewayClass
+	reconciler.MissingResources = MissingResources{OptionalResourceGRPCRoute: true}
+
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "pingora"}}
+	_, err := reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: "pingora"}, &stored))
+	assert.Equal(t, gatewayClassFeatures(reconciler.FeatureGates, reconciler.MissingResources),
+		stored.Status.SupportedFeatures)
+
+	degraded := meta.FindStatusCondition(stored.Status.Conditions, GatewayClassConditionDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Equal(t, GatewayClassReasonMissingCRDs, degraded.Reason)
+	assert.Contains(t, degraded.Message, "GRPCRoute")
+	assert.NotNil(t, meta.FindStatusCondition(stored.Status.Conditions, accepted.Type))
+
+	// The condition is removed once the CRDs are installed
+	reconciler.MissingResources = nil
+
+	_, err = reconciler.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Name: "pingora"}, &stored))
+	assert.Nil(t, meta.FindStatusCondition(stored.Status.Conditions, GatewayClassConditionDegraded))
+	require.Len(t, stored.Status.Conditions, 1)
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	pingoraingress "github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// syncGlobalConfig sends the defaults of the PingoraConfig to the proxy
+// unless the proxy already accepted them. Failures are logged and retried
+// on the next sync without holding back route updates.
+func (s *PingoraRouteSyncer) syncGlobalConfig(ctx context.Context, logger *slog.Logger) {
+	pingoraConfig, err := s.pingoraConfigForClass(ctx)
+	if err != nil {
+		logger.Debug("failed to get PingoraConfig for global config", "error", err)
+
+		return
+	}
+
+	var defaults *v1alpha1.ProxyDefaults
+	if pingoraConfig != nil {
+		defaults = pingoraConfig.Spec.Defaults
+	}
+
+	globalConfig := pingoraingress.GlobalConfigFromDefaults(defaults)
+
+	s.appliedMu.RLock()
+	applied := s.appliedGlobalConfig
+	s.appliedMu.RUnlock()
+
+	if applied != nil && proto.Equal(applied, globalConfig) {
+		return
+	}
+
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		return
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.UpdateGlobalConfig(rpcCtx, &routingv1.UpdateGlobalConfigRequest{Config: globalConfig})
+	grpcDuration := time.Since(grpcStart)
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// Older proxies keep their built-in defaults
+		if defaults != nil {
+			logger.Warn("proxy does not support global config, PingoraConfig defaults are ignored")
+		}
+	case err != nil:
+		s.Metrics.RecordGRPCCall(ctx, "UpdateGlobalConfig", "error", grpcDuration)
+		logger.Error("failed to update global config", "error", err)
+
+		return
+	case !resp.GetSuccess():
+		s.Metrics.RecordGRPCCall(ctx, "UpdateGlobalConfig", "failed", grpcDuration)
+		logger.Error("global config update failed", "error", resp.GetError())
+
+		return
+	default:
+		s.Metrics.RecordGRPCCall(ctx, "UpdateGlobalConfig", "success", grpcDuration)
+		logger.Info("successfully updated global config in Pingora")
+	}
+
+	s.appliedMu.Lock()
+	s.appliedGlobalConfig = globalConfig
+	s.appliedMu.Unlock()
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+func TestPingoraRouteSyncer_SyncGlobalConfig(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	requestTimeout := gatewayv1.Duration("1m")
+	pingoraConfig := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec: v1alpha1.PingoraConfigSpec{
+			Address: "pingora-proxy:50051",
+			Defaults: &v1alpha1.ProxyDefaults{
+				RequestTimeout: &requestTimeout,
+				TrustedProxies: []string{"10.0.0.0/8"},
+			},
+		},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pingoraConfig, newPingoraGatewayClass("pingora")).
+		Build()
+
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	syncer := &PingoraRouteSyncer{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		Metrics:          metrics.NewNoopCollector(),
+		Logger:           slog.Default(),
+		grpcClient:       routingv1.NewRoutingServiceClient(conn),
+	}
+	ctx := context.Background()
+
+	syncer.syncGlobalConfig(ctx, syncer.Logger)
+	require.NotNil(t, proxy.GlobalConfig())
+	assert.Equal(t, uint64(60000), proxy.GlobalConfig().GetDefaultRequestTimeoutMs())
+	assert.Equal(t, []string{"10.0.0.0/8"}, proxy.GlobalConfig().GetTrustedProxyCidrs())
+
+	// An accepted config is not sent again
+	proxy.Restart()
+	syncer.syncGlobalConfig(ctx, syncer.Logger)
+	assert.Nil(t, proxy.GlobalConfig())
+
+	// After a reconnect the config is resent
+	syncer.resetAppliedConfig()
+	syncer.syncGlobalConfig(ctx, syncer.Logger)
+	assert.Equal(t, uint64(60000), proxy.GlobalConfig().GetDefaultRequestTimeoutMs())
+
+	// Removed defaults reset the proxy to its own defaults
+	pingoraConfig.Spec.Defaults = nil
+	require.NoError(t, cli.Update(ctx, pingoraConfig))
+
+	syncer.syncGlobalConfig(ctx, syncer.Logger)
+	assert.Zero(t, proxy.GlobalConfig().GetDefaultRequestTimeoutMs())
+
+	// Failed updates are retried on the next sync
+	pingoraConfig.Spec.Defaults = &v1alpha1.ProxyDefaults{AccessLogFormat: v1alpha1.AccessLogFormatJSON}
+	require.NoError(t, cli.Update(ctx, pingoraConfig))
+	proxy.SetError(mockproxy.MethodUpdateGlobalConfig, status.Error(codes.Unavailable, "proxy down"))
+
+	syncer.syncGlobalConfig(ctx, syncer.Logger)
+	assert.Equal(t, routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_UNSPECIFIED, proxy.GlobalConfig().GetAccessLogFormat())
+
+	proxy.SetError(mockproxy.MethodUpdateGlobalConfig, nil)
+	syncer.syncGlobalConfig(ctx, syncer.Logger)
+	assert.Equal(t, routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_JSON, proxy.GlobalConfig().GetAccessLogFormat())
+}
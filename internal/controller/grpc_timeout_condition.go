@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+const (
+	// RouteConditionGRPCTimeouts reports the timeouts declared with the
+	// timeout annotations of a GRPCRoute.
+	RouteConditionGRPCTimeouts = "pingora.k8s.lex.la/GRPCTimeouts"
+
+	// RouteReasonGRPCTimeoutsApplied means the timeouts were programmed.
+	RouteReasonGRPCTimeoutsApplied = "Applied"
+
+	// RouteReasonGRPCTimeoutsInvalid means the annotations could not be
+	// parsed and were ignored, or name rules the route does not have.
+	RouteReasonGRPCTimeoutsInvalid = "Invalid"
+)
+
+// grpcTimeoutsCondition builds the GRPCTimeouts route condition from the
+// route annotations and rules. It returns nil for routes without timeout
+// annotations.
+func grpcTimeoutsCondition(
+	annotations map[string]string,
+	rules []gatewayv1.GRPCRouteRule,
+	generation int64,
+	now metav1.Time,
+) *metav1.Condition {
+	timeouts, err := ingress.ParseGRPCTimeouts(annotations)
+	if err == nil && timeouts == nil {
+		return nil
+	}
+
+	condition := &metav1.Condition{
+		Type:               RouteConditionGRPCTimeouts,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		LastTransitionTime: now,
+		Reason:             RouteReasonGRPCTimeoutsInvalid,
+	}
+
+	switch unknown := unknownGRPCTimeoutRules(timeouts, rules); {
+	case err != nil:
+		condition.Message = err.Error()
+	case len(unknown) > 0:
+		condition.Message = fmt.Sprintf("%s; no rule named %s, those timeouts are ignored",
+			timeouts.String(), strings.Join(unknown, ", "))
+	default:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = RouteReasonGRPCTimeoutsApplied
+		condition.Message = timeouts.String()
+	}
+
+	return condition
+}
+
+func unknownGRPCTimeoutRules(timeouts *ingress.GRPCTimeouts, rules []gatewayv1.GRPCRouteRule) []string {
+	if timeouts == nil {
+		return nil
+	}
+
+	return timeouts.UnknownRules(rules)
+}
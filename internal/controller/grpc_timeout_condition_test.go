@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+func TestGRPCTimeoutsCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	watch := gatewayv1.SectionName("Watch")
+	rules := []gatewayv1.GRPCRouteRule{{}, {Name: &watch}}
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		expectNil      bool
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:      "no annotations",
+			expectNil: true,
+		},
+		{
+			name:           "valid timeouts",
+			annotations:    map[string]string{ingress.GRPCTimeoutAnnotation: "10s, Watch=0s"},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: RouteReasonGRPCTimeoutsApplied,
+		},
+		{
+			name:           "invalid duration",
+			annotations:    map[string]string{ingress.GRPCHeaderTimeoutAnnotation: "soon"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: RouteReasonGRPCTimeoutsInvalid,
+		},
+		{
+			name:           "unknown rule name",
+			annotations:    map[string]string{ingress.GRPCTimeoutAnnotation: "List=1s"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: RouteReasonGRPCTimeoutsInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition := grpcTimeoutsCondition(tt.annotations, rules, 3, now)
+			if tt.expectNil {
+				assert.Nil(t, condition)
+
+				return
+			}
+
+			require.NotNil(t, condition)
+			assert.Equal(t, RouteConditionGRPCTimeouts, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, int64(3), condition.ObservedGeneration)
+			assert.NotEmpty(t, condition.Message)
+		})
+	}
+}
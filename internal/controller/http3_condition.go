@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+const (
+	// ListenerConditionHTTP3 reports whether a Gateway listener serves
+	// HTTP/3 over QUIC.
+	ListenerConditionHTTP3 = "pingora.k8s.lex.la/HTTP3"
+
+	// ListenerReasonHTTP3Enabled means HTTP/3 is programmed on the listener.
+	ListenerReasonHTTP3Enabled = "Enabled"
+
+	// ListenerReasonHTTP3UnsupportedProtocol means the listener protocol
+	// cannot carry HTTP/3.
+	ListenerReasonHTTP3UnsupportedProtocol = "UnsupportedProtocol"
+)
+
+// http3ListenerCondition builds the HTTP3 listener condition. It returns
+// nil unless the PingoraConfig enables HTTP/3.
+func http3ListenerCondition(
+	listener *gatewayv1.Listener,
+	config *v1alpha1.HTTP3Config,
+	generation int64,
+	now metav1.Time,
+) *metav1.Condition {
+	if !ingress.HTTP3Enabled(config) {
+		return nil
+	}
+
+	condition := &metav1.Condition{
+		Type:               ListenerConditionHTTP3,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		LastTransitionTime: now,
+		Reason:             ListenerReasonHTTP3UnsupportedProtocol,
+		Message:            fmt.Sprintf("HTTP/3 requires an HTTPS listener, not %s", listener.Protocol),
+	}
+
+	if !ingress.SupportsHTTP3(listener) {
+		return condition
+	}
+
+	advertised := int32(listener.Port)
+	if config.AdvertisedPort != nil {
+		advertised = *config.AdvertisedPort
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = ListenerReasonHTTP3Enabled
+	condition.Message = fmt.Sprintf("HTTP/3 served on UDP port %d and advertised with Alt-Svc on port %d",
+		listener.Port, advertised)
+
+	return condition
+}
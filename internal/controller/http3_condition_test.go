@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+)
+
+func TestHTTP3ListenerCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	advertised := int32(443)
+
+	https := &gatewayv1.Listener{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 8443}
+	http := &gatewayv1.Listener{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80}
+
+	assert.Nil(t, http3ListenerCondition(https, nil, 1, now))
+	assert.Nil(t, http3ListenerCondition(https, &v1alpha1.HTTP3Config{}, 1, now))
+
+	enabled := &v1alpha1.HTTP3Config{Enabled: true, AdvertisedPort: &advertised}
+
+	condition := http3ListenerCondition(https, enabled, 3, now)
+	require.NotNil(t, condition)
+	assert.Equal(t, ListenerConditionHTTP3, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ListenerReasonHTTP3Enabled, condition.Reason)
+	assert.Equal(t, int64(3), condition.ObservedGeneration)
+	assert.Equal(t, "HTTP/3 served on UDP port 8443 and advertised with Alt-Svc on port 443", condition.Message)
+
+	condition = http3ListenerCondition(http, enabled, 3, now)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ListenerReasonHTTP3UnsupportedProtocol, condition.Reason)
+}
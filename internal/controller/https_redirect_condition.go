@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+const (
+	// ListenerConditionHTTPSRedirect reports whether the requests of an HTTP
+	// listener are redirected to HTTPS.
+	ListenerConditionHTTPSRedirect = "pingora.k8s.lex.la/HTTPSRedirect"
+
+	// ListenerReasonHTTPSRedirected means the redirect is programmed.
+	ListenerReasonHTTPSRedirected = "Redirected"
+
+	// ListenerReasonNoHTTPSListener means the Gateway has no HTTPS listener
+	// with the hostname of the listener to redirect to.
+	ListenerReasonNoHTTPSListener = "NoHTTPSListener"
+)
+
+// httpsRedirectListenerCondition builds the HTTPSRedirect listener
+// condition. It returns nil unless the Gateway asks for HTTPS redirects and
+// the listener is an HTTP listener.
+func httpsRedirectListenerCondition(
+	gateway *gatewayv1.Gateway,
+	listener *gatewayv1.Listener,
+	now metav1.Time,
+) *metav1.Condition {
+	if !ingress.HTTPSRedirectEnabled(gateway) || listener.Protocol != gatewayv1.HTTPProtocolType {
+		return nil
+	}
+
+	condition := &metav1.Condition{
+		Type:               ListenerConditionHTTPSRedirect,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: gateway.Generation,
+		LastTransitionTime: now,
+		Reason:             ListenerReasonNoHTTPSListener,
+		Message:            "No HTTPS listener with the hostname of this listener to redirect to",
+	}
+
+	target := ingress.HTTPSRedirectTarget(gateway, listener)
+	if target == nil {
+		return condition
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = ListenerReasonHTTPSRedirected
+	condition.Message = fmt.Sprintf("Requests redirected with status %d to listener %s on port %d",
+		ingress.HTTPSRedirectStatusCode, target.Name, target.Port)
+
+	return condition
+}
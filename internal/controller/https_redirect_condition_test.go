@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+func TestHTTPSRedirectListenerCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	hostname := gatewayv1.Hostname("app.example.com")
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Generation:  2,
+			Annotations: map[string]string{ingress.HTTPSRedirectAnnotation: "true"},
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				{Name: "app-http", Protocol: gatewayv1.HTTPProtocolType, Port: 80, Hostname: &hostname},
+				{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443},
+			},
+		},
+	}
+
+	condition := httpsRedirectListenerCondition(gateway, &gateway.Spec.Listeners[0], now)
+	require.NotNil(t, condition)
+	assert.Equal(t, ListenerConditionHTTPSRedirect, condition.Type)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ListenerReasonHTTPSRedirected, condition.Reason)
+	assert.Equal(t, int64(2), condition.ObservedGeneration)
+	assert.Equal(t, "Requests redirected with status 301 to listener https on port 443", condition.Message)
+
+	condition = httpsRedirectListenerCondition(gateway, &gateway.Spec.Listeners[1], now)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ListenerReasonNoHTTPSListener, condition.Reason)
+
+	assert.Nil(t, httpsRedirectListenerCondition(gateway, &gateway.Spec.Listeners[2], now))
+
+	gateway.Annotations = nil
+	assert.Nil(t, httpsRedirectListenerCondition(gateway, &gateway.Spec.Listeners[0], now))
+}
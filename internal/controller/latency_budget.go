@@ -0,0 +1,54 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+const (
+	// RouteConditionLatencyBudget reports the settings derived from the
+	// latency budget annotation of an HTTPRoute.
+	RouteConditionLatencyBudget = "pingora.k8s.lex.la/LatencyBudget"
+
+	// RouteReasonLatencyBudgetApplied means the budget was translated into
+	// timeout and retry settings.
+	RouteReasonLatencyBudgetApplied = "Applied"
+
+	// RouteReasonLatencyBudgetInvalid means the budget could not be parsed
+	// and was ignored.
+	RouteReasonLatencyBudgetInvalid = "Invalid"
+)
+
+// latencyBudgetCondition builds the LatencyBudget route condition from the
+// route annotations. It returns nil for routes without a latency budget.
+func latencyBudgetCondition(
+	annotations map[string]string,
+	generation int64,
+	now metav1.Time,
+) *metav1.Condition {
+	budget, err := ingress.ParseLatencyBudget(annotations)
+
+	switch {
+	case err != nil:
+		return &metav1.Condition{
+			Type:               RouteConditionLatencyBudget,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: generation,
+			LastTransitionTime: now,
+			Reason:             RouteReasonLatencyBudgetInvalid,
+			Message:            err.Error(),
+		}
+	case budget == nil:
+		return nil
+	default:
+		return &metav1.Condition{
+			Type:               RouteConditionLatencyBudget,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+			LastTransitionTime: now,
+			Reason:             RouteReasonLatencyBudgetApplied,
+			Message:            budget.String() + " for rules without an explicit request timeout",
+		}
+	}
+}
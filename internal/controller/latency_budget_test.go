@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+func TestLatencyBudgetCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		expectNil      bool
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:      "no budget",
+			expectNil: true,
+		},
+		{
+			name:           "valid budget",
+			annotations:    map[string]string{ingress.LatencyBudgetAnnotation: "2s"},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: RouteReasonLatencyBudgetApplied,
+		},
+		{
+			name:           "invalid budget",
+			annotations:    map[string]string{ingress.LatencyBudgetAnnotation: "2 seconds"},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: RouteReasonLatencyBudgetInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition := latencyBudgetCondition(tt.annotations, 4, now)
+			if tt.expectNil {
+				assert.Nil(t, condition)
+
+				return
+			}
+
+			require.NotNil(t, condition)
+			assert.Equal(t, RouteConditionLatencyBudget, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, int64(4), condition.ObservedGeneration)
+			assert.NotEmpty(t, condition.Message)
+		})
+	}
+}
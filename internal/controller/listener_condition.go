@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+)
+
+const (
+	// RouteConditionAcceptedListeners lists the listeners of a parent
+	// Gateway that accept the route.
+	RouteConditionAcceptedListeners = "pingora.k8s.lex.la/AcceptedListeners"
+
+	// RouteReasonAllListeners means every listener selected by the parentRef
+	// accepts the route.
+	RouteReasonAllListeners = "AllListeners"
+
+	// RouteReasonSomeListeners means only some of the listeners selected by
+	// the parentRef accept the route.
+	RouteReasonSomeListeners = "SomeListeners"
+
+	// RouteReasonNoListeners means no listener selected by the parentRef
+	// accepts the route.
+	RouteReasonNoListeners = "NoListeners"
+)
+
+// acceptedListenersCondition builds the AcceptedListeners route condition
+// from the binding result of a parentRef. It returns nil if the parentRef
+// selects no listener.
+func acceptedListenersCondition(
+	result routebinding.BindingResult,
+	generation int64,
+	now metav1.Time,
+) *metav1.Condition {
+	if len(result.MatchedListeners) == 0 && len(result.RejectedListeners) == 0 {
+		return nil
+	}
+
+	status := metav1.ConditionTrue
+	reason := RouteReasonAllListeners
+	messages := make([]string, 0, 2)
+
+	switch {
+	case len(result.MatchedListeners) == 0:
+		status = metav1.ConditionFalse
+		reason = RouteReasonNoListeners
+	case len(result.RejectedListeners) > 0:
+		reason = RouteReasonSomeListeners
+	}
+
+	if len(result.MatchedListeners) > 0 {
+		names := make([]string, 0, len(result.MatchedListeners))
+		for _, name := range result.MatchedListeners {
+			names = append(names, string(name))
+		}
+
+		messages = append(messages, "Accepted by listeners "+strings.Join(names, ", "))
+	}
+
+	if len(result.RejectedListeners) > 0 {
+		messages = append(messages, "Rejected by listeners "+rejectedListeners(result.RejectedListeners))
+	}
+
+	return &metav1.Condition{
+		Type:               RouteConditionAcceptedListeners,
+		Status:             status,
+		ObservedGeneration: generation,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            strings.Join(messages, "; "),
+	}
+}
+
+// acceptedMessage adds the listeners that reject the route to the message
+// of the Accepted condition of a parentRef, when the parentRef selects
+// several listeners and only some of them are to blame.
+func acceptedMessage(message string, result routebinding.BindingResult) string {
+	if len(result.RejectedListeners) == 0 ||
+		len(result.MatchedListeners)+len(result.RejectedListeners) < 2 {
+		return message
+	}
+
+	return message + "; rejected by listeners " + rejectedListeners(result.RejectedListeners)
+}
+
+// rejectedListeners formats rejected listeners as a comma separated list.
+func rejectedListeners(rejections []routebinding.ListenerRejection) string {
+	parts := make([]string, 0, len(rejections))
+	for _, rejection := range rejections {
+		parts = append(parts, rejectedListener(rejection))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// rejectedListener formats a rejected listener as "<name> (<reason>)".
+func rejectedListener(rejection routebinding.ListenerRejection) string {
+	return fmt.Sprintf("%s (%s)", rejection.Name, rejection.Reason)
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+)
+
+func TestAcceptedListenersCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	admin := routebinding.ListenerRejection{Name: "admin", Reason: gatewayv1.RouteReasonNotAllowedByListeners}
+	other := routebinding.ListenerRejection{Name: "other", Reason: gatewayv1.RouteReasonNoMatchingListenerHostname}
+
+	tests := []struct {
+		name            string
+		result          routebinding.BindingResult
+		expectNil       bool
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:      "no listener selected",
+			result:    routebinding.BindingResult{Reason: gatewayv1.RouteReasonNoMatchingParent},
+			expectNil: true,
+		},
+		{
+			name: "all listeners accept",
+			result: routebinding.BindingResult{
+				Accepted:         true,
+				MatchedListeners: []gatewayv1.SectionName{"http", "https"},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  RouteReasonAllListeners,
+			expectedMessage: "Accepted by listeners http, https",
+		},
+		{
+			name: "some listeners accept",
+			result: routebinding.BindingResult{
+				Accepted:          true,
+				MatchedListeners:  []gatewayv1.SectionName{"http"},
+				RejectedListeners: []routebinding.ListenerRejection{admin},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  RouteReasonSomeListeners,
+			expectedMessage: "Accepted by listeners http; Rejected by listeners admin (NotAllowedByListeners)",
+		},
+		{
+			name: "no listener accepts",
+			result: routebinding.BindingResult{
+				RejectedListeners: []routebinding.ListenerRejection{admin, other},
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  RouteReasonNoListeners,
+			expectedMessage: "Rejected by listeners admin (NotAllowedByListeners), other (NoMatchingListenerHostname)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition := acceptedListenersCondition(tt.result, 4, now)
+			if tt.expectNil {
+				assert.Nil(t, condition)
+
+				return
+			}
+
+			require.NotNil(t, condition)
+			assert.Equal(t, RouteConditionAcceptedListeners, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, tt.expectedMessage, condition.Message)
+			assert.Equal(t, int64(4), condition.ObservedGeneration)
+		})
+	}
+}
+
+func TestAcceptedMessage(t *testing.T) {
+	t.Parallel()
+
+	admin := routebinding.ListenerRejection{Name: "admin", Reason: gatewayv1.RouteReasonNotAllowedByListeners}
+
+	tests := []struct {
+		name     string
+		result   routebinding.BindingResult
+		expected string
+	}{
+		{
+			name:     "all listeners accept",
+			result:   routebinding.BindingResult{MatchedListeners: []gatewayv1.SectionName{"http"}},
+			expected: "Route accepted",
+		},
+		{
+			name:     "single rejected listener",
+			result:   routebinding.BindingResult{RejectedListeners: []routebinding.ListenerRejection{admin}},
+			expected: "Route accepted",
+		},
+		{
+			name: "partially accepted",
+			result: routebinding.BindingResult{
+				MatchedListeners:  []gatewayv1.SectionName{"http"},
+				RejectedListeners: []routebinding.ListenerRejection{admin},
+			},
+			expected: "Route accepted; rejected by listeners admin (NotAllowedByListeners)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, acceptedMessage("Route accepted", tt.result))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	pingoraingress "github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// syncLoggingConfig sends the access log settings of the PingoraConfig to
+// the proxy unless the proxy already accepted them. Like the global config,
+// failures are logged and retried on the next sync.
+func (s *PingoraRouteSyncer) syncLoggingConfig(ctx context.Context, logger *slog.Logger) {
+	pingoraConfig, err := s.pingoraConfigForClass(ctx)
+	if err != nil {
+		logger.Debug("failed to get PingoraConfig for logging config", "error", err)
+
+		return
+	}
+
+	var defaults *v1alpha1.ProxyDefaults
+	if pingoraConfig != nil {
+		defaults = pingoraConfig.Spec.Defaults
+	}
+
+	loggingConfig := pingoraingress.LoggingConfigFromDefaults(defaults)
+
+	s.appliedMu.RLock()
+	applied := s.appliedLoggingConfig
+	s.appliedMu.RUnlock()
+
+	if applied != nil && proto.Equal(applied, loggingConfig) {
+		return
+	}
+
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		return
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.UpdateLoggingConfig(rpcCtx, &routingv1.UpdateLoggingConfigRequest{Config: loggingConfig})
+	grpcDuration := time.Since(grpcStart)
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		// Older proxies only know the access log format of the global config
+		if defaults != nil && defaults.AccessLog != nil {
+			logger.Warn("proxy does not support logging config, PingoraConfig access log settings are ignored")
+		}
+	case err != nil:
+		s.Metrics.RecordGRPCCall(ctx, "UpdateLoggingConfig", "error", grpcDuration)
+		logger.Error("failed to update logging config", "error", err)
+
+		return
+	case !resp.GetSuccess():
+		s.Metrics.RecordGRPCCall(ctx, "UpdateLoggingConfig", "failed", grpcDuration)
+		logger.Error("logging config update failed", "error", resp.GetError())
+
+		return
+	default:
+		s.Metrics.RecordGRPCCall(ctx, "UpdateLoggingConfig", "success", grpcDuration)
+		logger.Info("successfully updated logging config in Pingora")
+	}
+
+	s.appliedMu.Lock()
+	s.appliedLoggingConfig = loggingConfig
+	s.appliedMu.Unlock()
+}
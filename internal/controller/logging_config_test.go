@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+func TestPingoraRouteSyncer_SyncLoggingConfig(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	samplePercent := int32(10)
+	pingoraConfig := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec: v1alpha1.PingoraConfigSpec{
+			Address: "pingora-proxy:50051",
+			Defaults: &v1alpha1.ProxyDefaults{
+				AccessLogFormat: v1alpha1.AccessLogFormatJSON,
+				AccessLog: &v1alpha1.AccessLogConfig{
+					Fields:        []v1alpha1.AccessLogField{"timestamp", "status"},
+					SamplePercent: &samplePercent,
+					ExcludePaths:  []string{"/healthz"},
+				},
+			},
+		},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pingoraConfig, newPingoraGatewayClass("pingora")).
+		Build()
+
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	syncer := &PingoraRouteSyncer{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		Metrics:          metrics.NewNoopCollector(),
+		Logger:           slog.Default(),
+		grpcClient:       routingv1.NewRoutingServiceClient(conn),
+	}
+	ctx := context.Background()
+
+	syncer.syncLoggingConfig(ctx, syncer.Logger)
+	require.NotNil(t, proxy.LoggingConfig())
+	assert.Equal(t, routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_JSON, proxy.LoggingConfig().GetFormat())
+	assert.Equal(t, []string{"timestamp", "status"}, proxy.LoggingConfig().GetFields())
+	assert.Equal(t, uint32(10), proxy.LoggingConfig().GetSamplePercent())
+	assert.Equal(t, []string{"/healthz"}, proxy.LoggingConfig().GetExcludePathPrefixes())
+
+	// An accepted config is not sent again
+	proxy.Restart()
+	syncer.syncLoggingConfig(ctx, syncer.Logger)
+	assert.Nil(t, proxy.LoggingConfig())
+
+	// After a reconnect the config is resent
+	syncer.resetAppliedConfig()
+	syncer.syncLoggingConfig(ctx, syncer.Logger)
+	assert.Equal(t, uint32(10), proxy.LoggingConfig().GetSamplePercent())
+
+	// Proxies without the RPC are not asked again until the config changes
+	proxy.Restart()
+	syncer.resetAppliedConfig()
+	proxy.SetError(mockproxy.MethodUpdateLoggingConfig, status.Error(codes.Unimplemented, "unknown method"))
+
+	syncer.syncLoggingConfig(ctx, syncer.Logger)
+	assert.NotNil(t, syncer.appliedLoggingConfig)
+
+	// Failed updates are retried on the next sync
+	pingoraConfig.Spec.Defaults = nil
+	require.NoError(t, cli.Update(ctx, pingoraConfig))
+	proxy.SetError(mockproxy.MethodUpdateLoggingConfig, status.Error(codes.Unavailable, "proxy down"))
+
+	syncer.syncLoggingConfig(ctx, syncer.Logger)
+	assert.Nil(t, proxy.LoggingConfig())
+
+	proxy.SetError(mockproxy.MethodUpdateLoggingConfig, nil)
+	syncer.syncLoggingConfig(ctx, syncer.Logger)
+	require.NotNil(t, proxy.LoggingConfig())
+	assert.Nil(t, proxy.LoggingConfig().SamplePercent)
+}
@@ -3,23 +3,42 @@ package controller
 import (
 	"context"
 	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	ctrlMetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	ctrlWebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
 	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
 	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	"github.com/lexfrei/pingora-gateway-controller/internal/smoketest"
+	"github.com/lexfrei/pingora-gateway-controller/internal/webhook"
 )
 
+// clusterDomainRefreshInterval is how often the cluster domain is re-detected
+// when it was not configured explicitly.
+const clusterDomainRefreshInterval = time.Minute
+
 // Config holds all configuration options for the controller manager.
 // Values are typically populated from CLI flags or environment variables.
 type Config struct {
@@ -27,6 +46,11 @@ type Config struct {
 	// Defaults to "cluster.local".
 	ClusterDomain string
 
+	// ClusterDomainAutoDetect re-runs cluster domain detection periodically and
+	// resyncs all routes when the detected domain changes.
+	// Should only be set when the domain was not configured explicitly.
+	ClusterDomainAutoDetect bool
+
 	// GatewayClassName is the name of the GatewayClass to watch.
 	// Only Gateways referencing this class will be reconciled.
 	GatewayClassName string
@@ -49,6 +73,97 @@ type Config struct {
 
 	// LeaderElectName is the name of the leader election lease.
 	LeaderElectName string
+
+	// SyncDebounce is how long route changes are collected before a single
+	// sync is sent to the proxy. Zero disables debouncing.
+	SyncDebounce time.Duration
+
+	// ProxyHealthInterval is how often the proxy health is polled for status.
+	// Zero disables the proxy health monitor.
+	ProxyHealthInterval time.Duration
+
+	// ProxyUnreachableThreshold is how long the proxy must be unreachable
+	// before PingoraConfig and Gateway status report it down.
+	ProxyUnreachableThreshold time.Duration
+
+	// LivenessTimeout is how long route syncs may make no progress before the
+	// liveness check fails. Zero disables the sync watchdog.
+	LivenessTimeout time.Duration
+
+	// ProxyVersionCheckInterval is how often the proxy's config version is checked
+	// to detect a proxy that lost its routes. Zero disables the check.
+	ProxyVersionCheckInterval time.Duration
+
+	// BindingDebugAnnotations enables annotating routes with their binding
+	// results to help diagnose routes that do not attach.
+	BindingDebugAnnotations bool
+
+	// RouteDiagnosticsAnnotations enables annotating routes with the rules
+	// programmed into the proxy and the elements dropped from them.
+	RouteDiagnosticsAnnotations bool
+
+	// RouteDeletionFinalizer enables adding RouteFinalizer to programmed
+	// routes, so that deleted routes are removed from the proxy before their
+	// objects disappear.
+	RouteDeletionFinalizer bool
+
+	// RouteTrafficMetrics enables exporting the traffic counted by the proxy
+	// as metrics labeled with the routes, polled with the proxy version check.
+	RouteTrafficMetrics bool
+
+	// AdoptControllerNames are previous controller names whose route status
+	// entries are rewritten to ControllerName once at startup.
+	AdoptControllerNames []string
+
+	// RequireParentRefGrants makes routes attach to Gateways in other
+	// namespaces only if a ReferenceGrant in the Gateway namespace permits
+	// it. Rejected parentRefs report RefNotPermitted.
+	RequireParentRefGrants bool
+
+	// FeatureGates enables optional Gateway API resources, whose CRDs are
+	// not read unless their gate is enabled.
+	FeatureGates FeatureGates
+
+	// RouteIDScheme selects how route IDs sent to the proxy are derived.
+	// Empty means ingress.DefaultRouteIDScheme.
+	RouteIDScheme ingress.RouteIDScheme
+
+	// SmokeTestURL is a canary URL requested through the proxy after each
+	// applied sync. Empty disables the smoke test.
+	SmokeTestURL string
+
+	// SmokeTestAddress is the proxy data plane address used for smoke test
+	// requests. Empty dials the SmokeTestURL host directly.
+	SmokeTestAddress string
+
+	// SmokeTestTimeout bounds a single smoke test request.
+	SmokeTestTimeout time.Duration
+
+	// WatchNamespaces restricts the Gateway, HTTPRoute and GRPCRoute watches
+	// to these namespaces. Empty watches all namespaces.
+	WatchNamespaces []string
+
+	// RouteLabelSelector restricts the watched HTTPRoutes and GRPCRoutes to
+	// those matching it. Nil watches all routes.
+	RouteLabelSelector labels.Selector
+
+	// DryRun builds and validates route configs and updates statuses and
+	// metrics, but never sends routes to the proxy.
+	DryRun bool
+
+	// WebhookEnabled serves the PingoraConfig validating admission webhook.
+	WebhookEnabled bool
+
+	// WebhookPort is the port the webhook server listens on.
+	WebhookPort int
+
+	// WebhookMaxRouteMatches is the maximum number of matches in a single
+	// route rule accepted by the route webhooks. Zero disables the limit.
+	WebhookMaxRouteMatches int
+
+	// WebhookCertDir is the directory containing the webhook serving
+	// certificate (tls.crt and tls.key). Empty uses the controller-runtime default.
+	WebhookCertDir string
 }
 
 // Run initializes and starts the controller manager with the provided configuration.
@@ -67,13 +182,78 @@ func Run(ctx context.Context, cfg *Config) error {
 	logger := log.FromContext(ctx).WithName("manager")
 	logger.Info("initializing controller manager")
 
+	// The types are registered before the manager is created, as the cache
+	// options below refer to them
+	scheme := clientgoscheme.Scheme
+
+	// Register Gateway API types
+	if err := gatewayv1.Install(scheme); err != nil {
+		return errors.Wrap(err, "failed to add gateway-api scheme")
+	}
+
+	// Register Gateway API v1beta1 types (required for ReferenceGrant)
+	if err := gatewayv1beta1.Install(scheme); err != nil {
+		return errors.Wrap(err, "failed to add gateway-api v1beta1 scheme")
+	}
+
+	// Register PingoraConfig CRD types
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		return errors.Wrap(err, "failed to add PingoraConfig scheme")
+	}
+
+	restConfig := ctrl.GetConfigOrDie()
+
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to create HTTP client")
+	}
+
+	mapper, err := apiutil.NewDynamicRESTMapper(restConfig, httpClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to create REST mapper")
+	}
+
+	// The manager maps the kinds of its cache options right away, and a
+	// watch on a resource without its CRD stops it, so the optional ones are
+	// looked up before the manager and the controllers are set up
+	missing, err := DiscoverMissingResources(mapper, cfg.FeatureGates)
+	if err != nil {
+		return errors.Wrap(err, "failed to discover optional resources")
+	}
+
+	for _, resource := range missing.Names() {
+		slog.Warn("CRD not installed, running without it; restart the controller after installing it",
+			"resource", resource)
+	}
+
 	mgrOptions := ctrl.Options{
+		Scheme: scheme,
+		// The discovery done for the optional resources is reused
+		MapperProvider: func(*rest.Config, *http.Client) (meta.RESTMapper, error) {
+			return mapper, nil
+		},
 		Metrics: server.Options{
 			BindAddress: cfg.MetricsAddr,
 		},
 		HealthProbeBindAddress: cfg.HealthAddr,
 	}
 
+	mgrOptions.Cache = watchCacheOptions(cfg.WatchNamespaces, cfg.RouteLabelSelector, missing)
+
+	if len(cfg.WatchNamespaces) > 0 || cfg.RouteLabelSelector != nil {
+		logger.Info("restricting watched routes and gateways",
+			"namespaces", cfg.WatchNamespaces,
+			"routeLabelSelector", labelSelectorString(cfg.RouteLabelSelector),
+		)
+	}
+
+	if cfg.WebhookEnabled {
+		mgrOptions.WebhookServer = ctrlWebhook.NewServer(ctrlWebhook.Options{
+			Port:    cfg.WebhookPort,
+			CertDir: cfg.WebhookCertDir,
+		})
+	}
+
 	if cfg.LeaderElect {
 		mgrOptions.LeaderElection = true
 		mgrOptions.LeaderElectionID = cfg.LeaderElectName
@@ -85,48 +265,90 @@ func Run(ctx context.Context, cfg *Config) error {
 		)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOptions)
+	mgr, err := ctrl.NewManager(restConfig, mgrOptions)
 	if err != nil {
 		return errors.Wrap(err, "failed to create manager")
 	}
 
-	// Register Gateway API types
-	if err := gatewayv1.Install(mgr.GetScheme()); err != nil {
-		return errors.Wrap(err, "failed to add gateway-api scheme")
-	}
-
-	// Register Gateway API v1beta1 types (required for ReferenceGrant)
-	if err := gatewayv1beta1.Install(mgr.GetScheme()); err != nil {
-		return errors.Wrap(err, "failed to add gateway-api v1beta1 scheme")
-	}
-
-	// Register PingoraConfig CRD types
-	if err := v1alpha1.AddToScheme(mgr.GetScheme()); err != nil {
-		return errors.Wrap(err, "failed to add PingoraConfig scheme")
-	}
-
 	// Create metrics collector and register with controller-runtime
 	metricsCollector := metrics.NewCollector(ctrlMetrics.Registry)
 
+	features := Features(cfg, missing)
+	recordFeatures(ctx, metricsCollector, features)
+	logger.Info("controller features", featureLogValues(features)...)
+
 	// Determine default namespace for secret lookups
 	defaultNamespace := getControllerNamespace()
 
 	// Create Pingora config resolver
 	pingoraResolver := config.NewPingoraResolver(mgr.GetClient(), defaultNamespace)
+	pingoraResolver.Metrics = metricsCollector
 
 	// Create base logger for component injection
 	baseLogger := slog.Default()
 
+	// Cluster domain may change at runtime when auto-detected
+	clusterDomain := dns.NewClusterDomainProvider(cfg.ClusterDomain)
+
 	// Create shared route syncer for unified HTTP and GRPC route synchronization
 	routeSyncer := NewPingoraRouteSyncer(
 		mgr.GetClient(),
 		mgr.GetScheme(),
-		cfg.ClusterDomain,
+		clusterDomain,
 		cfg.GatewayClassName,
 		pingoraResolver,
 		metricsCollector,
+		cfg.SyncDebounce,
 		baseLogger,
 	)
+	routeSyncer.VersionCheckInterval = cfg.ProxyVersionCheckInterval
+	routeSyncer.BindingDebugAnnotations = cfg.BindingDebugAnnotations
+	routeSyncer.RouteDiagnosticsAnnotations = cfg.RouteDiagnosticsAnnotations
+	routeSyncer.RouteTrafficMetrics = cfg.RouteTrafficMetrics
+	routeSyncer.BackendTLSPolicies = cfg.FeatureGates.Enabled(FeatureGateBackendTLSPolicy) &&
+		!missing.Has(OptionalResourceBackendTLSPolicy)
+	routeSyncer.MissingResources = missing
+
+	// Events explain in kubectl describe why a route or Gateway is not working
+	recorder := mgr.GetEventRecorderFor(EventSource)
+	routeSyncer.Recorder = recorder
+	routeSyncer.Elected = mgr.Elected()
+	routeSyncer.DryRun = cfg.DryRun
+
+	if cfg.DryRun {
+		logger.Info("dry run enabled, routes are not sent to the proxy")
+	}
+
+	if cfg.RouteIDScheme != "" {
+		routeSyncer.SetRouteIDScheme(cfg.RouteIDScheme)
+	}
+
+	routeSyncer.SetRequireParentRefGrants(cfg.RequireParentRefGrants)
+
+	// Route bindings are shared by the route syncer and the Gateway controller
+	if err := routeSyncer.Bindings.SetupWithManager(ctx, mgr, missing); err != nil {
+		return errors.Wrap(err, "failed to setup binding cache")
+	}
+
+	if cfg.SmokeTestURL != "" {
+		verifier, verifierErr := smoketest.NewVerifier(smoketest.Config{
+			URL:     cfg.SmokeTestURL,
+			Address: cfg.SmokeTestAddress,
+			Timeout: cfg.SmokeTestTimeout,
+		}, metricsCollector, baseLogger)
+		if verifierErr != nil {
+			return errors.Wrap(verifierErr, "failed to create smoke test verifier")
+		}
+
+		routeSyncer.Verifier = verifier
+
+		logger.Info("post-sync smoke test enabled", "url", cfg.SmokeTestURL, "address", cfg.SmokeTestAddress)
+	}
+
+	// Detect proxies that restarted and lost their routes
+	if err := mgr.Add(routeSyncer); err != nil {
+		return errors.Wrap(err, "failed to add proxy version check runnable")
+	}
 
 	// Setup Gateway controller (simplified for Pingora - no Helm)
 	gatewayReconciler := &PingoraGatewayReconciler{
@@ -135,46 +357,192 @@ func Run(ctx context.Context, cfg *Config) error {
 		GatewayClassName: cfg.GatewayClassName,
 		ControllerName:   cfg.ControllerName,
 		ConfigResolver:   pingoraResolver,
+		Recorder:         recorder,
+		Bindings:         routeSyncer.Bindings,
+		MissingResources: missing,
+	}
+
+	if cfg.ProxyHealthInterval > 0 && !cfg.DryRun {
+		healthMonitor := NewProxyHealthMonitor(routeSyncer, cfg.ProxyHealthInterval, cfg.ProxyUnreachableThreshold,
+			metricsCollector, baseLogger)
+
+		if err := mgr.Add(healthMonitor); err != nil {
+			return errors.Wrap(err, "failed to add proxy health monitor")
+		}
+
+		gatewayReconciler.ProxyHealth = healthMonitor
 	}
 
 	if err := gatewayReconciler.SetupWithManager(mgr); err != nil {
 		return errors.Wrap(err, "failed to setup gateway controller")
 	}
 
-	// Setup HTTPRoute controller
-	httpRouteReconciler := &PingoraHTTPRouteReconciler{
+	// Report the supported features in the GatewayClass status
+	gatewayClassReconciler := &GatewayClassFeaturesReconciler{
 		Client:           mgr.GetClient(),
-		Scheme:           mgr.GetScheme(),
 		GatewayClassName: cfg.GatewayClassName,
 		ControllerName:   cfg.ControllerName,
-		RouteSyncer:      routeSyncer,
+		FeatureGates:     cfg.FeatureGates,
+		MissingResources: missing,
+	}
+
+	if err := gatewayClassReconciler.SetupWithManager(mgr); err != nil {
+		return errors.Wrap(err, "failed to setup gatewayclass controller")
+	}
+
+	// Setup HTTPRoute controller
+	httpRouteReconciler := &PingoraHTTPRouteReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		GatewayClassName:  cfg.GatewayClassName,
+		ControllerName:    cfg.ControllerName,
+		RouteSyncer:       routeSyncer,
+		Recorder:          recorder,
+		DeletionFinalizer: cfg.RouteDeletionFinalizer,
+		APIReader:         mgr.GetAPIReader(),
 	}
 
 	if err := httpRouteReconciler.SetupWithManager(mgr); err != nil {
 		return errors.Wrap(err, "failed to setup httproute controller")
 	}
 
-	// Setup GRPCRoute controller
-	grpcRouteReconciler := &PingoraGRPCRouteReconciler{
-		Client:           mgr.GetClient(),
-		Scheme:           mgr.GetScheme(),
-		GatewayClassName: cfg.GatewayClassName,
-		ControllerName:   cfg.ControllerName,
-		RouteSyncer:      routeSyncer,
+	// Setup GRPCRoute controller, unless the standard channel without
+	// GRPCRoute is installed
+	if !missing.Has(OptionalResourceGRPCRoute) {
+		grpcRouteReconciler := &PingoraGRPCRouteReconciler{
+			Client:            mgr.GetClient(),
+			Scheme:            mgr.GetScheme(),
+			GatewayClassName:  cfg.GatewayClassName,
+			ControllerName:    cfg.ControllerName,
+			RouteSyncer:       routeSyncer,
+			Recorder:          recorder,
+			DeletionFinalizer: cfg.RouteDeletionFinalizer,
+			APIReader:         mgr.GetAPIReader(),
+		}
+
+		if err := grpcRouteReconciler.SetupWithManager(mgr); err != nil {
+			return errors.Wrap(err, "failed to setup grpcroute controller")
+		}
 	}
 
-	if err := grpcRouteReconciler.SetupWithManager(mgr); err != nil {
-		return errors.Wrap(err, "failed to setup grpcroute controller")
+	// Push routes to restarted proxies as soon as they are ready
+	if !cfg.DryRun {
+		proxyPodReconciler := &ProxyPodReconciler{
+			Client:      mgr.GetClient(),
+			RouteSyncer: routeSyncer,
+		}
+
+		if err := proxyPodReconciler.SetupWithManager(mgr); err != nil {
+			return errors.Wrap(err, "failed to setup proxy pod controller")
+		}
+	}
+
+	// Setup policy status controllers
+	for _, policy := range []policyKind{
+		rateLimitPolicyKind(),
+		trafficPolicyKind(),
+		authPolicyKind(),
+		accessControlPolicyKind(),
+		cachePolicyKind(),
+		accessLogPolicyKind(),
+		backendPolicyKind(),
+		grpcPolicyKind(),
+	} {
+		policyReconciler := &PingoraPolicyReconciler{
+			Client:           mgr.GetClient(),
+			Scheme:           mgr.GetScheme(),
+			GatewayClassName: cfg.GatewayClassName,
+			ControllerName:   cfg.ControllerName,
+			MissingResources: missing,
+			policy:           policy,
+		}
+
+		if err := policyReconciler.SetupWithManager(mgr); err != nil {
+			return errors.Wrapf(err, "failed to setup %s controller", policy.kind)
+		}
+	}
+
+	if len(cfg.AdoptControllerNames) > 0 {
+		adopter := &ControllerNameAdopter{
+			Client:         mgr.GetClient(),
+			ControllerName: cfg.ControllerName,
+			AdoptedNames:   cfg.AdoptControllerNames,
+			Logger:         baseLogger,
+		}
+
+		if err := mgr.Add(adopter); err != nil {
+			return errors.Wrap(err, "failed to add controller name adoption runnable")
+		}
+
+		logger.Info("adopting route status of previous controller names", "names", cfg.AdoptControllerNames)
+	}
+
+	if len(cfg.WatchNamespaces) > 0 || cfg.RouteLabelSelector != nil {
+		releaser := &RouteFinalizerReleaser{
+			Client:             mgr.GetClient(),
+			APIReader:          mgr.GetAPIReader(),
+			GatewayClassName:   cfg.GatewayClassName,
+			WatchNamespaces:    cfg.WatchNamespaces,
+			RouteLabelSelector: cfg.RouteLabelSelector,
+			MissingResources:   missing,
+			Logger:             baseLogger,
+		}
+
+		if err := mgr.Add(releaser); err != nil {
+			return errors.Wrap(err, "failed to add route finalizer release runnable")
+		}
+	}
+
+	if cfg.WebhookEnabled {
+		if err := webhook.SetupPingoraConfigWebhook(mgr, defaultNamespace); err != nil {
+			return errors.Wrap(err, "failed to setup pingoraconfig webhook")
+		}
+
+		routeValidator := &webhook.RouteValidator{
+			Client:            mgr.GetClient(),
+			GatewayClassName:  cfg.GatewayClassName,
+			MaxMatchesPerRule: cfg.WebhookMaxRouteMatches,
+		}
+
+		if err := webhook.SetupRouteWebhooks(mgr, routeValidator); err != nil {
+			return errors.Wrap(err, "failed to setup route webhooks")
+		}
+
+		logger.Info("validating webhooks enabled", "port", cfg.WebhookPort)
+	}
+
+	if cfg.ClusterDomainAutoDetect {
+		err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			clusterDomain.Watch(ctx, clusterDomainRefreshInterval, dns.DetectClusterDomain)
+
+			return nil
+		}))
+		if err != nil {
+			return errors.Wrap(err, "failed to set up cluster domain detection")
+		}
+	}
+
+	if err := mgr.AddMetricsServerExtraHandler(AppliedConfigPath, routeSyncer.AppliedConfigHandler()); err != nil {
+		return errors.Wrap(err, "failed to set up applied config endpoint")
 	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		return errors.Wrap(err, "failed to set up health check")
 	}
 
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	livenessCheck := NewLivenessCheck(routeSyncer, mgr.Elected(), cfg.LivenessTimeout)
+	if err := mgr.AddHealthzCheck("sync-watchdog", livenessCheck.Check); err != nil {
+		return errors.Wrap(err, "failed to set up sync watchdog check")
+	}
+
+	if err := mgr.AddReadyzCheck("readyz", NewReadinessCheck(routeSyncer, mgr.Elected()).Check); err != nil {
 		return errors.Wrap(err, "failed to set up ready check")
 	}
 
+	if err := mgr.AddReadyzCheck("proxy-circuit", routeSyncer.CircuitCheck); err != nil {
+		return errors.Wrap(err, "failed to set up proxy circuit check")
+	}
+
 	logger.Info("starting manager")
 
 	if err := mgr.Start(ctx); err != nil {
@@ -184,6 +552,51 @@ func Run(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+// watchCacheOptions restricts the cache for Gateways, HTTPRoutes and
+// GRPCRoutes to the given namespaces, and for routes to those matching the
+// label selector. Routes outside the cache are neither reconciled nor
+// synced. Other objects, such as Secrets and policies, are still cached
+// cluster-wide. Empty namespaces and a nil selector do not restrict. Pods
+// are cached cluster-wide with only the fields needed to detect restarted
+// proxies. GRPCRoutes are left out while their CRD is missing, as the
+// manager cannot map them.
+func watchCacheOptions(namespaces []string, routeSelector labels.Selector, missing MissingResources) cache.Options {
+	byNamespace := func() map[string]cache.Config {
+		if len(namespaces) == 0 {
+			return nil
+		}
+
+		configs := make(map[string]cache.Config, len(namespaces))
+		for _, namespace := range namespaces {
+			configs[namespace] = cache.Config{}
+		}
+
+		return configs
+	}
+
+	byObject := map[client.Object]cache.ByObject{
+		&gatewayv1.Gateway{}:   {Namespaces: byNamespace()},
+		&gatewayv1.HTTPRoute{}: {Namespaces: byNamespace(), Label: routeSelector},
+		// Only the readiness of proxy pods is watched
+		&corev1.Pod{}: {Transform: trimPod},
+	}
+
+	if !missing.Has(OptionalResourceGRPCRoute) {
+		byObject[&gatewayv1.GRPCRoute{}] = cache.ByObject{Namespaces: byNamespace(), Label: routeSelector}
+	}
+
+	return cache.Options{ByObject: byObject}
+}
+
+// labelSelectorString formats an optional label selector for logging.
+func labelSelectorString(selector labels.Selector) string {
+	if selector == nil {
+		return ""
+	}
+
+	return selector.String()
+}
+
 // getControllerNamespace returns the namespace where the controller is running.
 // It first checks CONTROLLER_NAMESPACE environment variable, then reads from
 // the standard Kubernetes downward API file, falling back to "default".
@@ -1,9 +1,14 @@
 package controller
 
 import (
+	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
 func TestGetControllerNamespace(t *testing.T) {
@@ -36,3 +41,34 @@ func TestGetControllerNamespace(t *testing.T) {
 		})
 	}
 }
+
+func TestWatchCacheOptions(t *testing.T) {
+	t.Parallel()
+
+	selector := labels.SelectorFromSet(labels.Set{"app": "web"})
+
+	kinds := func(options cache.Options) []string {
+		var names []string
+		for obj := range options.ByObject {
+			names = append(names, reflect.TypeOf(obj).Elem().Name())
+		}
+
+		slices.Sort(names)
+
+		return names
+	}
+
+	options := watchCacheOptions([]string{"apps"}, selector, nil)
+	assert.Equal(t, []string{"GRPCRoute", "Gateway", "HTTPRoute", "Pod"}, kinds(options))
+
+	for obj, byObject := range options.ByObject {
+		if _, ok := obj.(*gatewayv1.GRPCRoute); ok {
+			assert.Equal(t, selector, byObject.Label)
+			assert.Contains(t, byObject.Namespaces, "apps")
+		}
+	}
+
+	// The manager cannot map a kind without its CRD
+	options = watchCacheOptions(nil, nil, MissingResources{OptionalResourceGRPCRoute: true})
+	assert.Equal(t, []string{"Gateway", "HTTPRoute", "Pod"}, kinds(options))
+}
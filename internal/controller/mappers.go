@@ -1,15 +1,22 @@
 package controller
 
 import (
+	"bytes"
 	"context"
+	"maps"
 	"slices"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
 	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
 	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
 )
@@ -24,9 +31,12 @@ type RequestsFunc func(ctx context.Context) []reconcile.Request
 type Route interface {
 	GetName() string
 	GetNamespace() string
+	GetUID() types.UID
+	GetGeneration() int64
 	GetHostnames() []gatewayv1.Hostname
 	GetParentRefs() []gatewayv1.ParentReference
 	GetRouteKind() gatewayv1.Kind
+	GetBackendRefs() []gatewayv1.BackendRef
 	// GetCrossNamespaceBackendNamespaces returns namespaces referenced by backends
 	// that differ from the route's own namespace.
 	GetCrossNamespaceBackendNamespaces() []string
@@ -36,7 +46,8 @@ type Route interface {
 type RouteFilterFunc func(ctx context.Context, name, namespace string) bool
 
 // FindRoutesForReferenceGrant returns reconcile requests for routes that have
-// cross-namespace references to Services in the ReferenceGrant's namespace.
+// cross-namespace references to Services or parent Gateways in the
+// ReferenceGrant's namespace.
 // This is used by both HTTPRoute and GRPCRoute controllers to watch ReferenceGrant changes.
 func FindRoutesForReferenceGrant(
 	obj client.Object,
@@ -55,7 +66,7 @@ func FindRoutesForReferenceGrant(
 	for _, route := range routes {
 		crossNsBackends := route.GetCrossNamespaceBackendNamespaces()
 
-		if slices.Contains(crossNsBackends, targetNamespace) {
+		if slices.Contains(crossNsBackends, targetNamespace) || hasParentInNamespace(route, targetNamespace) {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKey{
 					Name:      route.GetName(),
@@ -68,6 +79,215 @@ func FindRoutesForReferenceGrant(
 	return requests
 }
 
+// FindRoutesForBackend returns reconcile requests for routes whose
+// backendRefs reference the PingoraBackend.
+func FindRoutesForBackend(obj client.Object, routes []Route) []reconcile.Request {
+	if _, ok := obj.(*v1alpha1.PingoraBackend); !ok {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for _, route := range routes {
+		if slices.ContainsFunc(route.GetBackendRefs(), func(ref gatewayv1.BackendRef) bool {
+			return ingress.IsPingoraBackendRef(&ref) &&
+				backendNamespace(route.GetNamespace(), &ref) == obj.GetNamespace() &&
+				string(ref.Name) == obj.GetName()
+		}) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKey{
+					Name:      route.GetName(),
+					Namespace: route.GetNamespace(),
+				},
+			})
+		}
+	}
+
+	return requests
+}
+
+// hasParentInNamespace reports whether a route has a parentRef to a
+// Gateway in another namespace, whose attachment a ReferenceGrant there may
+// permit.
+func hasParentInNamespace(route Route, namespace string) bool {
+	if namespace == route.GetNamespace() {
+		return false
+	}
+
+	return slices.ContainsFunc(route.GetParentRefs(), func(ref gatewayv1.ParentReference) bool {
+		return ref.Namespace != nil && string(*ref.Namespace) == namespace &&
+			(ref.Kind == nil || *ref.Kind == kindGateway)
+	})
+}
+
+// backendNamespace returns the namespace of a backendRef, which defaults to
+// the namespace of the route.
+func backendNamespace(routeNamespace string, ref *gatewayv1.BackendRef) string {
+	if ref.Namespace != nil {
+		return string(*ref.Namespace)
+	}
+
+	return routeNamespace
+}
+
+// FindHTTPRoutesForCORSPolicy returns reconcile requests for HTTPRoutes whose
+// rules reference the PingoraCORSPolicy in an ExtensionRef filter.
+func FindHTTPRoutesForCORSPolicy(obj client.Object, routes []gatewayv1.HTTPRoute) []reconcile.Request {
+	if _, ok := obj.(*v1alpha1.PingoraCORSPolicy); !ok {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range routes {
+		route := &routes[i]
+		if route.Namespace == obj.GetNamespace() && referencesCORSPolicy(route, obj.GetName()) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(route),
+			})
+		}
+	}
+
+	return requests
+}
+
+// referencesCORSPolicy reports whether any rule of the route references the
+// named PingoraCORSPolicy.
+func referencesCORSPolicy(route *gatewayv1.HTTPRoute, name string) bool {
+	for i := range route.Spec.Rules {
+		for j := range route.Spec.Rules[i].Filters {
+			ref := route.Spec.Rules[i].Filters[j].ExtensionRef
+			if ingress.IsCORSPolicyRef(ref) && string(ref.Name) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FindHTTPRoutesForPolicy returns reconcile requests for HTTPRoutes targeted
+// by an attached policy, directly or through a parent Gateway.
+func FindHTTPRoutesForPolicy(obj client.Object, routes []gatewayv1.HTTPRoute) []reconcile.Request {
+	policy, ok := obj.(ingress.AttachedPolicy)
+	if !ok {
+		return nil
+	}
+
+	targets := ingress.PolicyTargets(policy)
+
+	var requests []reconcile.Request
+
+	for i := range routes {
+		route := &routes[i]
+		if slices.ContainsFunc(targets, func(target ingress.PolicyTarget) bool {
+			return policyTargetsRoute(target, route)
+		}) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(route),
+			})
+		}
+	}
+
+	return requests
+}
+
+// FindGRPCRoutesForPolicy returns reconcile requests for GRPCRoutes targeted
+// by an attached policy, directly or through their backend Services.
+func FindGRPCRoutesForPolicy(obj client.Object, routes []gatewayv1.GRPCRoute) []reconcile.Request {
+	policy, ok := obj.(ingress.AttachedPolicy)
+	if !ok {
+		return nil
+	}
+
+	targets := ingress.PolicyTargets(policy)
+
+	var requests []reconcile.Request
+
+	for i := range routes {
+		route := &routes[i]
+		refs := GRPCRouteWrapper{route}.GetBackendRefs()
+
+		if slices.ContainsFunc(targets, func(target ingress.PolicyTarget) bool {
+			switch target.Kind {
+			case ingress.PolicyTargetGRPCRoute:
+				return target.Namespace == route.Namespace && target.Name == route.Name
+			case ingress.PolicyTargetService:
+				return usesService(route.Namespace, refs, target)
+			}
+
+			return false
+		}) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(route),
+			})
+		}
+	}
+
+	return requests
+}
+
+// FindPoliciesForTarget returns reconcile requests for the attached policies
+// that target the object of the given kind.
+func FindPoliciesForTarget[P ingress.AttachedPolicy](
+	kind string,
+	obj client.Object,
+	policies []P,
+) []reconcile.Request {
+	var requests []reconcile.Request
+
+	for _, policy := range policies {
+		if slices.ContainsFunc(ingress.PolicyTargets(policy), func(target ingress.PolicyTarget) bool {
+			return target.Kind == kind && target.Namespace == obj.GetNamespace() && target.Name == obj.GetName()
+		}) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: policy.GetNamespace(), Name: policy.GetName()},
+			})
+		}
+	}
+
+	return requests
+}
+
+// policyTargetsRoute reports whether a policy target is the route, one of
+// its parent Gateways or one of its backend Services. Listener sections of a
+// Gateway target all of its routes.
+func policyTargetsRoute(target ingress.PolicyTarget, route *gatewayv1.HTTPRoute) bool {
+	switch target.Kind {
+	case ingress.PolicyTargetHTTPRoute:
+		return target.Namespace == route.Namespace && target.Name == route.Name
+	case ingress.PolicyTargetGateway:
+		return slices.ContainsFunc(ingress.RouteGatewayTargets(route), func(gateway ingress.PolicyTarget) bool {
+			return gateway.Namespace == target.Namespace && gateway.Name == target.Name
+		})
+	case ingress.PolicyTargetService:
+		return usesService(route.Namespace, HTTPRouteWrapper{route}.GetBackendRefs(), target)
+	}
+
+	return false
+}
+
+// usesService reports whether backend refs of a route reference the Service
+// of a policy target.
+func usesService(routeNamespace string, refs []gatewayv1.BackendRef, target ingress.PolicyTarget) bool {
+	return slices.ContainsFunc(refs, func(ref gatewayv1.BackendRef) bool {
+		if ref.Group != nil && *ref.Group != "" {
+			return false
+		}
+
+		if ref.Kind != nil && *ref.Kind != ingress.PolicyTargetService {
+			return false
+		}
+
+		namespace := routeNamespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		return namespace == target.Namespace && string(ref.Name) == target.Name
+	})
+}
+
 // extractCrossNamespaceBackends returns unique namespaces from backend refs
 // that differ from the route's own namespace.
 func extractCrossNamespaceBackends(routeNamespace string, refs []gatewayv1.BackendRef) []string {
@@ -93,8 +313,8 @@ type HTTPRouteWrapper struct {
 	*gatewayv1.HTTPRoute
 }
 
-// GetCrossNamespaceBackendNamespaces returns namespaces of backends in other namespaces.
-func (w HTTPRouteWrapper) GetCrossNamespaceBackendNamespaces() []string {
+// GetBackendRefs returns backend references from all HTTPRoute rules.
+func (w HTTPRouteWrapper) GetBackendRefs() []gatewayv1.BackendRef {
 	var refs []gatewayv1.BackendRef
 
 	for _, rule := range w.Spec.Rules {
@@ -103,7 +323,12 @@ func (w HTTPRouteWrapper) GetCrossNamespaceBackendNamespaces() []string {
 		}
 	}
 
-	return extractCrossNamespaceBackends(w.Namespace, refs)
+	return refs
+}
+
+// GetCrossNamespaceBackendNamespaces returns namespaces of backends in other namespaces.
+func (w HTTPRouteWrapper) GetCrossNamespaceBackendNamespaces() []string {
+	return extractCrossNamespaceBackends(w.Namespace, w.GetBackendRefs())
 }
 
 // GRPCRouteWrapper wraps GRPCRoute to implement Route.
@@ -111,17 +336,29 @@ type GRPCRouteWrapper struct {
 	*gatewayv1.GRPCRoute
 }
 
-// GetCrossNamespaceBackendNamespaces returns namespaces of backends in other namespaces.
-func (w GRPCRouteWrapper) GetCrossNamespaceBackendNamespaces() []string {
+// GetBackendRefs returns backend references from all GRPCRoute rules,
+// including the backends of RequestMirror filters.
+func (w GRPCRouteWrapper) GetBackendRefs() []gatewayv1.BackendRef {
 	var refs []gatewayv1.BackendRef
 
 	for _, rule := range w.Spec.Rules {
 		for i := range rule.BackendRefs {
 			refs = append(refs, rule.BackendRefs[i].BackendRef)
 		}
+
+		for i := range rule.Filters {
+			if mirror := rule.Filters[i].RequestMirror; mirror != nil {
+				refs = append(refs, gatewayv1.BackendRef{BackendObjectReference: mirror.BackendRef})
+			}
+		}
 	}
 
-	return extractCrossNamespaceBackends(w.Namespace, refs)
+	return refs
+}
+
+// GetCrossNamespaceBackendNamespaces returns namespaces of backends in other namespaces.
+func (w GRPCRouteWrapper) GetCrossNamespaceBackendNamespaces() []string {
+	return extractCrossNamespaceBackends(w.Namespace, w.GetBackendRefs())
 }
 
 // GetHostnames returns the hostnames from the HTTPRoute spec.
@@ -189,14 +426,14 @@ func FindRoutesForGateway(obj client.Object, gatewayClassName string, routes []R
 func FilterAcceptedRoutes(
 	ctx context.Context,
 	cli client.Client,
-	validator *routebinding.Validator,
+	bindings *BindingCache,
 	gatewayClassName string,
 	routes []Route,
 ) []reconcile.Request {
 	var requests []reconcile.Request
 
 	for _, route := range routes {
-		if IsRouteAcceptedByGateway(ctx, cli, validator, gatewayClassName, route) {
+		if IsRouteAcceptedByGateway(ctx, cli, bindings, gatewayClassName, route) {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: client.ObjectKey{
 					Name:      route.GetName(),
@@ -215,11 +452,11 @@ func FilterAcceptedRoutes(
 func IsRouteAcceptedByGateway(
 	ctx context.Context,
 	cli client.Client,
-	validator *routebinding.Validator,
+	bindings *BindingCache,
 	gatewayClassName string,
 	route Route,
 ) bool {
-	for _, ref := range route.GetParentRefs() {
+	for refIdx, ref := range route.GetParentRefs() {
 		if ref.Kind != nil && *ref.Kind != kindGateway {
 			continue
 		}
@@ -248,7 +485,7 @@ func IsRouteAcceptedByGateway(
 			SectionName: ref.SectionName,
 		}
 
-		result, err := validator.ValidateBinding(ctx, &gateway, routeInfo)
+		result, err := bindings.Binding(ctx, &gateway, route, refIdx, routeInfo)
 		if err != nil {
 			logging.FromContext(ctx).Error("failed to validate route binding",
 				"route", route.GetNamespace()+"/"+route.GetName(),
@@ -265,3 +502,170 @@ func IsRouteAcceptedByGateway(
 
 	return false
 }
+
+// FindRoutesForNamespace returns reconcile requests for routes in the given Namespace
+// whose parent Gateway of the specified class selects route namespaces by label.
+// Such routes may gain or lose acceptance when the Namespace labels change.
+func FindRoutesForNamespace(
+	ctx context.Context,
+	cli client.Client,
+	obj client.Object,
+	gatewayClassName string,
+	routes []Route,
+) []reconcile.Request {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for _, route := range routes {
+		if route.GetNamespace() != namespace.Name {
+			continue
+		}
+
+		for _, gateway := range parentGatewaysOfClass(ctx, cli, gatewayClassName, route) {
+			if hasSelectorListener(gateway) {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: client.ObjectKey{
+						Name:      route.GetName(),
+						Namespace: route.GetNamespace(),
+					},
+				})
+
+				break
+			}
+		}
+	}
+
+	return requests
+}
+
+// ReferencesGatewayClass reports whether any parentRef of the route points to
+// an existing Gateway of the specified class, regardless of binding acceptance.
+func ReferencesGatewayClass(ctx context.Context, cli client.Client, gatewayClassName string, route Route) bool {
+	return len(parentGatewaysOfClass(ctx, cli, gatewayClassName, route)) > 0
+}
+
+// parentGatewaysOfClass returns the parent Gateways of the route that belong to the specified class.
+func parentGatewaysOfClass(
+	ctx context.Context,
+	cli client.Client,
+	gatewayClassName string,
+	route Route,
+) []*gatewayv1.Gateway {
+	var gateways []*gatewayv1.Gateway
+
+	for _, ref := range route.GetParentRefs() {
+		if ref.Kind != nil && *ref.Kind != kindGateway {
+			continue
+		}
+
+		namespace := route.GetNamespace()
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		var gateway gatewayv1.Gateway
+
+		err := cli.Get(ctx, types.NamespacedName{Name: string(ref.Name), Namespace: namespace}, &gateway)
+		if err != nil {
+			continue
+		}
+
+		if gateway.Spec.GatewayClassName == gatewayv1.ObjectName(gatewayClassName) {
+			gateways = append(gateways, &gateway)
+		}
+	}
+
+	return gateways
+}
+
+// hasSelectorListener reports whether any listener selects route namespaces by label.
+func hasSelectorListener(gateway *gatewayv1.Gateway) bool {
+	for i := range gateway.Spec.Listeners {
+		allowed := gateway.Spec.Listeners[i].AllowedRoutes
+		if allowed != nil && allowed.Namespaces != nil && allowed.Namespaces.From != nil &&
+			*allowed.Namespaces.From == gatewayv1.NamespacesFromSelector {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NamespaceLabelsChangedPredicate passes Namespace updates that change labels.
+// Namespace label changes do not bump the generation, so this is combined with
+// GenerationChangedPredicate to let them through the route controllers' event filter.
+func NamespaceLabelsChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNamespace, ok := e.ObjectOld.(*corev1.Namespace)
+			if !ok {
+				return false
+			}
+
+			newNamespace, ok := e.ObjectNew.(*corev1.Namespace)
+			if !ok {
+				return false
+			}
+
+			return !maps.Equal(oldNamespace.Labels, newNamespace.Labels)
+		},
+	}
+}
+
+// GatewayAnnotationChangedPredicate passes Gateway updates that change the
+// given annotation. Annotation changes do not bump the generation, so this is
+// combined with GenerationChangedPredicate to let them through the route
+// controllers' event filter.
+func GatewayAnnotationChangedPredicate(annotation string) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldGateway, ok := e.ObjectOld.(*gatewayv1.Gateway)
+			if !ok {
+				return false
+			}
+
+			newGateway, ok := e.ObjectNew.(*gatewayv1.Gateway)
+			if !ok {
+				return false
+			}
+
+			return oldGateway.Annotations[annotation] != newGateway.Annotations[annotation]
+		},
+	}
+}
+
+// DataChangedPredicate passes Secret and ConfigMap updates that change data.
+// Their changes do not bump the generation, so this is combined with
+// GenerationChangedPredicate to let them through the route controllers' event
+// filter.
+func DataChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			switch oldObj := e.ObjectOld.(type) {
+			case *corev1.Secret:
+				newObj, ok := e.ObjectNew.(*corev1.Secret)
+
+				return ok && !maps.EqualFunc(oldObj.Data, newObj.Data, bytes.Equal)
+			case *corev1.ConfigMap:
+				newObj, ok := e.ObjectNew.(*corev1.ConfigMap)
+
+				return ok && !maps.Equal(oldObj.Data, newObj.Data)
+			default:
+				return false
+			}
+		},
+	}
+}
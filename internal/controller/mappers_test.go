@@ -0,0 +1,605 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+)
+
+func newGateway(name, className string, from gatewayv1.FromNamespaces) *gatewayv1.Gateway {
+	return &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gateway-system"},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayv1.ObjectName(className),
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:     "http",
+					Port:     80,
+					Protocol: gatewayv1.HTTPProtocolType,
+					AllowedRoutes: &gatewayv1.AllowedRoutes{
+						Namespaces: &gatewayv1.RouteNamespaces{From: &from},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newRouteWithParent(name, namespace, gatewayName string) Route {
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+
+	return HTTPRouteWrapper{&gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{
+					{Name: gatewayv1.ObjectName(gatewayName), Namespace: &gatewayNamespace},
+				},
+			},
+		},
+	}}
+}
+
+func TestFindRoutesForNamespace(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newGateway("selector", "pingora", gatewayv1.NamespacesFromSelector),
+		newGateway("all", "pingora", gatewayv1.NamespacesFromAll),
+		newGateway("other-class", "other", gatewayv1.NamespacesFromSelector),
+	).Build()
+
+	routes := []Route{
+		newRouteWithParent("selected", "team-a", "selector"),
+		newRouteWithParent("all-namespaces", "team-a", "all"),
+		newRouteWithParent("other-class", "team-a", "other-class"),
+		newRouteWithParent("missing-gateway", "team-a", "missing"),
+		newRouteWithParent("other-namespace", "team-b", "selector"),
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}}
+
+	requests := FindRoutesForNamespace(context.Background(), cli, namespace, "pingora", routes)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "selected", requests[0].Name)
+	assert.Equal(t, "team-a", requests[0].Namespace)
+
+	assert.Nil(t, FindRoutesForNamespace(context.Background(), cli, &gatewayv1.Gateway{}, "pingora", routes))
+}
+
+func TestPingoraGatewayReconciler_NamespaceToGateways(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newGateway("selector", "pingora", gatewayv1.NamespacesFromSelector),
+		newGateway("all", "pingora", gatewayv1.NamespacesFromAll),
+		newGateway("other-class", "other", gatewayv1.NamespacesFromSelector),
+	).Build()
+
+	bindings := NewBindingCache(routebinding.NewValidator(cli))
+	reconciler := &PingoraGatewayReconciler{Client: cli, GatewayClassName: "pingora", Bindings: bindings}
+
+	requests := reconciler.namespaceToGateways(context.Background(),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}})
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "selector", requests[0].Name)
+	assert.Equal(t, "gateway-system", requests[0].Namespace)
+	// Cached bindings are dropped before the Gateways are reconciled
+	assert.Equal(t, uint64(1), bindings.epoch)
+}
+
+func TestFindRoutesForReferenceGrant(t *testing.T) {
+	t.Parallel()
+
+	namespace := func(name string) *gatewayv1.Namespace {
+		ns := gatewayv1.Namespace(name)
+
+		return &ns
+	}
+
+	route := func(name string, parent gatewayv1.ParentReference, backend gatewayv1.BackendRef) Route {
+		return HTTPRouteWrapper{&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{parent}},
+				Rules: []gatewayv1.HTTPRouteRule{
+					{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: backend}}},
+				},
+			},
+		}}
+	}
+
+	localGateway := gatewayv1.ParentReference{Name: "gateway"}
+	sharedGateway := gatewayv1.ParentReference{Name: "gateway", Namespace: namespace("infra")}
+	localService := gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "web"}}
+	sharedService := gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+		Name: "web", Namespace: namespace("infra"),
+	}}
+
+	requests := FindRoutesForReferenceGrant(
+		&gatewayv1beta1.ReferenceGrant{ObjectMeta: metav1.ObjectMeta{Name: "routes", Namespace: "infra"}},
+		[]Route{
+			route("local", localGateway, localService),
+			route("shared-backend", localGateway, sharedService),
+			route("shared-gateway", sharedGateway, localService),
+		},
+	)
+
+	names := make([]string, 0, len(requests))
+	for _, request := range requests {
+		names = append(names, request.String())
+	}
+
+	assert.Equal(t, []string{"team-a/shared-backend", "team-a/shared-gateway"}, names)
+}
+
+func TestFindHTTPRoutesForCORSPolicy(t *testing.T) {
+	t.Parallel()
+
+	routeWithFilter := func(name, namespace string, ref gatewayv1.LocalObjectReference) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gatewayv1.HTTPRouteSpec{
+				Rules: []gatewayv1.HTTPRouteRule{{
+					Filters: []gatewayv1.HTTPRouteFilter{{
+						Type:         gatewayv1.HTTPRouteFilterExtensionRef,
+						ExtensionRef: &ref,
+					}},
+				}},
+			},
+		}
+	}
+
+	policyRef := gatewayv1.LocalObjectReference{
+		Group: gatewayv1.Group(v1alpha1.GroupVersion.Group),
+		Kind:  v1alpha1.PingoraCORSPolicyKind,
+		Name:  "cors",
+	}
+	otherKind := policyRef
+	otherKind.Kind = "Other"
+
+	otherName := policyRef
+	otherName.Name = "other"
+
+	routes := []gatewayv1.HTTPRoute{
+		routeWithFilter("referencing", "team-a", policyRef),
+		routeWithFilter("other-kind", "team-a", otherKind),
+		routeWithFilter("other-name", "team-a", otherName),
+		routeWithFilter("other-namespace", "team-b", policyRef),
+	}
+
+	policy := &v1alpha1.PingoraCORSPolicy{ObjectMeta: metav1.ObjectMeta{Name: "cors", Namespace: "team-a"}}
+
+	requests := FindHTTPRoutesForCORSPolicy(policy, routes)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "referencing", requests[0].Name)
+	assert.Equal(t, "team-a", requests[0].Namespace)
+
+	assert.Nil(t, FindHTTPRoutesForCORSPolicy(&gatewayv1.Gateway{}, routes))
+}
+
+func rateLimitPolicy(name, namespace string, refs ...gatewayv1.LocalPolicyTargetReferenceWithSectionName) *v1alpha1.PingoraRateLimitPolicy {
+	return &v1alpha1.PingoraRateLimitPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       v1alpha1.PingoraRateLimitPolicySpec{TargetRefs: refs, Requests: 10},
+	}
+}
+
+func policyTarget(kind, name string) gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+			Group: gatewayv1.GroupName,
+			Kind:  gatewayv1.Kind(kind),
+			Name:  gatewayv1.ObjectName(name),
+		},
+	}
+}
+
+func TestFindHTTPRoutesForPolicy(t *testing.T) {
+	t.Parallel()
+
+	routes := []gatewayv1.HTTPRoute{
+		*newRouteWithParent("targeted", "gateway-system", "other").(HTTPRouteWrapper).HTTPRoute,
+		*newRouteWithParent("attached", "team-a", "public").(HTTPRouteWrapper).HTTPRoute,
+		*newRouteWithParent("unrelated", "team-a", "internal").(HTTPRouteWrapper).HTTPRoute,
+		*newRouteWithParent("targeted", "team-b", "internal").(HTTPRouteWrapper).HTTPRoute,
+	}
+
+	policy := rateLimitPolicy("limit", "gateway-system",
+		policyTarget("HTTPRoute", "targeted"),
+		policyTarget("Gateway", "public"),
+	)
+
+	requests := FindHTTPRoutesForPolicy(policy, routes)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "gateway-system/targeted", requests[0].String())
+	assert.Equal(t, "team-a/attached", requests[1].String())
+
+	assert.Nil(t, FindHTTPRoutesForPolicy(&gatewayv1.Gateway{}, routes))
+}
+
+func serviceTarget(name string) gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	target := policyTarget("Service", name)
+	target.Group = ""
+
+	return target
+}
+
+func TestFindRoutesForBackendPolicy(t *testing.T) {
+	t.Parallel()
+
+	otherNamespace := gatewayv1.Namespace("team-b")
+	backend := func(name string, namespace *gatewayv1.Namespace) gatewayv1.BackendRef {
+		return gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name:      gatewayv1.ObjectName(name),
+			Namespace: namespace,
+		}}
+	}
+
+	httpRoute := func(name string, ref gatewayv1.BackendRef) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{
+				{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: ref}}},
+			}},
+		}
+	}
+
+	grpcRoute := func(name string, ref gatewayv1.BackendRef) gatewayv1.GRPCRoute {
+		return gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"},
+			Spec: gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{
+				{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: ref}}},
+			}},
+		}
+	}
+
+	policy := &v1alpha1.PingoraBackendPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "breaker", Namespace: "team-a"},
+		Spec: v1alpha1.PingoraBackendPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{serviceTarget("api")},
+		},
+	}
+
+	httpRequests := FindHTTPRoutesForPolicy(policy, []gatewayv1.HTTPRoute{
+		httpRoute("uses", backend("api", nil)),
+		httpRoute("other-service", backend("web", nil)),
+		// A Service of the same name in another namespace is not targeted
+		httpRoute("other-namespace", backend("api", &otherNamespace)),
+	})
+
+	require.Len(t, httpRequests, 1)
+	assert.Equal(t, "team-a/uses", httpRequests[0].String())
+
+	grpcRequests := FindGRPCRoutesForPolicy(policy, []gatewayv1.GRPCRoute{
+		grpcRoute("uses", backend("api", nil)),
+		grpcRoute("other-service", backend("web", nil)),
+	})
+
+	require.Len(t, grpcRequests, 1)
+	assert.Equal(t, "team-a/uses", grpcRequests[0].String())
+}
+
+func TestFindRoutesForBackend(t *testing.T) {
+	t.Parallel()
+
+	group := gatewayv1.Group(v1alpha1.GroupVersion.Group)
+	kind := gatewayv1.Kind(v1alpha1.PingoraBackendKind)
+	backendRef := func(name, namespace string) gatewayv1.BackendRef {
+		ref := gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: &group,
+			Kind:  &kind,
+			Name:  gatewayv1.ObjectName(name),
+		}}
+		if namespace != "" {
+			backendNamespace := gatewayv1.Namespace(namespace)
+			ref.Namespace = &backendNamespace
+		}
+
+		return ref
+	}
+
+	httpRoute := func(name, namespace string, ref gatewayv1.BackendRef) Route {
+		return HTTPRouteWrapper{&gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{
+				{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: ref}}},
+			}},
+		}}
+	}
+
+	service := backendRef("external", "")
+	service.Group = nil
+	service.Kind = nil
+
+	backend := &v1alpha1.PingoraBackend{ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "team-a"}}
+
+	requests := FindRoutesForBackend(backend, []Route{
+		httpRoute("uses", "team-a", backendRef("external", "")),
+		httpRoute("cross-namespace", "team-b", backendRef("external", "team-a")),
+		httpRoute("other-namespace", "team-b", backendRef("external", "")),
+		httpRoute("other-backend", "team-a", backendRef("internal", "")),
+		// A Service of the same name is not a PingoraBackend
+		httpRoute("service", "team-a", service),
+		GRPCRouteWrapper{&gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: "team-a"},
+			Spec: gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{
+				{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: backendRef("external", "")}}},
+			}},
+		}},
+		GRPCRouteWrapper{&gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "grpc-mirror", Namespace: "team-a"},
+			Spec: gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{{
+				Filters: []gatewayv1.GRPCRouteFilter{{
+					Type:          gatewayv1.GRPCRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{BackendRef: backendRef("external", "").BackendObjectReference},
+				}},
+			}}},
+		}},
+	})
+
+	names := make([]string, 0, len(requests))
+	for _, request := range requests {
+		names = append(names, request.String())
+	}
+
+	assert.Equal(t, []string{"team-a/uses", "team-b/cross-namespace", "team-a/grpc", "team-a/grpc-mirror"}, names)
+	assert.Nil(t, FindRoutesForBackend(&corev1.Service{}, []Route{httpRoute("uses", "team-a", service)}))
+}
+
+func TestFindGRPCRoutesForPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := &v1alpha1.PingoraGRPCPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc-web", Namespace: "team-a"},
+		Spec: v1alpha1.PingoraGRPCPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{policyTarget("GRPCRoute", "api")},
+		},
+	}
+
+	requests := FindGRPCRoutesForPolicy(policy, []gatewayv1.GRPCRoute{
+		{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "team-b"}},
+	})
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "team-a/api", requests[0].String())
+}
+
+func TestFindPoliciesForTarget(t *testing.T) {
+	t.Parallel()
+
+	policies := []*v1alpha1.PingoraRateLimitPolicy{
+		rateLimitPolicy("route", "team-a", policyTarget("HTTPRoute", "web")),
+		rateLimitPolicy("gateway", "team-a", policyTarget("Gateway", "web")),
+		rateLimitPolicy("other", "team-a", policyTarget("HTTPRoute", "api")),
+	}
+
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+
+	requests := FindPoliciesForTarget("HTTPRoute", route, policies)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "team-a/route", requests[0].String())
+}
+
+func TestReferencesGatewayClass(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newGateway("ours", "pingora", gatewayv1.NamespacesFromSame),
+		newGateway("theirs", "other", gatewayv1.NamespacesFromSame),
+	).Build()
+
+	ctx := context.Background()
+
+	assert.True(t, ReferencesGatewayClass(ctx, cli, "pingora", newRouteWithParent("a", "default", "ours")))
+	assert.False(t, ReferencesGatewayClass(ctx, cli, "pingora", newRouteWithParent("b", "default", "theirs")))
+	assert.False(t, ReferencesGatewayClass(ctx, cli, "pingora", newRouteWithParent("c", "default", "missing")))
+}
+
+func TestNamespaceLabelsChangedPredicate(t *testing.T) {
+	t.Parallel()
+
+	namespace := func(labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: labels}}
+	}
+
+	tests := []struct {
+		name     string
+		event    event.UpdateEvent
+		expected bool
+	}{
+		{
+			name: "label added",
+			event: event.UpdateEvent{
+				ObjectOld: namespace(nil),
+				ObjectNew: namespace(map[string]string{"expose": "true"}),
+			},
+			expected: true,
+		},
+		{
+			name: "label value changed",
+			event: event.UpdateEvent{
+				ObjectOld: namespace(map[string]string{"expose": "true"}),
+				ObjectNew: namespace(map[string]string{"expose": "false"}),
+			},
+			expected: true,
+		},
+		{
+			name: "labels unchanged",
+			event: event.UpdateEvent{
+				ObjectOld: namespace(map[string]string{"expose": "true"}),
+				ObjectNew: namespace(map[string]string{"expose": "true"}),
+			},
+			expected: false,
+		},
+		{
+			name: "not a namespace",
+			event: event.UpdateEvent{
+				ObjectOld: &corev1.Secret{},
+				ObjectNew: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "b"}}},
+			},
+			expected: false,
+		},
+	}
+
+	pred := NamespaceLabelsChangedPredicate()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, pred.Update(tt.event))
+		})
+	}
+
+	assert.False(t, pred.Create(event.CreateEvent{Object: namespace(nil)}))
+}
+
+func TestGatewayAnnotationChangedPredicate(t *testing.T) {
+	t.Parallel()
+
+	gateway := func(annotations map[string]string) *gatewayv1.Gateway {
+		return &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: "web", Annotations: annotations}}
+	}
+
+	tests := []struct {
+		name     string
+		event    event.UpdateEvent
+		expected bool
+	}{
+		{
+			name: "annotation added",
+			event: event.UpdateEvent{
+				ObjectOld: gateway(nil),
+				ObjectNew: gateway(map[string]string{"example.com/watched": "true"}),
+			},
+			expected: true,
+		},
+		{
+			name: "other annotation changed",
+			event: event.UpdateEvent{
+				ObjectOld: gateway(map[string]string{"example.com/watched": "true"}),
+				ObjectNew: gateway(map[string]string{"example.com/watched": "true", "example.com/other": "x"}),
+			},
+			expected: false,
+		},
+		{
+			name: "not a gateway",
+			event: event.UpdateEvent{
+				ObjectOld: &corev1.Secret{},
+				ObjectNew: &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"example.com/watched": "true"},
+				}},
+			},
+			expected: false,
+		},
+	}
+
+	pred := GatewayAnnotationChangedPredicate("example.com/watched")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, pred.Update(tt.event))
+		})
+	}
+
+	assert.False(t, pred.Create(event.CreateEvent{Object: gateway(nil)}))
+}
+
+func TestDataChangedPredicate(t *testing.T) {
+	t.Parallel()
+
+	secret := func(value string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: "team-a"},
+			Data:       map[string][]byte{"jwks.json": []byte(value)},
+		}
+	}
+
+	configMap := func(value string, labels map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: "team-a", Labels: labels},
+			Data:       map[string]string{"jwks.json": value},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		event    event.UpdateEvent
+		expected bool
+	}{
+		{
+			name:     "secret data changed",
+			event:    event.UpdateEvent{ObjectOld: secret("a"), ObjectNew: secret("b")},
+			expected: true,
+		},
+		{
+			name:     "secret data unchanged",
+			event:    event.UpdateEvent{ObjectOld: secret("a"), ObjectNew: secret("a")},
+			expected: false,
+		},
+		{
+			name:     "configmap data changed",
+			event:    event.UpdateEvent{ObjectOld: configMap("a", nil), ObjectNew: configMap("b", nil)},
+			expected: true,
+		},
+		{
+			name: "configmap labels changed",
+			event: event.UpdateEvent{
+				ObjectOld: configMap("a", nil),
+				ObjectNew: configMap("a", map[string]string{"team": "a"}),
+			},
+			expected: false,
+		},
+		{
+			name: "other kind",
+			event: event.UpdateEvent{
+				ObjectOld: &corev1.Namespace{},
+				ObjectNew: &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"a": "b"}}},
+			},
+			expected: false,
+		},
+	}
+
+	pred := DataChangedPredicate()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, pred.Update(tt.event))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// OptionalResource is a Gateway API resource the controller can run
+// without, as not every Gateway API channel or release installs its CRD.
+type OptionalResource string
+
+// Optional resources, named by kind.
+const (
+	OptionalResourceGRPCRoute        OptionalResource = "GRPCRoute"
+	OptionalResourceReferenceGrant   OptionalResource = "ReferenceGrant"
+	OptionalResourceBackendTLSPolicy OptionalResource = "BackendTLSPolicy"
+)
+
+// MissingResources are the optional resources whose CRDs are not installed.
+// The zero value has none missing.
+type MissingResources map[OptionalResource]bool
+
+// Has reports whether the CRD of a resource is missing.
+func (m MissingResources) Has(resource OptionalResource) bool {
+	return m[resource]
+}
+
+// Names returns the missing resources in a stable order, for logs and
+// status messages.
+func (m MissingResources) Names() []string {
+	names := make([]string, 0, len(m))
+
+	for resource, missing := range m {
+		if missing {
+			names = append(names, string(resource))
+		}
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// optionalResources returns the optional resources the controller uses with
+// the given feature gates.
+func optionalResources(gates FeatureGates) []OptionalResource {
+	resources := []OptionalResource{OptionalResourceGRPCRoute, OptionalResourceReferenceGrant}
+
+	if gates.Enabled(FeatureGateBackendTLSPolicy) {
+		resources = append(resources, OptionalResourceBackendTLSPolicy)
+	}
+
+	return resources
+}
+
+// groupVersionKind returns the version of the resource the controller reads.
+func (r OptionalResource) groupVersionKind() schema.GroupVersionKind {
+	if r == OptionalResourceReferenceGrant {
+		return gatewayv1beta1.SchemeGroupVersion.WithKind(string(r))
+	}
+
+	return gatewayv1.SchemeGroupVersion.WithKind(string(r))
+}
+
+// DiscoverMissingResources looks up the optional resources the controller
+// would use with the given feature gates, and returns those the API server
+// does not serve. Watching a resource without its CRD stops the manager,
+// so their watches and controllers are not set up.
+func DiscoverMissingResources(mapper meta.RESTMapper, gates FeatureGates) (MissingResources, error) {
+	missing := make(MissingResources)
+
+	for _, resource := range optionalResources(gates) {
+		gvk := resource.groupVersionKind()
+
+		_, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+
+		switch {
+		case meta.IsNoMatchError(err):
+			missing[resource] = true
+		case err != nil:
+			return nil, errors.Wrapf(err, "failed to discover %s", resource)
+		}
+	}
+
+	return missing, nil
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func TestDiscoverMissingResources(t *testing.T) {
+	t.Parallel()
+
+	mapper := func(kinds ...schema.GroupVersionKind) meta.RESTMapper {
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		for _, kind := range kinds {
+			restMapper.Add(kind, meta.RESTScopeNamespace)
+		}
+
+		return restMapper
+	}
+
+	grpcRoute := gatewayv1.SchemeGroupVersion.WithKind("GRPCRoute")
+	referenceGrant := gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant")
+	backendTLSPolicy := gatewayv1.SchemeGroupVersion.WithKind("BackendTLSPolicy")
+
+	tests := []struct {
+		name     string
+		mapper   meta.RESTMapper
+		gates    FeatureGates
+		expected []string
+	}{
+		{
+			name:     "all installed",
+			mapper:   mapper(grpcRoute, referenceGrant, backendTLSPolicy),
+			gates:    FeatureGates{FeatureGateBackendTLSPolicy: true},
+			expected: []string{},
+		},
+		{
+			name:     "standard channel without grpcroute",
+			mapper:   mapper(referenceGrant),
+			expected: []string{"GRPCRoute"},
+		},
+		{
+			name:     "gated resource missing",
+			mapper:   mapper(grpcRoute),
+			gates:    FeatureGates{FeatureGateBackendTLSPolicy: true},
+			expected: []string{"BackendTLSPolicy", "ReferenceGrant"},
+		},
+		{
+			name:     "only another version served",
+			mapper:   mapper(grpcRoute, gatewayv1.SchemeGroupVersion.WithKind("ReferenceGrant")),
+			expected: []string{"ReferenceGrant"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			missing, err := DiscoverMissingResources(tt.mapper, tt.gates)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, missing.Names())
+		})
+	}
+}
+
+func TestMissingResourcesZeroValue(t *testing.T) {
+	t.Parallel()
+
+	var missing MissingResources
+
+	assert.False(t, missing.Has(OptionalResourceGRPCRoute))
+	assert.Empty(t, missing.Names())
+}
@@ -5,22 +5,29 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
 	"github.com/lexfrei/pingora-gateway-controller/internal/config"
 	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
 	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	"github.com/lexfrei/pingora-gateway-controller/internal/tracing"
 )
 
 const (
@@ -34,6 +41,7 @@ const (
 //   - Watches Gateway resources matching the configured GatewayClassName
 //   - Reads configuration from PingoraConfig via parametersRef
 //   - Updates Gateway status with Pingora proxy connection status
+//   - Creates cert-manager Certificates for Gateways with an issuer annotation
 //   - Handles Gateway deletion with proper cleanup
 type PingoraGatewayReconciler struct {
 	client.Client
@@ -49,9 +57,38 @@ type PingoraGatewayReconciler struct {
 
 	// ConfigResolver resolves configuration from PingoraConfig.
 	ConfigResolver *config.PingoraResolver
+
+	// ProxyHealth, if set, marks Gateways not programmed while the proxy is down.
+	ProxyHealth *ProxyHealthMonitor
+
+	// Recorder, if set, receives a ProxyUnreachable Event when a Gateway is
+	// no longer programmed because the proxy is down.
+	Recorder record.EventRecorder
+
+	// RequireParentRefGrants counts routes from other namespaces as attached
+	// only if a ReferenceGrant permits them to attach. It is ignored when
+	// Bindings is set, whose validator decides.
+	RequireParentRefGrants bool
+
+	// Bindings, if set, is the binding cache shared with the route syncer.
+	Bindings *BindingCache
+
+	// MissingResources are the optional resources without a CRD, which are
+	// neither watched nor counted in attachedRoutes.
+	MissingResources MissingResources
 }
 
+// Reconcile reconciles a Gateway within a trace span.
 func (r *PingoraGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.Start(ctx, "Gateway.Reconcile", tracing.ObjectAttributes(kindGateway, req.Namespace, req.Name))
+
+	result, err := r.reconcile(ctx, req)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (r *PingoraGatewayReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 
 	var gateway gatewayv1.Gateway
@@ -87,6 +124,8 @@ func (r *PingoraGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
+	r.applyCertificates(ctx, &gateway)
+
 	if err := r.updateStatus(ctx, &gateway, resolvedConfig); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed to update gateway status")
 	}
@@ -94,7 +133,7 @@ func (r *PingoraGatewayReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return ctrl.Result{}, nil
 }
 
-//nolint:funlen // status update logic with retry
+//nolint:funlen // status update logic
 func (r *PingoraGatewayReconciler) updateStatus(
 	ctx context.Context,
 	gateway *gatewayv1.Gateway,
@@ -102,99 +141,133 @@ func (r *PingoraGatewayReconciler) updateStatus(
 ) error {
 	gatewayKey := types.NamespacedName{Name: gateway.Name, Namespace: gateway.Namespace}
 
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		// Get fresh copy of the gateway to avoid conflict errors
-		var freshGateway gatewayv1.Gateway
-		if err := r.Get(ctx, gatewayKey, &freshGateway); err != nil {
-			return errors.Wrap(err, "failed to get fresh gateway")
-		}
+	// Get fresh copy of the gateway for the status fields that are kept
+	var freshGateway gatewayv1.Gateway
+	if err := r.Get(ctx, gatewayKey, &freshGateway); err != nil {
+		return errors.Wrap(err, "failed to get fresh gateway")
+	}
+
+	now := metav1.Now()
+	previous := freshGateway.Status.DeepCopy()
 
-		now := metav1.Now()
+	attachedRoutes := r.countAttachedRoutes(ctx, &freshGateway)
 
-		attachedRoutes := r.countAttachedRoutes(ctx, &freshGateway)
+	// Set Pingora proxy address as the gateway address
+	freshGateway.Status.Addresses = []gatewayv1.GatewayStatusAddress{
+		{
+			Type:  ptr(gatewayv1.HostnameAddressType),
+			Value: cfg.Address,
+		},
+	}
 
-		// Set Pingora proxy address as the gateway address
-		freshGateway.Status.Addresses = []gatewayv1.GatewayStatusAddress{
-			{
-				Type:  ptr(gatewayv1.HostnameAddressType),
-				Value: cfg.Address,
+	freshGateway.Status.Conditions = []metav1.Condition{
+		{
+			Type:               string(gatewayv1.GatewayConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: freshGateway.Generation,
+			LastTransitionTime: now,
+			Reason:             string(gatewayv1.GatewayReasonAccepted),
+			Message:            "Gateway accepted by Pingora controller",
+		},
+		{
+			Type:               string(gatewayv1.GatewayConditionProgrammed),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: freshGateway.Generation,
+			LastTransitionTime: now,
+			Reason:             string(gatewayv1.GatewayReasonProgrammed),
+			Message:            "Gateway programmed in Pingora proxy",
+		},
+	}
+
+	if r.ProxyHealth != nil && r.ProxyHealth.ProxyDown() {
+		programmed := &freshGateway.Status.Conditions[1]
+		programmed.Status = metav1.ConditionFalse
+		programmed.Reason = GatewayReasonProxyUnreachable
+		programmed.Message = "Pingora proxy unreachable"
+	}
+
+	http3, err := r.http3Config(ctx, cfg.ConfigName)
+	if err != nil {
+		return err
+	}
+
+	listenerStatuses := make([]gatewayv1.ListenerStatus, 0, len(freshGateway.Spec.Listeners))
+
+	for i := range freshGateway.Spec.Listeners {
+		listener := &freshGateway.Spec.Listeners[i]
+
+		supportedKinds, invalidKinds := routebinding.ListenerRouteKinds(listener)
+
+		listenerStatuses = append(listenerStatuses, gatewayv1.ListenerStatus{
+			Name:           listener.Name,
+			SupportedKinds: supportedKinds,
+			AttachedRoutes: attachedRoutes[listener.Name],
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(gatewayv1.ListenerConditionAccepted),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: freshGateway.Generation,
+					LastTransitionTime: now,
+					Reason:             string(gatewayv1.ListenerReasonAccepted),
+					Message:            "Listener accepted",
+				},
+				{
+					Type:               string(gatewayv1.ListenerConditionProgrammed),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: freshGateway.Generation,
+					LastTransitionTime: now,
+					Reason:             string(gatewayv1.ListenerReasonProgrammed),
+					Message:            "Listener programmed",
+				},
+				{
+					Type:               string(gatewayv1.ListenerConditionResolvedRefs),
+					Status:             metav1.ConditionTrue,
+					ObservedGeneration: freshGateway.Generation,
+					LastTransitionTime: now,
+					Reason:             string(gatewayv1.ListenerReasonResolvedRefs),
+					Message:            "References resolved",
+				},
 			},
+		})
+
+		if len(invalidKinds) > 0 {
+			setInvalidRouteKinds(listenerStatuses[len(listenerStatuses)-1].Conditions, invalidKinds)
 		}
 
-		freshGateway.Status.Conditions = []metav1.Condition{
-			{
-				Type:               string(gatewayv1.GatewayConditionAccepted),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: freshGateway.Generation,
-				LastTransitionTime: now,
-				Reason:             string(gatewayv1.GatewayReasonAccepted),
-				Message:            "Gateway accepted by Pingora controller",
-			},
-			{
-				Type:               string(gatewayv1.GatewayConditionProgrammed),
-				Status:             metav1.ConditionTrue,
-				ObservedGeneration: freshGateway.Generation,
-				LastTransitionTime: now,
-				Reason:             string(gatewayv1.GatewayReasonProgrammed),
-				Message:            "Gateway programmed in Pingora proxy",
-			},
+		problem, err := listenerCertificateProblem(ctx, r.Client, &freshGateway, listener)
+		if err != nil {
+			return err
 		}
 
-		listenerStatuses := make([]gatewayv1.ListenerStatus, 0, len(freshGateway.Spec.Listeners))
-
-		for _, listener := range freshGateway.Spec.Listeners {
-			listenerStatuses = append(listenerStatuses, gatewayv1.ListenerStatus{
-				Name: listener.Name,
-				SupportedKinds: []gatewayv1.RouteGroupKind{
-					{
-						Group: (*gatewayv1.Group)(&gatewayv1.GroupVersion.Group),
-						Kind:  "HTTPRoute",
-					},
-					{
-						Group: (*gatewayv1.Group)(&gatewayv1.GroupVersion.Group),
-						Kind:  "GRPCRoute",
-					},
-				},
-				AttachedRoutes: attachedRoutes[listener.Name],
-				Conditions: []metav1.Condition{
-					{
-						Type:               string(gatewayv1.ListenerConditionAccepted),
-						Status:             metav1.ConditionTrue,
-						ObservedGeneration: freshGateway.Generation,
-						LastTransitionTime: now,
-						Reason:             string(gatewayv1.ListenerReasonAccepted),
-						Message:            "Listener accepted",
-					},
-					{
-						Type:               string(gatewayv1.ListenerConditionProgrammed),
-						Status:             metav1.ConditionTrue,
-						ObservedGeneration: freshGateway.Generation,
-						LastTransitionTime: now,
-						Reason:             string(gatewayv1.ListenerReasonProgrammed),
-						Message:            "Listener programmed",
-					},
-					{
-						Type:               string(gatewayv1.ListenerConditionResolvedRefs),
-						Status:             metav1.ConditionTrue,
-						ObservedGeneration: freshGateway.Generation,
-						LastTransitionTime: now,
-						Reason:             string(gatewayv1.ListenerReasonResolvedRefs),
-						Message:            "References resolved",
-					},
-				},
-			})
+		if problem != nil {
+			setCertificateProblem(listenerStatuses[len(listenerStatuses)-1].Conditions, problem)
 		}
 
-		freshGateway.Status.Listeners = listenerStatuses
+		if condition := http3ListenerCondition(listener, http3, freshGateway.Generation, now); condition != nil {
+			status := &listenerStatuses[len(listenerStatuses)-1]
+			status.Conditions = append(status.Conditions, *condition)
+		}
 
-		if err := r.Status().Update(ctx, &freshGateway); err != nil {
-			return errors.Wrap(err, "failed to update gateway status")
+		if condition := httpsRedirectListenerCondition(&freshGateway, listener, now); condition != nil {
+			status := &listenerStatuses[len(listenerStatuses)-1]
+			status.Conditions = append(status.Conditions, *condition)
 		}
+	}
+
+	freshGateway.Status.Listeners = listenerStatuses
+	preserveGatewayTransitions(previous, &freshGateway.Status)
 
+	if equality.Semantic.DeepEqual(previous, &freshGateway.Status) {
 		return nil
-	})
+	}
 
-	return errors.Wrap(err, "failed to update gateway status after retries")
+	if err := applyStatus(ctx, r.Client, appliedGatewayStatus(&freshGateway)); err != nil {
+		return errors.Wrap(err, "failed to apply gateway status")
+	}
+
+	recordGatewayEvents(r.Recorder, &freshGateway, previous, &freshGateway.Status)
+
+	return nil
 }
 
 func (r *PingoraGatewayReconciler) setConfigErrorStatus(
@@ -204,37 +277,81 @@ func (r *PingoraGatewayReconciler) setConfigErrorStatus(
 ) error {
 	gatewayKey := types.NamespacedName{Name: gateway.Name, Namespace: gateway.Namespace}
 
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		// Get fresh copy of the gateway to avoid conflict errors
-		var freshGateway gatewayv1.Gateway
-		if err := r.Get(ctx, gatewayKey, &freshGateway); err != nil {
-			return errors.Wrap(err, "failed to get fresh gateway")
-		}
+	// Get fresh copy of the gateway for the status fields that are kept
+	var freshGateway gatewayv1.Gateway
+	if err := r.Get(ctx, gatewayKey, &freshGateway); err != nil {
+		return errors.Wrap(err, "failed to get fresh gateway")
+	}
 
-		now := metav1.Now()
+	now := metav1.Now()
+	previous := freshGateway.Status.DeepCopy()
+
+	freshGateway.Status.Conditions = []metav1.Condition{
+		{
+			Type:               string(gatewayv1.GatewayConditionAccepted),
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: freshGateway.Generation,
+			LastTransitionTime: now,
+			Reason:             "InvalidParameters",
+			Message:            "Failed to resolve PingoraConfig: " + configErr.Error(),
+		},
+	}
+	preserveGatewayTransitions(previous, &freshGateway.Status)
 
-		freshGateway.Status.Conditions = []metav1.Condition{
-			{
-				Type:               string(gatewayv1.GatewayConditionAccepted),
-				Status:             metav1.ConditionFalse,
-				ObservedGeneration: freshGateway.Generation,
-				LastTransitionTime: now,
-				Reason:             "InvalidParameters",
-				Message:            "Failed to resolve PingoraConfig: " + configErr.Error(),
-			},
-		}
+	if equality.Semantic.DeepEqual(previous, &freshGateway.Status) {
+		return nil
+	}
 
-		if err := r.Status().Update(ctx, &freshGateway); err != nil {
-			return errors.Wrap(err, "failed to update gateway status")
-		}
+	return errors.Wrap(applyStatus(ctx, r.Client, appliedGatewayStatus(&freshGateway)), "failed to apply gateway status")
+}
 
-		return nil
-	})
+// http3Config returns the HTTP/3 config of the named PingoraConfig, nil if
+// it has none.
+func (r *PingoraGatewayReconciler) http3Config(ctx context.Context, configName string) (*v1alpha1.HTTP3Config, error) {
+	var pingoraConfig v1alpha1.PingoraConfig
 
-	return errors.Wrap(err, "failed to update gateway status after retries")
+	if err := r.Get(ctx, client.ObjectKey{Name: configName}, &pingoraConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to get PingoraConfig")
+	}
+
+	if pingoraConfig.Spec.Defaults == nil {
+		return nil, nil
+	}
+
+	return pingoraConfig.Spec.Defaults.HTTP3, nil
+}
+
+// appliedGatewayStatus returns the gateway identity and status to apply.
+func appliedGatewayStatus(gateway *gatewayv1.Gateway) *gatewayv1.Gateway {
+	return &gatewayv1.Gateway{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: kindGateway},
+		ObjectMeta: metav1.ObjectMeta{Name: gateway.Name, Namespace: gateway.Namespace},
+		Status:     gateway.Status,
+	}
 }
 
-//nolint:gocognit,gocyclo,cyclop,dupl,funlen // complexity due to counting two route types
+// preserveGatewayTransitions keeps the LastTransitionTime of the gateway and
+// listener conditions in status whose status did not change since previous.
+func preserveGatewayTransitions(previous, status *gatewayv1.GatewayStatus) {
+	status.Conditions = mergeConditions(previous.Conditions, status.Conditions)
+
+	for i := range status.Listeners {
+		listener := &status.Listeners[i]
+
+		for _, old := range previous.Listeners {
+			if old.Name == listener.Name {
+				listener.Conditions = mergeConditions(old.Conditions, listener.Conditions)
+
+				break
+			}
+		}
+	}
+}
+
+// countAttachedRoutes counts the routes attached to each listener of a
+// Gateway. Only the routes whose parentRefs name the Gateway are listed, and
+// their binding results come from the binding cache shared with the route
+// syncer.
 func (r *PingoraGatewayReconciler) countAttachedRoutes(
 	ctx context.Context,
 	gateway *gatewayv1.Gateway,
@@ -246,76 +363,59 @@ func (r *PingoraGatewayReconciler) countAttachedRoutes(
 		result[listener.Name] = 0
 	}
 
-	validator := routebinding.NewValidator(r.Client)
+	bindings := r.Bindings
+	if bindings == nil {
+		validator := routebinding.NewValidator(r.Client)
+		validator.RequireParentRefGrants = r.RequireParentRefGrants
+		bindings = NewBindingCache(validator)
+	}
 
-	// Count HTTPRoutes with binding validation
-	var httpRouteList gatewayv1.HTTPRouteList
+	parentGateway := client.MatchingFields{IndexRouteParentGateway: gateway.Namespace + "/" + gateway.Name}
 
-	err := r.List(ctx, &httpRouteList)
-	if err != nil {
+	var routes []Route
+
+	var httpRouteList gatewayv1.HTTPRouteList
+	if err := r.List(ctx, &httpRouteList, parentGateway); err != nil {
 		logger.Error("failed to list HTTPRoutes for attached routes count", "error", err)
-	} else {
-		for i := range httpRouteList.Items {
-			route := &httpRouteList.Items[i]
-
-			for _, ref := range route.Spec.ParentRefs {
-				if !r.refMatchesGateway(ref, gateway, route.Namespace) {
-					continue
-				}
-
-				routeInfo := &routebinding.RouteInfo{
-					Name:        route.Name,
-					Namespace:   route.Namespace,
-					Hostnames:   route.Spec.Hostnames,
-					Kind:        routebinding.KindHTTPRoute,
-					SectionName: ref.SectionName,
-				}
-
-				bindingResult, bindErr := validator.ValidateBinding(ctx, gateway, routeInfo)
-				if bindErr != nil || !bindingResult.Accepted {
-					continue
-				}
-
-				// Count this route for each matched listener
-				for _, listenerName := range bindingResult.MatchedListeners {
-					result[listenerName]++
-				}
-			}
-		}
 	}
 
-	// Count GRPCRoutes with binding validation
+	for i := range httpRouteList.Items {
+		routes = append(routes, HTTPRouteWrapper{&httpRouteList.Items[i]})
+	}
+
 	var grpcRouteList gatewayv1.GRPCRouteList
+	if !r.MissingResources.Has(OptionalResourceGRPCRoute) {
+		if err := r.List(ctx, &grpcRouteList, parentGateway); err != nil {
+			logger.Error("failed to list GRPCRoutes for attached routes count", "error", err)
+		}
+	}
 
-	err = r.List(ctx, &grpcRouteList)
-	if err != nil {
-		logger.Error("failed to list GRPCRoutes for attached routes count", "error", err)
-	} else {
-		for i := range grpcRouteList.Items {
-			route := &grpcRouteList.Items[i]
-
-			for _, ref := range route.Spec.ParentRefs {
-				if !r.refMatchesGateway(ref, gateway, route.Namespace) {
-					continue
-				}
-
-				routeInfo := &routebinding.RouteInfo{
-					Name:        route.Name,
-					Namespace:   route.Namespace,
-					Hostnames:   route.Spec.Hostnames,
-					Kind:        routebinding.KindGRPCRoute,
-					SectionName: ref.SectionName,
-				}
-
-				bindingResult, bindErr := validator.ValidateBinding(ctx, gateway, routeInfo)
-				if bindErr != nil || !bindingResult.Accepted {
-					continue
-				}
-
-				// Count this route for each matched listener
-				for _, listenerName := range bindingResult.MatchedListeners {
-					result[listenerName]++
-				}
+	for i := range grpcRouteList.Items {
+		routes = append(routes, GRPCRouteWrapper{&grpcRouteList.Items[i]})
+	}
+
+	for _, route := range routes {
+		for refIdx, ref := range route.GetParentRefs() {
+			if !r.refMatchesGateway(ref, gateway, route.GetNamespace()) {
+				continue
+			}
+
+			routeInfo := &routebinding.RouteInfo{
+				Name:        route.GetName(),
+				Namespace:   route.GetNamespace(),
+				Hostnames:   route.GetHostnames(),
+				Kind:        route.GetRouteKind(),
+				SectionName: ref.SectionName,
+			}
+
+			bindingResult, bindErr := bindings.Binding(ctx, gateway, route, refIdx, routeInfo)
+			if bindErr != nil || !bindingResult.Accepted {
+				continue
+			}
+
+			// Count this route for each matched listener
+			for _, listenerName := range bindingResult.MatchedListeners {
+				result[listenerName]++
 			}
 		}
 	}
@@ -348,20 +448,61 @@ func (r *PingoraGatewayReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		ConfigResolver:   r.ConfigResolver,
 	}
 
-	//nolint:wrapcheck // controller-runtime builder pattern
-	return ctrl.NewControllerManagedBy(mgr).
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&gatewayv1.Gateway{}).
 		// Watch GatewayClass for parametersRef changes
 		Watches(
 			&gatewayv1.GatewayClass{},
 			handler.EnqueueRequestsFromMapFunc(r.gatewayClassToGateways),
 		).
-		// Watch PingoraConfig for config changes
+		// Watch PingoraConfig for config changes.
+		// Status updates written after every route sync are ignored.
 		Watches(
 			&v1alpha1.PingoraConfig{},
 			handler.EnqueueRequestsFromMapFunc(mapper.MapConfigToRequests(r.getAllGatewaysForClass)),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		// Watch Secrets referenced by listeners, such as certificates issued
+		// by cert-manager after the Gateway was created
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.secretToGateways),
 		).
-		Complete(r)
+		// Watch ConfigMaps holding CA certificates of frontend validation
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.configMapToGateways),
+		).
+		// Watch Namespace labels, which select the routes counted in
+		// attachedRoutes of listeners with selector-based allowedRoutes
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceToGateways),
+			builder.WithPredicates(NamespaceLabelsChangedPredicate()),
+		)
+
+	if !r.MissingResources.Has(OptionalResourceReferenceGrant) {
+		// Watch ReferenceGrants permitting certificateRefs and caCertificateRefs
+		// to other namespaces
+		bldr = bldr.Watches(
+			&gatewayv1beta1.ReferenceGrant{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+				return r.getAllGatewaysForClass(ctx)
+			}),
+		)
+	}
+
+	if r.ProxyHealth != nil {
+		// Reflect the proxy going down or recovering in Programmed
+		bldr = bldr.WatchesRawSource(source.Channel(
+			r.ProxyHealth.GatewayEvents(),
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, _ client.Object) []reconcile.Request {
+				return r.getAllGatewaysForClass(ctx)
+			}),
+		))
+	}
+
+	return bldr.Complete(r) //nolint:wrapcheck // controller-runtime builder pattern
 }
 
 // gatewayClassToGateways maps GatewayClass events to Gateway reconcile requests.
@@ -381,6 +522,34 @@ func (r *PingoraGatewayReconciler) gatewayClassToGateways(
 	return r.getAllGatewaysForClass(ctx)
 }
 
+// namespaceToGateways maps Namespace label changes to the Gateways of our
+// GatewayClass with a listener selecting route namespaces by label.
+func (r *PingoraGatewayReconciler) namespaceToGateways(ctx context.Context, _ client.Object) []reconcile.Request {
+	var gatewayList gatewayv1.GatewayList
+	if err := r.List(ctx, &gatewayList); err != nil {
+		return nil
+	}
+
+	// The binding cache drops its results on the same event, but its handler
+	// may run after the Gateways are reconciled.
+	if r.Bindings != nil {
+		r.Bindings.Invalidate()
+	}
+
+	var requests []reconcile.Request
+
+	for i := range gatewayList.Items {
+		gateway := &gatewayList.Items[i]
+		if string(gateway.Spec.GatewayClassName) != r.GatewayClassName || !hasSelectorListener(gateway) {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(gateway)})
+	}
+
+	return requests
+}
+
 func (r *PingoraGatewayReconciler) getAllGatewaysForClass(ctx context.Context) []reconcile.Request {
 	var gatewayList gatewayv1.GatewayList
 
@@ -6,10 +6,12 @@ import (
 
 	"github.com/cockroachdb/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -20,8 +22,10 @@ import (
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
 	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
 	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	"github.com/lexfrei/pingora-gateway-controller/internal/tracing"
 )
 
 const (
@@ -56,15 +60,40 @@ type PingoraGRPCRouteReconciler struct {
 	// RouteSyncer provides unified sync for both HTTP and GRPC routes.
 	RouteSyncer *PingoraRouteSyncer
 
-	// bindingValidator validates route binding to Gateway listeners.
-	bindingValidator *routebinding.Validator
+	// Recorder, if set, receives Events about the sync and rejection of
+	// GRPCRoutes.
+	Recorder record.EventRecorder
+
+	// DeletionFinalizer adds RouteFinalizer to programmed GRPCRoutes, so that
+	// they are removed from the proxy with DeleteRoutes before they are
+	// deleted. Routes that have the finalizer are finalized either way.
+	DeletionFinalizer bool
+
+	// APIReader, if set, reads GRPCRoutes missing from the cache, so that
+	// those that stopped matching the route label selector lose
+	// RouteFinalizer.
+	APIReader client.Reader
+
+	// bindings caches route binding to Gateway listeners, shared with
+	// RouteSyncer.
+	bindings *BindingCache
 
 	// startupComplete indicates whether the startup sync has completed.
 	// This prevents race conditions between startup sync and reconcile loop.
 	startupComplete atomic.Bool
 }
 
+// Reconcile reconciles a GRPCRoute within a trace span.
 func (r *PingoraGRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.Start(ctx, "GRPCRoute.Reconcile", tracing.ObjectAttributes("GRPCRoute", req.Namespace, req.Name))
+
+	result, err := r.reconcile(ctx, req)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (r *PingoraGRPCRouteReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	// Wait for startup sync to complete before processing reconcile events
 	// to prevent race conditions with gRPC updates
 	if !r.startupComplete.Load() {
@@ -78,6 +107,11 @@ func (r *PingoraGRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	var route gatewayv1.GRPCRoute
 	if err := r.Get(ctx, req.NamespacedName, &route); err != nil {
 		if apierrors.IsNotFound(err) {
+			// Routes outside the route label selector are only gone from the cache
+			if err := r.releaseFinalizer(ctx, req.NamespacedName); err != nil {
+				return ctrl.Result{}, err
+			}
+
 			logger.Info("grpcroute deleted, triggering full sync")
 
 			return r.syncAndUpdateStatus(ctx)
@@ -86,8 +120,33 @@ func (r *PingoraGRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, errors.Wrap(err, "failed to get grpcroute")
 	}
 
+	if !route.DeletionTimestamp.IsZero() {
+		logger.Info("grpcroute is being deleted, triggering full sync")
+
+		if err := finalizeRoute(ctx, r.Client, r.RouteSyncer, routebinding.KindGRPCRoute, &route); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return r.syncAndUpdateStatus(ctx)
+	}
+
 	if !r.isRouteForOurGateway(ctx, &route) {
-		return ctrl.Result{}, nil
+		// A route that lost acceptance (e.g. its namespace no longer matches a
+		// listener selector) must still be removed from the proxy.
+		if !ReferencesGatewayClass(ctx, r.Client, r.GatewayClassName, GRPCRouteWrapper{&route}) {
+			// Routes of other classes are not finalized by this controller
+			return ctrl.Result{}, removeRouteFinalizer(ctx, r.Client, &route)
+		}
+
+		logger.Info("grpcroute not accepted by any listener, triggering full sync")
+
+		return r.syncAndUpdateStatus(ctx)
+	}
+
+	if r.DeletionFinalizer {
+		if err := addRouteFinalizer(ctx, r.Client, r.RouteSyncer, &route); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
 	logger.Info("reconciling grpcroute")
@@ -98,7 +157,7 @@ func (r *PingoraGRPCRouteReconciler) Reconcile(ctx context.Context, req ctrl.Req
 func (r *PingoraGRPCRouteReconciler) syncAndUpdateStatus(ctx context.Context) (ctrl.Result, error) {
 	logger := logging.FromContext(ctx)
 
-	result, syncResult, syncErr := r.RouteSyncer.SyncAllRoutes(ctx)
+	result, syncResult, syncErr := r.RouteSyncer.RequestSync(ctx)
 
 	// Update status for all GRPC routes with per-parent binding results
 	var statusUpdateErr error
@@ -132,112 +191,173 @@ func (r *PingoraGRPCRouteReconciler) syncAndUpdateStatus(ctx context.Context) (c
 	return result, nil
 }
 
+// releaseFinalizer removes RouteFinalizer from a GRPCRoute that is missing
+// from the cache but still exists.
+func (r *PingoraGRPCRouteReconciler) releaseFinalizer(ctx context.Context, key types.NamespacedName) error {
+	return releaseRouteFinalizer(ctx, r.Client, r.APIReader, r.GatewayClassName, key, &gatewayv1.GRPCRoute{})
+}
+
 func (r *PingoraGRPCRouteReconciler) isRouteForOurGateway(ctx context.Context, route *gatewayv1.GRPCRoute) bool {
-	return IsRouteAcceptedByGateway(ctx, r.Client, r.bindingValidator, r.GatewayClassName, GRPCRouteWrapper{route})
+	return IsRouteAcceptedByGateway(ctx, r.Client, r.bindings, r.GatewayClassName, GRPCRouteWrapper{route})
 }
 
-//nolint:funlen,dupl // status update logic; similar structure to HTTPRoute controller is intentional
+// updateRouteStatus applies the route status, retrying when another writer
+// updated the route since it was read.
 func (r *PingoraGRPCRouteReconciler) updateRouteStatus(
 	ctx context.Context,
 	route *gatewayv1.GRPCRoute,
 	bindingInfo routeBindingInfo,
 	syncErr error,
+) error {
+	//nolint:wrapcheck // wrapped inside the retry function
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return r.applyRouteStatus(ctx, route, bindingInfo, syncErr)
+	})
+}
+
+//nolint:funlen,dupl // status update logic; similar structure to HTTPRoute controller is intentional
+func (r *PingoraGRPCRouteReconciler) applyRouteStatus(
+	ctx context.Context,
+	route *gatewayv1.GRPCRoute,
+	bindingInfo routeBindingInfo,
+	syncErr error,
 ) error {
 	routeKey := types.NamespacedName{Name: route.Name, Namespace: route.Namespace}
 
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		// Get fresh copy of the route to avoid conflict errors
-		var freshRoute gatewayv1.GRPCRoute
-		if err := r.Get(ctx, routeKey, &freshRoute); err != nil {
-			return errors.Wrap(err, "failed to get fresh grpcroute")
+	// Get fresh copy of the route for the parent statuses of other controllers
+	var freshRoute gatewayv1.GRPCRoute
+	if err := r.Get(ctx, routeKey, &freshRoute); err != nil {
+		return errors.Wrap(err, "failed to get fresh grpcroute")
+	}
+
+	now := metav1.Now()
+	previousParents := freshRoute.Status.Parents
+	previousConditions := ownParentConditions(previousParents, r.ControllerName)
+	freshRoute.Status.Parents = foreignParentStatuses(previousParents, r.ControllerName)
+	refsStatus := resolveBackendRefs(ctx, r.Client, routebinding.KindGRPCRoute, freshRoute.Namespace,
+		GRPCRouteWrapper{&freshRoute}.GetBackendRefs())
+	regexIssues := ingress.GRPCRouteRegexIssues(freshRoute.Spec.Rules)
+
+	for refIdx, ref := range freshRoute.Spec.ParentRefs {
+		if ref.Kind != nil && *ref.Kind != kindGateway {
+			continue
 		}
 
-		now := metav1.Now()
-		freshRoute.Status.Parents = nil
+		namespace := freshRoute.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
 
-		for refIdx, ref := range freshRoute.Spec.ParentRefs {
-			if ref.Kind != nil && *ref.Kind != kindGateway {
-				continue
-			}
+		var gateway gatewayv1.Gateway
+		if err := r.Get(ctx, client.ObjectKey{Name: string(ref.Name), Namespace: namespace}, &gateway); err != nil {
+			continue
+		}
 
-			namespace := freshRoute.Namespace
-			if ref.Namespace != nil {
-				namespace = string(*ref.Namespace)
-			}
+		if gateway.Spec.GatewayClassName != gatewayv1.ObjectName(r.GatewayClassName) {
+			continue
+		}
 
-			var gateway gatewayv1.Gateway
-			if err := r.Get(ctx, client.ObjectKey{Name: string(ref.Name), Namespace: namespace}, &gateway); err != nil {
-				continue
-			}
+		// Get binding result for this parent ref
+		bindingResult, hasBinding := bindingInfo.bindingResults[refIdx]
+
+		status := metav1.ConditionTrue
+		reason := string(gatewayv1.RouteReasonAccepted)
+		message := pingoraGRPCRouteAcceptedMessage
+
+		if syncErr != nil {
+			status = metav1.ConditionFalse
+			reason = string(gatewayv1.RouteReasonPending)
+			message = syncErr.Error()
+		} else if hasBinding && !bindingResult.Accepted {
+			status = metav1.ConditionFalse
+			reason = string(bindingResult.Reason)
+			message = acceptedMessage(bindingResult.Message, bindingResult)
+		} else if allRulesDropped(regexIssues, len(freshRoute.Spec.Rules)) {
+			status = metav1.ConditionFalse
+			reason = string(gatewayv1.RouteReasonUnsupportedValue)
+			message = droppedRulesMessage(regexIssues)
+		} else if hasBinding {
+			message = acceptedMessage(message, bindingResult)
+		}
 
-			if gateway.Spec.GatewayClassName != gatewayv1.ObjectName(r.GatewayClassName) {
-				continue
-			}
+		// Create copy to avoid pointer to loop variable
+		parentNS := gatewayv1.Namespace(namespace)
+
+		parentStatus := gatewayv1.RouteParentStatus{
+			ParentRef: gatewayv1.ParentReference{
+				Group:       ref.Group,
+				Kind:        ref.Kind,
+				Namespace:   &parentNS,
+				Name:        ref.Name,
+				SectionName: ref.SectionName,
+			},
+			ControllerName: gatewayv1.GatewayController(r.ControllerName),
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(gatewayv1.RouteConditionAccepted),
+					Status:             status,
+					ObservedGeneration: freshRoute.Generation,
+					LastTransitionTime: now,
+					Reason:             reason,
+					Message:            message,
+				},
+				resolvedRefsCondition(refsStatus, freshRoute.Generation, now),
+			},
+		}
 
-			// Get binding result for this parent ref
-			bindingResult, hasBinding := bindingInfo.bindingResults[refIdx]
-
-			status := metav1.ConditionTrue
-			reason := string(gatewayv1.RouteReasonAccepted)
-			message := pingoraGRPCRouteAcceptedMessage
-
-			if syncErr != nil {
-				status = metav1.ConditionFalse
-				reason = string(gatewayv1.RouteReasonPending)
-				message = syncErr.Error()
-			} else if hasBinding && !bindingResult.Accepted {
-				status = metav1.ConditionFalse
-				reason = string(bindingResult.Reason)
-				message = bindingResult.Message
-			}
+		if condition := partiallyInvalidCondition(regexIssues, len(freshRoute.Spec.Rules),
+			status == metav1.ConditionTrue, freshRoute.Generation, now); condition != nil {
+			parentStatus.Conditions = append(parentStatus.Conditions, *condition)
+		}
 
-			// Create copy to avoid pointer to loop variable
-			parentNS := gatewayv1.Namespace(namespace)
+		condition := grpcTimeoutsCondition(freshRoute.Annotations, freshRoute.Spec.Rules, freshRoute.Generation, now)
+		if condition != nil {
+			parentStatus.Conditions = append(parentStatus.Conditions, *condition)
+		}
 
-			parentStatus := gatewayv1.RouteParentStatus{
-				ParentRef: gatewayv1.ParentReference{
-					Group:       ref.Group,
-					Kind:        ref.Kind,
-					Namespace:   &parentNS,
-					Name:        ref.Name,
-					SectionName: ref.SectionName,
-				},
-				ControllerName: gatewayv1.GatewayController(r.ControllerName),
-				Conditions: []metav1.Condition{
-					{
-						Type:               string(gatewayv1.RouteConditionAccepted),
-						Status:             status,
-						ObservedGeneration: freshRoute.Generation,
-						LastTransitionTime: now,
-						Reason:             reason,
-						Message:            message,
-					},
-					{
-						Type:               string(gatewayv1.RouteConditionResolvedRefs),
-						Status:             metav1.ConditionTrue,
-						ObservedGeneration: freshRoute.Generation,
-						LastTransitionTime: now,
-						Reason:             string(gatewayv1.RouteReasonResolvedRefs),
-						Message:            resolvedRefsMessage,
-					},
-				},
+		if hasBinding {
+			if condition := acceptedListenersCondition(bindingResult, freshRoute.Generation, now); condition != nil {
+				parentStatus.Conditions = append(parentStatus.Conditions, *condition)
 			}
-
-			freshRoute.Status.Parents = append(freshRoute.Status.Parents, parentStatus)
 		}
 
-		if err := r.Status().Update(ctx, &freshRoute); err != nil {
-			return errors.Wrap(err, "failed to update grpcroute status")
-		}
+		parentStatus.Conditions = mergeConditions(
+			previousConditions[ancestorKey(parentStatus.ParentRef)], parentStatus.Conditions)
+		freshRoute.Status.Parents = append(freshRoute.Status.Parents, parentStatus)
+	}
 
+	if equality.Semantic.DeepEqual(previousParents, freshRoute.Status.Parents) {
 		return nil
-	})
+	}
+
+	// The parents of other controllers are applied as read, so the
+	// resourceVersion makes the API server reject the apply if they changed
+	applied := &gatewayv1.GRPCRoute{
+		TypeMeta: metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "GRPCRoute"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            freshRoute.Name,
+			Namespace:       freshRoute.Namespace,
+			ResourceVersion: freshRoute.ResourceVersion,
+		},
+		Status: freshRoute.Status,
+	}
 
-	return errors.Wrap(err, "failed to update grpcroute status after retries")
+	if err := applyStatus(ctx, r.Client, applied); err != nil {
+		return errors.Wrap(err, "failed to apply grpcroute status")
+	}
+
+	recordRouteEvents(r.Recorder, &freshRoute, r.ControllerName, previousConditions, freshRoute.Status.Parents)
+
+	return nil
 }
 
 func (r *PingoraGRPCRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.bindingValidator = routebinding.NewValidator(r.Client)
+	r.bindings = r.RouteSyncer.Bindings
+
+	err := indexRoutes(context.Background(), mgr.GetFieldIndexer(), &gatewayv1.GRPCRoute{}, grpcRouteForIndex)
+	if err != nil {
+		return err
+	}
 
 	mapper := &PingoraConfigMapper{
 		Client:           r.Client,
@@ -245,11 +365,17 @@ func (r *PingoraGRPCRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		ConfigResolver:   r.RouteSyncer.ConfigResolver,
 	}
 
-	err := ctrl.NewControllerManagedBy(mgr).
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&gatewayv1.GRPCRoute{}).
 		// Filter out status-only updates to prevent infinite reconciliation loops.
 		// We only care about spec changes (generation changes) or deletions.
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		// Namespace label changes are let through for selector-based allowedRoutes,
+		// Secret and ConfigMap data changes for credentials and CA certificates.
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			NamespaceLabelsChangedPredicate(),
+			DataChangedPredicate(),
+		)).
 		Watches(
 			&gatewayv1.Gateway{},
 			handler.EnqueueRequestsFromMapFunc(r.findRoutesForGateway),
@@ -259,17 +385,64 @@ func (r *PingoraGRPCRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&v1alpha1.PingoraConfig{},
 			handler.EnqueueRequestsFromMapFunc(mapper.MapConfigToRequests(r.getAllRelevantRoutes)),
 		).
-		// Watch Secrets for TLS credential changes
+		// Watch Secrets for TLS credential and listener certificate changes
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(mapper.MapSecretToRequests(r.getAllRelevantRoutes)),
 		).
-		// Watch ReferenceGrant for cross-namespace permission changes
+		// Watch Namespace labels for selector-based allowedRoutes
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForNamespace),
+		).
+		// Watch Services created or deleted under backendRefs for ResolvedRefs
 		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForService),
+		).
+		// Watch PingoraBackendPolicy attached to backend Services
+		Watches(
+			&v1alpha1.PingoraBackendPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		).
+		// Watch PingoraBackend referenced by backendRefs
+		Watches(
+			&v1alpha1.PingoraBackend{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForBackend),
+		).
+		// Watch PingoraGRPCPolicy attached to GRPCRoutes
+		Watches(
+			&v1alpha1.PingoraGRPCPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		)
+
+	if !r.RouteSyncer.MissingResources.Has(OptionalResourceReferenceGrant) {
+		// Watch ReferenceGrant for cross-namespace permission changes
+		bldr = bldr.Watches(
 			&gatewayv1beta1.ReferenceGrant{},
 			handler.EnqueueRequestsFromMapFunc(r.findRoutesForReferenceGrant),
-		).
-		Complete(r)
+		)
+	}
+
+	if r.RouteSyncer.BackendTLSPolicies {
+		// Watch BackendTLSPolicy attached to backend Services and the CA
+		// certificates it reads
+		bldr = bldr.
+			Watches(
+				&gatewayv1.BackendTLSPolicy{},
+				handler.EnqueueRequestsFromMapFunc(r.findRoutesForBackendTLS),
+			).
+			Watches(
+				&corev1.ConfigMap{},
+				handler.EnqueueRequestsFromMapFunc(r.findRoutesForBackendTLS),
+			).
+			Watches(
+				&corev1.Secret{},
+				handler.EnqueueRequestsFromMapFunc(r.findRoutesForBackendTLS),
+			)
+	}
+
+	err = bldr.Complete(r)
 	if err != nil {
 		return errors.Wrap(err, "failed to setup pingora grpcroute controller")
 	}
@@ -310,7 +483,11 @@ func (r *PingoraGRPCRouteReconciler) findRoutesForGateway(
 	obj client.Object,
 ) []reconcile.Request {
 	var routeList gatewayv1.GRPCRouteList
-	if err := r.List(ctx, &routeList); err != nil {
+
+	err := r.List(ctx, &routeList, client.MatchingFields{
+		IndexRouteParentGateway: obj.GetNamespace() + "/" + obj.GetName(),
+	})
+	if err != nil {
 		return nil
 	}
 
@@ -328,7 +505,7 @@ func (r *PingoraGRPCRouteReconciler) findRoutesForReferenceGrant(
 ) []reconcile.Request {
 	var routeList gatewayv1.GRPCRouteList
 
-	err := r.List(ctx, &routeList)
+	err := r.List(ctx, &routeList, client.MatchingFields{IndexRouteBackendNamespace: obj.GetNamespace()})
 	if err != nil {
 		return nil
 	}
@@ -346,6 +523,100 @@ func (r *PingoraGRPCRouteReconciler) findRoutesForReferenceGrant(
 	return FindRoutesForReferenceGrant(obj, routes)
 }
 
+// findRoutesForBackendTLS maps BackendTLSPolicy events, and events of the
+// ConfigMaps and Secrets holding their CA certificates, to the routes
+// referencing the Services the policies are attached to.
+func (r *PingoraGRPCRouteReconciler) findRoutesForBackendTLS(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	return routesForServices(ctx, backendTLSPolicyServices(ctx, r.Client, obj), r.findRoutesForService)
+}
+
+// findRoutesForService maps Service events to the routes referencing the
+// Service in their backendRefs.
+func (r *PingoraGRPCRouteReconciler) findRoutesForService(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var routeList gatewayv1.GRPCRouteList
+
+	err := r.List(ctx, &routeList, client.MatchingFields{
+		IndexRouteBackendService: obj.GetNamespace() + "/" + obj.GetName(),
+	})
+	if err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(routeList.Items))
+	for i := range routeList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&routeList.Items[i])}
+	}
+
+	return requests
+}
+
+func (r *PingoraGRPCRouteReconciler) findRoutesForNamespace(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var routeList gatewayv1.GRPCRouteList
+
+	err := r.List(ctx, &routeList, client.InNamespace(obj.GetName()))
+	if err != nil {
+		return nil
+	}
+
+	routes := make([]Route, len(routeList.Items))
+	for i := range routeList.Items {
+		routes[i] = GRPCRouteWrapper{&routeList.Items[i]}
+	}
+
+	// The binding cache drops its results on the same event, but its handler
+	// may run after the routes are reconciled.
+	if r.bindings != nil {
+		r.bindings.Invalidate()
+	}
+
+	return FindRoutesForNamespace(ctx, r.Client, obj, r.GatewayClassName, routes)
+}
+
+func (r *PingoraGRPCRouteReconciler) findRoutesForBackend(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	// Routes in any namespace may reference the PingoraBackend
+	var routeList gatewayv1.GRPCRouteList
+
+	err := r.List(ctx, &routeList)
+	if err != nil {
+		return nil
+	}
+
+	routes := make([]Route, len(routeList.Items))
+	for i := range routeList.Items {
+		routes[i] = GRPCRouteWrapper{&routeList.Items[i]}
+	}
+
+	return FindRoutesForBackend(obj, routes)
+}
+
+func (r *PingoraGRPCRouteReconciler) findRoutesForPolicy(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	// Routes in any namespace may use a targeted Service as a backend, so
+	// list them all rather than only the policy's namespace
+	var routeList gatewayv1.GRPCRouteList
+
+	err := r.List(ctx, &routeList)
+	if err != nil {
+		return nil
+	}
+
+	return FindGRPCRoutesForPolicy(obj, routeList.Items)
+}
+
 func (r *PingoraGRPCRouteReconciler) getAllRelevantRoutes(ctx context.Context) []reconcile.Request {
 	var routeList gatewayv1.GRPCRouteList
 
@@ -359,5 +630,5 @@ func (r *PingoraGRPCRouteReconciler) getAllRelevantRoutes(ctx context.Context) [
 		routes[i] = GRPCRouteWrapper{&routeList.Items[i]}
 	}
 
-	return FilterAcceptedRoutes(ctx, r.Client, r.bindingValidator, r.GatewayClassName, routes)
+	return FilterAcceptedRoutes(ctx, r.Client, r.bindings, r.GatewayClassName, routes)
 }
@@ -7,10 +7,12 @@ import (
 
 	"github.com/cockroachdb/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -22,8 +24,10 @@ import (
 
 	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
 	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
 	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
 	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	"github.com/lexfrei/pingora-gateway-controller/internal/tracing"
 )
 
 const (
@@ -32,9 +36,6 @@ const (
 
 	// startupPendingRequeueDelay is the delay before retrying when startup sync is pending.
 	startupPendingRequeueDelay = 1 * time.Second
-
-	// resolvedRefsMessage is the default message for resolved refs condition.
-	resolvedRefsMessage = "References resolved"
 )
 
 // PingoraHTTPRouteReconciler reconciles HTTPRoute resources and synchronizes them
@@ -64,15 +65,40 @@ type PingoraHTTPRouteReconciler struct {
 	// RouteSyncer provides unified sync for both HTTP and GRPC routes.
 	RouteSyncer *PingoraRouteSyncer
 
-	// bindingValidator validates route binding to Gateway listeners.
-	bindingValidator *routebinding.Validator
+	// Recorder, if set, receives Events about the sync and rejection of
+	// HTTPRoutes.
+	Recorder record.EventRecorder
+
+	// DeletionFinalizer adds RouteFinalizer to programmed HTTPRoutes, so that
+	// they are removed from the proxy with DeleteRoutes before they are
+	// deleted. Routes that have the finalizer are finalized either way.
+	DeletionFinalizer bool
+
+	// APIReader, if set, reads HTTPRoutes missing from the cache, so that
+	// those that stopped matching the route label selector lose
+	// RouteFinalizer.
+	APIReader client.Reader
+
+	// bindings caches route binding to Gateway listeners, shared with
+	// RouteSyncer.
+	bindings *BindingCache
 
 	// startupComplete indicates whether the startup sync has completed.
 	// This prevents race conditions between startup sync and reconcile loop.
 	startupComplete atomic.Bool
 }
 
+// Reconcile reconciles a HTTPRoute within a trace span.
 func (r *PingoraHTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.Start(ctx, "HTTPRoute.Reconcile", tracing.ObjectAttributes("HTTPRoute", req.Namespace, req.Name))
+
+	result, err := r.reconcile(ctx, req)
+	tracing.End(span, err)
+
+	return result, err
+}
+
+func (r *PingoraHTTPRouteReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	// Wait for startup sync to complete before processing reconcile events
 	// to prevent race conditions with gRPC updates
 	if !r.startupComplete.Load() {
@@ -86,6 +112,11 @@ func (r *PingoraHTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	var route gatewayv1.HTTPRoute
 	if err := r.Get(ctx, req.NamespacedName, &route); err != nil {
 		if apierrors.IsNotFound(err) {
+			// Routes outside the route label selector are only gone from the cache
+			if err := r.releaseFinalizer(ctx, req.NamespacedName); err != nil {
+				return ctrl.Result{}, err
+			}
+
 			logger.Info("httproute deleted, triggering full sync")
 
 			return r.syncAndUpdateStatus(ctx)
@@ -94,8 +125,33 @@ func (r *PingoraHTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, errors.Wrap(err, "failed to get httproute")
 	}
 
+	if !route.DeletionTimestamp.IsZero() {
+		logger.Info("httproute is being deleted, triggering full sync")
+
+		if err := finalizeRoute(ctx, r.Client, r.RouteSyncer, routebinding.KindHTTPRoute, &route); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		return r.syncAndUpdateStatus(ctx)
+	}
+
 	if !r.isRouteForOurGateway(ctx, &route) {
-		return ctrl.Result{}, nil
+		// A route that lost acceptance (e.g. its namespace no longer matches a
+		// listener selector) must still be removed from the proxy.
+		if !ReferencesGatewayClass(ctx, r.Client, r.GatewayClassName, HTTPRouteWrapper{&route}) {
+			// Routes of other classes are not finalized by this controller
+			return ctrl.Result{}, removeRouteFinalizer(ctx, r.Client, &route)
+		}
+
+		logger.Info("httproute not accepted by any listener, triggering full sync")
+
+		return r.syncAndUpdateStatus(ctx)
+	}
+
+	if r.DeletionFinalizer {
+		if err := addRouteFinalizer(ctx, r.Client, r.RouteSyncer, &route); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
 	logger.Info("reconciling httproute")
@@ -106,7 +162,7 @@ func (r *PingoraHTTPRouteReconciler) Reconcile(ctx context.Context, req ctrl.Req
 func (r *PingoraHTTPRouteReconciler) syncAndUpdateStatus(ctx context.Context) (ctrl.Result, error) {
 	logger := logging.FromContext(ctx)
 
-	result, syncResult, syncErr := r.RouteSyncer.SyncAllRoutes(ctx)
+	result, syncResult, syncErr := r.RouteSyncer.RequestSync(ctx)
 
 	// Update status for all HTTP routes with per-parent binding results
 	var statusUpdateErr error
@@ -140,112 +196,197 @@ func (r *PingoraHTTPRouteReconciler) syncAndUpdateStatus(ctx context.Context) (c
 	return result, nil
 }
 
+// releaseFinalizer removes RouteFinalizer from a HTTPRoute that is missing
+// from the cache but still exists.
+func (r *PingoraHTTPRouteReconciler) releaseFinalizer(ctx context.Context, key types.NamespacedName) error {
+	return releaseRouteFinalizer(ctx, r.Client, r.APIReader, r.GatewayClassName, key, &gatewayv1.HTTPRoute{})
+}
+
 func (r *PingoraHTTPRouteReconciler) isRouteForOurGateway(ctx context.Context, route *gatewayv1.HTTPRoute) bool {
-	return IsRouteAcceptedByGateway(ctx, r.Client, r.bindingValidator, r.GatewayClassName, HTTPRouteWrapper{route})
+	return IsRouteAcceptedByGateway(ctx, r.Client, r.bindings, r.GatewayClassName, HTTPRouteWrapper{route})
 }
 
-//nolint:funlen,dupl // status update logic; similar structure to GRPCRoute controller is intentional
+// updateRouteStatus applies the route status, retrying when another writer
+// updated the route since it was read.
 func (r *PingoraHTTPRouteReconciler) updateRouteStatus(
 	ctx context.Context,
 	route *gatewayv1.HTTPRoute,
 	bindingInfo routeBindingInfo,
 	syncErr error,
+) error {
+	//nolint:wrapcheck // wrapped inside the retry function
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return r.applyRouteStatus(ctx, route, bindingInfo, syncErr)
+	})
+}
+
+//nolint:funlen,dupl // status update logic; similar structure to GRPCRoute controller is intentional
+func (r *PingoraHTTPRouteReconciler) applyRouteStatus(
+	ctx context.Context,
+	route *gatewayv1.HTTPRoute,
+	bindingInfo routeBindingInfo,
+	syncErr error,
 ) error {
 	routeKey := types.NamespacedName{Name: route.Name, Namespace: route.Namespace}
 
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		// Get fresh copy of the route to avoid conflict errors
-		var freshRoute gatewayv1.HTTPRoute
-		if err := r.Get(ctx, routeKey, &freshRoute); err != nil {
-			return errors.Wrap(err, "failed to get fresh httproute")
-		}
+	// Get fresh copy of the route for the parent statuses of other controllers
+	var freshRoute gatewayv1.HTTPRoute
+	if err := r.Get(ctx, routeKey, &freshRoute); err != nil {
+		return errors.Wrap(err, "failed to get fresh httproute")
+	}
 
-		now := metav1.Now()
-		freshRoute.Status.Parents = nil
+	now := metav1.Now()
+	previousParents := freshRoute.Status.Parents
+	previousConditions := ownParentConditions(previousParents, r.ControllerName)
+	freshRoute.Status.Parents = foreignParentStatuses(previousParents, r.ControllerName)
+	refsStatus := resolveBackendRefs(ctx, r.Client, routebinding.KindHTTPRoute, freshRoute.Namespace,
+		HTTPRouteWrapper{&freshRoute}.GetBackendRefs())
+	regexIssues := ingress.HTTPRouteRegexIssues(freshRoute.Spec.Rules)
+
+	for refIdx, ref := range freshRoute.Spec.ParentRefs {
+		if ref.Kind != nil && *ref.Kind != kindGateway {
+			continue
+		}
 
-		for refIdx, ref := range freshRoute.Spec.ParentRefs {
-			if ref.Kind != nil && *ref.Kind != kindGateway {
-				continue
-			}
+		namespace := freshRoute.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
 
-			namespace := freshRoute.Namespace
-			if ref.Namespace != nil {
-				namespace = string(*ref.Namespace)
-			}
+		var gateway gatewayv1.Gateway
+		if err := r.Get(ctx, client.ObjectKey{Name: string(ref.Name), Namespace: namespace}, &gateway); err != nil {
+			continue
+		}
 
-			var gateway gatewayv1.Gateway
-			if err := r.Get(ctx, client.ObjectKey{Name: string(ref.Name), Namespace: namespace}, &gateway); err != nil {
-				continue
-			}
+		if gateway.Spec.GatewayClassName != gatewayv1.ObjectName(r.GatewayClassName) {
+			continue
+		}
 
-			if gateway.Spec.GatewayClassName != gatewayv1.ObjectName(r.GatewayClassName) {
-				continue
-			}
+		// Get binding result for this parent ref
+		bindingResult, hasBinding := bindingInfo.bindingResults[refIdx]
+
+		status := metav1.ConditionTrue
+		reason := string(gatewayv1.RouteReasonAccepted)
+		message := pingoraRouteAcceptedMessage
+
+		if syncErr != nil {
+			status = metav1.ConditionFalse
+			reason = string(gatewayv1.RouteReasonPending)
+			message = syncErr.Error()
+		} else if hasBinding && !bindingResult.Accepted {
+			status = metav1.ConditionFalse
+			reason = string(bindingResult.Reason)
+			message = acceptedMessage(bindingResult.Message, bindingResult)
+		} else if allRulesDropped(regexIssues, len(freshRoute.Spec.Rules)) {
+			status = metav1.ConditionFalse
+			reason = string(gatewayv1.RouteReasonUnsupportedValue)
+			message = droppedRulesMessage(regexIssues)
+		} else if hasBinding {
+			message = acceptedMessage(message, bindingResult)
+		}
 
-			// Get binding result for this parent ref
-			bindingResult, hasBinding := bindingInfo.bindingResults[refIdx]
-
-			status := metav1.ConditionTrue
-			reason := string(gatewayv1.RouteReasonAccepted)
-			message := pingoraRouteAcceptedMessage
-
-			if syncErr != nil {
-				status = metav1.ConditionFalse
-				reason = string(gatewayv1.RouteReasonPending)
-				message = syncErr.Error()
-			} else if hasBinding && !bindingResult.Accepted {
-				status = metav1.ConditionFalse
-				reason = string(bindingResult.Reason)
-				message = bindingResult.Message
-			}
+		// Create copy to avoid pointer to loop variable
+		parentNS := gatewayv1.Namespace(namespace)
+
+		parentStatus := gatewayv1.RouteParentStatus{
+			ParentRef: gatewayv1.ParentReference{
+				Group:       ref.Group,
+				Kind:        ref.Kind,
+				Namespace:   &parentNS,
+				Name:        ref.Name,
+				SectionName: ref.SectionName,
+			},
+			ControllerName: gatewayv1.GatewayController(r.ControllerName),
+			Conditions: []metav1.Condition{
+				{
+					Type:               string(gatewayv1.RouteConditionAccepted),
+					Status:             status,
+					ObservedGeneration: freshRoute.Generation,
+					LastTransitionTime: now,
+					Reason:             reason,
+					Message:            message,
+				},
+				resolvedRefsCondition(refsStatus, freshRoute.Generation, now),
+			},
+		}
 
-			// Create copy to avoid pointer to loop variable
-			parentNS := gatewayv1.Namespace(namespace)
+		if condition := partiallyInvalidCondition(regexIssues, len(freshRoute.Spec.Rules),
+			status == metav1.ConditionTrue, freshRoute.Generation, now); condition != nil {
+			parentStatus.Conditions = append(parentStatus.Conditions, *condition)
+		}
 
-			parentStatus := gatewayv1.RouteParentStatus{
-				ParentRef: gatewayv1.ParentReference{
-					Group:       ref.Group,
-					Kind:        ref.Kind,
-					Namespace:   &parentNS,
-					Name:        ref.Name,
-					SectionName: ref.SectionName,
-				},
-				ControllerName: gatewayv1.GatewayController(r.ControllerName),
-				Conditions: []metav1.Condition{
-					{
-						Type:               string(gatewayv1.RouteConditionAccepted),
-						Status:             status,
-						ObservedGeneration: freshRoute.Generation,
-						LastTransitionTime: now,
-						Reason:             reason,
-						Message:            message,
-					},
-					{
-						Type:               string(gatewayv1.RouteConditionResolvedRefs),
-						Status:             metav1.ConditionTrue,
-						ObservedGeneration: freshRoute.Generation,
-						LastTransitionTime: now,
-						Reason:             string(gatewayv1.RouteReasonResolvedRefs),
-						Message:            resolvedRefsMessage,
-					},
-				},
-			}
+		if condition := latencyBudgetCondition(freshRoute.Annotations, freshRoute.Generation, now); condition != nil {
+			parentStatus.Conditions = append(parentStatus.Conditions, *condition)
+		}
 
-			freshRoute.Status.Parents = append(freshRoute.Status.Parents, parentStatus)
+		if condition := retryCondition(freshRoute.Annotations, freshRoute.Spec.Rules, freshRoute.Generation, now); condition != nil {
+			parentStatus.Conditions = append(parentStatus.Conditions, *condition)
 		}
 
-		if err := r.Status().Update(ctx, &freshRoute); err != nil {
-			return errors.Wrap(err, "failed to update httproute status")
+		if hasBinding {
+			if condition := acceptedListenersCondition(bindingResult, freshRoute.Generation, now); condition != nil {
+				parentStatus.Conditions = append(parentStatus.Conditions, *condition)
+			}
 		}
 
+		parentStatus.Conditions = mergeConditions(
+			previousConditions[ancestorKey(parentStatus.ParentRef)], parentStatus.Conditions)
+		freshRoute.Status.Parents = append(freshRoute.Status.Parents, parentStatus)
+	}
+
+	if equality.Semantic.DeepEqual(previousParents, freshRoute.Status.Parents) {
 		return nil
-	})
+	}
+
+	// The parents of other controllers are applied as read, so the
+	// resourceVersion makes the API server reject the apply if they changed
+	applied := &gatewayv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{APIVersion: gatewayv1.GroupVersion.String(), Kind: "HTTPRoute"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            freshRoute.Name,
+			Namespace:       freshRoute.Namespace,
+			ResourceVersion: freshRoute.ResourceVersion,
+		},
+		Status: freshRoute.Status,
+	}
+
+	if err := applyStatus(ctx, r.Client, applied); err != nil {
+		return errors.Wrap(err, "failed to apply httproute status")
+	}
+
+	recordRouteEvents(r.Recorder, &freshRoute, r.ControllerName, previousConditions, freshRoute.Status.Parents)
+
+	return nil
+}
+
+// resolvedRefsCondition builds the ResolvedRefs route condition from the backendRefs check.
+func resolvedRefsCondition(
+	refsStatus ingress.BackendRefsStatus,
+	generation int64,
+	now metav1.Time,
+) metav1.Condition {
+	status := metav1.ConditionTrue
+	if !refsStatus.Resolved {
+		status = metav1.ConditionFalse
+	}
 
-	return errors.Wrap(err, "failed to update httproute status after retries")
+	return metav1.Condition{
+		Type:               string(gatewayv1.RouteConditionResolvedRefs),
+		Status:             status,
+		ObservedGeneration: generation,
+		LastTransitionTime: now,
+		Reason:             string(refsStatus.Reason),
+		Message:            refsStatus.Message,
+	}
 }
 
 func (r *PingoraHTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	r.bindingValidator = routebinding.NewValidator(r.Client)
+	r.bindings = r.RouteSyncer.Bindings
+
+	err := indexRoutes(context.Background(), mgr.GetFieldIndexer(), &gatewayv1.HTTPRoute{}, httpRouteForIndex)
+	if err != nil {
+		return err
+	}
 
 	mapper := &PingoraConfigMapper{
 		Client:           r.Client,
@@ -253,11 +394,19 @@ func (r *PingoraHTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		ConfigResolver:   r.RouteSyncer.ConfigResolver,
 	}
 
-	err := ctrl.NewControllerManagedBy(mgr).
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&gatewayv1.HTTPRoute{}).
 		// Filter out status-only updates to prevent infinite reconciliation loops.
 		// We only care about spec changes (generation changes) or deletions.
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		// Namespace label changes are let through for selector-based allowedRoutes,
+		// Secret and ConfigMap data changes for credentials and key sets, and
+		// Gateway annotation changes for HTTPS redirects.
+		WithEventFilter(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			NamespaceLabelsChangedPredicate(),
+			DataChangedPredicate(),
+			GatewayAnnotationChangedPredicate(ingress.HTTPSRedirectAnnotation),
+		)).
 		Watches(
 			&gatewayv1.Gateway{},
 			handler.EnqueueRequestsFromMapFunc(r.findRoutesForGateway),
@@ -267,17 +416,107 @@ func (r *PingoraHTTPRouteReconciler) SetupWithManager(mgr ctrl.Manager) error {
 			&v1alpha1.PingoraConfig{},
 			handler.EnqueueRequestsFromMapFunc(mapper.MapConfigToRequests(r.getAllRelevantRoutes)),
 		).
-		// Watch Secrets for TLS credential changes
+		// Watch Secrets for TLS credential and listener certificate changes
 		Watches(
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(mapper.MapSecretToRequests(r.getAllRelevantRoutes)),
 		).
-		// Watch ReferenceGrant for cross-namespace permission changes
+		// Watch ConfigMaps for CA certificates of frontend validation
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(mapper.MapConfigMapToRequests(r.getAllRelevantRoutes)),
+		).
+		// Watch Namespace labels for selector-based allowedRoutes
 		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForNamespace),
+		).
+		// Watch Services created or deleted under backendRefs for ResolvedRefs
+		Watches(
+			&corev1.Service{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForService),
+		).
+		// Watch PingoraCORSPolicy referenced by ExtensionRef filters
+		Watches(
+			&v1alpha1.PingoraCORSPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForCORSPolicy),
+		).
+		// Watch PingoraRateLimitPolicy attached to routes and Gateways
+		Watches(
+			&v1alpha1.PingoraRateLimitPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		).
+		// Watch PingoraTrafficPolicy attached to Gateways
+		Watches(
+			&v1alpha1.PingoraTrafficPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		).
+		// Watch PingoraAccessControlPolicy attached to routes and Gateways
+		Watches(
+			&v1alpha1.PingoraAccessControlPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		).
+		// Watch PingoraCachePolicy attached to routes
+		Watches(
+			&v1alpha1.PingoraCachePolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		).
+		// Watch PingoraAccessLogPolicy attached to routes
+		Watches(
+			&v1alpha1.PingoraAccessLogPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		).
+		// Watch PingoraBackendPolicy attached to backend Services
+		Watches(
+			&v1alpha1.PingoraBackendPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		).
+		// Watch PingoraBackend referenced by backendRefs
+		Watches(
+			&v1alpha1.PingoraBackend{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForBackend),
+		).
+		// Watch PingoraAuthPolicy attached to routes and the key sets it reads
+		Watches(
+			&v1alpha1.PingoraAuthPolicy{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForPolicy),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForJWKS),
+		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findRoutesForJWKS),
+		)
+
+	if !r.RouteSyncer.MissingResources.Has(OptionalResourceReferenceGrant) {
+		// Watch ReferenceGrant for cross-namespace permission changes
+		bldr = bldr.Watches(
 			&gatewayv1beta1.ReferenceGrant{},
 			handler.EnqueueRequestsFromMapFunc(r.findRoutesForReferenceGrant),
-		).
-		Complete(r)
+		)
+	}
+
+	if r.RouteSyncer.BackendTLSPolicies {
+		// Watch BackendTLSPolicy attached to backend Services and the CA
+		// certificates it reads
+		bldr = bldr.
+			Watches(
+				&gatewayv1.BackendTLSPolicy{},
+				handler.EnqueueRequestsFromMapFunc(r.findRoutesForBackendTLS),
+			).
+			Watches(
+				&corev1.ConfigMap{},
+				handler.EnqueueRequestsFromMapFunc(r.findRoutesForBackendTLS),
+			).
+			Watches(
+				&corev1.Secret{},
+				handler.EnqueueRequestsFromMapFunc(r.findRoutesForBackendTLS),
+			)
+	}
+
+	err = bldr.Complete(r)
 	if err != nil {
 		return errors.Wrap(err, "failed to setup pingora httproute controller")
 	}
@@ -318,7 +557,11 @@ func (r *PingoraHTTPRouteReconciler) findRoutesForGateway(
 	obj client.Object,
 ) []reconcile.Request {
 	var routeList gatewayv1.HTTPRouteList
-	if err := r.List(ctx, &routeList); err != nil {
+
+	err := r.List(ctx, &routeList, client.MatchingFields{
+		IndexRouteParentGateway: obj.GetNamespace() + "/" + obj.GetName(),
+	})
+	if err != nil {
 		return nil
 	}
 
@@ -336,7 +579,7 @@ func (r *PingoraHTTPRouteReconciler) findRoutesForReferenceGrant(
 ) []reconcile.Request {
 	var routeList gatewayv1.HTTPRouteList
 
-	err := r.List(ctx, &routeList)
+	err := r.List(ctx, &routeList, client.MatchingFields{IndexRouteBackendNamespace: obj.GetNamespace()})
 	if err != nil {
 		return nil
 	}
@@ -354,6 +597,144 @@ func (r *PingoraHTTPRouteReconciler) findRoutesForReferenceGrant(
 	return FindRoutesForReferenceGrant(obj, routes)
 }
 
+// findRoutesForBackendTLS maps BackendTLSPolicy events, and events of the
+// ConfigMaps and Secrets holding their CA certificates, to the routes
+// referencing the Services the policies are attached to.
+func (r *PingoraHTTPRouteReconciler) findRoutesForBackendTLS(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	return routesForServices(ctx, backendTLSPolicyServices(ctx, r.Client, obj), r.findRoutesForService)
+}
+
+// findRoutesForService maps Service events to the routes referencing the
+// Service in their backendRefs.
+func (r *PingoraHTTPRouteReconciler) findRoutesForService(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var routeList gatewayv1.HTTPRouteList
+
+	err := r.List(ctx, &routeList, client.MatchingFields{
+		IndexRouteBackendService: obj.GetNamespace() + "/" + obj.GetName(),
+	})
+	if err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, len(routeList.Items))
+	for i := range routeList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&routeList.Items[i])}
+	}
+
+	return requests
+}
+
+func (r *PingoraHTTPRouteReconciler) findRoutesForNamespace(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var routeList gatewayv1.HTTPRouteList
+
+	err := r.List(ctx, &routeList, client.InNamespace(obj.GetName()))
+	if err != nil {
+		return nil
+	}
+
+	routes := make([]Route, len(routeList.Items))
+	for i := range routeList.Items {
+		routes[i] = HTTPRouteWrapper{&routeList.Items[i]}
+	}
+
+	// The binding cache drops its results on the same event, but its handler
+	// may run after the routes are reconciled.
+	if r.bindings != nil {
+		r.bindings.Invalidate()
+	}
+
+	return FindRoutesForNamespace(ctx, r.Client, obj, r.GatewayClassName, routes)
+}
+
+func (r *PingoraHTTPRouteReconciler) findRoutesForCORSPolicy(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var routeList gatewayv1.HTTPRouteList
+
+	err := r.List(ctx, &routeList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+
+	return FindHTTPRoutesForCORSPolicy(obj, routeList.Items)
+}
+
+func (r *PingoraHTTPRouteReconciler) findRoutesForBackend(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	// Routes in any namespace may reference the PingoraBackend
+	var routeList gatewayv1.HTTPRouteList
+
+	err := r.List(ctx, &routeList)
+	if err != nil {
+		return nil
+	}
+
+	routes := make([]Route, len(routeList.Items))
+	for i := range routeList.Items {
+		routes[i] = HTTPRouteWrapper{&routeList.Items[i]}
+	}
+
+	return FindRoutesForBackend(obj, routes)
+}
+
+func (r *PingoraHTTPRouteReconciler) findRoutesForPolicy(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	// Routes in any namespace may attach to a targeted Gateway
+	var routeList gatewayv1.HTTPRouteList
+
+	err := r.List(ctx, &routeList)
+	if err != nil {
+		return nil
+	}
+
+	return FindHTTPRoutesForPolicy(obj, routeList.Items)
+}
+
+func (r *PingoraHTTPRouteReconciler) findRoutesForJWKS(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	var policyList v1alpha1.PingoraAuthPolicyList
+
+	err := r.List(ctx, &policyList, client.InNamespace(obj.GetNamespace()))
+	if err != nil || len(policyList.Items) == 0 {
+		return nil
+	}
+
+	// Auth policies target routes in their own namespace
+	var routeList gatewayv1.HTTPRouteList
+
+	err = r.List(ctx, &routeList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range policyList.Items {
+		policy := &policyList.Items[i]
+		if authPolicyReferences(policy, obj) {
+			requests = append(requests, FindHTTPRoutesForPolicy(policy, routeList.Items)...)
+		}
+	}
+
+	return requests
+}
+
 func (r *PingoraHTTPRouteReconciler) getAllRelevantRoutes(ctx context.Context) []reconcile.Request {
 	var routeList gatewayv1.HTTPRouteList
 
@@ -367,7 +748,7 @@ func (r *PingoraHTTPRouteReconciler) getAllRelevantRoutes(ctx context.Context) [
 		routes[i] = HTTPRouteWrapper{&routeList.Items[i]}
 	}
 
-	return FilterAcceptedRoutes(ctx, r.Client, r.bindingValidator, r.GatewayClassName, routes)
+	return FilterAcceptedRoutes(ctx, r.Client, r.bindings, r.GatewayClassName, routes)
 }
 
 // PingoraConfigMapper maps PingoraConfig and Secret changes to route reconcile requests.
@@ -431,15 +812,51 @@ func (m *PingoraConfigMapper) MapSecretToRequests(
 		}
 
 		// Check if this secret is referenced by the config
-		if pingoraConfig.Spec.TLS != nil && pingoraConfig.Spec.TLS.SecretRef != nil {
-			secretRef := pingoraConfig.Spec.TLS.SecretRef
+		if pingoraConfig.Spec.TLS != nil && m.isConfigSecret(secret, pingoraConfig.Spec.TLS.SecretRef) {
+			return getRoutes(ctx)
+		}
 
-			secretNS := secretRef.Namespace
-			if secretNS == "" {
-				secretNS = "default"
+		if pingoraConfig.Spec.Defaults != nil && pingoraConfig.Spec.Defaults.ListenerTLS != nil &&
+			m.isConfigSecret(secret, pingoraConfig.Spec.Defaults.ListenerTLS.FallbackCertificateRef) {
+			return getRoutes(ctx)
+		}
+
+		// Or by a listener of one of our Gateways
+		if m.isListenerCertificate(ctx, secret) {
+			return getRoutes(ctx)
+		}
+
+		return nil
+	}
+}
+
+func (m *PingoraConfigMapper) isConfigSecret(secret *corev1.Secret, ref *v1alpha1.SecretReference) bool {
+	return ref != nil && secret.Name == ref.Name && secret.Namespace == m.ConfigResolver.SecretNamespace(ref.Namespace)
+}
+
+// MapConfigMapToRequests returns a function that maps changes of ConfigMaps
+// holding CA certificates of frontend validation to route requests.
+func (m *PingoraConfigMapper) MapConfigMapToRequests(
+	getRoutes func(ctx context.Context) []reconcile.Request,
+) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		configMap, ok := obj.(*corev1.ConfigMap)
+		if !ok {
+			return nil
+		}
+
+		var gatewayList gatewayv1.GatewayList
+		if err := m.Client.List(ctx, &gatewayList); err != nil {
+			return nil
+		}
+
+		for i := range gatewayList.Items {
+			gateway := &gatewayList.Items[i]
+			if gateway.Spec.GatewayClassName != gatewayv1.ObjectName(m.GatewayClassName) {
+				continue
 			}
 
-			if secret.Name == secretRef.Name && secret.Namespace == secretNS {
+			if ingress.ReferencesCACertificate(gateway, "ConfigMap", configMap.Namespace, configMap.Name) {
 				return getRoutes(ctx)
 			}
 		}
@@ -447,3 +864,31 @@ func (m *PingoraConfigMapper) MapSecretToRequests(
 		return nil
 	}
 }
+
+// isListenerCertificate reports whether a listener of one of our Gateways
+// terminates TLS with the Secret or validates client certificates with it.
+func (m *PingoraConfigMapper) isListenerCertificate(ctx context.Context, secret *corev1.Secret) bool {
+	var gatewayList gatewayv1.GatewayList
+	if err := m.Client.List(ctx, &gatewayList); err != nil {
+		return false
+	}
+
+	for i := range gatewayList.Items {
+		gateway := &gatewayList.Items[i]
+		if gateway.Spec.GatewayClassName != gatewayv1.ObjectName(m.GatewayClassName) {
+			continue
+		}
+
+		if ingress.ReferencesCACertificate(gateway, "Secret", secret.Namespace, secret.Name) {
+			return true
+		}
+
+		for _, ref := range ingress.ListenerCertificateRefs(gateway) {
+			if ref.Namespace == secret.Namespace && ref.Name == secret.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -3,28 +3,41 @@ package controller
 import (
 	"context"
 	"log/slog"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
 	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
 	pingoraingress "github.com/lexfrei/pingora-gateway-controller/internal/ingress"
 	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
 	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
 	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	"github.com/lexfrei/pingora-gateway-controller/internal/tracing"
 	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
 )
 
 const (
 	// apiErrorRequeueDelay is the delay before retrying when API errors occur.
 	apiErrorRequeueDelay = 30 * time.Second
+
+	// DefaultProxyVersionCheckInterval is the default interval for comparing
+	// the proxy's config version with the applied config.
+	DefaultProxyVersionCheckInterval = 30 * time.Second
 )
 
 // SyncResult holds the results of a route synchronization.
@@ -35,6 +48,11 @@ type SyncResult struct {
 	GRPCRouteBindings map[string]routeBindingInfo
 }
 
+// PostSyncVerifier checks the proxy data plane after a route config was applied.
+type PostSyncVerifier interface {
+	Verify(ctx context.Context) error
+}
+
 // routeBindingInfo holds binding validation results for a route.
 type routeBindingInfo struct {
 	bindingResults map[int]routebinding.BindingResult
@@ -49,24 +67,134 @@ type PingoraRouteSyncer struct {
 	client.Client
 
 	Scheme           *runtime.Scheme
-	ClusterDomain    string
+	ClusterDomain    *dns.ClusterDomainProvider
 	GatewayClassName string
 	ConfigResolver   *config.PingoraResolver
 	Metrics          metrics.Collector
 	Logger           *slog.Logger
 
-	builder          *pingoraingress.PingoraBuilder
-	bindingValidator *routebinding.Validator
+	// VersionCheckInterval is how often the proxy's config version is compared
+	// with the applied config while running as a manager.Runnable.
+	VersionCheckInterval time.Duration
+
+	// BindingDebugAnnotations enables writing per-parent binding results to
+	// the BindingDebugAnnotation on every evaluated route.
+	BindingDebugAnnotations bool
+
+	// RouteDiagnosticsAnnotations enables writing the programmed rules and
+	// dropped elements to the RouteDiagnosticsAnnotation of every synced route.
+	RouteDiagnosticsAnnotations bool
+
+	// RouteTrafficMetrics enables exporting the traffic the proxy counts per
+	// route and listener as metrics labeled with the routes.
+	RouteTrafficMetrics bool
+
+	// BackendTLSPolicies enables applying BackendTLSPolicies to backend
+	// Services. The BackendTLSPolicy CRD is not read otherwise.
+	BackendTLSPolicies bool
+
+	// MissingResources are the optional resources without a CRD. Without
+	// the GRPCRoute CRD only HTTPRoutes are synced.
+	MissingResources MissingResources
+
+	// Verifier, if set, sends a smoke test request through the proxy after
+	// every config the proxy applied.
+	Verifier PostSyncVerifier
+
+	// Recorder, if set, receives a CertificateInvalid Event on the
+	// PingoraConfig when its TLS certificates cannot be loaded.
+	Recorder record.EventRecorder
+
+	// Elected is closed once this replica is the leader, see
+	// manager.Manager.Elected. Syncs are skipped until then, so that only
+	// the leader writes to the proxy. Nil means always elected.
+	Elected <-chan struct{}
+
+	// DryRun builds and validates route configs and updates statuses and
+	// metrics, but never connects to the proxy or sends routes to it.
+	DryRun bool
+
+	// domain is the cluster domain of the GatewayClass, which the
+	// PingoraConfig may override.
+	domain *classClusterDomain
+
+	// Bindings caches the binding results of route parentRefs. The Gateway
+	// reconciler shares it to count attached routes.
+	Bindings *BindingCache
+
+	builder     *pingoraingress.PingoraBuilder
+	routes      routeCache
+	coordinator *SyncCoordinator
 
 	// gRPC connection state
 	connMu     sync.RWMutex
 	conn       *grpc.ClientConn
 	grpcClient routingv1.RoutingServiceClient
+	stream     *routeStream
 	configName string
 
-	// Version tracking for optimistic concurrency
+	// fanOut is the client of all proxy instances with spec.discovery set,
+	// and grpcClient then. conn and stream are nil in that case.
+	fanOut *fanOutClient
+
+	// credentials holds the TLS certificates of the connection, nil without
+	// TLS. Rotated certificates are loaded into it on every sync. It is
+	// closed with the connection.
+	credentials *config.TLSCredentials
+
+	// requestTimeout bounds every call to the proxy, so that a hung proxy
+	// cannot hold syncMu indefinitely.
+	requestTimeout time.Duration
+
+	// reconnect spaces out connect attempts while the proxy is unreachable.
+	reconnect reconnectBackoff
+
+	// Version tracking for optimistic concurrency.
+	// Restored on connect from the PingoraConfig status and the proxy.
 	version atomic.Uint64
 
+	// proxyVersion is the config version the proxy last reported as
+	// applied, sent as the expected version of route updates so that they
+	// are not applied on top of a config sent by another sync. Zero if
+	// unknown; updates are then applied regardless of the proxy version.
+	proxyVersion atomic.Uint64
+
+	// appliedMu protects appliedConfig, appliedGlobalConfig,
+	// appliedLoggingConfig, appliedCertificates and routeRefs.
+	appliedMu sync.RWMutex
+	// appliedConfig is the last configuration confirmed by the proxy.
+	// A zero value means nothing is known to be applied and the next sync is always sent.
+	appliedConfig AppliedConfig
+	// appliedGlobalConfig is the last global config accepted by the proxy,
+	// nil until the first update.
+	appliedGlobalConfig *routingv1.GlobalConfig
+	// appliedLoggingConfig is the last access log config accepted by the
+	// proxy, nil until the first update.
+	appliedLoggingConfig *routingv1.LoggingConfig
+	// appliedCertificates are the last listener certificates accepted by
+	// the proxy, nil until the first update.
+	appliedCertificates *routingv1.UpdateCertificatesRequest
+	// routeRefs maps the route IDs of the last config confirmed by the
+	// proxy to their routes, nil until the first confirmed sync.
+	routeRefs map[string]routeRef
+
+	// startupSynced is set once the proxy confirmed the first route sync.
+	startupSynced atomic.Bool
+
+	// stopped is set on shutdown. Later syncs are skipped, so that no new
+	// connection is opened.
+	stopped atomic.Bool
+
+	// takeover is set until the first connect restored the version counter.
+	// Every controller start takes over from a previous leader or process.
+	takeover atomic.Bool
+
+	// proxyVersions receives config versions from proxy health reports on the route stream.
+	proxyVersions chan uint64
+
+	// watchdog tracks sync progress for the liveness check.
+	watchdog syncWatchdog
+
 	// syncMu protects concurrent calls to SyncAllRoutes.
 	// Both HTTPRouteReconciler and GRPCRouteReconciler may call SyncAllRoutes
 	// concurrently, and this mutex ensures serialized access to gRPC calls.
@@ -77,10 +205,11 @@ type PingoraRouteSyncer struct {
 func NewPingoraRouteSyncer(
 	c client.Client,
 	scheme *runtime.Scheme,
-	clusterDomain string,
+	clusterDomain *dns.ClusterDomainProvider,
 	gatewayClassName string,
 	configResolver *config.PingoraResolver,
 	metricsCollector metrics.Collector,
+	syncDebounce time.Duration,
 	logger *slog.Logger,
 ) *PingoraRouteSyncer {
 	if logger == nil {
@@ -88,8 +217,9 @@ func NewPingoraRouteSyncer(
 	}
 
 	componentLogger := logger.With("component", "pingora-route-syncer")
+	domain := &classClusterDomain{global: clusterDomain}
 
-	return &PingoraRouteSyncer{
+	syncer := &PingoraRouteSyncer{
 		Client:           c,
 		Scheme:           scheme,
 		ClusterDomain:    clusterDomain,
@@ -97,9 +227,29 @@ func NewPingoraRouteSyncer(
 		ConfigResolver:   configResolver,
 		Metrics:          metricsCollector,
 		Logger:           componentLogger,
-		builder:          pingoraingress.NewPingoraBuilder(clusterDomain),
-		bindingValidator: routebinding.NewValidator(c),
+		domain:           domain,
+		builder:          pingoraingress.NewPingoraBuilderWithSource(domain),
+		Bindings:         NewBindingCache(routebinding.NewValidator(c)),
+		proxyVersions:    make(chan uint64, 1),
 	}
+	syncer.takeover.Store(true)
+	syncer.coordinator = NewSyncCoordinator(syncer.SyncAllRoutes, syncDebounce, metricsCollector)
+	clusterDomain.OnChange(syncer.onClusterDomainChange)
+
+	return syncer
+}
+
+// SetRouteIDScheme selects how route IDs sent to the proxy are derived.
+// It must be called before the first sync.
+func (s *PingoraRouteSyncer) SetRouteIDScheme(scheme pingoraingress.RouteIDScheme) {
+	s.builder.SetRouteIDScheme(scheme)
+}
+
+// SetRequireParentRefGrants makes routes attach to Gateways in other
+// namespaces only if a ReferenceGrant permits it. It must be called before
+// the first sync.
+func (s *PingoraRouteSyncer) SetRequireParentRefGrants(require bool) {
+	s.Bindings.Validator().RequireParentRefGrants = require
 }
 
 // Connect establishes a gRPC connection to the Pingora proxy.
@@ -108,10 +258,8 @@ func (s *PingoraRouteSyncer) Connect(ctx context.Context) error {
 	defer s.connMu.Unlock()
 
 	// Close existing connection if any
-	if s.conn != nil {
-		if err := s.conn.Close(); err != nil {
-			s.Logger.Error("failed to close existing connection", "error", err)
-		}
+	if err := s.closeConnection(); err != nil {
+		s.Logger.Error("failed to close existing connection", "error", err)
 	}
 
 	// Resolve config
@@ -121,34 +269,178 @@ func (s *PingoraRouteSyncer) Connect(ctx context.Context) error {
 	}
 
 	// Create new connection
-	conn, err := s.ConfigResolver.CreateGRPCConnection(ctx, resolved)
+	credentials := &config.TLSCredentials{}
+
+	if resolved.DiscoveryEnabled {
+		err = s.connectInstances(ctx, resolved, credentials)
+	} else {
+		err = s.connectProxy(ctx, resolved, credentials)
+	}
+
+	if err != nil {
+		_ = credentials.Close()
+
+		return err
+	}
+
+	if resolved.TLSEnabled {
+		s.credentials = credentials
+	}
+
+	s.configName = resolved.ConfigName
+	s.requestTimeout = resolved.RequestTimeout
+
+	// A new connection may point to a restarted proxy, so always resend the config.
+	s.resetAppliedConfig()
+
+	// The counter starts at zero after a restart or leader failover
+	s.restoreVersion(ctx, resolved.ConfigName, s.grpcClient)
+
+	if s.fanOut != nil {
+		s.Logger.Info("connected to Pingora proxy instances",
+			"address", resolved.Address, "instances", s.fanOut.addresses())
+
+		return nil
+	}
+
+	s.Logger.Info("connected to Pingora proxy", "address", resolved.Address)
+
+	return nil
+}
+
+// connectProxy connects to the single proxy at the address of resolved.
+// Must be called with connMu held.
+func (s *PingoraRouteSyncer) connectProxy(
+	ctx context.Context,
+	resolved *config.ResolvedPingoraConfig,
+	credentials *config.TLSCredentials,
+) error {
+	conn, err := s.ConfigResolver.CreateGRPCConnectionWithCredentials(ctx, resolved, credentials)
 	if err != nil {
 		return errors.Wrap(err, "failed to create gRPC connection")
 	}
 
 	s.conn = conn
 	s.grpcClient = s.ConfigResolver.CreateRoutingClient(conn)
-	s.configName = resolved.ConfigName
+	s.stream = newRouteStream(s.grpcClient, s.Logger, s.reportProxyHealth)
 
-	s.Logger.Info("connected to Pingora proxy", "address", resolved.Address)
+	return nil
+}
+
+// connectInstances connects to every proxy instance found by the discovery
+// settings of resolved. The instances share the TLS credentials.
+// Must be called with connMu held.
+func (s *PingoraRouteSyncer) connectInstances(
+	ctx context.Context,
+	resolved *config.ResolvedPingoraConfig,
+	credentials *config.TLSCredentials,
+) error {
+	instances, err := s.ConfigResolver.ResolveInstances(ctx, resolved)
+	if err != nil {
+		return errors.Wrap(err, "failed to discover proxy instances")
+	}
+
+	addresses := make([]string, 0, len(instances))
+	conns := make([]*grpc.ClientConn, 0, len(instances))
+
+	for _, instance := range instances {
+		conn, connErr := s.ConfigResolver.CreateGRPCConnectionWithCredentials(ctx, instance, credentials)
+		if connErr != nil {
+			for _, opened := range conns {
+				_ = opened.Close()
+			}
+
+			return errors.Wrapf(connErr, "failed to create gRPC connection to %s", instance.Address)
+		}
+
+		addresses = append(addresses, instance.Address)
+		conns = append(conns, conn)
+	}
+
+	s.fanOut = newFanOutClient(addresses, conns, s.ConfigResolver.CreateRoutingClient, s.Logger, s.reportProxyHealth)
+	s.grpcClient = s.fanOut
 
 	return nil
 }
 
+// proxyInstancesChanged reports whether the discovered proxy instances
+// differ from the connected ones. Always false without discovery.
+func (s *PingoraRouteSyncer) proxyInstancesChanged(ctx context.Context) bool {
+	s.connMu.RLock()
+	fanOut := s.fanOut
+	s.connMu.RUnlock()
+
+	if fanOut == nil {
+		return false
+	}
+
+	resolved, err := s.ConfigResolver.ResolveFromGatewayClassName(ctx, s.GatewayClassName)
+	if err != nil {
+		s.Logger.Debug("failed to resolve Pingora config", "error", err)
+
+		return false
+	}
+
+	instances, err := s.ConfigResolver.ResolveInstances(ctx, resolved)
+	if err != nil {
+		// Keep the connected instances until discovery works again
+		s.Logger.Debug("failed to discover proxy instances", "error", err)
+
+		return false
+	}
+
+	addresses := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		addresses = append(addresses, instance.Address)
+	}
+
+	return !slices.Equal(addresses, fanOut.addresses())
+}
+
+// checkProxyInstances resyncs all routes if proxy instances were added or
+// removed, so that new instances receive the routes.
+func (s *PingoraRouteSyncer) checkProxyInstances(ctx context.Context) {
+	if !s.proxyInstancesChanged(ctx) {
+		return
+	}
+
+	if _, _, err := s.RequestSync(ctx); err != nil {
+		s.Logger.Error("route sync after proxy instance change failed", "error", err)
+	}
+}
+
 // Close closes the gRPC connection.
 func (s *PingoraRouteSyncer) Close() error {
 	s.connMu.Lock()
 	defer s.connMu.Unlock()
 
+	return s.closeConnection()
+}
+
+// closeConnection closes the streams, connections and TLS credentials of
+// the proxy. Must be called with connMu held.
+func (s *PingoraRouteSyncer) closeConnection() error {
+	var err error
+
+	if s.stream != nil {
+		s.stream.Close()
+		s.stream = nil
+	}
+
 	if s.conn != nil {
-		err := s.conn.Close()
+		err = s.conn.Close()
 		s.conn = nil
-		s.grpcClient = nil
+	}
 
-		return err //nolint:wrapcheck // simple close error
+	if s.fanOut != nil {
+		err = errors.CombineErrors(err, s.fanOut.close())
+		s.fanOut = nil
 	}
 
-	return nil
+	s.grpcClient = nil
+	s.closeCredentials()
+
+	return err //nolint:wrapcheck // simple close error
 }
 
 // IsConnected returns whether a connection is established.
@@ -159,16 +451,68 @@ func (s *PingoraRouteSyncer) IsConnected() bool {
 	return s.grpcClient != nil
 }
 
+// RequestSync requests a synchronization of all routes and waits for its result.
+// Requests arriving within the debounce interval are coalesced into a single sync.
+func (s *PingoraRouteSyncer) RequestSync(ctx context.Context) (ctrl.Result, *SyncResult, error) {
+	if s.coordinator == nil {
+		return s.SyncAllRoutes(ctx)
+	}
+
+	return s.coordinator.Sync(ctx)
+}
+
+// onClusterDomainChange resyncs all routes so that backend addresses use the new domain.
+func (s *PingoraRouteSyncer) onClusterDomainChange(ctx context.Context, domain string) {
+	s.Logger.Info("cluster domain changed, resyncing all routes", "clusterDomain", domain)
+
+	s.resetAppliedConfig()
+
+	if _, _, err := s.RequestSync(ctx); err != nil {
+		s.Logger.Error("failed to resync routes after cluster domain change", "error", err)
+	}
+}
+
+// clusterDomain returns the cluster domain used for backend addresses.
+func (s *PingoraRouteSyncer) clusterDomain() string {
+	if s.domain != nil {
+		return s.domain.ClusterDomain()
+	}
+
+	if s.ClusterDomain == nil {
+		return ""
+	}
+
+	return s.ClusterDomain.ClusterDomain()
+}
+
 // SyncAllRoutes synchronizes all HTTPRoute and GRPCRoute resources to Pingora proxy.
-//
-//nolint:funlen // complex sync logic requires length
 func (s *PingoraRouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResult, error) {
+	ctx, span := tracing.Start(ctx, "PingoraRouteSyncer.SyncAllRoutes")
+
+	result, syncResult, err := s.syncAllRoutes(ctx)
+	tracing.End(span, err)
+
+	return result, syncResult, err
+}
+
+//nolint:funlen // complex sync logic requires length
+func (s *PingoraRouteSyncer) syncAllRoutes(ctx context.Context) (ctrl.Result, *SyncResult, error) {
 	// Serialize concurrent sync calls to prevent race conditions when
 	// both HTTPRouteReconciler and GRPCRouteReconciler trigger syncs.
+	lockStart := time.Now()
+
 	s.syncMu.Lock()
 	defer s.syncMu.Unlock()
 
+	if s.stopped.Load() || !s.isLeader() {
+		return ctrl.Result{}, nil, nil
+	}
+
 	startTime := time.Now()
+	s.Metrics.RecordSyncLockWait(ctx, startTime.Sub(lockStart))
+
+	s.watchdog.locked(startTime)
+	defer func() { s.watchdog.unlocked(time.Now()) }()
 
 	// Prefer context logger (with reconcile ID) over struct logger
 	logger := logging.FromContext(ctx)
@@ -176,86 +520,154 @@ func (s *PingoraRouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *S
 		logger = s.Logger
 	}
 
-	// Ensure we're connected
-	if !s.IsConnected() {
+	// Reconnect if proxy instances were added or removed
+	if s.proxyInstancesChanged(ctx) {
+		logger.Info("proxy instances changed, reconnecting")
+
+		if err := s.Close(); err != nil {
+			logger.Debug("failed to close proxy connections", "error", err)
+		}
+	}
+
+	// Ensure we're connected, dry runs only validate the config
+	if s.DryRun {
+		if err := s.resolveDryRunConfig(ctx); err != nil {
+			logger.Error("failed to resolve Pingora config", "error", err)
+			s.Metrics.RecordSyncDuration(ctx, "error", time.Since(startTime))
+			s.Metrics.RecordSyncError(ctx, "invalid_config")
+			s.recordSyncAttempt(ctx, syncAttempt{dryRun: true, err: err})
+
+			return ctrl.Result{RequeueAfter: apiErrorRequeueDelay}, nil, nil
+		}
+	} else if !s.IsConnected() {
+		if wait := s.reconnect.wait(); wait > 0 {
+			logger.Debug("waiting to reconnect to Pingora proxy", "wait", wait)
+
+			return ctrl.Result{RequeueAfter: wait}, nil, nil
+		}
+
 		if err := s.Connect(ctx); err != nil {
-			logger.Error("failed to connect to Pingora proxy", "error", err)
+			delay := s.reconnect.failure()
+			s.recordConnectionState(ctx)
+
+			logger.Error("failed to connect to Pingora proxy", "error", err, "retryIn", delay)
 			s.Metrics.RecordSyncDuration(ctx, "error", time.Since(startTime))
 			s.Metrics.RecordSyncError(ctx, "connection_failed")
+			s.recordSyncAttempt(ctx, syncAttempt{err: err})
 
-			return ctrl.Result{RequeueAfter: apiErrorRequeueDelay}, nil, nil
+			if errors.Is(err, config.ErrInvalidCertificate) {
+				s.recordCertificateInvalid(ctx, err)
+			}
+
+			return ctrl.Result{RequeueAfter: delay}, nil, nil
 		}
+
+		s.reconnect.success()
+		s.recordConnectionState(ctx)
 	}
 
-	// Collect all relevant HTTPRoutes with binding validation
-	httpRoutes, httpBindings, err := s.getRelevantHTTPRoutes(ctx)
+	s.refreshCredentials(ctx, logger)
+	s.refreshClusterDomain(ctx, logger)
+	s.syncGlobalConfig(ctx, logger)
+	s.syncLoggingConfig(ctx, logger)
+	s.syncCertificates(ctx, logger)
+
+	built, err := s.buildRoutes(ctx, logger)
 	if err != nil {
-		return ctrl.Result{}, nil, errors.Wrap(err, "failed to list httproutes")
+		return ctrl.Result{}, nil, err
 	}
 
-	// Collect all relevant GRPCRoutes with binding validation
-	grpcRoutes, grpcBindings, err := s.getRelevantGRPCRoutes(ctx)
-	if err != nil {
-		return ctrl.Result{}, nil, errors.Wrap(err, "failed to list grpcroutes")
+	httpRoutes, grpcRoutes := built.httpRoutes, built.grpcRoutes
+	httpBindings, grpcBindings := built.httpBindings, built.grpcBindings
+	pingoraHTTPRoutes, pingoraGRPCRoutes := built.pingoraHTTPRoutes, built.pingoraGRPCRoutes
+	listeners := built.listeners
+
+	configHash, hashErr := hashRouteConfig(pingoraHTTPRoutes, pingoraGRPCRoutes, listeners)
+	if hashErr != nil {
+		logger.Error("failed to hash route config, syncing unconditionally", "error", hashErr)
 	}
 
-	logger.Info("syncing routes to Pingora",
-		"httpRoutes", len(httpRoutes),
-		"grpcRoutes", len(grpcRoutes),
-	)
+	if hashErr == nil && s.isConfigApplied(ctx, configHash) {
+		logger.Debug("route config unchanged, skipping sync", "hash", configHash)
+		trace.SpanFromContext(ctx).AddEvent("route config unchanged, sync skipped")
+		s.Metrics.RecordSyncSkipped(ctx)
+		s.recordSyncAttempt(ctx, syncAttempt{connected: !s.DryRun, dryRun: s.DryRun})
+		s.recordSyncConfirmed(ctx, logger, built)
 
-	// Build Pingora route configurations
-	pingoraHTTPRoutes := make([]*routingv1.HTTPRoute, 0, len(httpRoutes))
-	for i := range httpRoutes {
-		pingoraHTTPRoutes = append(pingoraHTTPRoutes, s.builder.BuildHTTPRoute(&httpRoutes[i]))
+		return ctrl.Result{}, &SyncResult{
+			HTTPRoutes:        httpRoutes,
+			GRPCRoutes:        grpcRoutes,
+			HTTPRouteBindings: httpBindings,
+			GRPCRouteBindings: grpcBindings,
+		}, nil
 	}
 
-	pingoraGRPCRoutes := make([]*routingv1.GRPCRoute, 0, len(grpcRoutes))
-	for i := range grpcRoutes {
-		pingoraGRPCRoutes = append(pingoraGRPCRoutes, s.builder.BuildGRPCRoute(&grpcRoutes[i]))
+	if s.DryRun {
+		s.recordDryRun(ctx, logger, AppliedConfig{
+			Hash:           configHash,
+			AppliedAt:      time.Now(),
+			HTTPRouteCount: len(pingoraHTTPRoutes),
+			GRPCRouteCount: len(pingoraGRPCRoutes),
+		})
+
+		s.Metrics.RecordSyncDuration(ctx, "success", time.Since(startTime))
+		s.Metrics.RecordSyncedRoutes(ctx, "http", len(httpRoutes))
+		s.Metrics.RecordSyncedRoutes(ctx, "grpc", len(grpcRoutes))
+		s.recordSyncConfirmed(ctx, logger, built)
+
+		return ctrl.Result{}, &SyncResult{
+			HTTPRoutes:        httpRoutes,
+			GRPCRoutes:        grpcRoutes,
+			HTTPRouteBindings: httpBindings,
+			GRPCRouteBindings: grpcBindings,
+		}, nil
 	}
 
 	// Send routes to Pingora via gRPC
-	version := s.version.Add(1)
-
 	req := &routingv1.UpdateRoutesRequest{
 		HttpRoutes: pingoraHTTPRoutes,
 		GrpcRoutes: pingoraGRPCRoutes,
-		Version:    version,
+		Version:    s.version.Add(1),
+		Listeners:  listeners,
 	}
 
 	s.connMu.RLock()
 	grpcClient := s.grpcClient
+	stream := s.stream
+	requestTimeout := s.requestTimeout
 	s.connMu.RUnlock()
 
 	if grpcClient == nil {
 		logger.Error("gRPC client is nil")
 		s.Metrics.RecordSyncDuration(ctx, "error", time.Since(startTime))
 		s.Metrics.RecordSyncError(ctx, "not_connected")
+		s.recordSyncAttempt(ctx, syncAttempt{err: errors.New("not connected to Pingora proxy")})
 
 		return ctrl.Result{RequeueAfter: apiErrorRequeueDelay}, nil, nil
 	}
 
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
 	grpcStart := time.Now()
-	resp, err := grpcClient.UpdateRoutes(ctx, req)
+	resp, method, err := s.updateRoutes(rpcCtx, logger, grpcClient, stream, req)
 	grpcDuration := time.Since(grpcStart)
 
+	cancel()
+
 	if err != nil {
-		s.Metrics.RecordGRPCCall(ctx, "UpdateRoutes", "error", grpcDuration)
+		s.Metrics.RecordGRPCCall(ctx, method, "error", grpcDuration)
 		s.Metrics.RecordSyncDuration(ctx, "error", time.Since(startTime))
 		s.Metrics.RecordSyncError(ctx, "grpc_error")
 		logger.Error("failed to update routes via gRPC", "error", err)
 
 		// Try to reconnect on next sync
 		s.connMu.Lock()
-
-		if s.conn != nil {
-			_ = s.conn.Close()
-			s.conn = nil
-			s.grpcClient = nil
-		}
-
+		_ = s.closeConnection()
 		s.connMu.Unlock()
+		s.resetAppliedConfig()
+		s.recordConnectionState(ctx)
+
+		// The proxy may have applied the update before the call failed
+		s.recordSyncAttempt(ctx, syncAttempt{version: req.GetVersion(), err: err})
 
 		result := &SyncResult{
 			HTTPRoutes:        httpRoutes,
@@ -268,10 +680,15 @@ func (s *PingoraRouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *S
 	}
 
 	if !resp.GetSuccess() {
-		s.Metrics.RecordGRPCCall(ctx, "UpdateRoutes", "failed", grpcDuration)
+		s.Metrics.RecordGRPCCall(ctx, method, "failed", grpcDuration)
 		s.Metrics.RecordSyncDuration(ctx, "error", time.Since(startTime))
 		s.Metrics.RecordSyncError(ctx, "update_failed")
 		logger.Error("route update failed", "error", resp.GetError())
+		s.recordSyncAttempt(ctx, syncAttempt{
+			connected: true,
+			version:   req.GetVersion(),
+			err:       errors.Newf("proxy rejected route update: %s", resp.GetError()),
+		})
 
 		result := &SyncResult{
 			HTTPRoutes:        httpRoutes,
@@ -284,17 +701,29 @@ func (s *PingoraRouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *S
 		return ctrl.Result{RequeueAfter: apiErrorRequeueDelay}, result, errors.Newf("route update failed: %s", resp.GetError())
 	}
 
-	s.Metrics.RecordGRPCCall(ctx, "UpdateRoutes", "success", grpcDuration)
+	s.Metrics.RecordGRPCCall(ctx, method, "success", grpcDuration)
 	logger.Info("successfully updated routes in Pingora",
 		"httpRouteCount", resp.GetHttpRouteCount(),
 		"grpcRouteCount", resp.GetGrpcRouteCount(),
 		"version", resp.GetAppliedVersion(),
 	)
 
+	s.setAppliedConfig(AppliedConfig{
+		Hash:           configHash,
+		Version:        resp.GetAppliedVersion(),
+		AppliedAt:      time.Now(),
+		HTTPRouteCount: len(pingoraHTTPRoutes),
+		GRPCRouteCount: len(pingoraGRPCRoutes),
+	})
+	s.verifyDataPlane(ctx)
+
+	s.recordSyncAttempt(ctx, syncAttempt{connected: true, applied: true, version: req.GetVersion()})
+
 	// Record success metrics
 	s.Metrics.RecordSyncDuration(ctx, "success", time.Since(startTime))
 	s.Metrics.RecordSyncedRoutes(ctx, "http", len(httpRoutes))
 	s.Metrics.RecordSyncedRoutes(ctx, "grpc", len(grpcRoutes))
+	s.recordSyncConfirmed(ctx, logger, built)
 
 	result := &SyncResult{
 		HTTPRoutes:        httpRoutes,
@@ -306,6 +735,163 @@ func (s *PingoraRouteSyncer) SyncAllRoutes(ctx context.Context) (ctrl.Result, *S
 	return ctrl.Result{}, result, nil
 }
 
+// recordSyncConfirmed records that the proxy runs the built configuration,
+// either because it just applied it or because it already had it.
+func (s *PingoraRouteSyncer) recordSyncConfirmed(ctx context.Context, logger *slog.Logger, built *builtRoutes) {
+	s.Metrics.RecordLastSuccessfulSync(ctx, time.Now())
+	s.Metrics.RecordRouteGenerations(ctx, "http", httpRouteGenerations(built.httpRoutes))
+	s.Metrics.RecordRouteGenerations(ctx, "grpc", grpcRouteGenerations(built.grpcRoutes))
+
+	if s.RouteTrafficMetrics {
+		s.setRouteRefs(builtRouteRefs(built))
+	}
+
+	if s.RouteDiagnosticsAnnotations {
+		s.annotateRouteDiagnostics(ctx, logger, built)
+	}
+}
+
+// classGateways returns the Gateways of our GatewayClass.
+func (s *PingoraRouteSyncer) classGateways(ctx context.Context) ([]gatewayv1.Gateway, error) {
+	var gatewayList gatewayv1.GatewayList
+	if err := s.List(ctx, &gatewayList); err != nil {
+		return nil, errors.Wrap(err, "failed to list gateways")
+	}
+
+	gateways := make([]gatewayv1.Gateway, 0, len(gatewayList.Items))
+
+	for i := range gatewayList.Items {
+		if gatewayList.Items[i].Spec.GatewayClassName == gatewayv1.ObjectName(s.GatewayClassName) {
+			gateways = append(gateways, gatewayList.Items[i])
+		}
+	}
+
+	return gateways, nil
+}
+
+// buildListeners returns the listener settings of the given Gateways of our
+// GatewayClass from the PingoraTrafficPolicies and the given
+// PingoraAccessControlPolicies attached to them, with HTTP/3 on their HTTPS
+// listeners if the PingoraConfig enables it and HTTPS redirects on the HTTP
+// listeners of Gateways that ask for them.
+func (s *PingoraRouteSyncer) buildListeners(
+	ctx context.Context,
+	gateways []gatewayv1.Gateway,
+	accessPolicies []v1alpha1.PingoraAccessControlPolicy,
+) ([]*routingv1.Listener, error) {
+	var policies v1alpha1.PingoraTrafficPolicyList
+	if err := s.List(ctx, &policies); err != nil {
+		return nil, errors.Wrap(err, "failed to list traffic policies")
+	}
+
+	http3, err := s.http3Config(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners := pingoraingress.BuildListeners(gateways, policies.Items, accessPolicies)
+	listeners = pingoraingress.EnableHTTP3(listeners, gateways, http3)
+
+	return pingoraingress.EnableHTTPSRedirects(listeners, gateways), nil
+}
+
+// http3Config returns the HTTP/3 config of the PingoraConfig of our
+// GatewayClass, nil if it has none.
+func (s *PingoraRouteSyncer) http3Config(ctx context.Context) (*v1alpha1.HTTP3Config, error) {
+	pingoraConfig, err := s.pingoraConfigForClass(ctx)
+	if err != nil || pingoraConfig == nil || pingoraConfig.Spec.Defaults == nil {
+		return nil, err
+	}
+
+	return pingoraConfig.Spec.Defaults.HTTP3, nil
+}
+
+// verifyDataPlane runs the post-sync smoke test in the background,
+// so a slow or broken data plane never delays route syncs.
+// The verifier records metrics and logs failures itself.
+func (s *PingoraRouteSyncer) verifyDataPlane(ctx context.Context) {
+	if s.Verifier == nil {
+		return
+	}
+
+	verifyCtx := context.WithoutCancel(ctx)
+
+	go func() {
+		_ = s.Verifier.Verify(verifyCtx)
+	}()
+}
+
+// updateRoutes sends the route update, expecting the proxy to run the
+// version it last reported. If the proxy applied another version in the
+// meantime, e.g. a sync of a previous leader that arrived late, the version
+// counter is raised above the proxy version and the update is sent once
+// more with the refreshed expected version, as long as this replica is
+// still the leader.
+func (s *PingoraRouteSyncer) updateRoutes(
+	ctx context.Context,
+	logger *slog.Logger,
+	grpcClient routingv1.RoutingServiceClient,
+	stream *routeStream,
+	req *routingv1.UpdateRoutesRequest,
+) (*routingv1.UpdateRoutesResponse, string, error) {
+	for retried := false; ; retried = true {
+		s.expectProxyVersion(req)
+
+		resp, method, err := s.sendRoutes(ctx, grpcClient, stream, req)
+		if err != nil {
+			return nil, method, err
+		}
+
+		if resp.GetSuccess() || resp.GetVersionConflict() {
+			s.proxyVersion.Store(resp.GetAppliedVersion())
+		}
+
+		if !resp.GetVersionConflict() || retried || !s.isLeader() {
+			return resp, method, nil
+		}
+
+		s.Metrics.RecordSyncError(ctx, "version_conflict")
+		logger.Warn("proxy applied another config version, retrying route update",
+			"expectedVersion", req.GetExpectedVersion(),
+			"proxyVersion", resp.GetAppliedVersion(),
+		)
+
+		s.advanceVersion(resp.GetAppliedVersion())
+		req.Version = s.version.Add(1)
+	}
+}
+
+// sendRoutes pushes the route update over the StreamRoutes stream and falls back
+// to the unary UpdateRoutes call when the proxy does not support streaming.
+// Returns the method used, for metrics.
+func (s *PingoraRouteSyncer) sendRoutes(
+	ctx context.Context,
+	grpcClient routingv1.RoutingServiceClient,
+	stream *routeStream,
+	req *routingv1.UpdateRoutesRequest,
+) (*routingv1.UpdateRoutesResponse, string, error) {
+	if stream != nil {
+		resp, err := stream.Send(ctx, req)
+		if !errors.Is(err, errStreamUnsupported) {
+			return resp, methodStreamRoutes, err
+		}
+	}
+
+	resp, err := grpcClient.UpdateRoutes(ctx, req)
+
+	return resp, methodUpdateRoutes, err //nolint:wrapcheck // wrapped by caller
+}
+
+// withRequestTimeout bounds a call to the proxy by the request timeout of the
+// PingoraConfig, including retries. A zero timeout leaves ctx unbounded.
+func withRequestTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
 //nolint:funlen,dupl // complex binding validation logic; similar to GRPC but for HTTP types
 func (s *PingoraRouteSyncer) getRelevantHTTPRoutes(
 	ctx context.Context,
@@ -327,9 +913,18 @@ func (s *PingoraRouteSyncer) getRelevantHTTPRoutes(
 
 	bindings := make(map[string]routeBindingInfo)
 
+	live := make(map[types.NamespacedName]bool, len(routeList.Items))
+
 	for i := range routeList.Items {
 		route := &routeList.Items[i]
 		routeKey := route.Namespace + "/" + route.Name
+		live[client.ObjectKeyFromObject(route)] = true
+
+		// Routes being deleted are no longer programmed
+		if !route.DeletionTimestamp.IsZero() {
+			continue
+		}
+
 		bindingInfo := routeBindingInfo{
 			bindingResults: make(map[int]routebinding.BindingResult),
 		}
@@ -365,7 +960,7 @@ func (s *PingoraRouteSyncer) getRelevantHTTPRoutes(
 				SectionName: ref.SectionName,
 			}
 
-			result, bindErr := s.bindingValidator.ValidateBinding(ctx, &gateway, routeInfo)
+			result, bindErr := s.Bindings.Binding(ctx, &gateway, route, refIdx, routeInfo)
 			if bindErr != nil {
 				logger.Error("failed to validate route binding",
 					"route", routeKey,
@@ -384,11 +979,17 @@ func (s *PingoraRouteSyncer) getRelevantHTTPRoutes(
 
 		bindings[routeKey] = bindingInfo
 
+		if s.BindingDebugAnnotations && len(bindingInfo.bindingResults) > 0 {
+			s.annotateBindingDebug(ctx, logger, route, route.Spec.ParentRefs, bindingInfo)
+		}
+
 		if hasAcceptedBinding {
 			relevantRoutes = append(relevantRoutes, routeList.Items[i])
 		}
 	}
 
+	s.Bindings.Retain(routebinding.KindHTTPRoute, live)
+
 	return relevantRoutes, bindings, nil
 }
 
@@ -402,6 +1003,10 @@ func (s *PingoraRouteSyncer) getRelevantGRPCRoutes(
 		logger = s.Logger
 	}
 
+	if s.MissingResources.Has(OptionalResourceGRPCRoute) {
+		return nil, make(map[string]routeBindingInfo), nil
+	}
+
 	var routeList gatewayv1.GRPCRouteList
 
 	err := s.List(ctx, &routeList)
@@ -413,9 +1018,18 @@ func (s *PingoraRouteSyncer) getRelevantGRPCRoutes(
 
 	bindings := make(map[string]routeBindingInfo)
 
+	live := make(map[types.NamespacedName]bool, len(routeList.Items))
+
 	for i := range routeList.Items {
 		route := &routeList.Items[i]
 		routeKey := route.Namespace + "/" + route.Name
+		live[client.ObjectKeyFromObject(route)] = true
+
+		// Routes being deleted are no longer programmed
+		if !route.DeletionTimestamp.IsZero() {
+			continue
+		}
+
 		bindingInfo := routeBindingInfo{
 			bindingResults: make(map[int]routebinding.BindingResult),
 		}
@@ -451,7 +1065,7 @@ func (s *PingoraRouteSyncer) getRelevantGRPCRoutes(
 				SectionName: ref.SectionName,
 			}
 
-			result, bindErr := s.bindingValidator.ValidateBinding(ctx, &gateway, routeInfo)
+			result, bindErr := s.Bindings.Binding(ctx, &gateway, route, refIdx, routeInfo)
 			if bindErr != nil {
 				logger.Error("failed to validate route binding",
 					"route", routeKey,
@@ -470,11 +1084,17 @@ func (s *PingoraRouteSyncer) getRelevantGRPCRoutes(
 
 		bindings[routeKey] = bindingInfo
 
+		if s.BindingDebugAnnotations && len(bindingInfo.bindingResults) > 0 {
+			s.annotateBindingDebug(ctx, logger, route, route.Spec.ParentRefs, bindingInfo)
+		}
+
 		if hasAcceptedBinding {
 			relevantRoutes = append(relevantRoutes, routeList.Items[i])
 		}
 	}
 
+	s.Bindings.Retain(routebinding.KindGRPCRoute, live)
+
 	return relevantRoutes, bindings, nil
 }
 
@@ -490,3 +1110,286 @@ func (s *PingoraRouteSyncer) GetConfigName() string {
 func (s *PingoraRouteSyncer) GetVersion() uint64 {
 	return s.version.Load()
 }
+
+// GetAppliedConfig returns the last route configuration confirmed by the proxy.
+func (s *PingoraRouteSyncer) GetAppliedConfig() AppliedConfig {
+	s.appliedMu.RLock()
+	defer s.appliedMu.RUnlock()
+
+	return s.appliedConfig
+}
+
+// isConfigApplied reports whether the config with the given hash is already active
+// in the proxy. Besides comparing hashes, it asks the proxy for its current version,
+// so a proxy that restarted behind a live connection still gets the config resent.
+func (s *PingoraRouteSyncer) isConfigApplied(ctx context.Context, configHash string) bool {
+	applied := s.GetAppliedConfig()
+	if applied.Hash == "" || applied.Hash != configHash {
+		return false
+	}
+
+	// Dry runs have no proxy to ask
+	if s.DryRun {
+		return true
+	}
+
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		return false
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.Health(rpcCtx, &routingv1.HealthRequest{})
+	grpcDuration := time.Since(grpcStart)
+
+	if err != nil {
+		s.Metrics.RecordGRPCCall(ctx, "Health", "error", grpcDuration)
+
+		return false
+	}
+
+	s.Metrics.RecordGRPCCall(ctx, "Health", "success", grpcDuration)
+
+	return resp.GetConfigVersion() == applied.Version
+}
+
+func (s *PingoraRouteSyncer) setAppliedConfig(applied AppliedConfig) {
+	s.appliedMu.Lock()
+	defer s.appliedMu.Unlock()
+
+	s.appliedConfig = applied
+
+	if applied.Hash != "" {
+		s.startupSynced.Store(true)
+	}
+}
+
+func (s *PingoraRouteSyncer) resetAppliedConfig() {
+	s.setAppliedConfig(AppliedConfig{})
+
+	s.appliedMu.Lock()
+	s.appliedGlobalConfig = nil
+	s.appliedLoggingConfig = nil
+	s.appliedCertificates = nil
+	s.appliedMu.Unlock()
+}
+
+// Start implements manager.Runnable. It periodically compares the proxy's config
+// version with the applied config and forces a full resync when the proxy lags
+// behind, e.g. after a proxy restart, and collects the health of backends.
+// Health reports received on the route stream are checked immediately.
+// Routes are synced once on start, and on every check until the first
+// sync succeeds. When ctx is cancelled on manager shutdown, the connection
+// to the proxy is closed after the sync in flight.
+func (s *PingoraRouteSyncer) Start(ctx context.Context) error {
+	defer s.shutdown(context.WithoutCancel(ctx))
+
+	if !s.StartupSynced() {
+		s.syncOnStartup(ctx)
+	}
+
+	if s.VersionCheckInterval <= 0 {
+		<-ctx.Done()
+
+		return nil
+	}
+
+	ticker := time.NewTicker(s.VersionCheckInterval)
+	defer ticker.Stop()
+
+	s.watchdog.progressed(time.Now())
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.watchdog.progressed(time.Now())
+
+			if !s.StartupSynced() {
+				s.syncOnStartup(ctx)
+			}
+
+			s.checkProxyInstances(ctx)
+			s.checkProxyVersion(ctx)
+			s.checkBackendHealth(ctx)
+			s.checkRouteTraffic(ctx)
+		case version := <-s.proxyVersions:
+			s.handleProxyVersion(ctx, version)
+		}
+	}
+}
+
+// checkProxyVersion asks the proxy for its config version and resyncs if it lags.
+func (s *PingoraRouteSyncer) checkProxyVersion(ctx context.Context) {
+	if s.GetAppliedConfig().Hash == "" {
+		// Nothing applied yet, the next sync sends the full config anyway
+		return
+	}
+
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		return
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.Health(rpcCtx, &routingv1.HealthRequest{})
+	grpcDuration := time.Since(grpcStart)
+
+	if err != nil {
+		s.Metrics.RecordGRPCCall(ctx, "Health", "error", grpcDuration)
+		s.Logger.Debug("proxy version check failed", "error", err)
+
+		return
+	}
+
+	s.Metrics.RecordGRPCCall(ctx, "Health", "success", grpcDuration)
+	s.handleProxyVersion(ctx, resp.GetConfigVersion())
+}
+
+// checkBackendHealth asks the proxy for the health of health checked backends
+// and records it in metrics.
+func (s *PingoraRouteSyncer) checkBackendHealth(ctx context.Context) {
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		return
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.GetBackendHealth(rpcCtx, &routingv1.GetBackendHealthRequest{})
+	grpcDuration := time.Since(grpcStart)
+
+	if status.Code(err) == codes.Unimplemented {
+		// Proxies without health checking have nothing to report
+		return
+	}
+
+	if err != nil {
+		s.Metrics.RecordGRPCCall(ctx, "GetBackendHealth", "error", grpcDuration)
+		s.Logger.Debug("backend health check failed", "error", err)
+
+		return
+	}
+
+	s.Metrics.RecordGRPCCall(ctx, "GetBackendHealth", "success", grpcDuration)
+
+	backends := make([]metrics.BackendHealth, 0, len(resp.GetBackends()))
+	for _, backend := range resp.GetBackends() {
+		backends = append(backends, metrics.BackendHealth{
+			Backend:   backend.GetAddress(),
+			Healthy:   int(backend.GetHealthyEndpoints()),
+			Unhealthy: int(backend.GetUnhealthyEndpoints()),
+		})
+	}
+
+	s.Metrics.RecordBackendHealth(ctx, backends)
+}
+
+// handleProxyVersion forces a full resync if the proxy's config version is
+// behind the version it last acknowledged, or ahead of every version this
+// controller sent.
+func (s *PingoraRouteSyncer) handleProxyVersion(ctx context.Context, proxyVersion uint64) {
+	applied := s.GetAppliedConfig()
+	if applied.Hash == "" {
+		return
+	}
+
+	if proxyVersion > max(applied.Version, s.version.Load()) {
+		// Only another controller can have sent this version, typically a
+		// previous leader whose last sync arrived after this one's
+		s.Logger.Warn("proxy applied a config version this controller did not send, forcing full resync",
+			"proxyVersion", proxyVersion,
+			"appliedVersion", applied.Version,
+		)
+
+		s.advanceVersion(proxyVersion)
+		s.proxyVersion.Store(proxyVersion)
+
+		if _, _, err := s.forceFullResync(ctx); err != nil {
+			s.Logger.Error("failed to resync routes after foreign proxy version", "error", err)
+		}
+
+		return
+	}
+
+	if proxyVersion >= applied.Version {
+		return
+	}
+
+	s.Logger.Warn("proxy config version lags behind applied config, forcing full resync",
+		"proxyVersion", proxyVersion,
+		"appliedVersion", applied.Version,
+	)
+
+	s.proxyVersion.Store(proxyVersion)
+
+	if _, _, err := s.forceFullResync(ctx); err != nil {
+		s.Logger.Error("failed to resync routes after proxy version lag", "error", err)
+	}
+}
+
+// isLeader reports whether this replica may sync routes to the proxy.
+func (s *PingoraRouteSyncer) isLeader() bool {
+	if s.Elected == nil {
+		return true
+	}
+
+	select {
+	case <-s.Elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// forceFullResync syncs all routes, sending the full config even if the
+// proxy is believed to have it already.
+func (s *PingoraRouteSyncer) forceFullResync(ctx context.Context) (ctrl.Result, *SyncResult, error) {
+	s.resetAppliedConfig()
+
+	s.connMu.RLock()
+	stream := s.stream
+	fanOut := s.fanOut
+	s.connMu.RUnlock()
+
+	// The stream snapshot is stale as well, so the next update must be a full one
+	if stream != nil {
+		stream.Close()
+	}
+
+	if fanOut != nil {
+		fanOut.resetStreams()
+	}
+
+	return s.RequestSync(ctx)
+}
+
+// reportProxyHealth forwards health reports from the route stream to Start.
+// Reports are dropped if one is already pending.
+func (s *PingoraRouteSyncer) reportProxyHealth(health *routingv1.HealthResponse) {
+	select {
+	case s.proxyVersions <- health.GetConfigVersion():
+	default:
+	}
+}
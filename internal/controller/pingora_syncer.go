@@ -27,6 +27,9 @@ type PingoraSyncer struct {
 	grpcClient routingv1.RoutingServiceClient
 	configName string
 
+	// requestTimeout bounds every call to the proxy
+	requestTimeout time.Duration
+
 	// Version tracking for optimistic concurrency
 	version atomic.Uint64
 }
@@ -37,8 +40,11 @@ func NewPingoraSyncer(
 	defaultNamespace string,
 	metricsCollector metrics.Collector,
 ) *PingoraSyncer {
+	resolver := config.NewPingoraResolver(k8sClient, defaultNamespace)
+	resolver.Metrics = metricsCollector
+
 	return &PingoraSyncer{
-		resolver: config.NewPingoraResolver(k8sClient, defaultNamespace),
+		resolver: resolver,
 		metrics:  metricsCollector,
 	}
 }
@@ -70,6 +76,7 @@ func (s *PingoraSyncer) Connect(ctx context.Context, gatewayClassName string) er
 	s.conn = conn
 	s.grpcClient = s.resolver.CreateRoutingClient(conn)
 	s.configName = resolved.ConfigName
+	s.requestTimeout = resolved.RequestTimeout
 
 	return nil
 }
@@ -106,6 +113,7 @@ func (s *PingoraSyncer) SyncRoutes(
 ) error {
 	s.mu.RLock()
 	rpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
 	s.mu.RUnlock()
 
 	if rpcClient == nil {
@@ -121,8 +129,11 @@ func (s *PingoraSyncer) SyncRoutes(
 		Version:    version,
 	}
 
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
 	startTime := time.Now()
-	resp, err := rpcClient.UpdateRoutes(ctx, req)
+	resp, err := rpcClient.UpdateRoutes(rpcCtx, req)
 	duration := time.Since(startTime)
 
 	if err != nil {
@@ -153,14 +164,18 @@ func (s *PingoraSyncer) SyncRoutes(
 func (s *PingoraSyncer) GetRoutes(ctx context.Context) (*routingv1.GetRoutesResponse, error) {
 	s.mu.RLock()
 	rpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
 	s.mu.RUnlock()
 
 	if rpcClient == nil {
 		return nil, errors.New("not connected to Pingora proxy")
 	}
 
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
 	startTime := time.Now()
-	resp, err := rpcClient.GetRoutes(ctx, &routingv1.GetRoutesRequest{})
+	resp, err := rpcClient.GetRoutes(rpcCtx, &routingv1.GetRoutesRequest{})
 	duration := time.Since(startTime)
 
 	if err != nil {
@@ -180,14 +195,18 @@ func (s *PingoraSyncer) GetRoutes(ctx context.Context) (*routingv1.GetRoutesResp
 func (s *PingoraSyncer) Health(ctx context.Context) (*routingv1.HealthResponse, error) {
 	s.mu.RLock()
 	rpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
 	s.mu.RUnlock()
 
 	if rpcClient == nil {
 		return nil, errors.New("not connected to Pingora proxy")
 	}
 
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
 	startTime := time.Now()
-	resp, err := rpcClient.Health(ctx, &routingv1.HealthRequest{})
+	resp, err := rpcClient.Health(rpcCtx, &routingv1.HealthRequest{})
 	duration := time.Since(startTime)
 
 	if err != nil {
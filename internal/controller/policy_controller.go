@@ -0,0 +1,670 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
+)
+
+// maxPolicyAncestors is the maximum number of ancestors in a policy status.
+const maxPolicyAncestors = 16
+
+// policyObject is a policy CRD with a Gateway API policy status.
+type policyObject interface {
+	client.Object
+	ingress.AttachedPolicy
+
+	// GetPolicyStatus returns the policy status for in-place updates.
+	GetPolicyStatus() *gatewayv1.PolicyStatus
+}
+
+// policyKind describes a policy CRD reconciled by PingoraPolicyReconciler.
+type policyKind struct {
+	// kind is the policy kind, used in status messages and logs.
+	kind string
+
+	// newObject returns an empty policy.
+	newObject func() policyObject
+
+	// list returns the policies in a namespace.
+	list func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error)
+
+	// validate reports why a policy cannot be applied, nil if it can.
+	// Optional.
+	validate func(ctx context.Context, c client.Client, policy policyObject) error
+
+	// references are the kinds of objects that validate reads, and
+	// referencesObject reports whether a policy reads the object.
+	references       []client.Object
+	referencesObject func(policy policyObject, obj client.Object) bool
+}
+
+// rateLimitPolicyKind describes PingoraRateLimitPolicy.
+func rateLimitPolicyKind() policyKind {
+	return policyKind{
+		kind:      v1alpha1.PingoraRateLimitPolicyKind,
+		newObject: func() policyObject { return &v1alpha1.PingoraRateLimitPolicy{} },
+		list: func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error) {
+			var list v1alpha1.PingoraRateLimitPolicyList
+			if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return nil, errors.Wrap(err, "failed to list rate limit policies")
+			}
+
+			policies := make([]policyObject, 0, len(list.Items))
+			for i := range list.Items {
+				policies = append(policies, &list.Items[i])
+			}
+
+			return policies, nil
+		},
+	}
+}
+
+// trafficPolicyKind describes PingoraTrafficPolicy. Policies with a trusted
+// proxy that is not an address are invalid, and the entry is ignored.
+func trafficPolicyKind() policyKind {
+	return policyKind{
+		kind:      v1alpha1.PingoraTrafficPolicyKind,
+		newObject: func() policyObject { return &v1alpha1.PingoraTrafficPolicy{} },
+		list: func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error) {
+			var list v1alpha1.PingoraTrafficPolicyList
+			if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return nil, errors.Wrap(err, "failed to list traffic policies")
+			}
+
+			policies := make([]policyObject, 0, len(list.Items))
+			for i := range list.Items {
+				policies = append(policies, &list.Items[i])
+			}
+
+			return policies, nil
+		},
+		validate: func(_ context.Context, _ client.Client, policy policyObject) error {
+			trafficPolicy, ok := policy.(*v1alpha1.PingoraTrafficPolicy)
+			if !ok {
+				return nil
+			}
+
+			return ingress.ValidateTrafficPolicy(&trafficPolicy.Spec)
+		},
+	}
+}
+
+// authPolicyKind describes PingoraAuthPolicy. Policies whose key set cannot
+// be resolved, or whose external auth service does not exist, are reported as
+// invalid.
+func authPolicyKind() policyKind {
+	return policyKind{
+		kind:      v1alpha1.PingoraAuthPolicyKind,
+		newObject: func() policyObject { return &v1alpha1.PingoraAuthPolicy{} },
+		list: func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error) {
+			var list v1alpha1.PingoraAuthPolicyList
+			if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return nil, errors.Wrap(err, "failed to list auth policies")
+			}
+
+			policies := make([]policyObject, 0, len(list.Items))
+			for i := range list.Items {
+				policies = append(policies, &list.Items[i])
+			}
+
+			return policies, nil
+		},
+		validate: func(ctx context.Context, c client.Client, policy policyObject) error {
+			authPolicy, ok := policy.(*v1alpha1.PingoraAuthPolicy)
+			if !ok {
+				return nil
+			}
+
+			return validateAuthPolicy(ctx, c, authPolicy)
+		},
+		references: []client.Object{&corev1.Secret{}, &corev1.ConfigMap{}, &corev1.Service{}},
+		referencesObject: func(policy policyObject, obj client.Object) bool {
+			authPolicy, ok := policy.(*v1alpha1.PingoraAuthPolicy)
+
+			return ok && authPolicyReferences(authPolicy, obj)
+		},
+	}
+}
+
+// accessControlPolicyKind describes PingoraAccessControlPolicy. Policies
+// with an entry that is not an address are invalid and deny all requests.
+func accessControlPolicyKind() policyKind {
+	return policyKind{
+		kind:      v1alpha1.PingoraAccessControlPolicyKind,
+		newObject: func() policyObject { return &v1alpha1.PingoraAccessControlPolicy{} },
+		list: func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error) {
+			var list v1alpha1.PingoraAccessControlPolicyList
+			if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return nil, errors.Wrap(err, "failed to list access control policies")
+			}
+
+			policies := make([]policyObject, 0, len(list.Items))
+			for i := range list.Items {
+				policies = append(policies, &list.Items[i])
+			}
+
+			return policies, nil
+		},
+		validate: func(_ context.Context, _ client.Client, policy policyObject) error {
+			accessPolicy, ok := policy.(*v1alpha1.PingoraAccessControlPolicy)
+			if !ok {
+				return nil
+			}
+
+			return ingress.ValidateAccessControl(&accessPolicy.Spec)
+		},
+	}
+}
+
+// cachePolicyKind describes PingoraCachePolicy. Policies without a positive
+// TTL are invalid and their targets are not cached.
+func cachePolicyKind() policyKind {
+	return policyKind{
+		kind:      v1alpha1.PingoraCachePolicyKind,
+		newObject: func() policyObject { return &v1alpha1.PingoraCachePolicy{} },
+		list: func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error) {
+			var list v1alpha1.PingoraCachePolicyList
+			if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return nil, errors.Wrap(err, "failed to list cache policies")
+			}
+
+			policies := make([]policyObject, 0, len(list.Items))
+			for i := range list.Items {
+				policies = append(policies, &list.Items[i])
+			}
+
+			return policies, nil
+		},
+		validate: func(_ context.Context, _ client.Client, policy policyObject) error {
+			cachePolicy, ok := policy.(*v1alpha1.PingoraCachePolicy)
+			if !ok {
+				return nil
+			}
+
+			return ingress.ValidateCache(&cachePolicy.Spec)
+		},
+	}
+}
+
+// accessLogPolicyKind describes PingoraAccessLogPolicy.
+func accessLogPolicyKind() policyKind {
+	return policyKind{
+		kind:      v1alpha1.PingoraAccessLogPolicyKind,
+		newObject: func() policyObject { return &v1alpha1.PingoraAccessLogPolicy{} },
+		list: func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error) {
+			var list v1alpha1.PingoraAccessLogPolicyList
+			if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return nil, errors.Wrap(err, "failed to list access log policies")
+			}
+
+			policies := make([]policyObject, 0, len(list.Items))
+			for i := range list.Items {
+				policies = append(policies, &list.Items[i])
+			}
+
+			return policies, nil
+		},
+	}
+}
+
+// backendPolicyKind describes PingoraBackendPolicy. Its targets are
+// Services, so they are watched as references.
+func backendPolicyKind() policyKind {
+	return policyKind{
+		kind:      v1alpha1.PingoraBackendPolicyKind,
+		newObject: func() policyObject { return &v1alpha1.PingoraBackendPolicy{} },
+		list: func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error) {
+			var list v1alpha1.PingoraBackendPolicyList
+			if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return nil, errors.Wrap(err, "failed to list backend policies")
+			}
+
+			policies := make([]policyObject, 0, len(list.Items))
+			for i := range list.Items {
+				policies = append(policies, &list.Items[i])
+			}
+
+			return policies, nil
+		},
+		references: []client.Object{&corev1.Service{}},
+		referencesObject: func(policy policyObject, obj client.Object) bool {
+			return slices.ContainsFunc(ingress.PolicyTargets(policy), func(target ingress.PolicyTarget) bool {
+				return target.Kind == ingress.PolicyTargetService && target.Name == obj.GetName()
+			})
+		},
+	}
+}
+
+// grpcPolicyKind describes PingoraGRPCPolicy.
+func grpcPolicyKind() policyKind {
+	return policyKind{
+		kind:      v1alpha1.PingoraGRPCPolicyKind,
+		newObject: func() policyObject { return &v1alpha1.PingoraGRPCPolicy{} },
+		list: func(ctx context.Context, c client.Client, namespace string) ([]policyObject, error) {
+			var list v1alpha1.PingoraGRPCPolicyList
+			if err := c.List(ctx, &list, client.InNamespace(namespace)); err != nil {
+				return nil, errors.Wrap(err, "failed to list gRPC policies")
+			}
+
+			policies := make([]policyObject, 0, len(list.Items))
+			for i := range list.Items {
+				policies = append(policies, &list.Items[i])
+			}
+
+			return policies, nil
+		},
+	}
+}
+
+// PingoraPolicyReconciler reports the attachment of policies of one kind,
+// such as PingoraRateLimitPolicy, in their status.
+//
+// Every target managed by this controller is reported as an ancestor with an
+// Accepted condition. Targets of other GatewayClasses are left to their
+// controllers. Routes pick up policy changes through the HTTPRoute
+// controller, which watches the policies as well.
+type PingoraPolicyReconciler struct {
+	client.Client
+
+	// Scheme is the runtime scheme for API type registration.
+	Scheme *runtime.Scheme
+
+	// GatewayClassName filters which targets are reported.
+	GatewayClassName string
+
+	// ControllerName is reported in policy ancestor status.
+	ControllerName string
+
+	// MissingResources are the optional resources without a CRD. Without
+	// the GRPCRoute CRD, GRPCRoute targets are not watched.
+	MissingResources MissingResources
+
+	// policy is the reconciled policy kind.
+	policy policyKind
+}
+
+func (r *PingoraPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx = logging.WithReconcileID(ctx)
+	logger := logging.Component(ctx, "pingora-policy-reconciler").With("kind", r.policy.kind, "policy", req.String())
+
+	policy := r.policy.newObject()
+	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get %s", r.policy.kind)
+	}
+
+	logger.Debug("reconciling policy")
+
+	if err := r.updateStatus(ctx, req.NamespacedName); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *PingoraPolicyReconciler) updateStatus(ctx context.Context, key types.NamespacedName) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		policy := r.policy.newObject()
+		if err := r.Get(ctx, key, policy); err != nil {
+			return errors.Wrapf(err, "failed to get fresh %s", r.policy.kind)
+		}
+
+		policies, err := r.policy.list(ctx, r.Client, policy.GetNamespace())
+		if err != nil {
+			return err
+		}
+
+		active := ingress.ActivePolicies(policies)
+		status := r.policyStatus(ctx, policy, active)
+
+		if equality.Semantic.DeepEqual(status, policy.GetPolicyStatus()) {
+			return nil
+		}
+
+		*policy.GetPolicyStatus() = *status
+
+		if err := r.Status().Update(ctx, policy); err != nil {
+			return errors.Wrapf(err, "failed to update %s status", r.policy.kind)
+		}
+
+		return nil
+	})
+
+	return errors.Wrapf(err, "failed to update %s status after retries", r.policy.kind)
+}
+
+// policyStatus returns the policy status with the ancestors of this
+// controller replaced. Ancestors of other controllers are kept.
+func (r *PingoraPolicyReconciler) policyStatus(
+	ctx context.Context,
+	policy policyObject,
+	active map[ingress.PolicyTarget]policyObject,
+) *gatewayv1.PolicyStatus {
+	status := &gatewayv1.PolicyStatus{Ancestors: []gatewayv1.PolicyAncestorStatus{}}
+	previous := make(map[string][]metav1.Condition)
+
+	var invalid error
+	if r.policy.validate != nil {
+		invalid = r.policy.validate(ctx, r.Client, policy)
+	}
+
+	for _, ancestor := range policy.GetPolicyStatus().Ancestors {
+		if string(ancestor.ControllerName) != r.ControllerName {
+			status.Ancestors = append(status.Ancestors, ancestor)
+
+			continue
+		}
+
+		previous[ancestorKey(ancestor.AncestorRef)] = ancestor.Conditions
+	}
+
+	for _, target := range ingress.PolicyTargets(policy) {
+		if len(status.Ancestors) >= maxPolicyAncestors {
+			break
+		}
+
+		accepted := r.targetCondition(ctx, policy, target, active)
+		if accepted == nil {
+			continue
+		}
+
+		if invalid != nil && accepted.Status == metav1.ConditionTrue {
+			accepted.Status = metav1.ConditionFalse
+			accepted.Reason = string(gatewayv1.PolicyReasonInvalid)
+			accepted.Message = invalid.Error()
+		}
+
+		ref := targetAncestorRef(target)
+		conditions := append([]metav1.Condition(nil), previous[ancestorKey(ref)]...)
+		meta.SetStatusCondition(&conditions, *accepted)
+
+		status.Ancestors = append(status.Ancestors, gatewayv1.PolicyAncestorStatus{
+			AncestorRef:    ref,
+			ControllerName: gatewayv1.GatewayController(r.ControllerName),
+			Conditions:     conditions,
+		})
+	}
+
+	return status
+}
+
+// targetCondition returns the Accepted condition of a policy for one of its
+// targets, or nil if the target belongs to another GatewayClass.
+func (r *PingoraPolicyReconciler) targetCondition(
+	ctx context.Context,
+	policy policyObject,
+	target ingress.PolicyTarget,
+	active map[ingress.PolicyTarget]policyObject,
+) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               string(gatewayv1.PolicyConditionAccepted),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: policy.GetGeneration(),
+		Reason:             string(gatewayv1.PolicyReasonAccepted),
+		Message:            "Policy accepted",
+	}
+
+	key := types.NamespacedName{Namespace: target.Namespace, Name: target.Name}
+
+	switch target.Kind {
+	case ingress.PolicyTargetGateway:
+		var gateway gatewayv1.Gateway
+		if err := r.Get(ctx, key, &gateway); err != nil {
+			return targetNotFound(condition, fmt.Sprintf("Gateway %s not found", key))
+		}
+
+		if gateway.Spec.GatewayClassName != gatewayv1.ObjectName(r.GatewayClassName) {
+			return nil
+		}
+
+		if target.SectionName != "" && !hasListenerNamed(gateway.Spec.Listeners, target.SectionName) {
+			return targetNotFound(condition, fmt.Sprintf("Gateway %s has no listener named %q", key, target.SectionName))
+		}
+	case ingress.PolicyTargetHTTPRoute:
+		var route gatewayv1.HTTPRoute
+		if err := r.Get(ctx, key, &route); err != nil {
+			return targetNotFound(condition, fmt.Sprintf("HTTPRoute %s not found", key))
+		}
+
+		if !ReferencesGatewayClass(ctx, r.Client, r.GatewayClassName, HTTPRouteWrapper{&route}) {
+			return nil
+		}
+
+		if target.SectionName != "" && !hasRuleNamed(route.Spec.Rules, target.SectionName) {
+			return targetNotFound(condition, fmt.Sprintf("HTTPRoute %s has no rule named %q", key, target.SectionName))
+		}
+	case ingress.PolicyTargetGRPCRoute:
+		var route gatewayv1.GRPCRoute
+		if err := r.Get(ctx, key, &route); err != nil {
+			return targetNotFound(condition, fmt.Sprintf("GRPCRoute %s not found", key))
+		}
+
+		if !ReferencesGatewayClass(ctx, r.Client, r.GatewayClassName, GRPCRouteWrapper{&route}) {
+			return nil
+		}
+
+		if target.SectionName != "" && !hasGRPCRuleNamed(route.Spec.Rules, target.SectionName) {
+			return targetNotFound(condition, fmt.Sprintf("GRPCRoute %s has no rule named %q", key, target.SectionName))
+		}
+	case ingress.PolicyTargetService:
+		// Services are shared by all GatewayClasses, so every controller
+		// reports them
+		var service corev1.Service
+		if err := r.Get(ctx, key, &service); err != nil {
+			return targetNotFound(condition, fmt.Sprintf("Service %s not found", key))
+		}
+	default:
+		return nil
+	}
+
+	if winner := active[target]; winner != nil && winner.GetName() != policy.GetName() {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(gatewayv1.PolicyReasonConflicted)
+		condition.Message = fmt.Sprintf("%s %s/%s is already attached to the target",
+			r.policy.kind, winner.GetNamespace(), winner.GetName())
+	}
+
+	return condition
+}
+
+func targetNotFound(condition *metav1.Condition, message string) *metav1.Condition {
+	condition.Status = metav1.ConditionFalse
+	condition.Reason = string(gatewayv1.PolicyReasonTargetNotFound)
+	condition.Message = message
+
+	return condition
+}
+
+func hasRuleNamed(rules []gatewayv1.HTTPRouteRule, name string) bool {
+	for i := range rules {
+		if rules[i].Name != nil && string(*rules[i].Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasGRPCRuleNamed(rules []gatewayv1.GRPCRouteRule, name string) bool {
+	for i := range rules {
+		if rules[i].Name != nil && string(*rules[i].Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasListenerNamed(listeners []gatewayv1.Listener, name string) bool {
+	for i := range listeners {
+		if string(listeners[i].Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// targetAncestorRef returns the ancestor reference reported for a target.
+// The target itself is the ancestor, so every target has its own conditions.
+func targetAncestorRef(target ingress.PolicyTarget) gatewayv1.ParentReference {
+	group := gatewayv1.Group(gatewayv1.GroupName)
+	if target.Kind == ingress.PolicyTargetService {
+		group = ""
+	}
+	kind := gatewayv1.Kind(target.Kind)
+	namespace := gatewayv1.Namespace(target.Namespace)
+
+	ref := gatewayv1.ParentReference{
+		Group:     &group,
+		Kind:      &kind,
+		Namespace: &namespace,
+		Name:      gatewayv1.ObjectName(target.Name),
+	}
+
+	if target.SectionName != "" {
+		sectionName := gatewayv1.SectionName(target.SectionName)
+		ref.SectionName = &sectionName
+	}
+
+	return ref
+}
+
+func ancestorKey(ref gatewayv1.ParentReference) string {
+	key := string(ref.Name)
+
+	if ref.Kind != nil {
+		key = string(*ref.Kind) + "/" + key
+	}
+
+	if ref.Namespace != nil {
+		key = string(*ref.Namespace) + "/" + key
+	}
+
+	if ref.SectionName != nil {
+		key += "/" + string(*ref.SectionName)
+	}
+
+	return key
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PingoraPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(strings.ToLower(r.policy.kind)).
+		For(r.policy.newObject(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Policies on the same target conflict, so a change to one policy
+		// may change the status of the others in its namespace.
+		Watches(
+			r.policy.newObject(),
+			handler.EnqueueRequestsFromMapFunc(r.findPoliciesInNamespace),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&gatewayv1.HTTPRoute{},
+			handler.EnqueueRequestsFromMapFunc(r.findPoliciesForTarget(ingress.PolicyTargetHTTPRoute)),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&gatewayv1.Gateway{},
+			handler.EnqueueRequestsFromMapFunc(r.findPoliciesForTarget(ingress.PolicyTargetGateway)),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		)
+
+	if !r.MissingResources.Has(OptionalResourceGRPCRoute) {
+		b = b.Watches(
+			&gatewayv1.GRPCRoute{},
+			handler.EnqueueRequestsFromMapFunc(r.findPoliciesForTarget(ingress.PolicyTargetGRPCRoute)),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		)
+	}
+
+	// Objects read by validation have no generation, so every change counts
+	for _, obj := range r.policy.references {
+		b = b.Watches(obj, handler.EnqueueRequestsFromMapFunc(r.findPoliciesForReference))
+	}
+
+	//nolint:wrapcheck // controller-runtime builder pattern
+	return b.Complete(r)
+}
+
+func (r *PingoraPolicyReconciler) findPoliciesInNamespace(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	policies, err := r.policy.list(ctx, r.Client, obj.GetNamespace())
+	if err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(policies))
+
+	for _, policy := range policies {
+		if policy.GetName() != obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(policy),
+			})
+		}
+	}
+
+	return requests
+}
+
+func (r *PingoraPolicyReconciler) findPoliciesForReference(
+	ctx context.Context,
+	obj client.Object,
+) []reconcile.Request {
+	policies, err := r.policy.list(ctx, r.Client, obj.GetNamespace())
+	if err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for _, policy := range policies {
+		if r.policy.referencesObject(policy, obj) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(policy),
+			})
+		}
+	}
+
+	return requests
+}
+
+func (r *PingoraPolicyReconciler) findPoliciesForTarget(kind string) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		policies, err := r.policy.list(ctx, r.Client, obj.GetNamespace())
+		if err != nil {
+			return nil
+		}
+
+		return FindPoliciesForTarget(kind, obj, policies)
+	}
+}
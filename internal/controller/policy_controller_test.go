@@ -0,0 +1,445 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+)
+
+func TestRateLimitPolicyReconciler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	login := gatewayv1.SectionName("login")
+	signup := gatewayv1.SectionName("signup")
+
+	route := newRouteWithParent("web", "gateway-system", "ours").(HTTPRouteWrapper).HTTPRoute
+	route.Spec.Rules = []gatewayv1.HTTPRouteRule{{Name: &login}}
+
+	loginTarget := policyTarget("HTTPRoute", "web")
+	loginTarget.SectionName = &login
+
+	missingRuleTarget := policyTarget("HTTPRoute", "web")
+	missingRuleTarget.SectionName = &signup
+
+	created := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	first := rateLimitPolicy("first", "gateway-system",
+		policyTarget("HTTPRoute", "web"),
+		loginTarget,
+		missingRuleTarget,
+		policyTarget("HTTPRoute", "missing"),
+		policyTarget("Gateway", "theirs"),
+	)
+	first.CreationTimestamp = created
+	first.Status.Ancestors = []gatewayv1.PolicyAncestorStatus{{
+		AncestorRef:    gatewayv1.ParentReference{Name: "theirs"},
+		ControllerName: "example.com/other-controller",
+	}}
+
+	second := rateLimitPolicy("second", "gateway-system",
+		policyTarget("HTTPRoute", "web"),
+		policyTarget("Gateway", "ours"),
+	)
+	second.CreationTimestamp = metav1.NewTime(created.Add(time.Minute))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newGateway("ours", "pingora", gatewayv1.NamespacesFromSame),
+			newGateway("theirs", "other", gatewayv1.NamespacesFromSame),
+			route,
+			first,
+			second,
+		).
+		WithStatusSubresource(&v1alpha1.PingoraRateLimitPolicy{}).
+		Build()
+
+	reconciler := &PingoraPolicyReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+		policy:           rateLimitPolicyKind(),
+	}
+
+	results := func(policy *v1alpha1.PingoraRateLimitPolicy) []ancestorResult {
+		return reconcilePolicy(t, reconciler, policy)
+	}
+
+	assert.Equal(t, []ancestorResult{
+		{Name: "theirs"},
+		{Kind: "HTTPRoute", Name: "web", Reason: string(gatewayv1.PolicyReasonAccepted)},
+		{Kind: "HTTPRoute", Name: "web", Section: "login", Reason: string(gatewayv1.PolicyReasonAccepted)},
+		{Kind: "HTTPRoute", Name: "web", Section: "signup", Reason: string(gatewayv1.PolicyReasonTargetNotFound)},
+		{Kind: "HTTPRoute", Name: "missing", Reason: string(gatewayv1.PolicyReasonTargetNotFound)},
+	}, results(first))
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "HTTPRoute", Name: "web", Reason: string(gatewayv1.PolicyReasonConflicted)},
+		{Kind: "Gateway", Name: "ours", Reason: string(gatewayv1.PolicyReasonAccepted)},
+	}, results(second))
+}
+
+func TestTrafficPolicyReconciler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	gateway := newGateway("ours", "pingora", gatewayv1.NamespacesFromSame)
+
+	httpTarget := policyTarget("Gateway", "ours")
+	httpSection := gatewayv1.SectionName("http")
+	httpTarget.SectionName = &httpSection
+
+	missingTarget := policyTarget("Gateway", "ours")
+	missingSection := gatewayv1.SectionName("https")
+	missingTarget.SectionName = &missingSection
+
+	policy := &v1alpha1.PingoraTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "gateway-system"},
+		Spec: v1alpha1.PingoraTrafficPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				policyTarget("Gateway", "ours"),
+				httpTarget,
+				missingTarget,
+				policyTarget("Gateway", "theirs"),
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gateway, newGateway("theirs", "other", gatewayv1.NamespacesFromSame), policy).
+		WithStatusSubresource(&v1alpha1.PingoraTrafficPolicy{}).
+		Build()
+
+	reconciler := &PingoraPolicyReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+		policy:           trafficPolicyKind(),
+	}
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "Gateway", Name: "ours", Reason: string(gatewayv1.PolicyReasonAccepted)},
+		{Kind: "Gateway", Name: "ours", Section: "http", Reason: string(gatewayv1.PolicyReasonAccepted)},
+		{Kind: "Gateway", Name: "ours", Section: "https", Reason: string(gatewayv1.PolicyReasonTargetNotFound)},
+	}, reconcilePolicy(t, reconciler, policy))
+}
+
+func TestAuthPolicyReconciler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	route := newRouteWithParent("web", "gateway-system", "ours").(HTTPRouteWrapper).HTTPRoute
+
+	valid := authPolicy("valid", v1alpha1.JWKSSource{SecretRef: &v1alpha1.KeyReference{Name: "jwks"}},
+		policyTarget("HTTPRoute", "web"))
+	missing := authPolicy("missing", v1alpha1.JWKSSource{SecretRef: &v1alpha1.KeyReference{Name: "missing"}},
+		policyTarget("HTTPRoute", "web"))
+	missing.CreationTimestamp = metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	valid.CreationTimestamp = metav1.NewTime(missing.CreationTimestamp.Add(time.Minute))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newGateway("ours", "pingora", gatewayv1.NamespacesFromSame),
+			route,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "jwks", Namespace: "gateway-system"},
+				Data:       map[string][]byte{v1alpha1.DefaultJWKSKey: []byte(testJWKS)},
+			},
+			valid,
+			missing,
+		).
+		WithStatusSubresource(&v1alpha1.PingoraAuthPolicy{}).
+		Build()
+
+	reconciler := &PingoraPolicyReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+		policy:           authPolicyKind(),
+	}
+
+	// The invalid policy is older, so it still wins the target
+	assert.Equal(t, []ancestorResult{
+		{Kind: "HTTPRoute", Name: "web", Reason: string(gatewayv1.PolicyReasonInvalid)},
+	}, reconcilePolicy(t, reconciler, missing))
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "HTTPRoute", Name: "web", Reason: string(gatewayv1.PolicyReasonConflicted)},
+	}, reconcilePolicy(t, reconciler, valid))
+}
+
+func TestAccessControlPolicyReconciler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	route := newRouteWithParent("web", "gateway-system", "ours").(HTTPRouteWrapper).HTTPRoute
+
+	valid := &v1alpha1.PingoraAccessControlPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "gateway-system"},
+		Spec: v1alpha1.PingoraAccessControlPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				policyTarget("Gateway", "ours"),
+			},
+			Allow:         []string{"10.0.0.0/8", "192.0.2.1"},
+			DefaultAction: v1alpha1.AccessActionDeny,
+		},
+	}
+
+	invalid := &v1alpha1.PingoraAccessControlPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "gateway-system"},
+		Spec: v1alpha1.PingoraAccessControlPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				policyTarget("HTTPRoute", "web"),
+			},
+			Deny: []string{"10.0.0.0/33"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newGateway("ours", "pingora", gatewayv1.NamespacesFromSame), route, valid, invalid).
+		WithStatusSubresource(&v1alpha1.PingoraAccessControlPolicy{}).
+		Build()
+
+	reconciler := &PingoraPolicyReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+		policy:           accessControlPolicyKind(),
+	}
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "Gateway", Name: "ours", Reason: string(gatewayv1.PolicyReasonAccepted)},
+	}, reconcilePolicy(t, reconciler, valid))
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "HTTPRoute", Name: "web", Reason: string(gatewayv1.PolicyReasonInvalid)},
+	}, reconcilePolicy(t, reconciler, invalid))
+}
+
+func TestCachePolicyReconciler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	route := newRouteWithParent("web", "gateway-system", "ours").(HTTPRouteWrapper).HTTPRoute
+
+	cachePolicy := func(name, ttl string) *v1alpha1.PingoraCachePolicy {
+		return &v1alpha1.PingoraCachePolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gateway-system"},
+			Spec: v1alpha1.PingoraCachePolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTarget("HTTPRoute", "web"),
+				},
+				TTL: gatewayv1.Duration(ttl),
+			},
+		}
+	}
+
+	invalid := cachePolicy("invalid", "0s")
+	invalid.CreationTimestamp = metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	valid := cachePolicy("valid", "5m")
+	valid.CreationTimestamp = metav1.NewTime(invalid.CreationTimestamp.Add(time.Minute))
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newGateway("ours", "pingora", gatewayv1.NamespacesFromSame), route, valid, invalid).
+		WithStatusSubresource(&v1alpha1.PingoraCachePolicy{}).
+		Build()
+
+	reconciler := &PingoraPolicyReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+		policy:           cachePolicyKind(),
+	}
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "HTTPRoute", Name: "web", Reason: string(gatewayv1.PolicyReasonInvalid)},
+	}, reconcilePolicy(t, reconciler, invalid))
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "HTTPRoute", Name: "web", Reason: string(gatewayv1.PolicyReasonConflicted)},
+	}, reconcilePolicy(t, reconciler, valid))
+}
+
+func TestBackendPolicyReconciler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	policy := &v1alpha1.PingoraBackendPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "breaker", Namespace: "gateway-system"},
+		Spec: v1alpha1.PingoraBackendPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				serviceTarget("api"),
+				serviceTarget("missing"),
+			},
+			CircuitBreaker: &v1alpha1.CircuitBreaker{},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "gateway-system"}},
+			policy,
+		).
+		WithStatusSubresource(&v1alpha1.PingoraBackendPolicy{}).
+		Build()
+
+	reconciler := &PingoraPolicyReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+		policy:           backendPolicyKind(),
+	}
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "Service", Name: "api", Reason: string(gatewayv1.PolicyReasonAccepted)},
+		{Kind: "Service", Name: "missing", Reason: string(gatewayv1.PolicyReasonTargetNotFound)},
+	}, reconcilePolicy(t, reconciler, policy))
+
+	// Services are in the core group
+	var got v1alpha1.PingoraBackendPolicy
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(policy), &got))
+	require.NotEmpty(t, got.Status.Ancestors)
+	assert.Equal(t, gatewayv1.Group(""), *got.Status.Ancestors[0].AncestorRef.Group)
+}
+
+func TestGRPCPolicyReconciler(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+	unary := gatewayv1.SectionName("unary")
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "gateway-system"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "ours", Namespace: &gatewayNamespace}},
+			},
+			Rules: []gatewayv1.GRPCRouteRule{{Name: &unary}},
+		},
+	}
+
+	ruleTarget := func(name string) gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+		sectionName := gatewayv1.SectionName(name)
+		target := policyTarget("GRPCRoute", "api")
+		target.SectionName = &sectionName
+
+		return target
+	}
+
+	policy := &v1alpha1.PingoraGRPCPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc-web", Namespace: "gateway-system"},
+		Spec: v1alpha1.PingoraGRPCPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				ruleTarget("unary"),
+				ruleTarget("missing"),
+				policyTarget("GRPCRoute", "absent"),
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newGateway("ours", "pingora", gatewayv1.NamespacesFromSame), route, policy).
+		WithStatusSubresource(&v1alpha1.PingoraGRPCPolicy{}).
+		Build()
+
+	reconciler := &PingoraPolicyReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+		policy:           grpcPolicyKind(),
+	}
+
+	assert.Equal(t, []ancestorResult{
+		{Kind: "GRPCRoute", Name: "api", Section: "unary", Reason: string(gatewayv1.PolicyReasonAccepted)},
+		{Kind: "GRPCRoute", Name: "api", Section: "missing", Reason: string(gatewayv1.PolicyReasonTargetNotFound)},
+		{Kind: "GRPCRoute", Name: "absent", Reason: string(gatewayv1.PolicyReasonTargetNotFound)},
+	}, reconcilePolicy(t, reconciler, policy))
+}
+
+type ancestorResult struct {
+	Kind, Name, Section, Reason string
+}
+
+// reconcilePolicy reconciles a policy and returns a summary of its ancestors.
+func reconcilePolicy(t *testing.T, reconciler *PingoraPolicyReconciler, policy policyObject) []ancestorResult {
+	t.Helper()
+
+	key := client.ObjectKeyFromObject(policy)
+
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	got := reconciler.policy.newObject()
+	require.NoError(t, reconciler.Get(context.Background(), key, got))
+
+	var ancestors []ancestorResult
+
+	for _, ancestor := range got.GetPolicyStatus().Ancestors {
+		result := ancestorResult{Name: string(ancestor.AncestorRef.Name)}
+
+		if ancestor.AncestorRef.Kind != nil {
+			result.Kind = string(*ancestor.AncestorRef.Kind)
+		}
+
+		if ancestor.AncestorRef.SectionName != nil {
+			result.Section = string(*ancestor.AncestorRef.SectionName)
+		}
+
+		if len(ancestor.Conditions) > 0 {
+			result.Reason = ancestor.Conditions[0].Reason
+		}
+
+		ancestors = append(ancestors, result)
+	}
+
+	return ancestors
+}
@@ -0,0 +1,640 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// proxyInstance is one proxy of a fan-out connection.
+type proxyInstance struct {
+	address string
+	conn    *grpc.ClientConn
+	client  routingv1.RoutingServiceClient
+	stream  *routeStream
+
+	// appliedVersion is the version the instance applied last, and lastErr
+	// the reason its last route update failed. Guarded by fanOutClient.mu.
+	appliedVersion uint64
+	lastErr        string
+}
+
+// fanOutClient is a RoutingServiceClient for a proxy running as several
+// instances. Route and global config updates are sent to every instance,
+// each over its own route stream, and succeed only if every instance
+// applied them. Reads are answered by the first instance that responds.
+type fanOutClient struct {
+	instances []*proxyInstance
+
+	mu sync.Mutex
+}
+
+var _ routingv1.RoutingServiceClient = (*fanOutClient)(nil)
+
+// newFanOutClient creates a fanOutClient for open connections to the given
+// instances. onHealth receives the health reports of every route stream.
+func newFanOutClient(
+	addresses []string,
+	conns []*grpc.ClientConn,
+	newClient func(*grpc.ClientConn) routingv1.RoutingServiceClient,
+	logger *slog.Logger,
+	onHealth func(*routingv1.HealthResponse),
+) *fanOutClient {
+	instances := make([]*proxyInstance, 0, len(conns))
+
+	for i, conn := range conns {
+		client := newClient(conn)
+		instances = append(instances, &proxyInstance{
+			address: addresses[i],
+			conn:    conn,
+			client:  client,
+			stream:  newRouteStream(client, logger.With("proxy", addresses[i]), onHealth),
+		})
+	}
+
+	return &fanOutClient{instances: instances}
+}
+
+// fanOut calls call on every instance concurrently and returns the results
+// in the order of the instances.
+func fanOut[T any](
+	ctx context.Context,
+	instances []*proxyInstance,
+	call func(ctx context.Context, instance *proxyInstance) (T, error),
+) ([]T, []error) {
+	results := make([]T, len(instances))
+	errs := make([]error, len(instances))
+
+	var wg sync.WaitGroup
+
+	for i, instance := range instances {
+		wg.Go(func() {
+			results[i], errs[i] = call(ctx, instance)
+		})
+	}
+
+	wg.Wait()
+
+	return results, errs
+}
+
+// UpdateRoutes sends the routes to every instance. If no instance can be
+// reached, the error of the first one is returned. If only some instances
+// fail, the response is unsuccessful and lists them.
+func (c *fanOutClient) UpdateRoutes(
+	ctx context.Context,
+	req *routingv1.UpdateRoutesRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.UpdateRoutesResponse, error) {
+	resps, errs := fanOut(ctx, c.instances,
+		func(ctx context.Context, instance *proxyInstance) (*routingv1.UpdateRoutesResponse, error) {
+			instanceReq := c.expectInstanceVersion(instance, req)
+
+			resp, err := instance.stream.Send(ctx, instanceReq)
+			if !errors.Is(err, errStreamUnsupported) {
+				return resp, err
+			}
+
+			return instance.client.UpdateRoutes(ctx, instanceReq, opts...) //nolint:wrapcheck // reported per instance
+		})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		applied   *routingv1.UpdateRoutesResponse
+		conflict  *routingv1.UpdateRoutesResponse
+		failures  []string
+		reachable int
+	)
+
+	for i, instance := range c.instances {
+		switch {
+		case errs[i] != nil:
+			instance.lastErr = errs[i].Error()
+		case resps[i].GetVersionConflict():
+			reachable++
+			instance.appliedVersion = resps[i].GetAppliedVersion()
+			instance.lastErr = resps[i].GetError()
+
+			if conflict == nil || resps[i].GetAppliedVersion() > conflict.GetAppliedVersion() {
+				conflict = resps[i]
+			}
+		case !resps[i].GetSuccess():
+			reachable++
+			instance.lastErr = resps[i].GetError()
+		default:
+			reachable++
+			instance.appliedVersion = resps[i].GetAppliedVersion()
+			instance.lastErr = ""
+
+			if applied == nil {
+				applied = resps[i]
+			}
+
+			continue
+		}
+
+		failures = append(failures, instance.address+": "+instance.lastErr)
+	}
+
+	if reachable == 0 {
+		return nil, errors.Wrapf(errs[0], "no proxy instance reachable (%s)", strings.Join(failures, "; "))
+	}
+
+	if len(failures) > 0 {
+		return &routingv1.UpdateRoutesResponse{
+			Success:         false,
+			Error:           fanOutFailure(len(failures), len(c.instances), failures),
+			AppliedVersion:  conflict.GetAppliedVersion(),
+			VersionConflict: conflict != nil,
+		}, nil
+	}
+
+	return applied, nil
+}
+
+// expectInstanceVersion returns req with the version instance applied last
+// as its expected version, since instances may run different versions.
+// Instances that applied no version yet get no expected version, and
+// requests without one are sent as they are.
+func (c *fanOutClient) expectInstanceVersion(
+	instance *proxyInstance,
+	req *routingv1.UpdateRoutesRequest,
+) *routingv1.UpdateRoutesRequest {
+	if req.ExpectedVersion == nil {
+		return req
+	}
+
+	c.mu.Lock()
+	version := instance.appliedVersion
+	c.mu.Unlock()
+
+	instanceReq := proto.CloneOf(req)
+	instanceReq.ExpectedVersion = nil
+
+	if version > 0 {
+		instanceReq.ExpectedVersion = proto.Uint64(version)
+	}
+
+	return instanceReq
+}
+
+// DeleteRoutes removes the routes from every instance. Failures are
+// reported as for UpdateRoutes. The route stream of every instance that
+// removed them continues from the version it applied.
+func (c *fanOutClient) DeleteRoutes(
+	ctx context.Context,
+	req *routingv1.DeleteRoutesRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.UpdateRoutesResponse, error) {
+	resps, errs := fanOut(ctx, c.instances,
+		func(ctx context.Context, instance *proxyInstance) (*routingv1.UpdateRoutesResponse, error) {
+			resp, err := instance.client.DeleteRoutes(ctx, req, opts...)
+			if err == nil && resp.GetSuccess() {
+				instance.stream.Removed(req, resp.GetAppliedVersion())
+			}
+
+			return resp, err //nolint:wrapcheck // reported per instance
+		})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		failures  []string
+		reachable int
+	)
+
+	for i, instance := range c.instances {
+		switch {
+		case errs[i] != nil:
+			failures = append(failures, instance.address+": "+errs[i].Error())
+		case !resps[i].GetSuccess():
+			reachable++
+
+			failures = append(failures, instance.address+": "+resps[i].GetError())
+		default:
+			reachable++
+			instance.appliedVersion = resps[i].GetAppliedVersion()
+		}
+	}
+
+	if reachable == 0 {
+		// Keep the status code, so that Unimplemented is recognized
+		return nil, errs[0]
+	}
+
+	if len(failures) > 0 {
+		return &routingv1.UpdateRoutesResponse{
+			Success: false,
+			Error:   fanOutFailure(len(failures), len(c.instances), failures),
+		}, nil
+	}
+
+	return resps[0], nil
+}
+
+// UpdateGlobalConfig sends the global config to every instance. Failures
+// are reported as for UpdateRoutes.
+//
+//nolint:dupl // same fan-out as UpdateLoggingConfig with different message types
+func (c *fanOutClient) UpdateGlobalConfig(
+	ctx context.Context,
+	req *routingv1.UpdateGlobalConfigRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.UpdateGlobalConfigResponse, error) {
+	resps, errs := fanOut(ctx, c.instances,
+		func(ctx context.Context, instance *proxyInstance) (*routingv1.UpdateGlobalConfigResponse, error) {
+			return instance.client.UpdateGlobalConfig(ctx, req, opts...) //nolint:wrapcheck // reported per instance
+		})
+
+	var (
+		failures  []string
+		reachable int
+	)
+
+	for i, instance := range c.instances {
+		switch {
+		case errs[i] != nil:
+			failures = append(failures, instance.address+": "+errs[i].Error())
+		case !resps[i].GetSuccess():
+			reachable++
+
+			failures = append(failures, instance.address+": "+resps[i].GetError())
+		default:
+			reachable++
+		}
+	}
+
+	if reachable == 0 {
+		// Keep the status code, so that Unimplemented is recognized
+		return nil, errs[0]
+	}
+
+	if len(failures) > 0 {
+		return &routingv1.UpdateGlobalConfigResponse{
+			Success: false,
+			Error:   fanOutFailure(len(failures), len(c.instances), failures),
+		}, nil
+	}
+
+	return resps[0], nil
+}
+
+// UpdateLoggingConfig sends the access log settings to every instance.
+// Failures are reported as for UpdateRoutes.
+//
+//nolint:dupl // same fan-out as UpdateGlobalConfig with different message types
+func (c *fanOutClient) UpdateLoggingConfig(
+	ctx context.Context,
+	req *routingv1.UpdateLoggingConfigRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.UpdateLoggingConfigResponse, error) {
+	resps, errs := fanOut(ctx, c.instances,
+		func(ctx context.Context, instance *proxyInstance) (*routingv1.UpdateLoggingConfigResponse, error) {
+			return instance.client.UpdateLoggingConfig(ctx, req, opts...) //nolint:wrapcheck // reported per instance
+		})
+
+	var (
+		failures  []string
+		reachable int
+	)
+
+	for i, instance := range c.instances {
+		switch {
+		case errs[i] != nil:
+			failures = append(failures, instance.address+": "+errs[i].Error())
+		case !resps[i].GetSuccess():
+			reachable++
+
+			failures = append(failures, instance.address+": "+resps[i].GetError())
+		default:
+			reachable++
+		}
+	}
+
+	if reachable == 0 {
+		// Keep the status code, so that Unimplemented is recognized
+		return nil, errs[0]
+	}
+
+	if len(failures) > 0 {
+		return &routingv1.UpdateLoggingConfigResponse{
+			Success: false,
+			Error:   fanOutFailure(len(failures), len(c.instances), failures),
+		}, nil
+	}
+
+	return resps[0], nil
+}
+
+// UpdateCertificates sends the listener certificates to every instance.
+// Failures are reported as for UpdateRoutes.
+//
+//nolint:dupl // same fan-out as UpdateGlobalConfig with different message types
+func (c *fanOutClient) UpdateCertificates(
+	ctx context.Context,
+	req *routingv1.UpdateCertificatesRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.UpdateCertificatesResponse, error) {
+	resps, errs := fanOut(ctx, c.instances,
+		func(ctx context.Context, instance *proxyInstance) (*routingv1.UpdateCertificatesResponse, error) {
+			return instance.client.UpdateCertificates(ctx, req, opts...) //nolint:wrapcheck // reported per instance
+		})
+
+	var (
+		failures  []string
+		reachable int
+	)
+
+	for i, instance := range c.instances {
+		switch {
+		case errs[i] != nil:
+			failures = append(failures, instance.address+": "+errs[i].Error())
+		case !resps[i].GetSuccess():
+			reachable++
+
+			failures = append(failures, instance.address+": "+resps[i].GetError())
+		default:
+			reachable++
+		}
+	}
+
+	if reachable == 0 {
+		// Keep the status code, so that Unimplemented is recognized
+		return nil, errs[0]
+	}
+
+	if len(failures) > 0 {
+		return &routingv1.UpdateCertificatesResponse{
+			Success: false,
+			Error:   fanOutFailure(len(failures), len(c.instances), failures),
+		}, nil
+	}
+
+	return resps[0], nil
+}
+
+// Health reports the instances that answer as healthy if all of them are.
+// The config version is the lowest one, so that a restarted instance
+// triggers a full resync. An error is returned if no instance answers.
+func (c *fanOutClient) Health(
+	ctx context.Context,
+	req *routingv1.HealthRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.HealthResponse, error) {
+	resps, errs := fanOut(ctx, c.instances,
+		func(ctx context.Context, instance *proxyInstance) (*routingv1.HealthResponse, error) {
+			return instance.client.Health(ctx, req, opts...) //nolint:wrapcheck // reported per instance
+		})
+
+	var (
+		health      *routingv1.HealthResponse
+		unreachable []string
+	)
+
+	for i, instance := range c.instances {
+		if errs[i] != nil {
+			unreachable = append(unreachable, instance.address)
+
+			continue
+		}
+
+		if health == nil {
+			health = &routingv1.HealthResponse{Healthy: true, ConfigVersion: resps[i].GetConfigVersion()}
+		}
+
+		health.Healthy = health.GetHealthy() && resps[i].GetHealthy()
+		health.ActiveConnections += resps[i].GetActiveConnections()
+		health.ConfigVersion = min(health.GetConfigVersion(), resps[i].GetConfigVersion())
+	}
+
+	if health == nil {
+		return nil, errors.Wrap(errs[0], "no proxy instance reachable")
+	}
+
+	health.Status = fmt.Sprintf("%d of %d proxy instances reachable", len(c.instances)-len(unreachable), len(c.instances))
+	if len(unreachable) > 0 {
+		health.Status += ", unreachable: " + strings.Join(unreachable, ", ")
+	}
+
+	return health, nil
+}
+
+// GetRoutes returns the routes of the first instance that answers.
+func (c *fanOutClient) GetRoutes(
+	ctx context.Context,
+	req *routingv1.GetRoutesRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.GetRoutesResponse, error) {
+	return firstAnswer(c.instances, func(instance *proxyInstance) (*routingv1.GetRoutesResponse, error) {
+		return instance.client.GetRoutes(ctx, req, opts...) //nolint:wrapcheck // returned as is
+	})
+}
+
+// GetBackendHealth returns the backend health seen by the first instance
+// that answers. Every instance checks the backends on its own, so their
+// results are not added up.
+func (c *fanOutClient) GetBackendHealth(
+	ctx context.Context,
+	req *routingv1.GetBackendHealthRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.GetBackendHealthResponse, error) {
+	return firstAnswer(c.instances, func(instance *proxyInstance) (*routingv1.GetBackendHealthResponse, error) {
+		return instance.client.GetBackendHealth(ctx, req, opts...) //nolint:wrapcheck // returned as is
+	})
+}
+
+// GetRouteStats returns the traffic statistics of all instances added up.
+// It fails unless every instance answers, since the counts of a partial
+// sum would go backwards.
+func (c *fanOutClient) GetRouteStats(
+	ctx context.Context,
+	req *routingv1.GetRouteStatsRequest,
+	opts ...grpc.CallOption,
+) (*routingv1.GetRouteStatsResponse, error) {
+	resps, errs := fanOut(ctx, c.instances,
+		func(ctx context.Context, instance *proxyInstance) (*routingv1.GetRouteStatsResponse, error) {
+			return instance.client.GetRouteStats(ctx, req, opts...) //nolint:wrapcheck // reported per instance
+		})
+
+	for i, instance := range c.instances {
+		if errs[i] != nil {
+			if len(c.instances) == 1 {
+				// Keep the status code, so that Unimplemented is recognized
+				return nil, errs[i]
+			}
+
+			return nil, errors.Wrapf(errs[i], "proxy instance %s", instance.address)
+		}
+	}
+
+	return sumRouteStats(resps)
+}
+
+// StreamRoutes is not supported, every instance has its own stream.
+func (c *fanOutClient) StreamRoutes(
+	context.Context,
+	...grpc.CallOption,
+) (grpc.BidiStreamingClient[routingv1.StreamRoutesRequest, routingv1.StreamRoutesResponse], error) {
+	return nil, status.Error(codes.Unimplemented, "route streams are opened per proxy instance")
+}
+
+// resetStreams closes the route streams, so that the next update sends a
+// full snapshot to every instance.
+func (c *fanOutClient) resetStreams() {
+	for _, instance := range c.instances {
+		instance.stream.Close()
+	}
+}
+
+// addresses returns the addresses of the instances.
+func (c *fanOutClient) addresses() []string {
+	addresses := make([]string, 0, len(c.instances))
+	for _, instance := range c.instances {
+		addresses = append(addresses, instance.address)
+	}
+
+	return addresses
+}
+
+// status returns the sync state of every instance for the PingoraConfig status.
+func (c *fanOutClient) status() []v1alpha1.ProxyInstanceStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make([]v1alpha1.ProxyInstanceStatus, 0, len(c.instances))
+	for _, instance := range c.instances {
+		statuses = append(statuses, v1alpha1.ProxyInstanceStatus{
+			Address:       instance.address,
+			ConfigVersion: instance.appliedVersion,
+			Error:         instance.lastErr,
+		})
+	}
+
+	return statuses
+}
+
+// close closes the streams and connections of all instances.
+func (c *fanOutClient) close() error {
+	var errs []error
+
+	for _, instance := range c.instances {
+		instance.stream.Close()
+
+		if err := instance.conn.Close(); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to close connection to %s", instance.address))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// firstAnswer calls call on the instances in order until one succeeds and
+// returns its result, or the last error.
+func firstAnswer[T any](instances []*proxyInstance, call func(*proxyInstance) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+
+	for _, instance := range instances {
+		result, err = call(instance)
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return result, err
+}
+
+// sumRouteStats adds up the traffic statistics of several instances, which
+// must use the same duration buckets.
+func sumRouteStats(resps []*routingv1.GetRouteStatsResponse) (*routingv1.GetRouteStatsResponse, error) {
+	if len(resps) == 0 {
+		return &routingv1.GetRouteStatsResponse{}, nil
+	}
+
+	total := &routingv1.GetRouteStatsResponse{DurationBuckets: resps[0].GetDurationBuckets()}
+	routes := make(map[string]*routingv1.RouteStats)
+	listeners := make(map[uint32]*routingv1.ListenerStats)
+
+	for _, resp := range resps {
+		if !slices.Equal(resp.GetDurationBuckets(), total.GetDurationBuckets()) {
+			return nil, errors.New("proxy instances report different request duration buckets")
+		}
+
+		for _, stats := range resp.GetRoutes() {
+			sum, ok := routes[stats.GetRouteId()]
+			if !ok {
+				sum = &routingv1.RouteStats{RouteId: stats.GetRouteId(), Durations: &routingv1.RequestDurations{}}
+				routes[stats.GetRouteId()] = sum
+				total.Routes = append(total.Routes, sum)
+			}
+
+			sum.Responses = addStatusClassCounts(sum.GetResponses(), stats.GetResponses())
+			addRequestDurations(sum.GetDurations(), stats.GetDurations())
+		}
+
+		for _, stats := range resp.GetListeners() {
+			sum, ok := listeners[stats.GetPort()]
+			if !ok {
+				sum = &routingv1.ListenerStats{Port: stats.GetPort(), Durations: &routingv1.RequestDurations{}}
+				listeners[stats.GetPort()] = sum
+				total.Listeners = append(total.Listeners, sum)
+			}
+
+			sum.Responses = addStatusClassCounts(sum.GetResponses(), stats.GetResponses())
+			addRequestDurations(sum.GetDurations(), stats.GetDurations())
+		}
+	}
+
+	return total, nil
+}
+
+func addStatusClassCounts(sum, counts []*routingv1.StatusClassCount) []*routingv1.StatusClassCount {
+	for _, count := range counts {
+		i := slices.IndexFunc(sum, func(c *routingv1.StatusClassCount) bool {
+			return c.GetStatusClass() == count.GetStatusClass()
+		})
+		if i < 0 {
+			sum = append(sum, &routingv1.StatusClassCount{StatusClass: count.GetStatusClass()})
+			i = len(sum) - 1
+		}
+
+		sum[i].Count += count.GetCount()
+	}
+
+	return sum
+}
+
+func addRequestDurations(sum, durations *routingv1.RequestDurations) {
+	sum.Count += durations.GetCount()
+	sum.SumSeconds += durations.GetSumSeconds()
+
+	for i, count := range durations.GetBucketCounts() {
+		if i == len(sum.GetBucketCounts()) {
+			sum.BucketCounts = append(sum.BucketCounts, 0)
+		}
+
+		sum.BucketCounts[i] += count
+	}
+}
+
+// fanOutFailure describes the instances that failed an update.
+func fanOutFailure(failed, total int, failures []string) string {
+	return fmt.Sprintf("%d of %d proxy instances failed: %s", failed, total, strings.Join(failures, "; "))
+}
@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+// startFanOut starts count mock proxies and a fanOutClient connected to them.
+func startFanOut(t *testing.T, count int) ([]*mockproxy.Server, *fanOutClient) {
+	t.Helper()
+
+	servers := make([]*mockproxy.Server, 0, count)
+	addresses := make([]string, 0, count)
+	conns := make([]*grpc.ClientConn, 0, count)
+
+	for range count {
+		server := mockproxy.New()
+
+		addr, err := server.Start()
+		require.NoError(t, err)
+		t.Cleanup(server.Stop)
+
+		conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+
+		servers = append(servers, server)
+		addresses = append(addresses, addr)
+		conns = append(conns, conn)
+	}
+
+	newClient := func(conn *grpc.ClientConn) routingv1.RoutingServiceClient {
+		return routingv1.NewRoutingServiceClient(conn)
+	}
+
+	client := newFanOutClient(addresses, conns, newClient, slog.Default(), nil)
+	t.Cleanup(func() { _ = client.close() })
+
+	return servers, client
+}
+
+func TestFanOutClient_UpdateRoutes(t *testing.T) {
+	t.Parallel()
+
+	servers, client := startFanOut(t, 3)
+	ctx := context.Background()
+
+	resp, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+		Version:    1,
+		HttpRoutes: []*routingv1.HTTPRoute{{Id: "default/a"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	assert.Equal(t, uint64(1), resp.GetAppliedVersion())
+
+	for _, server := range servers {
+		assert.Equal(t, uint64(1), server.AppliedVersion())
+		assert.Len(t, server.HTTPRoutes(), 1)
+	}
+
+	// One rejecting instance fails the update, the others still apply it
+	servers[1].RejectUpdates("bad route")
+
+	resp, err = client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 2})
+	require.NoError(t, err)
+	assert.False(t, resp.GetSuccess())
+	assert.Contains(t, resp.GetError(), "1 of 3 proxy instances failed")
+	assert.Contains(t, resp.GetError(), servers[1].Addr()+": bad route")
+
+	statuses := client.status()
+	require.Len(t, statuses, 3)
+	assert.Equal(t, uint64(2), statuses[0].ConfigVersion)
+	assert.Empty(t, statuses[0].Error)
+	assert.Equal(t, uint64(1), statuses[1].ConfigVersion)
+	assert.Equal(t, "bad route", statuses[1].Error)
+
+	// Without any reachable instance, the update fails
+	for _, server := range servers {
+		server.Stop()
+	}
+
+	_, err = client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 3})
+	require.Error(t, err)
+}
+
+func TestFanOutClient_UpdateRoutesVersionConflict(t *testing.T) {
+	t.Parallel()
+
+	servers, client := startFanOut(t, 2)
+	ctx := context.Background()
+
+	resp, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 1})
+	require.NoError(t, err)
+	require.True(t, resp.GetSuccess())
+
+	// Another sync reaches only the second instance
+	_, err = client.instances[1].client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 4})
+	require.NoError(t, err)
+
+	resp, err = client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 2, ExpectedVersion: proto.Uint64(1)})
+	require.NoError(t, err)
+	assert.False(t, resp.GetSuccess())
+	assert.True(t, resp.GetVersionConflict())
+	assert.Equal(t, uint64(4), resp.GetAppliedVersion())
+	assert.Equal(t, uint64(2), servers[0].AppliedVersion())
+
+	// Every instance is expected to run the version it reported last
+	resp, err = client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 5, ExpectedVersion: proto.Uint64(4)})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+
+	for _, server := range servers {
+		assert.Equal(t, uint64(5), server.AppliedVersion())
+	}
+}
+
+func TestFanOutClient_DeleteRoutes(t *testing.T) {
+	t.Parallel()
+
+	servers, client := startFanOut(t, 2)
+	ctx := context.Background()
+
+	resp, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+		Version:    1,
+		HttpRoutes: []*routingv1.HTTPRoute{{Id: "default/a"}, {Id: "default/b"}},
+	})
+	require.NoError(t, err)
+	require.True(t, resp.GetSuccess())
+
+	resp, err = client.DeleteRoutes(ctx, &routingv1.DeleteRoutesRequest{Version: 2, HttpRouteIds: []string{"default/a"}})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+
+	for _, server := range servers {
+		assert.Len(t, server.HTTPRoutes(), 1)
+	}
+
+	// The streams continue with deltas from the version DeleteRoutes applied
+	resp, err = client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+		Version:    3,
+		HttpRoutes: []*routingv1.HTTPRoute{{Id: "default/b"}, {Id: "default/c"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+
+	for _, instance := range client.instances {
+		assert.Equal(t, uint64(3), instance.stream.appliedVersion)
+		assert.Len(t, instance.stream.httpRoutes, 2)
+	}
+
+	servers[1].RejectUpdates("read-only")
+
+	resp, err = client.DeleteRoutes(ctx, &routingv1.DeleteRoutesRequest{Version: 4, HttpRouteIds: []string{"default/b"}})
+	require.NoError(t, err)
+	assert.False(t, resp.GetSuccess())
+	assert.Contains(t, resp.GetError(), servers[1].Addr()+": read-only")
+}
+
+func TestFanOutClient_Health(t *testing.T) {
+	t.Parallel()
+
+	servers, client := startFanOut(t, 2)
+	ctx := context.Background()
+
+	resp, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 4})
+	require.NoError(t, err)
+	require.True(t, resp.GetSuccess())
+
+	health, err := client.Health(ctx, &routingv1.HealthRequest{})
+	require.NoError(t, err)
+	assert.True(t, health.GetHealthy())
+	assert.Equal(t, uint64(4), health.GetConfigVersion())
+
+	// A restarted instance reports the lowest version, which triggers a resync
+	servers[0].Restart()
+	servers[1].SetHealthy(false)
+
+	health, err = client.Health(ctx, &routingv1.HealthRequest{})
+	require.NoError(t, err)
+	assert.False(t, health.GetHealthy())
+	assert.Zero(t, health.GetConfigVersion())
+
+	servers[1].Stop()
+
+	health, err = client.Health(ctx, &routingv1.HealthRequest{})
+	require.NoError(t, err)
+	assert.True(t, health.GetHealthy())
+	assert.Contains(t, health.GetStatus(), "unreachable: "+servers[1].Addr())
+}
+
+func TestFanOutClient_GetRouteStats(t *testing.T) {
+	t.Parallel()
+
+	servers, client := startFanOut(t, 2)
+	ctx := context.Background()
+
+	for _, server := range servers {
+		server.SetRouteStats(&routingv1.GetRouteStatsResponse{
+			DurationBuckets: []float64{0.1, 1},
+			Routes: []*routingv1.RouteStats{{
+				RouteId:   "default/app",
+				Responses: []*routingv1.StatusClassCount{{StatusClass: 2, Count: 5}},
+				Durations: &routingv1.RequestDurations{Count: 5, SumSeconds: 0.5, BucketCounts: []uint64{4, 5}},
+			}},
+			Listeners: []*routingv1.ListenerStats{{
+				Port:      80,
+				Responses: []*routingv1.StatusClassCount{{StatusClass: 4, Count: 1}},
+			}},
+		})
+	}
+
+	stats, err := client.GetRouteStats(ctx, &routingv1.GetRouteStatsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.1, 1}, stats.GetDurationBuckets())
+	require.Len(t, stats.GetRoutes(), 1)
+	assert.Equal(t, uint64(10), stats.GetRoutes()[0].GetResponses()[0].GetCount())
+	assert.Equal(t, uint64(10), stats.GetRoutes()[0].GetDurations().GetCount())
+	assert.Equal(t, []uint64{8, 10}, stats.GetRoutes()[0].GetDurations().GetBucketCounts())
+	require.Len(t, stats.GetListeners(), 1)
+	assert.Equal(t, uint64(2), stats.GetListeners()[0].GetResponses()[0].GetCount())
+
+	// Different buckets cannot be added up
+	servers[1].SetRouteStats(&routingv1.GetRouteStatsResponse{DurationBuckets: []float64{0.5}})
+
+	_, err = client.GetRouteStats(ctx, &routingv1.GetRouteStatsRequest{})
+	require.Error(t, err)
+
+	// A partial sum would make the counts go backwards
+	servers[1].Stop()
+
+	_, err = client.GetRouteStats(ctx, &routingv1.GetRouteStatsRequest{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), servers[1].Addr())
+}
@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+const (
+	// DefaultProxyHealthInterval is the default interval between proxy health checks.
+	DefaultProxyHealthInterval = 15 * time.Second
+
+	// DefaultProxyUnreachableThreshold is the default time the proxy must be
+	// unreachable before it is reported down in status.
+	DefaultProxyUnreachableThreshold = time.Minute
+
+	// GatewayReasonProxyUnreachable is the reason of the Programmed=False
+	// Gateway condition while the proxy is down.
+	GatewayReasonProxyUnreachable = "ProxyUnreachable"
+)
+
+// ProxyHealthMonitor is a manager.Runnable that polls the Health RPC of the
+// proxy. Once the proxy has been unreachable for longer than Threshold, it
+// marks the PingoraConfig disconnected and the Gateways not programmed, and
+// reverts both when the proxy answers again.
+type ProxyHealthMonitor struct {
+	Syncer    *PingoraRouteSyncer
+	Interval  time.Duration
+	Threshold time.Duration
+	Metrics   metrics.Collector
+	Logger    *slog.Logger
+
+	// events requests a reconcile of the Gateways when the proxy goes down or recovers.
+	events chan event.GenericEvent
+
+	mu               sync.RWMutex
+	unreachableSince time.Time
+	down             bool
+
+	// now defaults to time.Now.
+	now func() time.Time
+}
+
+// NewProxyHealthMonitor creates a new ProxyHealthMonitor.
+func NewProxyHealthMonitor(
+	syncer *PingoraRouteSyncer,
+	interval, threshold time.Duration,
+	metricsCollector metrics.Collector,
+	logger *slog.Logger,
+) *ProxyHealthMonitor {
+	if metricsCollector == nil {
+		metricsCollector = metrics.NewNoopCollector()
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &ProxyHealthMonitor{
+		Syncer:    syncer,
+		Interval:  interval,
+		Threshold: threshold,
+		Metrics:   metricsCollector,
+		Logger:    logger.With("component", "proxy-health-monitor"),
+		events:    make(chan event.GenericEvent, 1),
+		now:       time.Now,
+	}
+}
+
+// Start implements manager.Runnable.
+func (m *ProxyHealthMonitor) Start(ctx context.Context) error {
+	if m.Interval <= 0 {
+		return nil
+	}
+
+	ticker := time.NewTicker(m.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			m.poll(ctx)
+		}
+	}
+}
+
+// ProxyDown reports whether the proxy has been unreachable for longer than the threshold.
+func (m *ProxyHealthMonitor) ProxyDown() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.down
+}
+
+// GatewayEvents returns the channel on which the monitor requests a
+// reconcile of the Gateways of the class.
+func (m *ProxyHealthMonitor) GatewayEvents() <-chan event.GenericEvent {
+	return m.events
+}
+
+// poll checks the health of the proxy once and reports state changes.
+func (m *ProxyHealthMonitor) poll(ctx context.Context) {
+	if !m.Syncer.IsConnected() {
+		// A sync reconnects, subject to the reconnect backoff
+		if _, _, err := m.Syncer.RequestSync(ctx); err != nil {
+			m.Logger.Debug("resync of disconnected proxy failed", "error", err)
+		}
+	}
+
+	err := m.Syncer.ProxyHealth(ctx)
+	m.Metrics.RecordProxyHealthy(ctx, err == nil)
+
+	if !m.observe(err) {
+		return
+	}
+
+	down := m.ProxyDown()
+	if down {
+		m.Logger.Warn("Pingora proxy unreachable", "threshold", m.Threshold, "error", err)
+	} else {
+		m.Logger.Info("Pingora proxy reachable again")
+	}
+
+	m.Syncer.recordProxyHealth(ctx, !down, err)
+
+	select {
+	case m.events <- event.GenericEvent{Object: &gatewayv1.GatewayClass{}}:
+	default:
+		// A reconcile of the Gateways is already pending
+	}
+}
+
+// observe records the result of a health check and reports whether the
+// proxy went down or recovered.
+func (m *ProxyHealthMonitor) observe(err error) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err == nil {
+		changed := m.down
+		m.unreachableSince = time.Time{}
+		m.down = false
+
+		return changed
+	}
+
+	now := m.now()
+	if m.unreachableSince.IsZero() {
+		m.unreachableSince = now
+	}
+
+	if !m.down && now.Sub(m.unreachableSince) >= m.Threshold {
+		m.down = true
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestProxyHealthMonitor_Poll(t *testing.T) {
+	t.Parallel()
+
+	syncer, written := newVersionTestSyncer(t, 0)
+	syncer.configName = "pingora"
+
+	proxy := &fakeRoutingClient{healthErr: errors.New("connection refused")}
+	syncer.grpcClient = proxy
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	monitor := NewProxyHealthMonitor(syncer, time.Second, time.Minute, nil, nil)
+	monitor.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// Unreachable, but not for longer than the threshold yet
+	monitor.poll(ctx)
+	assert.False(t, monitor.ProxyDown())
+	assert.Empty(t, *written)
+
+	now = now.Add(time.Minute)
+	monitor.poll(ctx)
+	assert.True(t, monitor.ProxyDown())
+	require.Len(t, *written, 1)
+	assert.False(t, (*written)[0].Connected)
+
+	ready := meta.FindStatusCondition((*written)[0].Conditions, v1alpha1.ConditionTypeReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, v1alpha1.ReasonConnectionFailed, ready.Reason)
+	assert.Len(t, monitor.GatewayEvents(), 1)
+
+	// Staying down reports nothing new
+	now = now.Add(time.Minute)
+	monitor.poll(ctx)
+	assert.Len(t, *written, 1)
+
+	proxy.healthErr = nil
+	proxy.health = &routingv1.HealthResponse{Healthy: true}
+
+	<-monitor.GatewayEvents()
+	monitor.poll(ctx)
+	assert.False(t, monitor.ProxyDown())
+	require.Len(t, *written, 2)
+	assert.True(t, (*written)[1].Connected)
+	assert.Len(t, monitor.GatewayEvents(), 1)
+}
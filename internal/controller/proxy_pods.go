@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
+)
+
+// ProxyPodReconciler pushes the full route config when a proxy pod selected
+// by spec.proxyRef of the PingoraConfig becomes ready. A restarted proxy
+// starts without routes, and would otherwise serve 404s until the next route
+// change or proxy version check.
+type ProxyPodReconciler struct {
+	client.Client
+
+	RouteSyncer *PingoraRouteSyncer
+}
+
+// Reconcile resyncs all routes if the pod is a ready proxy pod.
+func (r *ProxyPodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var pod corev1.Pod
+
+	if err := r.Get(ctx, req.NamespacedName, &pod); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err) //nolint:wrapcheck // controller-runtime handles it
+	}
+
+	if !isPodReady(&pod) {
+		return ctrl.Result{}, nil
+	}
+
+	resolved, err := r.RouteSyncer.ConfigResolver.ResolveFromGatewayClassName(ctx, r.RouteSyncer.GatewayClassName)
+	if err != nil {
+		// Config errors are reported by route syncs
+		return ctrl.Result{}, nil //nolint:nilerr // not a pod error
+	}
+
+	if resolved.ProxySelector == nil || pod.Namespace != resolved.ProxyNamespace ||
+		!resolved.ProxySelector.Matches(labels.Set(pod.Labels)) {
+		return ctrl.Result{}, nil
+	}
+
+	logging.FromContext(ctx).Info("proxy pod became ready, pushing full route config",
+		"pod", req.NamespacedName.String())
+
+	result, _, err := r.RouteSyncer.forceFullResync(ctx)
+
+	return result, err
+}
+
+// SetupWithManager registers the controller with the manager.
+func (r *ProxyPodReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		Named("proxy-pod").
+		For(&corev1.Pod{}, builder.WithPredicates(PodBecameReadyPredicate())).
+		Complete(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to setup proxy pod controller")
+	}
+
+	return nil
+}
+
+// PodBecameReadyPredicate passes Pod updates from not ready to ready. Pods
+// that exist when the controller starts are synced on startup anyway.
+func PodBecameReadyPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc:  func(event.CreateEvent) bool { return false },
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, ok := e.ObjectOld.(*corev1.Pod)
+			if !ok {
+				return false
+			}
+
+			newPod, ok := e.ObjectNew.(*corev1.Pod)
+
+			return ok && !isPodReady(oldPod) && isPodReady(newPod)
+		},
+	}
+}
+
+// trimPod keeps only the Pod fields read by ProxyPodReconciler, so that the
+// cache of all pods in the cluster stays small.
+func trimPod(obj any) (any, error) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return obj, nil
+	}
+
+	conditions := make([]corev1.PodCondition, 0, 1)
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			conditions = append(conditions, corev1.PodCondition{Type: condition.Type, Status: condition.Status})
+		}
+	}
+
+	return &corev1.Pod{
+		TypeMeta: pod.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            pod.Name,
+			Namespace:       pod.Namespace,
+			UID:             pod.UID,
+			ResourceVersion: pod.ResourceVersion,
+			Labels:          pod.Labels,
+		},
+		Status: corev1.PodStatus{Conditions: conditions},
+	}, nil
+}
+
+// isPodReady reports whether the Ready condition of the pod is true.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
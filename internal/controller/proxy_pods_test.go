@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+func newProxyPod(namespace, name string, labels map[string]string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue},
+				{Type: corev1.PodReady, Status: status, Reason: "ContainersReady"},
+			},
+		},
+	}
+}
+
+func TestPodBecameReadyPredicate(t *testing.T) {
+	t.Parallel()
+
+	proxyLabels := map[string]string{"app": "pingora-proxy"}
+
+	tests := []struct {
+		name     string
+		oldReady bool
+		newReady bool
+		expected bool
+	}{
+		{name: "became ready", oldReady: false, newReady: true, expected: true},
+		{name: "stays ready", oldReady: true, newReady: true, expected: false},
+		{name: "became not ready", oldReady: true, newReady: false, expected: false},
+		{name: "stays not ready", oldReady: false, newReady: false, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, PodBecameReadyPredicate().Update(event.UpdateEvent{
+				ObjectOld: newProxyPod("pingora-system", "proxy-0", proxyLabels, tt.oldReady),
+				ObjectNew: newProxyPod("pingora-system", "proxy-0", proxyLabels, tt.newReady),
+			}))
+		})
+	}
+
+	assert.False(t, PodBecameReadyPredicate().Create(event.CreateEvent{
+		Object: newProxyPod("pingora-system", "proxy-0", proxyLabels, true),
+	}))
+}
+
+func TestTrimPod(t *testing.T) {
+	t.Parallel()
+
+	pod := newProxyPod("pingora-system", "proxy-0", map[string]string{"app": "pingora-proxy"}, true)
+
+	trimmed, err := trimPod(pod)
+	require.NoError(t, err)
+
+	trimmedPod, ok := trimmed.(*corev1.Pod)
+	require.True(t, ok)
+	assert.Equal(t, "proxy-0", trimmedPod.Name)
+	assert.Equal(t, pod.Labels, trimmedPod.Labels)
+	assert.Empty(t, trimmedPod.Spec.NodeName)
+	assert.Equal(t, []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		trimmedPod.Status.Conditions)
+	assert.True(t, isPodReady(trimmedPod))
+}
+
+func TestProxyPodReconciler_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	proxyRef := &v1alpha1.ProxyReference{
+		Namespace: "pingora-system",
+		Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "pingora-proxy"}},
+	}
+
+	tests := []struct {
+		name           string
+		proxyRef       *v1alpha1.ProxyReference
+		pod            *corev1.Pod
+		expectedResync bool
+	}{
+		{
+			name:           "ready proxy pod",
+			proxyRef:       proxyRef,
+			pod:            newProxyPod("pingora-system", "proxy-0", map[string]string{"app": "pingora-proxy"}, true),
+			expectedResync: true,
+		},
+		{
+			name:     "proxy pod not ready",
+			proxyRef: proxyRef,
+			pod:      newProxyPod("pingora-system", "proxy-0", map[string]string{"app": "pingora-proxy"}, false),
+		},
+		{
+			name:     "other labels",
+			proxyRef: proxyRef,
+			pod:      newProxyPod("pingora-system", "web-0", map[string]string{"app": "web"}, true),
+		},
+		{
+			name:     "other namespace",
+			proxyRef: proxyRef,
+			pod:      newProxyPod("default", "proxy-0", map[string]string{"app": "pingora-proxy"}, true),
+		},
+		{
+			name: "no proxy reference",
+			pod:  newProxyPod("pingora-system", "proxy-0", map[string]string{"app": "pingora-proxy"}, true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			scheme := runtime.NewScheme()
+			require.NoError(t, corev1.AddToScheme(scheme))
+			require.NoError(t, v1alpha1.AddToScheme(scheme))
+			require.NoError(t, gatewayv1.Install(scheme))
+
+			pingoraConfig := &v1alpha1.PingoraConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+				Spec:       v1alpha1.PingoraConfigSpec{Address: "pingora-proxy:50051", ProxyRef: tt.proxyRef},
+			}
+
+			cli := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(pingoraConfig, newPingoraGatewayClass("pingora"), tt.pod).
+				Build()
+
+			resyncs := 0
+
+			syncer := &PingoraRouteSyncer{
+				Client:           cli,
+				GatewayClassName: "pingora",
+				ConfigResolver:   config.NewPingoraResolver(cli, "pingora-system"),
+				Metrics:          metrics.NewNoopCollector(),
+				Logger:           slog.Default(),
+			}
+			syncer.coordinator = NewSyncCoordinator(func(context.Context) (ctrl.Result, *SyncResult, error) {
+				resyncs++
+
+				return ctrl.Result{}, nil, nil
+			}, 0, nil)
+			syncer.setAppliedConfig(AppliedConfig{Hash: "abc", Version: 5})
+
+			reconciler := &ProxyPodReconciler{Client: cli, RouteSyncer: syncer}
+
+			_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{Namespace: tt.pod.Namespace, Name: tt.pod.Name},
+			})
+			require.NoError(t, err)
+
+			if tt.expectedResync {
+				assert.Equal(t, 1, resyncs)
+				assert.Empty(t, syncer.GetAppliedConfig().Hash, "applied config must be reset")
+			} else {
+				assert.Zero(t, resyncs)
+				assert.Equal(t, "abc", syncer.GetAppliedConfig().Hash)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// readinessHealthTTL is how long the result of a proxy health check is
+// reused by the readiness check, so that frequent probes do not each cost
+// a call to the proxy.
+const readinessHealthTTL = 10 * time.Second
+
+var (
+	errStartupSyncPending = errors.New("startup route sync has not completed")
+	errProxyUnhealthy     = errors.New("Pingora proxy reports unhealthy")
+)
+
+// ReadinessCheck reports the controller ready once the routes have been
+// synced to the proxy after startup and the proxy answers health checks.
+//
+// Replicas that are not the leader never sync and are always ready, so
+// that standby replicas do not block rollouts. In dry-run mode the proxy
+// health is not checked.
+type ReadinessCheck struct {
+	syncer  *PingoraRouteSyncer
+	elected <-chan struct{}
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	lastErr   error
+
+	// now defaults to time.Now.
+	now func() time.Time
+}
+
+// NewReadinessCheck creates a ReadinessCheck for syncer. elected is closed
+// once this replica is the leader, see manager.Manager.Elected.
+func NewReadinessCheck(syncer *PingoraRouteSyncer, elected <-chan struct{}) *ReadinessCheck {
+	return &ReadinessCheck{
+		syncer:  syncer,
+		elected: elected,
+		now:     time.Now,
+	}
+}
+
+// Check implements healthz.Checker.
+func (c *ReadinessCheck) Check(req *http.Request) error {
+	select {
+	case <-c.elected:
+	default:
+		return nil
+	}
+
+	if !c.syncer.StartupSynced() {
+		return errStartupSyncPending
+	}
+
+	// Dry runs never connect to the proxy
+	if c.syncer.DryRun {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checkedAt.IsZero() && c.now().Sub(c.checkedAt) < readinessHealthTTL {
+		return c.lastErr
+	}
+
+	c.lastErr = c.syncer.ProxyHealth(req.Context())
+	c.checkedAt = c.now()
+
+	return c.lastErr
+}
+
+// StartupSynced reports whether the proxy has confirmed a route sync since
+// the controller started.
+func (s *PingoraRouteSyncer) StartupSynced() bool {
+	return s.startupSynced.Load()
+}
+
+// ProxyHealth asks the proxy for its health and returns an error if it is
+// unreachable or unhealthy.
+func (s *PingoraRouteSyncer) ProxyHealth(ctx context.Context) error {
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		return errors.New("not connected to Pingora proxy")
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.Health(rpcCtx, &routingv1.HealthRequest{})
+	grpcDuration := time.Since(grpcStart)
+
+	if err != nil {
+		s.Metrics.RecordGRPCCall(ctx, "Health", "error", grpcDuration)
+
+		return errors.Wrap(err, "failed to check proxy health")
+	}
+
+	s.Metrics.RecordGRPCCall(ctx, "Health", "success", grpcDuration)
+
+	if !resp.GetHealthy() {
+		return errProxyUnhealthy
+	}
+
+	return nil
+}
+
+// syncOnStartup syncs all routes once the controller starts, so that the
+// proxy is programmed and the controller becomes ready even when no route
+// event arrives.
+func (s *PingoraRouteSyncer) syncOnStartup(ctx context.Context) {
+	if _, _, err := s.RequestSync(ctx); err != nil {
+		s.Logger.Error("startup route sync failed", "error", err)
+	}
+}
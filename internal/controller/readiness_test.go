@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestReadinessCheck(t *testing.T) {
+	t.Parallel()
+
+	elected := make(chan struct{})
+	proxy := &fakeRoutingClient{health: &routingv1.HealthResponse{Healthy: true}}
+	syncer := &PingoraRouteSyncer{Metrics: metrics.NewNoopCollector(), grpcClient: proxy}
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	check := NewReadinessCheck(syncer, elected)
+	check.now = func() time.Time { return now }
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+
+	// Standby replicas are ready
+	require.NoError(t, check.Check(req))
+
+	close(elected)
+	require.ErrorIs(t, check.Check(req), errStartupSyncPending)
+
+	syncer.setAppliedConfig(AppliedConfig{Hash: "abc", Version: 1})
+	require.NoError(t, check.Check(req))
+
+	// The cached result is reused until it expires
+	proxy.health = &routingv1.HealthResponse{Healthy: false}
+	require.NoError(t, check.Check(req))
+
+	now = now.Add(readinessHealthTTL)
+	require.ErrorIs(t, check.Check(req), errProxyUnhealthy)
+
+	proxy.healthErr = errors.New("unavailable")
+	now = now.Add(readinessHealthTTL)
+	require.Error(t, check.Check(req))
+
+	// Losing the connection does not undo the startup sync
+	syncer.resetAppliedConfig()
+	assert.True(t, syncer.StartupSynced())
+}
+
+func TestPingoraRouteSyncer_SyncRequiresLeadership(t *testing.T) {
+	t.Parallel()
+
+	elected := make(chan struct{})
+	syncer := &PingoraRouteSyncer{
+		Metrics: metrics.NewNoopCollector(),
+		Logger:  slog.Default(),
+		Elected: elected,
+	}
+
+	// A standby replica neither connects nor syncs
+	result, syncResult, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, result)
+	assert.Nil(t, syncResult)
+	assert.False(t, syncer.IsConnected())
+	assert.False(t, syncer.isLeader())
+
+	close(elected)
+	assert.True(t, syncer.isLeader())
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// reconnectBaseDelay is the delay after the first failed connect.
+	reconnectBaseDelay = time.Second
+
+	// reconnectMaxDelay caps the delay between connect attempts.
+	reconnectMaxDelay = 2 * time.Minute
+
+	// circuitOpenThreshold is the number of consecutive failed connects
+	// after which the circuit opens and the controller reports not ready.
+	circuitOpenThreshold = 5
+)
+
+// Proxy connection states, as reported by the pingora_proxy_connection_state metric.
+const (
+	ConnectionStateConnected    = "connected"
+	ConnectionStateDisconnected = "disconnected"
+	ConnectionStateCircuitOpen  = "circuit_open"
+)
+
+// errCircuitOpen is reported by the readiness check while the circuit is open.
+var errCircuitOpen = errors.New("circuit open: repeated failures connecting to Pingora proxy")
+
+// reconnectBackoff spaces out connect attempts to the proxy with exponential
+// backoff and jitter, so that an unreachable proxy is not hammered by every
+// route event, and opens the circuit after repeated failures.
+// The zero value is ready to use.
+type reconnectBackoff struct {
+	mu sync.Mutex
+
+	failures    int
+	nextAttempt time.Time
+
+	// now and jitter default to time.Now and equalJitter.
+	now    func() time.Time
+	jitter func(delay time.Duration) time.Duration
+}
+
+// wait returns how long to wait before the next connect attempt, zero if an
+// attempt is allowed now.
+func (b *reconnectBackoff) wait() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return max(b.nextAttempt.Sub(b.clock()), 0)
+}
+
+// failure records a failed connect and returns the delay before the next attempt.
+func (b *reconnectBackoff) failure() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+
+	delay := reconnectBaseDelay
+	for i := 1; i < b.failures && delay < reconnectMaxDelay; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, reconnectMaxDelay)
+	if b.jitter != nil {
+		delay = b.jitter(delay)
+	} else {
+		delay = equalJitter(delay)
+	}
+
+	b.nextAttempt = b.clock().Add(delay)
+
+	return delay
+}
+
+// success records a successful connect and closes the circuit.
+func (b *reconnectBackoff) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.nextAttempt = time.Time{}
+}
+
+// circuitOpen reports whether connects failed too often in a row.
+func (b *reconnectBackoff) circuitOpen() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.failures >= circuitOpenThreshold
+}
+
+// clock returns the current time.
+func (b *reconnectBackoff) clock() time.Time {
+	if b.now != nil {
+		return b.now()
+	}
+
+	return time.Now()
+}
+
+// equalJitter returns a random delay between half of delay and delay, so that
+// controllers failing together do not reconnect in lockstep.
+func equalJitter(delay time.Duration) time.Duration {
+	half := delay / 2
+
+	return half + rand.N(delay-half+1) //nolint:gosec // jitter needs no cryptographic randomness
+}
+
+// ConnectionState returns the state of the connection to the proxy.
+func (s *PingoraRouteSyncer) ConnectionState() string {
+	switch {
+	case s.reconnect.circuitOpen():
+		return ConnectionStateCircuitOpen
+	case s.IsConnected():
+		return ConnectionStateConnected
+	default:
+		return ConnectionStateDisconnected
+	}
+}
+
+// recordConnectionState records the connection state in metrics.
+func (s *PingoraRouteSyncer) recordConnectionState(ctx context.Context) {
+	if s.Metrics != nil {
+		s.Metrics.RecordProxyConnectionState(ctx, s.ConnectionState())
+	}
+}
+
+// CircuitCheck is a readiness check that fails while the circuit to the proxy is open.
+func (s *PingoraRouteSyncer) CircuitCheck(_ *http.Request) error {
+	if s.reconnect.circuitOpen() {
+		return errCircuitOpen
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconnectBackoff(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	backoff := reconnectBackoff{
+		now:    func() time.Time { return now },
+		jitter: func(delay time.Duration) time.Duration { return delay },
+	}
+
+	assert.Zero(t, backoff.wait())
+
+	delays := make([]time.Duration, 0, 9)
+	for range 9 {
+		delays = append(delays, backoff.failure())
+	}
+
+	assert.Equal(t, []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second,
+		32 * time.Second, 64 * time.Second, reconnectMaxDelay, reconnectMaxDelay,
+	}, delays)
+	assert.Equal(t, reconnectMaxDelay, backoff.wait())
+	assert.True(t, backoff.circuitOpen())
+
+	now = now.Add(reconnectMaxDelay)
+	assert.Zero(t, backoff.wait())
+
+	backoff.success()
+	assert.False(t, backoff.circuitOpen())
+	assert.Equal(t, time.Second, backoff.failure())
+}
+
+func TestEqualJitter(t *testing.T) {
+	t.Parallel()
+
+	for range 100 {
+		delay := equalJitter(10 * time.Second)
+		assert.GreaterOrEqual(t, delay, 5*time.Second)
+		assert.LessOrEqual(t, delay, 10*time.Second)
+	}
+}
+
+func TestPingoraRouteSyncer_CircuitCheck(t *testing.T) {
+	t.Parallel()
+
+	syncer := &PingoraRouteSyncer{}
+	require.NoError(t, syncer.CircuitCheck(nil))
+	assert.Equal(t, ConnectionStateDisconnected, syncer.ConnectionState())
+
+	for range circuitOpenThreshold {
+		syncer.reconnect.failure()
+	}
+
+	require.ErrorIs(t, syncer.CircuitCheck(nil), errCircuitOpen)
+	assert.Equal(t, ConnectionStateCircuitOpen, syncer.ConnectionState())
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+// allRulesDropped reports whether every rule of a route is dropped for
+// invalid regular expressions, which leaves nothing to accept.
+func allRulesDropped(issues []ingress.RegexIssue, ruleCount int) bool {
+	return len(issues) > 0 && len(issues) == ruleCount
+}
+
+// droppedRulesMessage returns the Accepted message of a route whose rules
+// are all dropped.
+func droppedRulesMessage(issues []ingress.RegexIssue) string {
+	return "All rules dropped: " + ingress.JoinRegexIssues(issues)
+}
+
+// partiallyInvalidCondition builds the PartiallyInvalid route condition for
+// rules dropped for invalid regular expressions. Per Gateway API it is only
+// set on accepted routes with both valid and dropped rules, so it returns
+// nil otherwise.
+func partiallyInvalidCondition(
+	issues []ingress.RegexIssue,
+	ruleCount int,
+	accepted bool,
+	generation int64,
+	now metav1.Time,
+) *metav1.Condition {
+	if !accepted || len(issues) == 0 || allRulesDropped(issues, ruleCount) {
+		return nil
+	}
+
+	return &metav1.Condition{
+		Type:               string(gatewayv1.RouteConditionPartiallyInvalid),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		LastTransitionTime: now,
+		Reason:             string(gatewayv1.RouteReasonUnsupportedValue),
+		Message:            "Dropped Rule(s): " + ingress.JoinRegexIssues(issues),
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+func TestPartiallyInvalidCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	issues := []ingress.RegexIssue{{Rule: 1, Err: errors.New(`invalid path regex "/(v1"`)}}
+
+	tests := []struct {
+		name      string
+		issues    []ingress.RegexIssue
+		ruleCount int
+		accepted  bool
+		expectNil bool
+	}{
+		{
+			name:      "no dropped rules",
+			ruleCount: 2,
+			accepted:  true,
+			expectNil: true,
+		},
+		{
+			name:      "some rules dropped",
+			issues:    issues,
+			ruleCount: 2,
+			accepted:  true,
+		},
+		{
+			name:      "all rules dropped",
+			issues:    issues,
+			ruleCount: 1,
+			accepted:  true,
+			expectNil: true,
+		},
+		{
+			name:      "route not accepted",
+			issues:    issues,
+			ruleCount: 2,
+			accepted:  false,
+			expectNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition := partiallyInvalidCondition(tt.issues, tt.ruleCount, tt.accepted, 3, now)
+			if tt.expectNil {
+				assert.Nil(t, condition)
+
+				return
+			}
+
+			require.NotNil(t, condition)
+			assert.Equal(t, string(gatewayv1.RouteConditionPartiallyInvalid), condition.Type)
+			assert.Equal(t, metav1.ConditionTrue, condition.Status)
+			assert.Equal(t, string(gatewayv1.RouteReasonUnsupportedValue), condition.Reason)
+			assert.Equal(t, `Dropped Rule(s): rule 1: invalid path regex "/(v1"`, condition.Message)
+			assert.Equal(t, int64(3), condition.ObservedGeneration)
+		})
+	}
+}
+
+func TestAllRulesDropped(t *testing.T) {
+	t.Parallel()
+
+	issues := []ingress.RegexIssue{{Rule: 0, Err: errors.New("invalid")}}
+
+	assert.True(t, allRulesDropped(issues, 1))
+	assert.False(t, allRulesDropped(issues, 2))
+	assert.False(t, allRulesDropped(nil, 0))
+	assert.Equal(t, "All rules dropped: rule 0: invalid", droppedRulesMessage(issues))
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+const (
+	// RouteConditionRetry reports whether the retry settings of an HTTPRoute
+	// are programmed as specified.
+	RouteConditionRetry = "pingora.k8s.lex.la/Retry"
+
+	// RouteReasonRetryApplied means all retry settings were programmed.
+	RouteReasonRetryApplied = "Applied"
+)
+
+// retryCondition builds the Retry route condition from the route annotations
+// and rules. It returns nil for routes without retry settings.
+func retryCondition(
+	annotations map[string]string,
+	rules []gatewayv1.HTTPRouteRule,
+	generation int64,
+	now metav1.Time,
+) *metav1.Condition {
+	retryStatus := ingress.CheckRetries(annotations, rules)
+	if retryStatus == nil {
+		return nil
+	}
+
+	status := metav1.ConditionTrue
+	reason := RouteReasonRetryApplied
+
+	if !retryStatus.Supported {
+		status = metav1.ConditionFalse
+		reason = string(gatewayv1.RouteReasonUnsupportedValue)
+	}
+
+	return &metav1.Condition{
+		Type:               RouteConditionRetry,
+		Status:             status,
+		ObservedGeneration: generation,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            retryStatus.Message,
+	}
+}
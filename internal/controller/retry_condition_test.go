@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+func TestRetryCondition(t *testing.T) {
+	t.Parallel()
+
+	now := metav1.Now()
+	port := gatewayv1.PortNumber(80)
+	backendRefs := []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{Name: "app", Port: &port},
+	}}}
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		rules          []gatewayv1.HTTPRouteRule
+		expectNil      bool
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:      "no retries",
+			rules:     []gatewayv1.HTTPRouteRule{{BackendRefs: backendRefs}},
+			expectNil: true,
+		},
+		{
+			name: "supported retries",
+			rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: backendRefs,
+				Retry:       &gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{503}},
+			}},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: RouteReasonRetryApplied,
+		},
+		{
+			name: "unsupported status code",
+			rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: backendRefs,
+				Retry:       &gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{429}},
+			}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: string(gatewayv1.RouteReasonUnsupportedValue),
+		},
+		{
+			name:        "retries disabled by annotation",
+			annotations: map[string]string{ingress.DisableRetriesAnnotation: "*"},
+			rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: backendRefs,
+				Retry:       &gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{503}},
+			}},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: string(gatewayv1.RouteReasonUnsupportedValue),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			condition := retryCondition(tt.annotations, tt.rules, 2, now)
+			if tt.expectNil {
+				assert.Nil(t, condition)
+
+				return
+			}
+
+			require.NotNil(t, condition)
+			assert.Equal(t, RouteConditionRetry, condition.Type)
+			assert.Equal(t, tt.expectedStatus, condition.Status)
+			assert.Equal(t, tt.expectedReason, condition.Reason)
+			assert.Equal(t, int64(2), condition.ObservedGeneration)
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	pingoraingress "github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/tracing"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// builtRoutes is the Pingora route configuration built from the routes and
+// policies in the cluster, together with the routes it was built from.
+type builtRoutes struct {
+	httpRoutes   []gatewayv1.HTTPRoute
+	grpcRoutes   []gatewayv1.GRPCRoute
+	httpBindings map[string]routeBindingInfo
+	grpcBindings map[string]routeBindingInfo
+
+	pingoraHTTPRoutes []*routingv1.HTTPRoute
+	pingoraGRPCRoutes []*routingv1.GRPCRoute
+	listeners         []*routingv1.Listener
+}
+
+// RenderRoutes builds the route update the next sync would send to the
+// proxy, without connecting to it. The version of the request is not set.
+func (s *PingoraRouteSyncer) RenderRoutes(ctx context.Context) (*routingv1.UpdateRoutesRequest, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	s.refreshClusterDomain(ctx, s.Logger)
+
+	built, err := s.buildRoutes(ctx, s.Logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return &routingv1.UpdateRoutesRequest{
+		HttpRoutes: built.pingoraHTTPRoutes,
+		GrpcRoutes: built.pingoraGRPCRoutes,
+		Listeners:  built.listeners,
+	}, nil
+}
+
+// buildRoutes collects the routes bound to Gateways of our GatewayClass and
+// the policies attached to them, and builds the Pingora route configuration.
+// Must be called with syncMu held.
+//
+//nolint:funlen // collects every builder input
+func (s *PingoraRouteSyncer) buildRoutes(ctx context.Context, logger *slog.Logger) (*builtRoutes, error) {
+	// Collect all relevant HTTPRoutes with binding validation
+	httpRoutes, httpBindings, err := s.getRelevantHTTPRoutes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list httproutes")
+	}
+
+	// Collect all relevant GRPCRoutes with binding validation
+	grpcRoutes, grpcBindings, err := s.getRelevantGRPCRoutes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list grpcroutes")
+	}
+
+	logger.Info("syncing routes to Pingora",
+		"httpRoutes", len(httpRoutes),
+		"grpcRoutes", len(grpcRoutes),
+	)
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int("pingora.http_routes", len(httpRoutes)),
+		attribute.Int("pingora.grpc_routes", len(grpcRoutes)),
+	)
+
+	// Resolve PingoraCORSPolicies referenced by ExtensionRef filters
+	var corsPolicies v1alpha1.PingoraCORSPolicyList
+	if err := s.List(ctx, &corsPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to list cors policies")
+	}
+
+	s.builder.SetCORSPolicies(corsPolicies.Items)
+
+	// Resolve PingoraRateLimitPolicies attached to routes and Gateways
+	var rateLimitPolicies v1alpha1.PingoraRateLimitPolicyList
+	if err := s.List(ctx, &rateLimitPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to list rate limit policies")
+	}
+
+	s.builder.SetRateLimitPolicies(rateLimitPolicies.Items)
+
+	// Resolve PingoraAuthPolicies attached to routes and their key sets
+	var authPolicies v1alpha1.PingoraAuthPolicyList
+	if err := s.List(ctx, &authPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to list auth policies")
+	}
+
+	resolvedAuthPolicies := resolveAuthPolicies(ctx, s.Client, authPolicies.Items)
+	s.builder.SetAuthPolicies(resolvedAuthPolicies)
+
+	// Apply PingoraAccessControlPolicies attached to routes
+	var accessPolicies v1alpha1.PingoraAccessControlPolicyList
+	if err := s.List(ctx, &accessPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to list access control policies")
+	}
+
+	s.builder.SetAccessControlPolicies(accessPolicies.Items)
+
+	// Apply PingoraCachePolicies attached to routes
+	var cachePolicies v1alpha1.PingoraCachePolicyList
+	if err := s.List(ctx, &cachePolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to list cache policies")
+	}
+
+	s.builder.SetCachePolicies(cachePolicies.Items)
+
+	// Apply PingoraAccessLogPolicies attached to routes
+	var accessLogPolicies v1alpha1.PingoraAccessLogPolicyList
+	if err := s.List(ctx, &accessLogPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to list access log policies")
+	}
+
+	s.builder.SetAccessLogPolicies(accessLogPolicies.Items)
+
+	// Apply PingoraBackendPolicies attached to backend Services
+	var backendPolicies v1alpha1.PingoraBackendPolicyList
+	if err := s.List(ctx, &backendPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to list backend policies")
+	}
+
+	s.builder.SetBackendPolicies(backendPolicies.Items)
+
+	// Resolve BackendTLSPolicies attached to backend Services, whose CRD is
+	// only read if its feature gate is enabled
+	resolvedTLSPolicies, err := s.backendTLSPolicies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.builder.SetBackendTLSPolicies(resolvedTLSPolicies)
+
+	// Resolve PingoraBackends referenced by backendRefs
+	var backends v1alpha1.PingoraBackendList
+	if err := s.List(ctx, &backends); err != nil {
+		return nil, errors.Wrap(err, "failed to list backends")
+	}
+
+	s.builder.SetBackends(backends.Items)
+
+	// Resolve the Services and ReferenceGrants of backendRefs, so that refs
+	// reported as not found or not permitted fail their share of requests
+	var services corev1.ServiceList
+	if err := s.List(ctx, &services); err != nil {
+		return nil, errors.Wrap(err, "failed to list services")
+	}
+
+	var grants gatewayv1beta1.ReferenceGrantList
+	if !s.MissingResources.Has(OptionalResourceReferenceGrant) {
+		if err := s.List(ctx, &grants); err != nil {
+			return nil, errors.Wrap(err, "failed to list reference grants")
+		}
+	}
+
+	s.builder.SetBackendRefTargets(services.Items, grants.Items)
+
+	// Apply PingoraGRPCPolicies attached to GRPCRoutes
+	var grpcPolicies v1alpha1.PingoraGRPCPolicyList
+	if err := s.List(ctx, &grpcPolicies); err != nil {
+		return nil, errors.Wrap(err, "failed to list gRPC policies")
+	}
+
+	s.builder.SetGRPCPolicies(grpcPolicies.Items)
+
+	// Resolve listener settings from policies attached to Gateways
+	gateways, err := s.classGateways(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	listeners, err := s.buildListeners(ctx, gateways, accessPolicies.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hostnames of all listeners by port, for listener isolation
+	portListeners := listenerHostnamesByPort(gateways)
+
+	// Only routes changed since the previous sync are rebuilt, unless a
+	// policy, a PingoraBackend, the set of Services, a ReferenceGrant or the
+	// cluster domain changed
+	inputs, err := builderInputs(s.clusterDomain(), resolvedAuthPolicies, resolvedTLSPolicies,
+		&corsPolicies, &rateLimitPolicies, &accessPolicies, &cachePolicies, &accessLogPolicies, &backendPolicies,
+		&grpcPolicies, &backends, &services, &grants)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fingerprint route builder inputs")
+	}
+
+	s.routes.begin(inputs)
+	defer s.routes.finish()
+
+	// Build Pingora route configurations in Gateway API precedence order
+	pingoraingress.SortHTTPRoutes(httpRoutes)
+	pingoraingress.SortGRPCRoutes(grpcRoutes)
+
+	pingoraHTTPRoutes := make([]*routingv1.HTTPRoute, 0, len(httpRoutes))
+	for i := range httpRoutes {
+		route := s.routes.httpRoute(&httpRoutes[i], func() *routingv1.HTTPRoute {
+			_, span := tracing.Start(ctx, "PingoraBuilder.BuildHTTPRoute",
+				tracing.ObjectAttributes("HTTPRoute", httpRoutes[i].Namespace, httpRoutes[i].Name))
+			defer span.End()
+
+			recordHTTPRouteDrops(ctx, s.Metrics, &httpRoutes[i])
+
+			return s.builder.BuildHTTPRoute(&httpRoutes[i])
+		})
+
+		// Serve the route only on the hostnames its listeners accept
+		binding := httpBindings[httpRoutes[i].Namespace+"/"+httpRoutes[i].Name]
+		if hostnames, ports, ok := routeListeners(binding, portListeners); ok {
+			route.Hostnames, route.Listeners = hostnames, ports
+		}
+
+		pingoraHTTPRoutes = append(pingoraHTTPRoutes, route)
+	}
+
+	pingoraGRPCRoutes := make([]*routingv1.GRPCRoute, 0, len(grpcRoutes))
+	for i := range grpcRoutes {
+		route := s.routes.grpcRoute(&grpcRoutes[i], func() *routingv1.GRPCRoute {
+			_, span := tracing.Start(ctx, "PingoraBuilder.BuildGRPCRoute",
+				tracing.ObjectAttributes("GRPCRoute", grpcRoutes[i].Namespace, grpcRoutes[i].Name))
+			defer span.End()
+
+			recordGRPCRouteDrops(ctx, s.Metrics, &grpcRoutes[i])
+
+			return s.builder.BuildGRPCRoute(&grpcRoutes[i])
+		})
+
+		binding := grpcBindings[grpcRoutes[i].Namespace+"/"+grpcRoutes[i].Name]
+		if hostnames, ports, ok := routeListeners(binding, portListeners); ok {
+			route.Hostnames, route.Listeners = hostnames, ports
+		}
+
+		pingoraGRPCRoutes = append(pingoraGRPCRoutes, route)
+	}
+
+	pingoraingress.PrioritizeHTTPRoutes(pingoraHTTPRoutes)
+	pingoraingress.PrioritizeGRPCRoutes(pingoraGRPCRoutes)
+
+	httpRules := httpRouteRules(pingoraHTTPRoutes)
+	grpcRules := grpcRouteRules(pingoraGRPCRoutes)
+
+	logger.Debug("built route configuration",
+		"builtRoutes", s.routes.builds,
+		"cachedRoutes", s.routes.hits,
+		"httpRules", len(httpRules),
+		"grpcRules", len(grpcRules),
+		"namedRules", append(namedRules(httpRules), namedRules(grpcRules)...),
+	)
+	s.Metrics.RecordRouteRules(ctx, "http", httpRules)
+	s.Metrics.RecordRouteRules(ctx, "grpc", grpcRules)
+
+	// Rules with invalid regexes were dropped by the builder
+	httpDropped, grpcDropped := httpRegexIssues(httpRoutes), grpcRegexIssues(grpcRoutes)
+	if len(httpDropped) > 0 || len(grpcDropped) > 0 {
+		logger.Warn("dropped route rules with invalid regular expressions",
+			"rules", append(httpDropped, grpcDropped...))
+	}
+
+	s.Metrics.RecordDroppedRules(ctx, "http", droppedRuleReasonInvalidRegex, len(httpDropped))
+	s.Metrics.RecordDroppedRules(ctx, "grpc", droppedRuleReasonInvalidRegex, len(grpcDropped))
+
+	return &builtRoutes{
+		httpRoutes:        httpRoutes,
+		grpcRoutes:        grpcRoutes,
+		httpBindings:      httpBindings,
+		grpcBindings:      grpcBindings,
+		pingoraHTTPRoutes: pingoraHTTPRoutes,
+		pingoraGRPCRoutes: pingoraGRPCRoutes,
+		listeners:         listeners,
+	}, nil
+}
+
+// backendTLSPolicies lists and resolves the BackendTLSPolicies, or returns
+// none unless BackendTLSPolicies is enabled.
+func (s *PingoraRouteSyncer) backendTLSPolicies(ctx context.Context) ([]pingoraingress.ResolvedBackendTLSPolicy, error) {
+	if !s.BackendTLSPolicies {
+		return nil, nil
+	}
+
+	var policies gatewayv1.BackendTLSPolicyList
+	if err := s.List(ctx, &policies); err != nil {
+		return nil, errors.Wrap(err, "failed to list backend TLS policies")
+	}
+
+	return resolveBackendTLSPolicies(ctx, s.Client, policies.Items)
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	pingoraingress "github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// cachedRoute is a Pingora route built from the given resourceVersion of a
+// Gateway API route.
+type cachedRoute[T proto.Message] struct {
+	resourceVersion string
+	route           T
+}
+
+// routeCache keeps the Pingora routes built by the previous sync, keyed by
+// namespace/name, so that a sync only rebuilds the routes that changed
+// since then. Listing and binding validation still cover every route, as
+// the route status depends on them.
+//
+// The cache is not safe for concurrent use; it is only used under the
+// syncer's syncMu.
+type routeCache struct {
+	// inputs fingerprints everything besides the route itself that the
+	// builder reads. All entries are dropped when it changes.
+	inputs string
+
+	http map[string]cachedRoute[*routingv1.HTTPRoute]
+	grpc map[string]cachedRoute[*routingv1.GRPCRoute]
+
+	// Entries used by the sync in progress. They replace the cache in
+	// finish, which drops the routes that no longer exist.
+	nextHTTP map[string]cachedRoute[*routingv1.HTTPRoute]
+	nextGRPC map[string]cachedRoute[*routingv1.GRPCRoute]
+
+	// hits and builds count the routes reused and built by the sync in
+	// progress.
+	hits, builds int
+}
+
+// begin starts a sync with the given builder inputs.
+func (c *routeCache) begin(inputs string) {
+	if inputs != c.inputs {
+		c.http, c.grpc = nil, nil
+		c.inputs = inputs
+	}
+
+	c.nextHTTP = make(map[string]cachedRoute[*routingv1.HTTPRoute], len(c.http))
+	c.nextGRPC = make(map[string]cachedRoute[*routingv1.GRPCRoute], len(c.grpc))
+	c.hits, c.builds = 0, 0
+}
+
+// finish replaces the cache with the routes used by the sync in progress.
+func (c *routeCache) finish() {
+	c.http, c.grpc = c.nextHTTP, c.nextGRPC
+	c.nextHTTP, c.nextGRPC = nil, nil
+}
+
+// httpRoute returns a copy of the cached Pingora route for an HTTPRoute,
+// building it only if the HTTPRoute changed since it was cached.
+func (c *routeCache) httpRoute(obj client.Object, build func() *routingv1.HTTPRoute) *routingv1.HTTPRoute {
+	return cachedBuild(c, c.http, c.nextHTTP, obj, build)
+}
+
+// grpcRoute returns a copy of the cached Pingora route for a GRPCRoute,
+// building it only if the GRPCRoute changed since it was cached.
+func (c *routeCache) grpcRoute(obj client.Object, build func() *routingv1.GRPCRoute) *routingv1.GRPCRoute {
+	return cachedBuild(c, c.grpc, c.nextGRPC, obj, build)
+}
+
+// cachedBuild looks up a route in entries and records it in next. The
+// caller gets a copy, since the syncer narrows hostnames and sets match
+// priorities on the routes it sends.
+func cachedBuild[T proto.Message](
+	c *routeCache,
+	entries, next map[string]cachedRoute[T],
+	obj client.Object,
+	build func() T,
+) T {
+	key := obj.GetNamespace() + "/" + obj.GetName()
+
+	entry, ok := entries[key]
+	if ok && entry.resourceVersion == obj.GetResourceVersion() {
+		c.hits++
+	} else {
+		entry = cachedRoute[T]{resourceVersion: obj.GetResourceVersion(), route: build()}
+		c.builds++
+	}
+
+	next[key] = entry
+
+	return proto.Clone(entry.route).(T) //nolint:forcetypeassert // Clone keeps the message type
+}
+
+// builderInputs fingerprints the builder inputs besides the routes: the
+// cluster domain, the spec generation of every policy, the resolved key
+// sets of the auth policies and the resolved CA certificates of the
+// backend TLS policies.
+func builderInputs(
+	clusterDomain string,
+	authPolicies []pingoraingress.ResolvedAuthPolicy,
+	tlsPolicies []pingoraingress.ResolvedBackendTLSPolicy,
+	policies ...client.ObjectList,
+) (string, error) {
+	sum := sha256.New()
+
+	writeField(sum, clusterDomain)
+
+	for _, resolved := range authPolicies {
+		writeObject(sum, resolved.Policy)
+		writeField(sum, resolved.JWKS)
+	}
+
+	for _, resolved := range tlsPolicies {
+		writeObject(sum, resolved.Policy)
+		writeField(sum, string(resolved.CACertificates))
+		writeField(sum, strconv.FormatBool(resolved.Invalid))
+	}
+
+	for _, list := range policies {
+		err := meta.EachListItem(list, func(item runtime.Object) error {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return errors.Newf("unexpected list item %T", item)
+			}
+
+			writeObject(sum, obj)
+
+			return nil
+		})
+		if err != nil {
+			return "", err //nolint:wrapcheck // wrapped by caller
+		}
+	}
+
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// writeObject adds the identity and spec generation of an object to a hash.
+func writeObject(sum hash.Hash, obj client.Object) {
+	writeField(sum, fmt.Sprintf("%T/%s/%s/%d", obj, obj.GetNamespace(), obj.GetName(), obj.GetGeneration()))
+}
+
+// writeField adds a length-prefixed field to a hash, so that adjacent
+// fields cannot run into each other.
+func writeField(sum hash.Hash, field string) {
+	_, _ = fmt.Fprintf(sum, "%d:%s", len(field), field)
+}
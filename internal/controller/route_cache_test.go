@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	pingoraingress "github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestRouteCache(t *testing.T) {
+	t.Parallel()
+
+	route := func(name, resourceVersion string) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default", Name: name, ResourceVersion: resourceVersion,
+		}}
+	}
+
+	var builds []string
+
+	build := func(obj *gatewayv1.HTTPRoute) func() *routingv1.HTTPRoute {
+		return func() *routingv1.HTTPRoute {
+			builds = append(builds, obj.Name+"@"+obj.ResourceVersion)
+
+			return &routingv1.HTTPRoute{Id: obj.Name, Hostnames: []string{"example.com"}}
+		}
+	}
+
+	sync := func(cache *routeCache, inputs string, routes ...*gatewayv1.HTTPRoute) []*routingv1.HTTPRoute {
+		cache.begin(inputs)
+		defer cache.finish()
+
+		built := make([]*routingv1.HTTPRoute, 0, len(routes))
+		for _, obj := range routes {
+			built = append(built, cache.httpRoute(obj, build(obj)))
+		}
+
+		return built
+	}
+
+	var cache routeCache
+
+	first := sync(&cache, "v1", route("a", "1"), route("b", "1"))
+	assert.Equal(t, []string{"a@1", "b@1"}, builds)
+
+	// Routes handed out are copies, so narrowing one leaves the cache intact
+	first[0].Hostnames = nil
+
+	second := sync(&cache, "v1", route("a", "1"), route("b", "2"))
+	assert.Equal(t, []string{"a@1", "b@1", "b@2"}, builds)
+	assert.Equal(t, []string{"example.com"}, second[0].GetHostnames())
+	assert.Equal(t, 1, cache.hits)
+	assert.Equal(t, 1, cache.builds)
+
+	// Deleted routes are dropped from the cache
+	sync(&cache, "v1", route("b", "2"))
+	sync(&cache, "v1", route("a", "1"), route("b", "2"))
+	assert.Equal(t, []string{"a@1", "b@1", "b@2", "a@1"}, builds)
+
+	// Changed builder inputs rebuild every route
+	sync(&cache, "v2", route("a", "1"), route("b", "2"))
+	assert.Equal(t, []string{"a@1", "b@1", "b@2", "a@1", "a@1", "b@2"}, builds)
+}
+
+func TestBuilderInputs(t *testing.T) {
+	t.Parallel()
+
+	policy := func(generation int64) *v1alpha1.PingoraCORSPolicyList {
+		return &v1alpha1.PingoraCORSPolicyList{Items: []v1alpha1.PingoraCORSPolicy{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "cors", Generation: generation},
+		}}}
+	}
+
+	auth := func(jwks string) []pingoraingress.ResolvedAuthPolicy {
+		return []pingoraingress.ResolvedAuthPolicy{{
+			Policy: &v1alpha1.PingoraAuthPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "jwt"}},
+			JWKS:   jwks,
+		}}
+	}
+
+	backendTLS := func(ca string) []pingoraingress.ResolvedBackendTLSPolicy {
+		return []pingoraingress.ResolvedBackendTLSPolicy{{
+			Policy:         &gatewayv1.BackendTLSPolicy{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "tls"}},
+			CACertificates: []byte(ca),
+		}}
+	}
+
+	base, err := builderInputs("cluster.local", auth("keys"), backendTLS("ca"), policy(1))
+	require.NoError(t, err)
+
+	same, err := builderInputs("cluster.local", auth("keys"), backendTLS("ca"), policy(1))
+	require.NoError(t, err)
+	assert.Equal(t, base, same)
+
+	tests := []struct {
+		name          string
+		clusterDomain string
+		auth          []pingoraingress.ResolvedAuthPolicy
+		backendTLS    []pingoraingress.ResolvedBackendTLSPolicy
+		policies      *v1alpha1.PingoraCORSPolicyList
+	}{
+		{name: "cluster domain", clusterDomain: "example.internal", auth: auth("keys"), backendTLS: backendTLS("ca"), policies: policy(1)},
+		{name: "policy generation", clusterDomain: "cluster.local", auth: auth("keys"), backendTLS: backendTLS("ca"), policies: policy(2)},
+		{name: "resolved key set", clusterDomain: "cluster.local", auth: auth("rotated"), backendTLS: backendTLS("ca"), policies: policy(1)},
+		{name: "resolved backend CA", clusterDomain: "cluster.local", auth: auth("keys"), backendTLS: backendTLS("rotated"), policies: policy(1)},
+		{name: "removed policy", clusterDomain: "cluster.local", auth: auth("keys"), backendTLS: backendTLS("ca"), policies: &v1alpha1.PingoraCORSPolicyList{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			changed, err := builderInputs(tt.clusterDomain, tt.auth, tt.backendTLS, tt.policies)
+			require.NoError(t, err)
+			assert.NotEqual(t, base, changed)
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/logging"
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+const (
+	// RouteFinalizer keeps a deleted route until it was removed from the
+	// proxy with DeleteRoutes.
+	RouteFinalizer = "pingora.k8s.lex.la/route-cleanup"
+
+	// methodDeleteRoutes is the metrics label for route deletions.
+	methodDeleteRoutes = "DeleteRoutes"
+)
+
+// DeleteRoute removes a route that is being deleted from the proxy with
+// DeleteRoutes, and returns once the proxy confirmed it. Dry runs and
+// proxies without DeleteRoutes succeed without removing it; the next sync
+// removes it then.
+func (s *PingoraRouteSyncer) DeleteRoute(ctx context.Context, kind gatewayv1.Kind, route client.Object) error {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	// Prefer context logger (with reconcile ID) over struct logger
+	logger := logging.FromContext(ctx)
+	if logger == slog.Default() {
+		logger = s.Logger
+	}
+
+	if s.DryRun {
+		return nil
+	}
+
+	if err := s.connectForDeletion(ctx); err != nil {
+		return err
+	}
+
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	stream := s.stream
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		//nolint:wrapcheck // New creates new error, not wrapping
+		return errors.New("not connected to Pingora proxy")
+	}
+
+	id := s.builder.RouteID(route)
+	req := &routingv1.DeleteRoutesRequest{Version: s.version.Add(1)}
+
+	if kind == routebinding.KindGRPCRoute {
+		req.GrpcRouteIds = []string{id}
+	} else {
+		req.HttpRouteIds = []string{id}
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.DeleteRoutes(rpcCtx, req)
+	grpcDuration := time.Since(grpcStart)
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		s.Metrics.RecordGRPCCall(ctx, methodDeleteRoutes, "error", grpcDuration)
+		logger.Info("Pingora proxy does not support DeleteRoutes, leaving the route to the next sync",
+			"routeID", id)
+
+		return nil
+	case err != nil:
+		s.Metrics.RecordGRPCCall(ctx, methodDeleteRoutes, "error", grpcDuration)
+
+		return errors.Wrap(err, "failed to delete route via gRPC")
+	case !resp.GetSuccess():
+		s.Metrics.RecordGRPCCall(ctx, methodDeleteRoutes, "failed", grpcDuration)
+
+		//nolint:wrapcheck // Newf creates new error, not wrapping
+		return errors.Newf("route deletion failed: %s", resp.GetError())
+	}
+
+	s.Metrics.RecordGRPCCall(ctx, methodDeleteRoutes, "success", grpcDuration)
+
+	if stream != nil {
+		stream.Removed(req, resp.GetAppliedVersion())
+	}
+
+	s.proxyVersion.Store(resp.GetAppliedVersion())
+
+	// The proxy runs no config built by a sync anymore, so the next sync
+	// is sent even if nothing else changed
+	applied := s.GetAppliedConfig()
+	applied.Hash = ""
+	applied.Version = resp.GetAppliedVersion()
+	s.setAppliedConfig(applied)
+
+	logger.Info("removed route from Pingora proxy",
+		"routeID", id,
+		"version", resp.GetAppliedVersion(),
+	)
+
+	return nil
+}
+
+// connectForDeletion connects to the proxy like a sync does. Finalizing
+// routes are deleted before they are synced, so without connecting here a
+// controller that is not connected yet would keep their finalizers forever.
+// Must be called with syncMu held.
+func (s *PingoraRouteSyncer) connectForDeletion(ctx context.Context) error {
+	if s.IsConnected() {
+		return nil
+	}
+
+	if wait := s.reconnect.wait(); wait > 0 {
+		//nolint:wrapcheck // Newf creates new error, not wrapping
+		return errors.Newf("not connected to Pingora proxy, reconnecting in %s", wait)
+	}
+
+	if err := s.Connect(ctx); err != nil {
+		s.reconnect.failure()
+		s.recordConnectionState(ctx)
+
+		if errors.Is(err, config.ErrInvalidCertificate) {
+			s.recordCertificateInvalid(ctx, err)
+		}
+
+		return errors.Wrap(err, "failed to connect to Pingora proxy")
+	}
+
+	s.reconnect.success()
+	s.recordConnectionState(ctx)
+
+	return nil
+}
+
+// addRouteFinalizer adds RouteFinalizer to a route programmed into the proxy.
+// Dry runs program nothing, so they leave routes without the finalizer.
+func addRouteFinalizer(ctx context.Context, cli client.Client, syncer *PingoraRouteSyncer, route client.Object) error {
+	if syncer.DryRun || controllerutil.ContainsFinalizer(route, RouteFinalizer) {
+		return nil
+	}
+
+	original, _ := route.DeepCopyObject().(client.Object)
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+
+	controllerutil.AddFinalizer(route, RouteFinalizer)
+
+	if err := cli.Patch(ctx, route, patch); err != nil {
+		return errors.Wrap(err, "failed to add route finalizer")
+	}
+
+	return nil
+}
+
+// finalizeRoute removes a route that is being deleted from the proxy and
+// then its RouteFinalizer, so that the route object disappears only once
+// the proxy no longer serves it. Routes without the finalizer are left to
+// the next sync.
+func finalizeRoute(
+	ctx context.Context,
+	cli client.Client,
+	syncer *PingoraRouteSyncer,
+	kind gatewayv1.Kind,
+	route client.Object,
+) error {
+	if !controllerutil.ContainsFinalizer(route, RouteFinalizer) {
+		return nil
+	}
+
+	if err := syncer.DeleteRoute(ctx, kind, route); err != nil {
+		return err
+	}
+
+	return removeRouteFinalizer(ctx, cli, route)
+}
+
+// removeRouteFinalizer removes RouteFinalizer from a route, if it has it.
+func removeRouteFinalizer(ctx context.Context, cli client.Client, route client.Object) error {
+	if !controllerutil.ContainsFinalizer(route, RouteFinalizer) {
+		return nil
+	}
+
+	original, _ := route.DeepCopyObject().(client.Object)
+	patch := client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{})
+
+	controllerutil.RemoveFinalizer(route, RouteFinalizer)
+
+	if err := cli.Patch(ctx, route, patch); client.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, "failed to remove route finalizer")
+	}
+
+	return nil
+}
+
+// releaseRouteFinalizer removes RouteFinalizer from a route of
+// gatewayClassName that is missing from the cache but still exists, because
+// it stopped matching the route label selector. The controller no longer
+// sees the route, so its deletion would wait forever; the next sync removes
+// it from the proxy instead. Routes of other classes keep the finalizer for
+// the controller of their class. reader must not be the cache. Without a
+// reader nothing is released.
+func releaseRouteFinalizer(
+	ctx context.Context,
+	cli client.Client,
+	reader client.Reader,
+	gatewayClassName string,
+	key types.NamespacedName,
+	route client.Object,
+) error {
+	if reader == nil {
+		return nil
+	}
+
+	err := reader.Get(ctx, key, route)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "failed to read route")
+	}
+
+	if !controllerutil.ContainsFinalizer(route, RouteFinalizer) || !referencesGatewayClass(ctx, cli, gatewayClassName, route) {
+		return nil
+	}
+
+	logging.FromContext(ctx).Info("removing route finalizer from route outside the route label selector")
+
+	return removeRouteFinalizer(ctx, cli, route)
+}
+
+// RouteFinalizerReleaser removes RouteFinalizer from the routes of our
+// GatewayClass outside the watched namespaces and the route label selector,
+// such as after either was narrowed. The controller never sees these
+// routes, so their deletion would wait forever. It runs once on the leader.
+type RouteFinalizerReleaser struct {
+	Client client.Client
+
+	// APIReader lists routes in every namespace, bypassing the cache.
+	APIReader client.Reader
+
+	// GatewayClassName is the class whose routes are released. Routes of
+	// other classes keep the finalizer for the controller of their class.
+	GatewayClassName string
+
+	// WatchNamespaces and RouteLabelSelector select the watched routes, as
+	// in Config.
+	WatchNamespaces    []string
+	RouteLabelSelector labels.Selector
+
+	// MissingResources skips GRPCRoutes while their CRD is missing.
+	MissingResources MissingResources
+
+	Logger *slog.Logger
+}
+
+// Start runs the release pass once. Failures are logged and do not stop the
+// manager; the finalizer can still be removed by hand.
+func (r *RouteFinalizerReleaser) Start(ctx context.Context) error {
+	logger := r.Logger.With("component", "route-finalizer-release")
+
+	lists := []client.ObjectList{&gatewayv1.HTTPRouteList{}}
+	if !r.MissingResources.Has(OptionalResourceGRPCRoute) {
+		lists = append(lists, &gatewayv1.GRPCRouteList{})
+	}
+
+	released := 0
+
+	for _, list := range lists {
+		count, err := r.release(ctx, list)
+		if err != nil {
+			logger.Error("failed to release route finalizers", "error", err)
+		}
+
+		released += count
+	}
+
+	if released > 0 {
+		logger.Info("removed route finalizers from unwatched routes", "routes", released)
+	}
+
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Only the leader patches routes.
+func (r *RouteFinalizerReleaser) NeedLeaderElection() bool {
+	return true
+}
+
+// release removes RouteFinalizer from the unwatched routes of list and
+// returns their number.
+func (r *RouteFinalizerReleaser) release(ctx context.Context, list client.ObjectList) (int, error) {
+	if err := r.APIReader.List(ctx, list); err != nil {
+		return 0, errors.Wrap(err, "failed to list routes")
+	}
+
+	released := 0
+
+	var errs error
+
+	err := meta.EachListItem(list, func(item runtime.Object) error {
+		route, ok := item.(client.Object)
+		if !ok || r.watches(route) || !controllerutil.ContainsFinalizer(route, RouteFinalizer) ||
+			!referencesGatewayClass(ctx, r.Client, r.GatewayClassName, route) {
+			return nil
+		}
+
+		if err := removeRouteFinalizer(ctx, r.Client, route); err != nil {
+			errs = errors.CombineErrors(errs, errors.Wrapf(err, "route %s", client.ObjectKeyFromObject(route)))
+
+			return nil
+		}
+
+		released++
+
+		return nil
+	})
+
+	return released, errors.CombineErrors(errs, err)
+}
+
+// watches reports whether route is in a watched namespace and matches the
+// route label selector.
+func (r *RouteFinalizerReleaser) watches(route client.Object) bool {
+	if len(r.WatchNamespaces) > 0 && !slices.Contains(r.WatchNamespaces, route.GetNamespace()) {
+		return false
+	}
+
+	return r.RouteLabelSelector == nil || r.RouteLabelSelector.Matches(labels.Set(route.GetLabels()))
+}
+
+// referencesGatewayClass reports whether an HTTPRoute or GRPCRoute has a
+// parent Gateway of gatewayClassName.
+func referencesGatewayClass(ctx context.Context, cli client.Client, gatewayClassName string, obj client.Object) bool {
+	switch route := obj.(type) {
+	case *gatewayv1.HTTPRoute:
+		return ReferencesGatewayClass(ctx, cli, gatewayClassName, HTTPRouteWrapper{route})
+	case *gatewayv1.GRPCRoute:
+		return ReferencesGatewayClass(ctx, cli, gatewayClassName, GRPCRouteWrapper{route})
+	default:
+		return false
+	}
+}
@@ -0,0 +1,297 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+func TestPingoraRouteSyncer_DeleteRoute(t *testing.T) {
+	t.Parallel()
+
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	syncer, _ := newStatusTestSyncer(t, v1alpha1.PingoraConfigStatus{})
+	syncer.builder = ingress.NewPingoraBuilder("cluster.local")
+	syncer.grpcClient = routingv1.NewRoutingServiceClient(conn)
+	syncer.stream = newRouteStream(syncer.grpcClient, slog.Default(), nil)
+	t.Cleanup(syncer.stream.Close)
+
+	ctx := context.Background()
+
+	_, err = syncer.stream.Send(ctx, updateRequest(1, map[string]string{"default/a": "a:80", "default/b": "b:80"}))
+	require.NoError(t, err)
+	syncer.version.Store(1)
+	syncer.setAppliedConfig(AppliedConfig{Hash: "routes", Version: 1})
+
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}}
+	require.NoError(t, syncer.DeleteRoute(ctx, routebinding.KindHTTPRoute, route))
+
+	require.Len(t, proxy.HTTPRoutes(), 1)
+	assert.Equal(t, "default/b", proxy.HTTPRoutes()[0].GetId())
+	assert.Equal(t, AppliedConfig{Version: 2}, syncer.GetAppliedConfig())
+
+	// The next delta is based on the version DeleteRoutes applied
+	resp, err := syncer.stream.Send(ctx, updateRequest(3, map[string]string{"default/b": "b:8080"}))
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	assert.Equal(t, []uint64{1, 2, 3}, proxy.AppliedVersions())
+	assert.Equal(t, uint64(3), syncer.stream.appliedVersion)
+
+	// A rejected deletion keeps the finalizer
+	proxy.RejectUpdates("read-only")
+	require.Error(t, syncer.DeleteRoute(ctx, routebinding.KindHTTPRoute, route))
+
+	// A proxy without DeleteRoutes leaves the route to the next sync
+	proxy.SetError(mockproxy.MethodDeleteRoutes, status.Error(codes.Unimplemented, "unknown method"))
+	require.NoError(t, syncer.DeleteRoute(ctx, routebinding.KindHTTPRoute, route))
+}
+
+func TestPingoraRouteSyncer_DeleteRouteConnects(t *testing.T) {
+	t.Parallel()
+
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	syncer, _ := newStatusTestSyncer(t, v1alpha1.PingoraConfigStatus{})
+	syncer.builder = ingress.NewPingoraBuilder("cluster.local")
+	syncer.ConfigResolver = config.NewPingoraResolver(syncer.Client, "pingora-system")
+	t.Cleanup(func() { _ = syncer.Close() })
+
+	ctx := context.Background()
+
+	var pingoraConfig v1alpha1.PingoraConfig
+	require.NoError(t, syncer.Get(ctx, client.ObjectKey{Name: "pingora"}, &pingoraConfig))
+	pingoraConfig.Spec.Address = addr
+	require.NoError(t, syncer.Update(ctx, &pingoraConfig))
+
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"}}
+
+	// Deletions wait for the reconnect backoff like syncs
+	syncer.reconnect.failure()
+	require.Error(t, syncer.DeleteRoute(ctx, routebinding.KindHTTPRoute, route))
+	assert.False(t, syncer.IsConnected())
+
+	// Once it passed, a deletion connects instead of waiting for a sync
+	syncer.reconnect.success()
+	require.NoError(t, syncer.DeleteRoute(ctx, routebinding.KindHTTPRoute, route))
+	assert.True(t, syncer.IsConnected())
+	assert.Equal(t, ConnectionStateConnected, syncer.ConnectionState())
+}
+
+func TestRouteFinalizer(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	route := &gatewayv1.GRPCRoute{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+	cli := fake.NewClientBuilder().WithScheme(scheme).WithObjects(route).Build()
+
+	// Dry runs remove nothing from a proxy
+	syncer := &PingoraRouteSyncer{DryRun: true}
+	ctx := context.Background()
+
+	// Dry runs leave routes without the finalizer
+	require.NoError(t, addRouteFinalizer(ctx, cli, syncer, route))
+	assert.Empty(t, route.Finalizers)
+
+	programming := &PingoraRouteSyncer{}
+	require.NoError(t, addRouteFinalizer(ctx, cli, programming, route))
+	require.NoError(t, addRouteFinalizer(ctx, cli, programming, route))
+
+	var stored gatewayv1.GRPCRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &stored))
+	assert.Equal(t, []string{RouteFinalizer}, stored.Finalizers)
+
+	require.NoError(t, cli.Delete(ctx, &stored))
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &stored))
+	assert.WithinDuration(t, time.Now(), stored.DeletionTimestamp.Time, time.Minute)
+
+	require.NoError(t, finalizeRoute(ctx, cli, syncer, routebinding.KindGRPCRoute, &stored))
+
+	err := cli.Get(ctx, client.ObjectKeyFromObject(route), &stored)
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReleaseRouteFinalizer(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	route := func(name, gateway string) *gatewayv1.HTTPRoute {
+		return &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  "default",
+				Finalizers: []string{RouteFinalizer, "example.com/other"},
+			},
+			Spec: gatewayv1.HTTPRouteSpec{CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gateway)}},
+			}},
+		}
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			finalizerTestGateway("pingora", "pingora"),
+			finalizerTestGateway("other", "other"),
+			route("app", "pingora"),
+			route("foreign", "other"),
+		).
+		Build()
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "default", Name: "app"}
+
+	// Without a reader nothing is released
+	require.NoError(t, releaseRouteFinalizer(ctx, cli, nil, "pingora", key, &gatewayv1.HTTPRoute{}))
+
+	var stored gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, key, &stored))
+	assert.Contains(t, stored.Finalizers, RouteFinalizer)
+
+	require.NoError(t, releaseRouteFinalizer(ctx, cli, cli, "pingora", key, &gatewayv1.HTTPRoute{}))
+	require.NoError(t, cli.Get(ctx, key, &stored))
+	assert.Equal(t, []string{"example.com/other"}, stored.Finalizers)
+
+	// Routes of other classes are left to their controller
+	foreign := client.ObjectKey{Namespace: "default", Name: "foreign"}
+	require.NoError(t, releaseRouteFinalizer(ctx, cli, cli, "pingora", foreign, &gatewayv1.HTTPRoute{}))
+	require.NoError(t, cli.Get(ctx, foreign, &stored))
+	assert.Contains(t, stored.Finalizers, RouteFinalizer)
+
+	// Deleted routes have nothing to release
+	missing := client.ObjectKey{Namespace: "default", Name: "missing"}
+	require.NoError(t, releaseRouteFinalizer(ctx, cli, cli, "pingora", missing, &gatewayv1.HTTPRoute{}))
+}
+
+func TestRouteFinalizerReleaser_Start(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	selector, err := labels.Parse("tier=canary")
+	require.NoError(t, err)
+
+	meta := func(namespace, name, tier string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:  namespace,
+			Name:       name,
+			Labels:     map[string]string{"tier": tier},
+			Finalizers: []string{RouteFinalizer},
+		}
+	}
+
+	gatewayNamespace := gatewayv1.Namespace("default")
+	parents := func(gateway string) gatewayv1.CommonRouteSpec {
+		return gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{
+			{Name: gatewayv1.ObjectName(gateway), Namespace: &gatewayNamespace},
+		}}
+	}
+
+	watched := &gatewayv1.HTTPRoute{
+		ObjectMeta: meta("team-a", "watched", "canary"),
+		Spec:       gatewayv1.HTTPRouteSpec{CommonRouteSpec: parents("pingora")},
+	}
+	otherLabels := &gatewayv1.HTTPRoute{
+		ObjectMeta: meta("team-a", "stable", "stable"),
+		Spec:       gatewayv1.HTTPRouteSpec{CommonRouteSpec: parents("pingora")},
+	}
+	otherNamespace := &gatewayv1.HTTPRoute{
+		ObjectMeta: meta("team-b", "watched", "canary"),
+		Spec:       gatewayv1.HTTPRouteSpec{CommonRouteSpec: parents("pingora")},
+	}
+	otherClass := &gatewayv1.HTTPRoute{
+		ObjectMeta: meta("team-b", "foreign", "stable"),
+		Spec:       gatewayv1.HTTPRouteSpec{CommonRouteSpec: parents("other")},
+	}
+	grpcRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: meta("team-b", "api", "canary"),
+		Spec:       gatewayv1.GRPCRouteSpec{CommonRouteSpec: parents("pingora")},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			finalizerTestGateway("pingora", "pingora"),
+			finalizerTestGateway("other", "other"),
+			watched, otherLabels, otherNamespace, otherClass, grpcRoute,
+		).
+		Build()
+
+	releaser := &RouteFinalizerReleaser{
+		Client:             cli,
+		APIReader:          cli,
+		GatewayClassName:   "pingora",
+		WatchNamespaces:    []string{"default", "team-a"},
+		RouteLabelSelector: selector,
+		Logger:             slog.Default(),
+	}
+
+	assert.True(t, releaser.NeedLeaderElection())
+	require.NoError(t, releaser.Start(context.Background()))
+
+	tests := []struct {
+		route     client.Object
+		finalized bool
+	}{
+		{route: watched, finalized: true},
+		{route: otherLabels, finalized: false},
+		{route: otherNamespace, finalized: false},
+		{route: otherClass, finalized: true},
+		{route: grpcRoute, finalized: false},
+	}
+
+	for _, tt := range tests {
+		stored, _ := tt.route.DeepCopyObject().(client.Object)
+		require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(tt.route), stored))
+		assert.Equal(t, tt.finalized, controllerutil.ContainsFinalizer(stored, RouteFinalizer),
+			"%T %s", tt.route, client.ObjectKeyFromObject(tt.route))
+	}
+}
+
+// finalizerTestGateway returns a Gateway of gatewayClassName in the default
+// namespace.
+func finalizerTestGateway(name, gatewayClassName string) *gatewayv1.Gateway {
+	return &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: gatewayv1.ObjectName(gatewayClassName)},
+	}
+}
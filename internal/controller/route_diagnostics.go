@@ -0,0 +1,391 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// RouteDiagnosticsAnnotation is the route annotation holding the rules the
+// proxy serves for the route when route diagnostics annotations are enabled.
+const RouteDiagnosticsAnnotation = "pingora.k8s.lex.la/route-diagnostics"
+
+// Reasons for route elements the builder leaves out.
+const (
+	droppedReasonInvalidRegex       = "InvalidRegex"
+	droppedReasonUnsupportedFilter  = "UnsupportedFilter"
+	droppedReasonUnsupportedBackend = "UnsupportedBackend"
+)
+
+// routeDiagnostics is the compact JSON written to RouteDiagnosticsAnnotation.
+type routeDiagnostics struct {
+	Generation int64                `json:"generation"`
+	ID         string               `json:"id"`
+	Hostnames  []string             `json:"hostnames,omitempty"`
+	Listeners  []string             `json:"listeners,omitempty"`
+	Rules      []ruleDiagnostics    `json:"rules"`
+	Dropped    []droppedDiagnostics `json:"dropped,omitempty"`
+}
+
+// ruleDiagnostics describes a rule as programmed into the proxy.
+type ruleDiagnostics struct {
+	Name                 string   `json:"name,omitempty"`
+	Matches              []string `json:"matches,omitempty"`
+	Backends             []string `json:"backends,omitempty"`
+	InvalidBackendWeight uint32   `json:"invalidBackendWeight,omitempty"`
+	FixedResponse        uint32   `json:"fixedResponse,omitempty"`
+}
+
+// droppedDiagnostics is a route element the builder leaves out.
+type droppedDiagnostics struct {
+	Path    string `json:"path"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+}
+
+// httpRouteDiagnostics describes what the proxy serves for an HTTPRoute and
+// what the builder dropped from it.
+func httpRouteDiagnostics(route *gatewayv1.HTTPRoute, built *routingv1.HTTPRoute) routeDiagnostics {
+	diagnostics := routeDiagnostics{
+		Generation: route.Generation,
+		ID:         built.GetId(),
+		Hostnames:  built.GetHostnames(),
+		Listeners:  routeListenerDiagnostics(built.GetListeners()),
+		Rules:      make([]ruleDiagnostics, 0, len(built.GetRules())),
+	}
+
+	for _, rule := range built.GetRules() {
+		matches := make([]string, 0, len(rule.GetMatches()))
+		for _, match := range rule.GetMatches() {
+			matches = append(matches, httpMatchDiagnostics(match))
+		}
+
+		diagnostics.Rules = append(diagnostics.Rules, ruleDiagnostics{
+			Name:                 rule.GetName(),
+			Matches:              matches,
+			Backends:             backendDiagnostics(rule.GetBackends()),
+			InvalidBackendWeight: rule.GetInvalidBackendWeight(),
+			FixedResponse:        rule.GetFixedResponse().GetStatusCode(),
+		})
+	}
+
+	rulesPath := field.NewPath("spec", "rules")
+
+	for _, issue := range ingress.HTTPRouteRegexIssues(route.Spec.Rules) {
+		diagnostics.Dropped = append(diagnostics.Dropped, droppedDiagnostics{
+			Path:    rulesPath.Index(issue.Rule).String(),
+			Reason:  droppedReasonInvalidRegex,
+			Message: issue.Err.Error(),
+		})
+	}
+
+	for i := range route.Spec.Rules {
+		rule := &route.Spec.Rules[i]
+
+		for j := range rule.Filters {
+			if !ingress.IsSupportedHTTPFilter(&rule.Filters[j]) {
+				diagnostics.Dropped = append(diagnostics.Dropped,
+					droppedFilter(rulesPath.Index(i).Child("filters").Index(j), string(rule.Filters[j].Type)))
+			}
+		}
+
+		for j := range rule.BackendRefs {
+			backendRefPath := rulesPath.Index(i).Child("backendRefs").Index(j)
+			diagnostics.Dropped = append(diagnostics.Dropped,
+				droppedBackendRef(backendRefPath, &rule.BackendRefs[j].BackendRef)...)
+
+			for k := range rule.BackendRefs[j].Filters {
+				filter := &rule.BackendRefs[j].Filters[k]
+				if !ingress.IsSupportedHTTPBackendFilter(filter) {
+					diagnostics.Dropped = append(diagnostics.Dropped,
+						droppedFilter(backendRefPath.Child("filters").Index(k), string(filter.Type)))
+				}
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// grpcRouteDiagnostics describes what the proxy serves for a GRPCRoute and
+// what the builder dropped from it.
+func grpcRouteDiagnostics(route *gatewayv1.GRPCRoute, built *routingv1.GRPCRoute) routeDiagnostics {
+	diagnostics := routeDiagnostics{
+		Generation: route.Generation,
+		ID:         built.GetId(),
+		Hostnames:  built.GetHostnames(),
+		Listeners:  routeListenerDiagnostics(built.GetListeners()),
+		Rules:      make([]ruleDiagnostics, 0, len(built.GetRules())),
+	}
+
+	for _, rule := range built.GetRules() {
+		matches := make([]string, 0, len(rule.GetMatches()))
+		for _, match := range rule.GetMatches() {
+			matches = append(matches, grpcMatchDiagnostics(match))
+		}
+
+		diagnostics.Rules = append(diagnostics.Rules, ruleDiagnostics{
+			Name:                 rule.GetName(),
+			Matches:              matches,
+			Backends:             backendDiagnostics(rule.GetBackends()),
+			InvalidBackendWeight: rule.GetInvalidBackendWeight(),
+			FixedResponse:        rule.GetFixedResponse().GetStatusCode(),
+		})
+	}
+
+	rulesPath := field.NewPath("spec", "rules")
+
+	for _, issue := range ingress.GRPCRouteRegexIssues(route.Spec.Rules) {
+		diagnostics.Dropped = append(diagnostics.Dropped, droppedDiagnostics{
+			Path:    rulesPath.Index(issue.Rule).String(),
+			Reason:  droppedReasonInvalidRegex,
+			Message: issue.Err.Error(),
+		})
+	}
+
+	for i := range route.Spec.Rules {
+		rule := &route.Spec.Rules[i]
+
+		for j := range rule.Filters {
+			if !ingress.IsSupportedGRPCFilter(&rule.Filters[j]) {
+				diagnostics.Dropped = append(diagnostics.Dropped,
+					droppedFilter(rulesPath.Index(i).Child("filters").Index(j), string(rule.Filters[j].Type)))
+			}
+		}
+
+		for j := range rule.BackendRefs {
+			backendRefPath := rulesPath.Index(i).Child("backendRefs").Index(j)
+			diagnostics.Dropped = append(diagnostics.Dropped,
+				droppedBackendRef(backendRefPath, &rule.BackendRefs[j].BackendRef)...)
+
+			for k := range rule.BackendRefs[j].Filters {
+				filter := &rule.BackendRefs[j].Filters[k]
+				if !ingress.IsSupportedGRPCBackendFilter(filter) {
+					diagnostics.Dropped = append(diagnostics.Dropped,
+						droppedFilter(backendRefPath.Child("filters").Index(k), string(filter.Type)))
+				}
+			}
+		}
+	}
+
+	return diagnostics
+}
+
+// httpMatchDiagnostics formats an HTTP match as "path PathPrefix /api,
+// method GET, header x-env=prod (priority 3)". Regular expression matches
+// of headers and query parameters use "~" instead of "=".
+func httpMatchDiagnostics(match *routingv1.HTTPRouteMatch) string {
+	var parts []string
+
+	if path := match.GetPath(); path != nil {
+		parts = append(parts, "path "+pathMatchType(path.GetType())+" "+path.GetValue())
+	}
+
+	if method := match.GetMethod(); method != "" {
+		parts = append(parts, "method "+method)
+	}
+
+	for _, header := range match.GetHeaders() {
+		parts = append(parts, "header "+header.GetName()+
+			matchOperator(header.GetType() == routingv1.HeaderMatchType_HEADER_MATCH_TYPE_REGEX)+header.GetValue())
+	}
+
+	for _, param := range match.GetQueryParams() {
+		parts = append(parts, "query "+param.GetName()+
+			matchOperator(param.GetType() == routingv1.QueryParamMatchType_QUERY_PARAM_MATCH_TYPE_REGEX)+param.GetValue())
+	}
+
+	return matchDiagnostics(parts, match.GetPriority())
+}
+
+// grpcMatchDiagnostics formats a gRPC match like httpMatchDiagnostics, as
+// "method Exact pkg.Service/Method, header x-env=prod (priority 1)".
+func grpcMatchDiagnostics(match *routingv1.GRPCRouteMatch) string {
+	var parts []string
+
+	if method := match.GetMethod(); method != nil {
+		matchType := "Exact"
+		if method.GetType() == routingv1.GRPCMethodMatchType_GRPC_METHOD_MATCH_TYPE_REGEX {
+			matchType = "RegularExpression"
+		}
+
+		parts = append(parts, "method "+matchType+" "+method.GetService()+"/"+method.GetMethod())
+	}
+
+	for _, header := range match.GetHeaders() {
+		parts = append(parts, "header "+header.GetName()+
+			matchOperator(header.GetType() == routingv1.HeaderMatchType_HEADER_MATCH_TYPE_REGEX)+header.GetValue())
+	}
+
+	return matchDiagnostics(parts, match.GetPriority())
+}
+
+func matchDiagnostics(parts []string, priority uint32) string {
+	if len(parts) == 0 {
+		parts = append(parts, "any")
+	}
+
+	return strings.Join(parts, ", ") + " (priority " + strconv.FormatUint(uint64(priority), 10) + ")"
+}
+
+func matchOperator(regex bool) string {
+	if regex {
+		return "~"
+	}
+
+	return "="
+}
+
+// pathMatchType returns the Gateway API name of a path match type.
+func pathMatchType(matchType routingv1.PathMatchType) string {
+	switch matchType {
+	case routingv1.PathMatchType_PATH_MATCH_TYPE_EXACT:
+		return string(gatewayv1.PathMatchExact)
+	case routingv1.PathMatchType_PATH_MATCH_TYPE_REGEX:
+		return string(gatewayv1.PathMatchRegularExpression)
+	case routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, routingv1.PathMatchType_PATH_MATCH_TYPE_UNSPECIFIED:
+		return string(gatewayv1.PathMatchPathPrefix)
+	default:
+		return matchType.String()
+	}
+}
+
+// backendDiagnostics formats the resolved backends of a rule as
+// "address weight=N", with the number of endpoints if they were resolved.
+func backendDiagnostics(backends []*routingv1.Backend) []string {
+	formatted := make([]string, 0, len(backends))
+
+	for _, backend := range backends {
+		value := backend.GetAddress() + " weight=" + strconv.FormatUint(uint64(backend.GetWeight()), 10)
+		if endpoints := len(backend.GetEndpoints()); endpoints > 0 {
+			value += " endpoints=" + strconv.Itoa(endpoints)
+		}
+
+		formatted = append(formatted, value)
+	}
+
+	return formatted
+}
+
+// routeListenerDiagnostics formats the listeners of a route as "port" or
+// "port:hostname,hostname", followed by " except hostname,hostname" for the
+// hostnames of more specific listeners.
+func routeListenerDiagnostics(listeners []*routingv1.RouteListener) []string {
+	formatted := make([]string, 0, len(listeners))
+
+	for _, listener := range listeners {
+		value := strconv.FormatUint(uint64(listener.GetPort()), 10)
+		if len(listener.GetHostnames()) > 0 {
+			value += ":" + strings.Join(listener.GetHostnames(), ",")
+		}
+
+		if len(listener.GetExcludedHostnames()) > 0 {
+			value += " except " + strings.Join(listener.GetExcludedHostnames(), ",")
+		}
+
+		formatted = append(formatted, value)
+	}
+
+	return formatted
+}
+
+func droppedFilter(path *field.Path, filterType string) droppedDiagnostics {
+	return droppedDiagnostics{
+		Path:    path.String(),
+		Reason:  droppedReasonUnsupportedFilter,
+		Message: "filter type " + strconv.Quote(filterType) + " is not supported by the Pingora proxy",
+	}
+}
+
+// droppedBackendRef returns the backendRef if the builder skips it.
+func droppedBackendRef(path *field.Path, ref *gatewayv1.BackendRef) []droppedDiagnostics {
+	status := ingress.CheckBackendRefs([]gatewayv1.BackendRef{*ref})
+	if status.Resolved {
+		return nil
+	}
+
+	return []droppedDiagnostics{{
+		Path:    path.String(),
+		Reason:  droppedReasonUnsupportedBackend,
+		Message: status.Message,
+	}}
+}
+
+// annotateRouteDiagnostics writes the diagnostics of every built route to
+// its RouteDiagnosticsAnnotation. Routes are built in the order of the
+// route lists, so the built routes are matched by index.
+func (s *PingoraRouteSyncer) annotateRouteDiagnostics(ctx context.Context, logger *slog.Logger, built *builtRoutes) {
+	for i := range built.httpRoutes {
+		if i < len(built.pingoraHTTPRoutes) {
+			route := &built.httpRoutes[i]
+			s.writeRouteDiagnostics(ctx, logger, route, httpRouteDiagnostics(route, built.pingoraHTTPRoutes[i]))
+		}
+	}
+
+	for i := range built.grpcRoutes {
+		if i < len(built.pingoraGRPCRoutes) {
+			route := &built.grpcRoutes[i]
+			s.writeRouteDiagnostics(ctx, logger, route, grpcRouteDiagnostics(route, built.pingoraGRPCRoutes[i]))
+		}
+	}
+}
+
+// writeRouteDiagnostics writes the diagnostics of a single route to its
+// RouteDiagnosticsAnnotation.
+func (s *PingoraRouteSyncer) writeRouteDiagnostics(
+	ctx context.Context,
+	logger *slog.Logger,
+	route client.Object,
+	diagnostics routeDiagnostics,
+) {
+	data, err := json.Marshal(diagnostics)
+	if err != nil {
+		logger.Warn("failed to build route diagnostics annotation",
+			"route", route.GetNamespace()+"/"+route.GetName(), "error", err)
+
+		return
+	}
+
+	s.annotateRoute(ctx, logger, route, RouteDiagnosticsAnnotation, string(data))
+}
+
+// annotateRoute sets an annotation of the route. The route is only patched
+// when the value changed. Failures are logged and do not affect the sync,
+// since the annotations are purely diagnostic.
+func (s *PingoraRouteSyncer) annotateRoute(
+	ctx context.Context,
+	logger *slog.Logger,
+	route client.Object,
+	annotation, value string,
+) {
+	if route.GetAnnotations()[annotation] == value {
+		return
+	}
+
+	base, ok := route.DeepCopyObject().(client.Object)
+	if !ok {
+		return
+	}
+
+	annotations := route.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+
+	annotations[annotation] = value
+	route.SetAnnotations(annotations)
+
+	if err := s.Patch(ctx, route, client.MergeFrom(base)); err != nil {
+		logger.Warn("failed to update route annotation", "route", route.GetNamespace()+"/"+route.GetName(),
+			"annotation", annotation, "error", err)
+	}
+}
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/config"
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestHTTPRouteDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	regex := gatewayv1.PathMatchRegularExpression
+	port := gatewayv1.PortNumber(8080)
+	configMapKind := gatewayv1.Kind("ConfigMap")
+	invalidPattern := "/(a"
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 4},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{
+					Filters: []gatewayv1.HTTPRouteFilter{
+						{Type: gatewayv1.HTTPRouteFilterCORS},
+						{Type: gatewayv1.HTTPRouteFilterRequestMirror},
+					},
+					BackendRefs: []gatewayv1.HTTPBackendRef{
+						{
+							BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+								Name: "app", Port: &port,
+							}},
+							Filters: []gatewayv1.HTTPRouteFilter{
+								{
+									Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+									RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Remove: []string{"x-debug"}},
+								},
+								{Type: gatewayv1.HTTPRouteFilterURLRewrite},
+							},
+						},
+						{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+							Name: "settings", Kind: &configMapKind,
+						}}},
+					},
+				},
+				{
+					Matches: []gatewayv1.HTTPRouteMatch{
+						{Path: &gatewayv1.HTTPPathMatch{Type: &regex, Value: &invalidPattern}},
+					},
+				},
+			},
+		},
+	}
+
+	built := &routingv1.HTTPRoute{
+		Id:        "default/app",
+		Hostnames: []string{"app.example.com"},
+		Listeners: []*routingv1.RouteListener{
+			{Port: 80, ExcludedHostnames: []string{"admin.example.com"}},
+			{Port: 443, Hostnames: []string{"app.example.com"}},
+		},
+		Rules: []*routingv1.HTTPRouteRule{
+			{
+				Name: "api",
+				Matches: []*routingv1.HTTPRouteMatch{
+					{
+						Path:     &routingv1.PathMatch{Type: routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, Value: "/api"},
+						Method:   "GET",
+						Headers:  []*routingv1.HeaderMatch{{Name: "x-env", Value: "prod"}},
+						Priority: 2,
+					},
+					{
+						QueryParams: []*routingv1.QueryParamMatch{{
+							Name: "v", Value: "^[0-9]+$", Type: routingv1.QueryParamMatchType_QUERY_PARAM_MATCH_TYPE_REGEX,
+						}},
+						Priority: 5,
+					},
+				},
+				Backends: []*routingv1.Backend{
+					{Address: "app.default.svc.cluster.local:8080", Weight: 1, Endpoints: []string{"10.0.0.1:8080", "10.0.0.2:8080"}},
+				},
+				InvalidBackendWeight: 1,
+			},
+		},
+	}
+
+	diagnostics := httpRouteDiagnostics(route, built)
+
+	assert.Equal(t, routeDiagnostics{
+		Generation: 4,
+		ID:         "default/app",
+		Hostnames:  []string{"app.example.com"},
+		Listeners:  []string{"80 except admin.example.com", "443:app.example.com"},
+		Rules: []ruleDiagnostics{{
+			Name: "api",
+			Matches: []string{
+				"path PathPrefix /api, method GET, header x-env=prod (priority 2)",
+				"query v~^[0-9]+$ (priority 5)",
+			},
+			Backends:             []string{"app.default.svc.cluster.local:8080 weight=1 endpoints=2"},
+			InvalidBackendWeight: 1,
+		}},
+		Dropped: []droppedDiagnostics{
+			{
+				Path:    "spec.rules[1]",
+				Reason:  droppedReasonInvalidRegex,
+				Message: diagnostics.Dropped[0].Message,
+			},
+			{
+				Path:    "spec.rules[0].filters[1]",
+				Reason:  droppedReasonUnsupportedFilter,
+				Message: `filter type "RequestMirror" is not supported by the Pingora proxy`,
+			},
+			{
+				Path:    "spec.rules[0].backendRefs[0].filters[1]",
+				Reason:  droppedReasonUnsupportedFilter,
+				Message: `filter type "URLRewrite" is not supported by the Pingora proxy`,
+			},
+			{
+				Path:    "spec.rules[0].backendRefs[1]",
+				Reason:  droppedReasonUnsupportedBackend,
+				Message: `Unsupported backend kind "ConfigMap" for backendRef "settings"`,
+			},
+		},
+	}, diagnostics)
+	assert.Contains(t, diagnostics.Dropped[0].Message, "/(a")
+}
+
+func TestGRPCRouteDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: "default", Generation: 1},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{{
+				Filters: []gatewayv1.GRPCRouteFilter{
+					{
+						Type:                  gatewayv1.GRPCRouteFilterRequestHeaderModifier,
+						RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Remove: []string{"x-debug"}},
+					},
+					{Type: gatewayv1.GRPCRouteFilterExtensionRef},
+				},
+			}},
+		},
+	}
+
+	built := &routingv1.GRPCRoute{
+		Id: "default/grpc",
+		Rules: []*routingv1.GRPCRouteRule{
+			{
+				Matches: []*routingv1.GRPCRouteMatch{
+					{
+						Method: &routingv1.GRPCMethodMatch{
+							Type:    routingv1.GRPCMethodMatchType_GRPC_METHOD_MATCH_TYPE_EXACT,
+							Service: "echo.Echo",
+							Method:  "Ping",
+						},
+						Priority: 1,
+					},
+					{Priority: 2},
+				},
+				FixedResponse: &routingv1.FixedResponse{StatusCode: 500},
+			},
+		},
+	}
+
+	diagnostics := grpcRouteDiagnostics(route, built)
+
+	require.Len(t, diagnostics.Rules, 1)
+	assert.Equal(t, []string{"method Exact echo.Echo/Ping (priority 1)", "any (priority 2)"}, diagnostics.Rules[0].Matches)
+	assert.Equal(t, uint32(500), diagnostics.Rules[0].FixedResponse)
+	assert.Equal(t, []droppedDiagnostics{{
+		Path:    "spec.rules[0].filters[1]",
+		Reason:  droppedReasonUnsupportedFilter,
+		Message: `filter type "ExtensionRef" is not supported by the Pingora proxy`,
+	}}, diagnostics.Dropped)
+}
+
+func TestPingoraRouteSyncer_RouteDiagnosticsAnnotations(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+
+	port := gatewayv1.PortNumber(8080)
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+
+	pingoraConfig := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec:       v1alpha1.PingoraConfigSpec{Address: "pingora-proxy:50051"},
+	}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 2},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "web", Namespace: &gatewayNamespace}},
+			},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: []gatewayv1.HTTPBackendRef{
+					{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: "app", Port: &port,
+					}}},
+				},
+			}},
+		},
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pingoraConfig, newPingoraGatewayClass("pingora"), newGateway("web", "pingora", gatewayv1.NamespacesFromAll), route,
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}).
+		WithStatusSubresource(pingoraConfig, &gatewayv1.HTTPRoute{}).
+		Build()
+
+	syncer := NewPingoraRouteSyncer(cli, scheme, dns.NewClusterDomainProvider(dns.DefaultClusterDomain), "pingora",
+		config.NewPingoraResolver(cli, "pingora-system"), metrics.NewNoopCollector(), 0, slog.Default())
+	syncer.DryRun = true
+	syncer.RouteDiagnosticsAnnotations = true
+
+	_, _, err := syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+
+	var annotated gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(route), &annotated))
+
+	value := annotated.Annotations[RouteDiagnosticsAnnotation]
+	require.NotEmpty(t, value)
+
+	var diagnostics routeDiagnostics
+	require.NoError(t, json.Unmarshal([]byte(value), &diagnostics))
+
+	assert.Equal(t, int64(2), diagnostics.Generation)
+	assert.Equal(t, "default/app", diagnostics.ID)
+	require.Len(t, diagnostics.Rules, 1)
+	assert.Equal(t, []string{"app.default.svc.cluster.local:8080 weight=1"}, diagnostics.Rules[0].Backends)
+	assert.Empty(t, diagnostics.Dropped)
+
+	// An unchanged config must not patch the route again
+	_, _, err = syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+
+	var unchanged gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(route), &unchanged))
+	assert.Equal(t, annotated.ResourceVersion, unchanged.ResourceVersion)
+}
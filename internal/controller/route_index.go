@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// IndexRouteParentGateway indexes routes by the namespace/name of the
+	// Gateways in their parentRefs.
+	IndexRouteParentGateway = "spec.parentRefs.gateway"
+
+	// IndexRouteBackendNamespace indexes routes by the namespaces of their
+	// backends outside the route namespace.
+	IndexRouteBackendNamespace = "spec.rules.backendRefs.crossNamespace"
+
+	// IndexRouteBackendService indexes routes by the namespace/name of the
+	// Services in their backendRefs.
+	IndexRouteBackendService = "spec.rules.backendRefs.service"
+)
+
+// indexRoutes registers the route field indexes for a route type, so that
+// the Gateway, ReferenceGrant and Service mappers list only the routes they affect.
+func indexRoutes(
+	ctx context.Context,
+	indexer client.FieldIndexer,
+	obj client.Object,
+	wrap func(client.Object) Route,
+) error {
+	err := indexer.IndexField(ctx, obj, IndexRouteParentGateway, func(obj client.Object) []string {
+		return parentGatewayKeys(wrap(obj))
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to index %T by parent gateway", obj)
+	}
+
+	err = indexer.IndexField(ctx, obj, IndexRouteBackendNamespace, func(obj client.Object) []string {
+		return wrap(obj).GetCrossNamespaceBackendNamespaces()
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to index %T by backend namespace", obj)
+	}
+
+	err = indexer.IndexField(ctx, obj, IndexRouteBackendService, func(obj client.Object) []string {
+		return backendServiceKeys(wrap(obj))
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to index %T by backend service", obj)
+	}
+
+	return nil
+}
+
+// parentGatewayKeys returns the namespace/name keys of the Gateways a
+// route references in its parentRefs.
+func parentGatewayKeys(route Route) []string {
+	var keys []string
+
+	for _, ref := range route.GetParentRefs() {
+		if ref.Kind != nil && *ref.Kind != kindGateway {
+			continue
+		}
+
+		if ref.Group != nil && *ref.Group != gatewayv1.GroupName {
+			continue
+		}
+
+		key := gatewayKey(route.GetNamespace(), ref)
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// backendServiceKeys returns the namespace/name keys of the Services a route
+// references in its backendRefs.
+func backendServiceKeys(route Route) []string {
+	var keys []string
+
+	for _, ref := range route.GetBackendRefs() {
+		if ref.Group != nil && *ref.Group != "" {
+			continue
+		}
+
+		if ref.Kind != nil && *ref.Kind != "Service" {
+			continue
+		}
+
+		key := backendNamespace(route.GetNamespace(), &ref) + "/" + string(ref.Name)
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// gatewayKey returns the namespace/name key of the Gateway a parentRef points
+// to, defaulting to the route namespace.
+func gatewayKey(routeNamespace string, ref gatewayv1.ParentReference) string {
+	namespace := routeNamespace
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+
+	return namespace + "/" + string(ref.Name)
+}
+
+// httpRouteForIndex wraps an indexed HTTPRoute.
+func httpRouteForIndex(obj client.Object) Route {
+	route, _ := obj.(*gatewayv1.HTTPRoute)
+	if route == nil {
+		route = &gatewayv1.HTTPRoute{}
+	}
+
+	return HTTPRouteWrapper{route}
+}
+
+// grpcRouteForIndex wraps an indexed GRPCRoute.
+func grpcRouteForIndex(obj client.Object) Route {
+	route, _ := obj.(*gatewayv1.GRPCRoute)
+	if route == nil {
+		route = &gatewayv1.GRPCRoute{}
+	}
+
+	return GRPCRouteWrapper{route}
+}
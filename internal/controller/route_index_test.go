@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestParentGatewayKeys(t *testing.T) {
+	t.Parallel()
+
+	otherNamespace := gatewayv1.Namespace("gateway-system")
+	serviceKind := gatewayv1.Kind("Service")
+	otherGroup := gatewayv1.Group("example.com")
+
+	tests := []struct {
+		name     string
+		refs     []gatewayv1.ParentReference
+		expected []string
+	}{
+		{
+			name:     "defaults to route namespace",
+			refs:     []gatewayv1.ParentReference{{Name: "public"}},
+			expected: []string{"default/public"},
+		},
+		{
+			name: "deduplicates section refs",
+			refs: []gatewayv1.ParentReference{
+				{Name: "public", Namespace: &otherNamespace},
+				{Name: "public", Namespace: &otherNamespace},
+				{Name: "internal"},
+			},
+			expected: []string{"gateway-system/public", "default/internal"},
+		},
+		{
+			name: "skips other kinds and groups",
+			refs: []gatewayv1.ParentReference{
+				{Name: "mesh", Kind: &serviceKind},
+				{Name: "custom", Group: &otherGroup},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: tt.refs},
+				},
+			}
+
+			assert.Equal(t, tt.expected, parentGatewayKeys(httpRouteForIndex(route)))
+		})
+	}
+}
+
+func TestHTTPRouteReconciler_FindRoutesForGateway(t *testing.T) {
+	t.Parallel()
+
+	gateway := newGateway("public", "pingora", gatewayv1.NamespacesFromAll)
+
+	attached := newRouteWithParent("attached", "team-a", "public").(HTTPRouteWrapper).HTTPRoute
+	other := newRouteWithParent("other", "team-a", "internal").(HTTPRouteWrapper).HTTPRoute
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gateway, attached, other).
+		WithIndex(&gatewayv1.HTTPRoute{}, IndexRouteParentGateway, func(obj client.Object) []string {
+			return parentGatewayKeys(httpRouteForIndex(obj))
+		}).
+		Build()
+
+	reconciler := &PingoraHTTPRouteReconciler{Client: cli, GatewayClassName: "pingora"}
+
+	requests := reconciler.findRoutesForGateway(context.Background(), gateway)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "attached", requests[0].Name)
+}
+
+func TestCrossNamespaceBackendIndex(t *testing.T) {
+	t.Parallel()
+
+	backends := gatewayv1.Namespace("backends")
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{{
+				BackendRefs: []gatewayv1.GRPCBackendRef{
+					{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{Name: "local"}}},
+					{BackendRef: gatewayv1.BackendRef{BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: "remote", Namespace: &backends,
+					}}},
+				},
+			}},
+		},
+	}
+
+	assert.Equal(t, []string{"backends"}, grpcRouteForIndex(route).GetCrossNamespaceBackendNamespaces())
+}
+
+func TestBackendServiceKeys(t *testing.T) {
+	t.Parallel()
+
+	backends := gatewayv1.Namespace("backends")
+	group := gatewayv1.Group("pingora.k8s.lex.la")
+	kind := gatewayv1.Kind("PingoraBackend")
+
+	backendRef := func(ref gatewayv1.BackendObjectReference) gatewayv1.HTTPBackendRef {
+		return gatewayv1.HTTPBackendRef{BackendRef: gatewayv1.BackendRef{BackendObjectReference: ref}}
+	}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{BackendRefs: []gatewayv1.HTTPBackendRef{
+					backendRef(gatewayv1.BackendObjectReference{Name: "local"}),
+					backendRef(gatewayv1.BackendObjectReference{Name: "remote", Namespace: &backends}),
+					backendRef(gatewayv1.BackendObjectReference{Name: "external", Group: &group, Kind: &kind}),
+				}},
+				{BackendRefs: []gatewayv1.HTTPBackendRef{
+					backendRef(gatewayv1.BackendObjectReference{Name: "local"}),
+				}},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"default/local", "backends/remote"}, backendServiceKeys(httpRouteForIndex(route)))
+}
+
+func TestGRPCRouteReconciler_FindRoutesForService(t *testing.T) {
+	t.Parallel()
+
+	route := func(name, service string) *gatewayv1.GRPCRoute {
+		return &gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: gatewayv1.GRPCRouteSpec{
+				Rules: []gatewayv1.GRPCRouteRule{{
+					BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{Name: gatewayv1.ObjectName(service)},
+					}}},
+				}},
+			},
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(route("uses-app", "app"), route("uses-other", "other")).
+		WithIndex(&gatewayv1.GRPCRoute{}, IndexRouteBackendService, func(obj client.Object) []string {
+			return backendServiceKeys(grpcRouteForIndex(obj))
+		}).
+		Build()
+
+	reconciler := &PingoraGRPCRouteReconciler{Client: cli, GatewayClassName: "pingora"}
+
+	requests := reconciler.findRoutesForService(context.Background(),
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}})
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, "uses-app", requests[0].Name)
+
+	assert.Empty(t, reconciler.findRoutesForService(context.Background(),
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "other"}}))
+}
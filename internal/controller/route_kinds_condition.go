@@ -0,0 +1,34 @@
+package controller
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// setInvalidRouteKinds marks the references of a listener not resolved
+// because its allowedRoutes.kinds name route kinds the controller does not
+// reconcile. The listener stays programmed for the supported kinds.
+func setInvalidRouteKinds(conditions []metav1.Condition, invalid []gatewayv1.RouteGroupKind) {
+	names := make([]string, 0, len(invalid))
+
+	for _, kind := range invalid {
+		name := string(kind.Kind)
+		if kind.Group != nil && *kind.Group != "" {
+			name = string(*kind.Group) + "/" + name
+		}
+
+		names = append(names, name)
+	}
+
+	for i := range conditions {
+		condition := &conditions[i]
+
+		if condition.Type == string(gatewayv1.ListenerConditionResolvedRefs) {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = string(gatewayv1.ListenerReasonInvalidRouteKinds)
+			condition.Message = "Unsupported route kinds: " + strings.Join(names, ", ")
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestSetInvalidRouteKinds(t *testing.T) {
+	t.Parallel()
+
+	conditions := []metav1.Condition{
+		{Type: string(gatewayv1.ListenerConditionAccepted), Status: metav1.ConditionTrue},
+		{Type: string(gatewayv1.ListenerConditionProgrammed), Status: metav1.ConditionTrue},
+		{Type: string(gatewayv1.ListenerConditionResolvedRefs), Status: metav1.ConditionTrue},
+	}
+
+	otherGroup := gatewayv1.Group("example.com")
+
+	setInvalidRouteKinds(conditions, []gatewayv1.RouteGroupKind{
+		{Kind: "TCPRoute"},
+		{Group: &otherGroup, Kind: "HTTPRoute"},
+	})
+
+	assert.Equal(t, metav1.ConditionTrue, conditions[0].Status)
+	assert.Equal(t, metav1.ConditionTrue, conditions[1].Status)
+	assert.Equal(t, metav1.ConditionFalse, conditions[2].Status)
+	assert.Equal(t, string(gatewayv1.ListenerReasonInvalidRouteKinds), conditions[2].Reason)
+	assert.Equal(t, "Unsupported route kinds: TCPRoute, example.com/HTTPRoute", conditions[2].Message)
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"maps"
+	"slices"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// portHostnames collects the hostnames a route serves on a listener port.
+// all is set once any listener on the port serves every hostname.
+type portHostnames struct {
+	all       bool
+	hostnames []string
+
+	// attached are the hostnames of the listeners the route is attached
+	// to, and shadowed the hostnames of the more specific listeners on the
+	// port, whose requests the route must not receive.
+	attached []string
+	shadowed []string
+}
+
+func (p *portHostnames) add(hostnames []string) {
+	if len(hostnames) == 0 {
+		p.all = true
+
+		return
+	}
+
+	for _, hostname := range hostnames {
+		if !slices.Contains(p.hostnames, hostname) {
+			p.hostnames = append(p.hostnames, hostname)
+		}
+	}
+}
+
+// isolate records the listener the route is attached to and the more
+// specific listeners on the port.
+func (p *portHostnames) isolate(listener *gatewayv1.Hostname, portListeners []*gatewayv1.Hostname) {
+	p.attached = appendHostname(p.attached, listener)
+
+	for _, other := range portListeners {
+		if routebinding.MoreSpecificHostname(listener, other) {
+			p.shadowed = appendHostname(p.shadowed, other)
+		}
+	}
+}
+
+func (p *portHostnames) list() []string {
+	if p.all {
+		return nil
+	}
+
+	return p.hostnames
+}
+
+// excluded returns the shadowed hostnames, except those of listeners the
+// route is attached to as well.
+func (p *portHostnames) excluded() []string {
+	var excluded []string
+
+	for _, hostname := range p.shadowed {
+		if !slices.Contains(p.attached, hostname) {
+			excluded = append(excluded, hostname)
+		}
+	}
+
+	return excluded
+}
+
+func appendHostname(hostnames []string, hostname *gatewayv1.Hostname) []string {
+	value := ""
+	if hostname != nil {
+		value = string(*hostname)
+	}
+
+	if slices.Contains(hostnames, value) {
+		return hostnames
+	}
+
+	return append(hostnames, value)
+}
+
+// listenerHostnamesByPort returns the hostnames of the listeners of the
+// given Gateways by port, nil for listeners without one. The proxy serves
+// the listeners of all Gateways of the class on a port together, so they
+// are isolated from each other as if they were listeners of one Gateway.
+func listenerHostnamesByPort(gateways []gatewayv1.Gateway) map[uint32][]*gatewayv1.Hostname {
+	ports := make(map[uint32][]*gatewayv1.Hostname)
+
+	for i := range gateways {
+		for j := range gateways[i].Spec.Listeners {
+			listener := &gateways[i].Spec.Listeners[j]
+
+			port := uint32(listener.Port) //nolint:gosec // listener ports are validated to 1-65535
+			ports[port] = append(ports[port], listener.Hostname)
+		}
+	}
+
+	return ports
+}
+
+// routeListeners returns the listener ports of the accepted bindings of a
+// route, ordered by port, with the route hostnames narrowed per listener,
+// and the union of those hostnames for the route as a whole. ok is false
+// if no listener accepted the route, so the built hostnames are kept.
+//
+// Hostnames of more specific listeners in portListeners that the route is
+// not attached to are excluded per port, so that requests for them never
+// fall through to the route of a broader listener.
+func routeListeners(
+	binding routeBindingInfo,
+	portListeners map[uint32][]*gatewayv1.Hostname,
+) (hostnames []string, listeners []*routingv1.RouteListener, ok bool) {
+	ports := make(map[uint32]*portHostnames)
+
+	var route portHostnames
+
+	for _, refIdx := range slices.Sorted(maps.Keys(binding.bindingResults)) {
+		result := binding.bindingResults[refIdx]
+		if !result.Accepted {
+			continue
+		}
+
+		for _, listener := range result.Listeners {
+			narrowed := make([]string, 0, len(listener.Hostnames))
+			for _, hostname := range listener.Hostnames {
+				narrowed = append(narrowed, string(hostname))
+			}
+
+			port := uint32(listener.Port) //nolint:gosec // listener ports are validated to 1-65535
+			if ports[port] == nil {
+				ports[port] = &portHostnames{}
+			}
+
+			ports[port].add(narrowed)
+			ports[port].isolate(listener.ListenerHostname, portListeners[port])
+			route.add(narrowed)
+		}
+	}
+
+	if len(ports) == 0 {
+		return nil, nil, false
+	}
+
+	listeners = make([]*routingv1.RouteListener, 0, len(ports))
+	for _, port := range slices.Sorted(maps.Keys(ports)) {
+		listeners = append(listeners, &routingv1.RouteListener{
+			Port:              port,
+			Hostnames:         ports[port].list(),
+			ExcludedHostnames: ports[port].excluded(),
+		})
+	}
+
+	return route.list(), listeners, true
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestRouteListeners(t *testing.T) {
+	t.Parallel()
+
+	listener := func(port gatewayv1.PortNumber, hostnames ...gatewayv1.Hostname) routebinding.ListenerMatch {
+		return routebinding.ListenerMatch{Port: port, Hostnames: hostnames}
+	}
+
+	hostname := func(value string) *gatewayv1.Hostname {
+		if value == "" {
+			return nil
+		}
+
+		hostname := gatewayv1.Hostname(value)
+
+		return &hostname
+	}
+
+	isolated := func(port gatewayv1.PortNumber, listenerHostname string, hostnames ...gatewayv1.Hostname) routebinding.ListenerMatch {
+		match := listener(port, hostnames...)
+		match.ListenerHostname = hostname(listenerHostname)
+
+		return match
+	}
+
+	// Port 443 serves a catch-all, a wildcard and two exact listeners
+	portListeners := map[uint32][]*gatewayv1.Hostname{
+		443: {nil, hostname("*.example.com"), hostname("foo.example.com"), hostname("bar.example.com")},
+		80:  {hostname("*.example.com")},
+	}
+
+	tests := []struct {
+		name              string
+		results           map[int]routebinding.BindingResult
+		portListeners     map[uint32][]*gatewayv1.Hostname
+		expectedHostnames []string
+		expectedListeners []*routingv1.RouteListener
+		expectedOK        bool
+	}{
+		{
+			name: "no accepted binding",
+			results: map[int]routebinding.BindingResult{
+				0: {Accepted: false},
+			},
+		},
+		{
+			name: "hostnames narrowed per port",
+			results: map[int]routebinding.BindingResult{
+				0: {Accepted: true, Listeners: []routebinding.ListenerMatch{
+					listener(443, "foo.example.com"),
+					listener(80, "foo.example.com"),
+				}},
+				1: {Accepted: true, Listeners: []routebinding.ListenerMatch{
+					listener(443, "bar.example.com", "foo.example.com"),
+				}},
+			},
+			expectedHostnames: []string{"foo.example.com", "bar.example.com"},
+			expectedListeners: []*routingv1.RouteListener{
+				{Port: 80, Hostnames: []string{"foo.example.com"}},
+				{Port: 443, Hostnames: []string{"foo.example.com", "bar.example.com"}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "listener matching all hostnames widens its port",
+			results: map[int]routebinding.BindingResult{
+				0: {Accepted: true, Listeners: []routebinding.ListenerMatch{
+					listener(80, "foo.example.com"),
+					listener(80),
+					listener(443, "foo.example.com"),
+				}},
+				1: {Accepted: false, Listeners: []routebinding.ListenerMatch{listener(8443)}},
+			},
+			expectedListeners: []*routingv1.RouteListener{
+				{Port: 80},
+				{Port: 443, Hostnames: []string{"foo.example.com"}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "wildcard listener excludes more specific listeners",
+			results: map[int]routebinding.BindingResult{
+				0: {Accepted: true, Listeners: []routebinding.ListenerMatch{
+					isolated(443, "*.example.com", "*.example.com"),
+					isolated(80, "*.example.com", "*.example.com"),
+				}},
+			},
+			portListeners:     portListeners,
+			expectedHostnames: []string{"*.example.com"},
+			expectedListeners: []*routingv1.RouteListener{
+				{Port: 80, Hostnames: []string{"*.example.com"}},
+				{
+					Port:              443,
+					Hostnames:         []string{"*.example.com"},
+					ExcludedHostnames: []string{"foo.example.com", "bar.example.com"},
+				},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "listeners the route is attached to are not excluded",
+			results: map[int]routebinding.BindingResult{
+				0: {Accepted: true, Listeners: []routebinding.ListenerMatch{
+					isolated(443, ""),
+					isolated(443, "foo.example.com", "foo.example.com"),
+				}},
+			},
+			portListeners: portListeners,
+			expectedListeners: []*routingv1.RouteListener{
+				{Port: 443, ExcludedHostnames: []string{"*.example.com", "bar.example.com"}},
+			},
+			expectedOK: true,
+		},
+		{
+			name: "exact listener excludes nothing",
+			results: map[int]routebinding.BindingResult{
+				0: {Accepted: true, Listeners: []routebinding.ListenerMatch{
+					isolated(443, "bar.example.com", "bar.example.com"),
+				}},
+			},
+			portListeners:     portListeners,
+			expectedHostnames: []string{"bar.example.com"},
+			expectedListeners: []*routingv1.RouteListener{
+				{Port: 443, Hostnames: []string{"bar.example.com"}},
+			},
+			expectedOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			hostnames, listeners, ok := routeListeners(routeBindingInfo{bindingResults: tt.results}, tt.portListeners)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedHostnames, hostnames)
+			assert.Equal(t, tt.expectedListeners, listeners)
+		})
+	}
+}
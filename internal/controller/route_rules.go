@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// httpRouteRules lists the rules of built HTTP routes for metrics and logs.
+func httpRouteRules(routes []*routingv1.HTTPRoute) []metrics.RouteRule {
+	var rules []metrics.RouteRule
+
+	for _, route := range routes {
+		for i, rule := range route.GetRules() {
+			rules = append(rules, metrics.RouteRule{Route: route.GetId(), Index: i, Name: rule.GetName()})
+		}
+	}
+
+	return rules
+}
+
+// grpcRouteRules lists the rules of built gRPC routes for metrics and logs.
+func grpcRouteRules(routes []*routingv1.GRPCRoute) []metrics.RouteRule {
+	var rules []metrics.RouteRule
+
+	for _, route := range routes {
+		for i, rule := range route.GetRules() {
+			rules = append(rules, metrics.RouteRule{Route: route.GetId(), Index: i, Name: rule.GetName()})
+		}
+	}
+
+	return rules
+}
+
+// httpRouteGenerations lists the generations of HTTPRoutes for metrics.
+func httpRouteGenerations(routes []gatewayv1.HTTPRoute) []metrics.RouteGeneration {
+	generations := make([]metrics.RouteGeneration, 0, len(routes))
+
+	for i := range routes {
+		generations = append(generations, metrics.RouteGeneration{
+			Route:      routes[i].Namespace + "/" + routes[i].Name,
+			Generation: routes[i].Generation,
+		})
+	}
+
+	return generations
+}
+
+// grpcRouteGenerations lists the generations of GRPCRoutes for metrics.
+func grpcRouteGenerations(routes []gatewayv1.GRPCRoute) []metrics.RouteGeneration {
+	generations := make([]metrics.RouteGeneration, 0, len(routes))
+
+	for i := range routes {
+		generations = append(generations, metrics.RouteGeneration{
+			Route:      routes[i].Namespace + "/" + routes[i].Name,
+			Generation: routes[i].Generation,
+		})
+	}
+
+	return generations
+}
+
+// namedRules formats the named rules as "<route id>:<rule name>" for logging.
+// Unnamed rules are left out, as their route is already logged.
+func namedRules(rules []metrics.RouteRule) []string {
+	names := make([]string, 0, len(rules))
+
+	for _, rule := range rules {
+		if rule.Name != "" {
+			names = append(names, fmt.Sprintf("%s:%s", rule.Route, rule.Name))
+		}
+	}
+
+	return names
+}
+
+const (
+	// droppedRuleReasonInvalidRegex is the metrics reason of rules dropped for
+	// invalid regular expressions.
+	droppedRuleReasonInvalidRegex = "invalid_regex"
+
+	// droppedRuleReasonUnsupportedBackend is the metrics reason of backendRefs
+	// skipped for a kind other than Service.
+	droppedRuleReasonUnsupportedBackend = "unsupported_backend"
+)
+
+// recordHTTPRouteDrops counts what the builder leaves out of an HTTPRoute:
+// rules with invalid regular expressions, skipped backendRefs and ignored
+// filters. It is called once per build, so cached routes are not counted
+// again on every sync.
+func recordHTTPRouteDrops(ctx context.Context, collector metrics.Collector, route *gatewayv1.HTTPRoute) {
+	recordRouteDrops(ctx, collector, "http",
+		len(ingress.HTTPRouteRegexIssues(route.Spec.Rules)),
+		ingress.HTTPRouteSkippedBackendRefs(route.Spec.Rules),
+		ingress.HTTPRouteUnsupportedFilters(route.Spec.Rules))
+}
+
+// recordGRPCRouteDrops counts what the builder leaves out of a GRPCRoute,
+// see recordHTTPRouteDrops.
+func recordGRPCRouteDrops(ctx context.Context, collector metrics.Collector, route *gatewayv1.GRPCRoute) {
+	recordRouteDrops(ctx, collector, "grpc",
+		len(ingress.GRPCRouteRegexIssues(route.Spec.Rules)),
+		ingress.GRPCRouteSkippedBackendRefs(route.Spec.Rules),
+		ingress.GRPCRouteUnsupportedFilters(route.Spec.Rules))
+}
+
+func recordRouteDrops(
+	ctx context.Context,
+	collector metrics.Collector,
+	routeType string,
+	invalidRegexRules, skippedBackends int,
+	unsupportedFilters []string,
+) {
+	for range invalidRegexRules {
+		collector.RecordDroppedRule(ctx, routeType, droppedRuleReasonInvalidRegex)
+	}
+
+	for range skippedBackends {
+		collector.RecordDroppedRule(ctx, routeType, droppedRuleReasonUnsupportedBackend)
+	}
+
+	for _, filter := range unsupportedFilters {
+		collector.RecordFilterUnsupported(ctx, filter)
+	}
+}
+
+// httpRegexIssues formats the HTTPRoute rules dropped for invalid regular
+// expressions as "<namespace>/<name> rule <index>: <error>" for logging.
+func httpRegexIssues(routes []gatewayv1.HTTPRoute) []string {
+	var issues []string
+
+	for i := range routes {
+		for _, issue := range ingress.HTTPRouteRegexIssues(routes[i].Spec.Rules) {
+			issues = append(issues, fmt.Sprintf("%s/%s %s", routes[i].Namespace, routes[i].Name, issue))
+		}
+	}
+
+	return issues
+}
+
+// grpcRegexIssues formats the GRPCRoute rules dropped for invalid regular
+// expressions as "<namespace>/<name> rule <index>: <error>" for logging.
+func grpcRegexIssues(routes []gatewayv1.GRPCRoute) []string {
+	var issues []string
+
+	for i := range routes {
+		for _, issue := range ingress.GRPCRouteRegexIssues(routes[i].Spec.Rules) {
+			issues = append(issues, fmt.Sprintf("%s/%s %s", routes[i].Namespace, routes[i].Name, issue))
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestRouteRules(t *testing.T) {
+	t.Parallel()
+
+	httpRoutes := []*routingv1.HTTPRoute{
+		{Id: "default/web", Rules: []*routingv1.HTTPRouteRule{{Name: "api"}, {}}},
+		{Id: "default/empty"},
+	}
+	grpcRoutes := []*routingv1.GRPCRoute{
+		{Id: "default/echo", Rules: []*routingv1.GRPCRouteRule{{}, {Name: "stream"}}},
+	}
+
+	httpRules := httpRouteRules(httpRoutes)
+	grpcRules := grpcRouteRules(grpcRoutes)
+
+	assert.Equal(t, []metrics.RouteRule{
+		{Route: "default/web", Index: 0, Name: "api"},
+		{Route: "default/web", Index: 1},
+	}, httpRules)
+	assert.Equal(t, []metrics.RouteRule{
+		{Route: "default/echo", Index: 0},
+		{Route: "default/echo", Index: 1, Name: "stream"},
+	}, grpcRules)
+
+	assert.Equal(t, []string{"default/web:api"}, namedRules(httpRules))
+	assert.Equal(t, []string{"default/echo:stream"}, namedRules(grpcRules))
+	assert.Empty(t, namedRules(nil))
+}
+
+func TestRouteGenerations(t *testing.T) {
+	t.Parallel()
+
+	httpRoutes := []gatewayv1.HTTPRoute{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Generation: 3}},
+	}
+	grpcRoutes := []gatewayv1.GRPCRoute{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "echo", Generation: 1}},
+	}
+
+	assert.Equal(t, []metrics.RouteGeneration{{Route: "default/web", Generation: 3}}, httpRouteGenerations(httpRoutes))
+	assert.Equal(t, []metrics.RouteGeneration{{Route: "apps/echo", Generation: 1}}, grpcRouteGenerations(grpcRoutes))
+	assert.Empty(t, httpRouteGenerations(nil))
+}
+
+// dropCollector counts dropped rules and unsupported filters.
+type dropCollector struct {
+	metrics.NoopCollector
+
+	dropped map[string]int
+	filters map[string]int
+}
+
+func (c *dropCollector) RecordDroppedRule(_ context.Context, routeType, reason string) {
+	c.dropped[routeType+"/"+reason]++
+}
+
+func (c *dropCollector) RecordFilterUnsupported(_ context.Context, filterType string) {
+	c.filters[filterType]++
+}
+
+func TestRecordRouteDrops(t *testing.T) {
+	t.Parallel()
+
+	regex := gatewayv1.PathMatchRegularExpression
+	pattern := "("
+	bucket := gatewayv1.Kind("S3Bucket")
+
+	httpRoute := &gatewayv1.HTTPRoute{Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{
+		{Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{Type: &regex, Value: &pattern}}}},
+		{
+			Filters: []gatewayv1.HTTPRouteFilter{{Type: gatewayv1.HTTPRouteFilterURLRewrite}},
+			BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{Kind: &bucket, Name: "bucket"},
+			}}},
+		},
+	}}}
+	grpcRoute := &gatewayv1.GRPCRoute{Spec: gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{
+		{Filters: []gatewayv1.GRPCRouteFilter{{Type: gatewayv1.GRPCRouteFilterRequestMirror}}},
+	}}}
+
+	collector := &dropCollector{dropped: map[string]int{}, filters: map[string]int{}}
+	recordHTTPRouteDrops(context.Background(), collector, httpRoute)
+	recordGRPCRouteDrops(context.Background(), collector, grpcRoute)
+
+	assert.Equal(t, map[string]int{
+		"http/" + droppedRuleReasonInvalidRegex:       1,
+		"http/" + droppedRuleReasonUnsupportedBackend: 1,
+	}, collector.dropped)
+	assert.Equal(t, map[string]int{"URLRewrite": 1, "RequestMirror": 1}, collector.filters)
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// StatusFieldManager is the field manager of the Gateway and route status
+// applied by the controller.
+const StatusFieldManager = "pingora-gateway-controller"
+
+// applyStatus writes the status of obj with server-side apply. obj must hold
+// only its identity and the complete status owned by the controller, since
+// fields applied before and left out are removed.
+//
+// Route status parents are an atomic list, so the parents of other
+// controllers are included as read, and an apply without a resourceVersion
+// would delete entries written since. Route status is applied with the
+// resourceVersion of the read route, failing with a conflict instead.
+func applyStatus(ctx context.Context, c client.Client, obj client.Object) error {
+	//nolint:wrapcheck // wrapped by callers
+	return c.Status().Patch(ctx, obj, client.Apply, client.FieldOwner(StatusFieldManager), client.ForceOwnership)
+}
+
+// foreignParentStatuses returns the route parent statuses written by other
+// controllers. Route status updates rebuild only the entries of
+// controllerName, so that controllers sharing a route keep their entries.
+func foreignParentStatuses(
+	parents []gatewayv1.RouteParentStatus,
+	controllerName string,
+) []gatewayv1.RouteParentStatus {
+	return slices.DeleteFunc(slices.Clone(parents), func(parent gatewayv1.RouteParentStatus) bool {
+		return string(parent.ControllerName) == controllerName
+	})
+}
+
+// ownParentConditions returns the conditions of the route parent statuses
+// written by controllerName, keyed by parent reference.
+func ownParentConditions(
+	parents []gatewayv1.RouteParentStatus,
+	controllerName string,
+) map[string][]metav1.Condition {
+	conditions := make(map[string][]metav1.Condition)
+
+	for _, parent := range parents {
+		if string(parent.ControllerName) == controllerName {
+			conditions[ancestorKey(parent.ParentRef)] = parent.Conditions
+		}
+	}
+
+	return conditions
+}
+
+// mergeConditions returns the desired conditions with the LastTransitionTime
+// of every previous condition whose status did not change. Rebuilt status
+// then equals the stored one unless something changed, and the update can
+// be skipped.
+func mergeConditions(previous, desired []metav1.Condition) []metav1.Condition {
+	result := make([]metav1.Condition, 0, len(desired))
+
+	for _, condition := range desired {
+		if old := meta.FindStatusCondition(previous, condition.Type); old != nil && old.Status == condition.Status {
+			condition.LastTransitionTime = old.LastTransitionTime
+		}
+
+		result = append(result, condition)
+	}
+
+	return result
+}
@@ -0,0 +1,341 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/routebinding"
+)
+
+func TestForeignParentStatuses(t *testing.T) {
+	t.Parallel()
+
+	parents := []gatewayv1.RouteParentStatus{
+		adoptionParent(adoptionForeignName, "public", "foreign"),
+		adoptionParent(adoptionControllerName, "public", "ours"),
+		adoptionParent(adoptionOldName, "internal", "old"),
+	}
+
+	result := foreignParentStatuses(parents, adoptionControllerName)
+
+	assert.Equal(t, []gatewayv1.RouteParentStatus{parents[0], parents[2]}, result)
+	assert.Len(t, parents, 3, "input must not be modified")
+}
+
+func TestMergeConditions(t *testing.T) {
+	t.Parallel()
+
+	before := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Hour))
+
+	condition := func(conditionType string, status metav1.ConditionStatus, at metav1.Time) metav1.Condition {
+		return metav1.Condition{Type: conditionType, Status: status, Reason: "Test", LastTransitionTime: at}
+	}
+
+	previous := []metav1.Condition{
+		condition("Accepted", metav1.ConditionTrue, before),
+		condition("ResolvedRefs", metav1.ConditionTrue, before),
+		condition("Stale", metav1.ConditionTrue, before),
+	}
+	desired := []metav1.Condition{
+		condition("Accepted", metav1.ConditionTrue, now),
+		condition("ResolvedRefs", metav1.ConditionFalse, now),
+		condition("New", metav1.ConditionTrue, now),
+	}
+
+	assert.Equal(t, []metav1.Condition{
+		condition("Accepted", metav1.ConditionTrue, before),
+		condition("ResolvedRefs", metav1.ConditionFalse, now),
+		condition("New", metav1.ConditionTrue, now),
+	}, mergeConditions(previous, desired))
+}
+
+func TestPreserveGatewayTransitions(t *testing.T) {
+	t.Parallel()
+
+	before := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Hour))
+
+	accepted := func(at metav1.Time) []metav1.Condition {
+		return []metav1.Condition{{Type: "Accepted", Status: metav1.ConditionTrue, LastTransitionTime: at}}
+	}
+
+	previous := &gatewayv1.GatewayStatus{
+		Conditions: accepted(before),
+		Listeners:  []gatewayv1.ListenerStatus{{Name: "http", Conditions: accepted(before)}},
+	}
+	status := &gatewayv1.GatewayStatus{
+		Conditions: accepted(now),
+		Listeners: []gatewayv1.ListenerStatus{
+			{Name: "http", Conditions: accepted(now)},
+			{Name: "https", Conditions: accepted(now)},
+		},
+	}
+
+	preserveGatewayTransitions(previous, status)
+
+	assert.Equal(t, accepted(before), status.Conditions)
+	assert.Equal(t, accepted(before), status.Listeners[0].Conditions)
+	assert.Equal(t, accepted(now), status.Listeners[1].Conditions)
+}
+
+func TestHTTPRouteUpdateRouteStatus_SkipsUnchanged(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: string(gatewayNamespace)},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: "pingora"},
+	}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "public", Namespace: &gatewayNamespace}},
+			},
+		},
+	}
+
+	var applies int
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gateway, route).
+		WithStatusSubresource(&gatewayv1.HTTPRoute{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(
+				ctx context.Context,
+				c client.Client,
+				subResource string,
+				obj client.Object,
+				patch client.Patch,
+				opts ...client.SubResourcePatchOption,
+			) error {
+				assert.Equal(t, types.ApplyPatchType, patch.Type())
+
+				applies++
+
+				//nolint:wrapcheck // test passthrough
+				return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	reconciler := &PingoraHTTPRouteReconciler{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+	}
+
+	binding := routeBindingInfo{bindingResults: map[int]routebinding.BindingResult{
+		0: {Accepted: true, Reason: gatewayv1.RouteReasonAccepted, MatchedListeners: []gatewayv1.SectionName{"http"}},
+	}}
+
+	ctx := context.Background()
+	require.NoError(t, reconciler.updateRouteStatus(ctx, route, binding, nil))
+
+	var first gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &first))
+
+	require.NoError(t, reconciler.updateRouteStatus(ctx, route, binding, nil))
+
+	var second gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &second))
+
+	assert.Equal(t, 1, applies, "unchanged status must not be written")
+
+	// A changed condition is written, and only its transition time moves
+	require.NoError(t, reconciler.updateRouteStatus(ctx, route, binding, errors.New("proxy unavailable")))
+
+	var third gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &third))
+
+	assert.Equal(t, 2, applies)
+
+	accepted := meta.FindStatusCondition(third.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionAccepted))
+	require.NotNil(t, accepted)
+	assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+
+	resolved := meta.FindStatusCondition(third.Status.Parents[0].Conditions, string(gatewayv1.RouteConditionResolvedRefs))
+	require.NotNil(t, resolved)
+	assert.Equal(t, first.Status.Parents[0].Conditions[1].LastTransitionTime.Unix(), resolved.LastTransitionTime.Unix())
+}
+
+func TestHTTPRouteUpdateRouteStatus_PreservesOtherControllers(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: string(gatewayNamespace)},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: "pingora"},
+	}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 2},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "public", Namespace: &gatewayNamespace}},
+			},
+		},
+		Status: gatewayv1.HTTPRouteStatus{RouteStatus: gatewayv1.RouteStatus{
+			Parents: []gatewayv1.RouteParentStatus{
+				adoptionParent(adoptionForeignName, "public", "foreign"),
+				adoptionParent(adoptionControllerName, "stale", "stale"),
+			},
+		}},
+	}
+
+	var applies int
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gateway, route).
+		WithStatusSubresource(&gatewayv1.HTTPRoute{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(
+				ctx context.Context,
+				c client.Client,
+				subResource string,
+				obj client.Object,
+				patch client.Patch,
+				opts ...client.SubResourcePatchOption,
+			) error {
+				assert.Equal(t, types.ApplyPatchType, patch.Type())
+
+				applies++
+
+				//nolint:wrapcheck // test passthrough
+				return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	reconciler := &PingoraHTTPRouteReconciler{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+	}
+
+	binding := routeBindingInfo{bindingResults: map[int]routebinding.BindingResult{
+		0: {Accepted: true, Reason: gatewayv1.RouteReasonAccepted, MatchedListeners: []gatewayv1.SectionName{"http"}},
+	}}
+
+	ctx := context.Background()
+	require.NoError(t, reconciler.updateRouteStatus(ctx, route, binding, nil))
+
+	var updated gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &updated))
+
+	require.Len(t, updated.Status.Parents, 2)
+	assert.Equal(t, gatewayv1.GatewayController(adoptionForeignName), updated.Status.Parents[0].ControllerName)
+	assert.Equal(t, "foreign", updated.Status.Parents[0].Conditions[0].Message)
+	assert.Equal(t, gatewayv1.GatewayController(adoptionControllerName), updated.Status.Parents[1].ControllerName)
+	assert.Equal(t, gatewayv1.ObjectName("public"), updated.Status.Parents[1].ParentRef.Name)
+}
+
+func TestHTTPRouteUpdateRouteStatus_RetriesConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: string(gatewayNamespace)},
+		Spec:       gatewayv1.GatewaySpec{GatewayClassName: "pingora"},
+	}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: "public", Namespace: &gatewayNamespace}},
+			},
+		},
+	}
+
+	var applies int
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(gateway, route).
+		WithStatusSubresource(&gatewayv1.HTTPRoute{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourcePatch: func(
+				ctx context.Context,
+				c client.Client,
+				subResource string,
+				obj client.Object,
+				patch client.Patch,
+				opts ...client.SubResourcePatchOption,
+			) error {
+				applies++
+
+				if applies == 1 {
+					// Another controller writes its entry after the route was read
+					var current gatewayv1.HTTPRoute
+					require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), &current))
+
+					current.Status.Parents = append(current.Status.Parents,
+						adoptionParent(adoptionForeignName, "public", "foreign"))
+					require.NoError(t, c.Status().Update(ctx, &current))
+				}
+
+				// The fake client does not check the resourceVersion of
+				// applies, so the API server check is emulated
+				var current gatewayv1.HTTPRoute
+				require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(obj), &current))
+
+				if obj.GetResourceVersion() != current.ResourceVersion {
+					return apierrors.NewConflict(gatewayv1.Resource("httproutes"), obj.GetName(),
+						errors.New("the object has been modified"))
+				}
+
+				//nolint:wrapcheck // test passthrough
+				return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	reconciler := &PingoraHTTPRouteReconciler{
+		Client:           cli,
+		GatewayClassName: "pingora",
+		ControllerName:   adoptionControllerName,
+	}
+
+	binding := routeBindingInfo{bindingResults: map[int]routebinding.BindingResult{
+		0: {Accepted: true, Reason: gatewayv1.RouteReasonAccepted, MatchedListeners: []gatewayv1.SectionName{"http"}},
+	}}
+
+	ctx := context.Background()
+	require.NoError(t, reconciler.updateRouteStatus(ctx, route, binding, nil))
+
+	var updated gatewayv1.HTTPRoute
+	require.NoError(t, cli.Get(ctx, client.ObjectKeyFromObject(route), &updated))
+
+	assert.Equal(t, 2, applies, "the stale apply must be retried")
+	require.Len(t, updated.Status.Parents, 2)
+	assert.Equal(t, gatewayv1.GatewayController(adoptionForeignName), updated.Status.Parents[0].ControllerName)
+	assert.Equal(t, gatewayv1.GatewayController(adoptionControllerName), updated.Status.Parents[1].ControllerName)
+}
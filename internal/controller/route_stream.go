@@ -0,0 +1,354 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+const (
+	// methodUpdateRoutes is the metrics label for unary route updates.
+	methodUpdateRoutes = "UpdateRoutes"
+
+	// methodStreamRoutes is the metrics label for route updates sent over the stream.
+	methodStreamRoutes = "StreamRoutes"
+)
+
+// errStreamUnsupported is returned when the proxy does not implement StreamRoutes.
+var errStreamUnsupported = errors.New("proxy does not support route streaming")
+
+// streamConn is a single open StreamRoutes stream with its receive loop.
+type streamConn struct {
+	stream grpc.BidiStreamingClient[routingv1.StreamRoutesRequest, routingv1.StreamRoutesResponse]
+	cancel context.CancelFunc
+
+	// acks delivers acknowledgements read by the receive loop.
+	acks chan *routingv1.UpdateRoutesResponse
+	// done is closed when the receive loop stops; err holds the reason.
+	done chan struct{}
+	err  error
+}
+
+// routeStream keeps a persistent StreamRoutes stream to the proxy.
+//
+// The first update on a stream is a full snapshot. Later updates only carry
+// the routes that changed since the last acknowledged snapshot. Deltas do not
+// carry listener settings, so a change to them is sent as a full snapshot. If
+// the proxy rejects a delta, the update is resent as a full snapshot.
+type routeStream struct {
+	client routingv1.RoutingServiceClient
+	logger *slog.Logger
+
+	// onHealth is called from the receive loop for every health report.
+	onHealth func(*routingv1.HealthResponse)
+
+	// mu serializes updates; only one update may wait for an ack at a time.
+	mu          sync.Mutex
+	conn        *streamConn
+	unsupported bool
+
+	// Last snapshot acknowledged by the proxy, nil if unknown.
+	appliedVersion uint64
+	httpRoutes     map[string]*routingv1.HTTPRoute
+	grpcRoutes     map[string]*routingv1.GRPCRoute
+	listeners      []*routingv1.Listener
+}
+
+// newRouteStream creates a routeStream. The stream is opened lazily on the first update.
+// onHealth may be nil.
+func newRouteStream(
+	client routingv1.RoutingServiceClient,
+	logger *slog.Logger,
+	onHealth func(*routingv1.HealthResponse),
+) *routeStream {
+	return &routeStream{
+		client:   client,
+		logger:   logger,
+		onHealth: onHealth,
+	}
+}
+
+// Send pushes the route configuration to the proxy and waits for the acknowledgement.
+// Returns errStreamUnsupported if the proxy does not implement StreamRoutes.
+func (r *routeStream) Send(
+	ctx context.Context,
+	req *routingv1.UpdateRoutesRequest,
+) (*routingv1.UpdateRoutesResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.unsupported {
+		return nil, errStreamUnsupported
+	}
+
+	update := r.buildUpdate(req)
+
+	resp, err := r.exchange(ctx, update)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.GetSuccess() && update.GetDelta() != nil {
+		r.logger.Info("proxy rejected route delta, resending full snapshot", "error", resp.GetError())
+		r.forgetSnapshot()
+
+		resp, err = r.exchange(ctx, fullUpdate(req))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.GetSuccess() {
+		r.rememberSnapshot(req, resp.GetAppliedVersion())
+	} else {
+		r.forgetSnapshot()
+	}
+
+	return resp, nil
+}
+
+// Close closes the stream. The next update opens a new stream with a full snapshot.
+func (r *routeStream) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reset()
+}
+
+// exchange sends a single update and waits for its acknowledgement.
+// Any failure closes the stream so that the next update starts from a full snapshot.
+func (r *routeStream) exchange(
+	ctx context.Context,
+	update *routingv1.StreamRoutesRequest,
+) (*routingv1.UpdateRoutesResponse, error) {
+	conn, err := r.open()
+	if err != nil {
+		return nil, err
+	}
+
+	if sendErr := conn.stream.Send(update); sendErr != nil {
+		// The real stream status is reported by Recv.
+		<-conn.done
+
+		return nil, r.fail(conn.err)
+	}
+
+	select {
+	case ack := <-conn.acks:
+		return ack, nil
+	case <-conn.done:
+		return nil, r.fail(conn.err)
+	case <-ctx.Done():
+		r.reset()
+
+		return nil, errors.Wrap(ctx.Err(), "context cancelled while waiting for route ack")
+	}
+}
+
+// open returns the current stream, opening a new one if needed.
+func (r *routeStream) open() (*streamConn, error) {
+	if r.conn != nil {
+		return r.conn, nil
+	}
+
+	// The stream lives across syncs, so it must not use a request context.
+	streamCtx, cancel := context.WithCancel(context.Background())
+
+	stream, err := r.client.StreamRoutes(streamCtx)
+	if err != nil {
+		cancel()
+
+		return nil, r.fail(err)
+	}
+
+	conn := &streamConn{
+		stream: stream,
+		cancel: cancel,
+		acks:   make(chan *routingv1.UpdateRoutesResponse, 1),
+		done:   make(chan struct{}),
+	}
+
+	go r.receive(conn)
+
+	r.conn = conn
+	r.logger.Debug("opened route stream to Pingora proxy")
+
+	return conn, nil
+}
+
+// receive reads messages from the proxy until the stream ends.
+func (r *routeStream) receive(conn *streamConn) {
+	defer close(conn.done)
+
+	for {
+		msg, err := conn.stream.Recv()
+		if err != nil {
+			conn.err = err
+
+			return
+		}
+
+		switch {
+		case msg.GetAck() != nil:
+			select {
+			case conn.acks <- msg.GetAck():
+			default:
+				r.logger.Warn("dropping unexpected route ack", "version", msg.GetAck().GetAppliedVersion())
+			}
+		case msg.GetHealth() != nil:
+			r.logger.Debug("proxy health report",
+				"healthy", msg.GetHealth().GetHealthy(),
+				"configVersion", msg.GetHealth().GetConfigVersion(),
+			)
+
+			if r.onHealth != nil {
+				r.onHealth(msg.GetHealth())
+			}
+		}
+	}
+}
+
+// fail closes the stream and converts its error.
+// Unimplemented marks streaming as unsupported for the lifetime of this routeStream.
+func (r *routeStream) fail(err error) error {
+	r.reset()
+
+	if status.Code(err) == codes.Unimplemented {
+		r.unsupported = true
+		r.logger.Info("Pingora proxy does not support StreamRoutes, using UpdateRoutes")
+
+		return errStreamUnsupported
+	}
+
+	if err == nil {
+		//nolint:wrapcheck // New creates new error, not wrapping
+		return errors.New("route stream closed by proxy")
+	}
+
+	return errors.Wrap(err, "route stream failed")
+}
+
+// reset closes the current stream and forgets the acknowledged snapshot.
+func (r *routeStream) reset() {
+	if r.conn != nil {
+		r.conn.cancel()
+		r.conn = nil
+	}
+
+	r.forgetSnapshot()
+}
+
+// buildUpdate returns a delta against the acknowledged snapshot,
+// or a full snapshot if none is known.
+func (r *routeStream) buildUpdate(req *routingv1.UpdateRoutesRequest) *routingv1.StreamRoutesRequest {
+	if r.httpRoutes == nil || r.grpcRoutes == nil {
+		return fullUpdate(req)
+	}
+
+	if !slices.EqualFunc(r.listeners, req.GetListeners(), func(a, b *routingv1.Listener) bool {
+		return proto.Equal(a, b)
+	}) {
+		return fullUpdate(req)
+	}
+
+	delta := &routingv1.RoutesDelta{
+		BaseVersion: r.appliedVersion,
+		Version:     req.GetVersion(),
+	}
+
+	seenHTTP := make(map[string]bool, len(req.GetHttpRoutes()))
+
+	for _, route := range req.GetHttpRoutes() {
+		seenHTTP[route.GetId()] = true
+
+		if prev, ok := r.httpRoutes[route.GetId()]; !ok || !proto.Equal(prev, route) {
+			delta.UpsertHttpRoutes = append(delta.UpsertHttpRoutes, route)
+		}
+	}
+
+	for id := range r.httpRoutes {
+		if !seenHTTP[id] {
+			delta.RemoveHttpRouteIds = append(delta.RemoveHttpRouteIds, id)
+		}
+	}
+
+	seenGRPC := make(map[string]bool, len(req.GetGrpcRoutes()))
+
+	for _, route := range req.GetGrpcRoutes() {
+		seenGRPC[route.GetId()] = true
+
+		if prev, ok := r.grpcRoutes[route.GetId()]; !ok || !proto.Equal(prev, route) {
+			delta.UpsertGrpcRoutes = append(delta.UpsertGrpcRoutes, route)
+		}
+	}
+
+	for id := range r.grpcRoutes {
+		if !seenGRPC[id] {
+			delta.RemoveGrpcRouteIds = append(delta.RemoveGrpcRouteIds, id)
+		}
+	}
+
+	return &routingv1.StreamRoutesRequest{
+		Update: &routingv1.StreamRoutesRequest_Delta{Delta: delta},
+	}
+}
+
+// Removed drops routes that the proxy removed outside the stream with
+// DeleteRoutes from the acknowledged snapshot, so that the next delta is
+// based on the version the proxy applied.
+func (r *routeStream) Removed(req *routingv1.DeleteRoutesRequest, version uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.httpRoutes == nil || r.grpcRoutes == nil {
+		return
+	}
+
+	for _, id := range req.GetHttpRouteIds() {
+		delete(r.httpRoutes, id)
+	}
+
+	for _, id := range req.GetGrpcRouteIds() {
+		delete(r.grpcRoutes, id)
+	}
+
+	r.appliedVersion = version
+}
+
+func (r *routeStream) rememberSnapshot(req *routingv1.UpdateRoutesRequest, version uint64) {
+	r.appliedVersion = version
+
+	r.httpRoutes = make(map[string]*routingv1.HTTPRoute, len(req.GetHttpRoutes()))
+	for _, route := range req.GetHttpRoutes() {
+		r.httpRoutes[route.GetId()] = route
+	}
+
+	r.grpcRoutes = make(map[string]*routingv1.GRPCRoute, len(req.GetGrpcRoutes()))
+	for _, route := range req.GetGrpcRoutes() {
+		r.grpcRoutes[route.GetId()] = route
+	}
+
+	r.listeners = req.GetListeners()
+}
+
+func (r *routeStream) forgetSnapshot() {
+	r.appliedVersion = 0
+	r.httpRoutes = nil
+	r.grpcRoutes = nil
+	r.listeners = nil
+}
+
+func fullUpdate(req *routingv1.UpdateRoutesRequest) *routingv1.StreamRoutesRequest {
+	return &routingv1.StreamRoutesRequest{
+		Update: &routingv1.StreamRoutesRequest_Full{Full: req},
+	}
+}
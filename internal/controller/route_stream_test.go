@@ -0,0 +1,294 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+// fakeProxy answers route updates sent over a fake StreamRoutes stream.
+type fakeProxy struct {
+	routingv1.RoutingServiceClient
+
+	// respond builds the reply for an update; nil ends the stream with streamErr.
+	respond   func(*routingv1.StreamRoutesRequest) *routingv1.StreamRoutesResponse
+	streamErr error
+
+	received []*routingv1.StreamRoutesRequest
+	opened   int
+}
+
+func (p *fakeProxy) StreamRoutes(
+	ctx context.Context,
+	_ ...grpc.CallOption,
+) (grpc.BidiStreamingClient[routingv1.StreamRoutesRequest, routingv1.StreamRoutesResponse], error) {
+	p.opened++
+
+	return &fakeRouteStream{proxy: p, ctx: ctx, out: make(chan *routingv1.StreamRoutesResponse, 1)}, nil
+}
+
+type fakeRouteStream struct {
+	grpc.ClientStream
+
+	proxy *fakeProxy
+	ctx   context.Context
+	out   chan *routingv1.StreamRoutesResponse
+	err   error
+}
+
+func (s *fakeRouteStream) Send(req *routingv1.StreamRoutesRequest) error {
+	s.proxy.received = append(s.proxy.received, req)
+
+	resp := s.proxy.respond(req)
+	if resp == nil {
+		s.err = s.proxy.streamErr
+		close(s.out)
+
+		return io.EOF
+	}
+
+	s.out <- resp
+
+	return nil
+}
+
+func (s *fakeRouteStream) Recv() (*routingv1.StreamRoutesResponse, error) {
+	select {
+	case resp, ok := <-s.out:
+		if !ok {
+			return nil, s.err
+		}
+
+		return resp, nil
+	case <-s.ctx.Done():
+		return nil, status.FromContextError(s.ctx.Err()).Err()
+	}
+}
+
+func ack(success bool, version uint64) *routingv1.StreamRoutesResponse {
+	return &routingv1.StreamRoutesResponse{
+		Message: &routingv1.StreamRoutesResponse_Ack{
+			Ack: &routingv1.UpdateRoutesResponse{Success: success, AppliedVersion: version},
+		},
+	}
+}
+
+func updateRequest(version uint64, addresses map[string]string) *routingv1.UpdateRoutesRequest {
+	req := &routingv1.UpdateRoutesRequest{Version: version}
+
+	for id, address := range addresses {
+		req.HttpRoutes = append(req.HttpRoutes, &routingv1.HTTPRoute{
+			Id: id,
+			Rules: []*routingv1.HTTPRouteRule{
+				{Backends: []*routingv1.Backend{{Address: address, Weight: 1}}},
+			},
+		})
+	}
+
+	return req
+}
+
+func TestRouteStream_SendsDeltasAfterSnapshot(t *testing.T) {
+	t.Parallel()
+
+	proxy := &fakeProxy{}
+	proxy.respond = func(req *routingv1.StreamRoutesRequest) *routingv1.StreamRoutesResponse {
+		if full := req.GetFull(); full != nil {
+			return ack(true, full.GetVersion())
+		}
+
+		return ack(true, req.GetDelta().GetVersion())
+	}
+
+	stream := newRouteStream(proxy, slog.Default(), nil)
+	defer stream.Close()
+
+	ctx := context.Background()
+
+	resp, err := stream.Send(ctx, updateRequest(1, map[string]string{"default/a": "a:80", "default/b": "b:80"}))
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+
+	_, err = stream.Send(ctx, updateRequest(2, map[string]string{"default/a": "a:8080", "default/c": "c:80"}))
+	require.NoError(t, err)
+
+	require.Len(t, proxy.received, 2)
+	assert.NotNil(t, proxy.received[0].GetFull(), "first update must be a full snapshot")
+
+	delta := proxy.received[1].GetDelta()
+	require.NotNil(t, delta)
+	assert.Equal(t, uint64(1), delta.GetBaseVersion())
+	assert.Equal(t, uint64(2), delta.GetVersion())
+	assert.ElementsMatch(t, []string{"default/a", "default/c"},
+		[]string{delta.GetUpsertHttpRoutes()[0].GetId(), delta.GetUpsertHttpRoutes()[1].GetId()})
+	assert.Equal(t, []string{"default/b"}, delta.GetRemoveHttpRouteIds())
+	assert.Equal(t, 1, proxy.opened, "stream must be reused across updates")
+}
+
+func TestRouteStream_RejectedDeltaResendsSnapshot(t *testing.T) {
+	t.Parallel()
+
+	proxy := &fakeProxy{}
+	proxy.respond = func(req *routingv1.StreamRoutesRequest) *routingv1.StreamRoutesResponse {
+		if full := req.GetFull(); full != nil {
+			return ack(true, full.GetVersion())
+		}
+
+		// Proxy lost its state and cannot apply deltas
+		return ack(false, 0)
+	}
+
+	stream := newRouteStream(proxy, slog.Default(), nil)
+	defer stream.Close()
+
+	ctx := context.Background()
+
+	_, err := stream.Send(ctx, updateRequest(1, map[string]string{"default/a": "a:80"}))
+	require.NoError(t, err)
+
+	resp, err := stream.Send(ctx, updateRequest(2, map[string]string{"default/a": "a:8080"}))
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+
+	require.Len(t, proxy.received, 3)
+	assert.NotNil(t, proxy.received[1].GetDelta())
+	assert.NotNil(t, proxy.received[2].GetFull())
+}
+
+func TestRouteStream_ListenerChangeSendsSnapshot(t *testing.T) {
+	t.Parallel()
+
+	proxy := &fakeProxy{}
+	proxy.respond = func(req *routingv1.StreamRoutesRequest) *routingv1.StreamRoutesResponse {
+		if full := req.GetFull(); full != nil {
+			return ack(true, full.GetVersion())
+		}
+
+		return ack(true, req.GetDelta().GetVersion())
+	}
+
+	stream := newRouteStream(proxy, slog.Default(), nil)
+	defer stream.Close()
+
+	ctx := context.Background()
+	routes := map[string]string{"default/a": "a:80"}
+
+	_, err := stream.Send(ctx, updateRequest(1, routes))
+	require.NoError(t, err)
+
+	limited := updateRequest(2, routes)
+	limited.Listeners = []*routingv1.Listener{
+		{Port: 80, Limits: &routingv1.ListenerLimits{MaxRequestBodyBytes: 1024}},
+	}
+
+	_, err = stream.Send(ctx, limited)
+	require.NoError(t, err)
+
+	unchanged := updateRequest(3, routes)
+	unchanged.Listeners = limited.GetListeners()
+
+	_, err = stream.Send(ctx, unchanged)
+	require.NoError(t, err)
+
+	require.Len(t, proxy.received, 3)
+	assert.NotNil(t, proxy.received[1].GetFull(), "listener change must be sent as a full snapshot")
+	assert.NotNil(t, proxy.received[2].GetDelta())
+}
+
+func TestRouteStream_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	proxy := &fakeProxy{streamErr: status.Error(codes.Unimplemented, "unknown method StreamRoutes")}
+	proxy.respond = func(*routingv1.StreamRoutesRequest) *routingv1.StreamRoutesResponse {
+		return nil
+	}
+
+	stream := newRouteStream(proxy, slog.Default(), nil)
+
+	_, err := stream.Send(context.Background(), updateRequest(1, nil))
+	require.ErrorIs(t, err, errStreamUnsupported)
+
+	// Streaming is not retried on the same connection
+	_, err = stream.Send(context.Background(), updateRequest(2, nil))
+	require.ErrorIs(t, err, errStreamUnsupported)
+	assert.Equal(t, 1, proxy.opened)
+}
+
+func TestRouteStream_StreamErrorReopens(t *testing.T) {
+	t.Parallel()
+
+	failNext := true
+
+	proxy := &fakeProxy{streamErr: status.Error(codes.Unavailable, "proxy restarting")}
+	proxy.respond = func(req *routingv1.StreamRoutesRequest) *routingv1.StreamRoutesResponse {
+		if failNext {
+			failNext = false
+
+			return nil
+		}
+
+		return ack(true, req.GetFull().GetVersion())
+	}
+
+	stream := newRouteStream(proxy, slog.Default(), nil)
+	defer stream.Close()
+
+	_, err := stream.Send(context.Background(), updateRequest(1, nil))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errStreamUnsupported)
+
+	resp, err := stream.Send(context.Background(), updateRequest(2, nil))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), resp.GetAppliedVersion())
+	assert.Equal(t, 2, proxy.opened)
+}
+
+func TestRouteStream_MockProxy(t *testing.T) {
+	t.Parallel()
+
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	stream := newRouteStream(routingv1.NewRoutingServiceClient(conn), slog.Default(), nil)
+	defer stream.Close()
+
+	ctx := context.Background()
+
+	_, err = stream.Send(ctx, updateRequest(1, map[string]string{"default/a": "a:80", "default/b": "b:80"}))
+	require.NoError(t, err)
+
+	resp, err := stream.Send(ctx, updateRequest(2, map[string]string{"default/a": "a:8080"}))
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+
+	routes := proxy.HTTPRoutes()
+	require.Len(t, routes, 1)
+	assert.Equal(t, "a:8080", routes[0].GetRules()[0].GetBackends()[0].GetAddress())
+
+	// A restarted proxy rejects the next delta, so the snapshot is resent
+	proxy.Restart()
+
+	resp, err = stream.Send(ctx, updateRequest(3, map[string]string{"default/a": "a:8080", "default/c": "c:80"}))
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	assert.Len(t, proxy.HTTPRoutes(), 2)
+	assert.Equal(t, []uint64{1, 2, 3}, proxy.AppliedVersions())
+}
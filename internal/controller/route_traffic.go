@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// routeRef identifies the route a route ID was built from.
+type routeRef struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// builtRouteRefs maps the IDs of the built routes to their routes.
+func builtRouteRefs(built *builtRoutes) map[string]routeRef {
+	refs := make(map[string]routeRef, len(built.pingoraHTTPRoutes)+len(built.pingoraGRPCRoutes))
+
+	for i, route := range built.pingoraHTTPRoutes {
+		if i < len(built.httpRoutes) {
+			refs[route.GetId()] = routeRef{
+				kind:      "HTTPRoute",
+				namespace: built.httpRoutes[i].Namespace,
+				name:      built.httpRoutes[i].Name,
+			}
+		}
+	}
+
+	for i, route := range built.pingoraGRPCRoutes {
+		if i < len(built.grpcRoutes) {
+			refs[route.GetId()] = routeRef{
+				kind:      "GRPCRoute",
+				namespace: built.grpcRoutes[i].Namespace,
+				name:      built.grpcRoutes[i].Name,
+			}
+		}
+	}
+
+	return refs
+}
+
+// setRouteRefs records the routes of the config the proxy confirmed, which
+// checkRouteTraffic labels the traffic of the proxy with.
+func (s *PingoraRouteSyncer) setRouteRefs(refs map[string]routeRef) {
+	s.appliedMu.Lock()
+	defer s.appliedMu.Unlock()
+
+	s.routeRefs = refs
+}
+
+// checkRouteTraffic asks the proxy for the traffic of routes and listeners
+// and records it in metrics, labeled with the routes it was built from.
+func (s *PingoraRouteSyncer) checkRouteTraffic(ctx context.Context) {
+	if !s.RouteTrafficMetrics {
+		return
+	}
+
+	s.connMu.RLock()
+	grpcClient := s.grpcClient
+	requestTimeout := s.requestTimeout
+	s.connMu.RUnlock()
+
+	if grpcClient == nil {
+		return
+	}
+
+	rpcCtx, cancel := withRequestTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	grpcStart := time.Now()
+	resp, err := grpcClient.GetRouteStats(rpcCtx, &routingv1.GetRouteStatsRequest{})
+	grpcDuration := time.Since(grpcStart)
+
+	if status.Code(err) == codes.Unimplemented {
+		// Proxies without traffic statistics have nothing to report
+		return
+	}
+
+	if err != nil {
+		s.Metrics.RecordGRPCCall(ctx, "GetRouteStats", "error", grpcDuration)
+		s.Logger.Debug("route traffic check failed", "error", err)
+
+		return
+	}
+
+	s.Metrics.RecordGRPCCall(ctx, "GetRouteStats", "success", grpcDuration)
+
+	s.appliedMu.RLock()
+	refs := s.routeRefs
+	s.appliedMu.RUnlock()
+
+	routes, listeners := proxyTraffic(resp, refs)
+	s.Metrics.RecordProxyTraffic(ctx, routes, listeners)
+}
+
+// proxyTraffic converts the traffic statistics of the proxy. Routes that
+// are not in refs, such as routes removed since, are left out.
+func proxyTraffic(
+	resp *routingv1.GetRouteStatsResponse,
+	refs map[string]routeRef,
+) ([]metrics.RouteTraffic, []metrics.ListenerTraffic) {
+	buckets := resp.GetDurationBuckets()
+
+	routes := make([]metrics.RouteTraffic, 0, len(resp.GetRoutes()))
+
+	for _, stats := range resp.GetRoutes() {
+		ref, ok := refs[stats.GetRouteId()]
+		if !ok {
+			continue
+		}
+
+		routes = append(routes, metrics.RouteTraffic{
+			Kind:      ref.kind,
+			Namespace: ref.namespace,
+			Name:      ref.name,
+			Responses: responsesByStatusClass(stats.GetResponses()),
+			Durations: requestDurations(buckets, stats.GetDurations()),
+		})
+	}
+
+	listeners := make([]metrics.ListenerTraffic, 0, len(resp.GetListeners()))
+
+	for _, stats := range resp.GetListeners() {
+		listeners = append(listeners, metrics.ListenerTraffic{
+			Port:      stats.GetPort(),
+			Responses: responsesByStatusClass(stats.GetResponses()),
+			Durations: requestDurations(buckets, stats.GetDurations()),
+		})
+	}
+
+	return routes, listeners
+}
+
+// responsesByStatusClass maps the response counts to status classes such
+// as "2xx".
+func responsesByStatusClass(counts []*routingv1.StatusClassCount) map[string]uint64 {
+	responses := make(map[string]uint64, len(counts))
+
+	for _, count := range counts {
+		responses[strconv.FormatUint(uint64(count.GetStatusClass()), 10)+"xx"] += count.GetCount()
+	}
+
+	return responses
+}
+
+// requestDurations converts a duration histogram of the proxy. Bucket
+// counts without a bucket bound are ignored.
+func requestDurations(buckets []float64, durations *routingv1.RequestDurations) metrics.RequestDurations {
+	converted := metrics.RequestDurations{
+		Count:   durations.GetCount(),
+		Sum:     durations.GetSumSeconds(),
+		Buckets: make(map[float64]uint64, len(buckets)),
+	}
+
+	for i, count := range durations.GetBucketCounts() {
+		if i < len(buckets) {
+			converted.Buckets[buckets[i]] = count
+		}
+	}
+
+	return converted
+}
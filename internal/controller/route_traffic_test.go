@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// routeStatsClient is a RoutingServiceClient that only answers GetRouteStats.
+type routeStatsClient struct {
+	routingv1.RoutingServiceClient
+
+	stats *routingv1.GetRouteStatsResponse
+	err   error
+}
+
+func (c *routeStatsClient) GetRouteStats(
+	_ context.Context,
+	_ *routingv1.GetRouteStatsRequest,
+	_ ...grpc.CallOption,
+) (*routingv1.GetRouteStatsResponse, error) {
+	return c.stats, c.err
+}
+
+// trafficCollector records the last reported proxy traffic.
+type trafficCollector struct {
+	metrics.NoopCollector
+
+	reports   int
+	routes    []metrics.RouteTraffic
+	listeners []metrics.ListenerTraffic
+}
+
+func (c *trafficCollector) RecordProxyTraffic(
+	_ context.Context,
+	routes []metrics.RouteTraffic,
+	listeners []metrics.ListenerTraffic,
+) {
+	c.reports++
+	c.routes = routes
+	c.listeners = listeners
+}
+
+func TestBuiltRouteRefs(t *testing.T) {
+	t.Parallel()
+
+	built := &builtRoutes{
+		httpRoutes:        []gatewayv1.HTTPRoute{{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}},
+		grpcRoutes:        []gatewayv1.GRPCRoute{{ObjectMeta: metav1.ObjectMeta{Name: "echo", Namespace: "grpc"}}},
+		pingoraHTTPRoutes: []*routingv1.HTTPRoute{{Id: "3f2a"}},
+		pingoraGRPCRoutes: []*routingv1.GRPCRoute{{Id: "9c1b"}},
+	}
+
+	assert.Equal(t, map[string]routeRef{
+		"3f2a": {kind: "HTTPRoute", namespace: "default", name: "app"},
+		"9c1b": {kind: "GRPCRoute", namespace: "grpc", name: "echo"},
+	}, builtRouteRefs(built))
+}
+
+func TestPingoraRouteSyncer_CheckRouteTraffic(t *testing.T) {
+	t.Parallel()
+
+	stats := &routingv1.GetRouteStatsResponse{
+		DurationBuckets: []float64{0.1, 1},
+		Routes: []*routingv1.RouteStats{
+			{
+				RouteId: "3f2a",
+				Responses: []*routingv1.StatusClassCount{
+					{StatusClass: 2, Count: 9},
+					{StatusClass: 5, Count: 1},
+				},
+				Durations: &routingv1.RequestDurations{Count: 10, SumSeconds: 2, BucketCounts: []uint64{7, 10}},
+			},
+			{RouteId: "removed", Responses: []*routingv1.StatusClassCount{{StatusClass: 2, Count: 3}}},
+		},
+		Listeners: []*routingv1.ListenerStats{{
+			Port:      443,
+			Responses: []*routingv1.StatusClassCount{{StatusClass: 2, Count: 12}},
+		}},
+	}
+
+	tests := []struct {
+		name            string
+		enabled         bool
+		client          *routeStatsClient
+		expectedReports int
+	}{
+		{name: "traffic reported", enabled: true, client: &routeStatsClient{stats: stats}, expectedReports: 1},
+		{name: "disabled", client: &routeStatsClient{stats: stats}},
+		{
+			name:    "proxy without traffic statistics",
+			enabled: true,
+			client:  &routeStatsClient{err: status.Error(codes.Unimplemented, "unknown method")},
+		},
+		{
+			name:    "proxy unavailable",
+			enabled: true,
+			client:  &routeStatsClient{err: status.Error(codes.Unavailable, "connection refused")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			collector := &trafficCollector{}
+			syncer := &PingoraRouteSyncer{
+				Metrics:             collector,
+				Logger:              slog.Default(),
+				RouteTrafficMetrics: tt.enabled,
+				grpcClient:          tt.client,
+			}
+			syncer.setRouteRefs(map[string]routeRef{"3f2a": {kind: "HTTPRoute", namespace: "default", name: "app"}})
+
+			syncer.checkRouteTraffic(context.Background())
+
+			assert.Equal(t, tt.expectedReports, collector.reports)
+
+			if tt.expectedReports == 0 {
+				return
+			}
+
+			require.Len(t, collector.routes, 1)
+			assert.Equal(t, metrics.RouteTraffic{
+				Kind:      "HTTPRoute",
+				Namespace: "default",
+				Name:      "app",
+				Responses: map[string]uint64{"2xx": 9, "5xx": 1},
+				Durations: metrics.RequestDurations{Count: 10, Sum: 2, Buckets: map[float64]uint64{0.1: 7, 1: 10}},
+			}, collector.routes[0])
+
+			require.Len(t, collector.listeners, 1)
+			assert.Equal(t, uint32(443), collector.listeners[0].Port)
+			assert.Equal(t, map[string]uint64{"2xx": 12}, collector.listeners[0].Responses)
+		})
+	}
+}
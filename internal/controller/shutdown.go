@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+)
+
+// shutdownStatusTimeout bounds the final PingoraConfig status update on shutdown.
+const shutdownStatusTimeout = 5 * time.Second
+
+// shutdown waits for the sync in flight, stops later syncs, closes the
+// connection to the proxy and records the shutdown in the PingoraConfig
+// status. The proxy keeps serving the routes it has.
+func (s *PingoraRouteSyncer) shutdown(ctx context.Context) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
+	if s.stopped.Swap(true) {
+		return
+	}
+
+	configName := s.GetConfigName()
+
+	if err := s.Close(); err != nil {
+		s.Logger.Warn("failed to close proxy connection", "error", err)
+	}
+
+	if configName == "" {
+		// Never connected, so there is no connection state to report
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, shutdownStatusTimeout)
+	defer cancel()
+
+	err := s.patchConfigStatus(ctx, func(status *v1alpha1.PingoraConfigStatus, generation int64) {
+		applyShutdown(status, generation)
+	})
+	if err != nil {
+		s.Logger.Warn("failed to update PingoraConfig status", "error", err)
+	}
+
+	s.Logger.Info("closed proxy connection on shutdown")
+}
@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+	"github.com/lexfrei/pingora-gateway-controller/pkg/testutil/mockproxy"
+)
+
+func TestPingoraRouteSyncer_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	proxy := mockproxy.New()
+
+	addr, err := proxy.Start()
+	require.NoError(t, err)
+	t.Cleanup(proxy.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	syncer, written := newStatusTestSyncer(t, v1alpha1.PingoraConfigStatus{Connected: true, ConfigVersion: 3})
+	syncer.configName = "pingora"
+	syncer.conn = conn
+	syncer.grpcClient = routingv1.NewRoutingServiceClient(conn)
+	syncer.startupSynced.Store(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Start returns on a cancelled context after shutting down
+	require.NoError(t, syncer.Start(ctx))
+
+	assert.False(t, syncer.IsConnected())
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+
+	require.Len(t, *written, 1)
+	assert.False(t, (*written)[0].Connected)
+	assert.Equal(t, uint64(3), (*written)[0].ConfigVersion)
+
+	ready := meta.FindStatusCondition((*written)[0].Conditions, v1alpha1.ConditionTypeReady)
+	require.NotNil(t, ready)
+	assert.Equal(t, v1alpha1.ReasonControllerStopped, ready.Reason)
+
+	// Syncs after shutdown neither reconnect nor write the status
+	_, _, err = syncer.SyncAllRoutes(context.Background())
+	require.NoError(t, err)
+	assert.False(t, syncer.IsConnected())
+
+	syncer.shutdown(context.Background())
+	assert.Len(t, *written, 1)
+}
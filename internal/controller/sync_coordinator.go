@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+// DefaultSyncDebounce is the default delay used to coalesce sync requests.
+const DefaultSyncDebounce = 200 * time.Millisecond
+
+// syncFunc performs a full route synchronization.
+type syncFunc func(ctx context.Context) (ctrl.Result, *SyncResult, error)
+
+// syncBatch is a single pending sync shared by all requests that joined it.
+type syncBatch struct {
+	done chan struct{}
+
+	// requests is the number of sync requests that joined the batch,
+	// guarded by SyncCoordinator.mu.
+	requests int
+
+	result     ctrl.Result
+	syncResult *SyncResult
+	err        error
+}
+
+// SyncCoordinator debounces and coalesces sync requests.
+//
+// Both route reconcilers request a full sync for every reconcile. When many routes
+// change at once, the coordinator waits for the debounce interval after the first
+// request and then runs a single sync for all requests received in the meantime.
+// Every caller gets the result of the sync it joined.
+type SyncCoordinator struct {
+	syncFn   syncFunc
+	debounce time.Duration
+	metrics  metrics.Collector
+
+	mu      sync.Mutex
+	pending *syncBatch
+
+	// queued is the number of sync requests waiting for a result.
+	queued atomic.Int64
+}
+
+// NewSyncCoordinator creates a new SyncCoordinator.
+// A zero or negative debounce disables coalescing and every request syncs immediately.
+func NewSyncCoordinator(syncFn syncFunc, debounce time.Duration, metricsCollector metrics.Collector) *SyncCoordinator {
+	if metricsCollector == nil {
+		metricsCollector = metrics.NewNoopCollector()
+	}
+
+	return &SyncCoordinator{
+		syncFn:   syncFn,
+		debounce: debounce,
+		metrics:  metricsCollector,
+	}
+}
+
+// Sync requests a route synchronization and waits for its result.
+// If a sync is already scheduled, the request joins it instead of triggering another one.
+func (c *SyncCoordinator) Sync(ctx context.Context) (ctrl.Result, *SyncResult, error) {
+	c.metrics.RecordSyncQueueDepth(ctx, int(c.queued.Add(1)))
+	defer func() { c.metrics.RecordSyncQueueDepth(ctx, int(c.queued.Add(-1))) }()
+
+	if c.debounce <= 0 {
+		// Every request is its own batch, queued on the sync lock
+		c.metrics.RecordSyncBatchSize(ctx, 1)
+
+		return c.syncFn(ctx)
+	}
+
+	c.mu.Lock()
+
+	batch := c.pending
+	if batch == nil {
+		batch = &syncBatch{done: make(chan struct{})}
+		c.pending = batch
+
+		// The sync outlives the request that scheduled it, so it must not be
+		// cancelled together with that request.
+		syncCtx := context.WithoutCancel(ctx)
+
+		time.AfterFunc(c.debounce, func() {
+			c.run(syncCtx, batch)
+		})
+	} else {
+		c.metrics.RecordSyncCoalesced(ctx)
+		trace.SpanFromContext(ctx).AddEvent("joined pending route sync")
+	}
+
+	batch.requests++
+
+	c.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return batch.result, batch.syncResult, batch.err
+	case <-ctx.Done():
+		return ctrl.Result{}, nil, errors.Wrap(ctx.Err(), "context cancelled while waiting for sync")
+	}
+}
+
+// run executes the sync for a batch. The batch is detached before syncing so that
+// requests arriving during the sync schedule a new one and see the latest state.
+func (c *SyncCoordinator) run(ctx context.Context, batch *syncBatch) {
+	c.mu.Lock()
+	if c.pending == batch {
+		c.pending = nil
+	}
+
+	requests := batch.requests
+	c.mu.Unlock()
+
+	c.metrics.RecordSyncBatchSize(ctx, requests)
+
+	batch.result, batch.syncResult, batch.err = c.syncFn(ctx)
+	close(batch.done)
+}
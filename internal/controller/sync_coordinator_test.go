@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+type countingCollector struct {
+	metrics.NoopCollector
+
+	coalesced atomic.Int32
+	maxQueued atomic.Int32
+
+	batchMu sync.Mutex
+	batches []int
+}
+
+func (c *countingCollector) RecordSyncCoalesced(_ context.Context) {
+	c.coalesced.Add(1)
+}
+
+func (c *countingCollector) RecordSyncQueueDepth(_ context.Context, depth int) {
+	for {
+		current := c.maxQueued.Load()
+		if int32(depth) <= current || c.maxQueued.CompareAndSwap(current, int32(depth)) {
+			return
+		}
+	}
+}
+
+func (c *countingCollector) RecordSyncBatchSize(_ context.Context, requests int) {
+	c.batchMu.Lock()
+	defer c.batchMu.Unlock()
+
+	c.batches = append(c.batches, requests)
+}
+
+func TestSyncCoordinator_CoalescesBurst(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	syncResult := &SyncResult{}
+	collector := &countingCollector{}
+
+	coordinator := NewSyncCoordinator(func(context.Context) (ctrl.Result, *SyncResult, error) {
+		calls.Add(1)
+
+		return ctrl.Result{}, syncResult, nil
+	}, 100*time.Millisecond, collector)
+
+	const requests = 5
+
+	var wg sync.WaitGroup
+
+	results := make([]*SyncResult, requests)
+
+	for i := range requests {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, res, err := coordinator.Sync(context.Background())
+			assert.NoError(t, err)
+
+			results[i] = res
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, int32(requests-1), collector.coalesced.Load())
+	assert.Equal(t, []int{requests}, collector.batches)
+	assert.Equal(t, int32(requests), collector.maxQueued.Load())
+
+	for _, res := range results {
+		assert.Same(t, syncResult, res)
+	}
+}
+
+func TestSyncCoordinator_SequentialRequestsSyncAgain(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	coordinator := NewSyncCoordinator(func(context.Context) (ctrl.Result, *SyncResult, error) {
+		calls.Add(1)
+
+		return ctrl.Result{}, nil, nil
+	}, time.Millisecond, nil)
+
+	for range 3 {
+		_, _, err := coordinator.Sync(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestSyncCoordinator_NoDebounce(t *testing.T) {
+	t.Parallel()
+
+	syncErr := errors.New("sync failed")
+
+	coordinator := NewSyncCoordinator(func(context.Context) (ctrl.Result, *SyncResult, error) {
+		return ctrl.Result{RequeueAfter: time.Minute}, nil, syncErr
+	}, 0, nil)
+
+	result, _, err := coordinator.Sync(context.Background())
+	require.ErrorIs(t, err, syncErr)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+}
+
+func TestSyncCoordinator_CallerCancelled(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+
+	coordinator := NewSyncCoordinator(func(ctx context.Context) (ctrl.Result, *SyncResult, error) {
+		defer close(done)
+
+		<-release
+
+		// The sync must not inherit the cancellation of the caller that scheduled it.
+		assert.NoError(t, ctx.Err())
+
+		return ctrl.Result{}, nil, nil
+	}, time.Millisecond, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := coordinator.Sync(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	close(release)
+	<-done
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// DefaultLivenessTimeout is the default time after which a route sync
+// holding the sync lock, or a stalled sync loop, fails the liveness check.
+const DefaultLivenessTimeout = 5 * time.Minute
+
+// syncWatchdog tracks the progress of route syncs for the liveness check.
+// The zero value is ready to use.
+type syncWatchdog struct {
+	// lockedAt is when the running sync acquired the sync lock, in Unix
+	// nanoseconds, or zero if no sync is running.
+	lockedAt atomic.Int64
+
+	// progressAt is when a sync last finished or the sync loop last ran,
+	// in Unix nanoseconds, or zero before either happened.
+	progressAt atomic.Int64
+}
+
+// locked records that a sync acquired the sync lock.
+func (w *syncWatchdog) locked(now time.Time) {
+	w.lockedAt.Store(now.UnixNano())
+}
+
+// unlocked records that a sync released the sync lock.
+func (w *syncWatchdog) unlocked(now time.Time) {
+	w.lockedAt.Store(0)
+	w.progressAt.Store(now.UnixNano())
+}
+
+// progressed records that the sync loop ran.
+func (w *syncWatchdog) progressed(now time.Time) {
+	w.progressAt.Store(now.UnixNano())
+}
+
+// LivenessCheck fails when route syncs stopped making progress: a sync held
+// the sync lock for longer than the timeout, or, on the leader, neither a
+// sync finished nor the sync loop ran within the timeout. Restarting the
+// controller is the only way out of such a deadlock.
+type LivenessCheck struct {
+	syncer  *PingoraRouteSyncer
+	elected <-chan struct{}
+	timeout time.Duration
+
+	// now defaults to time.Now.
+	now func() time.Time
+}
+
+// NewLivenessCheck creates a LivenessCheck for syncer. elected is closed
+// once this replica is the leader, see manager.Manager.Elected.
+func NewLivenessCheck(syncer *PingoraRouteSyncer, elected <-chan struct{}, timeout time.Duration) *LivenessCheck {
+	return &LivenessCheck{
+		syncer:  syncer,
+		elected: elected,
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+// Check implements healthz.Checker.
+func (c *LivenessCheck) Check(_ *http.Request) error {
+	if c.timeout <= 0 {
+		return nil
+	}
+
+	now := c.now()
+
+	if lockedAt := c.syncer.watchdog.lockedAt.Load(); lockedAt != 0 {
+		if held := now.Sub(time.Unix(0, lockedAt)); held > c.timeout {
+			return errors.Newf("route sync has held the sync lock for %s", held.Round(time.Second))
+		}
+	}
+
+	select {
+	case <-c.elected:
+	default:
+		// Standby replicas do not sync
+		return nil
+	}
+
+	// Without a sync loop, progress is only made on route events
+	if c.syncer.VersionCheckInterval <= 0 {
+		return nil
+	}
+
+	progressAt := c.syncer.watchdog.progressAt.Load()
+	if progressAt == 0 {
+		// The sync loop has not started yet
+		return nil
+	}
+
+	if stalled := now.Sub(time.Unix(0, progressAt)); stalled > c.timeout {
+		return errors.Newf("route syncs made no progress for %s", stalled.Round(time.Second))
+	}
+
+	return nil
+}
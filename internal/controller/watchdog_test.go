@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLivenessCheck(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	closed := make(chan struct{})
+	close(closed)
+
+	tests := []struct {
+		name     string
+		elected  chan struct{}
+		interval time.Duration
+		lockedAt time.Time
+		progress time.Time
+		wantErr  bool
+	}{
+		{name: "sync loop not started", elected: closed, interval: time.Minute},
+		{name: "recent progress", elected: closed, interval: time.Minute, progress: start.Add(-time.Minute)},
+		{name: "stalled sync loop", elected: closed, interval: time.Minute, progress: start.Add(-10 * time.Minute), wantErr: true},
+		{name: "stalled standby", elected: make(chan struct{}), interval: time.Minute, progress: start.Add(-10 * time.Minute)},
+		{name: "no sync loop", elected: closed, progress: start.Add(-10 * time.Minute)},
+		{name: "sync holding lock", elected: closed, interval: time.Minute, lockedAt: start.Add(-10 * time.Minute), progress: start, wantErr: true},
+		{name: "standby holding lock", elected: make(chan struct{}), lockedAt: start.Add(-10 * time.Minute), wantErr: true},
+		{name: "short sync", elected: closed, interval: time.Minute, lockedAt: start.Add(-time.Second), progress: start},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			syncer := &PingoraRouteSyncer{VersionCheckInterval: tt.interval}
+			if !tt.lockedAt.IsZero() {
+				syncer.watchdog.locked(tt.lockedAt)
+			}
+
+			if !tt.progress.IsZero() {
+				syncer.watchdog.progressed(tt.progress)
+			}
+
+			check := NewLivenessCheck(syncer, tt.elected, DefaultLivenessTimeout)
+			check.now = func() time.Time { return start }
+
+			if tt.wantErr {
+				assert.Error(t, check.Check(nil))
+			} else {
+				assert.NoError(t, check.Check(nil))
+			}
+		})
+	}
+}
+
+func TestLivenessCheck_Disabled(t *testing.T) {
+	t.Parallel()
+
+	syncer := &PingoraRouteSyncer{}
+	syncer.watchdog.locked(time.Now().Add(-time.Hour))
+
+	assert.NoError(t, NewLivenessCheck(syncer, nil, 0).Check(nil))
+}
@@ -0,0 +1,88 @@
+package dns
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ClusterDomainListener is called after the cluster domain has changed.
+type ClusterDomainListener func(ctx context.Context, domain string)
+
+// ClusterDomainProvider holds the cluster domain used to build service addresses.
+//
+// The domain may change at runtime, for example when auto-detection picks up
+// an updated /etc/resolv.conf. Components read the current value on every use
+// and register listeners to react to changes.
+type ClusterDomainProvider struct {
+	mu        sync.RWMutex
+	domain    string
+	listeners []ClusterDomainListener
+}
+
+// NewClusterDomainProvider creates a provider with the given initial domain.
+// An empty domain falls back to DefaultClusterDomain.
+func NewClusterDomainProvider(domain string) *ClusterDomainProvider {
+	if domain == "" {
+		domain = DefaultClusterDomain
+	}
+
+	return &ClusterDomainProvider{domain: domain}
+}
+
+// ClusterDomain returns the current cluster domain.
+func (p *ClusterDomainProvider) ClusterDomain() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.domain
+}
+
+// OnChange registers a listener that is called after every domain change.
+func (p *ClusterDomainProvider) OnChange(listener ClusterDomainListener) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.listeners = append(p.listeners, listener)
+}
+
+// Set updates the cluster domain and notifies listeners.
+// Empty and unchanged values are ignored. Returns true if the domain changed.
+func (p *ClusterDomainProvider) Set(ctx context.Context, domain string) bool {
+	p.mu.Lock()
+
+	if domain == "" || domain == p.domain {
+		p.mu.Unlock()
+
+		return false
+	}
+
+	p.domain = domain
+	listeners := append([]ClusterDomainListener(nil), p.listeners...)
+
+	p.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(ctx, domain)
+	}
+
+	return true
+}
+
+// Watch re-runs detect every interval and applies the detected domain
+// until the context is cancelled. Failed detections keep the current domain.
+func (p *ClusterDomainProvider) Watch(ctx context.Context, interval time.Duration, detect func() (string, bool)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if domain, ok := detect(); ok {
+				p.Set(ctx, domain)
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package dns_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/dns"
+)
+
+func TestNewClusterDomainProvider(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "example.local", dns.NewClusterDomainProvider("example.local").ClusterDomain())
+	assert.Equal(t, dns.DefaultClusterDomain, dns.NewClusterDomainProvider("").ClusterDomain())
+}
+
+func TestClusterDomainProvider_Set(t *testing.T) {
+	t.Parallel()
+
+	provider := dns.NewClusterDomainProvider("cluster.local")
+
+	var notified []string
+
+	provider.OnChange(func(_ context.Context, domain string) {
+		notified = append(notified, domain)
+	})
+
+	ctx := context.Background()
+
+	assert.False(t, provider.Set(ctx, "cluster.local"), "unchanged domain must be ignored")
+	assert.False(t, provider.Set(ctx, ""), "empty domain must be ignored")
+	assert.True(t, provider.Set(ctx, "example.local"))
+
+	assert.Equal(t, "example.local", provider.ClusterDomain())
+	assert.Equal(t, []string{"example.local"}, notified)
+}
+
+func TestClusterDomainProvider_Watch(t *testing.T) {
+	t.Parallel()
+
+	provider := dns.NewClusterDomainProvider("cluster.local")
+
+	var detections atomic.Int32
+
+	changed := make(chan string, 1)
+
+	provider.OnChange(func(_ context.Context, domain string) {
+		changed <- domain
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go provider.Watch(ctx, time.Millisecond, func() (string, bool) {
+		// First detection fails, the next ones succeed
+		if detections.Add(1) == 1 {
+			return "", false
+		}
+
+		return "example.local", true
+	})
+
+	select {
+	case domain := <-changed:
+		assert.Equal(t, "example.local", domain)
+	case <-time.After(5 * time.Second):
+		t.Fatal("domain change was not detected")
+	}
+
+	assert.Equal(t, "example.local", provider.ClusterDomain())
+}
@@ -0,0 +1,127 @@
+package ingress
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// SetAccessControlPolicies replaces the PingoraAccessControlPolicies applied
+// to route rules. Call it before building routes so that policy changes take
+// effect on the next sync. Policies on Gateways apply to listeners and are
+// handled by BuildListeners.
+func (b *PingoraBuilder) SetAccessControlPolicies(policies []v1alpha1.PingoraAccessControlPolicy) {
+	active := ActivePolicies(policyPointers(policies))
+	byTarget := make(map[PolicyTarget]*routingv1.AccessControl, len(active))
+
+	for target, policy := range active {
+		if target.Kind == PolicyTargetHTTPRoute {
+			byTarget[target] = accessControlFromPolicy(policy)
+		}
+	}
+
+	b.accessControlMu.Lock()
+	defer b.accessControlMu.Unlock()
+
+	b.accessControls = byTarget
+}
+
+// accessControlFor returns the access control of an HTTPRoute rule. A policy
+// on the named rule takes precedence over one on the whole route.
+func (b *PingoraBuilder) accessControlFor(route *gatewayv1.HTTPRoute, ruleName string) *routingv1.AccessControl {
+	b.accessControlMu.RLock()
+	defer b.accessControlMu.RUnlock()
+
+	if len(b.accessControls) == 0 {
+		return nil
+	}
+
+	routeTarget := PolicyTarget{Kind: PolicyTargetHTTPRoute, Namespace: route.Namespace, Name: route.Name}
+
+	if ruleName != "" {
+		ruleTarget := routeTarget
+		ruleTarget.SectionName = ruleName
+
+		if acl, ok := b.accessControls[ruleTarget]; ok {
+			return acl
+		}
+	}
+
+	return b.accessControls[routeTarget]
+}
+
+// ValidateAccessControl reports the first entry of a policy that is neither
+// a CIDR nor an IP address.
+func ValidateAccessControl(spec *v1alpha1.PingoraAccessControlPolicySpec) error {
+	for _, list := range [][]string{spec.Allow, spec.Deny} {
+		for _, entry := range list {
+			if _, err := parseCIDR(entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// accessControlFromPolicy converts a policy to proxy access control. A policy
+// with an invalid entry denies all requests, so that a typo never opens
+// access that the policy was meant to restrict.
+func accessControlFromPolicy(policy *v1alpha1.PingoraAccessControlPolicy) *routingv1.AccessControl {
+	spec := &policy.Spec
+	result := &routingv1.AccessControl{
+		Id:            policy.Namespace + "/" + policy.Name,
+		DefaultAction: routingv1.AccessAction_ACCESS_ACTION_DENY,
+	}
+
+	if ValidateAccessControl(spec) != nil {
+		return result
+	}
+
+	result.AllowCidrs = normalizeCIDRs(spec.Allow)
+	result.DenyCidrs = normalizeCIDRs(spec.Deny)
+
+	if spec.GetDefaultAction() == v1alpha1.AccessActionAllow {
+		result.DefaultAction = routingv1.AccessAction_ACCESS_ACTION_ALLOW
+	}
+
+	return result
+}
+
+// normalizeCIDRs returns valid entries in canonical CIDR notation, with
+// single addresses as host prefixes.
+func normalizeCIDRs(entries []string) []string {
+	result := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		prefix, err := parseCIDR(entry)
+		if err == nil {
+			result = append(result, prefix.String())
+		}
+	}
+
+	return result
+}
+
+func parseCIDR(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return netip.Prefix{}, errors.Newf("invalid CIDR %q", entry)
+		}
+
+		return prefix.Masked(), nil
+	}
+
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, errors.Newf("invalid IP address %q", entry)
+	}
+
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
@@ -0,0 +1,208 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func accessControlPolicy(
+	name string,
+	target gatewayv1.LocalPolicyTargetReferenceWithSectionName,
+	allow, deny []string,
+	defaultAction v1alpha1.AccessAction,
+) v1alpha1.PingoraAccessControlPolicy {
+	return v1alpha1.PingoraAccessControlPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.PingoraAccessControlPolicySpec{
+			TargetRefs:    []gatewayv1.LocalPolicyTargetReferenceWithSectionName{target},
+			Allow:         allow,
+			Deny:          deny,
+			DefaultAction: defaultAction,
+		},
+	}
+}
+
+func TestValidateAccessControl(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		allow   []string
+		deny    []string
+		wantErr string
+	}{
+		{name: "empty"},
+		{name: "CIDRs and addresses", allow: []string{"10.0.0.0/8", "192.0.2.1", "2001:db8::/32", "::1"}},
+		{name: "invalid CIDR", allow: []string{"10.0.0.0/33"}, wantErr: `invalid CIDR "10.0.0.0/33"`},
+		{name: "invalid address", deny: []string{"example.com"}, wantErr: `invalid IP address "example.com"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateAccessControl(&v1alpha1.PingoraAccessControlPolicySpec{Allow: tt.allow, Deny: tt.deny})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildHTTPRoute_AccessControl(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetAccessControlPolicies([]v1alpha1.PingoraAccessControlPolicy{
+		accessControlPolicy("route", policyTargetRef(PolicyTargetHTTPRoute, "app", ""),
+			nil, []string{"203.0.113.7", "2001:db8::1/64"}, ""),
+		accessControlPolicy("admin", policyTargetRef(PolicyTargetHTTPRoute, "app", "admin"),
+			[]string{"10.0.0.0/8"}, nil, v1alpha1.AccessActionDeny),
+		accessControlPolicy("typo", policyTargetRef(PolicyTargetHTTPRoute, "app", "typo"),
+			[]string{"10.0.0.0/8", "10.0.0.300"}, nil, ""),
+		// Gateway policies apply to listeners, not routes
+		accessControlPolicy("gateway", policyTargetRef(PolicyTargetGateway, "app", ""),
+			[]string{"10.0.0.0/8"}, nil, v1alpha1.AccessActionDeny),
+	})
+
+	tests := []struct {
+		name     string
+		route    string
+		rule     string
+		expected *routingv1.AccessControl
+	}{
+		{
+			name:  "route policy with normalized addresses",
+			route: "app",
+			expected: &routingv1.AccessControl{
+				Id:            "default/route",
+				AllowCidrs:    []string{},
+				DenyCidrs:     []string{"203.0.113.7/32", "2001:db8::/64"},
+				DefaultAction: routingv1.AccessAction_ACCESS_ACTION_ALLOW,
+			},
+		},
+		{
+			name:  "rule policy",
+			route: "app",
+			rule:  "admin",
+			expected: &routingv1.AccessControl{
+				Id:            "default/admin",
+				AllowCidrs:    []string{"10.0.0.0/8"},
+				DenyCidrs:     []string{},
+				DefaultAction: routingv1.AccessAction_ACCESS_ACTION_DENY,
+			},
+		},
+		{
+			name:  "invalid policy denies all",
+			route: "app",
+			rule:  "typo",
+			expected: &routingv1.AccessControl{
+				Id:            "default/typo",
+				DefaultAction: routingv1.AccessAction_ACCESS_ACTION_DENY,
+			},
+		},
+		{
+			name:  "untargeted route",
+			route: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := gatewayv1.HTTPRouteRule{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+			}
+			if tt.rule != "" {
+				rule.Name = ptrTo(gatewayv1.SectionName(tt.rule))
+			}
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.route, Namespace: "default"},
+				Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{rule}},
+			}
+
+			result := builder.BuildHTTPRoute(route)
+			require.Len(t, result.GetRules(), 1)
+
+			acl := result.GetRules()[0].GetAccessControl()
+			assert.True(t, proto.Equal(tt.expected, acl), "access control: %v", acl)
+		})
+	}
+}
+
+func TestBuildListeners_AccessControl(t *testing.T) {
+	t.Parallel()
+
+	gateways := []gatewayv1.Gateway{
+		trafficGateway("public", map[string]gatewayv1.PortNumber{"http": 80, "https": 443}),
+		trafficGateway("internal", map[string]gatewayv1.PortNumber{"http": 80}),
+		trafficGateway("open", map[string]gatewayv1.PortNumber{"http": 8080}),
+	}
+
+	for i := range gateways {
+		gateways[i].Namespace = "default"
+	}
+
+	policies := []v1alpha1.PingoraAccessControlPolicy{
+		accessControlPolicy("public", policyTargetRef(PolicyTargetGateway, "public", ""),
+			nil, []string{"198.51.100.0/24"}, ""),
+		accessControlPolicy("public-https", policyTargetRef(PolicyTargetGateway, "public", "https"),
+			[]string{"192.0.2.0/24"}, nil, v1alpha1.AccessActionDeny),
+		accessControlPolicy("internal", policyTargetRef(PolicyTargetGateway, "internal", ""),
+			[]string{"10.0.0.0/8"}, nil, v1alpha1.AccessActionDeny),
+	}
+
+	listeners := BuildListeners(gateways, nil, policies)
+
+	want := []*routingv1.Listener{
+		{
+			// Port 80 is shared by public and internal, so a request must be
+			// allowed by both
+			Port: 80,
+			AccessControls: []*routingv1.AccessControl{
+				{
+					Id:            "default/internal",
+					AllowCidrs:    []string{"10.0.0.0/8"},
+					DenyCidrs:     []string{},
+					DefaultAction: routingv1.AccessAction_ACCESS_ACTION_DENY,
+				},
+				{
+					Id:            "default/public",
+					AllowCidrs:    []string{},
+					DenyCidrs:     []string{"198.51.100.0/24"},
+					DefaultAction: routingv1.AccessAction_ACCESS_ACTION_ALLOW,
+				},
+			},
+		},
+		{
+			// The listener policy replaces the Gateway policy
+			Port: 443,
+			AccessControls: []*routingv1.AccessControl{
+				{
+					Id:            "default/public-https",
+					AllowCidrs:    []string{"192.0.2.0/24"},
+					DenyCidrs:     []string{},
+					DefaultAction: routingv1.AccessAction_ACCESS_ACTION_DENY,
+				},
+			},
+		},
+	}
+
+	require.Len(t, listeners, len(want))
+
+	for i := range want {
+		assert.True(t, proto.Equal(want[i], listeners[i]), "listener %d: got %v", i, listeners[i])
+	}
+}
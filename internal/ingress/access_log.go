@@ -0,0 +1,87 @@
+package ingress
+
+import (
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// LoggingConfigFromDefaults converts the access log settings in the defaults
+// of a PingoraConfig to the access log settings of the proxy. Nil defaults
+// result in an empty config, so that the proxy falls back to its own
+// defaults.
+func LoggingConfigFromDefaults(defaults *v1alpha1.ProxyDefaults) *routingv1.LoggingConfig {
+	if defaults == nil {
+		return &routingv1.LoggingConfig{}
+	}
+
+	result := &routingv1.LoggingConfig{Format: accessLogFormats[defaults.AccessLogFormat]}
+
+	accessLog := defaults.AccessLog
+	if accessLog == nil {
+		return result
+	}
+
+	result.Fields = make([]string, 0, len(accessLog.Fields))
+	for _, field := range accessLog.Fields {
+		result.Fields = append(result.Fields, string(field))
+	}
+
+	if accessLog.SamplePercent != nil {
+		percent := samplePercent(*accessLog.SamplePercent)
+		result.SamplePercent = &percent
+	}
+
+	result.IncludePathPrefixes = append([]string(nil), accessLog.IncludePaths...)
+	result.ExcludePathPrefixes = append([]string(nil), accessLog.ExcludePaths...)
+
+	return result
+}
+
+// SetAccessLogPolicies replaces the PingoraAccessLogPolicies applied to
+// routes. Call it before building routes so that policy changes take effect
+// on the next sync.
+func (b *PingoraBuilder) SetAccessLogPolicies(policies []v1alpha1.PingoraAccessLogPolicy) {
+	active := ActivePolicies(policyPointers(policies))
+	byTarget := make(map[PolicyTarget]*routingv1.RouteAccessLog, len(active))
+
+	for target, policy := range active {
+		byTarget[target] = &routingv1.RouteAccessLog{SamplePercent: samplePercent(policy.Spec.SamplePercent)}
+	}
+
+	b.accessLogMu.Lock()
+	defer b.accessLogMu.Unlock()
+
+	b.accessLogs = byTarget
+}
+
+// accessLogFor returns the access log sampling of an HTTPRoute rule. A
+// policy on the named rule takes precedence over one on the whole route.
+func (b *PingoraBuilder) accessLogFor(route *gatewayv1.HTTPRoute, ruleName string) *routingv1.RouteAccessLog {
+	b.accessLogMu.RLock()
+	defer b.accessLogMu.RUnlock()
+
+	if len(b.accessLogs) == 0 {
+		return nil
+	}
+
+	routeTarget := PolicyTarget{Kind: PolicyTargetHTTPRoute, Namespace: route.Namespace, Name: route.Name}
+
+	if ruleName != "" {
+		ruleTarget := routeTarget
+		ruleTarget.SectionName = ruleName
+
+		if accessLog, ok := b.accessLogs[ruleTarget]; ok {
+			return accessLog
+		}
+	}
+
+	return b.accessLogs[routeTarget]
+}
+
+// samplePercent clamps a percentage to 0-100, which the schema enforces
+// already.
+func samplePercent(percent int32) uint32 {
+	return uint32(min(max(percent, 0), 100)) //nolint:mnd // percentage
+}
@@ -0,0 +1,137 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestLoggingConfigFromDefaults(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		defaults *v1alpha1.ProxyDefaults
+		expected *routingv1.LoggingConfig
+	}{
+		{
+			name:     "no defaults",
+			expected: &routingv1.LoggingConfig{},
+		},
+		{
+			name:     "format only",
+			defaults: &v1alpha1.ProxyDefaults{AccessLogFormat: v1alpha1.AccessLogFormatText},
+			expected: &routingv1.LoggingConfig{Format: routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_TEXT},
+		},
+		{
+			name: "all settings",
+			defaults: &v1alpha1.ProxyDefaults{
+				AccessLogFormat: v1alpha1.AccessLogFormatJSON,
+				AccessLog: &v1alpha1.AccessLogConfig{
+					Fields:        []v1alpha1.AccessLogField{"timestamp", "method", "path", "status"},
+					SamplePercent: ptrTo(int32(0)),
+					IncludePaths:  []string{"/api"},
+					ExcludePaths:  []string{"/api/healthz"},
+				},
+			},
+			expected: &routingv1.LoggingConfig{
+				Format:              routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_JSON,
+				Fields:              []string{"timestamp", "method", "path", "status"},
+				SamplePercent:       ptrTo(uint32(0)),
+				IncludePathPrefixes: []string{"/api"},
+				ExcludePathPrefixes: []string{"/api/healthz"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := LoggingConfigFromDefaults(tt.defaults)
+			assert.True(t, proto.Equal(tt.expected, result), "got %v", result)
+		})
+	}
+}
+
+func accessLogPolicy(name, sectionName string, samplePercent int32) v1alpha1.PingoraAccessLogPolicy {
+	return v1alpha1.PingoraAccessLogPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.PingoraAccessLogPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				policyTargetRef(PolicyTargetHTTPRoute, "app", sectionName),
+			},
+			SamplePercent: samplePercent,
+		},
+	}
+}
+
+func TestBuildHTTPRoute_AccessLog(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetAccessLogPolicies([]v1alpha1.PingoraAccessLogPolicy{
+		accessLogPolicy("route", "", 25),
+		accessLogPolicy("quiet", "quiet", 0),
+	})
+
+	tests := []struct {
+		name     string
+		route    string
+		rule     string
+		expected *routingv1.RouteAccessLog
+	}{
+		{
+			name:     "route policy",
+			route:    "app",
+			expected: &routingv1.RouteAccessLog{SamplePercent: 25},
+		},
+		{
+			name:     "rule policy takes precedence",
+			route:    "app",
+			rule:     "quiet",
+			expected: &routingv1.RouteAccessLog{},
+		},
+		{
+			name:  "untargeted route",
+			route: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := gatewayv1.HTTPRouteRule{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+			}
+			if tt.rule != "" {
+				rule.Name = ptrTo(gatewayv1.SectionName(tt.rule))
+			}
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.route, Namespace: "default"},
+				Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{rule}},
+			}
+
+			result := builder.BuildHTTPRoute(route)
+			require.Len(t, result.GetRules(), 1)
+
+			accessLog := result.GetRules()[0].GetAccessLog()
+			if tt.expected == nil {
+				assert.Nil(t, accessLog)
+
+				return
+			}
+
+			assert.True(t, proto.Equal(tt.expected, accessLog), "access log: %v", accessLog)
+		})
+	}
+}
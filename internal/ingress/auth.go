@@ -0,0 +1,148 @@
+package ingress
+
+import (
+	"fmt"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// ResolvedAuthPolicy is a PingoraAuthPolicy with the JSON Web Key Set that
+// its Secret or ConfigMap reference resolves to.
+type ResolvedAuthPolicy struct {
+	Policy *v1alpha1.PingoraAuthPolicy
+
+	// JWKS is the resolved key set. It is empty for policies without JWT
+	// validation, with a JWKS URI, or whose reference could not be resolved.
+	JWKS string
+}
+
+// authEntry is the auth config of a policy target, or the response served
+// instead when the policy's key set could not be resolved.
+type authEntry struct {
+	auth       *routingv1.AuthConfig
+	unresolved *routingv1.FixedResponse
+}
+
+// SetAuthPolicies replaces the PingoraAuthPolicies applied to routes. Call it
+// before building routes so that policy changes take effect on the next
+// sync.
+func (b *PingoraBuilder) SetAuthPolicies(policies []ResolvedAuthPolicy) {
+	attached := make([]*v1alpha1.PingoraAuthPolicy, 0, len(policies))
+	jwks := make(map[*v1alpha1.PingoraAuthPolicy]string, len(policies))
+
+	for i := range policies {
+		attached = append(attached, policies[i].Policy)
+		jwks[policies[i].Policy] = policies[i].JWKS
+	}
+
+	active := ActivePolicies(attached)
+	byTarget := make(map[PolicyTarget]authEntry, len(active))
+
+	for target, policy := range active {
+		byTarget[target] = b.authFromPolicy(policy, jwks[policy])
+	}
+
+	b.authMu.Lock()
+	defer b.authMu.Unlock()
+
+	b.authPolicies = byTarget
+}
+
+// authFor returns the auth config of an HTTPRoute rule. A policy on the named
+// rule takes precedence over one on the whole route. Per Gateway API, a rule
+// must not be served without its policy, so if the policy's key set could
+// not be resolved a fixed 500 response is returned instead.
+func (b *PingoraBuilder) authFor(
+	route *gatewayv1.HTTPRoute,
+	ruleName string,
+) (*routingv1.AuthConfig, *routingv1.FixedResponse) {
+	b.authMu.RLock()
+	defer b.authMu.RUnlock()
+
+	if len(b.authPolicies) == 0 {
+		return nil, nil
+	}
+
+	routeTarget := PolicyTarget{Kind: PolicyTargetHTTPRoute, Namespace: route.Namespace, Name: route.Name}
+
+	if ruleName != "" {
+		ruleTarget := routeTarget
+		ruleTarget.SectionName = ruleName
+
+		if entry, ok := b.authPolicies[ruleTarget]; ok {
+			return entry.auth, entry.unresolved
+		}
+	}
+
+	entry := b.authPolicies[routeTarget]
+
+	return entry.auth, entry.unresolved
+}
+
+func (b *PingoraBuilder) authFromPolicy(policy *v1alpha1.PingoraAuthPolicy, jwks string) authEntry {
+	spec := &policy.Spec
+	id := policy.Namespace + "/" + policy.Name
+	result := &routingv1.AuthConfig{Id: id}
+
+	if spec.JWT != nil {
+		if spec.JWT.JWKS.URI == "" && jwks == "" {
+			return authEntry{unresolved: &routingv1.FixedResponse{
+				StatusCode: NoBackendsStatusCode,
+				Reason:     fmt.Sprintf("%s %s: JWKS not resolved", v1alpha1.PingoraAuthPolicyKind, id),
+			}}
+		}
+
+		result.Jwt = jwtFromPolicy(spec.JWT, jwks)
+	}
+
+	if spec.External != nil {
+		result.External = b.externalAuthFromPolicy(policy.Namespace, spec.External)
+	}
+
+	return authEntry{auth: result}
+}
+
+func jwtFromPolicy(spec *v1alpha1.JWTAuth, jwks string) *routingv1.JWTAuth {
+	result := &routingv1.JWTAuth{
+		Issuers:         append([]string(nil), spec.Issuers...),
+		Audiences:       append([]string(nil), spec.Audiences...),
+		JwksUri:         spec.JWKS.URI,
+		ClaimsToHeaders: make([]*routingv1.ClaimToHeader, 0, len(spec.ClaimsToHeaders)),
+	}
+
+	if result.GetJwksUri() == "" {
+		result.Jwks = jwks
+	}
+
+	for _, mapping := range spec.ClaimsToHeaders {
+		result.ClaimsToHeaders = append(result.ClaimsToHeaders, &routingv1.ClaimToHeader{
+			Claim:  mapping.Claim,
+			Header: mapping.Header,
+		})
+	}
+
+	return result
+}
+
+func (b *PingoraBuilder) externalAuthFromPolicy(namespace string, spec *v1alpha1.ExternalAuth) *routingv1.ExternalAuth {
+	result := &routingv1.ExternalAuth{
+		Protocol:         routingv1.ExternalAuthProtocol_EXTERNAL_AUTH_PROTOCOL_HTTP,
+		PathPrefix:       spec.PathPrefix,
+		TimeoutMs:        durationMs(spec.Timeout),
+		FailOpen:         spec.FailOpen,
+		HeadersToBackend: append([]string(nil), spec.HeadersToBackend...),
+	}
+
+	if spec.Protocol == v1alpha1.ExternalAuthProtocolGRPC {
+		result.Protocol = routingv1.ExternalAuthProtocol_EXTERNAL_AUTH_PROTOCOL_GRPC
+	}
+
+	if spec.BackendRef.Port != nil {
+		result.Address = b.serviceAddress(string(spec.BackendRef.Name), namespace, *spec.BackendRef.Port)
+	}
+
+	return result
+}
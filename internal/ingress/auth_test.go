@@ -0,0 +1,158 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+const testJWKS = `{"keys":[{"kty":"oct","kid":"test","k":"c2VjcmV0"}]}`
+
+func authPolicy(name, sectionName string, jwks v1alpha1.JWKSSource) *v1alpha1.PingoraAuthPolicy {
+	return &v1alpha1.PingoraAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.PingoraAuthPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				policyTargetRef(PolicyTargetHTTPRoute, "app", sectionName),
+			},
+			JWT: &v1alpha1.JWTAuth{
+				Issuers:   []string{"https://issuer.example.com"},
+				Audiences: []string{"app"},
+				JWKS:      jwks,
+				ClaimsToHeaders: []v1alpha1.ClaimToHeader{
+					{Claim: "sub", Header: "X-User"},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildHTTPRoute_Auth(t *testing.T) {
+	t.Parallel()
+
+	external := &v1alpha1.PingoraAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "default"},
+		Spec: v1alpha1.PingoraAuthPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				policyTargetRef(PolicyTargetHTTPRoute, "app", "orders"),
+			},
+			External: &v1alpha1.ExternalAuth{
+				Protocol: v1alpha1.ExternalAuthProtocolGRPC,
+				BackendRef: gatewayv1.BackendObjectReference{
+					Name: "authz",
+					Port: ptrTo(gatewayv1.PortNumber(9001)),
+				},
+				Timeout:          ptrTo(gatewayv1.Duration("500ms")),
+				HeadersToBackend: []string{"X-User-Id"},
+			},
+		},
+	}
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetAuthPolicies([]ResolvedAuthPolicy{
+		{Policy: authPolicy("route", "", v1alpha1.JWKSSource{URI: "https://issuer.example.com/jwks"})},
+		{
+			Policy: authPolicy("login", "login", v1alpha1.JWKSSource{
+				SecretRef: &v1alpha1.KeyReference{Name: "jwks"},
+			}),
+			JWKS: testJWKS,
+		},
+		{Policy: authPolicy("admin", "admin", v1alpha1.JWKSSource{
+			ConfigMapRef: &v1alpha1.KeyReference{Name: "missing"},
+		})},
+		{Policy: external},
+	})
+
+	jwt := func(uri, jwks string) *routingv1.JWTAuth {
+		return &routingv1.JWTAuth{
+			Issuers:         []string{"https://issuer.example.com"},
+			Audiences:       []string{"app"},
+			JwksUri:         uri,
+			Jwks:            jwks,
+			ClaimsToHeaders: []*routingv1.ClaimToHeader{{Claim: "sub", Header: "X-User"}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		route    string
+		rule     string
+		expected *routingv1.AuthConfig
+		fixed    bool
+	}{
+		{
+			name:     "route policy with JWKS URI",
+			route:    "app",
+			expected: &routingv1.AuthConfig{Id: "default/route", Jwt: jwt("https://issuer.example.com/jwks", "")},
+		},
+		{
+			name:     "rule policy with resolved JWKS",
+			route:    "app",
+			rule:     "login",
+			expected: &routingv1.AuthConfig{Id: "default/login", Jwt: jwt("", testJWKS)},
+		},
+		{
+			name:  "rule policy with unresolved JWKS",
+			route: "app",
+			rule:  "admin",
+			fixed: true,
+		},
+		{
+			name:  "rule policy with external auth",
+			route: "app",
+			rule:  "orders",
+			expected: &routingv1.AuthConfig{
+				Id: "default/external",
+				External: &routingv1.ExternalAuth{
+					Protocol:         routingv1.ExternalAuthProtocol_EXTERNAL_AUTH_PROTOCOL_GRPC,
+					Address:          "authz.default.svc.cluster.local:9001",
+					TimeoutMs:        500,
+					HeadersToBackend: []string{"X-User-Id"},
+				},
+			},
+		},
+		{
+			name:  "untargeted route",
+			route: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := gatewayv1.HTTPRouteRule{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+			}
+			if tt.rule != "" {
+				rule.Name = ptrTo(gatewayv1.SectionName(tt.rule))
+			}
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.route, Namespace: "default"},
+				Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{rule}},
+			}
+
+			result := builder.BuildHTTPRoute(route)
+			require.Len(t, result.GetRules(), 1)
+
+			ruleResult := result.GetRules()[0]
+			assert.True(t, proto.Equal(tt.expected, ruleResult.GetAuth()), "auth: %v", ruleResult.GetAuth())
+
+			if tt.fixed {
+				assert.Equal(t, uint32(NoBackendsStatusCode), ruleResult.GetFixedResponse().GetStatusCode())
+				assert.Contains(t, ruleResult.GetFixedResponse().GetReason(), "default/admin")
+				assert.Nil(t, ruleResult.GetRetry())
+			} else {
+				assert.Nil(t, ruleResult.GetFixedResponse())
+			}
+		})
+	}
+}
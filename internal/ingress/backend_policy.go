@@ -0,0 +1,86 @@
+package ingress
+
+import (
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// backendSettings are the proxy settings of a Service backend from its
+// PingoraBackendPolicy.
+type backendSettings struct {
+	circuitBreaker *routingv1.CircuitBreaker
+	healthCheck    *routingv1.HealthCheck
+}
+
+// SetBackendPolicies replaces the PingoraBackendPolicies applied to
+// backends. Call it before building routes so that policy changes take
+// effect on the next sync.
+func (b *PingoraBuilder) SetBackendPolicies(policies []v1alpha1.PingoraBackendPolicy) {
+	active := ActivePolicies(policyPointers(policies))
+	byTarget := make(map[PolicyTarget]backendSettings, len(active))
+
+	for target, policy := range active {
+		if target.Kind != PolicyTargetService {
+			continue
+		}
+
+		var settings backendSettings
+
+		if policy.Spec.CircuitBreaker != nil {
+			settings.circuitBreaker = circuitBreakerFromPolicy(policy)
+		}
+
+		if policy.Spec.HealthCheck != nil {
+			settings.healthCheck = healthCheckFromPolicy(policy)
+		}
+
+		byTarget[target] = settings
+	}
+
+	b.backendPolicyMu.Lock()
+	defer b.backendPolicyMu.Unlock()
+
+	b.backendSettings = byTarget
+}
+
+// backendSettingsFor returns the settings of a Service backend.
+func (b *PingoraBuilder) backendSettingsFor(namespace, name string) backendSettings {
+	b.backendPolicyMu.RLock()
+	defer b.backendPolicyMu.RUnlock()
+
+	return b.backendSettings[PolicyTarget{Kind: PolicyTargetService, Namespace: namespace, Name: name}]
+}
+
+func circuitBreakerFromPolicy(policy *v1alpha1.PingoraBackendPolicy) *routingv1.CircuitBreaker {
+	spec := policy.Spec.CircuitBreaker
+	ejection := spec.GetEjectionDuration()
+
+	return &routingv1.CircuitBreaker{
+		Id:                  policy.Namespace + "/" + policy.Name,
+		ConsecutiveFailures: uint32(max(spec.GetConsecutive5xx(), 1)),
+		EjectionDurationMs:  durationMs(&ejection),
+		MaxEjectionPercent:  uint32(min(max(spec.GetMaxEjectionPercent(), 0), 100)),
+	}
+}
+
+func healthCheckFromPolicy(policy *v1alpha1.PingoraBackendPolicy) *routingv1.HealthCheck {
+	spec := policy.Spec.HealthCheck
+	interval := spec.GetInterval()
+	timeout := spec.GetTimeout()
+
+	result := &routingv1.HealthCheck{
+		Id:                 policy.Namespace + "/" + policy.Name,
+		Path:               spec.GetPath(),
+		IntervalMs:         durationMs(&interval),
+		TimeoutMs:          durationMs(&timeout),
+		HealthyThreshold:   uint32(max(spec.GetHealthyThreshold(), 1)),
+		UnhealthyThreshold: uint32(max(spec.GetUnhealthyThreshold(), 1)),
+		ExpectedStatuses:   make([]uint32, 0, len(spec.ExpectedStatuses)),
+	}
+
+	for _, status := range spec.ExpectedStatuses {
+		result.ExpectedStatuses = append(result.ExpectedStatuses, uint32(max(status, 0)))
+	}
+
+	return result
+}
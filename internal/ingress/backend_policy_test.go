@@ -0,0 +1,108 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func serviceTargetRef(name string) gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+			Kind: PolicyTargetService,
+			Name: gatewayv1.ObjectName(name),
+		},
+	}
+}
+
+func TestBuildHTTPRoute_BackendPolicy(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetBackendPolicies([]v1alpha1.PingoraBackendPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "tuned", Namespace: "default"},
+			Spec: v1alpha1.PingoraBackendPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{serviceTargetRef("api")},
+				CircuitBreaker: &v1alpha1.CircuitBreaker{
+					Consecutive5xx:     ptrTo(int32(3)),
+					EjectionDuration:   ptrTo(gatewayv1.Duration("1m")),
+					MaxEjectionPercent: ptrTo(int32(20)),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "other"},
+			Spec: v1alpha1.PingoraBackendPolicySpec{
+				TargetRefs:     []gatewayv1.LocalPolicyTargetReferenceWithSectionName{serviceTargetRef("api")},
+				CircuitBreaker: &v1alpha1.CircuitBreaker{},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "probed", Namespace: "default"},
+			Spec: v1alpha1.PingoraBackendPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{serviceTargetRef("web")},
+				HealthCheck: &v1alpha1.HealthCheck{
+					Path:             "/healthz",
+					Interval:         ptrTo(gatewayv1.Duration("5s")),
+					ExpectedStatuses: []int32{200, 204},
+				},
+			},
+		},
+	})
+
+	otherNamespace := gatewayv1.Namespace("other")
+	crossNamespace := serviceRef("api", 80)
+	crossNamespace.Namespace = &otherNamespace
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{
+			BackendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: serviceRef("api", 80)},
+				{BackendRef: crossNamespace},
+				{BackendRef: serviceRef("web", 80)},
+			},
+		}}},
+	}
+
+	result := builder.BuildHTTPRoute(route)
+	require.Len(t, result.GetRules(), 1)
+
+	backends := result.GetRules()[0].GetBackends()
+	require.Len(t, backends, 3)
+
+	wantBreakers := []*routingv1.CircuitBreaker{
+		{Id: "default/tuned", ConsecutiveFailures: 3, EjectionDurationMs: 60000, MaxEjectionPercent: 20},
+		{Id: "other/defaults", ConsecutiveFailures: 5, EjectionDurationMs: 30000, MaxEjectionPercent: 50},
+		nil,
+	}
+
+	wantHealthChecks := []*routingv1.HealthCheck{
+		nil,
+		nil,
+		{
+			Id:                 "default/probed",
+			Path:               "/healthz",
+			IntervalMs:         5000,
+			TimeoutMs:          1000,
+			HealthyThreshold:   2,
+			UnhealthyThreshold: 3,
+			ExpectedStatuses:   []uint32{200, 204},
+		},
+	}
+
+	for i := range backends {
+		assert.True(t, proto.Equal(wantBreakers[i], backends[i].GetCircuitBreaker()),
+			"backend %s: %v", backends[i].GetAddress(), backends[i].GetCircuitBreaker())
+		assert.True(t, proto.Equal(wantHealthChecks[i], backends[i].GetHealthCheck()),
+			"backend %s: %v", backends[i].GetAddress(), backends[i].GetHealthCheck())
+	}
+}
@@ -0,0 +1,120 @@
+package ingress
+
+import (
+	"fmt"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// kindService is the kind of core Service backends.
+const kindService = "Service"
+
+// Route kinds, as referenced by the from of ReferenceGrants.
+const (
+	kindHTTPRoute = "HTTPRoute"
+	kindGRPCRoute = "GRPCRoute"
+)
+
+// BackendRefsStatus describes whether the backendRefs of a route can be resolved.
+type BackendRefsStatus struct {
+	Resolved bool
+	Reason   gatewayv1.RouteConditionReason
+	Message  string
+}
+
+// CheckBackendRefs reports whether all backendRefs of a route can be used
+// by the builder. Routes without any backendRefs are considered resolved:
+// their rules are programmed with a fixed 500 response instead. Service
+// references without a port are not resolved; the builder skips them.
+func CheckBackendRefs(refs []gatewayv1.BackendRef) BackendRefsStatus {
+	for i := range refs {
+		ref := &refs[i]
+
+		if !isSupportedBackendKind(ref) {
+			return BackendRefsStatus{
+				Resolved: false,
+				Reason:   gatewayv1.RouteReasonInvalidKind,
+				Message:  fmt.Sprintf("Unsupported backend kind %q for backendRef %q", backendRefKind(ref), ref.Name),
+			}
+		}
+
+		if ref.Port == nil && !IsPingoraBackendRef(ref) {
+			return BackendRefsStatus{
+				Resolved: false,
+				Reason:   gatewayv1.RouteReasonUnsupportedValue,
+				Message:  fmt.Sprintf("Port is required for Service backendRef %q", ref.Name),
+			}
+		}
+	}
+
+	return BackendRefsStatus{
+		Resolved: true,
+		Reason:   gatewayv1.RouteReasonResolvedRefs,
+		Message:  "References resolved",
+	}
+}
+
+// backendRefKind returns the kind of a backendRef, qualified with its group
+// unless it is in the core group.
+func backendRefKind(ref *gatewayv1.BackendRef) string {
+	kind := kindService
+	if ref.Kind != nil {
+		kind = string(*ref.Kind)
+	}
+
+	if ref.Group == nil || *ref.Group == "" {
+		return kind
+	}
+
+	return kind + "." + string(*ref.Group)
+}
+
+// isSupportedBackendKind reports whether the builder can convert the backendRef:
+// a core Service or a PingoraBackend.
+func isSupportedBackendKind(ref *gatewayv1.BackendRef) bool {
+	if IsPingoraBackendRef(ref) {
+		return true
+	}
+
+	return (ref.Group == nil || *ref.Group == "") && (ref.Kind == nil || *ref.Kind == kindService)
+}
+
+// isUsableBackendRef reports whether the builder converts the backendRef to a
+// backend: a Service with a port or a PingoraBackend. The CRD validation
+// requires the port of Service references; refs without one only get here
+// if it was bypassed.
+func isUsableBackendRef(ref *gatewayv1.BackendRef) bool {
+	return isSupportedBackendKind(ref) && (ref.Port != nil || IsPingoraBackendRef(ref))
+}
+
+// HTTPRouteSkippedBackendRefs returns the number of backendRefs of an
+// HTTPRoute that the builder skips because of their kind or a missing port.
+func HTTPRouteSkippedBackendRefs(rules []gatewayv1.HTTPRouteRule) int {
+	var skipped int
+
+	for i := range rules {
+		for j := range rules[i].BackendRefs {
+			if !isUsableBackendRef(&rules[i].BackendRefs[j].BackendRef) {
+				skipped++
+			}
+		}
+	}
+
+	return skipped
+}
+
+// GRPCRouteSkippedBackendRefs returns the number of backendRefs of a
+// GRPCRoute that the builder skips because of their kind or a missing port.
+func GRPCRouteSkippedBackendRefs(rules []gatewayv1.GRPCRouteRule) int {
+	var skipped int
+
+	for i := range rules {
+		for j := range rules[i].BackendRefs {
+			if !isUsableBackendRef(&rules[i].BackendRefs[j].BackendRef) {
+				skipped++
+			}
+		}
+	}
+
+	return skipped
+}
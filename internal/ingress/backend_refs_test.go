@@ -0,0 +1,109 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestCheckBackendRefs(t *testing.T) {
+	t.Parallel()
+
+	unsupported := serviceRef("bucket", 80)
+	unsupported.Kind = ptrTo(gatewayv1.Kind("S3Bucket"))
+
+	explicitService := serviceRef("app", 80)
+	explicitService.Kind = ptrTo(gatewayv1.Kind("Service"))
+
+	noPort := serviceRef("app", 80)
+	noPort.Port = nil
+
+	otherGroup := serviceRef("app", 80)
+	otherGroup.Group = ptrTo(gatewayv1.Group("example.com"))
+
+	tests := []struct {
+		name             string
+		refs             []gatewayv1.BackendRef
+		expectedResolved bool
+		expectedReason   gatewayv1.RouteConditionReason
+	}{
+		{
+			name:             "no refs are resolved",
+			refs:             nil,
+			expectedResolved: true,
+			expectedReason:   gatewayv1.RouteReasonResolvedRefs,
+		},
+		{
+			name:             "default kind is resolved",
+			refs:             []gatewayv1.BackendRef{serviceRef("app", 80)},
+			expectedResolved: true,
+			expectedReason:   gatewayv1.RouteReasonResolvedRefs,
+		},
+		{
+			name:             "explicit Service kind is resolved",
+			refs:             []gatewayv1.BackendRef{explicitService},
+			expectedResolved: true,
+			expectedReason:   gatewayv1.RouteReasonResolvedRefs,
+		},
+		{
+			name:             "unsupported kind is invalid",
+			refs:             []gatewayv1.BackendRef{serviceRef("app", 80), unsupported},
+			expectedResolved: false,
+			expectedReason:   gatewayv1.RouteReasonInvalidKind,
+		},
+		{
+			name:             "PingoraBackend without port is resolved",
+			refs:             []gatewayv1.BackendRef{pingoraBackendRef("external")},
+			expectedResolved: true,
+			expectedReason:   gatewayv1.RouteReasonResolvedRefs,
+		},
+		{
+			name:             "default kind in another group is invalid",
+			refs:             []gatewayv1.BackendRef{otherGroup},
+			expectedResolved: false,
+			expectedReason:   gatewayv1.RouteReasonInvalidKind,
+		},
+		{
+			name:             "missing port is unsupported",
+			refs:             []gatewayv1.BackendRef{serviceRef("app", 80), noPort},
+			expectedResolved: false,
+			expectedReason:   gatewayv1.RouteReasonUnsupportedValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := CheckBackendRefs(tt.refs)
+			assert.Equal(t, tt.expectedResolved, status.Resolved)
+			assert.Equal(t, tt.expectedReason, status.Reason)
+			assert.NotEmpty(t, status.Message)
+		})
+	}
+}
+
+func TestSkippedBackendRefs(t *testing.T) {
+	t.Parallel()
+
+	unsupported := serviceRef("bucket", 80)
+	unsupported.Kind = ptrTo(gatewayv1.Kind("S3Bucket"))
+
+	httpRules := []gatewayv1.HTTPRouteRule{
+		{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}, {BackendRef: unsupported}}},
+		{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: unsupported}}},
+	}
+	grpcRules := []gatewayv1.GRPCRouteRule{
+		{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: serviceRef("echo", 50051)}}},
+	}
+
+	assert.Equal(t, 2, HTTPRouteSkippedBackendRefs(httpRules))
+	assert.Equal(t, 0, GRPCRouteSkippedBackendRefs(grpcRules))
+
+	noPort := serviceRef("echo", 50051)
+	noPort.Port = nil
+
+	grpcRules = append(grpcRules, gatewayv1.GRPCRouteRule{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: noPort}}})
+	assert.Equal(t, 1, GRPCRouteSkippedBackendRefs(grpcRules))
+}
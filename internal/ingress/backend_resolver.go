@@ -0,0 +1,145 @@
+package ingress
+
+import (
+	"net"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/referencegrant"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// IsPingoraBackendRef reports whether the backendRef references a
+// PingoraBackend.
+func IsPingoraBackendRef(ref *gatewayv1.BackendRef) bool {
+	return ref.Group != nil && string(*ref.Group) == v1alpha1.GroupVersion.Group &&
+		ref.Kind != nil && *ref.Kind == v1alpha1.PingoraBackendKind
+}
+
+// SetBackends replaces the PingoraBackends that backendRefs resolve to.
+// Call it before building routes so that backend changes take effect on
+// the next sync.
+func (b *PingoraBuilder) SetBackends(backends []v1alpha1.PingoraBackend) {
+	byName := make(map[types.NamespacedName]*routingv1.Backend, len(backends))
+
+	for i := range backends {
+		backend := &backends[i]
+		byName[types.NamespacedName{Namespace: backend.Namespace, Name: backend.Name}] = backendFromPingoraBackend(backend)
+	}
+
+	b.backendsMu.Lock()
+	defer b.backendsMu.Unlock()
+
+	b.backends = byName
+}
+
+// backendRefTargets are the Services and ReferenceGrants that backendRefs
+// are resolved against.
+type backendRefTargets struct {
+	services map[types.NamespacedName]bool
+	grants   []gatewayv1beta1.ReferenceGrant
+}
+
+// SetBackendRefTargets replaces the Services and ReferenceGrants that
+// backendRefs are resolved against. References to Services that do not
+// exist, and references to other namespaces that no ReferenceGrant permits,
+// are invalid: their share of requests fails like that of unsupported
+// kinds. Until it is called, every reference is resolved.
+func (b *PingoraBuilder) SetBackendRefTargets(services []corev1.Service, grants []gatewayv1beta1.ReferenceGrant) {
+	targets := &backendRefTargets{
+		services: make(map[types.NamespacedName]bool, len(services)),
+		grants:   grants,
+	}
+
+	for i := range services {
+		targets.services[types.NamespacedName{Namespace: services[i].Namespace, Name: services[i].Name}] = true
+	}
+
+	b.targetsMu.Lock()
+	defer b.targetsMu.Unlock()
+
+	b.targets = targets
+}
+
+// backendRefResolved reports whether a backendRef of a route of routeKind
+// in namespace references an object it may use. PingoraBackends that do not
+// exist are left to resolvePingoraBackend.
+func (b *PingoraBuilder) backendRefResolved(routeKind, namespace string, ref *gatewayv1.BackendRef) bool {
+	b.targetsMu.RLock()
+	defer b.targetsMu.RUnlock()
+
+	if b.targets == nil {
+		return true
+	}
+
+	target := referencegrant.Reference{
+		Kind:      kindService,
+		Namespace: namespace,
+		Name:      string(ref.Name),
+	}
+
+	if ref.Namespace != nil {
+		target.Namespace = string(*ref.Namespace)
+	}
+
+	if IsPingoraBackendRef(ref) {
+		target.Group, target.Kind = v1alpha1.GroupVersion.Group, v1alpha1.PingoraBackendKind
+	}
+
+	from := referencegrant.Reference{Group: gatewayv1.GroupName, Kind: routeKind, Namespace: namespace}
+	if !referencegrant.Permits(b.targets.grants, from, target) {
+		return false
+	}
+
+	return IsPingoraBackendRef(ref) || b.targets.services[types.NamespacedName{Namespace: target.Namespace, Name: target.Name}]
+}
+
+// resolvePingoraBackend returns the backend of a PingoraBackend reference,
+// or nil if the PingoraBackend does not exist or has no endpoints.
+func (b *PingoraBuilder) resolvePingoraBackend(namespace string, ref *gatewayv1.BackendRef) *routingv1.Backend {
+	b.backendsMu.RLock()
+	defer b.backendsMu.RUnlock()
+
+	backend, ok := b.backends[types.NamespacedName{Namespace: namespace, Name: string(ref.Name)}]
+	if !ok || backend == nil {
+		return nil
+	}
+
+	// The weight is set per backendRef, so every route gets its own copy
+	return proto.Clone(backend).(*routingv1.Backend) //nolint:forcetypeassert // Clone keeps the message type
+}
+
+// backendFromPingoraBackend converts the spec of a PingoraBackend, or
+// returns nil if it has no endpoints.
+func backendFromPingoraBackend(backend *v1alpha1.PingoraBackend) *routingv1.Backend {
+	if len(backend.Spec.Endpoints) == 0 {
+		return nil
+	}
+
+	result := &routingv1.Backend{
+		Protocol:  routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTP,
+		Endpoints: make([]string, 0, len(backend.Spec.Endpoints)),
+	}
+
+	for _, endpoint := range backend.Spec.Endpoints {
+		result.Endpoints = append(result.Endpoints, net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port))))
+	}
+
+	result.Address = result.GetEndpoints()[0]
+
+	if tls := backend.Spec.TLS; tls != nil {
+		result.Protocol = routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTPS
+		result.Tls = &routingv1.BackendTLS{
+			Sni:                tls.SNI,
+			InsecureSkipVerify: tls.InsecureSkipVerify,
+		}
+	}
+
+	return result
+}
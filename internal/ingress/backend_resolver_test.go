@@ -0,0 +1,251 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func pingoraBackendRef(name string) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Group: ptrTo(gatewayv1.Group(v1alpha1.GroupVersion.Group)),
+			Kind:  ptrTo(gatewayv1.Kind(v1alpha1.PingoraBackendKind)),
+			Name:  gatewayv1.ObjectName(name),
+		},
+	}
+}
+
+func TestIsPingoraBackendRef(t *testing.T) {
+	t.Parallel()
+
+	otherGroup := pingoraBackendRef("external")
+	otherGroup.Group = ptrTo(gatewayv1.Group("example.com"))
+
+	assert.True(t, IsPingoraBackendRef(ptrTo(pingoraBackendRef("external"))))
+	assert.False(t, IsPingoraBackendRef(ptrTo(serviceRef("app", 80))))
+	assert.False(t, IsPingoraBackendRef(&otherGroup))
+}
+
+func TestBuildHTTPRoute_PingoraBackend(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetBackends([]v1alpha1.PingoraBackend{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "static", Namespace: "default"},
+			Spec: v1alpha1.PingoraBackendSpec{
+				Endpoints: []v1alpha1.BackendEndpoint{
+					{Host: "192.0.2.10", Port: 8080},
+					{Host: "2001:db8::1", Port: 8080},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "saas", Namespace: "other"},
+			Spec: v1alpha1.PingoraBackendSpec{
+				Endpoints: []v1alpha1.BackendEndpoint{{Host: "api.example.com", Port: 443}},
+				TLS:       &v1alpha1.BackendTLS{SNI: "api.example.com"},
+			},
+		},
+	})
+
+	otherNamespace := gatewayv1.Namespace("other")
+	crossNamespace := withWeight(pingoraBackendRef("saas"), 3)
+	crossNamespace.Namespace = &otherNamespace
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{
+			{BackendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: pingoraBackendRef("static")},
+				{BackendRef: crossNamespace},
+				{BackendRef: serviceRef("app", 80)},
+			}},
+			// A missing PingoraBackend is answered with 500, as any invalid backendRef
+			{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: pingoraBackendRef("missing")}}},
+		}},
+	}
+
+	result := builder.BuildHTTPRoute(route)
+	require.Len(t, result.GetRules(), 2)
+
+	want := []*routingv1.Backend{
+		{
+			Address:   "192.0.2.10:8080",
+			Weight:    1,
+			Protocol:  routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTP,
+			Endpoints: []string{"192.0.2.10:8080", "[2001:db8::1]:8080"},
+		},
+		{
+			Address:   "api.example.com:443",
+			Weight:    3,
+			Protocol:  routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTPS,
+			Endpoints: []string{"api.example.com:443"},
+			Tls:       &routingv1.BackendTLS{Sni: "api.example.com"},
+		},
+		{
+			Address:  "app.default.svc.cluster.local:80",
+			Weight:   1,
+			Protocol: routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTP,
+		},
+	}
+
+	backends := result.GetRules()[0].GetBackends()
+	require.Len(t, backends, len(want))
+
+	for i := range want {
+		assert.True(t, proto.Equal(want[i], backends[i]), "backend %d: %v", i, backends[i])
+	}
+
+	missing := result.GetRules()[1]
+	assert.Empty(t, missing.GetBackends())
+	require.NotNil(t, missing.GetFixedResponse())
+	assert.Equal(t, uint32(NoBackendsStatusCode), missing.GetFixedResponse().GetStatusCode())
+
+	// Removed PingoraBackends are no longer resolved
+	builder.SetBackends(nil)
+	assert.Len(t, builder.BuildHTTPRoute(route).GetRules()[0].GetBackends(), 1)
+}
+
+func TestBuildHTTPRoute_BackendRefTargets(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetBackendRefTargets(
+		[]corev1.Service{
+			{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "granted"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "private"}},
+		},
+		[]gatewayv1beta1.ReferenceGrant{{
+			ObjectMeta: metav1.ObjectMeta{Name: "routes", Namespace: "granted"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: kindHTTPRoute, Namespace: "default"}},
+				To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: kindService}},
+			},
+		}},
+	)
+
+	inNamespace := func(ref gatewayv1.BackendRef, namespace string) gatewayv1.BackendRef {
+		ref.Namespace = ptrTo(gatewayv1.Namespace(namespace))
+
+		return ref
+	}
+
+	tests := []struct {
+		name                  string
+		backendRefs           []gatewayv1.HTTPBackendRef
+		expectedBackends      int
+		expectedInvalidWeight uint32
+	}{
+		{
+			name: "missing Service fails its share",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: withWeight(serviceRef("app", 80), 70)},
+				{BackendRef: withWeight(serviceRef("missing", 80), 30)},
+			},
+			expectedBackends:      1,
+			expectedInvalidWeight: 30,
+		},
+		{
+			name: "cross-namespace Service permitted by a ReferenceGrant",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: serviceRef("app", 80)},
+				{BackendRef: inNamespace(serviceRef("app", 80), "granted")},
+			},
+			expectedBackends:      2,
+			expectedInvalidWeight: 0,
+		},
+		{
+			name: "cross-namespace Service without a ReferenceGrant fails its share",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: withWeight(serviceRef("app", 80), 2)},
+				{BackendRef: withWeight(inNamespace(serviceRef("app", 80), "private"), 3)},
+			},
+			expectedBackends:      1,
+			expectedInvalidWeight: 3,
+		},
+		{
+			name: "missing Service with weight 0 fails no requests",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: serviceRef("app", 80)},
+				{BackendRef: withWeight(serviceRef("missing", 80), 0)},
+			},
+			expectedBackends:      1,
+			expectedInvalidWeight: 0,
+		},
+		{
+			name: "valid Service with weight 0 next to a missing one",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: withWeight(serviceRef("app", 80), 0)},
+				{BackendRef: serviceRef("missing", 80)},
+			},
+			expectedBackends:      0,
+			expectedInvalidWeight: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					Rules: []gatewayv1.HTTPRouteRule{{BackendRefs: tt.backendRefs}},
+				},
+			}
+
+			rule := builder.BuildHTTPRoute(route).GetRules()[0]
+			assert.Len(t, rule.GetBackends(), tt.expectedBackends)
+			assert.Equal(t, tt.expectedInvalidWeight, rule.GetInvalidBackendWeight())
+
+			if tt.expectedBackends == 0 {
+				assert.Equal(t, uint32(NoBackendsStatusCode), rule.GetFixedResponse().GetStatusCode())
+			} else {
+				assert.Nil(t, rule.GetFixedResponse())
+			}
+		})
+	}
+}
+
+func TestBuildGRPCRoute_BackendRefTargets(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetBackendRefTargets(
+		[]corev1.Service{{ObjectMeta: metav1.ObjectMeta{Name: "grpc", Namespace: "granted"}}},
+		// The grant covers HTTPRoutes only
+		[]gatewayv1beta1.ReferenceGrant{{
+			ObjectMeta: metav1.ObjectMeta{Name: "routes", Namespace: "granted"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: kindHTTPRoute, Namespace: "default"}},
+				To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: kindService}},
+			},
+		}},
+	)
+
+	ref := withWeight(serviceRef("grpc", 9090), 4)
+	ref.Namespace = ptrTo(gatewayv1.Namespace("granted"))
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: ref}}}},
+		},
+	}
+
+	rule := builder.BuildGRPCRoute(route).GetRules()[0]
+	assert.Empty(t, rule.GetBackends())
+	assert.Equal(t, uint32(NoBackendsStatusCode), rule.GetFixedResponse().GetStatusCode())
+}
@@ -0,0 +1,91 @@
+package ingress
+
+import (
+	"google.golang.org/protobuf/proto"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// ResolvedBackendTLSPolicy is a BackendTLSPolicy with the CA certificates
+// that its caCertificateRefs resolve to.
+type ResolvedBackendTLSPolicy struct {
+	Policy *gatewayv1.BackendTLSPolicy
+
+	// CACertificates are the PEM-encoded CA certificates backend
+	// certificates are verified against. Empty uses the system roots.
+	CACertificates []byte
+
+	// Invalid is set if the CA certificates could not be resolved or the
+	// policy requires a verification the proxy does not support.
+	Invalid bool
+}
+
+// backendTLSPolicy adapts a BackendTLSPolicy to AttachedPolicy.
+type backendTLSPolicy struct {
+	*gatewayv1.BackendTLSPolicy
+}
+
+// GetTargetRefs returns the Services the policy is attached to.
+func (p backendTLSPolicy) GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	return p.Spec.TargetRefs
+}
+
+// SetBackendTLSPolicies replaces the BackendTLSPolicies applied to Service
+// backends. Call it before building routes so that policy changes take
+// effect on the next sync.
+//
+// Only policies targeting whole Services are applied. A Service whose
+// policy is invalid gets no backend, so that its traffic is never sent
+// without the verification the policy asks for.
+func (b *PingoraBuilder) SetBackendTLSPolicies(policies []ResolvedBackendTLSPolicy) {
+	attached := make([]backendTLSPolicy, 0, len(policies))
+	byPolicy := make(map[*gatewayv1.BackendTLSPolicy]*ResolvedBackendTLSPolicy, len(policies))
+
+	for i := range policies {
+		attached = append(attached, backendTLSPolicy{policies[i].Policy})
+		byPolicy[policies[i].Policy] = &policies[i]
+	}
+
+	active := ActivePolicies(attached)
+	byTarget := make(map[PolicyTarget]*routingv1.BackendTLS, len(active))
+
+	for target, policy := range active {
+		if target.Kind != PolicyTargetService || target.SectionName != "" {
+			continue
+		}
+
+		resolved := byPolicy[policy.BackendTLSPolicy]
+		if resolved.Invalid {
+			byTarget[target] = nil
+
+			continue
+		}
+
+		byTarget[target] = &routingv1.BackendTLS{
+			Sni:            string(policy.Spec.Validation.Hostname),
+			CaCertificates: resolved.CACertificates,
+		}
+	}
+
+	b.backendTLSMu.Lock()
+	defer b.backendTLSMu.Unlock()
+
+	b.backendTLS = byTarget
+}
+
+// backendTLSFor returns the TLS settings of a Service backend. The boolean
+// is false if no BackendTLSPolicy applies to the Service; the settings are
+// nil if the policy applying to it is invalid.
+func (b *PingoraBuilder) backendTLSFor(namespace, name string) (*routingv1.BackendTLS, bool) {
+	b.backendTLSMu.RLock()
+	defer b.backendTLSMu.RUnlock()
+
+	tls, ok := b.backendTLS[PolicyTarget{Kind: PolicyTargetService, Namespace: namespace, Name: name}]
+	if !ok || tls == nil {
+		return nil, ok
+	}
+
+	// Backends are sent per route, so every one gets its own copy
+	return proto.Clone(tls).(*routingv1.BackendTLS), true //nolint:forcetypeassert // Clone keeps the message type
+}
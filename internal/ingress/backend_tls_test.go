@@ -0,0 +1,80 @@
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestBuildHTTPRoute_BackendTLSPolicy(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tlsPolicy := func(name string, age time.Duration, hostname string, targets ...string) *gatewayv1.BackendTLSPolicy {
+		policy := &gatewayv1.BackendTLSPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: "default", CreationTimestamp: metav1.NewTime(created.Add(-age)),
+			},
+			Spec: gatewayv1.BackendTLSPolicySpec{
+				Validation: gatewayv1.BackendTLSPolicyValidation{Hostname: gatewayv1.PreciseHostname(hostname)},
+			},
+		}
+
+		for _, target := range targets {
+			policy.Spec.TargetRefs = append(policy.Spec.TargetRefs, serviceTargetRef(target))
+		}
+
+		return policy
+	}
+
+	section := serviceTargetRef("web")
+	section.SectionName = ptrTo(gatewayv1.SectionName("https"))
+
+	portPolicy := tlsPolicy("port", time.Hour, "web.example.com")
+	portPolicy.Spec.TargetRefs = append(portPolicy.Spec.TargetRefs, section)
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetBackendTLSPolicies([]ResolvedBackendTLSPolicy{
+		{Policy: tlsPolicy("newer", time.Minute, "newer.example.com", "api")},
+		{Policy: tlsPolicy("older", time.Hour, "api.example.com", "api"), CACertificates: []byte("ca")},
+		{Policy: tlsPolicy("broken", time.Hour, "db.example.com", "db"), Invalid: true},
+		{Policy: portPolicy},
+	})
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{
+			BackendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: serviceRef("api", 443)},
+				{BackendRef: serviceRef("web", 80)},
+				{BackendRef: serviceRef("db", 443)},
+			},
+		}}},
+	}
+
+	result := builder.BuildHTTPRoute(route)
+	require.Len(t, result.GetRules(), 1)
+
+	rule := result.GetRules()[0]
+	backends := rule.GetBackends()
+	require.Len(t, backends, 2)
+
+	// The oldest policy wins
+	assert.Equal(t, routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTPS, backends[0].GetProtocol())
+	assert.True(t, proto.Equal(&routingv1.BackendTLS{Sni: "api.example.com", CaCertificates: []byte("ca")},
+		backends[0].GetTls()))
+
+	// Policies on a Service port are not applied
+	assert.Equal(t, routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTP, backends[1].GetProtocol())
+	assert.Nil(t, backends[1].GetTls())
+
+	// Traffic to a Service with an invalid policy is never sent in plaintext
+	assert.Equal(t, uint32(1), rule.GetInvalidBackendWeight())
+}
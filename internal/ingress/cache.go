@@ -0,0 +1,110 @@
+package ingress
+
+import (
+	"github.com/cockroachdb/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// SetCachePolicies replaces the PingoraCachePolicies applied to routes. Call
+// it before building routes so that policy changes take effect on the next
+// sync. The targets of invalid policies are not cached.
+func (b *PingoraBuilder) SetCachePolicies(policies []v1alpha1.PingoraCachePolicy) {
+	active := ActivePolicies(policyPointers(policies))
+	byTarget := make(map[PolicyTarget]*routingv1.CacheConfig, len(active))
+
+	for target, policy := range active {
+		// An invalid policy on a rule still overrides the route policy
+		var cache *routingv1.CacheConfig
+		if ValidateCache(&policy.Spec) == nil {
+			cache = cacheFromPolicy(policy)
+		}
+
+		byTarget[target] = cache
+	}
+
+	b.cacheMu.Lock()
+	defer b.cacheMu.Unlock()
+
+	b.cachePolicies = byTarget
+}
+
+// cacheFor returns the cache config of an HTTPRoute rule. A policy on the
+// named rule takes precedence over one on the whole route.
+func (b *PingoraBuilder) cacheFor(route *gatewayv1.HTTPRoute, ruleName string) *routingv1.CacheConfig {
+	b.cacheMu.RLock()
+	defer b.cacheMu.RUnlock()
+
+	if len(b.cachePolicies) == 0 {
+		return nil
+	}
+
+	routeTarget := PolicyTarget{Kind: PolicyTargetHTTPRoute, Namespace: route.Namespace, Name: route.Name}
+
+	if ruleName != "" {
+		ruleTarget := routeTarget
+		ruleTarget.SectionName = ruleName
+
+		if cache, ok := b.cachePolicies[ruleTarget]; ok {
+			return cache
+		}
+	}
+
+	return b.cachePolicies[routeTarget]
+}
+
+// ValidateCache reports why a cache policy cannot be applied, nil if it can.
+func ValidateCache(spec *v1alpha1.PingoraCachePolicySpec) error {
+	if durationMs(&spec.TTL) == 0 {
+		return errors.Newf("ttl %q must be a positive duration", spec.TTL)
+	}
+
+	return nil
+}
+
+func cacheFromPolicy(policy *v1alpha1.PingoraCachePolicy) *routingv1.CacheConfig {
+	spec := &policy.Spec
+	result := &routingv1.CacheConfig{
+		Id:          policy.Namespace + "/" + policy.Name,
+		TtlMs:       durationMs(&spec.TTL),
+		Key:         &routingv1.CacheKey{},
+		VaryHeaders: headerNames(spec.VaryHeaders),
+		Bypass:      make([]*routingv1.CacheBypass, 0, len(spec.Bypass)),
+	}
+
+	if spec.Key != nil {
+		result.Key = &routingv1.CacheKey{
+			IgnoreQuery:     spec.Key.IgnoreQuery,
+			QueryParameters: append([]string(nil), spec.Key.QueryParameters...),
+			Headers:         headerNames(spec.Key.Headers),
+			Cookies:         append([]string(nil), spec.Key.Cookies...),
+		}
+	}
+
+	for _, rule := range spec.Bypass {
+		bypass := &routingv1.CacheBypass{
+			Type:  routingv1.CacheBypassType_CACHE_BYPASS_TYPE_HEADER,
+			Name:  rule.Name,
+			Value: rule.Value,
+		}
+
+		if rule.Type == v1alpha1.CacheBypassTypeCookie {
+			bypass.Type = routingv1.CacheBypassType_CACHE_BYPASS_TYPE_COOKIE
+		}
+
+		result.Bypass = append(result.Bypass, bypass)
+	}
+
+	return result
+}
+
+func headerNames(names []gatewayv1.HTTPHeaderName) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		result = append(result, string(name))
+	}
+
+	return result
+}
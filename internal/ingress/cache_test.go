@@ -0,0 +1,120 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func cachePolicy(name, sectionName, ttl string) v1alpha1.PingoraCachePolicy {
+	return v1alpha1.PingoraCachePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.PingoraCachePolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				policyTargetRef(PolicyTargetHTTPRoute, "app", sectionName),
+			},
+			TTL: gatewayv1.Duration(ttl),
+		},
+	}
+}
+
+func TestBuildHTTPRoute_Cache(t *testing.T) {
+	t.Parallel()
+
+	assets := cachePolicy("assets", "assets", "1h")
+	assets.Spec.Key = &v1alpha1.CacheKey{
+		QueryParameters: []string{"v"},
+		Headers:         []gatewayv1.HTTPHeaderName{"X-Tenant"},
+		Cookies:         []string{"locale"},
+	}
+	assets.Spec.VaryHeaders = []gatewayv1.HTTPHeaderName{"Accept-Encoding"}
+	assets.Spec.Bypass = []v1alpha1.CacheBypassRule{
+		{Type: v1alpha1.CacheBypassTypeHeader, Name: "Authorization"},
+		{Type: v1alpha1.CacheBypassTypeCookie, Name: "preview", Value: "true"},
+	}
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetCachePolicies([]v1alpha1.PingoraCachePolicy{
+		cachePolicy("route", "", "30s"),
+		assets,
+		cachePolicy("zero", "zero", "0s"),
+	})
+
+	tests := []struct {
+		name     string
+		route    string
+		rule     string
+		expected *routingv1.CacheConfig
+	}{
+		{
+			name:  "route policy with default key",
+			route: "app",
+			expected: &routingv1.CacheConfig{
+				Id:    "default/route",
+				TtlMs: 30000,
+				Key:   &routingv1.CacheKey{},
+			},
+		},
+		{
+			name:  "rule policy with key, vary headers and bypass rules",
+			route: "app",
+			rule:  "assets",
+			expected: &routingv1.CacheConfig{
+				Id:    "default/assets",
+				TtlMs: 3600000,
+				Key: &routingv1.CacheKey{
+					QueryParameters: []string{"v"},
+					Headers:         []string{"X-Tenant"},
+					Cookies:         []string{"locale"},
+				},
+				VaryHeaders: []string{"Accept-Encoding"},
+				Bypass: []*routingv1.CacheBypass{
+					{Type: routingv1.CacheBypassType_CACHE_BYPASS_TYPE_HEADER, Name: "Authorization"},
+					{Type: routingv1.CacheBypassType_CACHE_BYPASS_TYPE_COOKIE, Name: "preview", Value: "true"},
+				},
+			},
+		},
+		{
+			// The invalid rule policy disables caching rather than falling
+			// back to the route policy
+			name:  "rule policy with zero TTL",
+			route: "app",
+			rule:  "zero",
+		},
+		{
+			name:  "untargeted route",
+			route: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := gatewayv1.HTTPRouteRule{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+			}
+			if tt.rule != "" {
+				rule.Name = ptrTo(gatewayv1.SectionName(tt.rule))
+			}
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.route, Namespace: "default"},
+				Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{rule}},
+			}
+
+			result := builder.BuildHTTPRoute(route)
+			require.Len(t, result.GetRules(), 1)
+
+			cache := result.GetRules()[0].GetCache()
+			assert.True(t, proto.Equal(tt.expected, cache), "cache: %v", cache)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package ingress
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Annotations on a Gateway that request cert-manager Certificates for the
+// Secrets of its HTTPS listeners, named after the ClusterIssuer or the
+// Issuer in the namespace of the Gateway. The ClusterIssuer wins if both
+// are set.
+const (
+	CertManagerClusterIssuerAnnotation = "cert-manager.io/cluster-issuer"
+	CertManagerIssuerAnnotation        = "cert-manager.io/issuer"
+)
+
+// CertManagerGatewayLabel marks the Certificates the controller created,
+// with the name of the Gateway that asked for them. Certificates without
+// it, such as those of the cert-manager gateway-shim, are left alone.
+const CertManagerGatewayLabel = "pingora.k8s.lex.la/gateway"
+
+// CertManagerCertificateGVK is the kind of cert-manager Certificates. They
+// are handled as unstructured objects, so that cert-manager is only needed
+// when a Gateway asks for Certificates.
+var CertManagerCertificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+// CertManagerIssuer returns the kind and name of the issuer a Gateway asks
+// for. ok is false if the Gateway has no issuer annotation.
+func CertManagerIssuer(gateway *gatewayv1.Gateway) (kind, name string, ok bool) {
+	if name := gateway.Annotations[CertManagerClusterIssuerAnnotation]; name != "" {
+		return "ClusterIssuer", name, true
+	}
+
+	if name := gateway.Annotations[CertManagerIssuerAnnotation]; name != "" {
+		return "Issuer", name, true
+	}
+
+	return "", "", false
+}
+
+// CertManagerCertificates returns the Certificates a Gateway asks for, one
+// for every Secret its HTTPS listeners reference in its own namespace, in
+// listener order. The DNS names of a Certificate are the hostnames of the
+// listeners sharing the Secret. Listeners without a hostname are left out,
+// as there is no name to issue a certificate for. The Certificates are
+// owned by the Gateway, so that they are deleted with it, and carry
+// CertManagerGatewayLabel.
+func CertManagerCertificates(gateway *gatewayv1.Gateway) []*unstructured.Unstructured {
+	issuerKind, issuerName, ok := CertManagerIssuer(gateway)
+	if !ok {
+		return nil
+	}
+
+	var (
+		secrets   []string
+		dnsNames  = make(map[string][]string)
+		isGateway = true
+	)
+
+	for _, ref := range ListenerCertificateRefs(gateway) {
+		if ref.Namespace != gateway.Namespace || ref.Hostname == nil || *ref.Hostname == "" {
+			continue
+		}
+
+		if _, seen := dnsNames[ref.Name]; !seen {
+			secrets = append(secrets, ref.Name)
+		}
+
+		if !slices.Contains(dnsNames[ref.Name], string(*ref.Hostname)) {
+			dnsNames[ref.Name] = append(dnsNames[ref.Name], string(*ref.Hostname))
+		}
+	}
+
+	certificates := make([]*unstructured.Unstructured, 0, len(secrets))
+
+	for _, secret := range secrets {
+		certificate := &unstructured.Unstructured{}
+		certificate.SetGroupVersionKind(CertManagerCertificateGVK)
+		certificate.SetNamespace(gateway.Namespace)
+		certificate.SetName(secret)
+		certificate.SetLabels(map[string]string{CertManagerGatewayLabel: gateway.Name})
+		// Without BlockOwnerDeletion, which would need the update permission
+		// on gateways/finalizers
+		certificate.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "Gateway",
+			Name:       gateway.Name,
+			UID:        gateway.UID,
+			Controller: &isGateway,
+		}})
+
+		names := make([]any, 0, len(dnsNames[secret]))
+		for _, name := range dnsNames[secret] {
+			names = append(names, name)
+		}
+
+		certificate.Object["spec"] = map[string]any{
+			"secretName": secret,
+			"dnsNames":   names,
+			"issuerRef": map[string]any{
+				"group": CertManagerCertificateGVK.Group,
+				"kind":  issuerKind,
+				"name":  issuerName,
+			},
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates
+}
+
+// OwnsCertManagerCertificate reports whether an existing Certificate was
+// created by the controller for gateway: it carries CertManagerGatewayLabel
+// with the name of the Gateway and is controlled by it.
+func OwnsCertManagerCertificate(gateway *gatewayv1.Gateway, certificate metav1.Object) bool {
+	if certificate.GetLabels()[CertManagerGatewayLabel] != gateway.Name {
+		return false
+	}
+
+	owner := metav1.GetControllerOfNoCopy(certificate)
+
+	return owner != nil && owner.Kind == "Gateway" && owner.Name == gateway.Name && owner.UID == gateway.UID
+}
@@ -0,0 +1,172 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestCertManagerIssuer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		expectedKind string
+		expectedName string
+		expectedOK   bool
+	}{
+		{name: "no annotation"},
+		{
+			name:         "cluster issuer",
+			annotations:  map[string]string{CertManagerClusterIssuerAnnotation: "letsencrypt"},
+			expectedKind: "ClusterIssuer",
+			expectedName: "letsencrypt",
+			expectedOK:   true,
+		},
+		{
+			name:         "issuer",
+			annotations:  map[string]string{CertManagerIssuerAnnotation: "internal-ca"},
+			expectedKind: "Issuer",
+			expectedName: "internal-ca",
+			expectedOK:   true,
+		},
+		{
+			name: "cluster issuer wins",
+			annotations: map[string]string{
+				CertManagerClusterIssuerAnnotation: "letsencrypt",
+				CertManagerIssuerAnnotation:        "internal-ca",
+			},
+			expectedKind: "ClusterIssuer",
+			expectedName: "letsencrypt",
+			expectedOK:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+
+			kind, name, ok := CertManagerIssuer(gateway)
+			assert.Equal(t, tt.expectedKind, kind)
+			assert.Equal(t, tt.expectedName, name)
+			assert.Equal(t, tt.expectedOK, ok)
+		})
+	}
+}
+
+func TestCertManagerCertificates(t *testing.T) {
+	t.Parallel()
+
+	app := gatewayv1.Hostname("app.example.com")
+	www := gatewayv1.Hostname("www.example.com")
+	certs := gatewayv1.Namespace("certs")
+
+	https := func(name string, port gatewayv1.PortNumber, hostname *gatewayv1.Hostname, ref gatewayv1.SecretObjectReference) gatewayv1.Listener {
+		return gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(name),
+			Protocol: gatewayv1.HTTPSProtocolType,
+			Port:     port,
+			Hostname: hostname,
+			TLS:      &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{ref}},
+		}
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        "gateway",
+			UID:         "gateway-uid",
+			Annotations: map[string]string{CertManagerClusterIssuerAnnotation: "letsencrypt"},
+		},
+		Spec: gatewayv1.GatewaySpec{Listeners: []gatewayv1.Listener{
+			https("app", 443, &app, gatewayv1.SecretObjectReference{Name: "web-tls"}),
+			https("www", 443, &www, gatewayv1.SecretObjectReference{Name: "web-tls"}),
+			https("app-alt", 8443, &app, gatewayv1.SecretObjectReference{Name: "web-tls"}),
+			// No hostname to issue for
+			https("any", 9443, nil, gatewayv1.SecretObjectReference{Name: "any-tls"}),
+			// Secrets in other namespaces are not issued
+			https("shared", 10443, &app, gatewayv1.SecretObjectReference{Name: "shared-tls", Namespace: &certs}),
+		}},
+	}
+
+	certificates := CertManagerCertificates(gateway)
+	require.Len(t, certificates, 1)
+
+	certificate := certificates[0]
+	assert.Equal(t, CertManagerCertificateGVK, certificate.GroupVersionKind())
+	assert.Equal(t, "default", certificate.GetNamespace())
+	assert.Equal(t, "web-tls", certificate.GetName())
+
+	owners := certificate.GetOwnerReferences()
+	require.Len(t, owners, 1)
+	assert.Equal(t, "Gateway", owners[0].Kind)
+	assert.Equal(t, "gateway", owners[0].Name)
+	assert.Equal(t, "gateway-uid", string(owners[0].UID))
+	assert.Nil(t, owners[0].BlockOwnerDeletion)
+	assert.Equal(t, "gateway", certificate.GetLabels()[CertManagerGatewayLabel])
+	assert.True(t, OwnsCertManagerCertificate(gateway, certificate))
+
+	secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+	assert.Equal(t, "web-tls", secretName)
+
+	dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+	assert.Equal(t, []string{"app.example.com", "www.example.com"}, dnsNames)
+
+	issuerRef, _, _ := unstructured.NestedStringMap(certificate.Object, "spec", "issuerRef")
+	assert.Equal(t, map[string]string{"group": "cert-manager.io", "kind": "ClusterIssuer", "name": "letsencrypt"}, issuerRef)
+
+	gateway.Annotations = nil
+	assert.Empty(t, CertManagerCertificates(gateway))
+}
+
+func TestOwnsCertManagerCertificate(t *testing.T) {
+	t.Parallel()
+
+	gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway", UID: "gateway-uid"}}
+	isController := true
+
+	certificate := func(label string, uid types.UID) *metav1.ObjectMeta {
+		meta := &metav1.ObjectMeta{Namespace: "default", Name: "web-tls"}
+		if label != "" {
+			meta.Labels = map[string]string{CertManagerGatewayLabel: label}
+		}
+
+		meta.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: gatewayv1.GroupVersion.String(),
+			Kind:       "Gateway",
+			Name:       "gateway",
+			UID:        uid,
+			Controller: &isController,
+		}}
+
+		return meta
+	}
+
+	tests := []struct {
+		name        string
+		certificate *metav1.ObjectMeta
+		expected    bool
+	}{
+		{name: "created for the gateway", certificate: certificate("gateway", "gateway-uid"), expected: true},
+		{name: "created by the gateway-shim", certificate: certificate("", "gateway-uid"), expected: false},
+		{name: "labeled for another gateway", certificate: certificate("other", "gateway-uid"), expected: false},
+		{name: "owned by a recreated gateway", certificate: certificate("gateway", "old-uid"), expected: false},
+		{name: "without owner", certificate: &metav1.ObjectMeta{Labels: map[string]string{CertManagerGatewayLabel: "gateway"}}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, OwnsCertManagerCertificate(gateway, tt.certificate))
+		})
+	}
+}
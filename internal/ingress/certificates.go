@@ -0,0 +1,143 @@
+package ingress
+
+import (
+	"cmp"
+	"crypto/tls"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// ListenerCertificateRef is a Secret an HTTPS listener terminates TLS with.
+type ListenerCertificateRef struct {
+	// Port and Hostname are those of the listener.
+	Port     uint32
+	Hostname *gatewayv1.Hostname
+
+	// Namespace and Name identify the Secret.
+	Namespace string
+	Name      string
+
+	// FrontendValidation is the client certificate validation of the
+	// listener, nil if it requests no client certificate.
+	FrontendValidation *gatewayv1.FrontendTLSValidation
+}
+
+// ListenerCertificateRefs returns the Secrets referenced by the HTTPS
+// listeners of a Gateway that terminate TLS, in listener order. References
+// to other kinds than core Secrets are skipped.
+func ListenerCertificateRefs(gateway *gatewayv1.Gateway) []ListenerCertificateRef {
+	var refs []ListenerCertificateRef
+
+	for i := range gateway.Spec.Listeners {
+		listener := &gateway.Spec.Listeners[i]
+		if listener.Protocol != gatewayv1.HTTPSProtocolType || listener.TLS == nil {
+			continue
+		}
+
+		if listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1.TLSModeTerminate {
+			continue
+		}
+
+		for _, certRef := range listener.TLS.CertificateRefs {
+			if !IsSecretRef(certRef) {
+				continue
+			}
+
+			namespace := gateway.Namespace
+			if certRef.Namespace != nil {
+				namespace = string(*certRef.Namespace)
+			}
+
+			refs = append(refs, ListenerCertificateRef{
+				Port:               uint32(listener.Port),
+				Hostname:           listener.Hostname,
+				Namespace:          namespace,
+				Name:               string(certRef.Name),
+				FrontendValidation: FrontendValidation(gateway, listener),
+			})
+		}
+	}
+
+	return refs
+}
+
+// IsSecretRef reports whether a certificateRef references a core Secret.
+func IsSecretRef(ref gatewayv1.SecretObjectReference) bool {
+	return (ref.Group == nil || *ref.Group == "") && (ref.Kind == nil || *ref.Kind == "Secret")
+}
+
+// SortGatewaysByCreation orders Gateways by creation time, oldest first,
+// and by namespace and name on ties, which is the listener order of
+// certificate selection.
+func SortGatewaysByCreation(gateways []gatewayv1.Gateway) {
+	slices.SortStableFunc(gateways, func(a, b gatewayv1.Gateway) int {
+		return cmp.Or(
+			a.CreationTimestamp.Compare(b.CreationTimestamp.Time),
+			cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+}
+
+// CertificateFromSecret converts a Secret of type kubernetes.io/tls to a
+// certificate for the given hostnames. The certificate chain and private
+// key of the Secret must belong together.
+func CertificateFromSecret(secret *corev1.Secret, hostnames []string) (*routingv1.Certificate, error) {
+	name := secret.Namespace + "/" + secret.Name
+
+	if secret.Type != corev1.SecretTypeTLS {
+		return nil, errors.Newf("secret %s is of type %q, not %q", name, secret.Type, corev1.SecretTypeTLS)
+	}
+
+	chain := secret.Data[corev1.TLSCertKey]
+	key := secret.Data[corev1.TLSPrivateKeyKey]
+
+	if _, err := tls.X509KeyPair(chain, key); err != nil {
+		return nil, errors.Wrapf(err, "secret %s holds no valid certificate", name)
+	}
+
+	return &routingv1.Certificate{
+		Name:             name,
+		Hostnames:        hostnames,
+		CertificateChain: chain,
+		PrivateKey:       key,
+	}, nil
+}
+
+// CertificateSelectionFromConfig returns the certificate selection order of
+// the listener TLS settings of a PingoraConfig, most specific first if
+// unset.
+func CertificateSelectionFromConfig(config *v1alpha1.ListenerTLSConfig) routingv1.CertificateSelection {
+	if config != nil && config.CertificateSelection == v1alpha1.CertificateSelectionListenerOrder {
+		return routingv1.CertificateSelection_CERTIFICATE_SELECTION_LISTENER_ORDER
+	}
+
+	return routingv1.CertificateSelection_CERTIFICATE_SELECTION_MOST_SPECIFIC
+}
+
+// AddListenerCertificate adds a certificate to the certificates of a port,
+// keeping the listeners ordered by port and the certificates of a port in
+// the order they were added.
+func AddListenerCertificate(
+	listeners []*routingv1.ListenerCertificates,
+	port uint32,
+	certificate *routingv1.Certificate,
+) []*routingv1.ListenerCertificates {
+	index, found := slices.BinarySearchFunc(listeners, port, func(listener *routingv1.ListenerCertificates, port uint32) int {
+		return cmp.Compare(listener.GetPort(), port)
+	})
+
+	if !found {
+		listeners = slices.Insert(listeners, index, &routingv1.ListenerCertificates{Port: port})
+	}
+
+	listeners[index].Certificates = append(listeners[index].Certificates, certificate)
+
+	return listeners
+}
@@ -0,0 +1,199 @@
+package ingress
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestListenerCertificateRefs(t *testing.T) {
+	t.Parallel()
+
+	app := gatewayv1.Hostname("app.example.com")
+	certs := gatewayv1.Namespace("certs")
+	passthrough := gatewayv1.TLSModePassthrough
+	configMap := gatewayv1.Kind("ConfigMap")
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+		Spec: gatewayv1.GatewaySpec{Listeners: []gatewayv1.Listener{
+			{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+			{
+				Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443, Hostname: &app,
+				TLS: &gatewayv1.ListenerTLSConfig{CertificateRefs: []gatewayv1.SecretObjectReference{
+					{Name: "app-tls"},
+					{Name: "app-ecdsa", Namespace: &certs},
+					{Name: "not-a-secret", Kind: &configMap},
+				}},
+			},
+			{
+				Name: "passthrough", Protocol: gatewayv1.HTTPSProtocolType, Port: 8443,
+				TLS: &gatewayv1.ListenerTLSConfig{
+					Mode:            &passthrough,
+					CertificateRefs: []gatewayv1.SecretObjectReference{{Name: "ignored"}},
+				},
+			},
+		}},
+	}
+
+	assert.Equal(t, []ListenerCertificateRef{
+		{Port: 443, Hostname: &app, Namespace: "default", Name: "app-tls"},
+		{Port: 443, Hostname: &app, Namespace: "certs", Name: "app-ecdsa"},
+	}, ListenerCertificateRefs(gateway))
+}
+
+func TestSortGatewaysByCreation(t *testing.T) {
+	t.Parallel()
+
+	created := metav1.NewTime(time.Now())
+	gateway := func(namespace, name string, creation metav1.Time) gatewayv1.Gateway {
+		return gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace, Name: name, CreationTimestamp: creation,
+		}}
+	}
+
+	gateways := []gatewayv1.Gateway{
+		gateway("default", "newest", metav1.NewTime(created.Add(time.Minute))),
+		gateway("default", "b", created),
+		gateway("other", "a", created),
+		gateway("default", "a", created),
+	}
+
+	SortGatewaysByCreation(gateways)
+
+	names := make([]string, 0, len(gateways))
+	for i := range gateways {
+		names = append(names, gateways[i].Namespace+"/"+gateways[i].Name)
+	}
+
+	assert.Equal(t, []string{"default/a", "default/b", "other/a", "default/newest"}, names)
+}
+
+func TestCertificateFromSecret(t *testing.T) {
+	t.Parallel()
+
+	cert, key := generateKeyPair(t)
+	_, otherKey := generateKeyPair(t)
+
+	tests := []struct {
+		name      string
+		secret    *corev1.Secret
+		expectErr string
+	}{
+		{
+			name: "valid",
+			secret: &corev1.Secret{
+				Type: corev1.SecretTypeTLS,
+				Data: map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: key},
+			},
+		},
+		{
+			name:      "wrong type",
+			secret:    &corev1.Secret{Type: corev1.SecretTypeOpaque},
+			expectErr: `is of type "Opaque"`,
+		},
+		{
+			name: "mismatched key",
+			secret: &corev1.Secret{
+				Type: corev1.SecretTypeTLS,
+				Data: map[string][]byte{corev1.TLSCertKey: cert, corev1.TLSPrivateKeyKey: otherKey},
+			},
+			expectErr: "holds no valid certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.secret.Namespace = "default"
+			tt.secret.Name = "app-tls"
+
+			certificate, err := CertificateFromSecret(tt.secret, []string{"app.example.com"})
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "default/app-tls")
+				assert.Contains(t, err.Error(), tt.expectErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "default/app-tls", certificate.GetName())
+			assert.Equal(t, []string{"app.example.com"}, certificate.GetHostnames())
+			assert.Equal(t, cert, certificate.GetCertificateChain())
+			assert.Equal(t, key, certificate.GetPrivateKey())
+		})
+	}
+}
+
+func TestCertificateSelectionFromConfig(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, routingv1.CertificateSelection_CERTIFICATE_SELECTION_MOST_SPECIFIC,
+		CertificateSelectionFromConfig(nil))
+	assert.Equal(t, routingv1.CertificateSelection_CERTIFICATE_SELECTION_MOST_SPECIFIC,
+		CertificateSelectionFromConfig(&v1alpha1.ListenerTLSConfig{
+			CertificateSelection: v1alpha1.CertificateSelectionMostSpecific,
+		}))
+	assert.Equal(t, routingv1.CertificateSelection_CERTIFICATE_SELECTION_LISTENER_ORDER,
+		CertificateSelectionFromConfig(&v1alpha1.ListenerTLSConfig{
+			CertificateSelection: v1alpha1.CertificateSelectionListenerOrder,
+		}))
+}
+
+func TestAddListenerCertificate(t *testing.T) {
+	t.Parallel()
+
+	var listeners []*routingv1.ListenerCertificates
+
+	listeners = AddListenerCertificate(listeners, 8443, &routingv1.Certificate{Name: "a"})
+	listeners = AddListenerCertificate(listeners, 443, &routingv1.Certificate{Name: "b"})
+	listeners = AddListenerCertificate(listeners, 8443, &routingv1.Certificate{Name: "c"})
+
+	require.Len(t, listeners, 2)
+	assert.Equal(t, uint32(443), listeners[0].GetPort())
+	assert.Len(t, listeners[0].GetCertificates(), 1)
+	assert.Equal(t, uint32(8443), listeners[1].GetPort())
+	assert.Equal(t, "a", listeners[1].GetCertificates()[0].GetName())
+	assert.Equal(t, "c", listeners[1].GetCertificates()[1].GetName())
+}
+
+// generateKeyPair returns a self-signed certificate and its key in PEM form.
+func generateKeyPair(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "app.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
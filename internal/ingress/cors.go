@@ -0,0 +1,115 @@
+package ingress
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// SetCORSPolicies replaces the PingoraCORSPolicies that ExtensionRef filters
+// resolve to. Call it before building routes so that policy changes take
+// effect on the next sync.
+func (b *PingoraBuilder) SetCORSPolicies(policies []v1alpha1.PingoraCORSPolicy) {
+	byName := make(map[types.NamespacedName]*routingv1.CORSPolicy, len(policies))
+
+	for i := range policies {
+		policy := &policies[i]
+		byName[types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}] = corsFromPolicy(&policy.Spec)
+	}
+
+	b.corsMu.Lock()
+	defer b.corsMu.Unlock()
+
+	b.corsPolicies = byName
+}
+
+// IsCORSPolicyRef reports whether an ExtensionRef filter references a
+// PingoraCORSPolicy.
+func IsCORSPolicyRef(ref *gatewayv1.LocalObjectReference) bool {
+	return ref != nil &&
+		string(ref.Group) == v1alpha1.GroupVersion.Group &&
+		string(ref.Kind) == v1alpha1.PingoraCORSPolicyKind
+}
+
+// buildCORS returns the CORS policy of a rule from its first CORS or
+// PingoraCORSPolicy ExtensionRef filter. Per Gateway API, a rule whose filter
+// references a missing policy must not be served without it, so a fixed 500
+// response is returned instead.
+func (b *PingoraBuilder) buildCORS(
+	namespace string,
+	filters []gatewayv1.HTTPRouteFilter,
+) (*routingv1.CORSPolicy, *routingv1.FixedResponse) {
+	for i := range filters {
+		filter := &filters[i]
+
+		switch {
+		case filter.Type == gatewayv1.HTTPRouteFilterCORS && filter.CORS != nil:
+			return corsFromFilter(filter.CORS), nil
+		case filter.Type == gatewayv1.HTTPRouteFilterExtensionRef && IsCORSPolicyRef(filter.ExtensionRef):
+			key := types.NamespacedName{Namespace: namespace, Name: string(filter.ExtensionRef.Name)}
+
+			b.corsMu.RLock()
+			policy, ok := b.corsPolicies[key]
+			b.corsMu.RUnlock()
+
+			if !ok {
+				return nil, &routingv1.FixedResponse{
+					StatusCode: NoBackendsStatusCode,
+					Reason:     fmt.Sprintf("%s %s not found", v1alpha1.PingoraCORSPolicyKind, key),
+				}
+			}
+
+			return policy, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func corsFromPolicy(spec *v1alpha1.PingoraCORSPolicySpec) *routingv1.CORSPolicy {
+	return &routingv1.CORSPolicy{
+		AllowOrigins:     append([]string(nil), spec.AllowOrigins...),
+		AllowMethods:     append([]string(nil), spec.AllowMethods...),
+		AllowHeaders:     append([]string(nil), spec.AllowHeaders...),
+		ExposeHeaders:    append([]string(nil), spec.ExposeHeaders...),
+		AllowCredentials: spec.AllowCredentials,
+		MaxAgeSeconds:    uint32(max(spec.GetMaxAgeSeconds(), 0)),
+	}
+}
+
+func corsFromFilter(filter *gatewayv1.HTTPCORSFilter) *routingv1.CORSPolicy {
+	result := &routingv1.CORSPolicy{
+		AllowOrigins:     make([]string, 0, len(filter.AllowOrigins)),
+		AllowMethods:     make([]string, 0, len(filter.AllowMethods)),
+		AllowHeaders:     make([]string, 0, len(filter.AllowHeaders)),
+		ExposeHeaders:    make([]string, 0, len(filter.ExposeHeaders)),
+		AllowCredentials: filter.AllowCredentials != nil && *filter.AllowCredentials,
+		MaxAgeSeconds:    v1alpha1.DefaultCORSMaxAge,
+	}
+
+	for _, origin := range filter.AllowOrigins {
+		result.AllowOrigins = append(result.AllowOrigins, string(origin))
+	}
+
+	for _, method := range filter.AllowMethods {
+		result.AllowMethods = append(result.AllowMethods, string(method))
+	}
+
+	for _, header := range filter.AllowHeaders {
+		result.AllowHeaders = append(result.AllowHeaders, string(header))
+	}
+
+	for _, header := range filter.ExposeHeaders {
+		result.ExposeHeaders = append(result.ExposeHeaders, string(header))
+	}
+
+	if filter.MaxAge > 0 {
+		result.MaxAgeSeconds = uint32(filter.MaxAge)
+	}
+
+	return result
+}
@@ -0,0 +1,142 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func corsPolicyFilter(name string) gatewayv1.HTTPRouteFilter {
+	return gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterExtensionRef,
+		ExtensionRef: &gatewayv1.LocalObjectReference{
+			Group: gatewayv1.Group(v1alpha1.GroupVersion.Group),
+			Kind:  v1alpha1.PingoraCORSPolicyKind,
+			Name:  gatewayv1.ObjectName(name),
+		},
+	}
+}
+
+func TestBuildHTTPRoute_CORS(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetCORSPolicies([]v1alpha1.PingoraCORSPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: v1alpha1.PingoraCORSPolicySpec{
+				AllowOrigins:     []string{"https://app.example.com", "https://*.example.com"},
+				AllowMethods:     []string{"PUT", "DELETE"},
+				AllowHeaders:     []string{"Authorization"},
+				ExposeHeaders:    []string{"X-Request-Id"},
+				AllowCredentials: true,
+				MaxAgeSeconds:    ptrTo(int32(600)),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "defaults", Namespace: "default"},
+			Spec:       v1alpha1.PingoraCORSPolicySpec{AllowOrigins: []string{"*"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "other"},
+			Spec:       v1alpha1.PingoraCORSPolicySpec{AllowOrigins: []string{"https://other.example.com"}},
+		},
+	})
+
+	tests := []struct {
+		name             string
+		filters          []gatewayv1.HTTPRouteFilter
+		expectedCORS     *routingv1.CORSPolicy
+		expectedResponse *routingv1.FixedResponse
+	}{
+		{
+			name: "no filters",
+		},
+		{
+			name:    "policy reference",
+			filters: []gatewayv1.HTTPRouteFilter{corsPolicyFilter("web")},
+			expectedCORS: &routingv1.CORSPolicy{
+				AllowOrigins:     []string{"https://app.example.com", "https://*.example.com"},
+				AllowMethods:     []string{"PUT", "DELETE"},
+				AllowHeaders:     []string{"Authorization"},
+				ExposeHeaders:    []string{"X-Request-Id"},
+				AllowCredentials: true,
+				MaxAgeSeconds:    600,
+			},
+		},
+		{
+			name:    "policy reference with defaults",
+			filters: []gatewayv1.HTTPRouteFilter{corsPolicyFilter("defaults")},
+			expectedCORS: &routingv1.CORSPolicy{
+				AllowOrigins:  []string{"*"},
+				MaxAgeSeconds: v1alpha1.DefaultCORSMaxAge,
+			},
+		},
+		{
+			name:    "missing policy",
+			filters: []gatewayv1.HTTPRouteFilter{corsPolicyFilter("missing")},
+			expectedResponse: &routingv1.FixedResponse{
+				StatusCode: NoBackendsStatusCode,
+				Reason:     "PingoraCORSPolicy default/missing not found",
+			},
+		},
+		{
+			name: "native cors filter",
+			filters: []gatewayv1.HTTPRouteFilter{{
+				Type: gatewayv1.HTTPRouteFilterCORS,
+				CORS: &gatewayv1.HTTPCORSFilter{
+					AllowOrigins:  []gatewayv1.CORSOrigin{"https://app.example.com"},
+					AllowMethods:  []gatewayv1.HTTPMethodWithWildcard{"*"},
+					AllowHeaders:  []gatewayv1.HTTPHeaderName{"X-Custom"},
+					ExposeHeaders: []gatewayv1.HTTPHeaderName{"X-Request-Id"},
+					MaxAge:        60,
+				},
+			}},
+			expectedCORS: &routingv1.CORSPolicy{
+				AllowOrigins:  []string{"https://app.example.com"},
+				AllowMethods:  []string{"*"},
+				AllowHeaders:  []string{"X-Custom"},
+				ExposeHeaders: []string{"X-Request-Id"},
+				MaxAgeSeconds: 60,
+			},
+		},
+		{
+			name: "unrelated extension ref is ignored",
+			filters: []gatewayv1.HTTPRouteFilter{{
+				Type:         gatewayv1.HTTPRouteFilterExtensionRef,
+				ExtensionRef: &gatewayv1.LocalObjectReference{Group: "example.com", Kind: "Other", Name: "web"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					Rules: []gatewayv1.HTTPRouteRule{{
+						BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+						Filters:     tt.filters,
+					}},
+				},
+			}
+
+			result := builder.BuildHTTPRoute(route)
+			require.Len(t, result.GetRules(), 1)
+
+			rule := result.GetRules()[0]
+			assert.True(t, proto.Equal(tt.expectedCORS, rule.GetCors()), "cors: %v", rule.GetCors())
+			assert.True(t, proto.Equal(tt.expectedResponse, rule.GetFixedResponse()),
+				"fixed response: %v", rule.GetFixedResponse())
+		})
+	}
+}
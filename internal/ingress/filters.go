@@ -0,0 +1,223 @@
+package ingress
+
+import (
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// IsSupportedHTTPFilter reports whether the proxy programs a rule-level
+// HTTPRoute filter: CORS and ExtensionRefs to a PingoraCORSPolicy.
+func IsSupportedHTTPFilter(filter *gatewayv1.HTTPRouteFilter) bool {
+	switch filter.Type {
+	case gatewayv1.HTTPRouteFilterCORS:
+		return true
+	case gatewayv1.HTTPRouteFilterExtensionRef:
+		return IsCORSPolicyRef(filter.ExtensionRef)
+	default:
+		return false
+	}
+}
+
+// IsSupportedHTTPBackendFilter reports whether the proxy programs a
+// backendRef filter of an HTTPRoute: request and response header
+// modifiers.
+func IsSupportedHTTPBackendFilter(filter *gatewayv1.HTTPRouteFilter) bool {
+	switch filter.Type {
+	case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+		return filter.RequestHeaderModifier != nil
+	case gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+		return filter.ResponseHeaderModifier != nil
+	default:
+		return false
+	}
+}
+
+// HTTPRouteUnsupportedFilters returns the types of the rule and backendRef
+// filters of an HTTPRoute that the builder ignores, once per filter.
+func HTTPRouteUnsupportedFilters(rules []gatewayv1.HTTPRouteRule) []string {
+	var filters []string
+
+	for i := range rules {
+		rule := &rules[i]
+
+		for j := range rule.Filters {
+			if !IsSupportedHTTPFilter(&rule.Filters[j]) {
+				filters = append(filters, string(rule.Filters[j].Type))
+			}
+		}
+
+		for j := range rule.BackendRefs {
+			for k := range rule.BackendRefs[j].Filters {
+				if !IsSupportedHTTPBackendFilter(&rule.BackendRefs[j].Filters[k]) {
+					filters = append(filters, string(rule.BackendRefs[j].Filters[k].Type))
+				}
+			}
+		}
+	}
+
+	return filters
+}
+
+// IsSupportedGRPCFilter reports whether the proxy programs a rule-level
+// GRPCRoute filter: header modifiers and request mirrors.
+func IsSupportedGRPCFilter(filter *gatewayv1.GRPCRouteFilter) bool {
+	switch filter.Type {
+	case gatewayv1.GRPCRouteFilterRequestMirror:
+		return filter.RequestMirror != nil
+	default:
+		return IsSupportedGRPCBackendFilter(filter)
+	}
+}
+
+// IsSupportedGRPCBackendFilter reports whether the proxy programs a
+// backendRef filter of a GRPCRoute: request and response header modifiers.
+func IsSupportedGRPCBackendFilter(filter *gatewayv1.GRPCRouteFilter) bool {
+	switch filter.Type {
+	case gatewayv1.GRPCRouteFilterRequestHeaderModifier:
+		return filter.RequestHeaderModifier != nil
+	case gatewayv1.GRPCRouteFilterResponseHeaderModifier:
+		return filter.ResponseHeaderModifier != nil
+	default:
+		return false
+	}
+}
+
+// GRPCRouteUnsupportedFilters returns the types of the rule and backendRef
+// filters of a GRPCRoute that the builder ignores, once per filter.
+func GRPCRouteUnsupportedFilters(rules []gatewayv1.GRPCRouteRule) []string {
+	var filters []string
+
+	for i := range rules {
+		rule := &rules[i]
+
+		for j := range rule.Filters {
+			if !IsSupportedGRPCFilter(&rule.Filters[j]) {
+				filters = append(filters, string(rule.Filters[j].Type))
+			}
+		}
+
+		for j := range rule.BackendRefs {
+			for k := range rule.BackendRefs[j].Filters {
+				if !IsSupportedGRPCBackendFilter(&rule.BackendRefs[j].Filters[k]) {
+					filters = append(filters, string(rule.BackendRefs[j].Filters[k].Type))
+				}
+			}
+		}
+	}
+
+	return filters
+}
+
+// applyHTTPBackendFilters sets the header modifiers of the backendRef
+// filters of an HTTPRoute on its backend. Unsupported filters are left out.
+func applyHTTPBackendFilters(backend *routingv1.Backend, filters []gatewayv1.HTTPRouteFilter) {
+	for i := range filters {
+		filter := &filters[i]
+		if !IsSupportedHTTPBackendFilter(filter) {
+			continue
+		}
+
+		switch filter.Type {
+		case gatewayv1.HTTPRouteFilterRequestHeaderModifier:
+			backend.RequestHeaderModifier = mergeHeaderModifier(backend.GetRequestHeaderModifier(), filter.RequestHeaderModifier)
+		case gatewayv1.HTTPRouteFilterResponseHeaderModifier:
+			backend.ResponseHeaderModifier = mergeHeaderModifier(backend.GetResponseHeaderModifier(), filter.ResponseHeaderModifier)
+		default:
+		}
+	}
+}
+
+// applyGRPCBackendFilters sets the header modifiers of the backendRef
+// filters of a GRPCRoute on its backend. Unsupported filters are left out.
+func applyGRPCBackendFilters(backend *routingv1.Backend, filters []gatewayv1.GRPCRouteFilter) {
+	for i := range filters {
+		filter := &filters[i]
+		if !IsSupportedGRPCBackendFilter(filter) {
+			continue
+		}
+
+		switch filter.Type {
+		case gatewayv1.GRPCRouteFilterRequestHeaderModifier:
+			backend.RequestHeaderModifier = mergeHeaderModifier(backend.GetRequestHeaderModifier(), filter.RequestHeaderModifier)
+		case gatewayv1.GRPCRouteFilterResponseHeaderModifier:
+			backend.ResponseHeaderModifier = mergeHeaderModifier(backend.GetResponseHeaderModifier(), filter.ResponseHeaderModifier)
+		default:
+		}
+	}
+}
+
+// buildGRPCRuleFilters sets the header modifiers and request mirrors of the
+// rule-level filters of a GRPCRoute on its rule. Mirrors whose backend
+// cannot be resolved are left out.
+func (b *PingoraBuilder) buildGRPCRuleFilters(
+	namespace string,
+	rule *routingv1.GRPCRouteRule,
+	filters []gatewayv1.GRPCRouteFilter,
+) {
+	for i := range filters {
+		filter := &filters[i]
+		if !IsSupportedGRPCFilter(filter) {
+			continue
+		}
+
+		switch filter.Type {
+		case gatewayv1.GRPCRouteFilterRequestHeaderModifier:
+			rule.RequestHeaderModifier = mergeHeaderModifier(rule.GetRequestHeaderModifier(), filter.RequestHeaderModifier)
+		case gatewayv1.GRPCRouteFilterResponseHeaderModifier:
+			rule.ResponseHeaderModifier = mergeHeaderModifier(rule.GetResponseHeaderModifier(), filter.ResponseHeaderModifier)
+		case gatewayv1.GRPCRouteFilterRequestMirror:
+			if mirror := b.buildRequestMirror(namespace, filter.RequestMirror); mirror != nil {
+				rule.Mirrors = append(rule.Mirrors, mirror)
+			}
+		default:
+		}
+	}
+}
+
+// buildRequestMirror resolves the backend of a mirror filter, nil if it
+// cannot be resolved. Without percent or fraction every request is copied.
+func (b *PingoraBuilder) buildRequestMirror(namespace string, filter *gatewayv1.HTTPRequestMirrorFilter) *routingv1.RequestMirror {
+	backend := b.buildBackend(kindGRPCRoute, namespace, &gatewayv1.BackendRef{BackendObjectReference: filter.BackendRef})
+	if backend == nil {
+		return nil
+	}
+
+	fraction := 1.0
+
+	switch {
+	case filter.Percent != nil:
+		fraction = float64(*filter.Percent) / 100
+	case filter.Fraction != nil:
+		denominator := int32(100)
+		if filter.Fraction.Denominator != nil {
+			denominator = *filter.Fraction.Denominator
+		}
+
+		if denominator > 0 {
+			fraction = min(float64(filter.Fraction.Numerator)/float64(denominator), 1)
+		}
+	}
+
+	return &routingv1.RequestMirror{Backend: backend, Fraction: fraction}
+}
+
+// mergeHeaderModifier adds the changes of a header filter to a header
+// modifier, creating it if nil.
+func mergeHeaderModifier(modifier *routingv1.HeaderModifier, filter *gatewayv1.HTTPHeaderFilter) *routingv1.HeaderModifier {
+	if modifier == nil {
+		modifier = &routingv1.HeaderModifier{}
+	}
+
+	for _, header := range filter.Set {
+		modifier.Set = append(modifier.Set, &routingv1.Header{Name: string(header.Name), Value: header.Value})
+	}
+
+	for _, header := range filter.Add {
+		modifier.Add = append(modifier.Add, &routingv1.Header{Name: string(header.Name), Value: header.Value})
+	}
+
+	modifier.Remove = append(modifier.Remove, filter.Remove...)
+
+	return modifier
+}
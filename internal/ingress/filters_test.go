@@ -0,0 +1,210 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestHTTPRouteUnsupportedFilters(t *testing.T) {
+	t.Parallel()
+
+	corsPolicyRef := &gatewayv1.LocalObjectReference{
+		Group: gatewayv1.Group(v1alpha1.GroupVersion.Group),
+		Kind:  v1alpha1.PingoraCORSPolicyKind,
+		Name:  "cors",
+	}
+	otherRef := &gatewayv1.LocalObjectReference{Group: "example.com", Kind: "Widget", Name: "widget"}
+
+	rules := []gatewayv1.HTTPRouteRule{
+		{
+			Filters: []gatewayv1.HTTPRouteFilter{
+				{Type: gatewayv1.HTTPRouteFilterCORS, CORS: &gatewayv1.HTTPCORSFilter{}},
+				{Type: gatewayv1.HTTPRouteFilterExtensionRef, ExtensionRef: corsPolicyRef},
+				{Type: gatewayv1.HTTPRouteFilterURLRewrite},
+			},
+		},
+		{
+			Filters: []gatewayv1.HTTPRouteFilter{
+				{Type: gatewayv1.HTTPRouteFilterExtensionRef, ExtensionRef: otherRef},
+			},
+			BackendRefs: []gatewayv1.HTTPBackendRef{{
+				BackendRef: serviceRef("app", 80),
+				Filters: []gatewayv1.HTTPRouteFilter{
+					{Type: gatewayv1.HTTPRouteFilterCORS},
+					{
+						Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+						RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Remove: []string{"x-debug"}},
+					},
+				},
+			}},
+		},
+	}
+
+	assert.Equal(t, []string{"URLRewrite", "ExtensionRef", "CORS"}, HTTPRouteUnsupportedFilters(rules))
+	assert.Empty(t, HTTPRouteUnsupportedFilters(nil))
+}
+
+func TestBuildHTTPRoute_BackendFilters(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{
+			BackendRefs: []gatewayv1.HTTPBackendRef{
+				{
+					BackendRef: serviceRef("v1", 80),
+					Filters: []gatewayv1.HTTPRouteFilter{
+						{
+							Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+							RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+								Set:    []gatewayv1.HTTPHeader{{Name: "x-version", Value: "v1"}},
+								Add:    []gatewayv1.HTTPHeader{{Name: "x-tag", Value: "stable"}},
+								Remove: []string{"x-debug"},
+							},
+						},
+						{
+							Type: gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+							ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+								Set: []gatewayv1.HTTPHeader{{Name: "x-served-by", Value: "v1"}},
+							},
+						},
+						// Ignored, the proxy does not mirror per backend
+						{Type: gatewayv1.HTTPRouteFilterRequestMirror},
+					},
+				},
+				{BackendRef: serviceRef("v2", 80)},
+			},
+		}}},
+	}
+
+	built := NewPingoraBuilder("cluster.local").BuildHTTPRoute(route)
+	require.Len(t, built.GetRules(), 1)
+
+	backends := built.GetRules()[0].GetBackends()
+	require.Len(t, backends, 2)
+
+	assert.Equal(t, &routingv1.HeaderModifier{
+		Set:    []*routingv1.Header{{Name: "x-version", Value: "v1"}},
+		Add:    []*routingv1.Header{{Name: "x-tag", Value: "stable"}},
+		Remove: []string{"x-debug"},
+	}, backends[0].GetRequestHeaderModifier())
+	assert.Equal(t, &routingv1.HeaderModifier{
+		Set: []*routingv1.Header{{Name: "x-served-by", Value: "v1"}},
+	}, backends[0].GetResponseHeaderModifier())
+
+	assert.Nil(t, backends[1].GetRequestHeaderModifier())
+	assert.Nil(t, backends[1].GetResponseHeaderModifier())
+}
+
+func TestGRPCRouteUnsupportedFilters(t *testing.T) {
+	t.Parallel()
+
+	mirror := &gatewayv1.HTTPRequestMirrorFilter{BackendRef: serviceRef("shadow", 80).BackendObjectReference}
+	headers := &gatewayv1.HTTPHeaderFilter{Remove: []string{"x-debug"}}
+
+	rules := []gatewayv1.GRPCRouteRule{{
+		Filters: []gatewayv1.GRPCRouteFilter{
+			{Type: gatewayv1.GRPCRouteFilterRequestHeaderModifier, RequestHeaderModifier: headers},
+			{Type: gatewayv1.GRPCRouteFilterRequestMirror, RequestMirror: mirror},
+			{Type: gatewayv1.GRPCRouteFilterExtensionRef},
+		},
+		BackendRefs: []gatewayv1.GRPCBackendRef{{
+			BackendRef: serviceRef("app", 80),
+			Filters: []gatewayv1.GRPCRouteFilter{
+				{Type: gatewayv1.GRPCRouteFilterResponseHeaderModifier, ResponseHeaderModifier: headers},
+				// Mirrors are only supported on rules
+				{Type: gatewayv1.GRPCRouteFilterRequestMirror, RequestMirror: mirror},
+			},
+		}},
+	}}
+
+	assert.Equal(t, []string{"ExtensionRef", "RequestMirror"}, GRPCRouteUnsupportedFilters(rules))
+}
+
+func TestBuildGRPCRoute_Filters(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "echo", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{{
+			Filters: []gatewayv1.GRPCRouteFilter{
+				{
+					Type: gatewayv1.GRPCRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+						Set: []gatewayv1.HTTPHeader{{Name: "x-tenant", Value: "acme"}},
+					},
+				},
+				{
+					Type: gatewayv1.GRPCRouteFilterResponseHeaderModifier,
+					ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+						Remove: []string{"server"},
+					},
+				},
+				{
+					Type: gatewayv1.GRPCRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+						BackendRef: serviceRef("shadow", 9000).BackendObjectReference,
+						Percent:    ptrTo(int32(25)),
+					},
+				},
+				{
+					Type: gatewayv1.GRPCRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+						BackendRef: serviceRef("audit", 9000).BackendObjectReference,
+						Fraction:   &gatewayv1.Fraction{Numerator: 1, Denominator: ptrTo(int32(3))},
+					},
+				},
+				{
+					Type: gatewayv1.GRPCRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+						BackendRef: serviceRef("archive", 9000).BackendObjectReference,
+					},
+				},
+				// Left out, the mirror backend has no port
+				{
+					Type: gatewayv1.GRPCRouteFilterRequestMirror,
+					RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{
+						BackendRef: gatewayv1.BackendObjectReference{Name: "no-port"},
+					},
+				},
+			},
+			BackendRefs: []gatewayv1.GRPCBackendRef{{
+				BackendRef: serviceRef("echo", 9000),
+				Filters: []gatewayv1.GRPCRouteFilter{{
+					Type: gatewayv1.GRPCRouteFilterRequestHeaderModifier,
+					RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{
+						Add: []gatewayv1.HTTPHeader{{Name: "x-backend", Value: "echo"}},
+					},
+				}},
+			}},
+		}}},
+	}
+
+	built := NewPingoraBuilder("cluster.local").BuildGRPCRoute(route)
+	require.Len(t, built.GetRules(), 1)
+
+	rule := built.GetRules()[0]
+
+	assert.Equal(t, &routingv1.HeaderModifier{
+		Set: []*routingv1.Header{{Name: "x-tenant", Value: "acme"}},
+	}, rule.GetRequestHeaderModifier())
+	assert.Equal(t, &routingv1.HeaderModifier{Remove: []string{"server"}}, rule.GetResponseHeaderModifier())
+
+	require.Len(t, rule.GetMirrors(), 3)
+	assert.Equal(t, "shadow.default.svc.cluster.local:9000", rule.GetMirrors()[0].GetBackend().GetAddress())
+	assert.InDelta(t, 0.25, rule.GetMirrors()[0].GetFraction(), 1e-9)
+	assert.InDelta(t, 1.0/3, rule.GetMirrors()[1].GetFraction(), 1e-9)
+	assert.InDelta(t, 1.0, rule.GetMirrors()[2].GetFraction(), 1e-9)
+
+	require.Len(t, rule.GetBackends(), 1)
+	assert.Equal(t, &routingv1.HeaderModifier{
+		Add: []*routingv1.Header{{Name: "x-backend", Value: "echo"}},
+	}, rule.GetBackends()[0].GetRequestHeaderModifier())
+}
@@ -0,0 +1,117 @@
+package ingress
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/cockroachdb/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// CACertificateKey is the key of the CA certificates in the ConfigMaps and
+// Secrets referenced by caCertificateRefs.
+const CACertificateKey = "ca.crt"
+
+// FrontendValidation returns the client certificate validation of a
+// listener that terminates TLS: the per-port settings of
+// spec.tls.frontend of its Gateway for the port of the listener, else the
+// default settings. nil means the listener requests no client certificate.
+func FrontendValidation(gateway *gatewayv1.Gateway, listener *gatewayv1.Listener) *gatewayv1.FrontendTLSValidation {
+	if listener.Protocol != gatewayv1.HTTPSProtocolType || listener.TLS == nil ||
+		(listener.TLS.Mode != nil && *listener.TLS.Mode != gatewayv1.TLSModeTerminate) {
+		return nil
+	}
+
+	if gateway.Spec.TLS == nil || gateway.Spec.TLS.Frontend == nil {
+		return nil
+	}
+
+	frontend := gateway.Spec.TLS.Frontend
+
+	for i := range frontend.PerPort {
+		if frontend.PerPort[i].Port == listener.Port {
+			return frontend.PerPort[i].TLS.Validation
+		}
+	}
+
+	return frontend.Default.Validation
+}
+
+// ReferencesCACertificate reports whether a listener of a Gateway validates
+// client certificates with the CA certificates of the given ConfigMap or
+// Secret.
+func ReferencesCACertificate(gateway *gatewayv1.Gateway, kind, namespace, name string) bool {
+	for i := range gateway.Spec.Listeners {
+		validation := FrontendValidation(gateway, &gateway.Spec.Listeners[i])
+		if validation == nil {
+			continue
+		}
+
+		for _, ref := range validation.CACertificateRefs {
+			refNamespace := gateway.Namespace
+			if ref.Namespace != nil {
+				refNamespace = string(*ref.Namespace)
+			}
+
+			if string(ref.Kind) == kind && refNamespace == namespace && string(ref.Name) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// IsCACertificateKind reports whether a caCertificateRef references a core
+// ConfigMap or Secret, the kinds CA certificates are read from.
+func IsCACertificateKind(ref gatewayv1.ObjectReference) bool {
+	return ref.Group == "" && (ref.Kind == "ConfigMap" || ref.Kind == "Secret")
+}
+
+// ParseCACertificates checks that the ca.crt key of a ConfigMap or Secret
+// holds PEM-encoded CA certificates and returns them.
+func ParseCACertificates(name string, data map[string][]byte) ([]byte, error) {
+	bundle, ok := data[CACertificateKey]
+	if !ok {
+		return nil, errors.Newf("%s has no %q key", name, CACertificateKey)
+	}
+
+	found := false
+
+	for rest := bundle; ; {
+		var block *pem.Block
+
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return nil, errors.Wrapf(err, "%s holds an invalid CA certificate", name)
+		}
+
+		found = true
+	}
+
+	if !found {
+		return nil, errors.Newf("%s holds no PEM-encoded CA certificate", name)
+	}
+
+	return bundle, nil
+}
+
+// ClientValidationModeFromGateway converts the frontend validation mode of a
+// Gateway, AllowValidOnly if unset.
+func ClientValidationModeFromGateway(mode gatewayv1.FrontendValidationModeType) routingv1.ClientValidationMode {
+	if mode == gatewayv1.AllowInsecureFallback {
+		return routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_INSECURE_FALLBACK
+	}
+
+	return routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY
+}
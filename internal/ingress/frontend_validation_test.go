@@ -0,0 +1,130 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestFrontendValidation(t *testing.T) {
+	t.Parallel()
+
+	defaultValidation := gatewayv1.FrontendTLSValidation{
+		CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "ConfigMap", Name: "default-ca"}},
+	}
+	portValidation := gatewayv1.FrontendTLSValidation{
+		CACertificateRefs: []gatewayv1.ObjectReference{{Kind: "Secret", Name: "port-ca"}},
+		Mode:              gatewayv1.AllowInsecureFallback,
+	}
+	passthrough := gatewayv1.TLSModePassthrough
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443, TLS: &gatewayv1.ListenerTLSConfig{}},
+				{Name: "admin", Protocol: gatewayv1.HTTPSProtocolType, Port: 8443, TLS: &gatewayv1.ListenerTLSConfig{}},
+				{
+					Name: "passthrough", Protocol: gatewayv1.HTTPSProtocolType, Port: 9443,
+					TLS: &gatewayv1.ListenerTLSConfig{Mode: &passthrough},
+				},
+			},
+			TLS: &gatewayv1.GatewayTLSConfig{Frontend: &gatewayv1.FrontendTLSConfig{
+				Default: gatewayv1.TLSConfig{Validation: &defaultValidation},
+				PerPort: []gatewayv1.TLSPortConfig{{Port: 8443, TLS: gatewayv1.TLSConfig{Validation: &portValidation}}},
+			}},
+		},
+	}
+
+	listeners := gateway.Spec.Listeners
+
+	assert.Nil(t, FrontendValidation(gateway, &listeners[0]))
+	assert.Equal(t, &defaultValidation, FrontendValidation(gateway, &listeners[1]))
+	assert.Equal(t, &portValidation, FrontendValidation(gateway, &listeners[2]))
+	assert.Nil(t, FrontendValidation(gateway, &listeners[3]))
+
+	assert.True(t, ReferencesCACertificate(gateway, "ConfigMap", "default", "default-ca"))
+	assert.True(t, ReferencesCACertificate(gateway, "Secret", "default", "port-ca"))
+	assert.False(t, ReferencesCACertificate(gateway, "Secret", "default", "default-ca"))
+	assert.False(t, ReferencesCACertificate(gateway, "ConfigMap", "other", "default-ca"))
+
+	gateway.Spec.TLS = nil
+
+	assert.Nil(t, FrontendValidation(gateway, &listeners[1]))
+}
+
+func TestIsCACertificateKind(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsCACertificateKind(gatewayv1.ObjectReference{Kind: "ConfigMap", Name: "ca"}))
+	assert.True(t, IsCACertificateKind(gatewayv1.ObjectReference{Kind: "Secret", Name: "ca"}))
+	assert.False(t, IsCACertificateKind(gatewayv1.ObjectReference{Kind: "Service", Name: "ca"}))
+	assert.False(t, IsCACertificateKind(gatewayv1.ObjectReference{Group: "example.com", Kind: "Secret", Name: "ca"}))
+}
+
+func TestParseCACertificates(t *testing.T) {
+	t.Parallel()
+
+	cert, key := generateKeyPair(t)
+
+	tests := []struct {
+		name      string
+		data      map[string][]byte
+		expectErr string
+	}{
+		{
+			name: "valid",
+			data: map[string][]byte{CACertificateKey: cert},
+		},
+		{
+			name:      "missing key",
+			data:      map[string][]byte{"tls.crt": cert},
+			expectErr: `has no "ca.crt" key`,
+		},
+		{
+			name:      "no certificate",
+			data:      map[string][]byte{CACertificateKey: key},
+			expectErr: "holds no PEM-encoded CA certificate",
+		},
+		{
+			name:      "invalid certificate",
+			data:      map[string][]byte{CACertificateKey: []byte("-----BEGIN CERTIFICATE-----\naW52YWxpZA==\n-----END CERTIFICATE-----\n")},
+			expectErr: "holds an invalid CA certificate",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			bundle, err := ParseCACertificates("ConfigMap default/ca", tt.data)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "ConfigMap default/ca")
+				assert.Contains(t, err.Error(), tt.expectErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, cert, bundle)
+		})
+	}
+}
+
+func TestClientValidationModeFromGateway(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY,
+		ClientValidationModeFromGateway(""))
+	assert.Equal(t, routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY,
+		ClientValidationModeFromGateway(gatewayv1.AllowValidOnly))
+	assert.Equal(t, routingv1.ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_INSECURE_FALLBACK,
+		ClientValidationModeFromGateway(gatewayv1.AllowInsecureFallback))
+}
@@ -0,0 +1,50 @@
+package ingress
+
+import (
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// accessLogFormats maps the access log formats of the API to the proxy.
+var accessLogFormats = map[v1alpha1.AccessLogFormat]routingv1.AccessLogFormat{
+	v1alpha1.AccessLogFormatText:     routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_TEXT,
+	v1alpha1.AccessLogFormatJSON:     routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_JSON,
+	v1alpha1.AccessLogFormatDisabled: routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_DISABLED,
+}
+
+// GlobalConfigFromDefaults converts the defaults of a PingoraConfig to the
+// gateway-wide proxy settings. Nil defaults result in an empty config, so
+// that the proxy falls back to its own defaults.
+func GlobalConfigFromDefaults(defaults *v1alpha1.ProxyDefaults) *routingv1.GlobalConfig {
+	if defaults == nil {
+		return &routingv1.GlobalConfig{}
+	}
+
+	return &routingv1.GlobalConfig{
+		DefaultRequestTimeoutMs: durationMs(defaults.RequestTimeout),
+		MaxRequestBodyBytes:     quantityBytes(defaults.MaxRequestBodySize),
+		AccessLogFormat:         accessLogFormats[defaults.AccessLogFormat],
+		Http2Enabled:            defaults.HTTP2,
+		TrustedProxyCidrs:       defaults.TrustedProxies,
+		ForwardedForDepth:       forwardedForDepth(defaults.ForwardedForDepth),
+		RealIpHeader:            realIPHeader(defaults.RealIPHeader),
+	}
+}
+
+func forwardedForDepth(depth *int32) uint32 {
+	if depth == nil {
+		return 0
+	}
+
+	return uint32(max(*depth, 0))
+}
+
+func realIPHeader(header *gatewayv1.HTTPHeaderName) string {
+	if header == nil {
+		return ""
+	}
+
+	return string(*header)
+}
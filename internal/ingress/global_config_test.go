@@ -0,0 +1,65 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/resource"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestGlobalConfigFromDefaults(t *testing.T) {
+	t.Parallel()
+
+	bodySize := resource.MustParse("10Mi")
+
+	tests := []struct {
+		name     string
+		defaults *v1alpha1.ProxyDefaults
+		expected *routingv1.GlobalConfig
+	}{
+		{
+			name:     "no defaults",
+			expected: &routingv1.GlobalConfig{},
+		},
+		{
+			name: "all defaults",
+			defaults: &v1alpha1.ProxyDefaults{
+				RequestTimeout:     ptrTo(gatewayv1.Duration("1m30s")),
+				MaxRequestBodySize: &bodySize,
+				AccessLogFormat:    v1alpha1.AccessLogFormatJSON,
+				HTTP2:              ptrTo(false),
+				TrustedProxies:     []string{"10.0.0.0/8"},
+				ForwardedForDepth:  ptrTo(int32(2)),
+				RealIPHeader:       ptrTo(gatewayv1.HTTPHeaderName("X-Real-IP")),
+			},
+			expected: &routingv1.GlobalConfig{
+				DefaultRequestTimeoutMs: 90000,
+				MaxRequestBodyBytes:     10 << 20,
+				AccessLogFormat:         routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_JSON,
+				Http2Enabled:            ptrTo(false),
+				TrustedProxyCidrs:       []string{"10.0.0.0/8"},
+				ForwardedForDepth:       2,
+				RealIpHeader:            "X-Real-IP",
+			},
+		},
+		{
+			name:     "access log disabled",
+			defaults: &v1alpha1.ProxyDefaults{AccessLogFormat: v1alpha1.AccessLogFormatDisabled},
+			expected: &routingv1.GlobalConfig{AccessLogFormat: routingv1.AccessLogFormat_ACCESS_LOG_FORMAT_DISABLED},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := GlobalConfigFromDefaults(tt.defaults)
+			assert.True(t, proto.Equal(tt.expected, result), "got %v", result)
+		})
+	}
+}
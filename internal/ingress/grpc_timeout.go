@@ -0,0 +1,187 @@
+package ingress
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// GRPCRoute timeout annotations. GRPCRoute has no timeouts in its spec, so
+// they are declared on the route instead.
+//
+// Both take comma-separated entries that are either a Gateway API duration
+// for every rule (e.g., "10s") or "<rule name>=<duration>" for a single named
+// rule (e.g., "10s, Watch=0s"). Named entries override the default, and a
+// zero duration disables the timeout.
+const (
+	// GRPCTimeoutAnnotation bounds a whole call, including streamed messages.
+	GRPCTimeoutAnnotation = "pingora.k8s.lex.la/grpc-timeout"
+
+	// GRPCHeaderTimeoutAnnotation bounds the wait for the response headers,
+	// which suits streaming calls that may stay open indefinitely.
+	GRPCHeaderTimeoutAnnotation = "pingora.k8s.lex.la/grpc-header-timeout"
+)
+
+// ruleDurations holds the durations of a timeout annotation in milliseconds.
+type ruleDurations struct {
+	all    *uint64
+	byName map[string]uint64
+}
+
+// forRule returns the duration for the rule with the given name.
+func (d ruleDurations) forRule(name string) uint64 {
+	if ms, ok := d.byName[name]; ok && name != "" {
+		return ms
+	}
+
+	if d.all != nil {
+		return *d.all
+	}
+
+	return 0
+}
+
+// GRPCTimeouts holds the per-rule timeouts declared on a GRPCRoute.
+type GRPCTimeouts struct {
+	timeout       ruleDurations
+	headerTimeout ruleDurations
+}
+
+// ParseGRPCTimeouts reads GRPCTimeoutAnnotation and GRPCHeaderTimeoutAnnotation
+// from the given annotations. It returns nil without an error when neither
+// annotation is set.
+func ParseGRPCTimeouts(annotations map[string]string) (*GRPCTimeouts, error) {
+	timeoutValue, hasTimeout := annotations[GRPCTimeoutAnnotation]
+	headerValue, hasHeader := annotations[GRPCHeaderTimeoutAnnotation]
+
+	if !hasTimeout && !hasHeader {
+		return nil, nil //nolint:nilnil // no timeouts declared
+	}
+
+	var (
+		result GRPCTimeouts
+		err    error
+	)
+
+	if result.timeout, err = parseRuleDurations(timeoutValue); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation", GRPCTimeoutAnnotation)
+	}
+
+	if result.headerTimeout, err = parseRuleDurations(headerValue); err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation", GRPCHeaderTimeoutAnnotation)
+	}
+
+	return &result, nil
+}
+
+func parseRuleDurations(value string) (ruleDurations, error) {
+	result := ruleDurations{byName: make(map[string]uint64)}
+
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, durationValue, named := strings.Cut(entry, "=")
+		if !named {
+			durationValue = name
+		}
+
+		duration, err := parseGatewayDuration(strings.TrimSpace(durationValue))
+		if err != nil || duration < 0 {
+			return ruleDurations{}, errors.Newf("invalid duration %q", strings.TrimSpace(durationValue))
+		}
+
+		ms := uint64(duration.Milliseconds())
+
+		if !named {
+			result.all = &ms
+
+			continue
+		}
+
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return ruleDurations{}, errors.Newf("missing rule name in %q", entry)
+		}
+
+		result.byName[name] = ms
+	}
+
+	return result, nil
+}
+
+// UnknownRules returns the rule names referenced by the annotations that no
+// rule of the route carries, sorted.
+func (t *GRPCTimeouts) UnknownRules(rules []gatewayv1.GRPCRouteRule) []string {
+	known := make(map[string]bool, len(rules))
+	for i := range rules {
+		known[ruleName(rules[i].Name)] = true
+	}
+
+	var unknown []string
+
+	for _, durations := range []ruleDurations{t.timeout, t.headerTimeout} {
+		for name := range durations.byName {
+			if !known[name] && !slices.Contains(unknown, name) {
+				unknown = append(unknown, name)
+			}
+		}
+	}
+
+	slices.Sort(unknown)
+
+	return unknown
+}
+
+// String summarizes the declared timeouts for route status messages.
+func (t *GRPCTimeouts) String() string {
+	return fmt.Sprintf("gRPC timeouts applied: call %s, response headers %s",
+		t.timeout.String(), t.headerTimeout.String())
+}
+
+func (d ruleDurations) String() string {
+	parts := make([]string, 0, len(d.byName)+1)
+
+	if d.all != nil {
+		parts = append(parts, fmt.Sprintf("%dms", *d.all))
+	}
+
+	names := make([]string, 0, len(d.byName))
+	for name := range d.byName {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%dms", name, d.byName[name]))
+	}
+
+	if len(parts) == 0 {
+		return "unset"
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// apply sets the timeouts of a rule. The header timeout never exceeds the
+// call timeout.
+func (t *GRPCTimeouts) apply(rule *routingv1.GRPCRouteRule) {
+	if t == nil {
+		return
+	}
+
+	rule.TimeoutMs = t.timeout.forRule(rule.GetName())
+	rule.HeaderTimeoutMs = t.headerTimeout.forRule(rule.GetName())
+
+	if rule.GetTimeoutMs() > 0 && rule.GetHeaderTimeoutMs() > rule.GetTimeoutMs() {
+		rule.HeaderTimeoutMs = rule.GetTimeoutMs()
+	}
+}
@@ -0,0 +1,134 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestParseGRPCTimeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		expectNil       bool
+		expectErr       bool
+		expectedMessage string
+	}{
+		{name: "no annotations", expectNil: true},
+		{
+			name:            "default timeout",
+			annotations:     map[string]string{GRPCTimeoutAnnotation: "10s"},
+			expectedMessage: "gRPC timeouts applied: call 10000ms, response headers unset",
+		},
+		{
+			name: "named rules",
+			annotations: map[string]string{
+				GRPCTimeoutAnnotation:       "5s, Watch=0s",
+				GRPCHeaderTimeoutAnnotation: "Watch = 2s",
+			},
+			expectedMessage: "gRPC timeouts applied: call 5000ms, Watch=0ms, response headers Watch=2000ms",
+		},
+		{name: "invalid duration", annotations: map[string]string{GRPCTimeoutAnnotation: "soon"}, expectErr: true},
+		{name: "negative duration", annotations: map[string]string{GRPCHeaderTimeoutAnnotation: "-1s"}, expectErr: true},
+		{name: "missing rule name", annotations: map[string]string{GRPCTimeoutAnnotation: "=1s"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			timeouts, err := ParseGRPCTimeouts(tt.annotations)
+			if tt.expectErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.expectNil {
+				assert.Nil(t, timeouts)
+
+				return
+			}
+
+			require.NotNil(t, timeouts)
+			assert.Equal(t, tt.expectedMessage, timeouts.String())
+		})
+	}
+}
+
+func TestGRPCTimeouts_UnknownRules(t *testing.T) {
+	t.Parallel()
+
+	timeouts, err := ParseGRPCTimeouts(map[string]string{
+		GRPCTimeoutAnnotation:       "Get=1s, List=2s",
+		GRPCHeaderTimeoutAnnotation: "Watch=1s, List=1s",
+	})
+	require.NoError(t, err)
+
+	rules := []gatewayv1.GRPCRouteRule{{Name: ptrTo(gatewayv1.SectionName("Get"))}, {}}
+
+	assert.Equal(t, []string{"List", "Watch"}, timeouts.UnknownRules(rules))
+}
+
+func TestBuildGRPCRoute_Timeouts(t *testing.T) {
+	t.Parallel()
+
+	backendRefs := []gatewayv1.GRPCBackendRef{{BackendRef: serviceRef("grpc", 9090)}}
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "default",
+			Annotations: map[string]string{
+				GRPCTimeoutAnnotation:       "10s, Watch=0s, Slow=1s",
+				GRPCHeaderTimeoutAnnotation: "3s",
+			},
+		},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{
+				{BackendRefs: backendRefs},
+				{Name: ptrTo(gatewayv1.SectionName("Watch")), BackendRefs: backendRefs},
+				{Name: ptrTo(gatewayv1.SectionName("Slow")), BackendRefs: backendRefs},
+			},
+		},
+	}
+
+	result := NewPingoraBuilder("cluster.local").BuildGRPCRoute(route)
+	require.Len(t, result.GetRules(), 3)
+
+	assert.Equal(t, uint64(10_000), result.GetRules()[0].GetTimeoutMs())
+	assert.Equal(t, uint64(3_000), result.GetRules()[0].GetHeaderTimeoutMs())
+
+	// Streaming rule without a call timeout keeps the header timeout
+	assert.Zero(t, result.GetRules()[1].GetTimeoutMs())
+	assert.Equal(t, uint64(3_000), result.GetRules()[1].GetHeaderTimeoutMs())
+
+	// Header timeout is clamped to the call timeout
+	assert.Equal(t, uint64(1_000), result.GetRules()[2].GetTimeoutMs())
+	assert.Equal(t, uint64(1_000), result.GetRules()[2].GetHeaderTimeoutMs())
+}
+
+func TestBuildGRPCRoute_InvalidTimeouts(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{GRPCTimeoutAnnotation: "soon"},
+		},
+		Spec: gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{{}}},
+	}
+
+	result := NewPingoraBuilder("cluster.local").BuildGRPCRoute(route)
+	require.Len(t, result.GetRules(), 1)
+	assert.Zero(t, result.GetRules()[0].GetTimeoutMs())
+	assert.Zero(t, result.GetRules()[0].GetHeaderTimeoutMs())
+}
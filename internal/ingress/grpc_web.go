@@ -0,0 +1,58 @@
+package ingress
+
+import (
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// SetGRPCPolicies replaces the PingoraGRPCPolicies applied to GRPCRoutes.
+// Call it before building routes so that policy changes take effect on the
+// next sync.
+func (b *PingoraBuilder) SetGRPCPolicies(policies []v1alpha1.PingoraGRPCPolicy) {
+	active := ActivePolicies(policyPointers(policies))
+	byTarget := make(map[PolicyTarget]*routingv1.GRPCWebConfig, len(active))
+
+	for target, policy := range active {
+		if target.Kind == PolicyTargetGRPCRoute {
+			byTarget[target] = grpcWebFromPolicy(policy)
+		}
+	}
+
+	b.grpcMu.Lock()
+	defer b.grpcMu.Unlock()
+
+	b.grpcWebConfigs = byTarget
+}
+
+// grpcWebFor returns the gRPC-Web config of a GRPCRoute rule. A policy on the
+// named rule takes precedence over one on the whole route.
+func (b *PingoraBuilder) grpcWebFor(route *gatewayv1.GRPCRoute, ruleName string) *routingv1.GRPCWebConfig {
+	b.grpcMu.RLock()
+	defer b.grpcMu.RUnlock()
+
+	if len(b.grpcWebConfigs) == 0 {
+		return nil
+	}
+
+	routeTarget := PolicyTarget{Kind: PolicyTargetGRPCRoute, Namespace: route.Namespace, Name: route.Name}
+
+	if ruleName != "" {
+		ruleTarget := routeTarget
+		ruleTarget.SectionName = ruleName
+
+		if config, ok := b.grpcWebConfigs[ruleTarget]; ok {
+			return config
+		}
+	}
+
+	return b.grpcWebConfigs[routeTarget]
+}
+
+func grpcWebFromPolicy(policy *v1alpha1.PingoraGRPCPolicy) *routingv1.GRPCWebConfig {
+	return &routingv1.GRPCWebConfig{
+		Id:        policy.Namespace + "/" + policy.Name,
+		AllowText: policy.Spec.GRPCWeb.GetAllowText(),
+	}
+}
@@ -0,0 +1,92 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func grpcPolicy(name, kind, sectionName string, allowText *bool) v1alpha1.PingoraGRPCPolicy {
+	return v1alpha1.PingoraGRPCPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: v1alpha1.PingoraGRPCPolicySpec{
+			TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+				policyTargetRef(kind, "app", sectionName),
+			},
+			GRPCWeb: v1alpha1.GRPCWeb{AllowText: allowText},
+		},
+	}
+}
+
+func TestBuildGRPCRoute_GRPCWeb(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetGRPCPolicies([]v1alpha1.PingoraGRPCPolicy{
+		grpcPolicy("route", PolicyTargetGRPCRoute, "", nil),
+		grpcPolicy("binary", PolicyTargetGRPCRoute, "binary", ptrTo(false)),
+		// Only GRPCRoutes are targeted, even by a policy that slipped past
+		// CRD validation
+		grpcPolicy("http", PolicyTargetHTTPRoute, "", nil),
+	})
+
+	tests := []struct {
+		name     string
+		route    string
+		rule     string
+		expected *routingv1.GRPCWebConfig
+	}{
+		{
+			name:     "route policy",
+			route:    "app",
+			expected: &routingv1.GRPCWebConfig{Id: "default/route", AllowText: true},
+		},
+		{
+			name:     "rule policy takes precedence",
+			route:    "app",
+			rule:     "binary",
+			expected: &routingv1.GRPCWebConfig{Id: "default/binary"},
+		},
+		{
+			name:     "other rule falls back to route policy",
+			route:    "app",
+			rule:     "other",
+			expected: &routingv1.GRPCWebConfig{Id: "default/route", AllowText: true},
+		},
+		{
+			name:  "untargeted route",
+			route: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := gatewayv1.GRPCRouteRule{
+				BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: serviceRef("app", 50051)}},
+			}
+			if tt.rule != "" {
+				rule.Name = ptrTo(gatewayv1.SectionName(tt.rule))
+			}
+
+			route := &gatewayv1.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.route, Namespace: "default"},
+				Spec:       gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{rule}},
+			}
+
+			result := builder.BuildGRPCRoute(route)
+			require.Len(t, result.GetRules(), 1)
+
+			grpcWeb := result.GetRules()[0].GetGrpcWeb()
+			assert.True(t, proto.Equal(tt.expected, grpcWeb), "grpcWeb: %v", grpcWeb)
+		})
+	}
+}
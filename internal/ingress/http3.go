@@ -0,0 +1,81 @@
+package ingress
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// HTTP3Enabled reports whether the HTTP/3 config of a PingoraConfig enables it.
+func HTTP3Enabled(config *v1alpha1.HTTP3Config) bool {
+	return config != nil && config.Enabled
+}
+
+// SupportsHTTP3 reports whether HTTP/3 can be served on a Gateway listener.
+// QUIC terminates TLS in the proxy, so only HTTPS listeners qualify.
+func SupportsHTTP3(listener *gatewayv1.Listener) bool {
+	return listener.Protocol == gatewayv1.HTTPSProtocolType
+}
+
+// EnableHTTP3 enables HTTP/3 on the ports of the HTTPS listeners of the
+// given Gateways. Listeners for ports without other settings are added,
+// and the result stays ordered by port. The listeners are returned
+// unchanged if config does not enable HTTP/3.
+func EnableHTTP3(
+	listeners []*routingv1.Listener,
+	gateways []gatewayv1.Gateway,
+	config *v1alpha1.HTTP3Config,
+) []*routingv1.Listener {
+	if !HTTP3Enabled(config) {
+		return listeners
+	}
+
+	byPort := make(map[uint32]*routingv1.Listener, len(listeners))
+	for _, listener := range listeners {
+		byPort[listener.GetPort()] = listener
+	}
+
+	for i := range gateways {
+		for j := range gateways[i].Spec.Listeners {
+			gatewayListener := &gateways[i].Spec.Listeners[j]
+			if !SupportsHTTP3(gatewayListener) {
+				continue
+			}
+
+			port := uint32(gatewayListener.Port)
+
+			listener, ok := byPort[port]
+			if !ok {
+				listener = &routingv1.Listener{Port: port}
+				byPort[port] = listener
+				listeners = append(listeners, listener)
+			}
+
+			listener.Http3 = http3FromConfig(port, config)
+		}
+	}
+
+	slices.SortFunc(listeners, func(a, b *routingv1.Listener) int {
+		return cmp.Compare(a.GetPort(), b.GetPort())
+	})
+
+	return listeners
+}
+
+func http3FromConfig(port uint32, config *v1alpha1.HTTP3Config) *routingv1.HTTP3 {
+	http3 := &routingv1.HTTP3{
+		UdpPort:             port,
+		AltSvcMaxAgeSeconds: uint64(time.Duration(durationMs(config.AltSvcMaxAge)) * time.Millisecond / time.Second),
+	}
+
+	if config.AdvertisedPort != nil {
+		http3.AltSvcPort = uint32(max(*config.AdvertisedPort, 0))
+	}
+
+	return http3
+}
@@ -0,0 +1,90 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestEnableHTTP3(t *testing.T) {
+	t.Parallel()
+
+	limits := &routingv1.ListenerLimits{MaxRequestBodyBytes: 1 << 20}
+
+	gateways := []gatewayv1.Gateway{{
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80},
+				{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443},
+				{Name: "alt", Protocol: gatewayv1.HTTPSProtocolType, Port: 8443},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name      string
+		listeners []*routingv1.Listener
+		config    *v1alpha1.HTTP3Config
+		expected  []*routingv1.Listener
+	}{
+		{
+			name:      "no config",
+			listeners: []*routingv1.Listener{{Port: 443, Limits: limits}},
+			expected:  []*routingv1.Listener{{Port: 443, Limits: limits}},
+		},
+		{
+			name:      "disabled",
+			listeners: []*routingv1.Listener{{Port: 443, Limits: limits}},
+			config:    &v1alpha1.HTTP3Config{AdvertisedPort: ptrTo(int32(443))},
+			expected:  []*routingv1.Listener{{Port: 443, Limits: limits}},
+		},
+		{
+			name:   "adds HTTPS listeners",
+			config: &v1alpha1.HTTP3Config{Enabled: true},
+			expected: []*routingv1.Listener{
+				{Port: 443, Http3: &routingv1.HTTP3{UdpPort: 443}},
+				{Port: 8443, Http3: &routingv1.HTTP3{UdpPort: 8443}},
+			},
+		},
+		{
+			name: "merges into existing listeners in port order",
+			listeners: []*routingv1.Listener{
+				{Port: 8443, Limits: limits},
+				{Port: 80, Limits: limits},
+			},
+			config: &v1alpha1.HTTP3Config{
+				Enabled:        true,
+				AdvertisedPort: ptrTo(int32(443)),
+				AltSvcMaxAge:   ptrTo(gatewayv1.Duration("1h")),
+			},
+			expected: []*routingv1.Listener{
+				{Port: 80, Limits: limits},
+				{Port: 443, Http3: &routingv1.HTTP3{UdpPort: 443, AltSvcPort: 443, AltSvcMaxAgeSeconds: 3600}},
+				{
+					Port:   8443,
+					Limits: limits,
+					Http3:  &routingv1.HTTP3{UdpPort: 8443, AltSvcPort: 443, AltSvcMaxAgeSeconds: 3600},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			actual := EnableHTTP3(tt.listeners, gateways, tt.config)
+
+			assert.Len(t, actual, len(tt.expected))
+
+			for i := range tt.expected {
+				assert.True(t, proto.Equal(tt.expected[i], actual[i]), "listener %d: %v", i, actual[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package ingress
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// HTTPSRedirectAnnotation set to "true" on a Gateway redirects the requests
+// of its HTTP listeners to its HTTPS listeners with the same hostname.
+const HTTPSRedirectAnnotation = "pingora.k8s.lex.la/https-redirect"
+
+// HTTPSRedirectStatusCode is the status code of HTTPS redirects.
+const HTTPSRedirectStatusCode = http.StatusMovedPermanently
+
+// ACMEChallengePathPrefix is the path of ACME HTTP-01 challenges. It is
+// never redirected, so that certificates for the HTTPS listeners can still
+// be issued over HTTP.
+const ACMEChallengePathPrefix = "/.well-known/acme-challenge/"
+
+// HTTPSRedirectEnabled reports whether the Gateway asks for HTTPS redirects.
+func HTTPSRedirectEnabled(gateway *gatewayv1.Gateway) bool {
+	enabled, err := strconv.ParseBool(gateway.Annotations[HTTPSRedirectAnnotation])
+
+	return err == nil && enabled
+}
+
+// HTTPSRedirectTarget returns the first HTTPS listener of the Gateway with
+// the same hostname as the HTTP listener, or nil if there is none.
+func HTTPSRedirectTarget(gateway *gatewayv1.Gateway, listener *gatewayv1.Listener) *gatewayv1.Listener {
+	for i := range gateway.Spec.Listeners {
+		candidate := &gateway.Spec.Listeners[i]
+
+		if candidate.Protocol == gatewayv1.HTTPSProtocolType &&
+			listenerHostname(candidate) == listenerHostname(listener) {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+// EnableHTTPSRedirects adds HTTPS redirects to the ports of the HTTP
+// listeners of the given Gateways that ask for them and have an HTTPS
+// listener with the same hostname. Listeners for ports without other
+// settings are added, and the result stays ordered by port.
+func EnableHTTPSRedirects(listeners []*routingv1.Listener, gateways []gatewayv1.Gateway) []*routingv1.Listener {
+	byPort := make(map[uint32]*routingv1.Listener, len(listeners))
+	for _, listener := range listeners {
+		byPort[listener.GetPort()] = listener
+	}
+
+	added := false
+
+	for i := range gateways {
+		gateway := &gateways[i]
+		if !HTTPSRedirectEnabled(gateway) {
+			continue
+		}
+
+		for j := range gateway.Spec.Listeners {
+			gatewayListener := &gateway.Spec.Listeners[j]
+			if gatewayListener.Protocol != gatewayv1.HTTPProtocolType {
+				continue
+			}
+
+			target := HTTPSRedirectTarget(gateway, gatewayListener)
+			if target == nil {
+				continue
+			}
+
+			port := uint32(gatewayListener.Port)
+
+			listener, ok := byPort[port]
+			if !ok {
+				listener = &routingv1.Listener{Port: port}
+				byPort[port] = listener
+				listeners = append(listeners, listener)
+			}
+
+			listener.HttpsRedirects = appendHTTPSRedirect(listener.GetHttpsRedirects(), &routingv1.HTTPSRedirect{
+				Hostnames:          hostnameList(gatewayListener.Hostname),
+				Port:               uint32(target.Port),
+				StatusCode:         HTTPSRedirectStatusCode,
+				ExemptPathPrefixes: []string{ACMEChallengePathPrefix},
+			})
+			added = true
+		}
+	}
+
+	if !added {
+		return listeners
+	}
+
+	slices.SortFunc(listeners, func(a, b *routingv1.Listener) int {
+		return cmp.Compare(a.GetPort(), b.GetPort())
+	})
+
+	return listeners
+}
+
+// appendHTTPSRedirect adds a redirect unless one for the same hostnames is
+// already there, as for listeners of several Gateways sharing a port.
+func appendHTTPSRedirect(redirects []*routingv1.HTTPSRedirect, redirect *routingv1.HTTPSRedirect) []*routingv1.HTTPSRedirect {
+	for _, existing := range redirects {
+		if slices.Equal(existing.GetHostnames(), redirect.GetHostnames()) {
+			return redirects
+		}
+	}
+
+	return append(redirects, redirect)
+}
+
+func listenerHostname(listener *gatewayv1.Listener) gatewayv1.Hostname {
+	if listener.Hostname == nil {
+		return ""
+	}
+
+	return *listener.Hostname
+}
+
+func hostnameList(hostname *gatewayv1.Hostname) []string {
+	if hostname == nil || *hostname == "" {
+		return nil
+	}
+
+	return []string{string(*hostname)}
+}
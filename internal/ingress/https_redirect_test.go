@@ -0,0 +1,119 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestHTTPSRedirectEnabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{value: "true", expected: true},
+		{value: "false"},
+		{value: ""},
+		{value: "yes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			gateway := &gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{HTTPSRedirectAnnotation: tt.value},
+			}}
+
+			assert.Equal(t, tt.expected, HTTPSRedirectEnabled(gateway))
+		})
+	}
+}
+
+func TestEnableHTTPSRedirects(t *testing.T) {
+	t.Parallel()
+
+	app := gatewayv1.Hostname("app.example.com")
+	limits := &routingv1.ListenerLimits{MaxRequestBodyBytes: 1 << 20}
+	redirected := map[string]string{HTTPSRedirectAnnotation: "true"}
+
+	gateway := func(annotations map[string]string, listeners ...gatewayv1.Listener) gatewayv1.Gateway {
+		return gatewayv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec:       gatewayv1.GatewaySpec{Listeners: listeners},
+		}
+	}
+
+	http := gatewayv1.Listener{Name: "http", Protocol: gatewayv1.HTTPProtocolType, Port: 80}
+	https := gatewayv1.Listener{Name: "https", Protocol: gatewayv1.HTTPSProtocolType, Port: 443}
+	appHTTP := gatewayv1.Listener{Name: "app-http", Protocol: gatewayv1.HTTPProtocolType, Port: 8080, Hostname: &app}
+	appHTTPS := gatewayv1.Listener{Name: "app-https", Protocol: gatewayv1.HTTPSProtocolType, Port: 8443, Hostname: &app}
+
+	redirect := func(port uint32, hostnames ...string) *routingv1.HTTPSRedirect {
+		return &routingv1.HTTPSRedirect{
+			Hostnames:          hostnames,
+			Port:               port,
+			StatusCode:         301,
+			ExemptPathPrefixes: []string{ACMEChallengePathPrefix},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		listeners []*routingv1.Listener
+		gateways  []gatewayv1.Gateway
+		expected  []*routingv1.Listener
+	}{
+		{
+			name:      "not annotated",
+			listeners: []*routingv1.Listener{{Port: 80, Limits: limits}},
+			gateways:  []gatewayv1.Gateway{gateway(nil, http, https)},
+			expected:  []*routingv1.Listener{{Port: 80, Limits: limits}},
+		},
+		{
+			name:     "redirects to the listener with the same hostname",
+			gateways: []gatewayv1.Gateway{gateway(redirected, http, https, appHTTP, appHTTPS)},
+			expected: []*routingv1.Listener{
+				{Port: 80, HttpsRedirects: []*routingv1.HTTPSRedirect{redirect(443)}},
+				{Port: 8080, HttpsRedirects: []*routingv1.HTTPSRedirect{redirect(8443, "app.example.com")}},
+			},
+		},
+		{
+			name:      "merges into existing listeners",
+			listeners: []*routingv1.Listener{{Port: 443, Limits: limits}, {Port: 80, Limits: limits}},
+			gateways: []gatewayv1.Gateway{
+				gateway(redirected, http, https),
+				gateway(redirected, http, https),
+			},
+			expected: []*routingv1.Listener{
+				{Port: 80, Limits: limits, HttpsRedirects: []*routingv1.HTTPSRedirect{redirect(443)}},
+				{Port: 443, Limits: limits},
+			},
+		},
+		{
+			name:     "no HTTPS listener with the hostname",
+			gateways: []gatewayv1.Gateway{gateway(redirected, appHTTP, https)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := EnableHTTPSRedirects(tt.listeners, tt.gateways)
+
+			assert.Len(t, result, len(tt.expected))
+
+			for i := range tt.expected {
+				assert.True(t, proto.Equal(tt.expected[i], result[i]), "listener %d: %v", i, result[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package ingress
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// LatencyBudgetAnnotation declares the end-to-end latency budget of an
+// HTTPRoute as a Gateway API duration (e.g., "2s", "500ms").
+const LatencyBudgetAnnotation = "pingora.k8s.lex.la/latency-budget"
+
+const (
+	// maxBudgetRetryAttempts caps the retries derived from a latency budget.
+	maxBudgetRetryAttempts = 2
+
+	// budgetBackoffDivisor sets the retry backoff to a fraction of the budget.
+	budgetBackoffDivisor = 10
+
+	// minBudgetBackoff is the smallest derived retry backoff.
+	minBudgetBackoff = 10 * time.Millisecond
+)
+
+// budgetRetryStatusCodes are the responses retried within a latency budget.
+// Only gateway errors are retried, as they usually mean the request never
+// reached a healthy backend.
+//
+//nolint:gochecknoglobals // read-only list of status codes
+var budgetRetryStatusCodes = []uint32{
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// LatencyBudget holds the timeout and retry settings derived from a route's
+// latency budget. They apply to rules without an explicit request timeout.
+type LatencyBudget struct {
+	Budget    time.Duration
+	TimeoutMs uint64
+	Retry     *routingv1.RetryConfig
+}
+
+// ParseLatencyBudget reads LatencyBudgetAnnotation from the given annotations
+// and derives timeout and retry settings from it. It returns nil without an
+// error when the annotation is not set.
+func ParseLatencyBudget(annotations map[string]string) (*LatencyBudget, error) {
+	value, ok := annotations[LatencyBudgetAnnotation]
+	if !ok {
+		return nil, nil //nolint:nilnil // no budget declared
+	}
+
+	budget, err := parseGatewayDuration(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid latency budget %q", value)
+	}
+
+	if budget.Milliseconds() <= 0 {
+		return nil, errors.Newf("latency budget %q must be at least 1ms", value)
+	}
+
+	return DeriveLatencyBudget(budget), nil
+}
+
+// DeriveLatencyBudget derives timeout and retry settings from a latency budget.
+//
+// The whole budget becomes the request timeout. Retries use a backoff of a
+// tenth of the budget and are limited so that their backoff never takes more
+// than half of the budget; budgets too small for a single retry get none.
+func DeriveLatencyBudget(budget time.Duration) *LatencyBudget {
+	result := &LatencyBudget{
+		Budget:    budget,
+		TimeoutMs: uint64(budget.Milliseconds()),
+	}
+
+	backoff := max(budget/budgetBackoffDivisor, minBudgetBackoff)
+	attempts := min(maxBudgetRetryAttempts, int((budget/2)/backoff))
+
+	if attempts > 0 {
+		result.Retry = &routingv1.RetryConfig{
+			Attempts:           uint32(attempts),
+			BackoffMs:          uint64(backoff.Milliseconds()),
+			RetryOnStatusCodes: budgetRetryStatusCodes,
+		}
+	}
+
+	return result
+}
+
+// String summarizes the derived settings for route status messages.
+func (b *LatencyBudget) String() string {
+	summary := fmt.Sprintf("Latency budget %s: request timeout %dms", b.Budget, b.TimeoutMs)
+
+	if b.Retry == nil {
+		return summary + ", no retries"
+	}
+
+	codes := make([]string, 0, len(b.Retry.GetRetryOnStatusCodes()))
+	for _, code := range b.Retry.GetRetryOnStatusCodes() {
+		codes = append(codes, fmt.Sprint(code))
+	}
+
+	return fmt.Sprintf("%s, %d retries with %dms backoff on %s",
+		summary, b.Retry.GetAttempts(), b.Retry.GetBackoffMs(), strings.Join(codes, ", "))
+}
+
+// apply fills the timeout and retry settings of a rule that has no explicit
+// request timeout. Settings from the route spec always take precedence, and
+// rules answered with a fixed response get no retries.
+func (b *LatencyBudget) apply(rule *routingv1.HTTPRouteRule) {
+	if b == nil || rule.GetTimeoutMs() != 0 {
+		return
+	}
+
+	rule.TimeoutMs = b.TimeoutMs
+	clampBackendTimeout(rule)
+
+	if b.Retry != nil && rule.GetRetry() == nil && rule.GetFixedResponse() == nil {
+		rule.Retry = &routingv1.RetryConfig{
+			Attempts:           b.Retry.GetAttempts(),
+			BackoffMs:          b.Retry.GetBackoffMs(),
+			RetryOnStatusCodes: append([]uint32(nil), b.Retry.GetRetryOnStatusCodes()...),
+		}
+	}
+}
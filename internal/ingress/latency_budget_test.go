@@ -0,0 +1,154 @@
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestDeriveLatencyBudget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		budget          time.Duration
+		expectedTimeout uint64
+		expectedRetry   *routingv1.RetryConfig
+	}{
+		{
+			name:            "large budget allows two retries",
+			budget:          2 * time.Second,
+			expectedTimeout: 2000,
+			expectedRetry: &routingv1.RetryConfig{
+				Attempts: 2, BackoffMs: 200, RetryOnStatusCodes: []uint32{502, 503, 504},
+			},
+		},
+		{
+			name:            "small budget uses the minimum backoff",
+			budget:          30 * time.Millisecond,
+			expectedTimeout: 30,
+			expectedRetry: &routingv1.RetryConfig{
+				Attempts: 1, BackoffMs: 10, RetryOnStatusCodes: []uint32{502, 503, 504},
+			},
+		},
+		{
+			name:            "tiny budget gets no retries",
+			budget:          15 * time.Millisecond,
+			expectedTimeout: 15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := DeriveLatencyBudget(tt.budget)
+			assert.Equal(t, tt.expectedTimeout, result.TimeoutMs)
+			assert.Equal(t, tt.expectedRetry, result.Retry)
+		})
+	}
+}
+
+func TestParseLatencyBudget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectNil   bool
+		expectErr   bool
+	}{
+		{name: "no annotation", annotations: nil, expectNil: true},
+		{name: "valid budget", annotations: map[string]string{LatencyBudgetAnnotation: "500ms"}},
+		{name: "invalid duration", annotations: map[string]string{LatencyBudgetAnnotation: "fast"}, expectErr: true},
+		{name: "zero budget", annotations: map[string]string{LatencyBudgetAnnotation: "0s"}, expectErr: true},
+		{name: "negative budget", annotations: map[string]string{LatencyBudgetAnnotation: "-1s"}, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			budget, err := ParseLatencyBudget(tt.annotations)
+			if tt.expectErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectNil, budget == nil)
+		})
+	}
+}
+
+func TestLatencyBudget_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"Latency budget 2s: request timeout 2000ms, 2 retries with 200ms backoff on 502, 503, 504",
+		DeriveLatencyBudget(2*time.Second).String())
+	assert.Equal(t,
+		"Latency budget 5ms: request timeout 5ms, no retries",
+		DeriveLatencyBudget(5*time.Millisecond).String())
+}
+
+func TestBuildHTTPRoute_LatencyBudget(t *testing.T) {
+	t.Parallel()
+
+	backendRefs := []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{LatencyBudgetAnnotation: "1s"},
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{BackendRefs: backendRefs},
+				{
+					BackendRefs: backendRefs,
+					Timeouts:    &gatewayv1.HTTPRouteTimeouts{Request: ptrTo(gatewayv1.Duration("30s"))},
+				},
+			},
+		},
+	}
+
+	result := NewPingoraBuilder("cluster.local").BuildHTTPRoute(route)
+	require.Len(t, result.GetRules(), 2)
+
+	derived := result.GetRules()[0]
+	assert.Equal(t, uint64(1000), derived.GetTimeoutMs())
+	require.NotNil(t, derived.GetRetry())
+	assert.Equal(t, uint32(2), derived.GetRetry().GetAttempts())
+	assert.Equal(t, uint64(100), derived.GetRetry().GetBackoffMs())
+
+	explicit := result.GetRules()[1]
+	assert.Equal(t, uint64(30000), explicit.GetTimeoutMs())
+	assert.Nil(t, explicit.GetRetry())
+}
+
+func TestBuildHTTPRoute_InvalidLatencyBudget(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{LatencyBudgetAnnotation: "soon"},
+		},
+		Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{}}},
+	}
+
+	result := NewPingoraBuilder("cluster.local").BuildHTTPRoute(route)
+	require.Len(t, result.GetRules(), 1)
+	assert.Zero(t, result.GetRules()[0].GetTimeoutMs())
+	assert.Nil(t, result.GetRules()[0].GetRetry())
+}
@@ -2,13 +2,31 @@ package ingress
 
 import (
 	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
 )
 
+const (
+	// NoBackendsStatusCode is returned by the proxy for rules without usable backends.
+	// Per Gateway API spec, requests that cannot be routed to a valid backend
+	// must receive a 500 response.
+	NoBackendsStatusCode = http.StatusInternalServerError
+
+	// noBackendRefsReason is reported when a rule has no backendRefs at all.
+	noBackendRefsReason = "rule has no backendRefs"
+
+	// noValidBackendRefsReason is reported when none of a rule's backendRefs
+	// can be used, or all that can have weight 0.
+	noValidBackendRefsReason = "rule has no valid backendRefs"
+)
+
 // parseGatewayDuration parses a Gateway API duration string (e.g., "10s", "1m").
 //
 //nolint:wrapcheck // standard library errors are descriptive
@@ -16,24 +34,114 @@ func parseGatewayDuration(s string) (time.Duration, error) {
 	return time.ParseDuration(s)
 }
 
+// ruleName returns the name of a route rule, or an empty string if unnamed.
+func ruleName(name *gatewayv1.SectionName) string {
+	if name == nil {
+		return ""
+	}
+
+	return string(*name)
+}
+
+// durationMs converts an optional Gateway API duration to milliseconds.
+// Unset and invalid durations yield 0.
+func durationMs(duration *gatewayv1.Duration) uint64 {
+	if duration == nil {
+		return 0
+	}
+
+	parsed, err := parseGatewayDuration(string(*duration))
+	if err != nil || parsed.Milliseconds() <= 0 {
+		return 0
+	}
+
+	return uint64(parsed.Milliseconds())
+}
+
+// ClusterDomainSource provides the cluster domain used for backend addresses.
+type ClusterDomainSource interface {
+	ClusterDomain() string
+}
+
+// staticClusterDomain is a ClusterDomainSource that never changes.
+type staticClusterDomain string
+
+// ClusterDomain returns the fixed cluster domain.
+func (d staticClusterDomain) ClusterDomain() string {
+	return string(d)
+}
+
 // PingoraBuilder builds Pingora route configurations from Gateway API resources.
 type PingoraBuilder struct {
-	clusterDomain string
+	clusterDomain ClusterDomainSource
+	idScheme      RouteIDScheme
+
+	corsMu       sync.RWMutex
+	corsPolicies map[types.NamespacedName]*routingv1.CORSPolicy
+
+	rateLimitMu sync.RWMutex
+	rateLimits  map[PolicyTarget]*routingv1.RateLimit
+
+	authMu       sync.RWMutex
+	authPolicies map[PolicyTarget]authEntry
+
+	accessControlMu sync.RWMutex
+	accessControls  map[PolicyTarget]*routingv1.AccessControl
+
+	cacheMu       sync.RWMutex
+	cachePolicies map[PolicyTarget]*routingv1.CacheConfig
+
+	accessLogMu sync.RWMutex
+	accessLogs  map[PolicyTarget]*routingv1.RouteAccessLog
+
+	backendPolicyMu sync.RWMutex
+	backendSettings map[PolicyTarget]backendSettings
+
+	backendsMu sync.RWMutex
+	backends   map[types.NamespacedName]*routingv1.Backend
+
+	targetsMu sync.RWMutex
+	targets   *backendRefTargets
+
+	backendTLSMu sync.RWMutex
+	backendTLS   map[PolicyTarget]*routingv1.BackendTLS
+
+	grpcMu         sync.RWMutex
+	grpcWebConfigs map[PolicyTarget]*routingv1.GRPCWebConfig
 }
 
-// NewPingoraBuilder creates a new PingoraBuilder.
+// NewPingoraBuilder creates a new PingoraBuilder with a fixed cluster domain.
 func NewPingoraBuilder(clusterDomain string) *PingoraBuilder {
+	return NewPingoraBuilderWithSource(staticClusterDomain(clusterDomain))
+}
+
+// NewPingoraBuilderWithSource creates a new PingoraBuilder that reads the
+// cluster domain from source on every build, so domain changes take effect
+// on the next sync.
+func NewPingoraBuilderWithSource(source ClusterDomainSource) *PingoraBuilder {
 	return &PingoraBuilder{
-		clusterDomain: clusterDomain,
+		clusterDomain: source,
+		idScheme:      DefaultRouteIDScheme,
 	}
 }
 
+// SetRouteIDScheme selects how route IDs are derived. It must be called
+// before the builder is used.
+func (b *PingoraBuilder) SetRouteIDScheme(scheme RouteIDScheme) {
+	b.idScheme = scheme
+}
+
+// RouteID returns the ID of the Pingora route built from route.
+func (b *PingoraBuilder) RouteID(route metav1.Object) string {
+	return RouteID(b.idScheme, route)
+}
+
 // BuildHTTPRoute converts a Gateway API HTTPRoute to a Pingora HTTPRoute.
 //
 //nolint:dupl // HTTPRoute and GRPCRoute have similar structure but different types
 func (b *PingoraBuilder) BuildHTTPRoute(route *gatewayv1.HTTPRoute) *routingv1.HTTPRoute {
 	result := &routingv1.HTTPRoute{
-		Id:        fmt.Sprintf("%s/%s", route.Namespace, route.Name),
+		Id:        RouteID(b.idScheme, route),
 		Hostnames: make([]string, 0, len(route.Spec.Hostnames)),
 		Rules:     make([]*routingv1.HTTPRouteRule, 0, len(route.Spec.Rules)),
 	}
@@ -43,9 +151,40 @@ func (b *PingoraBuilder) BuildHTTPRoute(route *gatewayv1.HTTPRoute) *routingv1.H
 		result.Hostnames = append(result.Hostnames, string(hostname))
 	}
 
+	// An invalid budget is reported in the route status and otherwise ignored
+	budget, _ := ParseLatencyBudget(route.Annotations)
+
 	// Convert rules
-	for _, rule := range route.Spec.Rules {
-		result.Rules = append(result.Rules, b.buildHTTPRouteRule(route.Namespace, &rule))
+	for i, rule := range route.Spec.Rules {
+		// Rules with invalid regexes are dropped, see HTTPRouteRegexIssues
+		if httpRuleRegexError(&rule) != nil {
+			continue
+		}
+
+		ruleResult := b.buildHTTPRouteRule(route.Namespace, &rule)
+		ruleResult.SessionPersistence = buildSessionPersistence(result.GetId(), i, rule.SessionPersistence)
+		budget.apply(ruleResult)
+		ruleResult.RateLimit = b.rateLimitFor(route, ruleResult.GetName())
+		ruleResult.AccessControl = b.accessControlFor(route, ruleResult.GetName())
+		ruleResult.Cache = b.cacheFor(route, ruleResult.GetName())
+		ruleResult.AccessLog = b.accessLogFor(route, ruleResult.GetName())
+
+		// An unresolved auth policy overrides the backends
+		auth, authResponse := b.authFor(route, ruleResult.GetName())
+		ruleResult.Auth = auth
+
+		if authResponse != nil {
+			ruleResult.FixedResponse = authResponse
+			ruleResult.InvalidBackendWeight = 0
+			ruleResult.Retry = nil
+		}
+
+		if retriesDisabled(route.Annotations, rule.Name) {
+			ruleResult.Retry = nil
+			ruleResult.DisableRetries = true
+		}
+
+		result.Rules = append(result.Rules, ruleResult)
 	}
 
 	return result
@@ -56,7 +195,7 @@ func (b *PingoraBuilder) BuildHTTPRoute(route *gatewayv1.HTTPRoute) *routingv1.H
 //nolint:dupl // GRPCRoute and HTTPRoute have similar structure but different types
 func (b *PingoraBuilder) BuildGRPCRoute(route *gatewayv1.GRPCRoute) *routingv1.GRPCRoute {
 	result := &routingv1.GRPCRoute{
-		Id:        fmt.Sprintf("%s/%s", route.Namespace, route.Name),
+		Id:        RouteID(b.idScheme, route),
 		Hostnames: make([]string, 0, len(route.Spec.Hostnames)),
 		Rules:     make([]*routingv1.GRPCRouteRule, 0, len(route.Spec.Rules)),
 	}
@@ -66,9 +205,21 @@ func (b *PingoraBuilder) BuildGRPCRoute(route *gatewayv1.GRPCRoute) *routingv1.G
 		result.Hostnames = append(result.Hostnames, string(hostname))
 	}
 
+	// Invalid timeouts are reported in the route status and otherwise ignored
+	timeouts, _ := ParseGRPCTimeouts(route.Annotations)
+
 	// Convert rules
 	for _, rule := range route.Spec.Rules {
-		result.Rules = append(result.Rules, b.buildGRPCRouteRule(route.Namespace, &rule))
+		// Rules with invalid regexes are dropped, see GRPCRouteRegexIssues
+		if grpcRuleRegexError(&rule) != nil {
+			continue
+		}
+
+		ruleResult := b.buildGRPCRouteRule(route.Namespace, &rule)
+		ruleResult.DisableRetries = retriesDisabled(route.Annotations, rule.Name)
+		ruleResult.GrpcWeb = b.grpcWebFor(route, ruleResult.GetName())
+		timeouts.apply(ruleResult)
+		result.Rules = append(result.Rules, ruleResult)
 	}
 
 	return result
@@ -76,6 +227,7 @@ func (b *PingoraBuilder) BuildGRPCRoute(route *gatewayv1.GRPCRoute) *routingv1.G
 
 func (b *PingoraBuilder) buildHTTPRouteRule(namespace string, rule *gatewayv1.HTTPRouteRule) *routingv1.HTTPRouteRule {
 	result := &routingv1.HTTPRouteRule{
+		Name:     ruleName(rule.Name),
 		Matches:  make([]*routingv1.HTTPRouteMatch, 0),
 		Backends: make([]*routingv1.Backend, 0),
 	}
@@ -96,22 +248,45 @@ func (b *PingoraBuilder) buildHTTPRouteRule(namespace string, rule *gatewayv1.HT
 	}
 
 	// Convert backend references
+	var invalidWeight uint32
+
 	for _, backendRef := range rule.BackendRefs {
-		backend := b.buildBackend(namespace, &backendRef.BackendRef)
-		if backend != nil {
+		backend := b.buildBackend(kindHTTPRoute, namespace, &backendRef.BackendRef)
+
+		switch {
+		case backend == nil:
+			invalidWeight += backendRefWeight(&backendRef.BackendRef)
+		case backend.GetWeight() > 0:
+			// Backends with weight 0 receive no requests
+			applyHTTPBackendFilters(backend, backendRef.Filters)
 			result.Backends = append(result.Backends, backend)
 		}
 	}
 
+	result.FixedResponse = noBackendsResponse(len(rule.BackendRefs), len(result.Backends))
+
+	// Convert CORS filters; an unresolved policy overrides the backends
+	cors, corsResponse := b.buildCORS(namespace, rule.Filters)
+	result.Cors = cors
+
+	if corsResponse != nil {
+		result.FixedResponse = corsResponse
+	}
+
+	if result.FixedResponse == nil {
+		result.InvalidBackendWeight = invalidWeight
+	}
+
 	// Convert timeouts
-	if rule.Timeouts != nil && rule.Timeouts.Request != nil {
-		timeout, err := parseGatewayDuration(string(*rule.Timeouts.Request))
-		if err == nil {
-			ms := timeout.Milliseconds()
-			if ms > 0 {
-				result.TimeoutMs = uint64(ms)
-			}
-		}
+	if rule.Timeouts != nil {
+		result.TimeoutMs = durationMs(rule.Timeouts.Request)
+		result.BackendTimeoutMs = durationMs(rule.Timeouts.BackendRequest)
+		clampBackendTimeout(result)
+	}
+
+	// Convert retries; rules answered with a fixed response have nothing to retry
+	if result.FixedResponse == nil {
+		result.Retry = buildRetry(rule)
 	}
 
 	return result
@@ -125,10 +300,22 @@ func (b *PingoraBuilder) buildHTTPRouteMatch(match *gatewayv1.HTTPRouteMatch) *r
 
 	// Convert path match
 	if match.Path != nil {
+		// Unset fields take the Gateway API defaults, PathPrefix "/"
 		result.Path = &routingv1.PathMatch{
-			Value: *match.Path.Value,
+			Type:  routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX,
+			Value: "/",
+		}
+
+		if match.Path.Value != nil {
+			result.Path.Value = *match.Path.Value
 		}
-		switch *match.Path.Type {
+
+		pathType := gatewayv1.PathMatchPathPrefix
+		if match.Path.Type != nil {
+			pathType = *match.Path.Type
+		}
+
+		switch pathType {
 		case gatewayv1.PathMatchExact:
 			result.Path.Type = routingv1.PathMatchType_PATH_MATCH_TYPE_EXACT
 		case gatewayv1.PathMatchPathPrefix:
@@ -198,6 +385,7 @@ func (b *PingoraBuilder) buildQueryParamMatch(match *gatewayv1.HTTPQueryParamMat
 
 func (b *PingoraBuilder) buildGRPCRouteRule(namespace string, rule *gatewayv1.GRPCRouteRule) *routingv1.GRPCRouteRule {
 	result := &routingv1.GRPCRouteRule{
+		Name:     ruleName(rule.Name),
 		Matches:  make([]*routingv1.GRPCRouteMatch, 0),
 		Backends: make([]*routingv1.Backend, 0),
 	}
@@ -208,13 +396,29 @@ func (b *PingoraBuilder) buildGRPCRouteRule(namespace string, rule *gatewayv1.GR
 	}
 
 	// Convert backend references
+	var invalidWeight uint32
+
 	for _, backendRef := range rule.BackendRefs {
-		backend := b.buildBackend(namespace, &backendRef.BackendRef)
-		if backend != nil {
+		backend := b.buildBackend(kindGRPCRoute, namespace, &backendRef.BackendRef)
+
+		switch {
+		case backend == nil:
+			invalidWeight += backendRefWeight(&backendRef.BackendRef)
+		case backend.GetWeight() > 0:
+			// Backends with weight 0 receive no requests
+			applyGRPCBackendFilters(backend, backendRef.Filters)
 			result.Backends = append(result.Backends, backend)
 		}
 	}
 
+	result.FixedResponse = noBackendsResponse(len(rule.BackendRefs), len(result.Backends))
+	if result.FixedResponse == nil {
+		result.InvalidBackendWeight = invalidWeight
+	}
+
+	// Convert header modifier and mirror filters
+	b.buildGRPCRuleFilters(namespace, result, rule.Filters)
+
 	return result
 }
 
@@ -275,9 +479,58 @@ func (b *PingoraBuilder) buildGRPCHeaderMatch(match *gatewayv1.GRPCHeaderMatch)
 	return result
 }
 
-func (b *PingoraBuilder) buildBackend(namespace string, ref *gatewayv1.BackendRef) *routingv1.Backend {
-	// Only support Service backends
-	if ref.Kind != nil && *ref.Kind != "Service" {
+// clampBackendTimeout limits the backend timeout to the request timeout.
+// Gateway API forbids a longer backendRequest timeout; routes that bypassed
+// validation are clamped instead of being rejected.
+func clampBackendTimeout(rule *routingv1.HTTPRouteRule) {
+	if rule.GetTimeoutMs() > 0 && rule.GetBackendTimeoutMs() > rule.GetTimeoutMs() {
+		rule.BackendTimeoutMs = rule.GetTimeoutMs()
+	}
+}
+
+// noBackendsResponse returns the fixed response for a rule that ended up without
+// usable backends, or nil if at least one backend was built.
+func noBackendsResponse(refCount, backendCount int) *routingv1.FixedResponse {
+	if backendCount > 0 {
+		return nil
+	}
+
+	reason := noValidBackendRefsReason
+	if refCount == 0 {
+		reason = noBackendRefsReason
+	}
+
+	return &routingv1.FixedResponse{
+		StatusCode: NoBackendsStatusCode,
+		Reason:     reason,
+	}
+}
+
+// backendRefWeight returns the effective weight of a backendRef.
+// Per Gateway API spec, an unset weight defaults to 1.
+func backendRefWeight(ref *gatewayv1.BackendRef) uint32 {
+	if ref.Weight == nil {
+		return 1
+	}
+
+	if *ref.Weight < 0 {
+		return 0
+	}
+
+	return uint32(*ref.Weight)
+}
+
+// serviceAddress returns the in-cluster address of a Service port.
+func (b *PingoraBuilder) serviceAddress(name, namespace string, port gatewayv1.PortNumber) string {
+	return fmt.Sprintf("%s.%s.svc.%s:%d", name, namespace, b.clusterDomain.ClusterDomain(), port)
+}
+
+// buildBackend converts a backendRef of a route of routeKind in namespace,
+// or returns nil if it cannot be used. A weight of 0 is kept; such backends
+// must not receive requests, so rules leave them out.
+func (b *PingoraBuilder) buildBackend(routeKind, namespace string, ref *gatewayv1.BackendRef) *routingv1.Backend {
+	// Only support Service backends with a port and PingoraBackends
+	if !isUsableBackendRef(ref) || !b.backendRefResolved(routeKind, namespace, ref) {
 		return nil
 	}
 
@@ -287,24 +540,34 @@ func (b *PingoraBuilder) buildBackend(namespace string, ref *gatewayv1.BackendRe
 		backendNamespace = string(*ref.Namespace)
 	}
 
-	// Build service address
-	address := fmt.Sprintf("%s.%s.svc.%s:%d",
-		string(ref.Name),
-		backendNamespace,
-		b.clusterDomain,
-		*ref.Port,
-	)
+	var result *routingv1.Backend
 
-	result := &routingv1.Backend{
-		Address:  address,
-		Weight:   1,
-		Protocol: routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTP,
-	}
+	if IsPingoraBackendRef(ref) {
+		result = b.resolvePingoraBackend(backendNamespace, ref)
+		if result == nil {
+			return nil
+		}
+	} else {
+		settings := b.backendSettingsFor(backendNamespace, string(ref.Name))
 
-	// Set weight if specified
-	if ref.Weight != nil && *ref.Weight > 0 {
-		result.Weight = uint32(*ref.Weight)
+		result = &routingv1.Backend{
+			Address:        b.serviceAddress(string(ref.Name), backendNamespace, *ref.Port),
+			Protocol:       routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTP,
+			CircuitBreaker: settings.circuitBreaker,
+			HealthCheck:    settings.healthCheck,
+		}
+
+		if tls, ok := b.backendTLSFor(backendNamespace, string(ref.Name)); ok {
+			if tls == nil {
+				return nil
+			}
+
+			result.Protocol = routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTPS
+			result.Tls = tls
+		}
 	}
 
+	result.Weight = backendRefWeight(ref)
+
 	return result
 }
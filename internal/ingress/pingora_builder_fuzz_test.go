@@ -0,0 +1,399 @@
+package ingress
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// fuzzInput decodes route specs from the bytes of a fuzz input. Reads past
+// the end yield zero values, so that every input decodes to a route.
+type fuzzInput struct {
+	data []byte
+}
+
+func (in *fuzzInput) byte() byte {
+	if len(in.data) == 0 {
+		return 0
+	}
+
+	b := in.data[0]
+	in.data = in.data[1:]
+
+	return b
+}
+
+func (in *fuzzInput) bool() bool {
+	return in.byte()&1 == 1
+}
+
+func (in *fuzzInput) intn(n int) int {
+	return int(in.byte()) % n
+}
+
+func (in *fuzzInput) int32() int32 {
+	var buf [4]byte
+	for i := range buf {
+		buf[i] = in.byte()
+	}
+
+	return int32(binary.LittleEndian.Uint32(buf[:])) //nolint:gosec // any bit pattern is a valid fuzz value
+}
+
+// string returns up to 16 raw bytes, which need not be valid UTF-8.
+func (in *fuzzInput) string() string {
+	n := min(in.intn(17), len(in.data))
+	s := string(in.data[:n])
+	in.data = in.data[n:]
+
+	return s
+}
+
+// pick returns one of the values, or a raw string when the input chooses so.
+func (in *fuzzInput) pick(values ...string) string {
+	idx := in.intn(len(values) + 1)
+	if idx == len(values) {
+		return in.string()
+	}
+
+	return values[idx]
+}
+
+// weight returns an extreme or arbitrary backend weight.
+func (in *fuzzInput) weight() int32 {
+	weights := []int32{0, 1, -1, MaxBackendWeight, math.MaxInt32, math.MinInt32}
+
+	idx := in.intn(len(weights) + 1)
+	if idx == len(weights) {
+		return in.int32()
+	}
+
+	return weights[idx]
+}
+
+// optional returns a pointer to value(), or nil when the input chooses so.
+func optional[T any](in *fuzzInput, value func() T) *T {
+	if !in.bool() {
+		return nil
+	}
+
+	v := value()
+
+	return &v
+}
+
+func (in *fuzzInput) duration() gatewayv1.Duration {
+	return gatewayv1.Duration(in.pick("10s", "0s", "-1s", "1ms", "1.5s", "1h30m", "9999999h", "1ns", "", "bogus"))
+}
+
+func (in *fuzzInput) sectionName() gatewayv1.SectionName {
+	return gatewayv1.SectionName(in.pick("rule", "api", ""))
+}
+
+func (in *fuzzInput) regex() string {
+	return in.pick("/", "/api", "", "^/v[0-9]+$", "(", "[a-z", `\`)
+}
+
+func (in *fuzzInput) backendRef() gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Kind:      optional(in, func() gatewayv1.Kind { return gatewayv1.Kind(in.pick(kindService, "S3Bucket", "")) }),
+			Name:      gatewayv1.ObjectName(in.pick("app", "")),
+			Namespace: optional(in, func() gatewayv1.Namespace { return gatewayv1.Namespace(in.pick("other", "")) }),
+			Port:      optional(in, func() gatewayv1.PortNumber { return gatewayv1.PortNumber(in.int32()) }),
+		},
+		Weight: optional(in, in.weight),
+	}
+}
+
+func (in *fuzzInput) annotations() map[string]string {
+	if !in.bool() {
+		return nil
+	}
+
+	return map[string]string{
+		LatencyBudgetAnnotation:     in.pick("1s", "0s", "-1s", "bogus"),
+		DisableRetriesAnnotation:    in.pick("true", "rule", "rule,api", ""),
+		GRPCTimeoutAnnotation:       in.pick("1s", "0s", "-1s", "bogus"),
+		GRPCHeaderTimeoutAnnotation: in.pick("1s", "0s", "bogus"),
+	}
+}
+
+func (in *fuzzInput) httpRouteMatch() gatewayv1.HTTPRouteMatch {
+	match := gatewayv1.HTTPRouteMatch{
+		Path: optional(in, func() gatewayv1.HTTPPathMatch {
+			return gatewayv1.HTTPPathMatch{
+				Type: optional(in, func() gatewayv1.PathMatchType {
+					return gatewayv1.PathMatchType(in.pick(
+						string(gatewayv1.PathMatchExact),
+						string(gatewayv1.PathMatchPathPrefix),
+						string(gatewayv1.PathMatchRegularExpression),
+					))
+				}),
+				Value: optional(in, in.regex),
+			}
+		}),
+		Method: optional(in, func() gatewayv1.HTTPMethod { return gatewayv1.HTTPMethod(in.pick("GET", "POST")) }),
+	}
+
+	for range in.intn(3) {
+		match.Headers = append(match.Headers, gatewayv1.HTTPHeaderMatch{
+			Type: optional(in, func() gatewayv1.HeaderMatchType {
+				return gatewayv1.HeaderMatchType(in.pick(
+					string(gatewayv1.HeaderMatchExact),
+					string(gatewayv1.HeaderMatchRegularExpression),
+				))
+			}),
+			Name:  gatewayv1.HTTPHeaderName(in.string()),
+			Value: in.regex(),
+		})
+	}
+
+	for range in.intn(3) {
+		match.QueryParams = append(match.QueryParams, gatewayv1.HTTPQueryParamMatch{
+			Type: optional(in, func() gatewayv1.QueryParamMatchType {
+				return gatewayv1.QueryParamMatchType(in.pick(
+					string(gatewayv1.QueryParamMatchExact),
+					string(gatewayv1.QueryParamMatchRegularExpression),
+				))
+			}),
+			Name:  gatewayv1.HTTPHeaderName(in.string()),
+			Value: in.regex(),
+		})
+	}
+
+	return match
+}
+
+func (in *fuzzInput) httpRouteRule() gatewayv1.HTTPRouteRule {
+	rule := gatewayv1.HTTPRouteRule{
+		Name: optional(in, in.sectionName),
+		Timeouts: optional(in, func() gatewayv1.HTTPRouteTimeouts {
+			return gatewayv1.HTTPRouteTimeouts{
+				Request:        optional(in, in.duration),
+				BackendRequest: optional(in, in.duration),
+			}
+		}),
+		Retry: optional(in, func() gatewayv1.HTTPRouteRetry {
+			return gatewayv1.HTTPRouteRetry{
+				Codes:    []gatewayv1.HTTPRouteRetryStatusCode{gatewayv1.HTTPRouteRetryStatusCode(in.int32())},
+				Attempts: optional(in, func() int { return int(in.int32()) }),
+				Backoff:  optional(in, in.duration),
+			}
+		}),
+		SessionPersistence: optional(in, func() gatewayv1.SessionPersistence {
+			return gatewayv1.SessionPersistence{
+				SessionName:     optional(in, in.string),
+				AbsoluteTimeout: optional(in, in.duration),
+				IdleTimeout:     optional(in, in.duration),
+				Type: optional(in, func() gatewayv1.SessionPersistenceType {
+					return gatewayv1.SessionPersistenceType(in.pick(
+						string(gatewayv1.CookieBasedSessionPersistence),
+						string(gatewayv1.HeaderBasedSessionPersistence),
+					))
+				}),
+			}
+		}),
+	}
+
+	for range in.intn(4) {
+		rule.Matches = append(rule.Matches, in.httpRouteMatch())
+	}
+
+	for range in.intn(4) {
+		rule.BackendRefs = append(rule.BackendRefs, gatewayv1.HTTPBackendRef{BackendRef: in.backendRef()})
+	}
+
+	// CORS filters with and without their configuration
+	for range in.intn(2) {
+		filter := gatewayv1.HTTPRouteFilter{Type: gatewayv1.HTTPRouteFilterCORS}
+		if in.bool() {
+			filter.CORS = &gatewayv1.HTTPCORSFilter{
+				AllowOrigins: []gatewayv1.CORSOrigin{gatewayv1.CORSOrigin(in.pick("*", "https://example.com"))},
+				MaxAge:       in.int32(),
+			}
+		}
+
+		rule.Filters = append(rule.Filters, filter)
+	}
+
+	return rule
+}
+
+func (in *fuzzInput) grpcRouteRule() gatewayv1.GRPCRouteRule {
+	rule := gatewayv1.GRPCRouteRule{Name: optional(in, in.sectionName)}
+
+	for range in.intn(4) {
+		match := gatewayv1.GRPCRouteMatch{
+			Method: optional(in, func() gatewayv1.GRPCMethodMatch {
+				return gatewayv1.GRPCMethodMatch{
+					Type: optional(in, func() gatewayv1.GRPCMethodMatchType {
+						return gatewayv1.GRPCMethodMatchType(in.pick(
+							string(gatewayv1.GRPCMethodMatchExact),
+							string(gatewayv1.GRPCMethodMatchRegularExpression),
+						))
+					}),
+					Service: optional(in, in.regex),
+					Method:  optional(in, in.regex),
+				}
+			}),
+		}
+
+		for range in.intn(3) {
+			match.Headers = append(match.Headers, gatewayv1.GRPCHeaderMatch{
+				Type: optional(in, func() gatewayv1.GRPCHeaderMatchType {
+					return gatewayv1.GRPCHeaderMatchType(in.pick(
+						string(gatewayv1.GRPCHeaderMatchExact),
+						string(gatewayv1.GRPCHeaderMatchRegularExpression),
+					))
+				}),
+				Name:  gatewayv1.GRPCHeaderName(in.string()),
+				Value: in.regex(),
+			})
+		}
+
+		rule.Matches = append(rule.Matches, match)
+	}
+
+	for range in.intn(4) {
+		rule.BackendRefs = append(rule.BackendRefs, gatewayv1.GRPCBackendRef{BackendRef: in.backendRef()})
+	}
+
+	return rule
+}
+
+func fuzzObjectMeta(in *fuzzInput) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        in.pick("route", ""),
+		Namespace:   in.pick("default", ""),
+		UID:         "0b7e3c2a-5f4e-4d1b-9c8a-7e6f5d4c3b2a",
+		Annotations: in.annotations(),
+	}
+}
+
+// fuzzSeeds are inputs that reach the interesting branches quickly.
+var fuzzSeeds = [][]byte{
+	nil,
+	{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	{1, 1, 1, 3, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+	[]byte("\x01\x03\x01\x02\x01\x05(\x03\x01\x00\x01\x01\xff\xff\xff\xff\x01\x05"),
+}
+
+func FuzzBuildHTTPRoute(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	builder := NewPingoraBuilder("cluster.local")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		in := &fuzzInput{data: data}
+
+		route := &gatewayv1.HTTPRoute{ObjectMeta: fuzzObjectMeta(in)}
+		for range in.intn(4) {
+			route.Spec.Hostnames = append(route.Spec.Hostnames, gatewayv1.Hostname(in.pick("example.com", "*.example.com")))
+		}
+
+		for range in.intn(4) {
+			route.Spec.Rules = append(route.Spec.Rules, in.httpRouteRule())
+		}
+
+		result := builder.BuildHTTPRoute(route)
+
+		if len(result.GetRules()) > len(route.Spec.Rules) {
+			t.Fatalf("built %d rules from %d", len(result.GetRules()), len(route.Spec.Rules))
+		}
+
+		for _, rule := range result.GetRules() {
+			checkHTTPRouteRule(t, rule)
+		}
+	})
+}
+
+func FuzzBuildGRPCRoute(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	builder := NewPingoraBuilder("cluster.local")
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		in := &fuzzInput{data: data}
+
+		route := &gatewayv1.GRPCRoute{ObjectMeta: fuzzObjectMeta(in)}
+		for range in.intn(4) {
+			route.Spec.Hostnames = append(route.Spec.Hostnames, gatewayv1.Hostname(in.pick("example.com", "*.example.com")))
+		}
+
+		for range in.intn(4) {
+			route.Spec.Rules = append(route.Spec.Rules, in.grpcRouteRule())
+		}
+
+		result := builder.BuildGRPCRoute(route)
+
+		if len(result.GetRules()) > len(route.Spec.Rules) {
+			t.Fatalf("built %d rules from %d", len(result.GetRules()), len(route.Spec.Rules))
+		}
+
+		for _, rule := range result.GetRules() {
+			if len(rule.GetBackends()) == 0 && rule.GetFixedResponse() == nil {
+				t.Fatal("rule without backends has no fixed response")
+			}
+
+			checkBackends(t, rule.GetBackends())
+		}
+	})
+}
+
+// checkHTTPRouteRule fails if a built rule cannot be programmed as is.
+func checkHTTPRouteRule(t *testing.T, rule *routingv1.HTTPRouteRule) {
+	t.Helper()
+
+	if len(rule.GetMatches()) == 0 {
+		t.Fatal("rule has no matches")
+	}
+
+	for _, match := range rule.GetMatches() {
+		if match.GetPath() != nil && match.GetPath().GetValue() == "" &&
+			match.GetPath().GetType() == routingv1.PathMatchType_PATH_MATCH_TYPE_UNSPECIFIED {
+			t.Fatal("path match has neither type nor value")
+		}
+	}
+
+	if len(rule.GetBackends()) == 0 && rule.GetFixedResponse() == nil {
+		t.Fatal("rule without backends has no fixed response")
+	}
+
+	if rule.GetFixedResponse() != nil && rule.GetInvalidBackendWeight() != 0 {
+		t.Fatal("rule with a fixed response has an invalid backend weight")
+	}
+
+	if rule.GetTimeoutMs() > 0 && rule.GetBackendTimeoutMs() > rule.GetTimeoutMs() {
+		t.Fatalf("backend timeout %dms exceeds request timeout %dms", rule.GetBackendTimeoutMs(), rule.GetTimeoutMs())
+	}
+
+	if rule.GetDisableRetries() && rule.GetRetry() != nil {
+		t.Fatal("rule with disabled retries has a retry policy")
+	}
+
+	checkBackends(t, rule.GetBackends())
+}
+
+func checkBackends(t *testing.T, backends []*routingv1.Backend) {
+	t.Helper()
+
+	for _, backend := range backends {
+		if backend.GetAddress() == "" {
+			t.Fatal("backend has no address")
+		}
+
+		if backend.GetWeight() == 0 {
+			t.Fatal("backend has zero weight")
+		}
+	}
+}
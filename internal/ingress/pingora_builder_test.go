@@ -0,0 +1,399 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+func serviceRef(name string, port int32) gatewayv1.BackendRef {
+	return gatewayv1.BackendRef{
+		BackendObjectReference: gatewayv1.BackendObjectReference{
+			Name: gatewayv1.ObjectName(name),
+			Port: ptrTo(gatewayv1.PortNumber(port)),
+		},
+	}
+}
+
+func withWeight(ref gatewayv1.BackendRef, weight int32) gatewayv1.BackendRef {
+	ref.Weight = ptrTo(weight)
+
+	return ref
+}
+
+func TestBuildHTTPRoute_NoBackends(t *testing.T) {
+	t.Parallel()
+
+	unsupported := serviceRef("bucket", 80)
+	unsupported.Kind = ptrTo(gatewayv1.Kind("S3Bucket"))
+
+	noPort := serviceRef("app", 8080)
+	noPort.Port = nil
+
+	tests := []struct {
+		name                  string
+		backendRefs           []gatewayv1.HTTPBackendRef
+		expectedBackends      int
+		expectedFixed         *routingv1.FixedResponse
+		expectedInvalidWeight uint32
+	}{
+		{
+			name:             "rule without backendRefs gets fixed 500",
+			backendRefs:      nil,
+			expectedBackends: 0,
+			expectedFixed: &routingv1.FixedResponse{
+				StatusCode: NoBackendsStatusCode,
+				Reason:     noBackendRefsReason,
+			},
+		},
+		{
+			name: "rule with only unsupported backendRefs gets fixed 500",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: unsupported},
+			},
+			expectedBackends: 0,
+			expectedFixed: &routingv1.FixedResponse{
+				StatusCode: NoBackendsStatusCode,
+				Reason:     noValidBackendRefsReason,
+			},
+		},
+		{
+			name: "rule with valid backend has no fixed response",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: serviceRef("app", 8080)},
+				{BackendRef: unsupported},
+			},
+			expectedBackends:      1,
+			expectedFixed:         nil,
+			expectedInvalidWeight: 1,
+		},
+		{
+			name: "invalid backend weight honors explicit weights",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: withWeight(serviceRef("app", 8080), 70)},
+				{BackendRef: withWeight(unsupported, 20)},
+				{BackendRef: withWeight(unsupported, 10)},
+			},
+			expectedBackends:      1,
+			expectedFixed:         nil,
+			expectedInvalidWeight: 30,
+		},
+		{
+			name: "backendRef without port is invalid",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: serviceRef("app", 8080)},
+				{BackendRef: noPort},
+			},
+			expectedBackends:      1,
+			expectedFixed:         nil,
+			expectedInvalidWeight: 1,
+		},
+		{
+			name: "backend with weight 0 receives no requests",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: withWeight(serviceRef("app", 8080), 0)},
+				{BackendRef: withWeight(serviceRef("canary", 8080), 1)},
+			},
+			expectedBackends:      1,
+			expectedFixed:         nil,
+			expectedInvalidWeight: 0,
+		},
+		{
+			name: "invalid backend with weight 0 fails no requests",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: serviceRef("app", 8080)},
+				{BackendRef: withWeight(unsupported, 0)},
+			},
+			expectedBackends:      1,
+			expectedFixed:         nil,
+			expectedInvalidWeight: 0,
+		},
+		{
+			name: "rule whose valid backends all have weight 0 gets fixed 500",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: withWeight(serviceRef("app", 8080), 0)},
+				{BackendRef: withWeight(unsupported, 1)},
+			},
+			expectedBackends: 0,
+			expectedFixed: &routingv1.FixedResponse{
+				StatusCode: NoBackendsStatusCode,
+				Reason:     noValidBackendRefsReason,
+			},
+		},
+		{
+			name: "rule with only valid backends has no invalid weight",
+			backendRefs: []gatewayv1.HTTPBackendRef{
+				{BackendRef: serviceRef("app", 8080)},
+			},
+			expectedBackends:      1,
+			expectedFixed:         nil,
+			expectedInvalidWeight: 0,
+		},
+	}
+
+	builder := NewPingoraBuilder("cluster.local")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					Rules: []gatewayv1.HTTPRouteRule{{BackendRefs: tt.backendRefs}},
+				},
+			}
+
+			result := builder.BuildHTTPRoute(route)
+			require.Len(t, result.GetRules(), 1)
+
+			rule := result.GetRules()[0]
+			assert.Len(t, rule.GetBackends(), tt.expectedBackends)
+			assert.Equal(t, tt.expectedFixed.GetStatusCode(), rule.GetFixedResponse().GetStatusCode())
+			assert.Equal(t, tt.expectedFixed.GetReason(), rule.GetFixedResponse().GetReason())
+			assert.Equal(t, tt.expectedInvalidWeight, rule.GetInvalidBackendWeight())
+		})
+	}
+}
+
+func TestBuildGRPCRoute_NoBackends(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{
+				{},
+				{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: serviceRef("grpc", 9090)}}},
+			},
+		},
+	}
+
+	result := builder.BuildGRPCRoute(route)
+	require.Len(t, result.GetRules(), 2)
+
+	assert.Empty(t, result.GetRules()[0].GetBackends())
+	assert.Equal(t, uint32(NoBackendsStatusCode), result.GetRules()[0].GetFixedResponse().GetStatusCode())
+
+	assert.Len(t, result.GetRules()[1].GetBackends(), 1)
+	assert.Nil(t, result.GetRules()[1].GetFixedResponse())
+}
+
+func TestBuildGRPCRoute_InvalidBackendWeight(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+
+	unsupported := withWeight(serviceRef("bucket", 80), 3)
+	unsupported.Kind = ptrTo(gatewayv1.Kind("S3Bucket"))
+
+	route := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{
+				{BackendRefs: []gatewayv1.GRPCBackendRef{
+					{BackendRef: serviceRef("grpc", 9090)},
+					{BackendRef: unsupported},
+				}},
+				{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: unsupported}}},
+			},
+		},
+	}
+
+	result := builder.BuildGRPCRoute(route)
+	require.Len(t, result.GetRules(), 2)
+
+	assert.Equal(t, uint32(3), result.GetRules()[0].GetInvalidBackendWeight())
+	assert.Nil(t, result.GetRules()[0].GetFixedResponse())
+
+	// Rules without any valid backend are fully answered by the fixed response.
+	assert.Zero(t, result.GetRules()[1].GetInvalidBackendWeight())
+	assert.NotNil(t, result.GetRules()[1].GetFixedResponse())
+}
+
+func TestBuildHTTPRoute_PathMatchDefaults(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{{
+				Matches: []gatewayv1.HTTPRouteMatch{
+					{Path: &gatewayv1.HTTPPathMatch{}},
+					{Path: &gatewayv1.HTTPPathMatch{Value: ptrTo("/api")}},
+					{Path: &gatewayv1.HTTPPathMatch{Type: ptrTo(gatewayv1.PathMatchExact)}},
+				},
+			}},
+		},
+	}
+
+	result := builder.BuildHTTPRoute(route)
+	require.Len(t, result.GetRules(), 1)
+
+	matches := result.GetRules()[0].GetMatches()
+	require.Len(t, matches, 3)
+
+	assert.Equal(t, routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, matches[0].GetPath().GetType())
+	assert.Equal(t, "/", matches[0].GetPath().GetValue())
+	assert.Equal(t, routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, matches[1].GetPath().GetType())
+	assert.Equal(t, "/api", matches[1].GetPath().GetValue())
+	assert.Equal(t, routingv1.PathMatchType_PATH_MATCH_TYPE_EXACT, matches[2].GetPath().GetType())
+	assert.Equal(t, "/", matches[2].GetPath().GetValue())
+}
+
+type mutableClusterDomain struct {
+	domain string
+}
+
+func (d *mutableClusterDomain) ClusterDomain() string {
+	return d.domain
+}
+
+func TestPingoraBuilder_ClusterDomainSource(t *testing.T) {
+	t.Parallel()
+
+	source := &mutableClusterDomain{domain: "cluster.local"}
+	builder := NewPingoraBuilderWithSource(source)
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 8080)}}},
+			},
+		},
+	}
+
+	result := builder.BuildHTTPRoute(route)
+	assert.Equal(t, "app.default.svc.cluster.local:8080", result.GetRules()[0].GetBackends()[0].GetAddress())
+
+	source.domain = "example.local"
+
+	result = builder.BuildHTTPRoute(route)
+	assert.Equal(t, "app.default.svc.example.local:8080", result.GetRules()[0].GetBackends()[0].GetAddress())
+}
+
+func TestBuildHTTPRoute_Timeouts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                   string
+		timeouts               *gatewayv1.HTTPRouteTimeouts
+		annotations            map[string]string
+		expectedTimeoutMs      uint64
+		expectedBackendTimeout uint64
+	}{
+		{
+			name:     "no timeouts",
+			timeouts: nil,
+		},
+		{
+			name:              "request timeout only",
+			timeouts:          &gatewayv1.HTTPRouteTimeouts{Request: ptrTo(gatewayv1.Duration("10s"))},
+			expectedTimeoutMs: 10_000,
+		},
+		{
+			name:                   "backend request timeout only",
+			timeouts:               &gatewayv1.HTTPRouteTimeouts{BackendRequest: ptrTo(gatewayv1.Duration("2s"))},
+			expectedBackendTimeout: 2_000,
+		},
+		{
+			name: "both timeouts",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptrTo(gatewayv1.Duration("10s")),
+				BackendRequest: ptrTo(gatewayv1.Duration("500ms")),
+			},
+			expectedTimeoutMs:      10_000,
+			expectedBackendTimeout: 500,
+		},
+		{
+			name: "backend request timeout is clamped to request timeout",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptrTo(gatewayv1.Duration("1s")),
+				BackendRequest: ptrTo(gatewayv1.Duration("5s")),
+			},
+			expectedTimeoutMs:      1_000,
+			expectedBackendTimeout: 1_000,
+		},
+		{
+			name: "disabled request timeout keeps backend request timeout",
+			timeouts: &gatewayv1.HTTPRouteTimeouts{
+				Request:        ptrTo(gatewayv1.Duration("0s")),
+				BackendRequest: ptrTo(gatewayv1.Duration("5s")),
+			},
+			expectedBackendTimeout: 5_000,
+		},
+		{
+			name:                   "backend request timeout is clamped to latency budget",
+			timeouts:               &gatewayv1.HTTPRouteTimeouts{BackendRequest: ptrTo(gatewayv1.Duration("5s"))},
+			annotations:            map[string]string{LatencyBudgetAnnotation: "2s"},
+			expectedTimeoutMs:      2_000,
+			expectedBackendTimeout: 2_000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Annotations: tt.annotations},
+				Spec: gatewayv1.HTTPRouteSpec{
+					Rules: []gatewayv1.HTTPRouteRule{{
+						BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+						Timeouts:    tt.timeouts,
+					}},
+				},
+			}
+
+			result := NewPingoraBuilder("cluster.local").BuildHTTPRoute(route)
+			require.Len(t, result.GetRules(), 1)
+			assert.Equal(t, tt.expectedTimeoutMs, result.GetRules()[0].GetTimeoutMs())
+			assert.Equal(t, tt.expectedBackendTimeout, result.GetRules()[0].GetBackendTimeoutMs())
+		})
+	}
+}
+
+func TestBuildRoute_RuleNames(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	meta := metav1.ObjectMeta{Name: "app", Namespace: "default"}
+
+	httpRoute := builder.BuildHTTPRoute(&gatewayv1.HTTPRoute{
+		ObjectMeta: meta,
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{Name: ptrTo(gatewayv1.SectionName("api")), BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("api", 80)}}},
+				{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("web", 80)}}},
+			},
+		},
+	})
+	require.Len(t, httpRoute.GetRules(), 2)
+	assert.Equal(t, "api", httpRoute.GetRules()[0].GetName())
+	assert.Empty(t, httpRoute.GetRules()[1].GetName())
+
+	grpcRoute := builder.BuildGRPCRoute(&gatewayv1.GRPCRoute{
+		ObjectMeta: meta,
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{
+				{Name: ptrTo(gatewayv1.SectionName("echo")), BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: serviceRef("grpc", 9090)}}},
+			},
+		},
+	})
+	require.Len(t, grpcRoute.GetRules(), 1)
+	assert.Equal(t, "echo", grpcRoute.GetRules()[0].GetName())
+}
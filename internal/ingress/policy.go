@@ -0,0 +1,108 @@
+package ingress
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Kinds that a policy can target.
+const (
+	PolicyTargetHTTPRoute = "HTTPRoute"
+	PolicyTargetGRPCRoute = "GRPCRoute"
+	PolicyTargetGateway   = "Gateway"
+	PolicyTargetService   = "Service"
+)
+
+// AttachedPolicy is a policy attached to Gateway API resources with
+// targetRefs, as defined by Gateway API policy attachment.
+type AttachedPolicy interface {
+	metav1.Object
+
+	// GetTargetRefs returns the resources the policy is attached to.
+	GetTargetRefs() []gatewayv1.LocalPolicyTargetReferenceWithSectionName
+}
+
+// PolicyTarget identifies a resource that a policy is attached to.
+// SectionName selects a named route rule or Gateway listener and is empty
+// for whole resources.
+type PolicyTarget struct {
+	Kind        string
+	Namespace   string
+	Name        string
+	SectionName string
+}
+
+// PolicyTargets returns the targets of a policy. Targets are local, so they
+// are in the policy's namespace. Targets outside the Gateway API group other
+// than core Services are ignored.
+func PolicyTargets(policy AttachedPolicy) []PolicyTarget {
+	refs := policy.GetTargetRefs()
+	targets := make([]PolicyTarget, 0, len(refs))
+
+	for _, ref := range refs {
+		isService := ref.Group == "" && ref.Kind == PolicyTargetService
+		if string(ref.Group) != gatewayv1.GroupName && !isService {
+			continue
+		}
+
+		target := PolicyTarget{
+			Kind:      string(ref.Kind),
+			Namespace: policy.GetNamespace(),
+			Name:      string(ref.Name),
+		}
+
+		if ref.SectionName != nil {
+			target.SectionName = string(*ref.SectionName)
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+// ActivePolicies returns the policy in effect for every target. Per Gateway
+// API policy attachment, when several policies of a kind target the same
+// resource the oldest one wins, and ties are broken by namespace and name.
+func ActivePolicies[P AttachedPolicy](policies []P) map[PolicyTarget]P {
+	ordered := slices.Clone(policies)
+
+	slices.SortStableFunc(ordered, func(a, b P) int {
+		return compareAge(a, b)
+	})
+
+	active := make(map[PolicyTarget]P)
+
+	for _, policy := range ordered {
+		for _, target := range PolicyTargets(policy) {
+			if _, ok := active[target]; !ok {
+				active[target] = policy
+			}
+		}
+	}
+
+	return active
+}
+
+// RouteGatewayTargets returns the parent Gateways of an HTTPRoute as policy
+// targets, in parentRefs order.
+func RouteGatewayTargets(route *gatewayv1.HTTPRoute) []PolicyTarget {
+	targets := make([]PolicyTarget, 0, len(route.Spec.ParentRefs))
+
+	for _, ref := range route.Spec.ParentRefs {
+		if ref.Kind != nil && *ref.Kind != PolicyTargetGateway {
+			continue
+		}
+
+		namespace := route.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		targets = append(targets, PolicyTarget{Kind: PolicyTargetGateway, Namespace: namespace, Name: string(ref.Name)})
+	}
+
+	return targets
+}
@@ -0,0 +1,176 @@
+package ingress
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// SortHTTPRoutes orders HTTPRoutes for Gateway API precedence ties: the
+// oldest route first, then by namespace and name. Build routes in this order
+// before calling PrioritizeHTTPRoutes.
+func SortHTTPRoutes(routes []gatewayv1.HTTPRoute) {
+	slices.SortStableFunc(routes, func(a, b gatewayv1.HTTPRoute) int {
+		return compareAge(&a, &b)
+	})
+}
+
+// SortGRPCRoutes orders GRPCRoutes like SortHTTPRoutes.
+func SortGRPCRoutes(routes []gatewayv1.GRPCRoute) {
+	slices.SortStableFunc(routes, func(a, b gatewayv1.GRPCRoute) int {
+		return compareAge(&a, &b)
+	})
+}
+
+// PrioritizeHTTPRoutes sets the priority of every match of the routes per
+// Gateway API match precedence: exact paths, then the longest prefixes, then
+// regular expressions, then matches with a method, then the most header and
+// query parameter matches. Remaining ties keep the order of routes, rules and
+// matches, so routes must be ordered with SortHTTPRoutes.
+func PrioritizeHTTPRoutes(routes []*routingv1.HTTPRoute) {
+	var matches []*routingv1.HTTPRouteMatch
+
+	for _, route := range routes {
+		for _, rule := range route.GetRules() {
+			matches = append(matches, rule.GetMatches()...)
+		}
+	}
+
+	slices.SortStableFunc(matches, compareHTTPMatches)
+
+	for i, match := range matches {
+		match.Priority = uint32(i + 1) //nolint:gosec // bounded by the number of matches
+	}
+}
+
+// PrioritizeGRPCRoutes sets the priority of every match of the routes per
+// Gateway API match precedence: exact methods, then regular expressions, then
+// the longest service and method names, then the most header matches.
+// Remaining ties keep the order of routes, rules and matches, so routes must
+// be ordered with SortGRPCRoutes.
+func PrioritizeGRPCRoutes(routes []*routingv1.GRPCRoute) {
+	var matches []*routingv1.GRPCRouteMatch
+
+	for _, route := range routes {
+		for _, rule := range route.GetRules() {
+			matches = append(matches, rule.GetMatches()...)
+		}
+	}
+
+	slices.SortStableFunc(matches, compareGRPCMatches)
+
+	for i, match := range matches {
+		match.Priority = uint32(i + 1) //nolint:gosec // bounded by the number of matches
+	}
+}
+
+// compareAge orders objects by creation time, then namespace and name.
+func compareAge(a, b metav1.Object) int {
+	timeA, timeB := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if c := timeA.Compare(timeB.Time); c != 0 {
+		return c
+	}
+
+	if c := strings.Compare(a.GetNamespace(), b.GetNamespace()); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.GetName(), b.GetName())
+}
+
+func compareHTTPMatches(a, b *routingv1.HTTPRouteMatch) int {
+	rankA, rankB := pathRank(a.GetPath()), pathRank(b.GetPath())
+	if c := cmp.Compare(rankA, rankB); c != 0 {
+		return c
+	}
+
+	// Only prefixes overlap, and the longest one is the most specific
+	if rankA == pathRankPrefix {
+		if c := cmp.Compare(len(b.GetPath().GetValue()), len(a.GetPath().GetValue())); c != 0 {
+			return c
+		}
+	}
+
+	if c := cmp.Compare(methodMatches(b), methodMatches(a)); c != 0 {
+		return c
+	}
+
+	if c := cmp.Compare(len(b.GetHeaders()), len(a.GetHeaders())); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(len(b.GetQueryParams()), len(a.GetQueryParams()))
+}
+
+// methodMatches returns the number of method conditions of a match.
+func methodMatches(match *routingv1.HTTPRouteMatch) int {
+	if match.GetMethod() == "" {
+		return 0
+	}
+
+	return 1
+}
+
+// Precedence ranks of path match types, lowest first.
+const (
+	pathRankExact = iota
+	pathRankPrefix
+	pathRankRegex
+)
+
+// pathRank returns the precedence rank of a path match. A missing path
+// matches every request, like the prefix "/".
+func pathRank(path *routingv1.PathMatch) int {
+	switch path.GetType() {
+	case routingv1.PathMatchType_PATH_MATCH_TYPE_EXACT:
+		return pathRankExact
+	case routingv1.PathMatchType_PATH_MATCH_TYPE_REGEX:
+		return pathRankRegex
+	default:
+		return pathRankPrefix
+	}
+}
+
+func compareGRPCMatches(a, b *routingv1.GRPCRouteMatch) int {
+	rankA, rankB := methodRank(a.GetMethod()), methodRank(b.GetMethod())
+	if c := cmp.Compare(rankA, rankB); c != 0 {
+		return c
+	}
+
+	if rankA == methodRankExact {
+		if c := cmp.Compare(len(b.GetMethod().GetService()), len(a.GetMethod().GetService())); c != 0 {
+			return c
+		}
+
+		if c := cmp.Compare(len(b.GetMethod().GetMethod()), len(a.GetMethod().GetMethod())); c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(b.GetHeaders()), len(a.GetHeaders()))
+}
+
+// Precedence ranks of gRPC method match types, lowest first.
+const (
+	methodRankExact = iota
+	methodRankRegex
+	methodRankAny
+)
+
+// methodRank returns the precedence rank of a gRPC method match. A missing
+// method matches every request.
+func methodRank(method *routingv1.GRPCMethodMatch) int {
+	switch {
+	case method == nil:
+		return methodRankAny
+	case method.GetType() == routingv1.GRPCMethodMatchType_GRPC_METHOD_MATCH_TYPE_REGEX:
+		return methodRankRegex
+	default:
+		return methodRankExact
+	}
+}
@@ -0,0 +1,205 @@
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func TestSortHTTPRoutes(t *testing.T) {
+	t.Parallel()
+
+	older := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(older.Add(time.Hour))
+
+	route := func(namespace, name string, created metav1.Time) gatewayv1.HTTPRoute {
+		return gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace, Name: name, CreationTimestamp: created,
+		}}
+	}
+
+	routes := []gatewayv1.HTTPRoute{
+		route("default", "newest", newer),
+		route("default", "b", older),
+		route("apps", "z", older),
+		route("default", "a", older),
+	}
+
+	SortHTTPRoutes(routes)
+
+	names := make([]string, 0, len(routes))
+	for i := range routes {
+		names = append(names, routes[i].Namespace+"/"+routes[i].Name)
+	}
+
+	assert.Equal(t, []string{"apps/z", "default/a", "default/b", "default/newest"}, names)
+}
+
+func TestPrioritizeHTTPRoutes(t *testing.T) {
+	t.Parallel()
+
+	path := func(matchType routingv1.PathMatchType, value string) *routingv1.PathMatch {
+		return &routingv1.PathMatch{Type: matchType, Value: value}
+	}
+
+	header := &routingv1.HeaderMatch{Name: "x-env", Value: "canary"}
+	query := &routingv1.QueryParamMatch{Name: "debug", Value: "1"}
+
+	tests := []struct {
+		name     string
+		matches  []*routingv1.HTTPRouteMatch
+		expected []uint32
+	}{
+		{
+			name: "exact before prefix before regex",
+			matches: []*routingv1.HTTPRouteMatch{
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_REGEX, "/api/.*")},
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/api")},
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_EXACT, "/")},
+			},
+			expected: []uint32{3, 2, 1},
+		},
+		{
+			name: "longest prefix first",
+			matches: []*routingv1.HTTPRouteMatch{
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/")},
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/api/v1")},
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/api")},
+			},
+			expected: []uint32{3, 1, 2},
+		},
+		{
+			name: "method before headers before query parameters",
+			matches: []*routingv1.HTTPRouteMatch{
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/"), QueryParams: []*routingv1.QueryParamMatch{query}},
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/"), Headers: []*routingv1.HeaderMatch{header}},
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/"), Method: "GET"},
+			},
+			expected: []uint32{3, 2, 1},
+		},
+		{
+			name: "most headers first",
+			matches: []*routingv1.HTTPRouteMatch{
+				{Headers: []*routingv1.HeaderMatch{header}},
+				{Headers: []*routingv1.HeaderMatch{header, header}},
+			},
+			expected: []uint32{2, 1},
+		},
+		{
+			name: "regex paths keep their order",
+			matches: []*routingv1.HTTPRouteMatch{
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_REGEX, "/a")},
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_REGEX, "/longer/.*")},
+			},
+			expected: []uint32{1, 2},
+		},
+		{
+			name: "ties keep route order",
+			matches: []*routingv1.HTTPRouteMatch{
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/")},
+				{Path: path(routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, "/")},
+			},
+			expected: []uint32{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// One route per match, so that ties fall back to route order
+			routes := make([]*routingv1.HTTPRoute, 0, len(tt.matches))
+			for _, match := range tt.matches {
+				routes = append(routes, &routingv1.HTTPRoute{
+					Rules: []*routingv1.HTTPRouteRule{{Matches: []*routingv1.HTTPRouteMatch{match}}},
+				})
+			}
+
+			PrioritizeHTTPRoutes(routes)
+
+			priorities := make([]uint32, 0, len(tt.matches))
+			for _, match := range tt.matches {
+				priorities = append(priorities, match.GetPriority())
+			}
+
+			assert.Equal(t, tt.expected, priorities)
+		})
+	}
+}
+
+func TestPrioritizeGRPCRoutes(t *testing.T) {
+	t.Parallel()
+
+	exact := func(service, method string) *routingv1.GRPCMethodMatch {
+		return &routingv1.GRPCMethodMatch{
+			Type:    routingv1.GRPCMethodMatchType_GRPC_METHOD_MATCH_TYPE_EXACT,
+			Service: service,
+			Method:  method,
+		}
+	}
+
+	header := &routingv1.HeaderMatch{Name: "x-env", Value: "canary"}
+
+	tests := []struct {
+		name     string
+		matches  []*routingv1.GRPCRouteMatch
+		expected []uint32
+	}{
+		{
+			name: "exact before regex before any method",
+			matches: []*routingv1.GRPCRouteMatch{
+				{},
+				{Method: &routingv1.GRPCMethodMatch{
+					Type:    routingv1.GRPCMethodMatchType_GRPC_METHOD_MATCH_TYPE_REGEX,
+					Service: "example\\..*",
+				}},
+				{Method: exact("example.Echo", "")},
+			},
+			expected: []uint32{3, 2, 1},
+		},
+		{
+			name: "longest service then method first",
+			matches: []*routingv1.GRPCRouteMatch{
+				{Method: exact("example.Echo", "")},
+				{Method: exact("example.Echo", "Say")},
+				{Method: exact("", "Say")},
+			},
+			expected: []uint32{2, 1, 3},
+		},
+		{
+			name: "most headers first",
+			matches: []*routingv1.GRPCRouteMatch{
+				{Method: exact("example.Echo", "Say")},
+				{Method: exact("example.Echo", "Say"), Headers: []*routingv1.HeaderMatch{header}},
+			},
+			expected: []uint32{2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			routes := make([]*routingv1.GRPCRoute, 0, len(tt.matches))
+			for _, match := range tt.matches {
+				routes = append(routes, &routingv1.GRPCRoute{
+					Rules: []*routingv1.GRPCRouteRule{{Matches: []*routingv1.GRPCRouteMatch{match}}},
+				})
+			}
+
+			PrioritizeGRPCRoutes(routes)
+
+			priorities := make([]uint32, 0, len(tt.matches))
+			for _, match := range tt.matches {
+				priorities = append(priorities, match.GetPriority())
+			}
+
+			assert.Equal(t, tt.expected, priorities)
+		})
+	}
+}
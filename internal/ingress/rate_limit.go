@@ -0,0 +1,90 @@
+package ingress
+
+import (
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// SetRateLimitPolicies replaces the PingoraRateLimitPolicies applied to
+// routes. Call it before building routes so that policy changes take effect
+// on the next sync.
+func (b *PingoraBuilder) SetRateLimitPolicies(policies []v1alpha1.PingoraRateLimitPolicy) {
+	attached := make([]*v1alpha1.PingoraRateLimitPolicy, 0, len(policies))
+	for i := range policies {
+		attached = append(attached, &policies[i])
+	}
+
+	active := ActivePolicies(attached)
+	byTarget := make(map[PolicyTarget]*routingv1.RateLimit, len(active))
+
+	for target, policy := range active {
+		byTarget[target] = rateLimitFromPolicy(policy)
+	}
+
+	b.rateLimitMu.Lock()
+	defer b.rateLimitMu.Unlock()
+
+	b.rateLimits = byTarget
+}
+
+// rateLimitFor returns the rate limit of an HTTPRoute rule. A policy on the
+// named rule takes precedence over one on the whole route, which takes
+// precedence over one on a parent Gateway.
+func (b *PingoraBuilder) rateLimitFor(route *gatewayv1.HTTPRoute, ruleName string) *routingv1.RateLimit {
+	b.rateLimitMu.RLock()
+	defer b.rateLimitMu.RUnlock()
+
+	if len(b.rateLimits) == 0 {
+		return nil
+	}
+
+	routeTarget := PolicyTarget{Kind: PolicyTargetHTTPRoute, Namespace: route.Namespace, Name: route.Name}
+
+	if ruleName != "" {
+		ruleTarget := routeTarget
+		ruleTarget.SectionName = ruleName
+
+		if limit, ok := b.rateLimits[ruleTarget]; ok {
+			return limit
+		}
+	}
+
+	if limit, ok := b.rateLimits[routeTarget]; ok {
+		return limit
+	}
+
+	for _, gatewayTarget := range RouteGatewayTargets(route) {
+		if limit, ok := b.rateLimits[gatewayTarget]; ok {
+			return limit
+		}
+	}
+
+	return nil
+}
+
+func rateLimitFromPolicy(policy *v1alpha1.PingoraRateLimitPolicy) *routingv1.RateLimit {
+	spec := &policy.Spec
+
+	result := &routingv1.RateLimit{
+		Id:       policy.Namespace + "/" + policy.Name,
+		Requests: uint32(max(spec.Requests, 0)),
+		PeriodMs: uint64(time.Second.Milliseconds()),
+		Burst:    uint32(max(spec.Burst, 0)),
+		KeyType:  routingv1.RateLimitKeyType_RATE_LIMIT_KEY_TYPE_CLIENT_IP,
+	}
+
+	if spec.GetUnit() == v1alpha1.RateLimitUnitMinute {
+		result.PeriodMs = uint64(time.Minute.Milliseconds())
+	}
+
+	if spec.Key != nil && spec.Key.Type == v1alpha1.RateLimitKeyHeader {
+		result.KeyType = routingv1.RateLimitKeyType_RATE_LIMIT_KEY_TYPE_HEADER
+		result.KeyHeader = spec.Key.Header
+	}
+
+	return result
+}
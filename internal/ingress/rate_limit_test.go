@@ -0,0 +1,176 @@
+package ingress
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func policyTargetRef(kind, name, sectionName string) gatewayv1.LocalPolicyTargetReferenceWithSectionName {
+	ref := gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+		LocalPolicyTargetReference: gatewayv1.LocalPolicyTargetReference{
+			Group: gatewayv1.GroupName,
+			Kind:  gatewayv1.Kind(kind),
+			Name:  gatewayv1.ObjectName(name),
+		},
+	}
+
+	if sectionName != "" {
+		ref.SectionName = ptrTo(gatewayv1.SectionName(sectionName))
+	}
+
+	return ref
+}
+
+func TestBuildHTTPRoute_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetRateLimitPolicies([]v1alpha1.PingoraRateLimitPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "gateway-system"},
+			Spec: v1alpha1.PingoraRateLimitPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetGateway, "public", ""),
+				},
+				Requests: 600,
+				Unit:     v1alpha1.RateLimitUnitMinute,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "route", Namespace: "default", CreationTimestamp: metav1.NewTime(created),
+			},
+			Spec: v1alpha1.PingoraRateLimitPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetHTTPRoute, "app", ""),
+				},
+				Requests: 100,
+				Burst:    50,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "newer", Namespace: "default", CreationTimestamp: metav1.NewTime(created.Add(time.Minute)),
+			},
+			Spec: v1alpha1.PingoraRateLimitPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetHTTPRoute, "app", ""),
+				},
+				Requests: 1,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "login", Namespace: "default"},
+			Spec: v1alpha1.PingoraRateLimitPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetHTTPRoute, "app", "login"),
+				},
+				Requests: 5,
+				Key:      &v1alpha1.RateLimitKey{Type: v1alpha1.RateLimitKeyHeader, Header: "X-Api-Key"},
+			},
+		},
+	})
+
+	gatewayNamespace := gatewayv1.Namespace("gateway-system")
+	parentRefs := []gatewayv1.ParentReference{{Name: "public", Namespace: &gatewayNamespace}}
+
+	routeLimit := &routingv1.RateLimit{
+		Id:       "default/route",
+		Requests: 100,
+		PeriodMs: 1000,
+		Burst:    50,
+		KeyType:  routingv1.RateLimitKeyType_RATE_LIMIT_KEY_TYPE_CLIENT_IP,
+	}
+
+	tests := []struct {
+		name     string
+		route    string
+		rule     string
+		expected *routingv1.RateLimit
+	}{
+		{
+			name:     "route policy, oldest wins",
+			route:    "app",
+			expected: routeLimit,
+		},
+		{
+			name:     "route policy for rule without its own policy",
+			route:    "app",
+			rule:     "signup",
+			expected: routeLimit,
+		},
+		{
+			name:  "rule policy",
+			route: "app",
+			rule:  "login",
+			expected: &routingv1.RateLimit{
+				Id:        "default/login",
+				Requests:  5,
+				PeriodMs:  1000,
+				KeyType:   routingv1.RateLimitKeyType_RATE_LIMIT_KEY_TYPE_HEADER,
+				KeyHeader: "X-Api-Key",
+			},
+		},
+		{
+			name:  "gateway policy",
+			route: "other",
+			expected: &routingv1.RateLimit{
+				Id:       "gateway-system/gateway",
+				Requests: 600,
+				PeriodMs: 60000,
+				KeyType:  routingv1.RateLimitKeyType_RATE_LIMIT_KEY_TYPE_CLIENT_IP,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := gatewayv1.HTTPRouteRule{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+			}
+			if tt.rule != "" {
+				rule.Name = ptrTo(gatewayv1.SectionName(tt.rule))
+			}
+
+			route := &gatewayv1.HTTPRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.route, Namespace: "default"},
+				Spec: gatewayv1.HTTPRouteSpec{
+					CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: parentRefs},
+					Rules:           []gatewayv1.HTTPRouteRule{rule},
+				},
+			}
+
+			result := builder.BuildHTTPRoute(route)
+			require.Len(t, result.GetRules(), 1)
+
+			limit := result.GetRules()[0].GetRateLimit()
+			assert.True(t, proto.Equal(tt.expected, limit), "rate limit: %v", limit)
+		})
+	}
+
+	t.Run("no policies", func(t *testing.T) {
+		t.Parallel()
+
+		route := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{{}}},
+		}
+
+		result := NewPingoraBuilder("cluster.local").BuildHTTPRoute(route)
+		require.Len(t, result.GetRules(), 1)
+		assert.Nil(t, result.GetRules()[0].GetRateLimit())
+	})
+}
@@ -0,0 +1,138 @@
+package ingress
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RegexIssue is a route rule that is dropped because one of its regular
+// expression matches does not compile. A single bad pattern would otherwise
+// make the proxy reject the whole configuration.
+type RegexIssue struct {
+	// Rule is the index of the rule in the route spec.
+	Rule int
+
+	// Err describes the first invalid pattern of the rule.
+	Err error
+}
+
+// String returns the issue for route status messages.
+func (i RegexIssue) String() string {
+	return fmt.Sprintf("rule %d: %v", i.Rule, i.Err)
+}
+
+// JoinRegexIssues returns the issues as a single status message.
+func JoinRegexIssues(issues []RegexIssue) string {
+	messages := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// HTTPRouteRegexIssues returns the HTTPRoute rules that are dropped for
+// invalid regular expressions in path, header or query parameter matches.
+func HTTPRouteRegexIssues(rules []gatewayv1.HTTPRouteRule) []RegexIssue {
+	var issues []RegexIssue
+
+	for i := range rules {
+		if err := httpRuleRegexError(&rules[i]); err != nil {
+			issues = append(issues, RegexIssue{Rule: i, Err: err})
+		}
+	}
+
+	return issues
+}
+
+// GRPCRouteRegexIssues returns the GRPCRoute rules that are dropped for
+// invalid regular expressions in method or header matches.
+func GRPCRouteRegexIssues(rules []gatewayv1.GRPCRouteRule) []RegexIssue {
+	var issues []RegexIssue
+
+	for i := range rules {
+		if err := grpcRuleRegexError(&rules[i]); err != nil {
+			issues = append(issues, RegexIssue{Rule: i, Err: err})
+		}
+	}
+
+	return issues
+}
+
+// httpRuleRegexError reports the first regular expression of an HTTPRoute
+// rule that does not compile.
+func httpRuleRegexError(rule *gatewayv1.HTTPRouteRule) error {
+	for i := range rule.Matches {
+		match := &rule.Matches[i]
+
+		if match.Path != nil && match.Path.Type != nil && *match.Path.Type == gatewayv1.PathMatchRegularExpression &&
+			match.Path.Value != nil {
+			if err := compileRegex("path", *match.Path.Value); err != nil {
+				return err
+			}
+		}
+
+		for _, header := range match.Headers {
+			if header.Type != nil && *header.Type == gatewayv1.HeaderMatchRegularExpression {
+				if err := compileRegex("header "+string(header.Name), header.Value); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, param := range match.QueryParams {
+			if param.Type != nil && *param.Type == gatewayv1.QueryParamMatchRegularExpression {
+				if err := compileRegex("query parameter "+string(param.Name), param.Value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// grpcRuleRegexError reports the first regular expression of a GRPCRoute
+// rule that does not compile.
+func grpcRuleRegexError(rule *gatewayv1.GRPCRouteRule) error {
+	for i := range rule.Matches {
+		match := &rule.Matches[i]
+
+		if method := match.Method; method != nil && method.Type != nil &&
+			*method.Type == gatewayv1.GRPCMethodMatchRegularExpression {
+			if method.Service != nil {
+				if err := compileRegex("service", *method.Service); err != nil {
+					return err
+				}
+			}
+
+			if method.Method != nil {
+				if err := compileRegex("method", *method.Method); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, header := range match.Headers {
+			if header.Type != nil && *header.Type == gatewayv1.GRPCHeaderMatchRegularExpression {
+				if err := compileRegex("header "+string(header.Name), header.Value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func compileRegex(field, pattern string) error {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return errors.Newf("invalid %s regex %q: %v", field, pattern, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestHTTPRouteRegexIssues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		match    gatewayv1.HTTPRouteMatch
+		expected string
+	}{
+		{
+			name: "valid path regex",
+			match: gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{
+				Type:  ptrTo(gatewayv1.PathMatchRegularExpression),
+				Value: ptrTo("^/api/v[0-9]+/"),
+			}},
+		},
+		{
+			name: "invalid prefix is not a regex",
+			match: gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{
+				Type:  ptrTo(gatewayv1.PathMatchPathPrefix),
+				Value: ptrTo("/api/(v1"),
+			}},
+		},
+		{
+			name: "invalid path regex",
+			match: gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{
+				Type:  ptrTo(gatewayv1.PathMatchRegularExpression),
+				Value: ptrTo("/api/(v1"),
+			}},
+			expected: `rule 0: invalid path regex "/api/(v1"`,
+		},
+		{
+			name: "invalid header regex",
+			match: gatewayv1.HTTPRouteMatch{Headers: []gatewayv1.HTTPHeaderMatch{{
+				Type:  ptrTo(gatewayv1.HeaderMatchRegularExpression),
+				Name:  "X-Version",
+				Value: "v[0-9",
+			}}},
+			expected: `rule 0: invalid header X-Version regex "v[0-9"`,
+		},
+		{
+			name: "invalid query parameter regex",
+			match: gatewayv1.HTTPRouteMatch{QueryParams: []gatewayv1.HTTPQueryParamMatch{{
+				Type:  ptrTo(gatewayv1.QueryParamMatchRegularExpression),
+				Name:  "page",
+				Value: "*",
+			}}},
+			expected: `rule 0: invalid query parameter page regex "*"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			issues := HTTPRouteRegexIssues([]gatewayv1.HTTPRouteRule{
+				{Matches: []gatewayv1.HTTPRouteMatch{tt.match}},
+			})
+
+			if tt.expected == "" {
+				assert.Empty(t, issues)
+
+				return
+			}
+
+			require.Len(t, issues, 1)
+			assert.Contains(t, JoinRegexIssues(issues), tt.expected)
+		})
+	}
+}
+
+func TestGRPCRouteRegexIssues(t *testing.T) {
+	t.Parallel()
+
+	issues := GRPCRouteRegexIssues([]gatewayv1.GRPCRouteRule{
+		{Matches: []gatewayv1.GRPCRouteMatch{{Method: &gatewayv1.GRPCMethodMatch{
+			Type:    ptrTo(gatewayv1.GRPCMethodMatchRegularExpression),
+			Service: ptrTo(`example\.v[0-9]+\.Users`),
+		}}}},
+		{Matches: []gatewayv1.GRPCRouteMatch{{Method: &gatewayv1.GRPCMethodMatch{
+			Type:   ptrTo(gatewayv1.GRPCMethodMatchRegularExpression),
+			Method: ptrTo("Get(User"),
+		}}}},
+		{Matches: []gatewayv1.GRPCRouteMatch{{Headers: []gatewayv1.GRPCHeaderMatch{{
+			Type:  ptrTo(gatewayv1.GRPCHeaderMatchRegularExpression),
+			Name:  "x-tenant",
+			Value: "+",
+		}}}}},
+	})
+
+	require.Len(t, issues, 2)
+	assert.Equal(t, 1, issues[0].Rule)
+	assert.Contains(t, issues[0].String(), `invalid method regex "Get(User"`)
+	assert.Equal(t, 2, issues[1].Rule)
+	assert.Contains(t, issues[1].String(), `invalid header x-tenant regex "+"`)
+}
+
+func TestBuildRoutes_DropsInvalidRegexRules(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+
+	httpRoute := builder.BuildHTTPRoute(&gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{
+			{
+				Name: ptrTo(gatewayv1.SectionName("broken")),
+				Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{
+					Type:  ptrTo(gatewayv1.PathMatchRegularExpression),
+					Value: ptrTo("/(unclosed"),
+				}}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+			},
+			{
+				Name:        ptrTo(gatewayv1.SectionName("working")),
+				BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+			},
+		}},
+	})
+
+	require.Len(t, httpRoute.GetRules(), 1)
+	assert.Equal(t, "working", httpRoute.GetRules()[0].GetName())
+
+	grpcRoute := builder.BuildGRPCRoute(&gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: gatewayv1.GRPCRouteSpec{Rules: []gatewayv1.GRPCRouteRule{
+			{
+				Matches: []gatewayv1.GRPCRouteMatch{{Method: &gatewayv1.GRPCMethodMatch{
+					Type:    ptrTo(gatewayv1.GRPCMethodMatchRegularExpression),
+					Service: ptrTo("[a-"),
+				}}},
+				BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: serviceRef("api", 9090)}},
+			},
+		}},
+	})
+
+	assert.Empty(t, grpcRoute.GetRules())
+}
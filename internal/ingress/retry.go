@@ -0,0 +1,170 @@
+package ingress
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// DefaultRetryAttempts is used for rules with a retry stanza that does not
+// set attempts.
+const DefaultRetryAttempts = 1
+
+// DisableRetriesAnnotation lists the names of route rules, separated by
+// commas, whose requests the proxy must never retry or fail over to another
+// backend, e.g. for endpoints that are not idempotent. "*" selects every rule
+// of the route, including unnamed ones.
+const DisableRetriesAnnotation = "pingora.k8s.lex.la/disable-retries"
+
+// allRules selects every rule in DisableRetriesAnnotation.
+const allRules = "*"
+
+// RetryStatus describes whether the retry settings of a route can be
+// programmed as specified.
+type RetryStatus struct {
+	Supported bool
+	Message   string
+}
+
+// buildRetry converts the retry stanza of a rule. Status codes outside the
+// 5xx range are dropped, see CheckRetries. It returns nil when retries are not
+// configured or disabled with zero attempts.
+func buildRetry(rule *gatewayv1.HTTPRouteRule) *routingv1.RetryConfig {
+	if rule.Retry == nil {
+		return nil
+	}
+
+	attempts := DefaultRetryAttempts
+	if rule.Retry.Attempts != nil {
+		attempts = *rule.Retry.Attempts
+	}
+
+	if attempts <= 0 {
+		return nil
+	}
+
+	result := &routingv1.RetryConfig{
+		Attempts:           uint32(attempts),
+		BackoffMs:          durationMs(rule.Retry.Backoff),
+		RetryOnStatusCodes: make([]uint32, 0, len(rule.Retry.Codes)),
+	}
+
+	for _, code := range rule.Retry.Codes {
+		if isRetryableStatusCode(code) {
+			result.RetryOnStatusCodes = append(result.RetryOnStatusCodes, uint32(code))
+		}
+	}
+
+	return result
+}
+
+// isRetryableStatusCode reports whether the proxy retries on the status code.
+// Only server errors are retried; 4xx responses are usually not transient.
+func isRetryableStatusCode(code gatewayv1.HTTPRouteRetryStatusCode) bool {
+	return code >= http.StatusInternalServerError && code <= 599
+}
+
+// retriesDisabled reports whether DisableRetriesAnnotation selects the rule
+// with the given name.
+func retriesDisabled(annotations map[string]string, name *gatewayv1.SectionName) bool {
+	value, ok := annotations[DisableRetriesAnnotation]
+	if !ok {
+		return false
+	}
+
+	for _, selected := range strings.Split(value, ",") {
+		selected = strings.TrimSpace(selected)
+		if selected == allRules || (name != nil && selected == string(*name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CheckRetries reports retry settings of HTTPRoute rules that cannot be
+// programmed as specified, including retries disabled by
+// DisableRetriesAnnotation. It returns nil when no rule configures retries.
+func CheckRetries(annotations map[string]string, rules []gatewayv1.HTTPRouteRule) *RetryStatus {
+	var (
+		configured bool
+		issues     []string
+	)
+
+	for i := range rules {
+		rule := &rules[i]
+		if rule.Retry == nil {
+			continue
+		}
+
+		configured = true
+
+		if retriesDisabled(annotations, rule.Name) {
+			issues = append(issues, fmt.Sprintf("rule %d: retries are disabled by the %s annotation",
+				i, DisableRetriesAnnotation))
+
+			continue
+		}
+
+		issues = append(issues, retryIssues(i, rule)...)
+	}
+
+	if !configured {
+		return nil
+	}
+
+	if len(issues) > 0 {
+		return &RetryStatus{Supported: false, Message: strings.Join(issues, "; ")}
+	}
+
+	return &RetryStatus{Supported: true, Message: "Retries applied"}
+}
+
+// retryIssues lists the unsupported retry settings of a single rule.
+func retryIssues(index int, rule *gatewayv1.HTTPRouteRule) []string {
+	var issues []string
+
+	for _, code := range rule.Retry.Codes {
+		if !isRetryableStatusCode(code) {
+			issues = append(issues, fmt.Sprintf("rule %d: status code %d is not retried, only 5xx codes are supported", index, code))
+		}
+	}
+
+	refs := make([]gatewayv1.BackendRef, 0, len(rule.BackendRefs))
+	for _, ref := range rule.BackendRefs {
+		refs = append(refs, ref.BackendRef)
+	}
+
+	if !hasSupportedBackend(refs) {
+		issues = append(issues, fmt.Sprintf("rule %d: retry has no effect on a rule without usable backends", index))
+	}
+
+	retry := buildRetry(rule)
+	if retry == nil || retry.GetBackoffMs() == 0 || rule.Timeouts == nil {
+		return issues
+	}
+
+	timeoutMs := durationMs(rule.Timeouts.Request)
+	if backoffMs := uint64(retry.GetAttempts()) * retry.GetBackoffMs(); timeoutMs > 0 && backoffMs >= timeoutMs {
+		issues = append(issues, fmt.Sprintf(
+			"rule %d: %d retries with %dms backoff do not fit into the %dms request timeout",
+			index, retry.GetAttempts(), retry.GetBackoffMs(), timeoutMs))
+	}
+
+	return issues
+}
+
+// hasSupportedBackend reports whether at least one backendRef can be built.
+func hasSupportedBackend(refs []gatewayv1.BackendRef) bool {
+	for i := range refs {
+		if isSupportedBackendKind(&refs[i]) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,280 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func retryRule(retry *gatewayv1.HTTPRouteRetry) gatewayv1.HTTPRouteRule {
+	return gatewayv1.HTTPRouteRule{
+		BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+		Retry:       retry,
+	}
+}
+
+func TestBuildRetry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		retry    *gatewayv1.HTTPRouteRetry
+		expected *routingv1.RetryConfig
+	}{
+		{
+			name:     "not configured",
+			retry:    nil,
+			expected: nil,
+		},
+		{
+			name:  "empty stanza retries connection errors once",
+			retry: &gatewayv1.HTTPRouteRetry{},
+			expected: &routingv1.RetryConfig{
+				Attempts:           DefaultRetryAttempts,
+				RetryOnStatusCodes: []uint32{},
+			},
+		},
+		{
+			name: "all fields",
+			retry: &gatewayv1.HTTPRouteRetry{
+				Codes:    []gatewayv1.HTTPRouteRetryStatusCode{500, 502, 503, 504},
+				Attempts: ptrTo(3),
+				Backoff:  ptrTo(gatewayv1.Duration("100ms")),
+			},
+			expected: &routingv1.RetryConfig{
+				Attempts:           3,
+				BackoffMs:          100,
+				RetryOnStatusCodes: []uint32{500, 502, 503, 504},
+			},
+		},
+		{
+			name: "client error codes are dropped",
+			retry: &gatewayv1.HTTPRouteRetry{
+				Codes:    []gatewayv1.HTTPRouteRetryStatusCode{429, 503},
+				Attempts: ptrTo(2),
+			},
+			expected: &routingv1.RetryConfig{
+				Attempts:           2,
+				RetryOnStatusCodes: []uint32{503},
+			},
+		},
+		{
+			name:     "zero attempts disable retries",
+			retry:    &gatewayv1.HTTPRouteRetry{Attempts: ptrTo(0)},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule := retryRule(tt.retry)
+			assert.Equal(t, tt.expected, buildRetry(&rule))
+		})
+	}
+}
+
+func TestCheckRetries(t *testing.T) {
+	t.Parallel()
+
+	unsupported := serviceRef("bucket", 80)
+	unsupported.Kind = ptrTo(gatewayv1.Kind("S3Bucket"))
+
+	slowRetries := retryRule(&gatewayv1.HTTPRouteRetry{
+		Attempts: ptrTo(3),
+		Backoff:  ptrTo(gatewayv1.Duration("500ms")),
+	})
+	slowRetries.Timeouts = &gatewayv1.HTTPRouteTimeouts{Request: ptrTo(gatewayv1.Duration("1s"))}
+
+	fastRetries := retryRule(&gatewayv1.HTTPRouteRetry{
+		Attempts: ptrTo(2),
+		Backoff:  ptrTo(gatewayv1.Duration("100ms")),
+	})
+	fastRetries.Timeouts = &gatewayv1.HTTPRouteTimeouts{Request: ptrTo(gatewayv1.Duration("1s"))}
+
+	namedRetries := fastRetries
+	namedRetries.Name = ptrTo(gatewayv1.SectionName("payments"))
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		rules           []gatewayv1.HTTPRouteRule
+		expectNil       bool
+		expectSupported bool
+		expectMessage   string
+	}{
+		{
+			name:      "no retries",
+			rules:     []gatewayv1.HTTPRouteRule{retryRule(nil)},
+			expectNil: true,
+		},
+		{
+			name:            "supported retries",
+			rules:           []gatewayv1.HTTPRouteRule{retryRule(nil), fastRetries},
+			expectSupported: true,
+			expectMessage:   "Retries applied",
+		},
+		{
+			name: "client error code",
+			rules: []gatewayv1.HTTPRouteRule{retryRule(&gatewayv1.HTTPRouteRetry{
+				Codes: []gatewayv1.HTTPRouteRetryStatusCode{404},
+			})},
+			expectMessage: "rule 0: status code 404 is not retried, only 5xx codes are supported",
+		},
+		{
+			name: "rule without usable backends",
+			rules: []gatewayv1.HTTPRouteRule{
+				retryRule(nil),
+				{
+					BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: unsupported}},
+					Retry:       &gatewayv1.HTTPRouteRetry{},
+				},
+			},
+			expectMessage: "rule 1: retry has no effect on a rule without usable backends",
+		},
+		{
+			name:          "backoff exceeds request timeout",
+			rules:         []gatewayv1.HTTPRouteRule{slowRetries},
+			expectMessage: "rule 0: 3 retries with 500ms backoff do not fit into the 1000ms request timeout",
+		},
+		{
+			name:          "retries disabled by annotation",
+			annotations:   map[string]string{DisableRetriesAnnotation: "payments"},
+			rules:         []gatewayv1.HTTPRouteRule{fastRetries, namedRetries},
+			expectMessage: "rule 1: retries are disabled by the pingora.k8s.lex.la/disable-retries annotation",
+		},
+		{
+			name:        "disabled rule without retries",
+			annotations: map[string]string{DisableRetriesAnnotation: "*"},
+			rules:       []gatewayv1.HTTPRouteRule{retryRule(nil)},
+			expectNil:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			status := CheckRetries(tt.annotations, tt.rules)
+			if tt.expectNil {
+				assert.Nil(t, status)
+
+				return
+			}
+
+			require.NotNil(t, status)
+			assert.Equal(t, tt.expectSupported, status.Supported)
+			assert.Equal(t, tt.expectMessage, status.Message)
+		})
+	}
+}
+
+func TestBuildHTTPRoute_Retry(t *testing.T) {
+	t.Parallel()
+
+	retry := &gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{503}, Attempts: ptrTo(2)}
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{LatencyBudgetAnnotation: "1s"},
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				retryRule(retry),
+				{Retry: retry},
+			},
+		},
+	}
+
+	result := NewPingoraBuilder("cluster.local").BuildHTTPRoute(route)
+	require.Len(t, result.GetRules(), 2)
+
+	explicit := result.GetRules()[0].GetRetry()
+	require.NotNil(t, explicit, "explicit retry must win over the latency budget")
+	assert.Equal(t, uint32(2), explicit.GetAttempts())
+	assert.Equal(t, []uint32{503}, explicit.GetRetryOnStatusCodes())
+	assert.Equal(t, uint64(1000), result.GetRules()[0].GetTimeoutMs())
+
+	assert.NotNil(t, result.GetRules()[1].GetFixedResponse())
+	assert.Nil(t, result.GetRules()[1].GetRetry(), "rules without backends must not retry")
+}
+
+func TestRetriesDisabled(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    *string
+		rule     *gatewayv1.SectionName
+		expected bool
+	}{
+		{name: "no annotation", rule: ptrTo(gatewayv1.SectionName("payments"))},
+		{name: "named rule", value: ptrTo("payments"), rule: ptrTo(gatewayv1.SectionName("payments")), expected: true},
+		{name: "list with spaces", value: ptrTo("orders, payments"), rule: ptrTo(gatewayv1.SectionName("payments")), expected: true},
+		{name: "other rule", value: ptrTo("orders"), rule: ptrTo(gatewayv1.SectionName("payments"))},
+		{name: "unnamed rule", value: ptrTo("payments")},
+		{name: "all rules", value: ptrTo("*"), expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			annotations := map[string]string{}
+			if tt.value != nil {
+				annotations[DisableRetriesAnnotation] = *tt.value
+			}
+
+			assert.Equal(t, tt.expected, retriesDisabled(annotations, tt.rule))
+		})
+	}
+}
+
+func TestBuildRoute_DisableRetries(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	meta := metav1.ObjectMeta{
+		Name:      "app",
+		Namespace: "default",
+		Annotations: map[string]string{
+			DisableRetriesAnnotation: "payments",
+			LatencyBudgetAnnotation:  "2s",
+		},
+	}
+
+	payments := retryRule(&gatewayv1.HTTPRouteRetry{Codes: []gatewayv1.HTTPRouteRetryStatusCode{503}})
+	payments.Name = ptrTo(gatewayv1.SectionName("payments"))
+
+	httpRoute := builder.BuildHTTPRoute(&gatewayv1.HTTPRoute{
+		ObjectMeta: meta,
+		Spec:       gatewayv1.HTTPRouteSpec{Rules: []gatewayv1.HTTPRouteRule{payments, retryRule(nil)}},
+	})
+	require.Len(t, httpRoute.GetRules(), 2)
+	assert.True(t, httpRoute.GetRules()[0].GetDisableRetries())
+	assert.Nil(t, httpRoute.GetRules()[0].GetRetry())
+	assert.Equal(t, uint64(2000), httpRoute.GetRules()[0].GetTimeoutMs())
+	assert.False(t, httpRoute.GetRules()[1].GetDisableRetries())
+	assert.NotNil(t, httpRoute.GetRules()[1].GetRetry())
+
+	grpcRoute := builder.BuildGRPCRoute(&gatewayv1.GRPCRoute{
+		ObjectMeta: meta,
+		Spec: gatewayv1.GRPCRouteSpec{
+			Rules: []gatewayv1.GRPCRouteRule{
+				{Name: ptrTo(gatewayv1.SectionName("payments")), BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: serviceRef("grpc", 9090)}}},
+				{BackendRefs: []gatewayv1.GRPCBackendRef{{BackendRef: serviceRef("grpc", 9090)}}},
+			},
+		},
+	})
+	require.Len(t, grpcRoute.GetRules(), 2)
+	assert.True(t, grpcRoute.GetRules()[0].GetDisableRetries())
+	assert.False(t, grpcRoute.GetRules()[1].GetDisableRetries())
+}
@@ -0,0 +1,69 @@
+package ingress
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RouteIDScheme selects how route IDs sent to the proxy are derived.
+type RouteIDScheme string
+
+const (
+	// RouteIDSchemeName uses "namespace/name". A route deleted and recreated
+	// with the same name keeps its ID.
+	RouteIDSchemeName RouteIDScheme = "name"
+
+	// RouteIDSchemeUID uses "namespace/name/uid", so a recreated route always
+	// gets a new ID.
+	RouteIDSchemeUID RouteIDScheme = "uid"
+
+	// RouteIDSchemeHash uses "namespace/name-hash", where hash is derived from
+	// the route UID. It is shorter than RouteIDSchemeUID with the same effect.
+	RouteIDSchemeHash RouteIDScheme = "hash"
+
+	// DefaultRouteIDScheme is the route ID scheme used unless configured otherwise.
+	DefaultRouteIDScheme = RouteIDSchemeName
+)
+
+// routeIDHashLength is the number of hex characters of the UID hash suffix.
+const routeIDHashLength = 8
+
+// ParseRouteIDScheme validates a route ID scheme name.
+func ParseRouteIDScheme(value string) (RouteIDScheme, error) {
+	switch scheme := RouteIDScheme(value); scheme {
+	case RouteIDSchemeName, RouteIDSchemeUID, RouteIDSchemeHash:
+		return scheme, nil
+	default:
+		return "", errors.Newf("unknown route ID scheme %q (valid: %s, %s, %s)",
+			value, RouteIDSchemeName, RouteIDSchemeUID, RouteIDSchemeHash)
+	}
+}
+
+// RouteID returns the ID of a route under the given scheme. Objects without a
+// UID, which only exist before they are created, fall back to
+// RouteIDSchemeName.
+func RouteID(scheme RouteIDScheme, route metav1.Object) string {
+	id := fmt.Sprintf("%s/%s", route.GetNamespace(), route.GetName())
+
+	uid := string(route.GetUID())
+	if uid == "" {
+		return id
+	}
+
+	switch scheme {
+	case RouteIDSchemeUID:
+		return id + "/" + uid
+	case RouteIDSchemeHash:
+		sum := sha256.Sum256([]byte(uid))
+
+		return id + "-" + hex.EncodeToString(sum[:])[:routeIDHashLength]
+	case RouteIDSchemeName:
+		return id
+	default:
+		return id
+	}
+}
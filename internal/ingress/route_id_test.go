@@ -0,0 +1,87 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestParseRouteIDScheme(t *testing.T) {
+	t.Parallel()
+
+	for _, valid := range []string{"name", "uid", "hash"} {
+		scheme, err := ParseRouteIDScheme(valid)
+		require.NoError(t, err)
+		assert.Equal(t, RouteIDScheme(valid), scheme)
+	}
+
+	_, err := ParseRouteIDScheme("random")
+	require.Error(t, err)
+
+	_, err = ParseRouteIDScheme("")
+	require.Error(t, err)
+}
+
+func TestRouteID(t *testing.T) {
+	t.Parallel()
+
+	withUID := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{
+		Name: "app", Namespace: "default", UID: types.UID("0b7a9e2c-5d2f-4c43-9f0e-6d1f3a8b2c11"),
+	}}
+	withoutUID := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+
+	tests := []struct {
+		name     string
+		scheme   RouteIDScheme
+		route    metav1.Object
+		expected string
+	}{
+		{name: "name scheme", scheme: RouteIDSchemeName, route: withUID, expected: "default/app"},
+		{
+			name:     "uid scheme",
+			scheme:   RouteIDSchemeUID,
+			route:    withUID,
+			expected: "default/app/0b7a9e2c-5d2f-4c43-9f0e-6d1f3a8b2c11",
+		},
+		{name: "uid scheme without uid", scheme: RouteIDSchemeUID, route: withoutUID, expected: "default/app"},
+		{name: "hash scheme without uid", scheme: RouteIDSchemeHash, route: withoutUID, expected: "default/app"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, RouteID(tt.scheme, tt.route))
+		})
+	}
+}
+
+func TestRouteID_HashScheme(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid-1"}}
+	recreated := route.DeepCopy()
+	recreated.UID = "uid-2"
+
+	id := RouteID(RouteIDSchemeHash, route)
+
+	assert.Regexp(t, `^default/app-[0-9a-f]{8}$`, id)
+	assert.Equal(t, id, RouteID(RouteIDSchemeHash, route), "IDs must be stable")
+	assert.NotEqual(t, id, RouteID(RouteIDSchemeHash, recreated), "recreated routes must get a new ID")
+}
+
+func TestPingoraBuilder_RouteIDScheme(t *testing.T) {
+	t.Parallel()
+
+	builder := NewPingoraBuilder("cluster.local")
+	builder.SetRouteIDScheme(RouteIDSchemeUID)
+
+	meta := metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "1234"}
+
+	assert.Equal(t, "default/app/1234", builder.BuildHTTPRoute(&gatewayv1.HTTPRoute{ObjectMeta: meta}).GetId())
+	assert.Equal(t, "default/app/1234", builder.BuildGRPCRoute(&gatewayv1.GRPCRoute{ObjectMeta: meta}).GetId())
+}
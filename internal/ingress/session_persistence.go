@@ -0,0 +1,64 @@
+package ingress
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// defaultSessionNamePrefix prefixes generated session names.
+const defaultSessionNamePrefix = "pingora-session-"
+
+// buildSessionPersistence converts the sessionPersistence of a rule.
+// It returns nil when session persistence is not configured.
+//
+// Unset fields get the Gateway API defaults: cookie-based persistence with a
+// session cookie. Without a sessionName, a name unique to the route rule is
+// generated, so rules never share session tokens by accident.
+func buildSessionPersistence(
+	routeID string,
+	ruleIndex int,
+	persistence *gatewayv1.SessionPersistence,
+) *routingv1.SessionPersistence {
+	if persistence == nil {
+		return nil
+	}
+
+	result := &routingv1.SessionPersistence{
+		Type:        routingv1.SessionPersistenceType_SESSION_PERSISTENCE_TYPE_COOKIE,
+		SessionName: defaultSessionName(routeID, ruleIndex),
+	}
+
+	if persistence.Type != nil && *persistence.Type == gatewayv1.HeaderBasedSessionPersistence {
+		result.Type = routingv1.SessionPersistenceType_SESSION_PERSISTENCE_TYPE_HEADER
+	}
+
+	if persistence.SessionName != nil && *persistence.SessionName != "" {
+		result.SessionName = *persistence.SessionName
+	}
+
+	result.AbsoluteTimeoutMs = durationMs(persistence.AbsoluteTimeout)
+	result.IdleTimeoutMs = durationMs(persistence.IdleTimeout)
+
+	if result.GetType() == routingv1.SessionPersistenceType_SESSION_PERSISTENCE_TYPE_COOKIE {
+		result.CookieLifetime = routingv1.CookieLifetimeType_COOKIE_LIFETIME_TYPE_SESSION
+
+		if persistence.CookieConfig != nil && persistence.CookieConfig.LifetimeType != nil &&
+			*persistence.CookieConfig.LifetimeType == gatewayv1.PermanentCookieLifetimeType {
+			result.CookieLifetime = routingv1.CookieLifetimeType_COOKIE_LIFETIME_TYPE_PERMANENT
+		}
+	}
+
+	return result
+}
+
+// defaultSessionName derives a stable session name from the route ID and rule index.
+func defaultSessionName(routeID string, ruleIndex int) string {
+	hash := fnv.New32a()
+	_, _ = fmt.Fprintf(hash, "%s/%d", routeID, ruleIndex)
+
+	return fmt.Sprintf("%s%08x", defaultSessionNamePrefix, hash.Sum32())
+}
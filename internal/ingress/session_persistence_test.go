@@ -0,0 +1,132 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// TestBuildSessionPersistence covers the configurations exercised by the
+// Gateway API extended conformance features HTTPRouteSessionPersistence
+// (cookie and header based) and the cookie lifetime variants.
+func TestBuildSessionPersistence(t *testing.T) {
+	t.Parallel()
+
+	cookie := gatewayv1.CookieBasedSessionPersistence
+	header := gatewayv1.HeaderBasedSessionPersistence
+	permanent := gatewayv1.PermanentCookieLifetimeType
+	session := gatewayv1.SessionCookieLifetimeType
+
+	tests := []struct {
+		name        string
+		persistence *gatewayv1.SessionPersistence
+		expected    *routingv1.SessionPersistence
+	}{
+		{
+			name:        "not configured",
+			persistence: nil,
+			expected:    nil,
+		},
+		{
+			name:        "defaults to a session cookie with a generated name",
+			persistence: &gatewayv1.SessionPersistence{},
+			expected: &routingv1.SessionPersistence{
+				Type:           routingv1.SessionPersistenceType_SESSION_PERSISTENCE_TYPE_COOKIE,
+				SessionName:    defaultSessionName("default/app", 0),
+				CookieLifetime: routingv1.CookieLifetimeType_COOKIE_LIFETIME_TYPE_SESSION,
+			},
+		},
+		{
+			name: "named session cookie with absolute timeout",
+			persistence: &gatewayv1.SessionPersistence{
+				SessionName:     ptrTo("session-a"),
+				Type:            &cookie,
+				AbsoluteTimeout: ptrTo(gatewayv1.Duration("1h")),
+				CookieConfig:    &gatewayv1.CookieConfig{LifetimeType: &session},
+			},
+			expected: &routingv1.SessionPersistence{
+				Type:              routingv1.SessionPersistenceType_SESSION_PERSISTENCE_TYPE_COOKIE,
+				SessionName:       "session-a",
+				AbsoluteTimeoutMs: 3_600_000,
+				CookieLifetime:    routingv1.CookieLifetimeType_COOKIE_LIFETIME_TYPE_SESSION,
+			},
+		},
+		{
+			name: "permanent cookie",
+			persistence: &gatewayv1.SessionPersistence{
+				SessionName:     ptrTo("session-b"),
+				AbsoluteTimeout: ptrTo(gatewayv1.Duration("24h")),
+				IdleTimeout:     ptrTo(gatewayv1.Duration("30m")),
+				CookieConfig:    &gatewayv1.CookieConfig{LifetimeType: &permanent},
+			},
+			expected: &routingv1.SessionPersistence{
+				Type:              routingv1.SessionPersistenceType_SESSION_PERSISTENCE_TYPE_COOKIE,
+				SessionName:       "session-b",
+				AbsoluteTimeoutMs: 86_400_000,
+				IdleTimeoutMs:     1_800_000,
+				CookieLifetime:    routingv1.CookieLifetimeType_COOKIE_LIFETIME_TYPE_PERMANENT,
+			},
+		},
+		{
+			name: "header based",
+			persistence: &gatewayv1.SessionPersistence{
+				SessionName: ptrTo("X-Session"),
+				Type:        &header,
+				IdleTimeout: ptrTo(gatewayv1.Duration("10m")),
+			},
+			expected: &routingv1.SessionPersistence{
+				Type:          routingv1.SessionPersistenceType_SESSION_PERSISTENCE_TYPE_HEADER,
+				SessionName:   "X-Session",
+				IdleTimeoutMs: 600_000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, buildSessionPersistence("default/app", 0, tt.persistence))
+		})
+	}
+}
+
+func TestDefaultSessionName(t *testing.T) {
+	t.Parallel()
+
+	name := defaultSessionName("default/app", 0)
+
+	assert.Equal(t, name, defaultSessionName("default/app", 0), "names must be stable")
+	assert.NotEqual(t, name, defaultSessionName("default/app", 1))
+	assert.NotEqual(t, name, defaultSessionName("default/other", 0))
+	assert.Len(t, name, len(defaultSessionNamePrefix)+8)
+}
+
+func TestBuildHTTPRoute_SessionPersistence(t *testing.T) {
+	t.Parallel()
+
+	route := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			Rules: []gatewayv1.HTTPRouteRule{
+				{BackendRefs: []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}}},
+				{
+					BackendRefs:        []gatewayv1.HTTPBackendRef{{BackendRef: serviceRef("app", 80)}},
+					SessionPersistence: &gatewayv1.SessionPersistence{},
+				},
+			},
+		},
+	}
+
+	result := NewPingoraBuilder("cluster.local").BuildHTTPRoute(route)
+	require.Len(t, result.GetRules(), 2)
+
+	assert.Nil(t, result.GetRules()[0].GetSessionPersistence())
+	require.NotNil(t, result.GetRules()[1].GetSessionPersistence())
+	assert.Equal(t, defaultSessionName("default/app", 1), result.GetRules()[1].GetSessionPersistence().GetSessionName())
+}
@@ -0,0 +1,252 @@
+package ingress
+
+import (
+	"cmp"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// BuildListeners returns the settings of the proxy listeners from the
+// PingoraTrafficPolicies and PingoraAccessControlPolicies attached to the
+// given Gateways.
+//
+// A policy on a listener takes precedence over one of the same kind on its
+// whole Gateway. Listeners of all Gateways on the same port share one proxy
+// listener, so they get the strictest of their limits and a request must be
+// allowed by the access controls of all of them. The PROXY protocol is
+// enabled on a port if any of its listeners enables it, and the client
+// address detection trusts the proxies of all of them. Ports without a
+// policy are left out and use the proxy defaults.
+func BuildListeners(
+	gateways []gatewayv1.Gateway,
+	trafficPolicies []v1alpha1.PingoraTrafficPolicy,
+	accessPolicies []v1alpha1.PingoraAccessControlPolicy,
+) []*routingv1.Listener {
+	if len(trafficPolicies) == 0 && len(accessPolicies) == 0 {
+		return nil
+	}
+
+	traffic := ActivePolicies(policyPointers(trafficPolicies))
+	access := ActivePolicies(policyPointers(accessPolicies))
+	byPort := make(map[uint32]*routingv1.Listener)
+
+	for i := range gateways {
+		gateway := &gateways[i]
+		gatewayTarget := PolicyTarget{Kind: PolicyTargetGateway, Namespace: gateway.Namespace, Name: gateway.Name}
+
+		for j := range gateway.Spec.Listeners {
+			listener := &gateway.Spec.Listeners[j]
+
+			listenerTarget := gatewayTarget
+			listenerTarget.SectionName = string(listener.Name)
+
+			trafficPolicy := listenerPolicy(traffic, listenerTarget, gatewayTarget)
+			accessPolicy := listenerPolicy(access, listenerTarget, gatewayTarget)
+
+			if trafficPolicy == nil && accessPolicy == nil {
+				continue
+			}
+
+			port := uint32(listener.Port)
+
+			result, ok := byPort[port]
+			if !ok {
+				result = &routingv1.Listener{Port: port}
+				byPort[port] = result
+			}
+
+			if trafficPolicy != nil {
+				result.Limits = strictestLimits(result.GetLimits(), listenerLimitsFromPolicy(&trafficPolicy.Spec))
+				result.ProxyProtocol = mergeProxyProtocol(result.GetProxyProtocol(), trafficPolicy.Spec.ProxyProtocol)
+				result.ClientIp = mergeClientIP(result.GetClientIp(), trafficPolicy.Spec.ClientIP)
+			}
+
+			if accessPolicy != nil {
+				result.AccessControls = appendAccessControl(result.GetAccessControls(), accessPolicy)
+			}
+		}
+	}
+
+	listeners := make([]*routingv1.Listener, 0, len(byPort))
+	for _, listener := range byPort {
+		slices.SortFunc(listener.AccessControls, func(a, b *routingv1.AccessControl) int {
+			return cmp.Compare(a.GetId(), b.GetId())
+		})
+
+		listeners = append(listeners, listener)
+	}
+
+	slices.SortFunc(listeners, func(a, b *routingv1.Listener) int {
+		return cmp.Compare(a.GetPort(), b.GetPort())
+	})
+
+	return listeners
+}
+
+func policyPointers[T any](policies []T) []*T {
+	result := make([]*T, 0, len(policies))
+	for i := range policies {
+		result = append(result, &policies[i])
+	}
+
+	return result
+}
+
+// listenerPolicy returns the policy on a listener, or else the one on its
+// Gateway.
+func listenerPolicy[P any](active map[PolicyTarget]P, listener, gateway PolicyTarget) P {
+	if policy, ok := active[listener]; ok {
+		return policy
+	}
+
+	return active[gateway]
+}
+
+// appendAccessControl adds the access control of a policy unless another
+// listener on the same port already added it.
+func appendAccessControl(
+	controls []*routingv1.AccessControl,
+	policy *v1alpha1.PingoraAccessControlPolicy,
+) []*routingv1.AccessControl {
+	acl := accessControlFromPolicy(policy)
+
+	if slices.ContainsFunc(controls, func(existing *routingv1.AccessControl) bool {
+		return existing.GetId() == acl.GetId()
+	}) {
+		return controls
+	}
+
+	return append(controls, acl)
+}
+
+func listenerLimitsFromPolicy(spec *v1alpha1.PingoraTrafficPolicySpec) *routingv1.ListenerLimits {
+	limits := &routingv1.ListenerLimits{
+		MaxRequestBodyBytes:    quantityBytes(spec.MaxRequestBodySize),
+		MaxRequestHeadersBytes: quantityBytes(spec.MaxRequestHeadersSize),
+		RequestHeaderTimeoutMs: durationMs(spec.RequestHeaderTimeout),
+		RequestBodyTimeoutMs:   durationMs(spec.RequestBodyTimeout),
+		IdleTimeoutMs:          durationMs(spec.IdleTimeout),
+	}
+
+	if spec.MaxRequestHeaders != nil {
+		limits.MaxRequestHeaders = uint32(max(*spec.MaxRequestHeaders, 0))
+	}
+
+	return limits
+}
+
+var proxyProtocolVersions = map[v1alpha1.ProxyProtocolVersion]routingv1.ProxyProtocolVersion{
+	v1alpha1.ProxyProtocolVersionV1:  routingv1.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V1,
+	v1alpha1.ProxyProtocolVersionV2:  routingv1.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V2,
+	v1alpha1.ProxyProtocolVersionAny: routingv1.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_UNSPECIFIED,
+}
+
+// mergeProxyProtocol combines the PROXY protocol of listeners sharing a port.
+// The port expects the header if any of them enables it, and accepts both
+// versions if they ask for different ones.
+func mergeProxyProtocol(
+	current *routingv1.ProxyProtocol,
+	config *v1alpha1.ProxyProtocolConfig,
+) *routingv1.ProxyProtocol {
+	if config == nil || !config.Enabled {
+		return current
+	}
+
+	version := proxyProtocolVersions[config.Version]
+
+	if current != nil && current.GetVersion() != version {
+		version = routingv1.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_UNSPECIFIED
+	}
+
+	return &routingv1.ProxyProtocol{Version: version}
+}
+
+// ValidateTrafficPolicy reports the first trusted proxy of a policy that is
+// neither a CIDR nor an IP address.
+func ValidateTrafficPolicy(spec *v1alpha1.PingoraTrafficPolicySpec) error {
+	if spec.ClientIP == nil {
+		return nil
+	}
+
+	for _, entry := range spec.ClientIP.TrustedProxies {
+		if _, err := parseCIDR(entry); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mergeClientIP combines the client address detection of listeners sharing
+// a port. A connection may come from the proxies in front of any of them, so
+// all their trusted proxies are trusted, and the smallest forwarded-for depth
+// applies. The real IP header is kept only if they all use the same.
+func mergeClientIP(current *routingv1.ClientIPDetection, config *v1alpha1.ClientIPConfig) *routingv1.ClientIPDetection {
+	if config == nil {
+		return current
+	}
+
+	detection := &routingv1.ClientIPDetection{
+		TrustedProxyCidrs: normalizeCIDRs(config.TrustedProxies),
+		ForwardedForDepth: forwardedForDepth(config.ForwardedForDepth),
+		RealIpHeader:      realIPHeader(config.RealIPHeader),
+	}
+
+	if current == nil {
+		return detection
+	}
+
+	cidrs := append(slices.Clone(current.GetTrustedProxyCidrs()), detection.GetTrustedProxyCidrs()...)
+	slices.Sort(cidrs)
+
+	detection.TrustedProxyCidrs = slices.Compact(cidrs)
+	detection.ForwardedForDepth = minSet(current.GetForwardedForDepth(), detection.GetForwardedForDepth())
+
+	if current.GetRealIpHeader() != detection.GetRealIpHeader() {
+		detection.RealIpHeader = ""
+	}
+
+	return detection
+}
+
+// strictestLimits combines the limits of listeners sharing a port, taking
+// the smallest value of every field that is set.
+func strictestLimits(a, b *routingv1.ListenerLimits) *routingv1.ListenerLimits {
+	if a == nil {
+		return b
+	}
+
+	return &routingv1.ListenerLimits{
+		MaxRequestBodyBytes:    minSet(a.GetMaxRequestBodyBytes(), b.GetMaxRequestBodyBytes()),
+		MaxRequestHeadersBytes: minSet(a.GetMaxRequestHeadersBytes(), b.GetMaxRequestHeadersBytes()),
+		MaxRequestHeaders:      minSet(a.GetMaxRequestHeaders(), b.GetMaxRequestHeaders()),
+		RequestHeaderTimeoutMs: minSet(a.GetRequestHeaderTimeoutMs(), b.GetRequestHeaderTimeoutMs()),
+		RequestBodyTimeoutMs:   minSet(a.GetRequestBodyTimeoutMs(), b.GetRequestBodyTimeoutMs()),
+		IdleTimeoutMs:          minSet(a.GetIdleTimeoutMs(), b.GetIdleTimeoutMs()),
+	}
+}
+
+// minSet returns the smaller of two values, where 0 means unset.
+func minSet[T uint32 | uint64](a, b T) T {
+	switch {
+	case a == 0:
+		return b
+	case b == 0:
+		return a
+	default:
+		return min(a, b)
+	}
+}
+
+func quantityBytes(quantity *resource.Quantity) uint64 {
+	if quantity == nil {
+		return 0
+	}
+
+	return uint64(max(quantity.Value(), 0))
+}
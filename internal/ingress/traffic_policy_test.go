@@ -0,0 +1,230 @@
+package ingress
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func trafficGateway(name string, listeners map[string]gatewayv1.PortNumber) gatewayv1.Gateway {
+	gateway := gatewayv1.Gateway{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gateway-system"}}
+
+	for listenerName, port := range listeners {
+		gateway.Spec.Listeners = append(gateway.Spec.Listeners, gatewayv1.Listener{
+			Name:     gatewayv1.SectionName(listenerName),
+			Port:     port,
+			Protocol: gatewayv1.HTTPProtocolType,
+		})
+	}
+
+	return gateway
+}
+
+func TestBuildListeners(t *testing.T) {
+	t.Parallel()
+
+	bodySize := resource.MustParse("10Mi")
+	smallBodySize := resource.MustParse("1Mi")
+	headersSize := resource.MustParse("32Ki")
+	maxHeaders := int32(100)
+
+	gateways := []gatewayv1.Gateway{
+		trafficGateway("public", map[string]gatewayv1.PortNumber{"http": 80, "https": 443}),
+		trafficGateway("internal", map[string]gatewayv1.PortNumber{"http": 80}),
+		trafficGateway("unlimited", map[string]gatewayv1.PortNumber{"http": 8080}),
+	}
+
+	policies := []v1alpha1.PingoraTrafficPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "gateway-system"},
+			Spec: v1alpha1.PingoraTrafficPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetGateway, "public", ""),
+				},
+				MaxRequestBodySize:    &bodySize,
+				MaxRequestHeadersSize: &headersSize,
+				RequestHeaderTimeout:  ptrTo(gatewayv1.Duration("10s")),
+				IdleTimeout:           ptrTo(gatewayv1.Duration("1m")),
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "public-https", Namespace: "gateway-system"},
+			Spec: v1alpha1.PingoraTrafficPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetGateway, "public", "https"),
+				},
+				MaxRequestHeaders: &maxHeaders,
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "gateway-system"},
+			Spec: v1alpha1.PingoraTrafficPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetGateway, "internal", ""),
+				},
+				MaxRequestBodySize: &smallBodySize,
+				RequestBodyTimeout: ptrTo(gatewayv1.Duration("30s")),
+				IdleTimeout:        ptrTo(gatewayv1.Duration("5m")),
+			},
+		},
+	}
+
+	listeners := BuildListeners(gateways, policies, nil)
+
+	want := []*routingv1.Listener{
+		{
+			// Port 80 is shared by public and internal, so the strictest
+			// limits of both apply
+			Port: 80,
+			Limits: &routingv1.ListenerLimits{
+				MaxRequestBodyBytes:    1 << 20,
+				MaxRequestHeadersBytes: 32 << 10,
+				RequestHeaderTimeoutMs: 10000,
+				RequestBodyTimeoutMs:   30000,
+				IdleTimeoutMs:          60000,
+			},
+		},
+		{
+			// The listener policy replaces the Gateway policy
+			Port:   443,
+			Limits: &routingv1.ListenerLimits{MaxRequestHeaders: 100},
+		},
+	}
+
+	require.Len(t, listeners, len(want))
+
+	for i := range want {
+		assert.True(t, proto.Equal(want[i], listeners[i]), "listener %d: got %v", i, listeners[i])
+	}
+}
+
+func TestBuildListeners_NoPolicies(t *testing.T) {
+	t.Parallel()
+
+	gateways := []gatewayv1.Gateway{
+		trafficGateway("public", map[string]gatewayv1.PortNumber{"http": 80}),
+	}
+
+	assert.Nil(t, BuildListeners(gateways, nil, nil))
+}
+
+func TestBuildListeners_ProxyProtocol(t *testing.T) {
+	t.Parallel()
+
+	gateways := []gatewayv1.Gateway{
+		trafficGateway("public", map[string]gatewayv1.PortNumber{"http": 80, "https": 443}),
+		trafficGateway("internal", map[string]gatewayv1.PortNumber{"http": 80}),
+	}
+
+	policy := func(name, gateway, section string, config *v1alpha1.ProxyProtocolConfig) v1alpha1.PingoraTrafficPolicy {
+		return v1alpha1.PingoraTrafficPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gateway-system"},
+			Spec: v1alpha1.PingoraTrafficPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetGateway, gateway, section),
+				},
+				ProxyProtocol: config,
+			},
+		}
+	}
+
+	policies := []v1alpha1.PingoraTrafficPolicy{
+		policy("public", "public", "", &v1alpha1.ProxyProtocolConfig{
+			Enabled: true,
+			Version: v1alpha1.ProxyProtocolVersionV2,
+		}),
+		// The listener policy turns the PROXY protocol off again
+		policy("public-https", "public", "https", &v1alpha1.ProxyProtocolConfig{Enabled: false}),
+		policy("internal", "internal", "", &v1alpha1.ProxyProtocolConfig{
+			Enabled: true,
+			Version: v1alpha1.ProxyProtocolVersionV1,
+		}),
+	}
+
+	listeners := BuildListeners(gateways, policies, nil)
+
+	require.Len(t, listeners, 2)
+
+	// Port 80 is shared by listeners asking for different versions
+	assert.True(t, proto.Equal(&routingv1.ProxyProtocol{
+		Version: routingv1.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_UNSPECIFIED,
+	}, listeners[0].GetProxyProtocol()), "port 80: got %v", listeners[0].GetProxyProtocol())
+	assert.Nil(t, listeners[1].GetProxyProtocol())
+
+	listeners = BuildListeners(gateways, policies[:1], nil)
+
+	require.Len(t, listeners, 2)
+	assert.Equal(t, routingv1.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V2, listeners[0].GetProxyProtocol().GetVersion())
+	assert.Equal(t, routingv1.ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V2, listeners[1].GetProxyProtocol().GetVersion())
+}
+
+func TestBuildListeners_ClientIP(t *testing.T) {
+	t.Parallel()
+
+	gateways := []gatewayv1.Gateway{
+		trafficGateway("public", map[string]gatewayv1.PortNumber{"http": 80, "https": 443}),
+		trafficGateway("internal", map[string]gatewayv1.PortNumber{"http": 80}),
+	}
+
+	policies := []v1alpha1.PingoraTrafficPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "gateway-system"},
+			Spec: v1alpha1.PingoraTrafficPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetGateway, "public", ""),
+				},
+				ClientIP: &v1alpha1.ClientIPConfig{
+					TrustedProxies:    []string{"10.0.0.0/8", "192.0.2.1", "not-an-address"},
+					ForwardedForDepth: ptrTo(int32(2)),
+					RealIPHeader:      ptrTo(gatewayv1.HTTPHeaderName("CF-Connecting-IP")),
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "gateway-system"},
+			Spec: v1alpha1.PingoraTrafficPolicySpec{
+				TargetRefs: []gatewayv1.LocalPolicyTargetReferenceWithSectionName{
+					policyTargetRef(PolicyTargetGateway, "internal", ""),
+				},
+				ClientIP: &v1alpha1.ClientIPConfig{
+					TrustedProxies:    []string{"10.0.0.0/8", "172.16.0.0/12"},
+					ForwardedForDepth: ptrTo(int32(1)),
+					RealIPHeader:      ptrTo(gatewayv1.HTTPHeaderName("X-Real-IP")),
+				},
+			},
+		},
+	}
+
+	listeners := BuildListeners(gateways, policies, nil)
+
+	want := []*routingv1.ClientIPDetection{
+		{
+			// Port 80 is shared, so the proxies of both are trusted and
+			// the differing real IP headers are dropped
+			TrustedProxyCidrs: []string{"10.0.0.0/8", "172.16.0.0/12", "192.0.2.1/32"},
+			ForwardedForDepth: 1,
+		},
+		{
+			TrustedProxyCidrs: []string{"10.0.0.0/8", "192.0.2.1/32"},
+			ForwardedForDepth: 2,
+			RealIpHeader:      "CF-Connecting-IP",
+		},
+	}
+
+	require.Len(t, listeners, len(want))
+
+	for i := range want {
+		assert.True(t, proto.Equal(want[i], listeners[i].GetClientIp()), "listener %d: got %v", i, listeners[i].GetClientIp())
+	}
+
+	require.Error(t, ValidateTrafficPolicy(&policies[0].Spec))
+	require.NoError(t, ValidateTrafficPolicy(&policies[1].Spec))
+}
@@ -0,0 +1,420 @@
+// Package loadgen generates HTTPRoute churn against a cluster to soak-test
+// the controller.
+//
+// A Generator creates, updates and deletes synthetic HTTPRoutes at a fixed
+// rate and measures the convergence lag: the time between writing a route and
+// the controller reporting the written generation in the route status. The
+// lag covers sync debouncing, delta sync to the proxy and status updates.
+// The resulting Report is checked against Thresholds to fail a soak test run.
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// LabelKey marks routes created by the generator. Cleanup deletes every route
+// carrying it in the target namespace.
+const LabelKey = "pingora.k8s.lex.la/loadgen"
+
+// Defaults for Config fields left empty.
+const (
+	DefaultRoutes        = 50
+	DefaultRate          = 10.0
+	DefaultDuration      = 5 * time.Minute
+	DefaultPollInterval  = 500 * time.Millisecond
+	DefaultSettleTimeout = 2 * time.Minute
+	DefaultBackendPort   = 80
+	DefaultHostSuffix    = "loadgen.example.com"
+)
+
+// Config configures a Generator.
+type Config struct {
+	// Namespace receives the generated routes.
+	Namespace string
+
+	// GatewayName and GatewayNamespace identify the parent Gateway.
+	// An empty GatewayNamespace means Namespace.
+	GatewayName      string
+	GatewayNamespace string
+
+	// ControllerName is the controller whose status entries are awaited.
+	ControllerName string
+
+	// Routes is the maximum number of generated routes existing at once.
+	Routes int
+
+	// Rate is the number of write operations per second.
+	Rate float64
+
+	// Duration is how long churn is generated.
+	Duration time.Duration
+
+	// PollInterval is how often route status is checked for convergence.
+	PollInterval time.Duration
+
+	// SettleTimeout bounds the wait for pending routes after churn stops.
+	SettleTimeout time.Duration
+
+	// BackendService and BackendPort are referenced by every generated route.
+	BackendService string
+	BackendPort    int32
+
+	// HostSuffix is appended to generated hostnames.
+	HostSuffix string
+
+	// Seed makes the operation sequence reproducible. Zero picks a random seed.
+	Seed uint64
+
+	// Cleanup deletes all generated routes at the end of the run.
+	Cleanup bool
+}
+
+// Generator produces HTTPRoute churn and measures convergence lag.
+type Generator struct {
+	client  client.Client
+	cfg     Config
+	logger  *slog.Logger
+	tracker *lagTracker
+	rand    *rand.Rand
+
+	// existing holds the names of generated routes in creation order.
+	existing   []string
+	nextID     int
+	revision   int
+	operations map[string]int
+	errors     int
+}
+
+// New creates a Generator for the given configuration.
+func New(c client.Client, cfg Config, logger *slog.Logger) (*Generator, error) {
+	if cfg.Namespace == "" {
+		//nolint:wrapcheck // New creates new error, not wrapping
+		return nil, errors.New("namespace is required")
+	}
+
+	if cfg.GatewayName == "" {
+		//nolint:wrapcheck // New creates new error, not wrapping
+		return nil, errors.New("gateway name is required")
+	}
+
+	if cfg.BackendService == "" {
+		//nolint:wrapcheck // New creates new error, not wrapping
+		return nil, errors.New("backend service is required")
+	}
+
+	if cfg.Duration <= 0 {
+		//nolint:wrapcheck // New creates new error, not wrapping
+		return nil, errors.New("duration must be positive")
+	}
+
+	applyDefaults(&cfg)
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	return &Generator{
+		client:     c,
+		cfg:        cfg,
+		logger:     logger.With("component", "loadgen"),
+		tracker:    newLagTracker(cfg.ControllerName),
+		rand:       rand.New(rand.NewPCG(seed, seed)), //nolint:gosec // reproducible load, not security
+		operations: make(map[string]int),
+	}, nil
+}
+
+func applyDefaults(cfg *Config) {
+	if cfg.GatewayNamespace == "" {
+		cfg.GatewayNamespace = cfg.Namespace
+	}
+
+	if cfg.Routes <= 0 {
+		cfg.Routes = DefaultRoutes
+	}
+
+	if cfg.Rate <= 0 {
+		cfg.Rate = DefaultRate
+	}
+
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+
+	if cfg.SettleTimeout <= 0 {
+		cfg.SettleTimeout = DefaultSettleTimeout
+	}
+
+	if cfg.BackendPort == 0 {
+		cfg.BackendPort = DefaultBackendPort
+	}
+
+	if cfg.HostSuffix == "" {
+		cfg.HostSuffix = DefaultHostSuffix
+	}
+}
+
+// Run generates churn for the configured duration, waits for pending routes
+// to converge and returns the report. The context cancels the whole run; the
+// report is still returned for the part that completed.
+func (g *Generator) Run(ctx context.Context) (*Report, error) {
+	g.logger.Info("starting churn",
+		"namespace", g.cfg.Namespace, "routes", g.cfg.Routes,
+		"rate", g.cfg.Rate, "duration", g.cfg.Duration)
+
+	churnCtx, cancelChurn := context.WithTimeout(ctx, g.cfg.Duration)
+	defer cancelChurn()
+
+	pollDone := make(chan struct{})
+
+	go func() {
+		defer close(pollDone)
+
+		g.poll(churnCtx)
+	}()
+
+	g.churn(churnCtx)
+	<-pollDone
+
+	g.settle(ctx)
+
+	if g.cfg.Cleanup {
+		g.cleanup(context.WithoutCancel(ctx))
+	}
+
+	report := newReport(g.operations, g.errors, g.tracker.measured(), g.tracker.unconverged())
+
+	return report, errors.Wrap(context.Cause(ctx), "soak test interrupted")
+}
+
+// churn performs one write operation per tick until the context ends.
+func (g *Generator) churn(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / g.cfg.Rate))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.step(ctx)
+		}
+	}
+}
+
+// poll checks route status for convergence until the context ends.
+func (g *Generator) poll(ctx context.Context) {
+	ticker := time.NewTicker(g.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			g.observe(ctx)
+		}
+	}
+}
+
+// settle polls until all pending routes converged or the settle timeout expires.
+func (g *Generator) settle(ctx context.Context) {
+	settleCtx, cancel := context.WithTimeout(ctx, g.cfg.SettleTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(g.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for g.tracker.unconverged() > 0 {
+		g.observe(settleCtx)
+
+		select {
+		case <-settleCtx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (g *Generator) observe(ctx context.Context) {
+	var routes gatewayv1.HTTPRouteList
+
+	err := g.client.List(ctx, &routes, client.InNamespace(g.cfg.Namespace), client.HasLabels{LabelKey})
+	if err != nil {
+		if ctx.Err() == nil {
+			g.logger.Warn("failed to list routes", "error", err)
+		}
+
+		return
+	}
+
+	g.tracker.observe(routes.Items, time.Now())
+}
+
+// step performs a single randomly chosen write. A quarter of the writes are
+// deletes and a quarter are creates while the pool is not full; the rest are
+// updates.
+func (g *Generator) step(ctx context.Context) {
+	var err error
+
+	op := g.pickOperation()
+
+	switch op {
+	case OpCreate:
+		err = g.create(ctx)
+	case OpUpdate:
+		err = g.update(ctx)
+	case OpDelete:
+		err = g.delete(ctx)
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+
+		g.errors++
+		g.logger.Warn("operation failed", "operation", op, "error", err)
+
+		return
+	}
+
+	g.operations[op]++
+}
+
+func (g *Generator) pickOperation() string {
+	if len(g.existing) == 0 {
+		return OpCreate
+	}
+
+	roll := g.rand.IntN(4) //nolint:mnd // writes are split into quarters
+
+	switch {
+	case roll == 0 && len(g.existing) < g.cfg.Routes:
+		return OpCreate
+	case roll == 1:
+		return OpDelete
+	default:
+		return OpUpdate
+	}
+}
+
+func (g *Generator) create(ctx context.Context) error {
+	name := fmt.Sprintf("loadgen-%05d", g.nextID)
+	g.nextID++
+
+	route := g.newRoute(name)
+	if err := g.client.Create(ctx, route); err != nil {
+		return errors.Wrapf(err, "failed to create route %s", name)
+	}
+
+	g.existing = append(g.existing, name)
+	g.tracker.written(name, route.Generation, time.Now())
+
+	return nil
+}
+
+func (g *Generator) update(ctx context.Context) error {
+	name := g.existing[g.rand.IntN(len(g.existing))]
+
+	var route gatewayv1.HTTPRoute
+	if err := g.client.Get(ctx, client.ObjectKey{Namespace: g.cfg.Namespace, Name: name}, &route); err != nil {
+		return errors.Wrapf(err, "failed to get route %s", name)
+	}
+
+	g.revision++
+	route.Spec.Rules = g.rules()
+
+	if err := g.client.Update(ctx, &route); err != nil {
+		return errors.Wrapf(err, "failed to update route %s", name)
+	}
+
+	g.tracker.written(name, route.Generation, time.Now())
+
+	return nil
+}
+
+func (g *Generator) delete(ctx context.Context) error {
+	idx := g.rand.IntN(len(g.existing))
+	name := g.existing[idx]
+
+	route := &gatewayv1.HTTPRoute{ObjectMeta: metav1.ObjectMeta{Namespace: g.cfg.Namespace, Name: name}}
+	if err := g.client.Delete(ctx, route); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete route %s", name)
+	}
+
+	g.existing = append(g.existing[:idx], g.existing[idx+1:]...)
+	g.tracker.deleted(name)
+
+	return nil
+}
+
+func (g *Generator) cleanup(ctx context.Context) {
+	err := g.client.DeleteAllOf(ctx, &gatewayv1.HTTPRoute{},
+		client.InNamespace(g.cfg.Namespace), client.HasLabels{LabelKey})
+	if err != nil {
+		g.logger.Warn("failed to clean up routes", "error", err)
+
+		return
+	}
+
+	g.logger.Info("cleaned up generated routes", "namespace", g.cfg.Namespace)
+}
+
+func (g *Generator) newRoute(name string) *gatewayv1.HTTPRoute {
+	gatewayNamespace := gatewayv1.Namespace(g.cfg.GatewayNamespace)
+
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: g.cfg.Namespace,
+			Labels:    map[string]string{LabelKey: "true"},
+		},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{
+					Name:      gatewayv1.ObjectName(g.cfg.GatewayName),
+					Namespace: &gatewayNamespace,
+				}},
+			},
+			Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(name + "." + g.cfg.HostSuffix)},
+			Rules:     g.rules(),
+		},
+	}
+}
+
+// rules returns route rules for the current revision. Every update changes
+// the path prefix, so each write bumps the route generation.
+func (g *Generator) rules() []gatewayv1.HTTPRouteRule {
+	pathType := gatewayv1.PathMatchPathPrefix
+	path := fmt.Sprintf("/rev-%d", g.revision)
+	port := gatewayv1.PortNumber(g.cfg.BackendPort)
+
+	return []gatewayv1.HTTPRouteRule{{
+		Matches: []gatewayv1.HTTPRouteMatch{{
+			Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &path},
+		}},
+		BackendRefs: []gatewayv1.HTTPBackendRef{{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Name: gatewayv1.ObjectName(g.cfg.BackendService),
+					Port: &port,
+				},
+			},
+		}},
+	}}
+}
@@ -0,0 +1,156 @@
+package loadgen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const testNamespace = "loadgen"
+
+func newFakeClient(t *testing.T) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&gatewayv1.HTTPRoute{}).
+		Build()
+}
+
+func testConfig() Config {
+	return Config{
+		Namespace:      testNamespace,
+		GatewayName:    "pingora",
+		ControllerName: testControllerName,
+		Routes:         5,
+		Rate:           200,
+		Duration:       200 * time.Millisecond,
+		PollInterval:   10 * time.Millisecond,
+		SettleTimeout:  200 * time.Millisecond,
+		BackendService: "echo",
+		Seed:           42,
+		Cleanup:        true,
+	}
+}
+
+// acknowledge plays the controller: it reports the current generation of
+// every generated route in its status until the context ends.
+func acknowledge(ctx context.Context, c client.Client) {
+	ticker := time.NewTicker(5 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var routes gatewayv1.HTTPRouteList
+		if err := c.List(ctx, &routes, client.InNamespace(testNamespace)); err != nil {
+			continue
+		}
+
+		for i := range routes.Items {
+			route := &routes.Items[i]
+			route.Status.Parents = []gatewayv1.RouteParentStatus{acceptedParent(testControllerName, route.Generation)}
+			route.Status.Parents[0].ParentRef = gatewayv1.ParentReference{Name: "pingora"}
+			route.Status.Parents[0].Conditions[0].LastTransitionTime = metav1.Now()
+			route.Status.Parents[0].Conditions[0].Reason = string(gatewayv1.RouteReasonAccepted)
+
+			_ = c.Status().Update(ctx, route)
+		}
+	}
+}
+
+func TestNew_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+	}{
+		{name: "missing namespace", mutate: func(cfg *Config) { cfg.Namespace = "" }},
+		{name: "missing gateway", mutate: func(cfg *Config) { cfg.GatewayName = "" }},
+		{name: "missing backend", mutate: func(cfg *Config) { cfg.BackendService = "" }},
+		{name: "zero duration", mutate: func(cfg *Config) { cfg.Duration = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testConfig()
+			tt.mutate(&cfg)
+
+			_, err := New(newFakeClient(t), cfg, nil)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestGenerator_Run(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go acknowledge(ctx, c)
+
+	generator, err := New(c, testConfig(), nil)
+	require.NoError(t, err)
+
+	report, err := generator.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Positive(t, report.Operations[OpCreate])
+	assert.Zero(t, report.Errors)
+	assert.Zero(t, report.Unconverged)
+	assert.NotEmpty(t, report.Lags)
+	require.NoError(t, report.Check(Thresholds{MaxP99Lag: time.Second}))
+
+	var routes gatewayv1.HTTPRouteList
+	require.NoError(t, c.List(context.Background(), &routes, client.InNamespace(testNamespace)))
+	assert.Empty(t, routes.Items, "cleanup must delete generated routes")
+}
+
+func TestGenerator_RunWithoutController(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig()
+	cfg.Cleanup = false
+
+	c := newFakeClient(t)
+
+	generator, err := New(c, cfg, nil)
+	require.NoError(t, err)
+
+	report, err := generator.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Empty(t, report.Lags)
+	assert.Positive(t, report.Unconverged)
+	require.Error(t, report.Check(Thresholds{}))
+
+	var routes gatewayv1.HTTPRouteList
+	require.NoError(t, c.List(context.Background(), &routes, client.InNamespace(testNamespace)))
+	assert.Len(t, routes.Items, report.Unconverged)
+
+	for i := range routes.Items {
+		assert.Equal(t, "true", routes.Items[i].Labels[LabelKey])
+		assert.LessOrEqual(t, len(routes.Items), cfg.Routes)
+	}
+}
@@ -0,0 +1,109 @@
+package loadgen
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Operations performed by the generator.
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// DefaultMaxP99Lag is the default p99 convergence lag threshold.
+const DefaultMaxP99Lag = 10 * time.Second
+
+// Thresholds are the assertions checked against a Report.
+type Thresholds struct {
+	// MaxP99Lag is the highest accepted 99th percentile convergence lag.
+	// Zero disables the check.
+	MaxP99Lag time.Duration
+
+	// MaxUnconverged is the highest accepted number of routes whose status
+	// did not catch up before the settle timeout.
+	MaxUnconverged int
+
+	// MaxErrors is the highest accepted number of failed API calls.
+	MaxErrors int
+}
+
+// Report summarizes a soak test run.
+type Report struct {
+	// Operations counts successful writes by operation.
+	Operations map[string]int
+
+	// Errors counts failed API calls.
+	Errors int
+
+	// Lags are the measured convergence lags, sorted ascending.
+	Lags []time.Duration
+
+	// Unconverged is the number of routes whose status did not catch up
+	// before the settle timeout.
+	Unconverged int
+}
+
+// Percentile returns the convergence lag at percentile p (0-100) using the
+// nearest-rank method, or zero if no lag was measured.
+func (r *Report) Percentile(p float64) time.Duration {
+	if len(r.Lags) == 0 {
+		return 0
+	}
+
+	rank := int(math.Ceil(p / 100 * float64(len(r.Lags))))
+	rank = min(max(rank, 1), len(r.Lags))
+
+	return r.Lags[rank-1]
+}
+
+// Check returns an error listing every threshold the report violates.
+func (r *Report) Check(thresholds Thresholds) error {
+	var violations []string
+
+	if p99 := r.Percentile(99); thresholds.MaxP99Lag > 0 && p99 > thresholds.MaxP99Lag {
+		violations = append(violations, fmt.Sprintf("p99 convergence lag %s exceeds %s", p99, thresholds.MaxP99Lag))
+	}
+
+	if r.Unconverged > thresholds.MaxUnconverged {
+		violations = append(violations, fmt.Sprintf("%d routes did not converge, at most %d allowed",
+			r.Unconverged, thresholds.MaxUnconverged))
+	}
+
+	if r.Errors > thresholds.MaxErrors {
+		violations = append(violations, fmt.Sprintf("%d API errors, at most %d allowed", r.Errors, thresholds.MaxErrors))
+	}
+
+	if len(violations) > 0 {
+		return errors.Newf("soak test failed: %s", strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+// String renders the report as a short human-readable summary.
+func (r *Report) String() string {
+	return fmt.Sprintf(
+		"creates=%d updates=%d deletes=%d errors=%d converged=%d unconverged=%d lag p50=%s p90=%s p99=%s max=%s",
+		r.Operations[OpCreate], r.Operations[OpUpdate], r.Operations[OpDelete], r.Errors,
+		len(r.Lags), r.Unconverged,
+		r.Percentile(50), r.Percentile(90), r.Percentile(99), r.Percentile(100),
+	)
+}
+
+func newReport(operations map[string]int, errorCount int, lags []time.Duration, unconverged int) *Report {
+	slices.Sort(lags)
+
+	return &Report{
+		Operations:  operations,
+		Errors:      errorCount,
+		Lags:        lags,
+		Unconverged: unconverged,
+	}
+}
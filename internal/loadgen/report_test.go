@@ -0,0 +1,88 @@
+package loadgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lags(millis ...int) []time.Duration {
+	result := make([]time.Duration, 0, len(millis))
+	for _, ms := range millis {
+		result = append(result, time.Duration(ms)*time.Millisecond)
+	}
+
+	return result
+}
+
+func TestReport_Percentile(t *testing.T) {
+	t.Parallel()
+
+	report := newReport(nil, 0, lags(50, 10, 40, 20, 30, 60, 70, 80, 90, 100), 0)
+
+	assert.Equal(t, 10*time.Millisecond, report.Percentile(0))
+	assert.Equal(t, 50*time.Millisecond, report.Percentile(50))
+	assert.Equal(t, 90*time.Millisecond, report.Percentile(90))
+	assert.Equal(t, 100*time.Millisecond, report.Percentile(99))
+	assert.Equal(t, 100*time.Millisecond, report.Percentile(100))
+
+	assert.Zero(t, newReport(nil, 0, nil, 0).Percentile(99))
+}
+
+func TestReport_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		report     *Report
+		thresholds Thresholds
+		wantErr    string
+	}{
+		{
+			name:       "within thresholds",
+			report:     newReport(nil, 0, lags(100, 200), 0),
+			thresholds: Thresholds{MaxP99Lag: time.Second},
+		},
+		{
+			name:       "lag check disabled",
+			report:     newReport(nil, 0, lags(5000), 0),
+			thresholds: Thresholds{},
+		},
+		{
+			name:       "lag exceeded",
+			report:     newReport(nil, 0, lags(100, 2000), 0),
+			thresholds: Thresholds{MaxP99Lag: time.Second},
+			wantErr:    "p99 convergence lag 2s exceeds 1s",
+		},
+		{
+			name:       "unconverged routes",
+			report:     newReport(nil, 0, nil, 3),
+			thresholds: Thresholds{MaxUnconverged: 1},
+			wantErr:    "3 routes did not converge, at most 1 allowed",
+		},
+		{
+			name:       "api errors",
+			report:     newReport(nil, 2, nil, 0),
+			thresholds: Thresholds{},
+			wantErr:    "2 API errors, at most 0 allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.report.Check(tt.thresholds)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package loadgen
+
+import (
+	"sync"
+	"time"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// pendingWrite is a route write whose status has not caught up yet.
+type pendingWrite struct {
+	generation int64
+	writtenAt  time.Time
+}
+
+// lagTracker measures the time between writing a route and the controller
+// reporting the written generation in the route status.
+//
+// When a route is written again before it converged, the lag is measured from
+// the first unconverged write, so debouncing and coalescing delays are part of
+// the result.
+type lagTracker struct {
+	controllerName gatewayv1.GatewayController
+
+	mu      sync.Mutex
+	pending map[string]pendingWrite
+	lags    []time.Duration
+}
+
+func newLagTracker(controllerName string) *lagTracker {
+	return &lagTracker{
+		controllerName: gatewayv1.GatewayController(controllerName),
+		pending:        make(map[string]pendingWrite),
+	}
+}
+
+// written records a create or update of the named route.
+func (t *lagTracker) written(name string, generation int64, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	write := pendingWrite{generation: generation, writtenAt: at}
+	if previous, ok := t.pending[name]; ok {
+		write.writtenAt = previous.writtenAt
+	}
+
+	t.pending[name] = write
+}
+
+// deleted stops tracking the named route.
+func (t *lagTracker) deleted(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.pending, name)
+}
+
+// observe records the lag of every pending route whose status reports the
+// written generation.
+func (t *lagTracker) observe(routes []gatewayv1.HTTPRoute, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range routes {
+		route := &routes[i]
+
+		write, ok := t.pending[route.Name]
+		if !ok || observedGeneration(route, t.controllerName) < write.generation {
+			continue
+		}
+
+		t.lags = append(t.lags, now.Sub(write.writtenAt))
+		delete(t.pending, route.Name)
+	}
+}
+
+// unconverged returns the number of routes still waiting for their status.
+func (t *lagTracker) unconverged() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return len(t.pending)
+}
+
+// measured returns a copy of the recorded lags.
+func (t *lagTracker) measured() []time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]time.Duration(nil), t.lags...)
+}
+
+// observedGeneration returns the lowest generation the controller reported in
+// the Accepted condition over all of its parent status entries, or -1 if it
+// reported none.
+func observedGeneration(route *gatewayv1.HTTPRoute, controllerName gatewayv1.GatewayController) int64 {
+	observed := int64(-1)
+
+	for i := range route.Status.Parents {
+		parent := &route.Status.Parents[i]
+		if parent.ControllerName != controllerName {
+			continue
+		}
+
+		for j := range parent.Conditions {
+			condition := &parent.Conditions[j]
+			if condition.Type != string(gatewayv1.RouteConditionAccepted) {
+				continue
+			}
+
+			if observed < 0 || condition.ObservedGeneration < observed {
+				observed = condition.ObservedGeneration
+			}
+		}
+	}
+
+	return observed
+}
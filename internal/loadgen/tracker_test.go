@@ -0,0 +1,106 @@
+package loadgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const testControllerName = "pingora.k8s.lex.la/gateway-controller"
+
+func routeWithStatus(name string, parents ...gatewayv1.RouteParentStatus) gatewayv1.HTTPRoute {
+	return gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: gatewayv1.HTTPRouteStatus{
+			RouteStatus: gatewayv1.RouteStatus{Parents: parents},
+		},
+	}
+}
+
+func acceptedParent(controllerName string, generation int64) gatewayv1.RouteParentStatus {
+	return gatewayv1.RouteParentStatus{
+		ControllerName: gatewayv1.GatewayController(controllerName),
+		Conditions: []metav1.Condition{{
+			Type:               string(gatewayv1.RouteConditionAccepted),
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: generation,
+		}},
+	}
+}
+
+func TestObservedGeneration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		route    gatewayv1.HTTPRoute
+		expected int64
+	}{
+		{
+			name:     "no status",
+			route:    routeWithStatus("app"),
+			expected: -1,
+		},
+		{
+			name:     "other controller only",
+			route:    routeWithStatus("app", acceptedParent("example.com/other", 3)),
+			expected: -1,
+		},
+		{
+			name:     "single parent",
+			route:    routeWithStatus("app", acceptedParent(testControllerName, 3)),
+			expected: 3,
+		},
+		{
+			name: "lowest generation over parents",
+			route: routeWithStatus("app",
+				acceptedParent(testControllerName, 4),
+				acceptedParent(testControllerName, 2),
+				acceptedParent("example.com/other", 1)),
+			expected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, observedGeneration(&tt.route, testControllerName))
+		})
+	}
+}
+
+func TestLagTracker(t *testing.T) {
+	t.Parallel()
+
+	tracker := newLagTracker(testControllerName)
+	start := time.Now()
+
+	tracker.written("a", 1, start)
+	tracker.written("b", 1, start)
+	tracker.written("c", 1, start)
+
+	// A second write before convergence keeps the first write time
+	tracker.written("a", 2, start.Add(time.Second))
+	tracker.deleted("c")
+
+	assert.Equal(t, 2, tracker.unconverged())
+
+	tracker.observe([]gatewayv1.HTTPRoute{
+		routeWithStatus("a", acceptedParent(testControllerName, 1)),
+		routeWithStatus("b", acceptedParent(testControllerName, 1)),
+	}, start.Add(2*time.Second))
+
+	assert.Equal(t, 1, tracker.unconverged(), "route a must wait for generation 2")
+	assert.Equal(t, []time.Duration{2 * time.Second}, tracker.measured())
+
+	tracker.observe([]gatewayv1.HTTPRoute{
+		routeWithStatus("a", acceptedParent(testControllerName, 2)),
+	}, start.Add(3*time.Second))
+
+	assert.Zero(t, tracker.unconverged())
+	assert.Equal(t, []time.Duration{2 * time.Second, 3 * time.Second}, tracker.measured())
+}
@@ -3,6 +3,7 @@ package metrics
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -17,33 +18,119 @@ type Collector interface {
 	RecordIngressRules(ctx context.Context, count int)
 	RecordFailedBackendRefs(ctx context.Context, routeType string, count int)
 	RecordSyncError(ctx context.Context, errorType string)
+	RecordSyncSkipped(ctx context.Context)
+	RecordSyncCoalesced(ctx context.Context)
+	RecordSyncQueueDepth(ctx context.Context, depth int)
+	RecordSyncBatchSize(ctx context.Context, requests int)
+	RecordSyncLockWait(ctx context.Context, duration time.Duration)
+	RecordLastSuccessfulSync(ctx context.Context, at time.Time)
+	RecordRouteGenerations(ctx context.Context, routeType string, routes []RouteGeneration)
 
 	// Ingress builder metrics
 	RecordIngressBuildDuration(ctx context.Context, routeType string, duration time.Duration)
 	RecordBackendRefValidation(ctx context.Context, routeType, result, reason string)
+	RecordRouteRules(ctx context.Context, routeType string, rules []RouteRule)
+	RecordDroppedRules(ctx context.Context, routeType, reason string, count int)
+	RecordDroppedRule(ctx context.Context, routeType, reason string)
+	RecordFilterUnsupported(ctx context.Context, filterType string)
 
 	// gRPC metrics (Pingora proxy communication)
 	RecordGRPCCall(ctx context.Context, method, status string, duration time.Duration)
 	RecordGRPCError(ctx context.Context, method, errorType string)
+	RecordGRPCRetry(ctx context.Context, method, code string)
+	RecordProxyConnectionState(ctx context.Context, state string)
+	RecordProxyHealthy(ctx context.Context, healthy bool)
+
+	// Smoke test metrics (post-sync data plane checks)
+	RecordSmokeTest(ctx context.Context, result string, duration time.Duration)
+
+	// Feature metrics (capabilities of the running controller)
+	RecordFeature(ctx context.Context, category, feature string, enabled bool)
+
+	// Backend health metrics (reported by the proxy)
+	RecordBackendHealth(ctx context.Context, backends []BackendHealth)
+
+	// Traffic metrics (counted by the proxy)
+	RecordProxyTraffic(ctx context.Context, routes []RouteTraffic, listeners []ListenerTraffic)
+}
+
+// RouteRule identifies a rule of a synced route.
+type RouteRule struct {
+	// Route is the route ID sent to the proxy.
+	Route string
+
+	// Index is the position of the rule in the route spec.
+	Index int
+
+	// Name is the rule name, empty for unnamed rules.
+	Name string
+}
+
+// RouteGeneration is the generation of a route programmed in the proxy.
+type RouteGeneration struct {
+	// Route is the route as "namespace/name".
+	Route string
+
+	// Generation is the metadata.generation of the route.
+	Generation int64
+}
+
+// BackendHealth is the health of the endpoints behind a backend address.
+type BackendHealth struct {
+	// Backend is the backend address sent to the proxy.
+	Backend string
+
+	// Healthy is the number of endpoints passing the health check.
+	Healthy int
+
+	// Unhealthy is the number of endpoints failing the health check.
+	Unhealthy int
 }
 
 // prometheusCollector implements Collector using Prometheus metrics.
 type prometheusCollector struct {
 	// Sync metrics
-	syncDuration      *prometheus.HistogramVec
-	syncedRoutes      *prometheus.GaugeVec
-	ingressRulesTotal prometheus.Gauge
-	failedBackendRefs *prometheus.GaugeVec
-	syncErrorsTotal   *prometheus.CounterVec
+	syncDuration       *prometheus.HistogramVec
+	syncedRoutes       *prometheus.GaugeVec
+	ingressRulesTotal  prometheus.Gauge
+	failedBackendRefs  *prometheus.GaugeVec
+	syncErrorsTotal    *prometheus.CounterVec
+	syncSkippedTotal   prometheus.Counter
+	syncCoalesced      prometheus.Counter
+	syncQueueDepth     prometheus.Gauge
+	syncBatchSize      prometheus.Histogram
+	syncLockWait       prometheus.Histogram
+	lastSuccessfulSync prometheus.Gauge
+	routeGenerations   *prometheus.GaugeVec
 
 	// Ingress builder metrics
 	ingressBuildDuration *prometheus.HistogramVec
 	backendRefValidation *prometheus.CounterVec
+	routeRules           *prometheus.GaugeVec
+	droppedRules         *prometheus.GaugeVec
+	droppedRulesTotal    *prometheus.CounterVec
+	unsupportedFilters   *prometheus.CounterVec
 
 	// gRPC metrics
 	grpcDuration    *prometheus.HistogramVec
 	grpcCallsTotal  *prometheus.CounterVec
 	grpcErrorsTotal *prometheus.CounterVec
+	grpcRetries     *prometheus.CounterVec
+	connectionState *prometheus.GaugeVec
+	proxyHealthy    prometheus.Gauge
+
+	// Smoke test metrics
+	smokeTestDuration *prometheus.HistogramVec
+	smokeTestsTotal   *prometheus.CounterVec
+
+	// Feature metrics
+	features *prometheus.GaugeVec
+
+	// Backend health metrics
+	backendEndpoints *prometheus.GaugeVec
+
+	// Traffic metrics
+	traffic *trafficCollector
 }
 
 // NewCollector creates a new Prometheus metrics collector and registers metrics.
@@ -52,6 +139,10 @@ func NewCollector(reg prometheus.Registerer) Collector {
 	c.initSyncMetrics()
 	c.initIngressMetrics()
 	c.initGRPCMetrics()
+	c.initSmokeTestMetrics()
+	c.initFeatureMetrics()
+	c.initBackendHealthMetrics()
+	c.traffic = newTrafficCollector()
 	c.register(reg)
 
 	return c
@@ -82,6 +173,48 @@ func (c *prometheusCollector) RecordSyncError(_ context.Context, errorType strin
 	c.syncErrorsTotal.WithLabelValues(errorType).Inc()
 }
 
+// RecordSyncSkipped records a sync skipped because the route config was unchanged.
+func (c *prometheusCollector) RecordSyncSkipped(_ context.Context) {
+	c.syncSkippedTotal.Inc()
+}
+
+// RecordSyncCoalesced records a sync request merged into an already pending sync.
+func (c *prometheusCollector) RecordSyncCoalesced(_ context.Context) {
+	c.syncCoalesced.Inc()
+}
+
+// RecordSyncQueueDepth records the number of sync requests waiting for a result.
+func (c *prometheusCollector) RecordSyncQueueDepth(_ context.Context, depth int) {
+	c.syncQueueDepth.Set(float64(depth))
+}
+
+// RecordSyncBatchSize records the number of sync requests served by a single sync.
+func (c *prometheusCollector) RecordSyncBatchSize(_ context.Context, requests int) {
+	c.syncBatchSize.Observe(float64(requests))
+}
+
+// RecordSyncLockWait records how long a sync waited for the sync lock.
+func (c *prometheusCollector) RecordSyncLockWait(_ context.Context, duration time.Duration) {
+	c.syncLockWait.Observe(duration.Seconds())
+}
+
+// RecordLastSuccessfulSync records when the proxy last confirmed the route
+// configuration, as a Unix timestamp.
+func (c *prometheusCollector) RecordLastSuccessfulSync(_ context.Context, at time.Time) {
+	c.lastSuccessfulSync.Set(float64(at.UnixNano()) / float64(time.Second))
+}
+
+// RecordRouteGenerations records the generations of the routes of a type in
+// the configuration the proxy last confirmed. Series of routes that are no
+// longer programmed are removed.
+func (c *prometheusCollector) RecordRouteGenerations(_ context.Context, routeType string, routes []RouteGeneration) {
+	c.routeGenerations.DeletePartialMatch(prometheus.Labels{"type": routeType})
+
+	for _, route := range routes {
+		c.routeGenerations.WithLabelValues(routeType, route.Route).Set(float64(route.Generation))
+	}
+}
+
 // RecordIngressBuildDuration records the duration of ingress rule building.
 func (c *prometheusCollector) RecordIngressBuildDuration(
 	_ context.Context,
@@ -96,6 +229,33 @@ func (c *prometheusCollector) RecordBackendRefValidation(_ context.Context, rout
 	c.backendRefValidation.WithLabelValues(routeType, result, reason).Inc()
 }
 
+// RecordRouteRules replaces the rule info series of the given route type
+// with the rules of the last built configuration.
+func (c *prometheusCollector) RecordRouteRules(_ context.Context, routeType string, rules []RouteRule) {
+	c.routeRules.DeletePartialMatch(prometheus.Labels{"type": routeType})
+
+	for _, rule := range rules {
+		c.routeRules.WithLabelValues(routeType, rule.Route, strconv.Itoa(rule.Index), rule.Name).Set(1)
+	}
+}
+
+// RecordDroppedRules records the number of route rules of a type that were
+// left out of the last built config for the given reason.
+func (c *prometheusCollector) RecordDroppedRules(_ context.Context, routeType, reason string, count int) {
+	c.droppedRules.WithLabelValues(routeType, reason).Set(float64(count))
+}
+
+// RecordDroppedRule records a route rule or backendRef of a type that the
+// builder left out of a built route for the given reason.
+func (c *prometheusCollector) RecordDroppedRule(_ context.Context, routeType, reason string) {
+	c.droppedRulesTotal.WithLabelValues(routeType, reason).Inc()
+}
+
+// RecordFilterUnsupported records a route filter that the builder ignored.
+func (c *prometheusCollector) RecordFilterUnsupported(_ context.Context, filterType string) {
+	c.unsupportedFilters.WithLabelValues(filterType).Inc()
+}
+
 // RecordGRPCCall records a gRPC call to the Pingora proxy.
 func (c *prometheusCollector) RecordGRPCCall(
 	_ context.Context,
@@ -111,6 +271,66 @@ func (c *prometheusCollector) RecordGRPCError(_ context.Context, method, errorTy
 	c.grpcErrorsTotal.WithLabelValues(method, errorType).Inc()
 }
 
+// RecordGRPCRetry records a retry of a failed gRPC call, by the status code
+// of the failed attempt.
+func (c *prometheusCollector) RecordGRPCRetry(_ context.Context, method, code string) {
+	c.grpcRetries.WithLabelValues(method, code).Inc()
+}
+
+// RecordProxyConnectionState sets the current connection state to 1 and
+// removes the series of the previous state.
+func (c *prometheusCollector) RecordProxyConnectionState(_ context.Context, state string) {
+	c.connectionState.Reset()
+	c.connectionState.WithLabelValues(state).Set(1)
+}
+
+// RecordProxyHealthy records the result of the last proxy health check.
+func (c *prometheusCollector) RecordProxyHealthy(_ context.Context, healthy bool) {
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+
+	c.proxyHealthy.Set(value)
+}
+
+// RecordSmokeTest records the result of a post-sync smoke test request.
+func (c *prometheusCollector) RecordSmokeTest(_ context.Context, result string, duration time.Duration) {
+	c.smokeTestDuration.WithLabelValues(result).Observe(duration.Seconds())
+	c.smokeTestsTotal.WithLabelValues(result).Inc()
+}
+
+// RecordFeature records whether a controller feature is enabled.
+func (c *prometheusCollector) RecordFeature(_ context.Context, category, feature string, enabled bool) {
+	value := 0.0
+	if enabled {
+		value = 1
+	}
+
+	c.features.WithLabelValues(category, feature).Set(value)
+}
+
+// RecordBackendHealth replaces the endpoint counts of all backends with the
+// last report of the proxy, so that removed backends disappear.
+func (c *prometheusCollector) RecordBackendHealth(_ context.Context, backends []BackendHealth) {
+	c.backendEndpoints.Reset()
+
+	for _, backend := range backends {
+		c.backendEndpoints.WithLabelValues(backend.Backend, "healthy").Set(float64(backend.Healthy))
+		c.backendEndpoints.WithLabelValues(backend.Backend, "unhealthy").Set(float64(backend.Unhealthy))
+	}
+}
+
+// RecordProxyTraffic replaces the traffic of all routes and listeners with
+// the last report of the proxy, so that removed routes disappear.
+func (c *prometheusCollector) RecordProxyTraffic(
+	_ context.Context,
+	routes []RouteTraffic,
+	listeners []ListenerTraffic,
+) {
+	c.traffic.set(routes, listeners)
+}
+
 func (c *prometheusCollector) initSyncMetrics() {
 	c.syncDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -147,6 +367,51 @@ func (c *prometheusCollector) initSyncMetrics() {
 		},
 		[]string{"error_type"},
 	)
+	c.syncSkippedTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "pingora_sync_skipped_total",
+			Help: "Total syncs skipped because the route config was unchanged",
+		},
+	)
+	c.syncCoalesced = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "pingora_sync_coalesced_total",
+			Help: "Total sync requests coalesced into an already pending sync",
+		},
+	)
+	c.syncQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pingora_sync_queue_depth",
+			Help: "Sync requests waiting for a sync result",
+		},
+	)
+	c.syncBatchSize = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "pingora_sync_batch_requests",
+			Help:    "Sync requests served by a single sync",
+			Buckets: []float64{1, 2, 5, 10, 25, 50, 100, 250},
+		},
+	)
+	c.syncLockWait = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "pingora_sync_lock_wait_seconds",
+			Help:    "Time a sync waited for the sync lock",
+			Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10, 30},
+		},
+	)
+	c.lastSuccessfulSync = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pingora_last_successful_sync_timestamp_seconds",
+			Help: "Unix timestamp of the last sync confirmed by the proxy",
+		},
+	)
+	c.routeGenerations = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pingora_route_generation_observed",
+			Help: "Generation of each route in the configuration last confirmed by the proxy",
+		},
+		[]string{"type", "route"},
+	)
 }
 
 func (c *prometheusCollector) initIngressMetrics() {
@@ -165,6 +430,34 @@ func (c *prometheusCollector) initIngressMetrics() {
 		},
 		[]string{"type", "result", "reason"},
 	)
+	c.routeRules = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pingora_route_rule_info",
+			Help: "Rules of the routes in the last built proxy config, always 1",
+		},
+		[]string{"type", "route", "rule_index", "rule_name"},
+	)
+	c.droppedRules = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pingora_dropped_route_rules",
+			Help: "Route rules left out of the last built proxy config",
+		},
+		[]string{"type", "reason"},
+	)
+	c.droppedRulesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pingora_dropped_rules_total",
+			Help: "Route rules and backendRefs left out of built routes",
+		},
+		[]string{"type", "reason"},
+	)
+	c.unsupportedFilters = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pingora_unsupported_filters_total",
+			Help: "Route filters ignored when building routes",
+		},
+		[]string{"filter"},
+	)
 }
 
 func (c *prometheusCollector) initGRPCMetrics() {
@@ -190,6 +483,64 @@ func (c *prometheusCollector) initGRPCMetrics() {
 		},
 		[]string{"method", "error_type"},
 	)
+	c.grpcRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pingora_grpc_retries_total",
+			Help: "Total retries of failed gRPC calls to Pingora proxy by status code",
+		},
+		[]string{"method", "code"},
+	)
+	c.connectionState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pingora_proxy_connection_state",
+			Help: "State of the connection to Pingora proxy, 1 for the current state",
+		},
+		[]string{"state"},
+	)
+	c.proxyHealthy = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "pingora_proxy_healthy",
+			Help: "Whether the last health check of Pingora proxy succeeded, 1 if healthy and 0 if not",
+		},
+	)
+}
+
+func (c *prometheusCollector) initSmokeTestMetrics() {
+	c.smokeTestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "pingora_smoke_test_duration_seconds",
+			Help:    "Duration of post-sync smoke test requests through the proxy",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+		},
+		[]string{"result"},
+	)
+	c.smokeTestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pingora_smoke_tests_total",
+			Help: "Total post-sync smoke test requests by result",
+		},
+		[]string{"result"},
+	)
+}
+
+func (c *prometheusCollector) initFeatureMetrics() {
+	c.features = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pingora_controller_feature",
+			Help: "Controller features by category, 1 if enabled and 0 if disabled",
+		},
+		[]string{"category", "feature"},
+	)
+}
+
+func (c *prometheusCollector) initBackendHealthMetrics() {
+	c.backendEndpoints = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "pingora_backend_endpoints",
+			Help: "Endpoints of health checked backends by health, as reported by the proxy",
+		},
+		[]string{"backend", "health"},
+	)
 }
 
 func (c *prometheusCollector) register(reg prometheus.Registerer) {
@@ -199,11 +550,30 @@ func (c *prometheusCollector) register(reg prometheus.Registerer) {
 		c.ingressRulesTotal,
 		c.failedBackendRefs,
 		c.syncErrorsTotal,
+		c.syncSkippedTotal,
+		c.syncCoalesced,
+		c.syncQueueDepth,
+		c.syncBatchSize,
+		c.syncLockWait,
+		c.lastSuccessfulSync,
+		c.routeGenerations,
 		c.ingressBuildDuration,
 		c.backendRefValidation,
+		c.droppedRules,
+		c.droppedRulesTotal,
+		c.unsupportedFilters,
+		c.routeRules,
 		c.grpcDuration,
 		c.grpcCallsTotal,
 		c.grpcErrorsTotal,
+		c.grpcRetries,
+		c.connectionState,
+		c.proxyHealthy,
+		c.smokeTestDuration,
+		c.smokeTestsTotal,
+		c.features,
+		c.backendEndpoints,
+		c.traffic,
 	)
 }
 
@@ -230,14 +600,69 @@ func (c *NoopCollector) RecordFailedBackendRefs(_ context.Context, _ string, _ i
 // RecordSyncError is a no-op.
 func (c *NoopCollector) RecordSyncError(_ context.Context, _ string) {}
 
+// RecordSyncSkipped is a no-op.
+func (c *NoopCollector) RecordSyncSkipped(_ context.Context) {}
+
+// RecordSyncCoalesced is a no-op.
+func (c *NoopCollector) RecordSyncCoalesced(_ context.Context) {}
+
+// RecordSyncQueueDepth is a no-op.
+func (c *NoopCollector) RecordSyncQueueDepth(_ context.Context, _ int) {}
+
+// RecordSyncBatchSize is a no-op.
+func (c *NoopCollector) RecordSyncBatchSize(_ context.Context, _ int) {}
+
+// RecordSyncLockWait is a no-op.
+func (c *NoopCollector) RecordSyncLockWait(_ context.Context, _ time.Duration) {}
+
+// RecordLastSuccessfulSync is a no-op.
+func (c *NoopCollector) RecordLastSuccessfulSync(_ context.Context, _ time.Time) {}
+
+// RecordRouteGenerations is a no-op.
+func (c *NoopCollector) RecordRouteGenerations(_ context.Context, _ string, _ []RouteGeneration) {}
+
 // RecordIngressBuildDuration is a no-op.
 func (c *NoopCollector) RecordIngressBuildDuration(_ context.Context, _ string, _ time.Duration) {}
 
 // RecordBackendRefValidation is a no-op.
 func (c *NoopCollector) RecordBackendRefValidation(_ context.Context, _, _, _ string) {}
 
+// RecordRouteRules is a no-op.
+func (c *NoopCollector) RecordRouteRules(_ context.Context, _ string, _ []RouteRule) {}
+
+// RecordDroppedRules is a no-op.
+func (c *NoopCollector) RecordDroppedRules(_ context.Context, _, _ string, _ int) {}
+
+// RecordDroppedRule is a no-op.
+func (c *NoopCollector) RecordDroppedRule(_ context.Context, _, _ string) {}
+
+// RecordFilterUnsupported is a no-op.
+func (c *NoopCollector) RecordFilterUnsupported(_ context.Context, _ string) {}
+
 // RecordGRPCCall is a no-op.
 func (c *NoopCollector) RecordGRPCCall(_ context.Context, _, _ string, _ time.Duration) {}
 
 // RecordGRPCError is a no-op.
 func (c *NoopCollector) RecordGRPCError(_ context.Context, _, _ string) {}
+
+// RecordGRPCRetry is a no-op.
+func (c *NoopCollector) RecordGRPCRetry(_ context.Context, _, _ string) {}
+
+// RecordProxyConnectionState is a no-op.
+func (c *NoopCollector) RecordProxyConnectionState(_ context.Context, _ string) {}
+
+// RecordProxyHealthy is a no-op.
+func (c *NoopCollector) RecordProxyHealthy(_ context.Context, _ bool) {}
+
+// RecordSmokeTest is a no-op.
+func (c *NoopCollector) RecordSmokeTest(_ context.Context, _ string, _ time.Duration) {}
+
+// RecordFeature is a no-op.
+func (c *NoopCollector) RecordFeature(_ context.Context, _, _ string, _ bool) {}
+
+// RecordBackendHealth is a no-op.
+func (c *NoopCollector) RecordBackendHealth(_ context.Context, _ []BackendHealth) {}
+
+// RecordProxyTraffic is a no-op.
+func (c *NoopCollector) RecordProxyTraffic(_ context.Context, _ []RouteTraffic, _ []ListenerTraffic) {
+}
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -44,10 +45,28 @@ func TestNoopCollector(t *testing.T) {
 		collector.RecordIngressRules(ctx, 10)
 		collector.RecordFailedBackendRefs(ctx, "http", 2)
 		collector.RecordSyncError(ctx, "timeout")
+		collector.RecordSyncSkipped(ctx)
+		collector.RecordSyncCoalesced(ctx)
+		collector.RecordSyncQueueDepth(ctx, 2)
+		collector.RecordSyncBatchSize(ctx, 3)
+		collector.RecordSyncLockWait(ctx, time.Millisecond)
+		collector.RecordLastSuccessfulSync(ctx, time.Now())
+		collector.RecordRouteGenerations(ctx, "http", []RouteGeneration{{Route: "default/app", Generation: 1}})
 		collector.RecordIngressBuildDuration(ctx, "http", time.Millisecond*100)
 		collector.RecordBackendRefValidation(ctx, "http", "accepted", "")
+		collector.RecordRouteRules(ctx, "http", []RouteRule{{Route: "default/app", Index: 0, Name: "api"}})
+		collector.RecordDroppedRules(ctx, "http", "invalid_regex", 1)
+		collector.RecordDroppedRule(ctx, "http", "invalid_regex")
+		collector.RecordFilterUnsupported(ctx, "URLRewrite")
 		collector.RecordGRPCCall(ctx, "UpdateRoutes", "success", time.Second)
 		collector.RecordGRPCError(ctx, "UpdateRoutes", "timeout")
+		collector.RecordGRPCRetry(ctx, "UpdateRoutes", "Unavailable")
+		collector.RecordProxyConnectionState(ctx, "connected")
+		collector.RecordProxyHealthy(ctx, true)
+		collector.RecordSmokeTest(ctx, "success", time.Millisecond*20)
+		collector.RecordFeature(ctx, "filter", "CORS", true)
+		collector.RecordBackendHealth(ctx, []BackendHealth{{Backend: "app.default.svc.cluster.local:80", Healthy: 2}})
+		collector.RecordProxyTraffic(ctx, []RouteTraffic{{Kind: "HTTPRoute", Namespace: "default", Name: "app"}}, nil)
 	})
 }
 
@@ -64,10 +83,30 @@ func TestMetricsRegistration(t *testing.T) {
 	collector.RecordIngressRules(ctx, 1)
 	collector.RecordFailedBackendRefs(ctx, "http", 0)
 	collector.RecordSyncError(ctx, "test")
+	collector.RecordSyncSkipped(ctx)
+	collector.RecordSyncCoalesced(ctx)
+	collector.RecordSyncQueueDepth(ctx, 0)
+	collector.RecordSyncBatchSize(ctx, 1)
+	collector.RecordSyncLockWait(ctx, time.Millisecond)
+	collector.RecordLastSuccessfulSync(ctx, time.Now())
+	collector.RecordRouteGenerations(ctx, "http", []RouteGeneration{{Route: "default/app", Generation: 1}})
 	collector.RecordIngressBuildDuration(ctx, "http", time.Millisecond)
 	collector.RecordBackendRefValidation(ctx, "http", "accepted", "")
+	collector.RecordRouteRules(ctx, "http", []RouteRule{{Route: "default/app", Index: 0}})
+	collector.RecordDroppedRules(ctx, "http", "invalid_regex", 0)
+	collector.RecordDroppedRule(ctx, "http", "invalid_regex")
+	collector.RecordFilterUnsupported(ctx, "URLRewrite")
 	collector.RecordGRPCCall(ctx, "UpdateRoutes", "success", time.Second)
 	collector.RecordGRPCError(ctx, "UpdateRoutes", "test")
+	collector.RecordGRPCRetry(ctx, "UpdateRoutes", "Unavailable")
+	collector.RecordProxyConnectionState(ctx, "connected")
+	collector.RecordProxyHealthy(ctx, true)
+	collector.RecordSmokeTest(ctx, "success", time.Millisecond)
+	collector.RecordFeature(ctx, "filter", "CORS", true)
+	collector.RecordBackendHealth(ctx, []BackendHealth{{Backend: "app.default.svc.cluster.local:80", Healthy: 1}})
+	collector.RecordProxyTraffic(ctx,
+		[]RouteTraffic{{Kind: "HTTPRoute", Namespace: "default", Name: "app", Responses: map[string]uint64{"2xx": 1}}},
+		[]ListenerTraffic{{Port: 80, Responses: map[string]uint64{"2xx": 1}}})
 
 	// Verify metrics are registered
 	metricFamilies, err := reg.Gather()
@@ -80,13 +119,39 @@ func TestMetricsRegistration(t *testing.T) {
 		"pingora_ingress_rules",
 		"pingora_failed_backend_refs",
 		"pingora_sync_errors_total",
+		"pingora_sync_skipped_total",
+		"pingora_sync_coalesced_total",
+		"pingora_sync_queue_depth",
+		"pingora_sync_batch_requests",
+		"pingora_sync_lock_wait_seconds",
+		"pingora_last_successful_sync_timestamp_seconds",
+		"pingora_route_generation_observed",
 		// Ingress builder metrics
 		"pingora_ingress_build_duration_seconds",
 		"pingora_backend_ref_validation_total",
+		"pingora_route_rule_info",
+		"pingora_dropped_route_rules",
+		"pingora_dropped_rules_total",
+		"pingora_unsupported_filters_total",
 		// gRPC metrics
 		"pingora_grpc_duration_seconds",
 		"pingora_grpc_calls_total",
 		"pingora_grpc_errors_total",
+		"pingora_grpc_retries_total",
+		"pingora_proxy_connection_state",
+		"pingora_proxy_healthy",
+		// Smoke test metrics
+		"pingora_smoke_test_duration_seconds",
+		"pingora_smoke_tests_total",
+		// Feature metrics
+		"pingora_controller_feature",
+		// Backend health metrics
+		"pingora_backend_endpoints",
+		// Traffic metrics
+		"pingora_route_requests_total",
+		"pingora_route_request_duration_seconds",
+		"pingora_listener_requests_total",
+		"pingora_listener_request_duration_seconds",
 	}
 
 	registeredMetrics := make(map[string]bool)
@@ -174,6 +239,86 @@ func TestRecordSyncError(t *testing.T) {
 	assert.Equal(t, float64(1), networkCount)
 }
 
+func TestRecordSyncSkipped(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordSyncSkipped(ctx)
+	collector.RecordSyncSkipped(ctx)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.syncSkippedTotal))
+}
+
+func TestRecordSyncCoalesced(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordSyncCoalesced(ctx)
+	collector.RecordSyncCoalesced(ctx)
+	collector.RecordSyncCoalesced(ctx)
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(collector.syncCoalesced))
+}
+
+func TestRecordSyncQueueStatistics(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordSyncQueueDepth(ctx, 4)
+	collector.RecordSyncBatchSize(ctx, 4)
+	collector.RecordSyncBatchSize(ctx, 1)
+	collector.RecordSyncLockWait(ctx, 50*time.Millisecond)
+
+	assert.Equal(t, float64(4), testutil.ToFloat64(collector.syncQueueDepth))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector.syncBatchSize))
+	assert.Equal(t, 1, testutil.CollectAndCount(collector.syncLockWait))
+}
+
+func TestRecordLastSuccessfulSync(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordLastSuccessfulSync(ctx, time.Unix(1700000000, 500_000_000))
+
+	assert.InDelta(t, 1700000000.5, testutil.ToFloat64(collector.lastSuccessfulSync), 1e-3)
+}
+
+func TestRecordRouteGenerations(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordRouteGenerations(ctx, "http", []RouteGeneration{
+		{Route: "default/app", Generation: 3},
+		{Route: "default/web", Generation: 1},
+	})
+	collector.RecordRouteGenerations(ctx, "grpc", []RouteGeneration{{Route: "default/echo", Generation: 2}})
+
+	assert.Equal(t, 3, testutil.CollectAndCount(collector.routeGenerations))
+	assert.Equal(t, float64(3), testutil.ToFloat64(collector.routeGenerations.WithLabelValues("http", "default/app")))
+
+	// Routes no longer programmed are removed
+	collector.RecordRouteGenerations(ctx, "http", []RouteGeneration{{Route: "default/app", Generation: 4}})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(collector.routeGenerations))
+	assert.Equal(t, float64(4), testutil.ToFloat64(collector.routeGenerations.WithLabelValues("http", "default/app")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.routeGenerations.WithLabelValues("grpc", "default/echo")))
+}
+
 func TestRecordIngressBuildDuration(t *testing.T) {
 	t.Parallel()
 
@@ -205,6 +350,29 @@ func TestRecordBackendRefValidation(t *testing.T) {
 	assert.Equal(t, float64(1), rejectedCount)
 }
 
+func TestRecordRouteRules(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordRouteRules(ctx, "http", []RouteRule{
+		{Route: "default/app", Index: 0, Name: "api"},
+		{Route: "default/app", Index: 1},
+	})
+	collector.RecordRouteRules(ctx, "grpc", []RouteRule{{Route: "default/grpc", Index: 0, Name: "echo"}})
+
+	assert.Equal(t, 3, testutil.CollectAndCount(collector.routeRules))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.routeRules.WithLabelValues("http", "default/app", "0", "api")))
+
+	// A later sync replaces the rules of the same type only
+	collector.RecordRouteRules(ctx, "http", []RouteRule{{Route: "default/other", Index: 0, Name: "web"}})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(collector.routeRules))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.routeRules.WithLabelValues("grpc", "default/grpc", "0", "echo")))
+}
+
 func TestRecordGRPCCall(t *testing.T) {
 	t.Parallel()
 
@@ -235,6 +403,206 @@ func TestRecordGRPCError(t *testing.T) {
 	assert.Equal(t, float64(1), count)
 }
 
+func TestRecordGRPCRetry(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordGRPCRetry(ctx, "UpdateRoutes", "Unavailable")
+	collector.RecordGRPCRetry(ctx, "UpdateRoutes", "Unavailable")
+
+	count := testutil.ToFloat64(collector.grpcRetries.WithLabelValues("UpdateRoutes", "Unavailable"))
+	assert.Equal(t, float64(2), count)
+}
+
+func TestRecordProxyConnectionState(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordProxyConnectionState(ctx, "connected")
+	collector.RecordProxyConnectionState(ctx, "circuit_open")
+
+	assert.Equal(t, 1, testutil.CollectAndCount(collector.connectionState))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.connectionState.WithLabelValues("circuit_open")))
+}
+
+func TestRecordProxyHealthy(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordProxyHealthy(ctx, true)
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.proxyHealthy))
+
+	collector.RecordProxyHealthy(ctx, false)
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.proxyHealthy))
+}
+
+func TestRecordSmokeTest(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordSmokeTest(ctx, "failed", 50*time.Millisecond)
+
+	durationCount := testutil.CollectAndCount(collector.smokeTestDuration)
+	testsCount := testutil.ToFloat64(collector.smokeTestsTotal.WithLabelValues("failed"))
+
+	assert.Equal(t, 1, durationCount)
+	assert.Equal(t, float64(1), testsCount)
+}
+
+func TestRecordFeature(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordFeature(ctx, "filter", "CORS", true)
+	collector.RecordFeature(ctx, "filter", "URLRewrite", false)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.features.WithLabelValues("filter", "CORS")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.features.WithLabelValues("filter", "URLRewrite")))
+}
+
+func TestRecordDroppedRules(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordDroppedRules(ctx, "http", "invalid_regex", 2)
+	collector.RecordDroppedRules(ctx, "grpc", "invalid_regex", 1)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.droppedRules.WithLabelValues("http", "invalid_regex")))
+
+	// A later sync overwrites the count
+	collector.RecordDroppedRules(ctx, "http", "invalid_regex", 0)
+
+	assert.Equal(t, float64(0), testutil.ToFloat64(collector.droppedRules.WithLabelValues("http", "invalid_regex")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.droppedRules.WithLabelValues("grpc", "invalid_regex")))
+}
+
+func TestRecordDroppedRule(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordDroppedRule(ctx, "http", "invalid_regex")
+	collector.RecordDroppedRule(ctx, "http", "invalid_regex")
+	collector.RecordDroppedRule(ctx, "grpc", "unsupported_backend")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.droppedRulesTotal.WithLabelValues("http", "invalid_regex")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.droppedRulesTotal.WithLabelValues("grpc", "unsupported_backend")))
+}
+
+func TestRecordFilterUnsupported(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordFilterUnsupported(ctx, "URLRewrite")
+	collector.RecordFilterUnsupported(ctx, "URLRewrite")
+	collector.RecordFilterUnsupported(ctx, "RequestMirror")
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(collector.unsupportedFilters.WithLabelValues("URLRewrite")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(collector.unsupportedFilters.WithLabelValues("RequestMirror")))
+}
+
+func TestRecordBackendHealth(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	collector.RecordBackendHealth(ctx, []BackendHealth{
+		{Backend: "api.default.svc.cluster.local:80", Healthy: 2, Unhealthy: 1},
+		{Backend: "web.default.svc.cluster.local:80", Healthy: 3},
+	})
+
+	assert.Equal(t, 4, testutil.CollectAndCount(collector.backendEndpoints))
+	assert.Equal(t, float64(1),
+		testutil.ToFloat64(collector.backendEndpoints.WithLabelValues("api.default.svc.cluster.local:80", "unhealthy")))
+
+	// A later report replaces the previous one
+	collector.RecordBackendHealth(ctx, []BackendHealth{{Backend: "web.default.svc.cluster.local:80", Healthy: 2}})
+
+	assert.Equal(t, 2, testutil.CollectAndCount(collector.backendEndpoints))
+	assert.Equal(t, float64(2),
+		testutil.ToFloat64(collector.backendEndpoints.WithLabelValues("web.default.svc.cluster.local:80", "healthy")))
+}
+
+func TestRecordProxyTraffic(t *testing.T) {
+	t.Parallel()
+
+	reg := prometheus.NewRegistry()
+	collector := NewCollector(reg).(*prometheusCollector)
+	ctx := context.Background()
+
+	durations := RequestDurations{Count: 12, Sum: 1.5, Buckets: map[float64]uint64{0.1: 10, 1: 12}}
+
+	collector.RecordProxyTraffic(ctx,
+		[]RouteTraffic{{
+			Kind:      "HTTPRoute",
+			Namespace: "default",
+			Name:      "app",
+			Responses: map[string]uint64{"2xx": 10, "5xx": 2},
+			Durations: durations,
+		}},
+		[]ListenerTraffic{{
+			Port:      443,
+			Responses: map[string]uint64{"2xx": 10, "4xx": 1, "5xx": 2},
+			Durations: durations,
+		}})
+
+	expected := `
+# HELP pingora_route_requests_total Requests served by a route by response status class, as counted by the proxy
+# TYPE pingora_route_requests_total counter
+pingora_route_requests_total{kind="HTTPRoute",name="app",namespace="default",status_class="2xx"} 10
+pingora_route_requests_total{kind="HTTPRoute",name="app",namespace="default",status_class="5xx"} 2
+# HELP pingora_listener_requests_total Requests accepted on a listener port by response status class, as counted by the proxy
+# TYPE pingora_listener_requests_total counter
+pingora_listener_requests_total{port="443",status_class="2xx"} 10
+pingora_listener_requests_total{port="443",status_class="4xx"} 1
+pingora_listener_requests_total{port="443",status_class="5xx"} 2
+`
+	require.NoError(t, testutil.CollectAndCompare(collector.traffic, strings.NewReader(expected),
+		"pingora_route_requests_total", "pingora_listener_requests_total"))
+
+	expectedDurations := `
+# HELP pingora_route_request_duration_seconds Duration of the requests served by a route, as measured by the proxy
+# TYPE pingora_route_request_duration_seconds histogram
+pingora_route_request_duration_seconds_bucket{kind="HTTPRoute",name="app",namespace="default",le="0.1"} 10
+pingora_route_request_duration_seconds_bucket{kind="HTTPRoute",name="app",namespace="default",le="1"} 12
+pingora_route_request_duration_seconds_bucket{kind="HTTPRoute",name="app",namespace="default",le="+Inf"} 12
+pingora_route_request_duration_seconds_sum{kind="HTTPRoute",name="app",namespace="default"} 1.5
+pingora_route_request_duration_seconds_count{kind="HTTPRoute",name="app",namespace="default"} 12
+`
+	require.NoError(t, testutil.CollectAndCompare(collector.traffic, strings.NewReader(expectedDurations),
+		"pingora_route_request_duration_seconds"))
+
+	// A later report replaces the previous one
+	collector.RecordProxyTraffic(ctx, nil, nil)
+
+	assert.Equal(t, 0, testutil.CollectAndCount(collector.traffic))
+}
+
 func TestHistogramBuckets(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RouteTraffic is the traffic of a route as counted by the proxy.
+type RouteTraffic struct {
+	// Kind is the kind of the route, HTTPRoute or GRPCRoute.
+	Kind string
+
+	// Namespace and Name identify the route.
+	Namespace string
+	Name      string
+
+	// Responses counts the responses by status class, such as "2xx".
+	Responses map[string]uint64
+
+	// Durations is the histogram of the request durations.
+	Durations RequestDurations
+}
+
+// ListenerTraffic is the traffic of a listener as counted by the proxy.
+type ListenerTraffic struct {
+	// Port is the port of the listener.
+	Port uint32
+
+	// Responses counts the responses by status class, such as "2xx".
+	Responses map[string]uint64
+
+	// Durations is the histogram of the request durations.
+	Durations RequestDurations
+}
+
+// RequestDurations is a histogram of request durations in seconds.
+type RequestDurations struct {
+	Count uint64
+	Sum   float64
+
+	// Buckets maps the upper bound of every bucket to the cumulative
+	// number of requests in it.
+	Buckets map[float64]uint64
+}
+
+// trafficCollector exports the last traffic counts reported by the proxy.
+// The proxy counts since it started, so the values are exported as they
+// are instead of being added to counters of the controller.
+type trafficCollector struct {
+	routeRequests     *prometheus.Desc
+	routeDurations    *prometheus.Desc
+	listenerRequests  *prometheus.Desc
+	listenerDurations *prometheus.Desc
+
+	mu        sync.Mutex
+	routes    []RouteTraffic
+	listeners []ListenerTraffic
+}
+
+var _ prometheus.Collector = (*trafficCollector)(nil)
+
+func newTrafficCollector() *trafficCollector {
+	return &trafficCollector{
+		routeRequests: prometheus.NewDesc(
+			"pingora_route_requests_total",
+			"Requests served by a route by response status class, as counted by the proxy",
+			[]string{"kind", "namespace", "name", "status_class"}, nil,
+		),
+		routeDurations: prometheus.NewDesc(
+			"pingora_route_request_duration_seconds",
+			"Duration of the requests served by a route, as measured by the proxy",
+			[]string{"kind", "namespace", "name"}, nil,
+		),
+		listenerRequests: prometheus.NewDesc(
+			"pingora_listener_requests_total",
+			"Requests accepted on a listener port by response status class, as counted by the proxy",
+			[]string{"port", "status_class"}, nil,
+		),
+		listenerDurations: prometheus.NewDesc(
+			"pingora_listener_request_duration_seconds",
+			"Duration of the requests accepted on a listener port, as measured by the proxy",
+			[]string{"port"}, nil,
+		),
+	}
+}
+
+func (c *trafficCollector) set(routes []RouteTraffic, listeners []ListenerTraffic) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.routes = routes
+	c.listeners = listeners
+}
+
+// Describe implements prometheus.Collector.
+func (c *trafficCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.routeRequests
+	ch <- c.routeDurations
+	ch <- c.listenerRequests
+	ch <- c.listenerDurations
+}
+
+// Collect implements prometheus.Collector.
+func (c *trafficCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i := range c.routes {
+		route := &c.routes[i]
+
+		for class, count := range route.Responses {
+			ch <- prometheus.MustNewConstMetric(c.routeRequests, prometheus.CounterValue, float64(count),
+				route.Kind, route.Namespace, route.Name, class)
+		}
+
+		ch <- prometheus.MustNewConstHistogram(c.routeDurations,
+			route.Durations.Count, route.Durations.Sum, route.Durations.Buckets,
+			route.Kind, route.Namespace, route.Name)
+	}
+
+	for i := range c.listeners {
+		listener := &c.listeners[i]
+		port := strconv.FormatUint(uint64(listener.Port), 10)
+
+		for class, count := range listener.Responses {
+			ch <- prometheus.MustNewConstMetric(c.listenerRequests, prometheus.CounterValue, float64(count),
+				port, class)
+		}
+
+		ch <- prometheus.MustNewConstHistogram(c.listenerDurations,
+			listener.Durations.Count, listener.Durations.Sum, listener.Durations.Buckets,
+			port)
+	}
+}
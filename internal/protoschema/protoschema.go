@@ -0,0 +1,206 @@
+// Package protoschema generates JSON Schema and OpenAPI documents from
+// compiled protobuf descriptors.
+//
+// The schemas describe the protobuf JSON mapping (protojson): fields use their
+// lowerCamelCase JSON names, enums are written as value names, and 64-bit
+// integers may be either numbers or strings. Unknown fields are not allowed,
+// matching the default protojson decoder.
+package protoschema
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Supported schema formats.
+const (
+	// FormatJSONSchema produces a JSON Schema (draft 2020-12) document with all
+	// types in $defs.
+	FormatJSONSchema = "jsonschema"
+	// FormatOpenAPI produces an OpenAPI 3.1 document with all types in
+	// components.schemas.
+	FormatOpenAPI = "openapi"
+)
+
+const (
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	openAPIVersion    = "3.1.0"
+
+	jsonSchemaRefPrefix = "#/$defs/"
+	openAPIRefPrefix    = "#/components/schemas/"
+)
+
+// Schema is a JSON Schema object.
+type Schema map[string]any
+
+// JSONSchema returns a JSON Schema document defining every message and enum
+// of the file. Definitions are keyed by their full protobuf name.
+func JSONSchema(file protoreflect.FileDescriptor) Schema {
+	return Schema{
+		"$schema": jsonSchemaDialect,
+		"$id":     string(file.Package()) + ".schema.json",
+		"title":   string(file.Package()),
+		"$defs":   definitions(file, jsonSchemaRefPrefix),
+	}
+}
+
+// OpenAPI returns an OpenAPI document whose components.schemas define every
+// message and enum of the file. The document has no paths, since the API is
+// served over gRPC.
+func OpenAPI(file protoreflect.FileDescriptor, version string) Schema {
+	return Schema{
+		"openapi": openAPIVersion,
+		"info": Schema{
+			"title":   string(file.Package()),
+			"version": version,
+		},
+		"paths": Schema{},
+		"components": Schema{
+			"schemas": definitions(file, openAPIRefPrefix),
+		},
+	}
+}
+
+// definitions collects schemas for all top-level and nested messages and enums.
+func definitions(file protoreflect.FileDescriptor, refPrefix string) Schema {
+	defs := Schema{}
+
+	addEnums(defs, file.Enums())
+	addMessages(defs, file.Messages(), refPrefix)
+
+	return defs
+}
+
+func addMessages(defs Schema, messages protoreflect.MessageDescriptors, refPrefix string) {
+	for i := range messages.Len() {
+		message := messages.Get(i)
+		if message.IsMapEntry() {
+			continue
+		}
+
+		defs[string(message.FullName())] = messageSchema(message, refPrefix)
+
+		addEnums(defs, message.Enums())
+		addMessages(defs, message.Messages(), refPrefix)
+	}
+}
+
+func addEnums(defs Schema, enums protoreflect.EnumDescriptors) {
+	for i := range enums.Len() {
+		enum := enums.Get(i)
+		defs[string(enum.FullName())] = enumSchema(enum)
+	}
+}
+
+func enumSchema(enum protoreflect.EnumDescriptor) Schema {
+	values := enum.Values()
+
+	names := make([]any, 0, values.Len())
+	for i := range values.Len() {
+		names = append(names, string(values.Get(i).Name()))
+	}
+
+	return Schema{
+		"type": "string",
+		"enum": names,
+	}
+}
+
+func messageSchema(message protoreflect.MessageDescriptor, refPrefix string) Schema {
+	fields := message.Fields()
+	properties := make(Schema, fields.Len())
+
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		properties[field.JSONName()] = fieldSchema(field, refPrefix)
+	}
+
+	schema := Schema{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+	if constraints := oneofConstraints(message); len(constraints) > 0 {
+		schema["allOf"] = constraints
+	}
+
+	return schema
+}
+
+// oneofConstraints allows at most one field of each oneof to be set.
+// Synthetic oneofs of proto3 optional fields are skipped.
+func oneofConstraints(message protoreflect.MessageDescriptor) []any {
+	oneofs := message.Oneofs()
+
+	var constraints []any
+
+	for i := range oneofs.Len() {
+		oneof := oneofs.Get(i)
+		if oneof.IsSynthetic() {
+			continue
+		}
+
+		fields := oneof.Fields()
+		branches := make([]any, 0, fields.Len()+1)
+		anySet := make([]any, 0, fields.Len())
+
+		for j := range fields.Len() {
+			required := Schema{"required": []any{fields.Get(j).JSONName()}}
+			branches = append(branches, required)
+			anySet = append(anySet, required)
+		}
+
+		branches = append(branches, Schema{"not": Schema{"anyOf": anySet}})
+		constraints = append(constraints, Schema{"oneOf": branches})
+	}
+
+	return constraints
+}
+
+func fieldSchema(field protoreflect.FieldDescriptor, refPrefix string) Schema {
+	switch {
+	case field.IsMap():
+		return Schema{
+			"type":                 "object",
+			"additionalProperties": singularSchema(field.MapValue(), refPrefix),
+		}
+	case field.IsList():
+		return Schema{
+			"type":  "array",
+			"items": singularSchema(field, refPrefix),
+		}
+	default:
+		return singularSchema(field, refPrefix)
+	}
+}
+
+//nolint:exhaustive // GroupKind is not used in proto3
+func singularSchema(field protoreflect.FieldDescriptor, refPrefix string) Schema {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return Schema{"type": "boolean"}
+	case protoreflect.StringKind:
+		return Schema{"type": "string"}
+	case protoreflect.BytesKind:
+		return Schema{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return Schema{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return Schema{"type": "integer", "format": "uint32", "minimum": 0}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// protojson writes 64-bit integers as strings and accepts both forms
+		return Schema{"type": []any{"integer", "string"}, "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return Schema{"type": []any{"integer", "string"}, "format": "uint64"}
+	case protoreflect.FloatKind:
+		return Schema{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return Schema{"type": "number", "format": "double"}
+	case protoreflect.EnumKind:
+		return Schema{"$ref": refPrefix + string(field.Enum().FullName())}
+	case protoreflect.MessageKind:
+		return Schema{"$ref": refPrefix + string(field.Message().FullName())}
+	default:
+		return Schema{}
+	}
+}
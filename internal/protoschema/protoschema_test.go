@@ -0,0 +1,161 @@
+package protoschema_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/protoschema"
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// roundTrip encodes the schema as JSON and decodes it into generic maps.
+func roundTrip(t *testing.T, schema protoschema.Schema) map[string]any {
+	t.Helper()
+
+	data, err := json.Marshal(schema)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	return decoded
+}
+
+func TestJSONSchema_Definitions(t *testing.T) {
+	t.Parallel()
+
+	file := routingv1.File_routing_v1_routing_proto
+	defs := roundTrip(t, protoschema.JSONSchema(file))["$defs"].(map[string]any)
+
+	assert.Len(t, defs, file.Messages().Len()+file.Enums().Len())
+
+	for i := range file.Messages().Len() {
+		assert.Contains(t, defs, string(file.Messages().Get(i).FullName()))
+	}
+
+	rule := defs["routing.v1.HTTPRouteRule"].(map[string]any)
+	properties := rule["properties"].(map[string]any)
+
+	assert.Equal(t, false, rule["additionalProperties"])
+	assert.Equal(t, map[string]any{"type": []any{"integer", "string"}, "format": "uint64"}, properties["timeoutMs"])
+	assert.Equal(t, "#/$defs/routing.v1.FixedResponse", properties["fixedResponse"].(map[string]any)["$ref"])
+	assert.Equal(t, "#/$defs/routing.v1.HTTPRouteMatch",
+		properties["matches"].(map[string]any)["items"].(map[string]any)["$ref"])
+
+	enum := defs["routing.v1.PathMatchType"].(map[string]any)
+	assert.Equal(t, "string", enum["type"])
+	assert.Contains(t, enum["enum"], "PATH_MATCH_TYPE_REGEX")
+}
+
+func TestJSONSchema_Oneof(t *testing.T) {
+	t.Parallel()
+
+	defs := roundTrip(t, protoschema.JSONSchema(routingv1.File_routing_v1_routing_proto))["$defs"].(map[string]any)
+	request := defs["routing.v1.StreamRoutesRequest"].(map[string]any)
+
+	require.Contains(t, request, "allOf")
+
+	constraint := request["allOf"].([]any)[0].(map[string]any)
+	branches := constraint["oneOf"].([]any)
+
+	require.Len(t, branches, 3)
+	assert.Equal(t, map[string]any{"required": []any{"full"}}, branches[0])
+	assert.Equal(t, map[string]any{"required": []any{"delta"}}, branches[1])
+}
+
+func TestOpenAPI_References(t *testing.T) {
+	t.Parallel()
+
+	doc := roundTrip(t, protoschema.OpenAPI(routingv1.File_routing_v1_routing_proto, "v1.2.3"))
+
+	assert.Equal(t, "3.1.0", doc["openapi"])
+	assert.Equal(t, "v1.2.3", doc["info"].(map[string]any)["version"])
+
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	route := schemas["routing.v1.HTTPRoute"].(map[string]any)
+	rules := route["properties"].(map[string]any)["rules"].(map[string]any)
+
+	assert.Equal(t, "#/components/schemas/routing.v1.HTTPRouteRule", rules["items"].(map[string]any)["$ref"])
+}
+
+// TestJSONSchema_MatchesProtojson checks that every field written by protojson
+// is defined in the schema of its message.
+func TestJSONSchema_MatchesProtojson(t *testing.T) {
+	t.Parallel()
+
+	request := &routingv1.UpdateRoutesRequest{
+		Version: 42,
+		HttpRoutes: []*routingv1.HTTPRoute{{
+			Id:        "default/app",
+			Hostnames: []string{"app.example.com"},
+			Rules: []*routingv1.HTTPRouteRule{{
+				Matches: []*routingv1.HTTPRouteMatch{{
+					Path:        &routingv1.PathMatch{Type: routingv1.PathMatchType_PATH_MATCH_TYPE_PREFIX, Value: "/"},
+					Headers:     []*routingv1.HeaderMatch{{Name: "x-env", Value: "prod"}},
+					QueryParams: []*routingv1.QueryParamMatch{{Name: "debug", Value: "1"}},
+					Method:      "GET",
+				}},
+				Backends: []*routingv1.Backend{{
+					Address: "app.default.svc.cluster.local:80", Weight: 1,
+					Protocol: routingv1.BackendProtocol_BACKEND_PROTOCOL_HTTP,
+				}},
+				TimeoutMs:            1000,
+				FixedResponse:        &routingv1.FixedResponse{StatusCode: 500, Reason: "no backends"},
+				InvalidBackendWeight: 1,
+			}},
+		}},
+		GrpcRoutes: []*routingv1.GRPCRoute{{
+			Id: "default/api",
+			Rules: []*routingv1.GRPCRouteRule{{
+				Matches: []*routingv1.GRPCRouteMatch{{
+					Method: &routingv1.GRPCMethodMatch{Service: "helloworld.Greeter", Method: "SayHello"},
+				}},
+			}},
+		}},
+	}
+
+	data, err := protojson.Marshal(request)
+	require.NoError(t, err)
+
+	var document any
+	require.NoError(t, json.Unmarshal(data, &document))
+
+	defs := roundTrip(t, protoschema.JSONSchema(routingv1.File_routing_v1_routing_proto))["$defs"].(map[string]any)
+	name := string(proto.MessageName(request))
+
+	assertDefined(t, defs, map[string]any{"$ref": "#/$defs/" + name}, document, "$")
+}
+
+func assertDefined(t *testing.T, defs map[string]any, schema map[string]any, value any, path string) {
+	t.Helper()
+
+	if ref, ok := schema["$ref"].(string); ok {
+		schema = defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+	}
+
+	switch typed := value.(type) {
+	case map[string]any:
+		properties, ok := schema["properties"].(map[string]any)
+		require.True(t, ok, "%s: schema is not an object", path)
+
+		for key, child := range typed {
+			childSchema, ok := properties[key].(map[string]any)
+			require.True(t, ok, "%s.%s: field not defined in schema", path, key)
+
+			assertDefined(t, defs, childSchema, child, path+"."+key)
+		}
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		require.True(t, ok, "%s: schema is not an array", path)
+
+		for _, item := range typed {
+			assertDefined(t, defs, items, item, path+"[]")
+		}
+	}
+}
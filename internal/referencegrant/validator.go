@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
@@ -47,27 +48,42 @@ func (v *Validator) IsReferenceAllowed(ctx context.Context, fromRef, toRef Refer
 	var grants gatewayv1beta1.ReferenceGrantList
 
 	err := v.client.List(ctx, &grants, client.InNamespace(toRef.Namespace))
+	if meta.IsNoMatchError(err) {
+		// Without the ReferenceGrant CRD nothing grants the reference
+		return false, nil
+	}
+
 	if err != nil {
 		return false, errors.Wrap(err, "failed to list ReferenceGrants")
 	}
 
-	// Check if any grant allows this reference
-	for i := range grants.Items {
-		if v.grantAllowsReference(&grants.Items[i], fromRef, toRef) {
-			return true, nil
+	return Permits(grants.Items, fromRef, toRef), nil
+}
+
+// Permits reports whether one of the given ReferenceGrants allows a
+// reference. Grants outside the namespace of the target are ignored, and
+// references within the same namespace are always allowed.
+func Permits(grants []gatewayv1beta1.ReferenceGrant, fromRef, toRef Reference) bool {
+	if fromRef.Namespace == toRef.Namespace {
+		return true
+	}
+
+	for i := range grants {
+		if grants[i].Namespace == toRef.Namespace && grantAllowsReference(&grants[i], fromRef, toRef) {
+			return true
 		}
 	}
 
-	return false, nil
+	return false
 }
 
 // grantAllowsReference checks if a specific ReferenceGrant allows the reference.
-func (v *Validator) grantAllowsReference(grant *gatewayv1beta1.ReferenceGrant, fromRef, toRef Reference) bool {
+func grantAllowsReference(grant *gatewayv1beta1.ReferenceGrant, fromRef, toRef Reference) bool {
 	// Check if the grant allows references from the source
 	fromAllowed := false
 
 	for _, grantFrom := range grant.Spec.From {
-		if v.matchesFrom(grantFrom, fromRef) {
+		if matchesFrom(grantFrom, fromRef) {
 			fromAllowed = true
 
 			break
@@ -80,7 +96,7 @@ func (v *Validator) grantAllowsReference(grant *gatewayv1beta1.ReferenceGrant, f
 
 	// Check if the grant allows references to the target
 	for _, grantTo := range grant.Spec.To {
-		if v.matchesTo(grantTo, toRef) {
+		if matchesTo(grantTo, toRef) {
 			return true
 		}
 	}
@@ -89,7 +105,7 @@ func (v *Validator) grantAllowsReference(grant *gatewayv1beta1.ReferenceGrant, f
 }
 
 // matchesFrom checks if the ReferenceGrantFrom matches the source reference.
-func (v *Validator) matchesFrom(grantFrom gatewayv1beta1.ReferenceGrantFrom, fromRef Reference) bool {
+func matchesFrom(grantFrom gatewayv1beta1.ReferenceGrantFrom, fromRef Reference) bool {
 	// Check group
 	if string(grantFrom.Group) != fromRef.Group {
 		return false
@@ -109,7 +125,7 @@ func (v *Validator) matchesFrom(grantFrom gatewayv1beta1.ReferenceGrantFrom, fro
 }
 
 // matchesTo checks if the ReferenceGrantTo matches the target reference.
-func (v *Validator) matchesTo(grantTo gatewayv1beta1.ReferenceGrantTo, toRef Reference) bool {
+func matchesTo(grantTo gatewayv1beta1.ReferenceGrantTo, toRef Reference) bool {
 	// Check group - normalize "core" to empty string for core API group
 	grantGroup := string(grantTo.Group)
 	if grantGroup == "core" {
@@ -7,9 +7,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -78,6 +81,42 @@ func TestValidator_IsReferenceAllowed_CrossNamespaceWithoutGrant(t *testing.T) {
 	assert.False(t, allowed, "cross-namespace reference without ReferenceGrant should be denied")
 }
 
+func TestValidator_IsReferenceAllowed_WithoutCRD(t *testing.T) {
+	t.Parallel()
+
+	scheme := setupScheme(t)
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(_ context.Context, _ client.WithWatch, _ client.ObjectList, _ ...client.ListOption) error {
+				return &meta.NoKindMatchError{GroupKind: gatewayv1beta1.SchemeGroupVersion.WithKind("ReferenceGrant").GroupKind()}
+			},
+		}).
+		Build()
+
+	validator := referencegrant.NewValidator(fakeClient)
+
+	from := referencegrant.Reference{
+		Group:     gatewayv1.GroupName,
+		Kind:      "HTTPRoute",
+		Namespace: "default",
+		Name:      "test-route",
+	}
+
+	to := referencegrant.Reference{
+		Group:     coreGroup,
+		Kind:      "Service",
+		Namespace: "production",
+		Name:      "api-service",
+	}
+
+	ctx := context.Background()
+	allowed, err := validator.IsReferenceAllowed(ctx, from, to)
+
+	require.NoError(t, err)
+	assert.False(t, allowed, "cross-namespace reference should be denied without the ReferenceGrant CRD")
+}
+
 func TestValidator_IsReferenceAllowed_CrossNamespaceWithGrant(t *testing.T) {
 	t.Parallel()
 
@@ -504,6 +543,55 @@ func TestValidator_IsReferenceAllowed_CoreGroupAlias(t *testing.T) {
 	assert.True(t, allowed, "ReferenceGrant with 'core' group should match references with empty group")
 }
 
+func TestPermits(t *testing.T) {
+	t.Parallel()
+
+	grants := []gatewayv1beta1.ReferenceGrant{{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-routes", Namespace: "backend"},
+		Spec: gatewayv1beta1.ReferenceGrantSpec{
+			From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "default"}},
+			To:   []gatewayv1beta1.ReferenceGrantTo{{Kind: "Service"}},
+		},
+	}}
+
+	from := referencegrant.Reference{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "default"}
+
+	tests := []struct {
+		name     string
+		to       referencegrant.Reference
+		expected bool
+	}{
+		{
+			name:     "same namespace",
+			to:       referencegrant.Reference{Kind: "Service", Namespace: "default", Name: "app"},
+			expected: true,
+		},
+		{
+			name:     "granted namespace",
+			to:       referencegrant.Reference{Kind: "Service", Namespace: "backend", Name: "app"},
+			expected: true,
+		},
+		{
+			name:     "grant in another namespace does not apply",
+			to:       referencegrant.Reference{Kind: "Service", Namespace: "private", Name: "app"},
+			expected: false,
+		},
+		{
+			name:     "kind not granted",
+			to:       referencegrant.Reference{Kind: "Secret", Namespace: "backend", Name: "app"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, referencegrant.Permits(grants, from, tt.to))
+		})
+	}
+}
+
 // setupScheme creates a scheme with all required types.
 func setupScheme(t *testing.T) *runtime.Scheme {
 	t.Helper()
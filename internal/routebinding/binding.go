@@ -2,6 +2,7 @@ package routebinding
 
 import (
 	"context"
+	"fmt"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -23,6 +24,32 @@ type BindingResult struct {
 	Reason           gatewayv1.RouteConditionReason
 	Message          string
 	MatchedListeners []gatewayv1.SectionName
+
+	// Listeners details the matched listeners, in the same order.
+	Listeners []ListenerMatch
+
+	// RejectedListeners are the listeners selected by the parentRef that do
+	// not accept the route, in Gateway order.
+	RejectedListeners []ListenerRejection
+}
+
+// ListenerRejection is a listener that does not accept a route.
+type ListenerRejection struct {
+	Name   gatewayv1.SectionName
+	Reason gatewayv1.RouteConditionReason
+}
+
+// ListenerMatch is a listener that accepts a route.
+type ListenerMatch struct {
+	Name gatewayv1.SectionName
+	Port gatewayv1.PortNumber
+
+	// Hostnames are the route hostnames narrowed to the listener hostname,
+	// see IntersectHostnames. Empty matches all hostnames.
+	Hostnames []gatewayv1.Hostname
+
+	// ListenerHostname is the hostname of the listener, nil if it has none.
+	ListenerHostname *gatewayv1.Hostname
 }
 
 // ValidateBinding validates whether a route can bind to a gateway's listeners.
@@ -32,40 +59,63 @@ func (v *Validator) ValidateBinding(
 	gateway *gatewayv1.Gateway,
 	route *RouteInfo,
 ) (BindingResult, error) {
-	matchedListeners, rejectionReason, err := v.findMatchingListeners(ctx, gateway, route)
+	if v.RequireParentRefGrants && gateway.Namespace != route.Namespace {
+		permitted, err := v.isParentRefPermitted(ctx, gateway, route)
+		if err != nil {
+			return BindingResult{}, err
+		}
+
+		if !permitted {
+			return BindingResult{
+				Accepted: false,
+				Reason:   gatewayv1.RouteReasonRefNotPermitted,
+				Message: fmt.Sprintf("Gateway %s/%s is not permitted by any ReferenceGrant",
+					gateway.Namespace, gateway.Name),
+			}, nil
+		}
+	}
+
+	listeners, rejected, rejectionReason, err := v.findMatchingListeners(ctx, gateway, route)
 	if err != nil {
 		return BindingResult{}, err
 	}
 
-	if len(matchedListeners) == 0 {
+	if len(listeners) == 0 {
 		return BindingResult{
-			Accepted:         false,
-			Reason:           rejectionReason,
-			Message:          getReasonMessage(rejectionReason),
-			MatchedListeners: nil,
+			Accepted:          false,
+			Reason:            rejectionReason,
+			Message:           getReasonMessage(rejectionReason),
+			MatchedListeners:  nil,
+			RejectedListeners: rejected,
 		}, nil
 	}
 
 	return BindingResult{
-		Accepted:         true,
-		Reason:           gatewayv1.RouteReasonAccepted,
-		Message:          "Route accepted",
-		MatchedListeners: matchedListeners,
+		Accepted:          true,
+		Reason:            gatewayv1.RouteReasonAccepted,
+		Message:           "Route accepted",
+		MatchedListeners:  listenerNames(listeners),
+		Listeners:         listeners,
+		RejectedListeners: rejected,
 	}, nil
 }
 
 // findMatchingListeners finds all listeners that the route can bind to.
-// Returns matched listeners, rejection reason (if no matches), and error.
+// Returns matched listeners, rejected listeners, rejection reason (if no
+// matches), and error.
 func (v *Validator) findMatchingListeners(
 	ctx context.Context,
 	gateway *gatewayv1.Gateway,
 	route *RouteInfo,
-) ([]gatewayv1.SectionName, gatewayv1.RouteConditionReason, error) {
+) ([]ListenerMatch, []ListenerRejection, gatewayv1.RouteConditionReason, error) {
 	if len(gateway.Spec.Listeners) == 0 {
-		return nil, gatewayv1.RouteReasonNoMatchingParent, nil
+		return nil, nil, gatewayv1.RouteReasonNoMatchingParent, nil
 	}
 
-	var matchedListeners []gatewayv1.SectionName
+	var (
+		matchedListeners  []ListenerMatch
+		rejectedListeners []ListenerRejection
+	)
 
 	var lastRejectionReason gatewayv1.RouteConditionReason
 
@@ -78,29 +128,45 @@ func (v *Validator) findMatchingListeners(
 
 		reason, err := v.listenerAcceptsRoute(ctx, listener, gateway.Namespace, route)
 		if err != nil {
-			return nil, "", err
+			return nil, nil, "", err
 		}
 
 		if reason == gatewayv1.RouteReasonAccepted {
-			matchedListeners = append(matchedListeners, listener.Name)
+			matchedListeners = append(matchedListeners, ListenerMatch{
+				Name:      listener.Name,
+				Port:      listener.Port,
+				Hostnames: IntersectHostnames(listener.Hostname, route.Hostnames),
+
+				ListenerHostname: listener.Hostname,
+			})
 		} else {
 			lastRejectionReason = reason
+			rejectedListeners = append(rejectedListeners, ListenerRejection{Name: listener.Name, Reason: reason})
 		}
 	}
 
 	if len(matchedListeners) == 0 {
 		if route.SectionName != nil {
-			return nil, gatewayv1.RouteReasonNoMatchingParent, nil
+			return nil, rejectedListeners, gatewayv1.RouteReasonNoMatchingParent, nil
 		}
 
 		if lastRejectionReason == "" {
-			return nil, gatewayv1.RouteReasonNoMatchingParent, nil
+			return nil, rejectedListeners, gatewayv1.RouteReasonNoMatchingParent, nil
 		}
 
-		return nil, lastRejectionReason, nil
+		return nil, rejectedListeners, lastRejectionReason, nil
+	}
+
+	return matchedListeners, rejectedListeners, "", nil
+}
+
+func listenerNames(listeners []ListenerMatch) []gatewayv1.SectionName {
+	names := make([]gatewayv1.SectionName, 0, len(listeners))
+	for _, listener := range listeners {
+		names = append(names, listener.Name)
 	}
 
-	return matchedListeners, "", nil
+	return names
 }
 
 // listenerAcceptsRoute checks if a single listener accepts the route.
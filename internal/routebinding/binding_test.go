@@ -8,8 +8,11 @@ import (
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
 func TestValidateBinding(t *testing.T) {
@@ -451,3 +454,163 @@ func TestValidateBinding_WithNamespaceSelector(t *testing.T) {
 	assert.Equal(t, gatewayv1.RouteReasonAccepted, result.Reason)
 	assert.Equal(t, []gatewayv1.SectionName{"http"}, result.MatchedListeners)
 }
+
+func TestValidateBinding_ListenerHostnames(t *testing.T) {
+	t.Parallel()
+
+	fromAll := gatewayv1.NamespacesFromAll
+	allowedRoutes := &gatewayv1.AllowedRoutes{
+		Namespaces: &gatewayv1.RouteNamespaces{From: &fromAll},
+	}
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-gateway",
+			Namespace: "default",
+		},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{
+				{
+					Name:          "foo",
+					Port:          80,
+					Protocol:      gatewayv1.HTTPProtocolType,
+					Hostname:      ptr(gatewayv1.Hostname("foo.example.com")),
+					AllowedRoutes: allowedRoutes,
+				},
+				{
+					Name:          "any",
+					Port:          8080,
+					Protocol:      gatewayv1.HTTPProtocolType,
+					AllowedRoutes: allowedRoutes,
+				},
+				{
+					Name:          "other",
+					Port:          80,
+					Protocol:      gatewayv1.HTTPProtocolType,
+					Hostname:      ptr(gatewayv1.Hostname("other.org")),
+					AllowedRoutes: allowedRoutes,
+				},
+			},
+		},
+	}
+
+	validator := NewValidator(setupFakeClient())
+
+	route := &RouteInfo{
+		Name:      "test-route",
+		Namespace: "default",
+		Hostnames: []gatewayv1.Hostname{"*.example.com"},
+		Kind:      "HTTPRoute",
+	}
+
+	result, err := validator.ValidateBinding(context.Background(), gateway, route)
+
+	require.NoError(t, err)
+	assert.True(t, result.Accepted)
+	assert.Equal(t, []gatewayv1.SectionName{"foo", "any"}, result.MatchedListeners)
+	assert.Equal(t, []ListenerMatch{
+		{
+			Name:             "foo",
+			Port:             80,
+			Hostnames:        []gatewayv1.Hostname{"foo.example.com"},
+			ListenerHostname: ptr(gatewayv1.Hostname("foo.example.com")),
+		},
+		{Name: "any", Port: 8080, Hostnames: []gatewayv1.Hostname{"*.example.com"}},
+	}, result.Listeners)
+	assert.Equal(t, []ListenerRejection{
+		{Name: "other", Reason: gatewayv1.RouteReasonNoMatchingListenerHostname},
+	}, result.RejectedListeners)
+}
+
+func TestValidateBinding_ParentRefGrants(t *testing.T) {
+	t.Parallel()
+
+	fromAll := gatewayv1.NamespacesFromAll
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "infra"},
+		Spec: gatewayv1.GatewaySpec{
+			Listeners: []gatewayv1.Listener{{
+				Name:          "http",
+				Port:          80,
+				Protocol:      gatewayv1.HTTPProtocolType,
+				AllowedRoutes: &gatewayv1.AllowedRoutes{Namespaces: &gatewayv1.RouteNamespaces{From: &fromAll}},
+			}},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+	require.NoError(t, gatewayv1beta1.Install(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&gatewayv1beta1.ReferenceGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a-routes", Namespace: "infra"},
+			Spec: gatewayv1beta1.ReferenceGrantSpec{
+				From: []gatewayv1beta1.ReferenceGrantFrom{{Group: gatewayv1.GroupName, Kind: "HTTPRoute", Namespace: "team-a"}},
+				To:   []gatewayv1beta1.ReferenceGrantTo{{Group: gatewayv1.GroupName, Kind: "Gateway"}},
+			},
+		}).
+		Build()
+
+	tests := []struct {
+		name             string
+		require          bool
+		route            *RouteInfo
+		expectedAccepted bool
+		expectedReason   gatewayv1.RouteConditionReason
+	}{
+		{
+			name:             "not enforced",
+			route:            &RouteInfo{Name: "web", Namespace: "team-b", Kind: KindHTTPRoute},
+			expectedAccepted: true,
+			expectedReason:   gatewayv1.RouteReasonAccepted,
+		},
+		{
+			name:             "permitted by grant",
+			require:          true,
+			route:            &RouteInfo{Name: "web", Namespace: "team-a", Kind: KindHTTPRoute},
+			expectedAccepted: true,
+			expectedReason:   gatewayv1.RouteReasonAccepted,
+		},
+		{
+			name:             "same namespace needs no grant",
+			require:          true,
+			route:            &RouteInfo{Name: "web", Namespace: "infra", Kind: KindHTTPRoute},
+			expectedAccepted: true,
+			expectedReason:   gatewayv1.RouteReasonAccepted,
+		},
+		{
+			name:           "namespace without grant",
+			require:        true,
+			route:          &RouteInfo{Name: "web", Namespace: "team-b", Kind: KindHTTPRoute},
+			expectedReason: gatewayv1.RouteReasonRefNotPermitted,
+		},
+		{
+			name:           "kind without grant",
+			require:        true,
+			route:          &RouteInfo{Name: "api", Namespace: "team-a", Kind: KindGRPCRoute},
+			expectedReason: gatewayv1.RouteReasonRefNotPermitted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			validator := NewValidator(cli)
+			validator.RequireParentRefGrants = tt.require
+
+			result, err := validator.ValidateBinding(context.Background(), gateway, tt.route)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedAccepted, result.Accepted)
+			assert.Equal(t, tt.expectedReason, result.Reason)
+
+			if !tt.expectedAccepted {
+				assert.Equal(t, "Gateway infra/shared is not permitted by any ReferenceGrant", result.Message)
+				assert.Empty(t, result.MatchedListeners)
+			}
+		})
+	}
+}
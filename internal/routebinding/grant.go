@@ -0,0 +1,42 @@
+package routebinding
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/referencegrant"
+)
+
+// kindGateway is the kind of the parent a route attaches to.
+const kindGateway = "Gateway"
+
+// isParentRefPermitted checks whether a ReferenceGrant in the namespace of
+// the Gateway permits routes of the kind and namespace of the route to
+// attach to it.
+func (v *Validator) isParentRefPermitted(
+	ctx context.Context,
+	gateway *gatewayv1.Gateway,
+	route *RouteInfo,
+) (bool, error) {
+	allowed, err := referencegrant.NewValidator(v.client).IsReferenceAllowed(ctx,
+		referencegrant.Reference{
+			Group:     gatewayv1.GroupName,
+			Kind:      string(route.Kind),
+			Namespace: route.Namespace,
+			Name:      route.Name,
+		},
+		referencegrant.Reference{
+			Group:     gatewayv1.GroupName,
+			Kind:      kindGateway,
+			Namespace: gateway.Namespace,
+			Name:      gateway.Name,
+		},
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check ReferenceGrants")
+	}
+
+	return allowed, nil
+}
@@ -1,6 +1,7 @@
 package routebinding
 
 import (
+	"slices"
 	"strings"
 
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -29,6 +30,68 @@ func HostnamesIntersect(listenerHostname *gatewayv1.Hostname, routeHostnames []g
 	return false
 }
 
+// IntersectHostnames returns the hostnames a route serves on a listener.
+// Per Gateway API spec, these are the route hostnames that match the listener
+// hostname, with each wildcard narrowed to the more specific listener
+// hostname. A route without hostnames takes the listener hostname, and a
+// listener without hostname keeps the route hostnames. Empty matches all
+// hostnames.
+func IntersectHostnames(listenerHostname *gatewayv1.Hostname, routeHostnames []gatewayv1.Hostname) []gatewayv1.Hostname {
+	if listenerHostname == nil || *listenerHostname == "" {
+		return slices.Clone(routeHostnames)
+	}
+
+	if len(routeHostnames) == 0 {
+		return []gatewayv1.Hostname{*listenerHostname}
+	}
+
+	var result []gatewayv1.Hostname
+
+	for _, routeHost := range routeHostnames {
+		if !hostnameMatches(string(*listenerHostname), string(routeHost)) {
+			continue
+		}
+
+		narrowed := routeHost
+		if strings.HasPrefix(string(routeHost), "*.") {
+			narrowed = *listenerHostname
+		}
+
+		if !slices.Contains(result, narrowed) {
+			result = append(result, narrowed)
+		}
+	}
+
+	return result
+}
+
+// MoreSpecificHostname reports whether every request matching the listener
+// hostname specific also matches the listener hostname general, and general
+// matches more. Per Gateway API listener isolation, such requests are served
+// by the routes of the more specific listener only.
+func MoreSpecificHostname(general, specific *gatewayv1.Hostname) bool {
+	if specific == nil || *specific == "" {
+		return false
+	}
+
+	if general == nil || *general == "" {
+		return true
+	}
+
+	generalHost := strings.ToLower(string(*general))
+	specificHost := strings.ToLower(string(*specific))
+
+	if generalHost == specificHost || !strings.HasPrefix(generalHost, "*.") {
+		return false
+	}
+
+	if strings.HasPrefix(specificHost, "*.") {
+		return strings.HasSuffix(specificHost[1:], generalHost[1:])
+	}
+
+	return matchesWildcard(generalHost, specificHost)
+}
+
 // hostnameMatches checks if a listener hostname matches a route hostname.
 // Supports wildcard prefixes like *.example.com per Gateway API spec.
 // DNS names are case-insensitive, so comparison is done in lowercase.
@@ -230,3 +230,119 @@ func TestHostnameMatches(t *testing.T) {
 		})
 	}
 }
+
+func TestIntersectHostnames(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		listenerHost   *gatewayv1.Hostname
+		routeHostnames []gatewayv1.Hostname
+		expected       []gatewayv1.Hostname
+	}{
+		{
+			name:           "listener without hostname keeps route hostnames",
+			listenerHost:   nil,
+			routeHostnames: []gatewayv1.Hostname{"foo.example.com", "*.example.org"},
+			expected:       []gatewayv1.Hostname{"foo.example.com", "*.example.org"},
+		},
+		{
+			name:           "both without hostnames match all",
+			listenerHost:   ptr(gatewayv1.Hostname("")),
+			routeHostnames: nil,
+			expected:       nil,
+		},
+		{
+			name:           "route without hostnames takes listener hostname",
+			listenerHost:   ptr(gatewayv1.Hostname("*.example.com")),
+			routeHostnames: nil,
+			expected:       []gatewayv1.Hostname{"*.example.com"},
+		},
+		{
+			name:           "wildcard listener keeps matching route hostnames",
+			listenerHost:   ptr(gatewayv1.Hostname("*.example.com")),
+			routeHostnames: []gatewayv1.Hostname{"foo.example.com", "bar.example.org", "baz.example.com"},
+			expected:       []gatewayv1.Hostname{"foo.example.com", "baz.example.com"},
+		},
+		{
+			name:           "wildcard route narrowed to exact listener",
+			listenerHost:   ptr(gatewayv1.Hostname("foo.example.com")),
+			routeHostnames: []gatewayv1.Hostname{"*.example.com"},
+			expected:       []gatewayv1.Hostname{"foo.example.com"},
+		},
+		{
+			name:           "duplicates after narrowing are removed",
+			listenerHost:   ptr(gatewayv1.Hostname("foo.example.com")),
+			routeHostnames: []gatewayv1.Hostname{"*.example.com", "foo.example.com"},
+			expected:       []gatewayv1.Hostname{"foo.example.com"},
+		},
+		{
+			name:           "no intersection",
+			listenerHost:   ptr(gatewayv1.Hostname("*.example.com")),
+			routeHostnames: []gatewayv1.Hostname{"example.com"},
+			expected:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := IntersectHostnames(tt.listenerHost, tt.routeHostnames)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestMoreSpecificHostname(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		general  *gatewayv1.Hostname
+		specific *gatewayv1.Hostname
+		expected bool
+	}{
+		{name: "any hostname is more specific than none", specific: ptr(gatewayv1.Hostname("foo.example.com")), expected: true},
+		{name: "no hostname is never more specific", general: ptr(gatewayv1.Hostname("*.example.com"))},
+		{name: "both without hostname"},
+		{
+			name:     "exact under wildcard",
+			general:  ptr(gatewayv1.Hostname("*.example.com")),
+			specific: ptr(gatewayv1.Hostname("Foo.Example.com")),
+			expected: true,
+		},
+		{
+			name:     "nested wildcard under wildcard",
+			general:  ptr(gatewayv1.Hostname("*.example.com")),
+			specific: ptr(gatewayv1.Hostname("*.foo.example.com")),
+			expected: true,
+		},
+		{
+			name:     "same hostname",
+			general:  ptr(gatewayv1.Hostname("*.example.com")),
+			specific: ptr(gatewayv1.Hostname("*.example.com")),
+		},
+		{
+			name:     "apex is not under wildcard",
+			general:  ptr(gatewayv1.Hostname("*.example.com")),
+			specific: ptr(gatewayv1.Hostname("example.com")),
+		},
+		{
+			name:     "exact hostnames never overlap",
+			general:  ptr(gatewayv1.Hostname("foo.example.com")),
+			specific: ptr(gatewayv1.Hostname("bar.example.com")),
+		},
+		{
+			name:     "wildcard of another domain",
+			general:  ptr(gatewayv1.Hostname("*.example.com")),
+			specific: ptr(gatewayv1.Hostname("*.example.org")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, MoreSpecificHostname(tt.general, tt.specific))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package routebinding
 
 import (
+	"slices"
+
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
 
@@ -36,6 +38,45 @@ func IsRouteKindAllowed(
 	return false
 }
 
+// reconciledKinds are the route kinds the controller reconciles.
+//
+//nolint:gochecknoglobals // read-only list of route kinds
+var reconciledKinds = []gatewayv1.Kind{KindHTTPRoute, KindGRPCRoute}
+
+// ListenerRouteKinds returns the route kinds a listener accepts that the
+// controller reconciles, HTTPRoute and GRPCRoute on HTTP and HTTPS
+// listeners, as reported in the SupportedKinds of the listener status. The
+// kinds of allowedRoutes.kinds the controller cannot reconcile are returned
+// as invalid.
+func ListenerRouteKinds(listener *gatewayv1.Listener) ([]gatewayv1.RouteGroupKind, []gatewayv1.RouteGroupKind) {
+	group := gatewayv1.Group(gatewayv1.GroupName)
+	configured := listener.AllowedRoutes != nil && len(listener.AllowedRoutes.Kinds) > 0
+	httpListener := listener.Protocol == gatewayv1.HTTPProtocolType || listener.Protocol == gatewayv1.HTTPSProtocolType
+
+	supported := make([]gatewayv1.RouteGroupKind, 0, len(reconciledKinds))
+
+	var invalid []gatewayv1.RouteGroupKind
+
+	for _, kind := range getAllowedKinds(listener.AllowedRoutes, listener.Protocol) {
+		reconciled := slices.ContainsFunc(reconciledKinds, func(reconciled gatewayv1.Kind) bool {
+			return kindMatches(kind, reconciled)
+		})
+
+		switch {
+		case reconciled && httpListener:
+			if !slices.ContainsFunc(supported, func(existing gatewayv1.RouteGroupKind) bool {
+				return existing.Kind == kind.Kind
+			}) {
+				supported = append(supported, gatewayv1.RouteGroupKind{Group: &group, Kind: kind.Kind})
+			}
+		case configured:
+			invalid = append(invalid, kind)
+		}
+	}
+
+	return supported, invalid
+}
+
 // getAllowedKinds returns the list of allowed route kinds for a listener.
 func getAllowedKinds(
 	allowedRoutes *gatewayv1.AllowedRoutes,
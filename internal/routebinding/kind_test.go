@@ -221,3 +221,72 @@ func TestIsRouteKindAllowed(t *testing.T) {
 func groupPtr(g gatewayv1.Group) *gatewayv1.Group {
 	return &g
 }
+
+func TestListenerRouteKinds(t *testing.T) {
+	t.Parallel()
+
+	group := gatewayv1.Group(gatewayv1.GroupName)
+	otherGroup := gatewayv1.Group("example.com")
+
+	httpRoute := gatewayv1.RouteGroupKind{Group: &group, Kind: KindHTTPRoute}
+	grpcRoute := gatewayv1.RouteGroupKind{Group: &group, Kind: KindGRPCRoute}
+	tlsRoute := gatewayv1.RouteGroupKind{Group: &group, Kind: KindTLSRoute}
+
+	tests := []struct {
+		name              string
+		listener          gatewayv1.Listener
+		expectedSupported []gatewayv1.RouteGroupKind
+		expectedInvalid   []gatewayv1.RouteGroupKind
+	}{
+		{
+			name:              "HTTP defaults",
+			listener:          gatewayv1.Listener{Protocol: gatewayv1.HTTPProtocolType},
+			expectedSupported: []gatewayv1.RouteGroupKind{httpRoute, grpcRoute},
+		},
+		{
+			name: "restricted to GRPCRoute",
+			listener: gatewayv1.Listener{
+				Protocol:      gatewayv1.HTTPSProtocolType,
+				AllowedRoutes: &gatewayv1.AllowedRoutes{Kinds: []gatewayv1.RouteGroupKind{{Kind: KindGRPCRoute}}},
+			},
+			expectedSupported: []gatewayv1.RouteGroupKind{grpcRoute},
+		},
+		{
+			name: "unsupported configured kinds",
+			listener: gatewayv1.Listener{
+				Protocol: gatewayv1.HTTPProtocolType,
+				AllowedRoutes: &gatewayv1.AllowedRoutes{Kinds: []gatewayv1.RouteGroupKind{
+					httpRoute,
+					tlsRoute,
+					{Group: &otherGroup, Kind: KindHTTPRoute},
+				}},
+			},
+			expectedSupported: []gatewayv1.RouteGroupKind{httpRoute},
+			expectedInvalid:   []gatewayv1.RouteGroupKind{tlsRoute, {Group: &otherGroup, Kind: KindHTTPRoute}},
+		},
+		{
+			name:              "TLS defaults",
+			listener:          gatewayv1.Listener{Protocol: gatewayv1.TLSProtocolType},
+			expectedSupported: []gatewayv1.RouteGroupKind{},
+		},
+		{
+			name: "HTTPRoute on TCP listener",
+			listener: gatewayv1.Listener{
+				Protocol:      gatewayv1.TCPProtocolType,
+				AllowedRoutes: &gatewayv1.AllowedRoutes{Kinds: []gatewayv1.RouteGroupKind{httpRoute}},
+			},
+			expectedSupported: []gatewayv1.RouteGroupKind{},
+			expectedInvalid:   []gatewayv1.RouteGroupKind{httpRoute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			supported, invalid := ListenerRouteKinds(&tt.listener)
+			assert.Equal(t, tt.expectedSupported, supported)
+			assert.Equal(t, tt.expectedInvalid, invalid)
+		})
+	}
+}
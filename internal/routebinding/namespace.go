@@ -14,6 +14,11 @@ import (
 // Validator performs route binding validation against Gateway listeners.
 type Validator struct {
 	client client.Client
+
+	// RequireParentRefGrants makes routes attach to Gateways in other
+	// namespaces only if a ReferenceGrant in the Gateway namespace permits
+	// it, in addition to the allowedRoutes of the listeners.
+	RequireParentRefGrants bool
 }
 
 // NewValidator creates a new Validator with the given client.
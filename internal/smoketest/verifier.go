@@ -0,0 +1,166 @@
+// Package smoketest verifies the proxy data plane after route syncs.
+//
+// A Verifier sends a synthetic request for a canary URL through the proxy and
+// records the result. It catches cases where the proxy accepted the route
+// configuration but traffic is still broken, for example a certificate that
+// does not match the canary hostname.
+package smoketest
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+)
+
+// DefaultTimeout is the default timeout for a single smoke test request.
+const DefaultTimeout = 5 * time.Second
+
+// Smoke test results used as metric labels.
+const (
+	// ResultSuccess means the proxy answered with a non-error status.
+	ResultSuccess = "success"
+	// ResultFailed means the proxy answered with a 4xx or 5xx status.
+	ResultFailed = "failed"
+	// ResultError means no response was received (connection, TLS or timeout error).
+	ResultError = "error"
+)
+
+// maxBodyBytes limits how much of the response body is drained.
+const maxBodyBytes = 64 * 1024
+
+// Config configures a Verifier.
+type Config struct {
+	// URL is the canary URL requested through the proxy. Its host is sent as
+	// the Host header and TLS server name.
+	URL string
+
+	// Address is the proxy data plane address (host:port) to connect to.
+	// Empty means the URL host is resolved and dialed directly.
+	Address string
+
+	// Timeout bounds a single request. Zero uses DefaultTimeout.
+	Timeout time.Duration
+}
+
+// Verifier sends smoke test requests through the proxy data plane.
+type Verifier struct {
+	url     string
+	client  *http.Client
+	metrics metrics.Collector
+	logger  *slog.Logger
+}
+
+// NewVerifier creates a Verifier for the given configuration.
+func NewVerifier(cfg Config, collector metrics.Collector, logger *slog.Logger) (*Verifier, error) {
+	parsed, err := url.Parse(cfg.URL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid smoke test URL")
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, errors.Newf("smoke test URL must use http or https, got %q", parsed.Scheme)
+	}
+
+	if parsed.Host == "" {
+		//nolint:wrapcheck // New creates new error, not wrapping
+		return nil, errors.New("smoke test URL must include a host")
+	}
+
+	if collector == nil {
+		collector = metrics.NewNoopCollector()
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+
+	transport := &http.Transport{
+		Proxy:             nil,
+		DialContext:       dialer.DialContext,
+		TLSClientConfig:   &tls.Config{MinVersion: tls.VersionTLS12},
+		DisableKeepAlives: true,
+	}
+
+	if cfg.Address != "" {
+		address := cfg.Address
+		transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		}
+	}
+
+	return &Verifier{
+		url: parsed.String(),
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   timeout,
+			// A redirect is a valid answer from the route under test
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		metrics: collector,
+		logger:  logger.With("component", "smoke-test"),
+	}, nil
+}
+
+// Verify sends one request to the canary URL and records the result.
+// It returns an error if no response was received or the status is 4xx or 5xx.
+func (v *Verifier) Verify(ctx context.Context) error {
+	start := time.Now()
+
+	status, err := v.do(ctx)
+	duration := time.Since(start)
+
+	switch {
+	case err != nil:
+		v.metrics.RecordSmokeTest(ctx, ResultError, duration)
+		v.logger.Warn("smoke test request failed", "url", v.url, "error", err)
+
+		return err
+	case status >= http.StatusBadRequest:
+		v.metrics.RecordSmokeTest(ctx, ResultFailed, duration)
+		v.logger.Warn("smoke test returned error status", "url", v.url, "status", status)
+
+		return errors.Newf("smoke test returned status %d", status)
+	default:
+		v.metrics.RecordSmokeTest(ctx, ResultSuccess, duration)
+		v.logger.Debug("smoke test succeeded", "url", v.url, "status", status, "duration", duration)
+
+		return nil
+	}
+}
+
+func (v *Verifier) do(ctx context.Context) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, http.NoBody)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to build smoke test request")
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "smoke test request failed")
+	}
+
+	defer resp.Body.Close()
+
+	// Drain the body so the full response is part of the measured latency
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyBytes))
+
+	return resp.StatusCode, nil
+}
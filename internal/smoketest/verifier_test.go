@@ -0,0 +1,128 @@
+package smoketest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/metrics"
+	"github.com/lexfrei/pingora-gateway-controller/internal/smoketest"
+)
+
+type recordingCollector struct {
+	metrics.NoopCollector
+
+	mu      sync.Mutex
+	results []string
+}
+
+func (c *recordingCollector) RecordSmokeTest(_ context.Context, result string, _ time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.results = append(c.results, result)
+}
+
+func TestNewVerifier_InvalidURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "unsupported scheme", url: "ftp://canary.example.com/"},
+		{name: "missing host", url: "http:///healthz"},
+		{name: "malformed", url: "http://[::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := smoketest.NewVerifier(smoketest.Config{URL: tt.url}, nil, nil)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		wantErr  bool
+		expected string
+	}{
+		{name: "ok", status: http.StatusOK, expected: smoketest.ResultSuccess},
+		{name: "redirect", status: http.StatusFound, expected: smoketest.ResultSuccess},
+		{name: "not found", status: http.StatusNotFound, wantErr: true, expected: smoketest.ResultFailed},
+		{name: "bad gateway", status: http.StatusBadGateway, wantErr: true, expected: smoketest.ResultFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var gotHost, gotPath string
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHost = r.Host
+				gotPath = r.URL.Path
+
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "/elsewhere")
+				}
+
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			collector := &recordingCollector{}
+
+			verifier, err := smoketest.NewVerifier(smoketest.Config{
+				URL:     "http://canary.example.test/healthz",
+				Address: strings.TrimPrefix(server.URL, "http://"),
+			}, collector, nil)
+			require.NoError(t, err)
+
+			err = verifier.Verify(context.Background())
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, "canary.example.test", gotHost)
+			assert.Equal(t, "/healthz", gotPath)
+			assert.Equal(t, []string{tt.expected}, collector.results)
+		})
+	}
+}
+
+func TestVerifier_CertificateMismatch(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	collector := &recordingCollector{}
+
+	verifier, err := smoketest.NewVerifier(smoketest.Config{
+		URL:     "https://canary.example.test/",
+		Address: strings.TrimPrefix(server.URL, "https://"),
+	}, collector, nil)
+	require.NoError(t, err)
+
+	require.Error(t, verifier.Verify(context.Background()))
+	assert.Equal(t, []string{smoketest.ResultError}, collector.results)
+}
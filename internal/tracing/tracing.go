@@ -0,0 +1,130 @@
+// Package tracing sets up OpenTelemetry tracing for the controller.
+//
+// Spans are exported over OTLP/gRPC when an endpoint is configured. Without
+// an endpoint, the global no-op tracer provider is kept and spans cost next
+// to nothing. The trace IDs of recorded spans are added to log records by
+// logging.TraceHandler.
+package tracing
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultSampleRatio is the default fraction of new traces that are sampled.
+const DefaultSampleRatio = 1.0
+
+// ServiceName is the service name reported in exported spans.
+const ServiceName = "pingora-gateway-controller"
+
+// instrumentationName identifies the tracer of the controller.
+const instrumentationName = "github.com/lexfrei/pingora-gateway-controller"
+
+// Config configures tracing.
+type Config struct {
+	// Endpoint is the OTLP/gRPC collector address (host:port).
+	// Empty disables tracing.
+	Endpoint string
+
+	// Insecure sends spans without TLS.
+	Insecure bool
+
+	// SampleRatio is the fraction of new traces that are sampled, from 0 to 1.
+	// Traces started by a sampled parent are always sampled.
+	SampleRatio float64
+
+	// Version is the controller version reported in exported spans.
+	Version string
+}
+
+// ShutdownFunc flushes pending spans and stops the exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup installs the global tracer provider and propagator for cfg.
+// The returned function must be called on shutdown to flush pending spans.
+func Setup(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, errors.Newf("trace sample ratio must be between 0 and 1, got %v", cfg.SampleRatio)
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OTLP trace exporter")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(ServiceName),
+		semconv.ServiceVersion(cfg.Version),
+	))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build trace resource")
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return func(ctx context.Context) error {
+		return errors.Wrap(provider.Shutdown(ctx), "failed to shut down tracer provider")
+	}, nil
+}
+
+// Tracer returns the tracer of the controller. It reads the global tracer
+// provider on every call, so spans started before Setup are not recorded.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a span with the controller tracer.
+//
+//nolint:spancheck // the caller ends the span
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// ObjectAttributes returns the span attributes identifying a Kubernetes object.
+func ObjectAttributes(kind, namespace, name string) trace.SpanStartOption {
+	return trace.WithAttributes(
+		attribute.String("k8s.object.kind", kind),
+		semconv.K8SNamespaceName(namespace),
+		attribute.String("k8s.object.name", name),
+	)
+}
@@ -0,0 +1,55 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/tracing"
+)
+
+func TestSetup_Disabled(t *testing.T) {
+	t.Parallel()
+
+	shutdown, err := tracing.Setup(context.Background(), tracing.Config{})
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_InvalidSampleRatio(t *testing.T) {
+	t.Parallel()
+
+	_, err := tracing.Setup(context.Background(), tracing.Config{Endpoint: "localhost:4317", SampleRatio: 1.5})
+	require.Error(t, err)
+}
+
+func TestEnd(t *testing.T) {
+	t.Parallel()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, ok := tracer.Start(context.Background(), "ok",
+		tracing.ObjectAttributes("HTTPRoute", "default", "app"))
+	tracing.End(ok, nil)
+
+	_, failed := tracer.Start(context.Background(), "failed")
+	tracing.End(failed, errors.New("proxy unavailable"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Len(t, spans[0].Attributes(), 3)
+
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "proxy unavailable", spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1)
+	assert.Equal(t, "exception", spans[1].Events()[0].Name)
+}
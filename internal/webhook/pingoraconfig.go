@@ -0,0 +1,169 @@
+// Package webhook provides validating admission webhooks for PingoraConfig and Gateway API routes.
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/cockroachdb/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+)
+
+// DefaultPort is the default port of the webhook server.
+const DefaultPort = 9443
+
+// TLS Secret keys read by the controller.
+const (
+	secretKeyCert = "tls.crt"
+	secretKeyKey  = "tls.key"
+	secretKeyCA   = "ca.crt"
+)
+
+// +kubebuilder:webhook:path=/validate-pingora-k8s-lex-la-v1alpha1-pingoraconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=pingora.k8s.lex.la,resources=pingoraconfigs,verbs=create;update,versions=v1alpha1,name=vpingoraconfig.pingora.k8s.lex.la,admissionReviewVersions=v1
+
+// PingoraConfigValidator validates PingoraConfig resources at admission time.
+//
+// Spec rules come from PingoraConfig.Validate, so admission and the controller
+// agree on what is valid. In addition, the referenced TLS Secret is checked for
+// the keys the controller needs.
+type PingoraConfigValidator struct {
+	Client client.Reader
+
+	// DefaultNamespace is used for Secret references without a namespace.
+	DefaultNamespace string
+}
+
+var _ admission.CustomValidator = (*PingoraConfigValidator)(nil)
+
+// SetupPingoraConfigWebhook registers the PingoraConfig validating webhook with the manager.
+func SetupPingoraConfigWebhook(mgr ctrl.Manager, defaultNamespace string) error {
+	//nolint:wrapcheck // controller-runtime builder pattern
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.PingoraConfig{}).
+		WithValidator(&PingoraConfigValidator{
+			Client:           mgr.GetClient(),
+			DefaultNamespace: defaultNamespace,
+		}).
+		Complete()
+}
+
+// ValidateCreate validates a new PingoraConfig.
+func (v *PingoraConfigValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate validates an updated PingoraConfig.
+func (v *PingoraConfigValidator) ValidateUpdate(
+	ctx context.Context,
+	_, newObj runtime.Object,
+) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete allows all deletions.
+func (v *PingoraConfigValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *PingoraConfigValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	pingoraConfig, ok := obj.(*v1alpha1.PingoraConfig)
+	if !ok {
+		//nolint:wrapcheck // Newf creates new error, not wrapping
+		return nil, errors.Newf("expected a PingoraConfig, got %T", obj)
+	}
+
+	var warnings admission.Warnings
+
+	errs := pingoraConfig.Validate()
+
+	if tlsConfig := pingoraConfig.Spec.TLS; tlsConfig != nil {
+		tlsPath := field.NewPath("spec", "tls")
+
+		if tlsConfig.InsecureSkipVerify {
+			warnings = append(warnings, "spec.tls.insecureSkipVerify disables certificate verification")
+		}
+
+		switch {
+		case !tlsConfig.Enabled && tlsConfig.SecretRef != nil:
+			warnings = append(warnings, "spec.tls.secretRef is ignored because TLS is disabled")
+		case tlsConfig.Enabled && tlsConfig.SecretRef != nil && tlsConfig.SecretRef.Name != "":
+			secretWarnings, secretErrs := v.validateSecret(ctx, tlsConfig.SecretRef, tlsPath.Child("secretRef"))
+			warnings = append(warnings, secretWarnings...)
+			errs = append(errs, secretErrs...)
+		}
+	}
+
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(
+			v1alpha1.GroupVersion.WithKind("PingoraConfig").GroupKind(),
+			pingoraConfig.Name,
+			errs,
+		)
+	}
+
+	return warnings, nil
+}
+
+// validateSecret checks that the TLS Secret holds usable key material.
+// A missing Secret only produces a warning, because it is often created
+// after the PingoraConfig, for example by cert-manager or a GitOps tool.
+func (v *PingoraConfigValidator) validateSecret(
+	ctx context.Context,
+	ref *v1alpha1.SecretReference,
+	path *field.Path,
+) (admission.Warnings, field.ErrorList) {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = v.DefaultNamespace
+	}
+
+	var secret corev1.Secret
+
+	err := v.Client.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, &secret)
+	if apierrors.IsNotFound(err) {
+		return admission.Warnings{
+			"TLS Secret " + namespace + "/" + ref.Name + " does not exist yet; the controller cannot connect until it is created",
+		}, nil
+	}
+
+	if err != nil {
+		return nil, field.ErrorList{field.InternalError(path, errors.Wrap(err, "failed to get TLS Secret"))}
+	}
+
+	return nil, validateSecretData(ref.Name, secret.Data, path)
+}
+
+// validateSecretData checks the keys of a TLS Secret.
+// A client certificate needs both tls.crt and tls.key, and at least one of
+// the client certificate or ca.crt must be present.
+func validateSecretData(name string, data map[string][]byte, path *field.Path) field.ErrorList {
+	cert, hasCert := data[secretKeyCert]
+	key, hasKey := data[secretKeyKey]
+	_, hasCA := data[secretKeyCA]
+
+	secretPath := path.Child("name")
+
+	switch {
+	case hasCert != hasKey:
+		return field.ErrorList{field.Invalid(secretPath, name,
+			"TLS Secret must contain both "+secretKeyCert+" and "+secretKeyKey)}
+	case !hasCert && !hasCA:
+		return field.ErrorList{field.Invalid(secretPath, name,
+			"TLS Secret must contain "+secretKeyCert+" and "+secretKeyKey+", or "+secretKeyCA)}
+	case hasCert:
+		if _, err := tls.X509KeyPair(cert, key); err != nil {
+			return field.ErrorList{field.Invalid(secretPath, name,
+				"TLS Secret does not contain a valid certificate and key pair: "+err.Error())}
+		}
+	}
+
+	return nil
+}
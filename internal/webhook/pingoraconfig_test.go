@@ -0,0 +1,257 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/lexfrei/pingora-gateway-controller/api/v1alpha1"
+	"github.com/lexfrei/pingora-gateway-controller/internal/webhook"
+)
+
+const testNamespace = "pingora-system"
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func generateKeyPair(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "pingora-controller"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM
+}
+
+func newTLSSecret(name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Data:       data,
+	}
+}
+
+func newPingoraConfig(mutate func(*v1alpha1.PingoraConfigSpec)) *v1alpha1.PingoraConfig {
+	cfg := &v1alpha1.PingoraConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pingora"},
+		Spec: v1alpha1.PingoraConfigSpec{
+			Address: "pingora-proxy.pingora-system.svc:50051",
+		},
+	}
+
+	if mutate != nil {
+		mutate(&cfg.Spec)
+	}
+
+	return cfg
+}
+
+func withTLSSecret(name string) func(*v1alpha1.PingoraConfigSpec) {
+	return func(spec *v1alpha1.PingoraConfigSpec) {
+		spec.TLS = &v1alpha1.TLSConfig{
+			Enabled:   true,
+			SecretRef: &v1alpha1.SecretReference{Name: name},
+		}
+	}
+}
+
+func newValidator(t *testing.T, objects ...client.Object) *webhook.PingoraConfigValidator {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	return &webhook.PingoraConfigValidator{
+		Client:           fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		DefaultNamespace: testNamespace,
+	}
+}
+
+func TestPingoraConfigValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	certPEM, keyPEM := generateKeyPair(t)
+	_, otherKeyPEM := generateKeyPair(t)
+
+	secrets := []client.Object{
+		newTLSSecret("client-cert", map[string][]byte{"tls.crt": certPEM, "tls.key": keyPEM}),
+		newTLSSecret("ca-only", map[string][]byte{"ca.crt": certPEM}),
+		newTLSSecret("missing-key", map[string][]byte{"tls.crt": certPEM}),
+		newTLSSecret("mismatched", map[string][]byte{"tls.crt": certPEM, "tls.key": otherKeyPEM}),
+		newTLSSecret("empty", map[string][]byte{"other": []byte("x")}),
+	}
+
+	tests := []struct {
+		name         string
+		config       *v1alpha1.PingoraConfig
+		wantErr      bool
+		wantField    string
+		wantWarnings int
+	}{
+		{
+			name:   "minimal config",
+			config: newPingoraConfig(nil),
+		},
+		{
+			name:   "client certificate",
+			config: newPingoraConfig(withTLSSecret("client-cert")),
+		},
+		{
+			name:   "CA only",
+			config: newPingoraConfig(withTLSSecret("ca-only")),
+		},
+		{
+			name: "malformed address",
+			config: newPingoraConfig(func(spec *v1alpha1.PingoraConfigSpec) {
+				spec.Address = "pingora-proxy"
+			}),
+			wantErr:   true,
+			wantField: "spec.address",
+		},
+		{
+			name: "retries exceed request timeout",
+			config: newPingoraConfig(func(spec *v1alpha1.PingoraConfigSpec) {
+				spec.Connection = &v1alpha1.ConnectionConfig{
+					RequestTimeoutSeconds: int32Ptr(5),
+					MaxRetries:            int32Ptr(20),
+					RetryBackoffMs:        int32Ptr(500),
+				}
+			}),
+			wantErr:   true,
+			wantField: "spec.connection.maxRetries",
+		},
+		{
+			name:      "secret missing tls.key",
+			config:    newPingoraConfig(withTLSSecret("missing-key")),
+			wantErr:   true,
+			wantField: "spec.tls.secretRef.name",
+		},
+		{
+			name:      "mismatched certificate and key",
+			config:    newPingoraConfig(withTLSSecret("mismatched")),
+			wantErr:   true,
+			wantField: "spec.tls.secretRef.name",
+		},
+		{
+			name:      "secret without TLS keys",
+			config:    newPingoraConfig(withTLSSecret("empty")),
+			wantErr:   true,
+			wantField: "spec.tls.secretRef.name",
+		},
+		{
+			name:         "missing secret warns",
+			config:       newPingoraConfig(withTLSSecret("does-not-exist")),
+			wantWarnings: 1,
+		},
+		{
+			name: "secretRef with TLS disabled warns",
+			config: newPingoraConfig(func(spec *v1alpha1.PingoraConfigSpec) {
+				spec.TLS = &v1alpha1.TLSConfig{SecretRef: &v1alpha1.SecretReference{Name: "missing-key"}}
+			}),
+			wantWarnings: 1,
+		},
+		{
+			name: "insecureSkipVerify warns",
+			config: newPingoraConfig(func(spec *v1alpha1.PingoraConfigSpec) {
+				spec.TLS = &v1alpha1.TLSConfig{Enabled: true, InsecureSkipVerify: true}
+			}),
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			validator := newValidator(t, secrets...)
+
+			warnings, err := validator.ValidateCreate(context.Background(), tt.config)
+			assert.Len(t, warnings, tt.wantWarnings)
+
+			if !tt.wantErr {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+			require.True(t, apierrors.IsInvalid(err))
+
+			var statusErr *apierrors.StatusError
+
+			require.ErrorAs(t, err, &statusErr)
+			require.NotEmpty(t, statusErr.ErrStatus.Details.Causes)
+			assert.Equal(t, tt.wantField, statusErr.ErrStatus.Details.Causes[0].Field)
+		})
+	}
+}
+
+func TestPingoraConfigValidator_ValidateUpdate(t *testing.T) {
+	t.Parallel()
+
+	validator := newValidator(t)
+
+	oldConfig := newPingoraConfig(nil)
+	newConfig := newPingoraConfig(func(spec *v1alpha1.PingoraConfigSpec) {
+		spec.Address = "pingora-proxy:0"
+	})
+
+	_, err := validator.ValidateUpdate(context.Background(), oldConfig, newConfig)
+	require.Error(t, err)
+	assert.True(t, apierrors.IsInvalid(err))
+}
+
+func TestPingoraConfigValidator_ValidateDelete(t *testing.T) {
+	t.Parallel()
+
+	validator := newValidator(t)
+
+	invalid := newPingoraConfig(func(spec *v1alpha1.PingoraConfigSpec) {
+		spec.Address = ""
+	})
+
+	warnings, err := validator.ValidateDelete(context.Background(), invalid)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+}
+
+func TestPingoraConfigValidator_WrongType(t *testing.T) {
+	t.Parallel()
+
+	validator := newValidator(t)
+
+	_, err := validator.ValidateCreate(context.Background(), &corev1.Secret{})
+	require.Error(t, err)
+}
@@ -0,0 +1,335 @@
+package webhook
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/ingress"
+)
+
+// DefaultMaxMatchesPerRule is the default limit of matches in a single route rule.
+const DefaultMaxMatchesPerRule = 32
+
+const kindGateway = "Gateway"
+
+// +kubebuilder:webhook:path=/validate-gateway-networking-k8s-io-v1-httproute,mutating=false,failurePolicy=ignore,sideEffects=None,groups=gateway.networking.k8s.io,resources=httproutes,verbs=create;update,versions=v1,name=vhttproute.pingora.k8s.lex.la,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-gateway-networking-k8s-io-v1-grpcroute,mutating=false,failurePolicy=ignore,sideEffects=None,groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=create;update,versions=v1,name=vgrpcroute.pingora.k8s.lex.la,admissionReviewVersions=v1
+
+// RouteValidator checks HTTPRoutes and GRPCRoutes for features the proxy
+// cannot program.
+//
+// Routes that cannot be translated at all, such as routes with invalid regular
+// expressions or too many matches in a rule, are rejected. Features that are
+// silently dropped during translation, such as filters, produce warnings.
+// Only routes attached to a Gateway of GatewayClassName are checked, so routes
+// of other implementations are never affected.
+type RouteValidator struct {
+	Client client.Reader
+
+	// GatewayClassName selects the routes to validate.
+	GatewayClassName string
+
+	// MaxMatchesPerRule limits the matches of a single rule. Zero disables the limit.
+	MaxMatchesPerRule int
+}
+
+var _ admission.CustomValidator = (*RouteValidator)(nil)
+
+// SetupRouteWebhooks registers the HTTPRoute and GRPCRoute validating webhooks with the manager.
+func SetupRouteWebhooks(mgr ctrl.Manager, validator *RouteValidator) error {
+	err := ctrl.NewWebhookManagedBy(mgr).
+		For(&gatewayv1.HTTPRoute{}).
+		WithValidator(validator).
+		Complete()
+	if err != nil {
+		return errors.Wrap(err, "failed to setup httproute webhook")
+	}
+
+	err = ctrl.NewWebhookManagedBy(mgr).
+		For(&gatewayv1.GRPCRoute{}).
+		WithValidator(validator).
+		Complete()
+	if err != nil {
+		return errors.Wrap(err, "failed to setup grpcroute webhook")
+	}
+
+	return nil
+}
+
+// ValidateCreate validates a new route.
+func (v *RouteValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate validates an updated route.
+func (v *RouteValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete allows all deletions.
+func (v *RouteValidator) ValidateDelete(context.Context, runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *RouteValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	var (
+		route    client.Object
+		parents  []gatewayv1.ParentReference
+		kind     string
+		warnings admission.Warnings
+		errs     field.ErrorList
+	)
+
+	switch typed := obj.(type) {
+	case *gatewayv1.HTTPRoute:
+		route, parents, kind = typed, typed.Spec.ParentRefs, "HTTPRoute"
+	case *gatewayv1.GRPCRoute:
+		route, parents, kind = typed, typed.Spec.ParentRefs, "GRPCRoute"
+	default:
+		//nolint:wrapcheck // Newf creates new error, not wrapping
+		return nil, errors.Newf("expected an HTTPRoute or GRPCRoute, got %T", obj)
+	}
+
+	attached, err := v.attachedToClass(ctx, route.GetNamespace(), parents)
+	if err != nil {
+		return nil, err
+	}
+
+	if !attached {
+		return nil, nil
+	}
+
+	switch typed := obj.(type) {
+	case *gatewayv1.HTTPRoute:
+		warnings, errs = v.validateHTTPRoute(typed)
+	case *gatewayv1.GRPCRoute:
+		warnings, errs = v.validateGRPCRoute(typed)
+	}
+
+	if len(errs) > 0 {
+		return warnings, apierrors.NewInvalid(
+			schema.GroupKind{Group: gatewayv1.GroupName, Kind: kind},
+			route.GetName(),
+			errs,
+		)
+	}
+
+	return warnings, nil
+}
+
+// attachedToClass reports whether any parentRef points to a Gateway of our class.
+// Gateways that do not exist yet are skipped.
+func (v *RouteValidator) attachedToClass(
+	ctx context.Context,
+	routeNamespace string,
+	parents []gatewayv1.ParentReference,
+) (bool, error) {
+	for _, ref := range parents {
+		if ref.Kind != nil && *ref.Kind != kindGateway {
+			continue
+		}
+
+		namespace := routeNamespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		var gateway gatewayv1.Gateway
+
+		err := v.Client.Get(ctx, client.ObjectKey{Name: string(ref.Name), Namespace: namespace}, &gateway)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return false, errors.Wrap(err, "failed to get parent gateway")
+		}
+
+		if string(gateway.Spec.GatewayClassName) == v.GatewayClassName {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (v *RouteValidator) validateHTTPRoute(route *gatewayv1.HTTPRoute) (admission.Warnings, field.ErrorList) {
+	var (
+		warnings admission.Warnings
+		errs     field.ErrorList
+	)
+
+	rulesPath := field.NewPath("spec", "rules")
+
+	for i := range route.Spec.Rules {
+		rule := &route.Spec.Rules[i]
+		rulePath := rulesPath.Index(i)
+
+		errs = append(errs, v.validateMatchCount(len(rule.Matches), rulePath.Child("matches"))...)
+
+		for j := range rule.Matches {
+			errs = append(errs, validateHTTPRouteMatch(&rule.Matches[j], rulePath.Child("matches").Index(j))...)
+		}
+
+		for j := range rule.Filters {
+			if !ingress.IsSupportedHTTPFilter(&rule.Filters[j]) {
+				warnings = append(warnings, unsupportedFilter(rulePath.Child("filters").Index(j), string(rule.Filters[j].Type)))
+			}
+		}
+
+		for j := range rule.BackendRefs {
+			for k := range rule.BackendRefs[j].Filters {
+				if !ingress.IsSupportedHTTPBackendFilter(&rule.BackendRefs[j].Filters[k]) {
+					path := rulePath.Child("backendRefs").Index(j).Child("filters").Index(k)
+					warnings = append(warnings, unsupportedFilter(path, string(rule.BackendRefs[j].Filters[k].Type)))
+				}
+			}
+		}
+
+		if rule.Timeouts != nil {
+			errs = append(errs, validateTimeouts(rule.Timeouts, rulePath.Child("timeouts"))...)
+		}
+	}
+
+	return warnings, errs
+}
+
+func (v *RouteValidator) validateGRPCRoute(route *gatewayv1.GRPCRoute) (admission.Warnings, field.ErrorList) {
+	var (
+		warnings admission.Warnings
+		errs     field.ErrorList
+	)
+
+	rulesPath := field.NewPath("spec", "rules")
+
+	for i := range route.Spec.Rules {
+		rule := &route.Spec.Rules[i]
+		rulePath := rulesPath.Index(i)
+
+		errs = append(errs, v.validateMatchCount(len(rule.Matches), rulePath.Child("matches"))...)
+
+		for j := range rule.Matches {
+			errs = append(errs, validateGRPCRouteMatch(&rule.Matches[j], rulePath.Child("matches").Index(j))...)
+		}
+
+		for j := range rule.Filters {
+			if !ingress.IsSupportedGRPCFilter(&rule.Filters[j]) {
+				warnings = append(warnings, unsupportedFilter(rulePath.Child("filters").Index(j), string(rule.Filters[j].Type)))
+			}
+		}
+
+		for j := range rule.BackendRefs {
+			for k := range rule.BackendRefs[j].Filters {
+				if !ingress.IsSupportedGRPCBackendFilter(&rule.BackendRefs[j].Filters[k]) {
+					path := rulePath.Child("backendRefs").Index(j).Child("filters").Index(k)
+					warnings = append(warnings, unsupportedFilter(path, string(rule.BackendRefs[j].Filters[k].Type)))
+				}
+			}
+		}
+	}
+
+	return warnings, errs
+}
+
+func (v *RouteValidator) validateMatchCount(count int, path *field.Path) field.ErrorList {
+	if v.MaxMatchesPerRule > 0 && count > v.MaxMatchesPerRule {
+		return field.ErrorList{field.TooMany(path, count, v.MaxMatchesPerRule)}
+	}
+
+	return nil
+}
+
+func validateHTTPRouteMatch(match *gatewayv1.HTTPRouteMatch, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if match.Path != nil && match.Path.Type != nil && match.Path.Value != nil &&
+		*match.Path.Type == gatewayv1.PathMatchRegularExpression {
+		errs = append(errs, validateRegex(*match.Path.Value, path.Child("path", "value"))...)
+	}
+
+	for i, header := range match.Headers {
+		if header.Type != nil && *header.Type == gatewayv1.HeaderMatchRegularExpression {
+			errs = append(errs, validateRegex(header.Value, path.Child("headers").Index(i).Child("value"))...)
+		}
+	}
+
+	for i, param := range match.QueryParams {
+		if param.Type != nil && *param.Type == gatewayv1.QueryParamMatchRegularExpression {
+			errs = append(errs, validateRegex(param.Value, path.Child("queryParams").Index(i).Child("value"))...)
+		}
+	}
+
+	return errs
+}
+
+func validateGRPCRouteMatch(match *gatewayv1.GRPCRouteMatch, path *field.Path) field.ErrorList {
+	var errs field.ErrorList
+
+	if method := match.Method; method != nil && method.Type != nil &&
+		*method.Type == gatewayv1.GRPCMethodMatchRegularExpression {
+		methodPath := path.Child("method")
+
+		if method.Service != nil {
+			errs = append(errs, validateRegex(*method.Service, methodPath.Child("service"))...)
+		}
+
+		if method.Method != nil {
+			errs = append(errs, validateRegex(*method.Method, methodPath.Child("method"))...)
+		}
+	}
+
+	for i, header := range match.Headers {
+		if header.Type != nil && *header.Type == gatewayv1.GRPCHeaderMatchRegularExpression {
+			errs = append(errs, validateRegex(header.Value, path.Child("headers").Index(i).Child("value"))...)
+		}
+	}
+
+	return errs
+}
+
+// validateTimeouts rejects a backendRequest timeout longer than the request
+// timeout. A zero request timeout disables it and allows any backendRequest.
+func validateTimeouts(timeouts *gatewayv1.HTTPRouteTimeouts, path *field.Path) field.ErrorList {
+	if timeouts.Request == nil || timeouts.BackendRequest == nil {
+		return nil
+	}
+
+	request, requestErr := time.ParseDuration(string(*timeouts.Request))
+	backendRequest, backendErr := time.ParseDuration(string(*timeouts.BackendRequest))
+
+	// Malformed durations are rejected by the CRD schema
+	if requestErr != nil || backendErr != nil || request == 0 || backendRequest <= request {
+		return nil
+	}
+
+	return field.ErrorList{field.Invalid(path.Child("backendRequest"), string(*timeouts.BackendRequest),
+		"must not be longer than the request timeout "+string(*timeouts.Request))}
+}
+
+// validateRegex checks a regular expression with the RE2 syntax, which the
+// proxy's regex engine also accepts. Constructs outside RE2, such as
+// backreferences and lookaround, are rejected by both.
+func validateRegex(expr string, path *field.Path) field.ErrorList {
+	if _, err := regexp.Compile(expr); err != nil {
+		return field.ErrorList{field.Invalid(path, expr, "invalid regular expression: "+err.Error())}
+	}
+
+	return nil
+}
+
+func unsupportedFilter(path *field.Path, filterType string) string {
+	return path.String() + ": filter type " + strconv.Quote(filterType) +
+		" is not supported by the Pingora proxy and is ignored"
+}
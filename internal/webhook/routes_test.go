@@ -0,0 +1,340 @@
+package webhook_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/lexfrei/pingora-gateway-controller/internal/webhook"
+)
+
+const routeNamespace = "team-a"
+
+func newRouteValidator(t *testing.T) *webhook.RouteValidator {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, gatewayv1.Install(scheme))
+
+	gateways := []*gatewayv1.Gateway{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pingora", Namespace: routeNamespace},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "pingora"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: routeNamespace},
+			Spec:       gatewayv1.GatewaySpec{GatewayClassName: "other-class"},
+		},
+	}
+
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, gateway := range gateways {
+		builder = builder.WithObjects(gateway)
+	}
+
+	return &webhook.RouteValidator{
+		Client:            builder.Build(),
+		GatewayClassName:  "pingora",
+		MaxMatchesPerRule: 2,
+	}
+}
+
+func newHTTPRoute(gateway string, rules ...gatewayv1.HTTPRouteRule) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: routeNamespace},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gateway)}},
+			},
+			Rules: rules,
+		},
+	}
+}
+
+func newGRPCRoute(gateway string, rules ...gatewayv1.GRPCRouteRule) *gatewayv1.GRPCRoute {
+	return &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: routeNamespace},
+		Spec: gatewayv1.GRPCRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gateway)}},
+			},
+			Rules: rules,
+		},
+	}
+}
+
+func regexPathMatch(value string) gatewayv1.HTTPRouteMatch {
+	pathType := gatewayv1.PathMatchRegularExpression
+
+	return gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}}
+}
+
+func prefixPathMatch(value string) gatewayv1.HTTPRouteMatch {
+	pathType := gatewayv1.PathMatchPathPrefix
+
+	return gatewayv1.HTTPRouteMatch{Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &value}}
+}
+
+func TestRouteValidator_HTTPRoute(t *testing.T) {
+	t.Parallel()
+
+	headerRegex := gatewayv1.HeaderMatchRegularExpression
+	queryRegex := gatewayv1.QueryParamMatchRegularExpression
+	backendTimeout := gatewayv1.Duration("5s")
+	requestTimeout := gatewayv1.Duration("10s")
+	shortTimeout := gatewayv1.Duration("2s")
+	disabledTimeout := gatewayv1.Duration("0s")
+
+	tests := []struct {
+		name         string
+		route        *gatewayv1.HTTPRoute
+		wantField    string
+		wantWarnings int
+	}{
+		{
+			name:  "supported route",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{regexPathMatch("^/api/v[0-9]+")}}),
+		},
+		{
+			name:      "invalid path regex",
+			route:     newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{regexPathMatch("/api/(")}}),
+			wantField: "spec.rules[0].matches[0].path.value",
+		},
+		{
+			name: "invalid header regex",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{{
+				Headers: []gatewayv1.HTTPHeaderMatch{{Type: &headerRegex, Name: "x-version", Value: "v[1-"}},
+			}}}),
+			wantField: "spec.rules[0].matches[0].headers[0].value",
+		},
+		{
+			name: "backreference in query param regex",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{{
+				QueryParams: []gatewayv1.HTTPQueryParamMatch{{Type: &queryRegex, Name: "id", Value: `(a)\1`}},
+			}}}),
+			wantField: "spec.rules[0].matches[0].queryParams[0].value",
+		},
+		{
+			name: "too many matches",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{
+				prefixPathMatch("/a"), prefixPathMatch("/b"), prefixPathMatch("/c"),
+			}}),
+			wantField: "spec.rules[0].matches",
+		},
+		{
+			name: "filters are ignored with warnings",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{
+				Filters: []gatewayv1.HTTPRouteFilter{{Type: gatewayv1.HTTPRouteFilterRequestRedirect}},
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					Filters: []gatewayv1.HTTPRouteFilter{{Type: gatewayv1.HTTPRouteFilterRequestHeaderModifier}},
+				}},
+			}),
+			wantWarnings: 2,
+		},
+		{
+			name: "backendRef header modifiers are supported",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					Filters: []gatewayv1.HTTPRouteFilter{
+						{
+							Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+							RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Remove: []string{"x-debug"}},
+						},
+						{
+							Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+							ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{Remove: []string{"server"}},
+						},
+						{Type: gatewayv1.HTTPRouteFilterURLRewrite},
+					},
+				}},
+			}),
+			wantWarnings: 1,
+		},
+		{
+			name: "cors filters are supported",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{
+				Filters: []gatewayv1.HTTPRouteFilter{
+					{Type: gatewayv1.HTTPRouteFilterCORS, CORS: &gatewayv1.HTTPCORSFilter{}},
+					{Type: gatewayv1.HTTPRouteFilterExtensionRef, ExtensionRef: &gatewayv1.LocalObjectReference{
+						Group: "pingora.k8s.lex.la", Kind: "PingoraCORSPolicy", Name: "cors",
+					}},
+					{Type: gatewayv1.HTTPRouteFilterExtensionRef, ExtensionRef: &gatewayv1.LocalObjectReference{
+						Group: "example.com", Kind: "Other", Name: "other",
+					}},
+				},
+			}),
+			wantWarnings: 1,
+		},
+		{
+			name: "backend request timeout alone",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{
+				Timeouts: &gatewayv1.HTTPRouteTimeouts{BackendRequest: &backendTimeout},
+			}),
+		},
+		{
+			name: "backend request timeout within request timeout",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{
+				Timeouts: &gatewayv1.HTTPRouteTimeouts{Request: &requestTimeout, BackendRequest: &backendTimeout},
+			}),
+		},
+		{
+			name: "backend request timeout with disabled request timeout",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{
+				Timeouts: &gatewayv1.HTTPRouteTimeouts{Request: &disabledTimeout, BackendRequest: &backendTimeout},
+			}),
+		},
+		{
+			name: "backend request timeout longer than request timeout",
+			route: newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{
+				Timeouts: &gatewayv1.HTTPRouteTimeouts{Request: &shortTimeout, BackendRequest: &backendTimeout},
+			}),
+			wantField: "spec.rules[0].timeouts.backendRequest",
+		},
+		{
+			name:  "route of another class is not checked",
+			route: newHTTPRoute("other", gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{regexPathMatch("/api/(")}}),
+		},
+		{
+			name:  "route of a missing gateway is not checked",
+			route: newHTTPRoute("missing", gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{regexPathMatch("/api/(")}}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings, err := newRouteValidator(t).ValidateCreate(context.Background(), tt.route)
+			assert.Len(t, warnings, tt.wantWarnings)
+			assertInvalidField(t, err, tt.wantField)
+		})
+	}
+}
+
+func TestRouteValidator_GRPCRoute(t *testing.T) {
+	t.Parallel()
+
+	methodRegex := gatewayv1.GRPCMethodMatchRegularExpression
+	headerRegex := gatewayv1.GRPCHeaderMatchRegularExpression
+	service := "helloworld\\.Greeter"
+	badMethod := "Say(Hello"
+
+	tests := []struct {
+		name         string
+		route        *gatewayv1.GRPCRoute
+		wantField    string
+		wantWarnings int
+	}{
+		{
+			name: "supported route",
+			route: newGRPCRoute("pingora", gatewayv1.GRPCRouteRule{Matches: []gatewayv1.GRPCRouteMatch{{
+				Method: &gatewayv1.GRPCMethodMatch{Type: &methodRegex, Service: &service},
+			}}}),
+		},
+		{
+			name: "invalid method regex",
+			route: newGRPCRoute("pingora", gatewayv1.GRPCRouteRule{Matches: []gatewayv1.GRPCRouteMatch{{
+				Method: &gatewayv1.GRPCMethodMatch{Type: &methodRegex, Service: &service, Method: &badMethod},
+			}}}),
+			wantField: "spec.rules[0].matches[0].method.method",
+		},
+		{
+			name: "invalid header regex",
+			route: newGRPCRoute("pingora", gatewayv1.GRPCRouteRule{Matches: []gatewayv1.GRPCRouteMatch{{
+				Headers: []gatewayv1.GRPCHeaderMatch{{Type: &headerRegex, Name: "x-tenant", Value: "*"}},
+			}}}),
+			wantField: "spec.rules[0].matches[0].headers[0].value",
+		},
+		{
+			name: "filters are ignored with warnings",
+			route: newGRPCRoute("pingora", gatewayv1.GRPCRouteRule{
+				Filters: []gatewayv1.GRPCRouteFilter{{Type: gatewayv1.GRPCRouteFilterExtensionRef}},
+				BackendRefs: []gatewayv1.GRPCBackendRef{{
+					Filters: []gatewayv1.GRPCRouteFilter{{
+						Type:          gatewayv1.GRPCRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{},
+					}},
+				}},
+			}),
+			wantWarnings: 2,
+		},
+		{
+			name: "header modifier and mirror filters are supported",
+			route: newGRPCRoute("pingora", gatewayv1.GRPCRouteRule{
+				Filters: []gatewayv1.GRPCRouteFilter{
+					{
+						Type:                  gatewayv1.GRPCRouteFilterRequestHeaderModifier,
+						RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Remove: []string{"x-debug"}},
+					},
+					{
+						Type:          gatewayv1.GRPCRouteFilterRequestMirror,
+						RequestMirror: &gatewayv1.HTTPRequestMirrorFilter{},
+					},
+				},
+				BackendRefs: []gatewayv1.GRPCBackendRef{{
+					Filters: []gatewayv1.GRPCRouteFilter{{
+						Type:                   gatewayv1.GRPCRouteFilterResponseHeaderModifier,
+						ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{Remove: []string{"server"}},
+					}},
+				}},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			warnings, err := newRouteValidator(t).ValidateCreate(context.Background(), tt.route)
+			assert.Len(t, warnings, tt.wantWarnings)
+			assertInvalidField(t, err, tt.wantField)
+		})
+	}
+}
+
+func TestRouteValidator_NoMatchLimit(t *testing.T) {
+	t.Parallel()
+
+	validator := newRouteValidator(t)
+	validator.MaxMatchesPerRule = 0
+
+	route := newHTTPRoute("pingora", gatewayv1.HTTPRouteRule{Matches: []gatewayv1.HTTPRouteMatch{
+		prefixPathMatch("/a"), prefixPathMatch("/b"), prefixPathMatch("/c"),
+	}})
+
+	_, err := validator.ValidateUpdate(context.Background(), route, route)
+	require.NoError(t, err)
+}
+
+func TestRouteValidator_WrongType(t *testing.T) {
+	t.Parallel()
+
+	_, err := newRouteValidator(t).ValidateCreate(context.Background(), &gatewayv1.Gateway{})
+	require.Error(t, err)
+}
+
+func assertInvalidField(t *testing.T, err error, wantField string) {
+	t.Helper()
+
+	if wantField == "" {
+		require.NoError(t, err)
+
+		return
+	}
+
+	require.Error(t, err)
+	require.True(t, apierrors.IsInvalid(err))
+
+	var statusErr *apierrors.StatusError
+
+	require.ErrorAs(t, err, &statusErr)
+	require.NotEmpty(t, statusErr.ErrStatus.Details.Causes)
+	assert.Equal(t, wantField, statusErr.ErrStatus.Details.Causes[0].Field)
+}
@@ -26,6 +26,210 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// AccessLogFormat is the format of the access log of the proxy.
+type AccessLogFormat int32
+
+const (
+	AccessLogFormat_ACCESS_LOG_FORMAT_UNSPECIFIED AccessLogFormat = 0
+	AccessLogFormat_ACCESS_LOG_FORMAT_TEXT        AccessLogFormat = 1
+	AccessLogFormat_ACCESS_LOG_FORMAT_JSON        AccessLogFormat = 2
+	AccessLogFormat_ACCESS_LOG_FORMAT_DISABLED    AccessLogFormat = 3
+)
+
+// Enum value maps for AccessLogFormat.
+var (
+	AccessLogFormat_name = map[int32]string{
+		0: "ACCESS_LOG_FORMAT_UNSPECIFIED",
+		1: "ACCESS_LOG_FORMAT_TEXT",
+		2: "ACCESS_LOG_FORMAT_JSON",
+		3: "ACCESS_LOG_FORMAT_DISABLED",
+	}
+	AccessLogFormat_value = map[string]int32{
+		"ACCESS_LOG_FORMAT_UNSPECIFIED": 0,
+		"ACCESS_LOG_FORMAT_TEXT":        1,
+		"ACCESS_LOG_FORMAT_JSON":        2,
+		"ACCESS_LOG_FORMAT_DISABLED":    3,
+	}
+)
+
+func (x AccessLogFormat) Enum() *AccessLogFormat {
+	p := new(AccessLogFormat)
+	*p = x
+	return p
+}
+
+func (x AccessLogFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccessLogFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[0].Descriptor()
+}
+
+func (AccessLogFormat) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[0]
+}
+
+func (x AccessLogFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccessLogFormat.Descriptor instead.
+func (AccessLogFormat) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{0}
+}
+
+// ClientValidationMode is what happens to clients without a valid
+// certificate.
+type ClientValidationMode int32
+
+const (
+	// Their handshakes fail.
+	ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY ClientValidationMode = 0
+	// They are accepted, leaving authorization to the backends.
+	ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_INSECURE_FALLBACK ClientValidationMode = 1
+)
+
+// Enum value maps for ClientValidationMode.
+var (
+	ClientValidationMode_name = map[int32]string{
+		0: "CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY",
+		1: "CLIENT_VALIDATION_MODE_ALLOW_INSECURE_FALLBACK",
+	}
+	ClientValidationMode_value = map[string]int32{
+		"CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY":        0,
+		"CLIENT_VALIDATION_MODE_ALLOW_INSECURE_FALLBACK": 1,
+	}
+)
+
+func (x ClientValidationMode) Enum() *ClientValidationMode {
+	p := new(ClientValidationMode)
+	*p = x
+	return p
+}
+
+func (x ClientValidationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ClientValidationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[1].Descriptor()
+}
+
+func (ClientValidationMode) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[1]
+}
+
+func (x ClientValidationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ClientValidationMode.Descriptor instead.
+func (ClientValidationMode) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{1}
+}
+
+// CertificateSelection is the order in which the certificates of a port
+// are matched against the server name of a client.
+type CertificateSelection int32
+
+const (
+	// Exact hostnames before wildcards, and longer wildcards before shorter
+	// ones.
+	CertificateSelection_CERTIFICATE_SELECTION_MOST_SPECIFIC CertificateSelection = 0
+	// The first certificate that matches, in listener order.
+	CertificateSelection_CERTIFICATE_SELECTION_LISTENER_ORDER CertificateSelection = 1
+)
+
+// Enum value maps for CertificateSelection.
+var (
+	CertificateSelection_name = map[int32]string{
+		0: "CERTIFICATE_SELECTION_MOST_SPECIFIC",
+		1: "CERTIFICATE_SELECTION_LISTENER_ORDER",
+	}
+	CertificateSelection_value = map[string]int32{
+		"CERTIFICATE_SELECTION_MOST_SPECIFIC":  0,
+		"CERTIFICATE_SELECTION_LISTENER_ORDER": 1,
+	}
+)
+
+func (x CertificateSelection) Enum() *CertificateSelection {
+	p := new(CertificateSelection)
+	*p = x
+	return p
+}
+
+func (x CertificateSelection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CertificateSelection) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[2].Descriptor()
+}
+
+func (CertificateSelection) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[2]
+}
+
+func (x CertificateSelection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CertificateSelection.Descriptor instead.
+func (CertificateSelection) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{2}
+}
+
+// ProxyProtocolVersion is a version of the HAProxy PROXY protocol.
+type ProxyProtocolVersion int32
+
+const (
+	ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_UNSPECIFIED ProxyProtocolVersion = 0
+	ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V1          ProxyProtocolVersion = 1
+	ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_V2          ProxyProtocolVersion = 2
+)
+
+// Enum value maps for ProxyProtocolVersion.
+var (
+	ProxyProtocolVersion_name = map[int32]string{
+		0: "PROXY_PROTOCOL_VERSION_UNSPECIFIED",
+		1: "PROXY_PROTOCOL_VERSION_V1",
+		2: "PROXY_PROTOCOL_VERSION_V2",
+	}
+	ProxyProtocolVersion_value = map[string]int32{
+		"PROXY_PROTOCOL_VERSION_UNSPECIFIED": 0,
+		"PROXY_PROTOCOL_VERSION_V1":          1,
+		"PROXY_PROTOCOL_VERSION_V2":          2,
+	}
+)
+
+func (x ProxyProtocolVersion) Enum() *ProxyProtocolVersion {
+	p := new(ProxyProtocolVersion)
+	*p = x
+	return p
+}
+
+func (x ProxyProtocolVersion) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProxyProtocolVersion) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[3].Descriptor()
+}
+
+func (ProxyProtocolVersion) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[3]
+}
+
+func (x ProxyProtocolVersion) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProxyProtocolVersion.Descriptor instead.
+func (ProxyProtocolVersion) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{3}
+}
+
 // PathMatchType defines the type of path matching.
 type PathMatchType int32
 
@@ -63,11 +267,11 @@ func (x PathMatchType) String() string {
 }
 
 func (PathMatchType) Descriptor() protoreflect.EnumDescriptor {
-	return file_routing_v1_routing_proto_enumTypes[0].Descriptor()
+	return file_routing_v1_routing_proto_enumTypes[4].Descriptor()
 }
 
 func (PathMatchType) Type() protoreflect.EnumType {
-	return &file_routing_v1_routing_proto_enumTypes[0]
+	return &file_routing_v1_routing_proto_enumTypes[4]
 }
 
 func (x PathMatchType) Number() protoreflect.EnumNumber {
@@ -76,7 +280,7 @@ func (x PathMatchType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PathMatchType.Descriptor instead.
 func (PathMatchType) EnumDescriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{0}
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{4}
 }
 
 // HeaderMatchType defines the type of header matching.
@@ -113,11 +317,11 @@ func (x HeaderMatchType) String() string {
 }
 
 func (HeaderMatchType) Descriptor() protoreflect.EnumDescriptor {
-	return file_routing_v1_routing_proto_enumTypes[1].Descriptor()
+	return file_routing_v1_routing_proto_enumTypes[5].Descriptor()
 }
 
 func (HeaderMatchType) Type() protoreflect.EnumType {
-	return &file_routing_v1_routing_proto_enumTypes[1]
+	return &file_routing_v1_routing_proto_enumTypes[5]
 }
 
 func (x HeaderMatchType) Number() protoreflect.EnumNumber {
@@ -126,7 +330,7 @@ func (x HeaderMatchType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use HeaderMatchType.Descriptor instead.
 func (HeaderMatchType) EnumDescriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{1}
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{5}
 }
 
 // QueryParamMatchType defines the type of query parameter matching.
@@ -163,11 +367,11 @@ func (x QueryParamMatchType) String() string {
 }
 
 func (QueryParamMatchType) Descriptor() protoreflect.EnumDescriptor {
-	return file_routing_v1_routing_proto_enumTypes[2].Descriptor()
+	return file_routing_v1_routing_proto_enumTypes[6].Descriptor()
 }
 
 func (QueryParamMatchType) Type() protoreflect.EnumType {
-	return &file_routing_v1_routing_proto_enumTypes[2]
+	return &file_routing_v1_routing_proto_enumTypes[6]
 }
 
 func (x QueryParamMatchType) Number() protoreflect.EnumNumber {
@@ -176,7 +380,7 @@ func (x QueryParamMatchType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use QueryParamMatchType.Descriptor instead.
 func (QueryParamMatchType) EnumDescriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{2}
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{6}
 }
 
 // GRPCMethodMatchType defines the type of gRPC method matching.
@@ -213,11 +417,11 @@ func (x GRPCMethodMatchType) String() string {
 }
 
 func (GRPCMethodMatchType) Descriptor() protoreflect.EnumDescriptor {
-	return file_routing_v1_routing_proto_enumTypes[3].Descriptor()
+	return file_routing_v1_routing_proto_enumTypes[7].Descriptor()
 }
 
 func (GRPCMethodMatchType) Type() protoreflect.EnumType {
-	return &file_routing_v1_routing_proto_enumTypes[3]
+	return &file_routing_v1_routing_proto_enumTypes[7]
 }
 
 func (x GRPCMethodMatchType) Number() protoreflect.EnumNumber {
@@ -226,7 +430,7 @@ func (x GRPCMethodMatchType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GRPCMethodMatchType.Descriptor instead.
 func (GRPCMethodMatchType) EnumDescriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{3}
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{7}
 }
 
 // BackendProtocol defines the protocol for backend connections.
@@ -269,11 +473,11 @@ func (x BackendProtocol) String() string {
 }
 
 func (BackendProtocol) Descriptor() protoreflect.EnumDescriptor {
-	return file_routing_v1_routing_proto_enumTypes[4].Descriptor()
+	return file_routing_v1_routing_proto_enumTypes[8].Descriptor()
 }
 
 func (BackendProtocol) Type() protoreflect.EnumType {
-	return &file_routing_v1_routing_proto_enumTypes[4]
+	return &file_routing_v1_routing_proto_enumTypes[8]
 }
 
 func (x BackendProtocol) Number() protoreflect.EnumNumber {
@@ -282,123 +486,453 @@ func (x BackendProtocol) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BackendProtocol.Descriptor instead.
 func (BackendProtocol) EnumDescriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{4}
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{8}
 }
 
-// UpdateRoutesRequest contains the complete routing configuration.
-type UpdateRoutesRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// List of all HTTP routes to configure.
-	HttpRoutes []*HTTPRoute `protobuf:"bytes,1,rep,name=http_routes,json=httpRoutes,proto3" json:"http_routes,omitempty"`
-	// List of all gRPC routes to configure.
-	GrpcRoutes []*GRPCRoute `protobuf:"bytes,2,rep,name=grpc_routes,json=grpcRoutes,proto3" json:"grpc_routes,omitempty"`
-	// Configuration version for tracking updates.
-	// Monotonically increasing, used for optimistic concurrency.
-	Version       uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// RateLimitKeyType selects what requests are grouped by when counted.
+type RateLimitKeyType int32
+
+const (
+	RateLimitKeyType_RATE_LIMIT_KEY_TYPE_UNSPECIFIED RateLimitKeyType = 0
+	RateLimitKeyType_RATE_LIMIT_KEY_TYPE_CLIENT_IP   RateLimitKeyType = 1
+	RateLimitKeyType_RATE_LIMIT_KEY_TYPE_HEADER      RateLimitKeyType = 2
+)
+
+// Enum value maps for RateLimitKeyType.
+var (
+	RateLimitKeyType_name = map[int32]string{
+		0: "RATE_LIMIT_KEY_TYPE_UNSPECIFIED",
+		1: "RATE_LIMIT_KEY_TYPE_CLIENT_IP",
+		2: "RATE_LIMIT_KEY_TYPE_HEADER",
+	}
+	RateLimitKeyType_value = map[string]int32{
+		"RATE_LIMIT_KEY_TYPE_UNSPECIFIED": 0,
+		"RATE_LIMIT_KEY_TYPE_CLIENT_IP":   1,
+		"RATE_LIMIT_KEY_TYPE_HEADER":      2,
+	}
+)
+
+func (x RateLimitKeyType) Enum() *RateLimitKeyType {
+	p := new(RateLimitKeyType)
+	*p = x
+	return p
 }
 
-func (x *UpdateRoutesRequest) Reset() {
-	*x = UpdateRoutesRequest{}
-	mi := &file_routing_v1_routing_proto_msgTypes[0]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x RateLimitKeyType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *UpdateRoutesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (RateLimitKeyType) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[9].Descriptor()
 }
 
-func (*UpdateRoutesRequest) ProtoMessage() {}
+func (RateLimitKeyType) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[9]
+}
 
-func (x *UpdateRoutesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[0]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (x RateLimitKeyType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-// Deprecated: Use UpdateRoutesRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRoutesRequest) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use RateLimitKeyType.Descriptor instead.
+func (RateLimitKeyType) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *UpdateRoutesRequest) GetHttpRoutes() []*HTTPRoute {
-	if x != nil {
-		return x.HttpRoutes
+// ExternalAuthProtocol is the protocol spoken with an external auth service.
+type ExternalAuthProtocol int32
+
+const (
+	ExternalAuthProtocol_EXTERNAL_AUTH_PROTOCOL_UNSPECIFIED ExternalAuthProtocol = 0
+	// Check method of envoy.service.auth.v3.Authorization.
+	ExternalAuthProtocol_EXTERNAL_AUTH_PROTOCOL_GRPC ExternalAuthProtocol = 1
+	// HTTP request with the original method, path and headers; a 2xx
+	// response allows the request.
+	ExternalAuthProtocol_EXTERNAL_AUTH_PROTOCOL_HTTP ExternalAuthProtocol = 2
+)
+
+// Enum value maps for ExternalAuthProtocol.
+var (
+	ExternalAuthProtocol_name = map[int32]string{
+		0: "EXTERNAL_AUTH_PROTOCOL_UNSPECIFIED",
+		1: "EXTERNAL_AUTH_PROTOCOL_GRPC",
+		2: "EXTERNAL_AUTH_PROTOCOL_HTTP",
 	}
-	return nil
+	ExternalAuthProtocol_value = map[string]int32{
+		"EXTERNAL_AUTH_PROTOCOL_UNSPECIFIED": 0,
+		"EXTERNAL_AUTH_PROTOCOL_GRPC":        1,
+		"EXTERNAL_AUTH_PROTOCOL_HTTP":        2,
+	}
+)
+
+func (x ExternalAuthProtocol) Enum() *ExternalAuthProtocol {
+	p := new(ExternalAuthProtocol)
+	*p = x
+	return p
 }
 
-func (x *UpdateRoutesRequest) GetGrpcRoutes() []*GRPCRoute {
-	if x != nil {
-		return x.GrpcRoutes
-	}
-	return nil
+func (x ExternalAuthProtocol) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *UpdateRoutesRequest) GetVersion() uint64 {
-	if x != nil {
-		return x.Version
-	}
-	return 0
+func (ExternalAuthProtocol) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[10].Descriptor()
 }
 
-// UpdateRoutesResponse confirms the route update.
-type UpdateRoutesResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Whether the update was successful.
-	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	// Error message if success is false.
-	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	// The version that was applied.
-	AppliedVersion uint64 `protobuf:"varint,3,opt,name=applied_version,json=appliedVersion,proto3" json:"applied_version,omitempty"`
-	// Number of HTTP routes configured.
-	HttpRouteCount uint32 `protobuf:"varint,4,opt,name=http_route_count,json=httpRouteCount,proto3" json:"http_route_count,omitempty"`
-	// Number of gRPC routes configured.
-	GrpcRouteCount uint32 `protobuf:"varint,5,opt,name=grpc_route_count,json=grpcRouteCount,proto3" json:"grpc_route_count,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (ExternalAuthProtocol) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[10]
 }
 
-func (x *UpdateRoutesResponse) Reset() {
-	*x = UpdateRoutesResponse{}
-	mi := &file_routing_v1_routing_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x ExternalAuthProtocol) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *UpdateRoutesResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+// Deprecated: Use ExternalAuthProtocol.Descriptor instead.
+func (ExternalAuthProtocol) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{10}
 }
 
-func (*UpdateRoutesResponse) ProtoMessage() {}
+// AccessAction is what happens to a request.
+type AccessAction int32
 
-func (x *UpdateRoutesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[1]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+const (
+	AccessAction_ACCESS_ACTION_UNSPECIFIED AccessAction = 0
+	AccessAction_ACCESS_ACTION_ALLOW       AccessAction = 1
+	AccessAction_ACCESS_ACTION_DENY        AccessAction = 2
+)
+
+// Enum value maps for AccessAction.
+var (
+	AccessAction_name = map[int32]string{
+		0: "ACCESS_ACTION_UNSPECIFIED",
+		1: "ACCESS_ACTION_ALLOW",
+		2: "ACCESS_ACTION_DENY",
 	}
-	return mi.MessageOf(x)
+	AccessAction_value = map[string]int32{
+		"ACCESS_ACTION_UNSPECIFIED": 0,
+		"ACCESS_ACTION_ALLOW":       1,
+		"ACCESS_ACTION_DENY":        2,
+	}
+)
+
+func (x AccessAction) Enum() *AccessAction {
+	p := new(AccessAction)
+	*p = x
+	return p
 }
 
-// Deprecated: Use UpdateRoutesResponse.ProtoReflect.Descriptor instead.
-func (*UpdateRoutesResponse) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{1}
+func (x AccessAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *UpdateRoutesResponse) GetSuccess() bool {
-	if x != nil {
+func (AccessAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[11].Descriptor()
+}
+
+func (AccessAction) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[11]
+}
+
+func (x AccessAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccessAction.Descriptor instead.
+func (AccessAction) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{11}
+}
+
+// CacheBypassType is the part of a request that a bypass rule matches.
+type CacheBypassType int32
+
+const (
+	CacheBypassType_CACHE_BYPASS_TYPE_UNSPECIFIED CacheBypassType = 0
+	CacheBypassType_CACHE_BYPASS_TYPE_HEADER      CacheBypassType = 1
+	CacheBypassType_CACHE_BYPASS_TYPE_COOKIE      CacheBypassType = 2
+)
+
+// Enum value maps for CacheBypassType.
+var (
+	CacheBypassType_name = map[int32]string{
+		0: "CACHE_BYPASS_TYPE_UNSPECIFIED",
+		1: "CACHE_BYPASS_TYPE_HEADER",
+		2: "CACHE_BYPASS_TYPE_COOKIE",
+	}
+	CacheBypassType_value = map[string]int32{
+		"CACHE_BYPASS_TYPE_UNSPECIFIED": 0,
+		"CACHE_BYPASS_TYPE_HEADER":      1,
+		"CACHE_BYPASS_TYPE_COOKIE":      2,
+	}
+)
+
+func (x CacheBypassType) Enum() *CacheBypassType {
+	p := new(CacheBypassType)
+	*p = x
+	return p
+}
+
+func (x CacheBypassType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CacheBypassType) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[12].Descriptor()
+}
+
+func (CacheBypassType) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[12]
+}
+
+func (x CacheBypassType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CacheBypassType.Descriptor instead.
+func (CacheBypassType) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{12}
+}
+
+// SessionPersistenceType specifies how the session token is carried.
+type SessionPersistenceType int32
+
+const (
+	SessionPersistenceType_SESSION_PERSISTENCE_TYPE_UNSPECIFIED SessionPersistenceType = 0
+	SessionPersistenceType_SESSION_PERSISTENCE_TYPE_COOKIE      SessionPersistenceType = 1
+	SessionPersistenceType_SESSION_PERSISTENCE_TYPE_HEADER      SessionPersistenceType = 2
+)
+
+// Enum value maps for SessionPersistenceType.
+var (
+	SessionPersistenceType_name = map[int32]string{
+		0: "SESSION_PERSISTENCE_TYPE_UNSPECIFIED",
+		1: "SESSION_PERSISTENCE_TYPE_COOKIE",
+		2: "SESSION_PERSISTENCE_TYPE_HEADER",
+	}
+	SessionPersistenceType_value = map[string]int32{
+		"SESSION_PERSISTENCE_TYPE_UNSPECIFIED": 0,
+		"SESSION_PERSISTENCE_TYPE_COOKIE":      1,
+		"SESSION_PERSISTENCE_TYPE_HEADER":      2,
+	}
+)
+
+func (x SessionPersistenceType) Enum() *SessionPersistenceType {
+	p := new(SessionPersistenceType)
+	*p = x
+	return p
+}
+
+func (x SessionPersistenceType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionPersistenceType) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[13].Descriptor()
+}
+
+func (SessionPersistenceType) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[13]
+}
+
+func (x SessionPersistenceType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionPersistenceType.Descriptor instead.
+func (SessionPersistenceType) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{13}
+}
+
+// CookieLifetimeType specifies the lifetime of a session cookie.
+type CookieLifetimeType int32
+
+const (
+	CookieLifetimeType_COOKIE_LIFETIME_TYPE_UNSPECIFIED CookieLifetimeType = 0
+	// Session cookie without Expires or Max-Age attributes.
+	CookieLifetimeType_COOKIE_LIFETIME_TYPE_SESSION CookieLifetimeType = 1
+	// Permanent cookie expiring after absolute_timeout_ms.
+	CookieLifetimeType_COOKIE_LIFETIME_TYPE_PERMANENT CookieLifetimeType = 2
+)
+
+// Enum value maps for CookieLifetimeType.
+var (
+	CookieLifetimeType_name = map[int32]string{
+		0: "COOKIE_LIFETIME_TYPE_UNSPECIFIED",
+		1: "COOKIE_LIFETIME_TYPE_SESSION",
+		2: "COOKIE_LIFETIME_TYPE_PERMANENT",
+	}
+	CookieLifetimeType_value = map[string]int32{
+		"COOKIE_LIFETIME_TYPE_UNSPECIFIED": 0,
+		"COOKIE_LIFETIME_TYPE_SESSION":     1,
+		"COOKIE_LIFETIME_TYPE_PERMANENT":   2,
+	}
+)
+
+func (x CookieLifetimeType) Enum() *CookieLifetimeType {
+	p := new(CookieLifetimeType)
+	*p = x
+	return p
+}
+
+func (x CookieLifetimeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CookieLifetimeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_routing_v1_routing_proto_enumTypes[14].Descriptor()
+}
+
+func (CookieLifetimeType) Type() protoreflect.EnumType {
+	return &file_routing_v1_routing_proto_enumTypes[14]
+}
+
+func (x CookieLifetimeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CookieLifetimeType.Descriptor instead.
+func (CookieLifetimeType) EnumDescriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{14}
+}
+
+// UpdateRoutesRequest contains the complete routing configuration.
+type UpdateRoutesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// List of all HTTP routes to configure.
+	HttpRoutes []*HTTPRoute `protobuf:"bytes,1,rep,name=http_routes,json=httpRoutes,proto3" json:"http_routes,omitempty"`
+	// List of all gRPC routes to configure.
+	GrpcRoutes []*GRPCRoute `protobuf:"bytes,2,rep,name=grpc_routes,json=grpcRoutes,proto3" json:"grpc_routes,omitempty"`
+	// Configuration version for tracking updates.
+	// Monotonically increasing, used for optimistic concurrency.
+	Version uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Settings of the listeners, matched by port. Listeners that are not
+	// listed use the proxy defaults.
+	Listeners []*Listener `protobuf:"bytes,4,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	// Version the proxy must have applied for the update to be applied.
+	// If the applied version differs, the proxy rejects the update with
+	// version_conflict set and its applied version in applied_version.
+	// Unset applies the update regardless of the applied version.
+	ExpectedVersion *uint64 `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRoutesRequest) Reset() {
+	*x = UpdateRoutesRequest{}
+	mi := &file_routing_v1_routing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoutesRequest) ProtoMessage() {}
+
+func (x *UpdateRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoutesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UpdateRoutesRequest) GetHttpRoutes() []*HTTPRoute {
+	if x != nil {
+		return x.HttpRoutes
+	}
+	return nil
+}
+
+func (x *UpdateRoutesRequest) GetGrpcRoutes() []*GRPCRoute {
+	if x != nil {
+		return x.GrpcRoutes
+	}
+	return nil
+}
+
+func (x *UpdateRoutesRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateRoutesRequest) GetListeners() []*Listener {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *UpdateRoutesRequest) GetExpectedVersion() uint64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+// UpdateRoutesResponse confirms the route update.
+type UpdateRoutesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the update was successful.
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Error message if success is false.
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// The version that was applied.
+	AppliedVersion uint64 `protobuf:"varint,3,opt,name=applied_version,json=appliedVersion,proto3" json:"applied_version,omitempty"`
+	// Number of HTTP routes configured.
+	HttpRouteCount uint32 `protobuf:"varint,4,opt,name=http_route_count,json=httpRouteCount,proto3" json:"http_route_count,omitempty"`
+	// Number of gRPC routes configured.
+	GrpcRouteCount uint32 `protobuf:"varint,5,opt,name=grpc_route_count,json=grpcRouteCount,proto3" json:"grpc_route_count,omitempty"`
+	// Whether the update was rejected because the applied version differs
+	// from its expected_version.
+	VersionConflict bool `protobuf:"varint,6,opt,name=version_conflict,json=versionConflict,proto3" json:"version_conflict,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateRoutesResponse) Reset() {
+	*x = UpdateRoutesResponse{}
+	mi := &file_routing_v1_routing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoutesResponse) ProtoMessage() {}
+
+func (x *UpdateRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoutesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateRoutesResponse) GetSuccess() bool {
+	if x != nil {
 		return x.Success
 	}
 	return false
@@ -432,6 +966,13 @@ func (x *UpdateRoutesResponse) GetGrpcRouteCount() uint32 {
 	return 0
 }
 
+func (x *UpdateRoutesResponse) GetVersionConflict() bool {
+	if x != nil {
+		return x.VersionConflict
+	}
+	return false
+}
+
 // GetRoutesRequest requests the current route configuration.
 type GetRoutesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -477,7 +1018,9 @@ type GetRoutesResponse struct {
 	// List of all gRPC routes.
 	GrpcRoutes []*GRPCRoute `protobuf:"bytes,2,rep,name=grpc_routes,json=grpcRoutes,proto3" json:"grpc_routes,omitempty"`
 	// Current configuration version.
-	Version       uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Version uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// Settings of the listeners.
+	Listeners     []*Listener `protobuf:"bytes,4,rep,name=listeners,proto3" json:"listeners,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -533,6 +1076,13 @@ func (x *GetRoutesResponse) GetVersion() uint64 {
 	return 0
 }
 
+func (x *GetRoutesResponse) GetListeners() []*Listener {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
 // HealthRequest requests health status.
 type HealthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -580,6 +1130,8 @@ type HealthResponse struct {
 	// Number of active connections.
 	ActiveConnections uint64 `protobuf:"varint,3,opt,name=active_connections,json=activeConnections,proto3" json:"active_connections,omitempty"`
 	// Current configuration version.
+	// Must be 0 until the first update is applied, so that the controller can
+	// detect a restarted proxy and resend the full configuration.
 	ConfigVersion uint64 `protobuf:"varint,4,opt,name=config_version,json=configVersion,proto3" json:"config_version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -643,33 +1195,27 @@ func (x *HealthResponse) GetConfigVersion() uint64 {
 	return 0
 }
 
-// HTTPRoute defines an HTTP routing rule.
-type HTTPRoute struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Unique identifier for this route (namespace/name).
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Hostnames this route matches.
-	Hostnames []string `protobuf:"bytes,2,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
-	// Routing rules for this HTTPRoute.
-	Rules         []*HTTPRouteRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+// GetBackendHealthRequest requests the health of backends.
+type GetBackendHealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HTTPRoute) Reset() {
-	*x = HTTPRoute{}
+func (x *GetBackendHealthRequest) Reset() {
+	*x = GetBackendHealthRequest{}
 	mi := &file_routing_v1_routing_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HTTPRoute) String() string {
+func (x *GetBackendHealthRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HTTPRoute) ProtoMessage() {}
+func (*GetBackendHealthRequest) ProtoMessage() {}
 
-func (x *HTTPRoute) ProtoReflect() protoreflect.Message {
+func (x *GetBackendHealthRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_routing_v1_routing_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -681,62 +1227,144 @@ func (x *HTTPRoute) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HTTPRoute.ProtoReflect.Descriptor instead.
-func (*HTTPRoute) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetBackendHealthRequest.ProtoReflect.Descriptor instead.
+func (*GetBackendHealthRequest) Descriptor() ([]byte, []int) {
 	return file_routing_v1_routing_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *HTTPRoute) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// GetBackendHealthResponse returns the health of backends.
+type GetBackendHealthResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Backends with active health checking, one per address.
+	Backends      []*BackendHealth `protobuf:"bytes,1,rep,name=backends,proto3" json:"backends,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HTTPRoute) GetHostnames() []string {
-	if x != nil {
-		return x.Hostnames
-	}
-	return nil
+func (x *GetBackendHealthResponse) Reset() {
+	*x = GetBackendHealthResponse{}
+	mi := &file_routing_v1_routing_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *HTTPRoute) GetRules() []*HTTPRouteRule {
-	if x != nil {
-		return x.Rules
-	}
-	return nil
+func (x *GetBackendHealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-// HTTPRouteRule defines a single HTTP routing rule.
-type HTTPRouteRule struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Matchers for this rule.
-	Matches []*HTTPRouteMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
-	// Backend references for this rule.
-	Backends []*Backend `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty"`
-	// Request timeout in milliseconds.
-	TimeoutMs uint64 `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
-	// Retry configuration.
-	Retry         *RetryConfig `protobuf:"bytes,4,opt,name=retry,proto3" json:"retry,omitempty"`
+func (*GetBackendHealthResponse) ProtoMessage() {}
+
+func (x *GetBackendHealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBackendHealthResponse.ProtoReflect.Descriptor instead.
+func (*GetBackendHealthResponse) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetBackendHealthResponse) GetBackends() []*BackendHealth {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+// BackendHealth is the health of the endpoints behind a backend address.
+type BackendHealth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Backend address (host:port), as sent in Backend.address.
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Number of endpoints that pass the health check.
+	HealthyEndpoints uint32 `protobuf:"varint,2,opt,name=healthy_endpoints,json=healthyEndpoints,proto3" json:"healthy_endpoints,omitempty"`
+	// Number of endpoints that fail the health check.
+	UnhealthyEndpoints uint32 `protobuf:"varint,3,opt,name=unhealthy_endpoints,json=unhealthyEndpoints,proto3" json:"unhealthy_endpoints,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *BackendHealth) Reset() {
+	*x = BackendHealth{}
+	mi := &file_routing_v1_routing_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackendHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackendHealth) ProtoMessage() {}
+
+func (x *BackendHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackendHealth.ProtoReflect.Descriptor instead.
+func (*BackendHealth) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BackendHealth) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *BackendHealth) GetHealthyEndpoints() uint32 {
+	if x != nil {
+		return x.HealthyEndpoints
+	}
+	return 0
+}
+
+func (x *BackendHealth) GetUnhealthyEndpoints() uint32 {
+	if x != nil {
+		return x.UnhealthyEndpoints
+	}
+	return 0
+}
+
+// GetRouteStatsRequest requests the traffic statistics of routes and
+// listeners.
+type GetRouteStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HTTPRouteRule) Reset() {
-	*x = HTTPRouteRule{}
-	mi := &file_routing_v1_routing_proto_msgTypes[7]
+func (x *GetRouteStatsRequest) Reset() {
+	*x = GetRouteStatsRequest{}
+	mi := &file_routing_v1_routing_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HTTPRouteRule) String() string {
+func (x *GetRouteStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HTTPRouteRule) ProtoMessage() {}
+func (*GetRouteStatsRequest) ProtoMessage() {}
 
-func (x *HTTPRouteRule) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[7]
+func (x *GetRouteStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -747,69 +1375,105 @@ func (x *HTTPRouteRule) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HTTPRouteRule.ProtoReflect.Descriptor instead.
-func (*HTTPRouteRule) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetRouteStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetRouteStatsRequest) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *HTTPRouteRule) GetMatches() []*HTTPRouteMatch {
+// GetRouteStatsResponse returns the traffic statistics of routes and
+// listeners. All counts are cumulative since the proxy started.
+type GetRouteStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Routes that served requests, by route ID.
+	Routes []*RouteStats `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+	// Listeners that accepted requests, by port.
+	Listeners []*ListenerStats `protobuf:"bytes,2,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	// Upper bounds of the request duration buckets in seconds, ascending.
+	// The same for all routes and listeners.
+	DurationBuckets []float64 `protobuf:"fixed64,3,rep,packed,name=duration_buckets,json=durationBuckets,proto3" json:"duration_buckets,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetRouteStatsResponse) Reset() {
+	*x = GetRouteStatsResponse{}
+	mi := &file_routing_v1_routing_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRouteStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRouteStatsResponse) ProtoMessage() {}
+
+func (x *GetRouteStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[10]
 	if x != nil {
-		return x.Matches
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *HTTPRouteRule) GetBackends() []*Backend {
+// Deprecated: Use GetRouteStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetRouteStatsResponse) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetRouteStatsResponse) GetRoutes() []*RouteStats {
 	if x != nil {
-		return x.Backends
+		return x.Routes
 	}
 	return nil
 }
 
-func (x *HTTPRouteRule) GetTimeoutMs() uint64 {
+func (x *GetRouteStatsResponse) GetListeners() []*ListenerStats {
 	if x != nil {
-		return x.TimeoutMs
+		return x.Listeners
 	}
-	return 0
+	return nil
 }
 
-func (x *HTTPRouteRule) GetRetry() *RetryConfig {
+func (x *GetRouteStatsResponse) GetDurationBuckets() []float64 {
 	if x != nil {
-		return x.Retry
+		return x.DurationBuckets
 	}
 	return nil
 }
 
-// HTTPRouteMatch defines conditions for matching an HTTP request.
-type HTTPRouteMatch struct {
+// RouteStats is the traffic of a route.
+type RouteStats struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Path match configuration.
-	Path *PathMatch `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
-	// Header match conditions.
-	Headers []*HeaderMatch `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
-	// Query parameter match conditions.
-	QueryParams []*QueryParamMatch `protobuf:"bytes,3,rep,name=query_params,json=queryParams,proto3" json:"query_params,omitempty"`
-	// HTTP method to match (GET, POST, etc.).
-	Method        string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	// Route ID, as sent in HTTPRoute.id or GRPCRoute.id.
+	RouteId string `protobuf:"bytes,1,opt,name=route_id,json=routeId,proto3" json:"route_id,omitempty"`
+	// Responses by status class.
+	Responses []*StatusClassCount `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses,omitempty"`
+	// Durations of the requests.
+	Durations     *RequestDurations `protobuf:"bytes,3,opt,name=durations,proto3" json:"durations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HTTPRouteMatch) Reset() {
-	*x = HTTPRouteMatch{}
-	mi := &file_routing_v1_routing_proto_msgTypes[8]
+func (x *RouteStats) Reset() {
+	*x = RouteStats{}
+	mi := &file_routing_v1_routing_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HTTPRouteMatch) String() string {
+func (x *RouteStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HTTPRouteMatch) ProtoMessage() {}
+func (*RouteStats) ProtoMessage() {}
 
-func (x *HTTPRouteMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[8]
+func (x *RouteStats) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -820,65 +1484,122 @@ func (x *HTTPRouteMatch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HTTPRouteMatch.ProtoReflect.Descriptor instead.
-func (*HTTPRouteMatch) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RouteStats.ProtoReflect.Descriptor instead.
+func (*RouteStats) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *HTTPRouteMatch) GetPath() *PathMatch {
+func (x *RouteStats) GetRouteId() string {
 	if x != nil {
-		return x.Path
+		return x.RouteId
+	}
+	return ""
+}
+
+func (x *RouteStats) GetResponses() []*StatusClassCount {
+	if x != nil {
+		return x.Responses
 	}
 	return nil
 }
 
-func (x *HTTPRouteMatch) GetHeaders() []*HeaderMatch {
+func (x *RouteStats) GetDurations() *RequestDurations {
 	if x != nil {
-		return x.Headers
+		return x.Durations
 	}
 	return nil
 }
 
-func (x *HTTPRouteMatch) GetQueryParams() []*QueryParamMatch {
+// ListenerStats is the traffic of a listener.
+type ListenerStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Port of the listener.
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// Responses by status class, including requests that matched no route.
+	Responses []*StatusClassCount `protobuf:"bytes,2,rep,name=responses,proto3" json:"responses,omitempty"`
+	// Durations of the requests.
+	Durations     *RequestDurations `protobuf:"bytes,3,opt,name=durations,proto3" json:"durations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenerStats) Reset() {
+	*x = ListenerStats{}
+	mi := &file_routing_v1_routing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerStats) ProtoMessage() {}
+
+func (x *ListenerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[12]
 	if x != nil {
-		return x.QueryParams
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerStats.ProtoReflect.Descriptor instead.
+func (*ListenerStats) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListenerStats) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ListenerStats) GetResponses() []*StatusClassCount {
+	if x != nil {
+		return x.Responses
 	}
 	return nil
 }
 
-func (x *HTTPRouteMatch) GetMethod() string {
+func (x *ListenerStats) GetDurations() *RequestDurations {
 	if x != nil {
-		return x.Method
+		return x.Durations
 	}
-	return ""
+	return nil
 }
 
-// PathMatch defines how to match the request path.
-type PathMatch struct {
+// StatusClassCount is the number of responses with a status class.
+type StatusClassCount struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Type of path match.
-	Type PathMatchType `protobuf:"varint,1,opt,name=type,proto3,enum=routing.v1.PathMatchType" json:"type,omitempty"`
-	// Value to match against.
-	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// First digit of the status code, 1 to 5.
+	StatusClass uint32 `protobuf:"varint,1,opt,name=status_class,json=statusClass,proto3" json:"status_class,omitempty"`
+	// Number of responses.
+	Count         uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PathMatch) Reset() {
-	*x = PathMatch{}
-	mi := &file_routing_v1_routing_proto_msgTypes[9]
+func (x *StatusClassCount) Reset() {
+	*x = StatusClassCount{}
+	mi := &file_routing_v1_routing_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PathMatch) String() string {
+func (x *StatusClassCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PathMatch) ProtoMessage() {}
+func (*StatusClassCount) ProtoMessage() {}
 
-func (x *PathMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[9]
+func (x *StatusClassCount) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -889,53 +1610,54 @@ func (x *PathMatch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PathMatch.ProtoReflect.Descriptor instead.
-func (*PathMatch) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use StatusClassCount.ProtoReflect.Descriptor instead.
+func (*StatusClassCount) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *PathMatch) GetType() PathMatchType {
+func (x *StatusClassCount) GetStatusClass() uint32 {
 	if x != nil {
-		return x.Type
+		return x.StatusClass
 	}
-	return PathMatchType_PATH_MATCH_TYPE_UNSPECIFIED
+	return 0
 }
 
-func (x *PathMatch) GetValue() string {
+func (x *StatusClassCount) GetCount() uint64 {
 	if x != nil {
-		return x.Value
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-// HeaderMatch defines a header matching condition.
-type HeaderMatch struct {
+// RequestDurations is a histogram of request durations.
+type RequestDurations struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Header name.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Header value to match.
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	// Type of match.
-	Type          HeaderMatchType `protobuf:"varint,3,opt,name=type,proto3,enum=routing.v1.HeaderMatchType" json:"type,omitempty"`
+	// Number of requests.
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Sum of the request durations in seconds.
+	SumSeconds float64 `protobuf:"fixed64,2,opt,name=sum_seconds,json=sumSeconds,proto3" json:"sum_seconds,omitempty"`
+	// Cumulative number of requests per bucket of
+	// GetRouteStatsResponse.duration_buckets.
+	BucketCounts  []uint64 `protobuf:"varint,3,rep,packed,name=bucket_counts,json=bucketCounts,proto3" json:"bucket_counts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HeaderMatch) Reset() {
-	*x = HeaderMatch{}
-	mi := &file_routing_v1_routing_proto_msgTypes[10]
+func (x *RequestDurations) Reset() {
+	*x = RequestDurations{}
+	mi := &file_routing_v1_routing_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HeaderMatch) String() string {
+func (x *RequestDurations) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HeaderMatch) ProtoMessage() {}
+func (*RequestDurations) ProtoMessage() {}
 
-func (x *HeaderMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[10]
+func (x *RequestDurations) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -946,60 +1668,3745 @@ func (x *HeaderMatch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HeaderMatch.ProtoReflect.Descriptor instead.
-func (*HeaderMatch) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RequestDurations.ProtoReflect.Descriptor instead.
+func (*RequestDurations) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RequestDurations) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *RequestDurations) GetSumSeconds() float64 {
+	if x != nil {
+		return x.SumSeconds
+	}
+	return 0
+}
+
+func (x *RequestDurations) GetBucketCounts() []uint64 {
+	if x != nil {
+		return x.BucketCounts
+	}
+	return nil
+}
+
+// UpdateGlobalConfigRequest contains the gateway-wide proxy settings.
+type UpdateGlobalConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *GlobalConfig          `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGlobalConfigRequest) Reset() {
+	*x = UpdateGlobalConfigRequest{}
+	mi := &file_routing_v1_routing_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGlobalConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGlobalConfigRequest) ProtoMessage() {}
+
+func (x *UpdateGlobalConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGlobalConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGlobalConfigRequest) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateGlobalConfigRequest) GetConfig() *GlobalConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// UpdateGlobalConfigResponse confirms the settings update.
+type UpdateGlobalConfigResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the update was successful.
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Error message if success is false.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGlobalConfigResponse) Reset() {
+	*x = UpdateGlobalConfigResponse{}
+	mi := &file_routing_v1_routing_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGlobalConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGlobalConfigResponse) ProtoMessage() {}
+
+func (x *UpdateGlobalConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGlobalConfigResponse.ProtoReflect.Descriptor instead.
+func (*UpdateGlobalConfigResponse) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateGlobalConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateGlobalConfigResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GlobalConfig defines proxy behavior shared by all listeners and routes.
+// Zero values use the proxy defaults.
+type GlobalConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Timeout in milliseconds of requests matched by rules without a
+	// timeout.
+	DefaultRequestTimeoutMs uint64 `protobuf:"varint,1,opt,name=default_request_timeout_ms,json=defaultRequestTimeoutMs,proto3" json:"default_request_timeout_ms,omitempty"`
+	// Largest request body accepted in bytes on listeners without a limit
+	// of their own. Larger requests are answered with 413.
+	MaxRequestBodyBytes uint64 `protobuf:"varint,2,opt,name=max_request_body_bytes,json=maxRequestBodyBytes,proto3" json:"max_request_body_bytes,omitempty"`
+	// Format of the access log.
+	AccessLogFormat AccessLogFormat `protobuf:"varint,3,opt,name=access_log_format,json=accessLogFormat,proto3,enum=routing.v1.AccessLogFormat" json:"access_log_format,omitempty"`
+	// Whether clients may use HTTP/2. Unset uses the proxy default.
+	Http2Enabled *bool `protobuf:"varint,4,opt,name=http2_enabled,json=http2Enabled,proto3,oneof" json:"http2_enabled,omitempty"`
+	// Networks in CIDR notation of proxies in front of the gateway. The
+	// client address is taken from X-Forwarded-For only for requests from
+	// these networks.
+	TrustedProxyCidrs []string `protobuf:"bytes,5,rep,name=trusted_proxy_cidrs,json=trustedProxyCidrs,proto3" json:"trusted_proxy_cidrs,omitempty"`
+	// Number of proxies in front of the gateway that append to
+	// X-Forwarded-For. The client address is the entry this many positions
+	// from the right. 0 skips trusted proxy addresses from the right instead.
+	ForwardedForDepth uint32 `protobuf:"varint,6,opt,name=forwarded_for_depth,json=forwardedForDepth,proto3" json:"forwarded_for_depth,omitempty"`
+	// Header that trusted proxies set to the client address, used instead of
+	// X-Forwarded-For. Empty uses X-Forwarded-For.
+	RealIpHeader  string `protobuf:"bytes,7,opt,name=real_ip_header,json=realIpHeader,proto3" json:"real_ip_header,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GlobalConfig) Reset() {
+	*x = GlobalConfig{}
+	mi := &file_routing_v1_routing_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GlobalConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlobalConfig) ProtoMessage() {}
+
+func (x *GlobalConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlobalConfig.ProtoReflect.Descriptor instead.
+func (*GlobalConfig) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GlobalConfig) GetDefaultRequestTimeoutMs() uint64 {
+	if x != nil {
+		return x.DefaultRequestTimeoutMs
+	}
+	return 0
+}
+
+func (x *GlobalConfig) GetMaxRequestBodyBytes() uint64 {
+	if x != nil {
+		return x.MaxRequestBodyBytes
+	}
+	return 0
+}
+
+func (x *GlobalConfig) GetAccessLogFormat() AccessLogFormat {
+	if x != nil {
+		return x.AccessLogFormat
+	}
+	return AccessLogFormat_ACCESS_LOG_FORMAT_UNSPECIFIED
+}
+
+func (x *GlobalConfig) GetHttp2Enabled() bool {
+	if x != nil && x.Http2Enabled != nil {
+		return *x.Http2Enabled
+	}
+	return false
+}
+
+func (x *GlobalConfig) GetTrustedProxyCidrs() []string {
+	if x != nil {
+		return x.TrustedProxyCidrs
+	}
+	return nil
+}
+
+func (x *GlobalConfig) GetForwardedForDepth() uint32 {
+	if x != nil {
+		return x.ForwardedForDepth
+	}
+	return 0
+}
+
+func (x *GlobalConfig) GetRealIpHeader() string {
+	if x != nil {
+		return x.RealIpHeader
+	}
+	return ""
+}
+
+// UpdateLoggingConfigRequest contains the access log settings.
+type UpdateLoggingConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        *LoggingConfig         `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLoggingConfigRequest) Reset() {
+	*x = UpdateLoggingConfigRequest{}
+	mi := &file_routing_v1_routing_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLoggingConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLoggingConfigRequest) ProtoMessage() {}
+
+func (x *UpdateLoggingConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLoggingConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLoggingConfigRequest) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateLoggingConfigRequest) GetConfig() *LoggingConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+// UpdateLoggingConfigResponse confirms the access log settings update.
+type UpdateLoggingConfigResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the update was successful.
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Error message if success is false.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLoggingConfigResponse) Reset() {
+	*x = UpdateLoggingConfigResponse{}
+	mi := &file_routing_v1_routing_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLoggingConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLoggingConfigResponse) ProtoMessage() {}
+
+func (x *UpdateLoggingConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLoggingConfigResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLoggingConfigResponse) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateLoggingConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateLoggingConfigResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// LoggingConfig defines which requests the proxy writes to the access log
+// and how. Zero values use the proxy defaults.
+type LoggingConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Format of the access log.
+	Format AccessLogFormat `protobuf:"varint,1,opt,name=format,proto3,enum=routing.v1.AccessLogFormat" json:"format,omitempty"`
+	// Fields of JSON access log entries, in order. Empty logs the default
+	// fields of the proxy.
+	Fields []string `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	// Percentage of requests logged, 0 to 100. Unset logs all requests.
+	SamplePercent *uint32 `protobuf:"varint,3,opt,name=sample_percent,json=samplePercent,proto3,oneof" json:"sample_percent,omitempty"`
+	// Path prefixes of requests that are logged. Empty logs all paths.
+	IncludePathPrefixes []string `protobuf:"bytes,4,rep,name=include_path_prefixes,json=includePathPrefixes,proto3" json:"include_path_prefixes,omitempty"`
+	// Path prefixes of requests that are never logged. They take precedence
+	// over include_path_prefixes.
+	ExcludePathPrefixes []string `protobuf:"bytes,5,rep,name=exclude_path_prefixes,json=excludePathPrefixes,proto3" json:"exclude_path_prefixes,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *LoggingConfig) Reset() {
+	*x = LoggingConfig{}
+	mi := &file_routing_v1_routing_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoggingConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoggingConfig) ProtoMessage() {}
+
+func (x *LoggingConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoggingConfig.ProtoReflect.Descriptor instead.
+func (*LoggingConfig) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *LoggingConfig) GetFormat() AccessLogFormat {
+	if x != nil {
+		return x.Format
+	}
+	return AccessLogFormat_ACCESS_LOG_FORMAT_UNSPECIFIED
+}
+
+func (x *LoggingConfig) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *LoggingConfig) GetSamplePercent() uint32 {
+	if x != nil && x.SamplePercent != nil {
+		return *x.SamplePercent
+	}
+	return 0
+}
+
+func (x *LoggingConfig) GetIncludePathPrefixes() []string {
+	if x != nil {
+		return x.IncludePathPrefixes
+	}
+	return nil
+}
+
+func (x *LoggingConfig) GetExcludePathPrefixes() []string {
+	if x != nil {
+		return x.ExcludePathPrefixes
+	}
+	return nil
+}
+
+// UpdateCertificatesRequest contains the certificates of all HTTPS
+// listeners.
+type UpdateCertificatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Certificates of the HTTPS listeners by port, ordered by port.
+	Listeners []*ListenerCertificates `protobuf:"bytes,1,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	// Certificate presented to clients that send no server name, or one that
+	// no certificate of the listener port matches. Unset fails their
+	// handshakes.
+	Fallback *Certificate `protobuf:"bytes,2,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	// Order in which the certificates of a port are matched against the
+	// server name.
+	Selection     CertificateSelection `protobuf:"varint,3,opt,name=selection,proto3,enum=routing.v1.CertificateSelection" json:"selection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCertificatesRequest) Reset() {
+	*x = UpdateCertificatesRequest{}
+	mi := &file_routing_v1_routing_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCertificatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCertificatesRequest) ProtoMessage() {}
+
+func (x *UpdateCertificatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCertificatesRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCertificatesRequest) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateCertificatesRequest) GetListeners() []*ListenerCertificates {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *UpdateCertificatesRequest) GetFallback() *Certificate {
+	if x != nil {
+		return x.Fallback
+	}
+	return nil
+}
+
+func (x *UpdateCertificatesRequest) GetSelection() CertificateSelection {
+	if x != nil {
+		return x.Selection
+	}
+	return CertificateSelection_CERTIFICATE_SELECTION_MOST_SPECIFIC
+}
+
+// UpdateCertificatesResponse confirms the certificates update.
+type UpdateCertificatesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether the update was successful.
+	Success bool `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Error message if success is false.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCertificatesResponse) Reset() {
+	*x = UpdateCertificatesResponse{}
+	mi := &file_routing_v1_routing_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCertificatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCertificatesResponse) ProtoMessage() {}
+
+func (x *UpdateCertificatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCertificatesResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCertificatesResponse) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateCertificatesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *UpdateCertificatesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// ListenerCertificates are the certificates of the HTTPS listeners on a
+// port, in the order of the listeners.
+type ListenerCertificates struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Port of the listeners.
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// Certificates of the listeners.
+	Certificates  []*Certificate `protobuf:"bytes,2,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenerCertificates) Reset() {
+	*x = ListenerCertificates{}
+	mi := &file_routing_v1_routing_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenerCertificates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerCertificates) ProtoMessage() {}
+
+func (x *ListenerCertificates) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerCertificates.ProtoReflect.Descriptor instead.
+func (*ListenerCertificates) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListenerCertificates) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ListenerCertificates) GetCertificates() []*Certificate {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+// Certificate is a certificate chain with its private key.
+type Certificate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Source of the certificate as namespace/name, for logs only.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Server names the certificate is selected for, which may start with a
+	// "*." wildcard label. Empty matches every server name.
+	Hostnames []string `protobuf:"bytes,2,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	// PEM-encoded certificate chain, leaf first.
+	CertificateChain []byte `protobuf:"bytes,3,opt,name=certificate_chain,json=certificateChain,proto3" json:"certificate_chain,omitempty"`
+	// PEM-encoded private key.
+	PrivateKey []byte `protobuf:"bytes,4,opt,name=private_key,json=privateKey,proto3" json:"private_key,omitempty"`
+	// Client certificate validation of the listener. Unset requests no
+	// client certificate.
+	ClientValidation *ClientValidation `protobuf:"bytes,5,opt,name=client_validation,json=clientValidation,proto3" json:"client_validation,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Certificate) Reset() {
+	*x = Certificate{}
+	mi := &file_routing_v1_routing_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Certificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Certificate) ProtoMessage() {}
+
+func (x *Certificate) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Certificate.ProtoReflect.Descriptor instead.
+func (*Certificate) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Certificate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Certificate) GetHostnames() []string {
+	if x != nil {
+		return x.Hostnames
+	}
+	return nil
+}
+
+func (x *Certificate) GetCertificateChain() []byte {
+	if x != nil {
+		return x.CertificateChain
+	}
+	return nil
+}
+
+func (x *Certificate) GetPrivateKey() []byte {
+	if x != nil {
+		return x.PrivateKey
+	}
+	return nil
+}
+
+func (x *Certificate) GetClientValidation() *ClientValidation {
+	if x != nil {
+		return x.ClientValidation
+	}
+	return nil
+}
+
+// ClientValidation verifies the certificates of clients against trusted
+// certificate authorities (mutual TLS).
+type ClientValidation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// PEM-encoded CA certificates that client certificates must chain to.
+	// Empty validates no client certificate.
+	CaCertificates []byte `protobuf:"bytes,1,opt,name=ca_certificates,json=caCertificates,proto3" json:"ca_certificates,omitempty"`
+	// Whether clients without a valid certificate are accepted.
+	Mode          ClientValidationMode `protobuf:"varint,2,opt,name=mode,proto3,enum=routing.v1.ClientValidationMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientValidation) Reset() {
+	*x = ClientValidation{}
+	mi := &file_routing_v1_routing_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientValidation) ProtoMessage() {}
+
+func (x *ClientValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientValidation.ProtoReflect.Descriptor instead.
+func (*ClientValidation) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ClientValidation) GetCaCertificates() []byte {
+	if x != nil {
+		return x.CaCertificates
+	}
+	return nil
+}
+
+func (x *ClientValidation) GetMode() ClientValidationMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ClientValidationMode_CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY
+}
+
+// StreamRoutesRequest is a route update sent over the StreamRoutes stream.
+type StreamRoutesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Update:
+	//
+	//	*StreamRoutesRequest_Full
+	//	*StreamRoutesRequest_Delta
+	Update        isStreamRoutesRequest_Update `protobuf_oneof:"update"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRoutesRequest) Reset() {
+	*x = StreamRoutesRequest{}
+	mi := &file_routing_v1_routing_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRoutesRequest) ProtoMessage() {}
+
+func (x *StreamRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRoutesRequest.ProtoReflect.Descriptor instead.
+func (*StreamRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *StreamRoutesRequest) GetUpdate() isStreamRoutesRequest_Update {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *StreamRoutesRequest) GetFull() *UpdateRoutesRequest {
+	if x != nil {
+		if x, ok := x.Update.(*StreamRoutesRequest_Full); ok {
+			return x.Full
+		}
+	}
+	return nil
+}
+
+func (x *StreamRoutesRequest) GetDelta() *RoutesDelta {
+	if x != nil {
+		if x, ok := x.Update.(*StreamRoutesRequest_Delta); ok {
+			return x.Delta
+		}
+	}
+	return nil
+}
+
+type isStreamRoutesRequest_Update interface {
+	isStreamRoutesRequest_Update()
+}
+
+type StreamRoutesRequest_Full struct {
+	// Full replaces all routes, same as UpdateRoutes.
+	Full *UpdateRoutesRequest `protobuf:"bytes,1,opt,name=full,proto3,oneof"`
+}
+
+type StreamRoutesRequest_Delta struct {
+	// Delta changes only the listed routes.
+	Delta *RoutesDelta `protobuf:"bytes,2,opt,name=delta,proto3,oneof"`
+}
+
+func (*StreamRoutesRequest_Full) isStreamRoutesRequest_Update() {}
+
+func (*StreamRoutesRequest_Delta) isStreamRoutesRequest_Update() {}
+
+// RoutesDelta describes changes relative to a previously applied version.
+type RoutesDelta struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version the delta is based on. The proxy must reject the delta
+	// if its applied version differs.
+	BaseVersion uint64 `protobuf:"varint,1,opt,name=base_version,json=baseVersion,proto3" json:"base_version,omitempty"`
+	// Version of the configuration after applying the delta.
+	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// HTTP routes to add or replace, matched by id.
+	UpsertHttpRoutes []*HTTPRoute `protobuf:"bytes,3,rep,name=upsert_http_routes,json=upsertHttpRoutes,proto3" json:"upsert_http_routes,omitempty"`
+	// gRPC routes to add or replace, matched by id.
+	UpsertGrpcRoutes []*GRPCRoute `protobuf:"bytes,4,rep,name=upsert_grpc_routes,json=upsertGrpcRoutes,proto3" json:"upsert_grpc_routes,omitempty"`
+	// IDs of HTTP routes to remove.
+	RemoveHttpRouteIds []string `protobuf:"bytes,5,rep,name=remove_http_route_ids,json=removeHttpRouteIds,proto3" json:"remove_http_route_ids,omitempty"`
+	// IDs of gRPC routes to remove.
+	RemoveGrpcRouteIds []string `protobuf:"bytes,6,rep,name=remove_grpc_route_ids,json=removeGrpcRouteIds,proto3" json:"remove_grpc_route_ids,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RoutesDelta) Reset() {
+	*x = RoutesDelta{}
+	mi := &file_routing_v1_routing_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoutesDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoutesDelta) ProtoMessage() {}
+
+func (x *RoutesDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoutesDelta.ProtoReflect.Descriptor instead.
+func (*RoutesDelta) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RoutesDelta) GetBaseVersion() uint64 {
+	if x != nil {
+		return x.BaseVersion
+	}
+	return 0
+}
+
+func (x *RoutesDelta) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *RoutesDelta) GetUpsertHttpRoutes() []*HTTPRoute {
+	if x != nil {
+		return x.UpsertHttpRoutes
+	}
+	return nil
+}
+
+func (x *RoutesDelta) GetUpsertGrpcRoutes() []*GRPCRoute {
+	if x != nil {
+		return x.UpsertGrpcRoutes
+	}
+	return nil
+}
+
+func (x *RoutesDelta) GetRemoveHttpRouteIds() []string {
+	if x != nil {
+		return x.RemoveHttpRouteIds
+	}
+	return nil
+}
+
+func (x *RoutesDelta) GetRemoveGrpcRouteIds() []string {
+	if x != nil {
+		return x.RemoveGrpcRouteIds
+	}
+	return nil
+}
+
+// StreamRoutesResponse is a message sent by the proxy over the StreamRoutes stream.
+type StreamRoutesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Message:
+	//
+	//	*StreamRoutesResponse_Ack
+	//	*StreamRoutesResponse_Health
+	Message       isStreamRoutesResponse_Message `protobuf_oneof:"message"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamRoutesResponse) Reset() {
+	*x = StreamRoutesResponse{}
+	mi := &file_routing_v1_routing_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRoutesResponse) ProtoMessage() {}
+
+func (x *StreamRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRoutesResponse.ProtoReflect.Descriptor instead.
+func (*StreamRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StreamRoutesResponse) GetMessage() isStreamRoutesResponse_Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *StreamRoutesResponse) GetAck() *UpdateRoutesResponse {
+	if x != nil {
+		if x, ok := x.Message.(*StreamRoutesResponse_Ack); ok {
+			return x.Ack
+		}
+	}
+	return nil
+}
+
+func (x *StreamRoutesResponse) GetHealth() *HealthResponse {
+	if x != nil {
+		if x, ok := x.Message.(*StreamRoutesResponse_Health); ok {
+			return x.Health
+		}
+	}
+	return nil
+}
+
+type isStreamRoutesResponse_Message interface {
+	isStreamRoutesResponse_Message()
+}
+
+type StreamRoutesResponse_Ack struct {
+	// Ack confirms or rejects the most recent update.
+	Ack *UpdateRoutesResponse `protobuf:"bytes,1,opt,name=ack,proto3,oneof"`
+}
+
+type StreamRoutesResponse_Health struct {
+	// Health is an unsolicited health report.
+	Health *HealthResponse `protobuf:"bytes,2,opt,name=health,proto3,oneof"`
+}
+
+func (*StreamRoutesResponse_Ack) isStreamRoutesResponse_Message() {}
+
+func (*StreamRoutesResponse_Health) isStreamRoutesResponse_Message() {}
+
+// DeleteRoutesRequest lists the routes to remove. Routes that are not
+// configured are ignored.
+type DeleteRoutesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// IDs of HTTP routes to remove.
+	HttpRouteIds []string `protobuf:"bytes,1,rep,name=http_route_ids,json=httpRouteIds,proto3" json:"http_route_ids,omitempty"`
+	// IDs of gRPC routes to remove.
+	GrpcRouteIds []string `protobuf:"bytes,2,rep,name=grpc_route_ids,json=grpcRouteIds,proto3" json:"grpc_route_ids,omitempty"`
+	// Version of the configuration after removing the routes.
+	Version       uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoutesRequest) Reset() {
+	*x = DeleteRoutesRequest{}
+	mi := &file_routing_v1_routing_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoutesRequest) ProtoMessage() {}
+
+func (x *DeleteRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoutesRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteRoutesRequest) GetHttpRouteIds() []string {
+	if x != nil {
+		return x.HttpRouteIds
+	}
+	return nil
+}
+
+func (x *DeleteRoutesRequest) GetGrpcRouteIds() []string {
+	if x != nil {
+		return x.GrpcRouteIds
+	}
+	return nil
+}
+
+func (x *DeleteRoutesRequest) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Listener defines the settings of the proxy listener on a port.
+type Listener struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Port the listener accepts connections on.
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// Request size limits and client timeouts.
+	Limits *ListenerLimits `protobuf:"bytes,2,opt,name=limits,proto3" json:"limits,omitempty"`
+	// Client address restrictions. A request must be allowed by every one of
+	// them, before any access control of its route rule is checked.
+	AccessControls []*AccessControl `protobuf:"bytes,3,rep,name=access_controls,json=accessControls,proto3" json:"access_controls,omitempty"`
+	// HTTP/3 over QUIC on the listener, only set for HTTPS listeners. Unset
+	// disables HTTP/3.
+	Http3 *HTTP3 `protobuf:"bytes,4,opt,name=http3,proto3" json:"http3,omitempty"`
+	// HAProxy PROXY protocol on the listener. Unset disables it.
+	ProxyProtocol *ProxyProtocol `protobuf:"bytes,5,opt,name=proxy_protocol,json=proxyProtocol,proto3" json:"proxy_protocol,omitempty"`
+	// Client address detection of the listener. Replaces the detection of
+	// GlobalConfig when set.
+	ClientIp *ClientIPDetection `protobuf:"bytes,6,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	// Redirects of plain HTTP requests to HTTPS listeners, applied before
+	// route matching. The redirect with the most specific hostname matching
+	// the request applies.
+	HttpsRedirects []*HTTPSRedirect `protobuf:"bytes,7,rep,name=https_redirects,json=httpsRedirects,proto3" json:"https_redirects,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Listener) Reset() {
+	*x = Listener{}
+	mi := &file_routing_v1_routing_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Listener) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Listener) ProtoMessage() {}
+
+func (x *Listener) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Listener.ProtoReflect.Descriptor instead.
+func (*Listener) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Listener) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Listener) GetLimits() *ListenerLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+func (x *Listener) GetAccessControls() []*AccessControl {
+	if x != nil {
+		return x.AccessControls
+	}
+	return nil
+}
+
+func (x *Listener) GetHttp3() *HTTP3 {
+	if x != nil {
+		return x.Http3
+	}
+	return nil
+}
+
+func (x *Listener) GetProxyProtocol() *ProxyProtocol {
+	if x != nil {
+		return x.ProxyProtocol
+	}
+	return nil
+}
+
+func (x *Listener) GetClientIp() *ClientIPDetection {
+	if x != nil {
+		return x.ClientIp
+	}
+	return nil
+}
+
+func (x *Listener) GetHttpsRedirects() []*HTTPSRedirect {
+	if x != nil {
+		return x.HttpsRedirects
+	}
+	return nil
+}
+
+// HTTPSRedirect redirects the requests for some hostnames to an HTTPS
+// listener, keeping the host, path and query.
+type HTTPSRedirect struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hostnames to redirect, may be wildcards like *.example.com. Empty
+	// redirects all hostnames.
+	Hostnames []string `protobuf:"bytes,1,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	// Port of the HTTPS listener. Left out of the Location header if 443.
+	Port uint32 `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	// Status code of the redirect response.
+	StatusCode uint32 `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// Path prefixes routed as usual instead of redirected, such as the
+	// ACME HTTP-01 challenge path.
+	ExemptPathPrefixes []string `protobuf:"bytes,4,rep,name=exempt_path_prefixes,json=exemptPathPrefixes,proto3" json:"exempt_path_prefixes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *HTTPSRedirect) Reset() {
+	*x = HTTPSRedirect{}
+	mi := &file_routing_v1_routing_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPSRedirect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPSRedirect) ProtoMessage() {}
+
+func (x *HTTPSRedirect) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPSRedirect.ProtoReflect.Descriptor instead.
+func (*HTTPSRedirect) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *HTTPSRedirect) GetHostnames() []string {
+	if x != nil {
+		return x.Hostnames
+	}
+	return nil
+}
+
+func (x *HTTPSRedirect) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *HTTPSRedirect) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *HTTPSRedirect) GetExemptPathPrefixes() []string {
+	if x != nil {
+		return x.ExemptPathPrefixes
+	}
+	return nil
+}
+
+// ClientIPDetection defines how the client address of requests that passed
+// proxies in front of the gateway is found, with the same meaning as the
+// fields of GlobalConfig.
+type ClientIPDetection struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Networks in CIDR notation of proxies in front of the listener.
+	TrustedProxyCidrs []string `protobuf:"bytes,1,rep,name=trusted_proxy_cidrs,json=trustedProxyCidrs,proto3" json:"trusted_proxy_cidrs,omitempty"`
+	// Number of proxies that append to X-Forwarded-For, 0 if unknown.
+	ForwardedForDepth uint32 `protobuf:"varint,2,opt,name=forwarded_for_depth,json=forwardedForDepth,proto3" json:"forwarded_for_depth,omitempty"`
+	// Header that trusted proxies set to the client address.
+	RealIpHeader  string `protobuf:"bytes,3,opt,name=real_ip_header,json=realIpHeader,proto3" json:"real_ip_header,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClientIPDetection) Reset() {
+	*x = ClientIPDetection{}
+	mi := &file_routing_v1_routing_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientIPDetection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientIPDetection) ProtoMessage() {}
+
+func (x *ClientIPDetection) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientIPDetection.ProtoReflect.Descriptor instead.
+func (*ClientIPDetection) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ClientIPDetection) GetTrustedProxyCidrs() []string {
+	if x != nil {
+		return x.TrustedProxyCidrs
+	}
+	return nil
+}
+
+func (x *ClientIPDetection) GetForwardedForDepth() uint32 {
+	if x != nil {
+		return x.ForwardedForDepth
+	}
+	return 0
+}
+
+func (x *ClientIPDetection) GetRealIpHeader() string {
+	if x != nil {
+		return x.RealIpHeader
+	}
+	return ""
+}
+
+// ProxyProtocol makes a listener read a HAProxy PROXY protocol header at the
+// start of every TCP connection and use its source address as the client
+// address. Connections without a valid header are closed.
+type ProxyProtocol struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Version accepted, unspecified accepts both.
+	Version       ProxyProtocolVersion `protobuf:"varint,1,opt,name=version,proto3,enum=routing.v1.ProxyProtocolVersion" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProxyProtocol) Reset() {
+	*x = ProxyProtocol{}
+	mi := &file_routing_v1_routing_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProxyProtocol) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProxyProtocol) ProtoMessage() {}
+
+func (x *ProxyProtocol) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProxyProtocol.ProtoReflect.Descriptor instead.
+func (*ProxyProtocol) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ProxyProtocol) GetVersion() ProxyProtocolVersion {
+	if x != nil {
+		return x.Version
+	}
+	return ProxyProtocolVersion_PROXY_PROTOCOL_VERSION_UNSPECIFIED
+}
+
+// HTTP3 enables HTTP/3 over QUIC on a listener, with the TLS settings of
+// the listener.
+type HTTP3 struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// UDP port QUIC connections are accepted on.
+	UdpPort uint32 `protobuf:"varint,1,opt,name=udp_port,json=udpPort,proto3" json:"udp_port,omitempty"`
+	// Port announced in the Alt-Svc header of HTTP/1.1 and HTTP/2 responses.
+	// 0 announces udp_port.
+	AltSvcPort uint32 `protobuf:"varint,2,opt,name=alt_svc_port,json=altSvcPort,proto3" json:"alt_svc_port,omitempty"`
+	// Time clients may remember the Alt-Svc announcement, in seconds. 0
+	// means the proxy default.
+	AltSvcMaxAgeSeconds uint64 `protobuf:"varint,3,opt,name=alt_svc_max_age_seconds,json=altSvcMaxAgeSeconds,proto3" json:"alt_svc_max_age_seconds,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *HTTP3) Reset() {
+	*x = HTTP3{}
+	mi := &file_routing_v1_routing_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTP3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTP3) ProtoMessage() {}
+
+func (x *HTTP3) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTP3.ProtoReflect.Descriptor instead.
+func (*HTTP3) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *HTTP3) GetUdpPort() uint32 {
+	if x != nil {
+		return x.UdpPort
+	}
+	return 0
+}
+
+func (x *HTTP3) GetAltSvcPort() uint32 {
+	if x != nil {
+		return x.AltSvcPort
+	}
+	return 0
+}
+
+func (x *HTTP3) GetAltSvcMaxAgeSeconds() uint64 {
+	if x != nil {
+		return x.AltSvcMaxAgeSeconds
+	}
+	return 0
+}
+
+// ListenerLimits defines request size limits and client timeouts of a
+// listener. 0 means the proxy default for every field.
+type ListenerLimits struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Largest request body accepted in bytes. Larger requests are answered
+	// with 413.
+	MaxRequestBodyBytes uint64 `protobuf:"varint,1,opt,name=max_request_body_bytes,json=maxRequestBodyBytes,proto3" json:"max_request_body_bytes,omitempty"`
+	// Largest total size of the request line and headers in bytes. Larger
+	// requests are answered with 431.
+	MaxRequestHeadersBytes uint64 `protobuf:"varint,2,opt,name=max_request_headers_bytes,json=maxRequestHeadersBytes,proto3" json:"max_request_headers_bytes,omitempty"`
+	// Largest number of request headers. Requests with more headers are
+	// answered with 431.
+	MaxRequestHeaders uint32 `protobuf:"varint,3,opt,name=max_request_headers,json=maxRequestHeaders,proto3" json:"max_request_headers,omitempty"`
+	// Time a client may take to send the request line and headers, in
+	// milliseconds. Slower clients are answered with 408.
+	RequestHeaderTimeoutMs uint64 `protobuf:"varint,4,opt,name=request_header_timeout_ms,json=requestHeaderTimeoutMs,proto3" json:"request_header_timeout_ms,omitempty"`
+	// Time the proxy waits for the next chunk of a request body, in
+	// milliseconds.
+	RequestBodyTimeoutMs uint64 `protobuf:"varint,5,opt,name=request_body_timeout_ms,json=requestBodyTimeoutMs,proto3" json:"request_body_timeout_ms,omitempty"`
+	// Time a keep-alive connection may stay idle between requests, in
+	// milliseconds.
+	IdleTimeoutMs uint64 `protobuf:"varint,6,opt,name=idle_timeout_ms,json=idleTimeoutMs,proto3" json:"idle_timeout_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListenerLimits) Reset() {
+	*x = ListenerLimits{}
+	mi := &file_routing_v1_routing_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListenerLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListenerLimits) ProtoMessage() {}
+
+func (x *ListenerLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListenerLimits.ProtoReflect.Descriptor instead.
+func (*ListenerLimits) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListenerLimits) GetMaxRequestBodyBytes() uint64 {
+	if x != nil {
+		return x.MaxRequestBodyBytes
+	}
+	return 0
+}
+
+func (x *ListenerLimits) GetMaxRequestHeadersBytes() uint64 {
+	if x != nil {
+		return x.MaxRequestHeadersBytes
+	}
+	return 0
+}
+
+func (x *ListenerLimits) GetMaxRequestHeaders() uint32 {
+	if x != nil {
+		return x.MaxRequestHeaders
+	}
+	return 0
+}
+
+func (x *ListenerLimits) GetRequestHeaderTimeoutMs() uint64 {
+	if x != nil {
+		return x.RequestHeaderTimeoutMs
+	}
+	return 0
+}
+
+func (x *ListenerLimits) GetRequestBodyTimeoutMs() uint64 {
+	if x != nil {
+		return x.RequestBodyTimeoutMs
+	}
+	return 0
+}
+
+func (x *ListenerLimits) GetIdleTimeoutMs() uint64 {
+	if x != nil {
+		return x.IdleTimeoutMs
+	}
+	return 0
+}
+
+// RouteListener defines the hostnames a route serves on a listener port.
+type RouteListener struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Port of the listener.
+	Port uint32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// Route hostnames narrowed to the hostnames of the listeners on the port.
+	// Empty matches all hostnames.
+	Hostnames []string `protobuf:"bytes,2,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	// Hostnames of more specific listeners on the port the route is not
+	// attached to. Requests for them must not reach the route even if they
+	// match its hostnames, so that a request is only served by the routes of
+	// the most specific listener matching it (listener isolation). Entries
+	// may be wildcards like *.example.com.
+	ExcludedHostnames []string `protobuf:"bytes,3,rep,name=excluded_hostnames,json=excludedHostnames,proto3" json:"excluded_hostnames,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RouteListener) Reset() {
+	*x = RouteListener{}
+	mi := &file_routing_v1_routing_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteListener) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteListener) ProtoMessage() {}
+
+func (x *RouteListener) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteListener.ProtoReflect.Descriptor instead.
+func (*RouteListener) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RouteListener) GetPort() uint32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *RouteListener) GetHostnames() []string {
+	if x != nil {
+		return x.Hostnames
+	}
+	return nil
+}
+
+func (x *RouteListener) GetExcludedHostnames() []string {
+	if x != nil {
+		return x.ExcludedHostnames
+	}
+	return nil
+}
+
+// HTTPRoute defines an HTTP routing rule.
+type HTTPRoute struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique identifier for this route.
+	// Derived from namespace and name, optionally suffixed with the route UID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Hostnames this route matches: the union of the hostnames of all its
+	// listeners. Empty matches all hostnames.
+	Hostnames []string `protobuf:"bytes,2,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	// Routing rules for this HTTPRoute.
+	Rules []*HTTPRouteRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Listener ports the route is attached to, with the hostnames it serves
+	// on each. The route is only served on these ports when set.
+	Listeners     []*RouteListener `protobuf:"bytes,4,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HTTPRoute) Reset() {
+	*x = HTTPRoute{}
+	mi := &file_routing_v1_routing_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPRoute) ProtoMessage() {}
+
+func (x *HTTPRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPRoute.ProtoReflect.Descriptor instead.
+func (*HTTPRoute) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *HTTPRoute) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HTTPRoute) GetHostnames() []string {
+	if x != nil {
+		return x.Hostnames
+	}
+	return nil
+}
+
+func (x *HTTPRoute) GetRules() []*HTTPRouteRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *HTTPRoute) GetListeners() []*RouteListener {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+// HTTPRouteRule defines a single HTTP routing rule.
+type HTTPRouteRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matchers for this rule.
+	Matches []*HTTPRouteMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	// Backend references for this rule.
+	Backends []*Backend `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty"`
+	// Request timeout in milliseconds.
+	TimeoutMs uint64 `protobuf:"varint,3,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// Retry configuration.
+	Retry *RetryConfig `protobuf:"bytes,4,opt,name=retry,proto3" json:"retry,omitempty"`
+	// Fixed response for rules without usable backends.
+	// When set, the proxy must answer matching requests directly
+	// with this response instead of forwarding them.
+	FixedResponse *FixedResponse `protobuf:"bytes,5,opt,name=fixed_response,json=fixedResponse,proto3" json:"fixed_response,omitempty"`
+	// Combined weight of backendRefs that could not be resolved.
+	// The proxy must answer this share of requests, relative to the sum of
+	// backend weights, with a 500 response instead of redistributing them.
+	// Not set when fixed_response is set.
+	InvalidBackendWeight uint32 `protobuf:"varint,6,opt,name=invalid_backend_weight,json=invalidBackendWeight,proto3" json:"invalid_backend_weight,omitempty"`
+	// Session persistence configuration.
+	// When set, the proxy must send requests carrying a valid session
+	// token to the backend that issued it.
+	SessionPersistence *SessionPersistence `protobuf:"bytes,7,opt,name=session_persistence,json=sessionPersistence,proto3" json:"session_persistence,omitempty"`
+	// Timeout for a single request to a backend in milliseconds.
+	// Applies to every retry attempt separately and is never larger
+	// than timeout_ms when both are set.
+	BackendTimeoutMs uint64 `protobuf:"varint,8,opt,name=backend_timeout_ms,json=backendTimeoutMs,proto3" json:"backend_timeout_ms,omitempty"`
+	// Name of the rule from the HTTPRoute spec, empty if the rule is unnamed.
+	// Informational only: the proxy should include it in logs and metrics
+	// and must return it unchanged in GetRoutes.
+	Name string `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	// Disables all retries and failover for this rule, for endpoints that are
+	// not idempotent. When set, the proxy must send each request to a single
+	// backend exactly once and must not retry it, not even on connection
+	// errors or with a proxy-wide default retry policy. retry is not set.
+	DisableRetries bool `protobuf:"varint,10,opt,name=disable_retries,json=disableRetries,proto3" json:"disable_retries,omitempty"`
+	// CORS policy for this rule.
+	// When set, the proxy must answer preflight requests directly and add
+	// CORS headers to responses for allowed origins.
+	Cors *CORSPolicy `protobuf:"bytes,11,opt,name=cors,proto3" json:"cors,omitempty"`
+	// Rate limit for this rule.
+	// When set, the proxy must answer requests over the limit with 429.
+	RateLimit *RateLimit `protobuf:"bytes,12,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	// Authentication required for this rule.
+	// When set, the proxy must answer unauthenticated requests with 401 and
+	// forward only authenticated ones.
+	Auth *AuthConfig `protobuf:"bytes,13,opt,name=auth,proto3" json:"auth,omitempty"`
+	// Client address restrictions for this rule.
+	// When set, the proxy must answer denied requests with 403.
+	AccessControl *AccessControl `protobuf:"bytes,14,opt,name=access_control,json=accessControl,proto3" json:"access_control,omitempty"`
+	// Response caching for this rule.
+	// When set, the proxy must serve cacheable GET and HEAD responses from its
+	// cache until they expire.
+	Cache *CacheConfig `protobuf:"bytes,15,opt,name=cache,proto3" json:"cache,omitempty"`
+	// Access log sampling for this rule.
+	// When set, it replaces the sampling of LoggingConfig for requests
+	// matched by the rule. Path filters of LoggingConfig still apply.
+	AccessLog     *RouteAccessLog `protobuf:"bytes,16,opt,name=access_log,json=accessLog,proto3" json:"access_log,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HTTPRouteRule) Reset() {
+	*x = HTTPRouteRule{}
+	mi := &file_routing_v1_routing_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPRouteRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPRouteRule) ProtoMessage() {}
+
+func (x *HTTPRouteRule) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPRouteRule.ProtoReflect.Descriptor instead.
+func (*HTTPRouteRule) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *HTTPRouteRule) GetMatches() []*HTTPRouteMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetBackends() []*Backend {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetTimeoutMs() uint64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *HTTPRouteRule) GetRetry() *RetryConfig {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetFixedResponse() *FixedResponse {
+	if x != nil {
+		return x.FixedResponse
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetInvalidBackendWeight() uint32 {
+	if x != nil {
+		return x.InvalidBackendWeight
+	}
+	return 0
+}
+
+func (x *HTTPRouteRule) GetSessionPersistence() *SessionPersistence {
+	if x != nil {
+		return x.SessionPersistence
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetBackendTimeoutMs() uint64 {
+	if x != nil {
+		return x.BackendTimeoutMs
+	}
+	return 0
+}
+
+func (x *HTTPRouteRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HTTPRouteRule) GetDisableRetries() bool {
+	if x != nil {
+		return x.DisableRetries
+	}
+	return false
+}
+
+func (x *HTTPRouteRule) GetCors() *CORSPolicy {
+	if x != nil {
+		return x.Cors
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetRateLimit() *RateLimit {
+	if x != nil {
+		return x.RateLimit
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetAuth() *AuthConfig {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetAccessControl() *AccessControl {
+	if x != nil {
+		return x.AccessControl
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetCache() *CacheConfig {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+func (x *HTTPRouteRule) GetAccessLog() *RouteAccessLog {
+	if x != nil {
+		return x.AccessLog
+	}
+	return nil
+}
+
+// RouteAccessLog defines the access log sampling of a route rule.
+type RouteAccessLog struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Percentage of requests logged, 0 to 100. 0 logs no requests.
+	SamplePercent uint32 `protobuf:"varint,1,opt,name=sample_percent,json=samplePercent,proto3" json:"sample_percent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RouteAccessLog) Reset() {
+	*x = RouteAccessLog{}
+	mi := &file_routing_v1_routing_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RouteAccessLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RouteAccessLog) ProtoMessage() {}
+
+func (x *RouteAccessLog) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RouteAccessLog.ProtoReflect.Descriptor instead.
+func (*RouteAccessLog) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RouteAccessLog) GetSamplePercent() uint32 {
+	if x != nil {
+		return x.SamplePercent
+	}
+	return 0
+}
+
+// HTTPRouteMatch defines conditions for matching an HTTP request.
+type HTTPRouteMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Path match configuration.
+	Path *PathMatch `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// Header match conditions.
+	Headers []*HeaderMatch `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	// Query parameter match conditions.
+	QueryParams []*QueryParamMatch `protobuf:"bytes,3,rep,name=query_params,json=queryParams,proto3" json:"query_params,omitempty"`
+	// HTTP method to match (GET, POST, etc.).
+	Method string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	// Precedence of this match among the matches of all HTTP routes, 1 being
+	// the highest. The proxy first narrows a request to the routes with the
+	// most specific hostname that matches it, exact before wildcard and longer
+	// before shorter, then evaluates their matches in ascending priority.
+	// Zero means unset.
+	Priority      uint32 `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HTTPRouteMatch) Reset() {
+	*x = HTTPRouteMatch{}
+	mi := &file_routing_v1_routing_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HTTPRouteMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTTPRouteMatch) ProtoMessage() {}
+
+func (x *HTTPRouteMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTTPRouteMatch.ProtoReflect.Descriptor instead.
+func (*HTTPRouteMatch) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *HTTPRouteMatch) GetPath() *PathMatch {
+	if x != nil {
+		return x.Path
+	}
+	return nil
+}
+
+func (x *HTTPRouteMatch) GetHeaders() []*HeaderMatch {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *HTTPRouteMatch) GetQueryParams() []*QueryParamMatch {
+	if x != nil {
+		return x.QueryParams
+	}
+	return nil
+}
+
+func (x *HTTPRouteMatch) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *HTTPRouteMatch) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// PathMatch defines how to match the request path.
+type PathMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Type of path match.
+	Type PathMatchType `protobuf:"varint,1,opt,name=type,proto3,enum=routing.v1.PathMatchType" json:"type,omitempty"`
+	// Value to match against.
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PathMatch) Reset() {
+	*x = PathMatch{}
+	mi := &file_routing_v1_routing_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PathMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PathMatch) ProtoMessage() {}
+
+func (x *PathMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PathMatch.ProtoReflect.Descriptor instead.
+func (*PathMatch) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PathMatch) GetType() PathMatchType {
+	if x != nil {
+		return x.Type
+	}
+	return PathMatchType_PATH_MATCH_TYPE_UNSPECIFIED
+}
+
+func (x *PathMatch) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// HeaderMatch defines a header matching condition.
+type HeaderMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Header name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Header value to match.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Type of match.
+	Type          HeaderMatchType `protobuf:"varint,3,opt,name=type,proto3,enum=routing.v1.HeaderMatchType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeaderMatch) Reset() {
+	*x = HeaderMatch{}
+	mi := &file_routing_v1_routing_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeaderMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderMatch) ProtoMessage() {}
+
+func (x *HeaderMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderMatch.ProtoReflect.Descriptor instead.
+func (*HeaderMatch) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *HeaderMatch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HeaderMatch) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *HeaderMatch) GetType() HeaderMatchType {
+	if x != nil {
+		return x.Type
+	}
+	return HeaderMatchType_HEADER_MATCH_TYPE_UNSPECIFIED
+}
+
+// QueryParamMatch defines a query parameter matching condition.
+type QueryParamMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Parameter name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Parameter value to match.
+	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	// Type of match.
+	Type          QueryParamMatchType `protobuf:"varint,3,opt,name=type,proto3,enum=routing.v1.QueryParamMatchType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryParamMatch) Reset() {
+	*x = QueryParamMatch{}
+	mi := &file_routing_v1_routing_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryParamMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryParamMatch) ProtoMessage() {}
+
+func (x *QueryParamMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryParamMatch.ProtoReflect.Descriptor instead.
+func (*QueryParamMatch) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *QueryParamMatch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *QueryParamMatch) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *QueryParamMatch) GetType() QueryParamMatchType {
+	if x != nil {
+		return x.Type
+	}
+	return QueryParamMatchType_QUERY_PARAM_MATCH_TYPE_UNSPECIFIED
+}
+
+// GRPCRoute defines a gRPC routing rule.
+type GRPCRoute struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unique identifier for this route.
+	// Derived from namespace and name, optionally suffixed with the route UID.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Hostnames this route matches: the union of the hostnames of all its
+	// listeners. Empty matches all hostnames.
+	Hostnames []string `protobuf:"bytes,2,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	// Routing rules for this GRPCRoute.
+	Rules []*GRPCRouteRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Listener ports the route is attached to, with the hostnames it serves
+	// on each. The route is only served on these ports when set.
+	Listeners     []*RouteListener `protobuf:"bytes,4,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GRPCRoute) Reset() {
+	*x = GRPCRoute{}
+	mi := &file_routing_v1_routing_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GRPCRoute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCRoute) ProtoMessage() {}
+
+func (x *GRPCRoute) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCRoute.ProtoReflect.Descriptor instead.
+func (*GRPCRoute) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GRPCRoute) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GRPCRoute) GetHostnames() []string {
+	if x != nil {
+		return x.Hostnames
+	}
+	return nil
+}
+
+func (x *GRPCRoute) GetRules() []*GRPCRouteRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *GRPCRoute) GetListeners() []*RouteListener {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+// GRPCRouteRule defines a single gRPC routing rule.
+type GRPCRouteRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Matchers for this rule.
+	Matches []*GRPCRouteMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	// Backend references for this rule.
+	Backends []*Backend `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty"`
+	// Fixed response for rules without usable backends.
+	// When set, the proxy must answer matching requests directly
+	// with this response instead of forwarding them.
+	FixedResponse *FixedResponse `protobuf:"bytes,3,opt,name=fixed_response,json=fixedResponse,proto3" json:"fixed_response,omitempty"`
+	// Combined weight of backendRefs that could not be resolved.
+	// The proxy must answer this share of requests, relative to the sum of
+	// backend weights, with a 500 response instead of redistributing them.
+	// Not set when fixed_response is set.
+	InvalidBackendWeight uint32 `protobuf:"varint,4,opt,name=invalid_backend_weight,json=invalidBackendWeight,proto3" json:"invalid_backend_weight,omitempty"`
+	// Name of the rule from the GRPCRoute spec, empty if the rule is unnamed.
+	// Informational only: the proxy should include it in logs and metrics
+	// and must return it unchanged in GetRoutes.
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// Disables all retries and failover for this rule, for endpoints that are
+	// not idempotent. When set, the proxy must send each request to a single
+	// backend exactly once and must not retry it, not even on connection
+	// errors or with a proxy-wide default retry policy.
+	DisableRetries bool `protobuf:"varint,6,opt,name=disable_retries,json=disableRetries,proto3" json:"disable_retries,omitempty"`
+	// Timeout for the whole call in milliseconds, including all streamed
+	// messages. When the client sends a grpc-timeout header, the smaller
+	// value applies. Zero disables the timeout.
+	TimeoutMs uint64 `protobuf:"varint,7,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// Timeout for receiving the response headers from the backend in
+	// milliseconds. Bounds how long a streaming call may take to start
+	// without limiting its total duration. Never larger than timeout_ms
+	// when both are set. Zero disables the timeout.
+	HeaderTimeoutMs uint64 `protobuf:"varint,8,opt,name=header_timeout_ms,json=headerTimeoutMs,proto3" json:"header_timeout_ms,omitempty"`
+	// gRPC-Web bridging for this rule.
+	// When set, the proxy must accept gRPC-Web requests from browsers and
+	// forward them to the backends as native gRPC calls, translating the
+	// responses and trailers back to gRPC-Web.
+	GrpcWeb *GRPCWebConfig `protobuf:"bytes,9,opt,name=grpc_web,json=grpcWeb,proto3" json:"grpc_web,omitempty"`
+	// Header changes of requests matched by the rule, from its
+	// RequestHeaderModifier filters. Applied before those of the backend.
+	RequestHeaderModifier *HeaderModifier `protobuf:"bytes,10,opt,name=request_header_modifier,json=requestHeaderModifier,proto3" json:"request_header_modifier,omitempty"`
+	// Header changes of responses to requests matched by the rule, from its
+	// ResponseHeaderModifier filters. Applied after those of the backend.
+	ResponseHeaderModifier *HeaderModifier `protobuf:"bytes,11,opt,name=response_header_modifier,json=responseHeaderModifier,proto3" json:"response_header_modifier,omitempty"`
+	// Backends receiving copies of requests matched by the rule, from its
+	// RequestMirror filters.
+	Mirrors       []*RequestMirror `protobuf:"bytes,12,rep,name=mirrors,proto3" json:"mirrors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GRPCRouteRule) Reset() {
+	*x = GRPCRouteRule{}
+	mi := &file_routing_v1_routing_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GRPCRouteRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCRouteRule) ProtoMessage() {}
+
+func (x *GRPCRouteRule) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCRouteRule.ProtoReflect.Descriptor instead.
+func (*GRPCRouteRule) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GRPCRouteRule) GetMatches() []*GRPCRouteMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *GRPCRouteRule) GetBackends() []*Backend {
+	if x != nil {
+		return x.Backends
+	}
+	return nil
+}
+
+func (x *GRPCRouteRule) GetFixedResponse() *FixedResponse {
+	if x != nil {
+		return x.FixedResponse
+	}
+	return nil
+}
+
+func (x *GRPCRouteRule) GetInvalidBackendWeight() uint32 {
+	if x != nil {
+		return x.InvalidBackendWeight
+	}
+	return 0
+}
+
+func (x *GRPCRouteRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GRPCRouteRule) GetDisableRetries() bool {
+	if x != nil {
+		return x.DisableRetries
+	}
+	return false
+}
+
+func (x *GRPCRouteRule) GetTimeoutMs() uint64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *GRPCRouteRule) GetHeaderTimeoutMs() uint64 {
+	if x != nil {
+		return x.HeaderTimeoutMs
+	}
+	return 0
+}
+
+func (x *GRPCRouteRule) GetGrpcWeb() *GRPCWebConfig {
+	if x != nil {
+		return x.GrpcWeb
+	}
+	return nil
+}
+
+func (x *GRPCRouteRule) GetRequestHeaderModifier() *HeaderModifier {
+	if x != nil {
+		return x.RequestHeaderModifier
+	}
+	return nil
+}
+
+func (x *GRPCRouteRule) GetResponseHeaderModifier() *HeaderModifier {
+	if x != nil {
+		return x.ResponseHeaderModifier
+	}
+	return nil
+}
+
+func (x *GRPCRouteRule) GetMirrors() []*RequestMirror {
+	if x != nil {
+		return x.Mirrors
+	}
+	return nil
+}
+
+// RequestMirror sends copies of requests to a backend. The proxy must not
+// wait for the mirrored call and must discard its response.
+type RequestMirror struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Backend receiving the copies.
+	Backend *Backend `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	// Share of requests copied, from 0 to 1.
+	Fraction      float64 `protobuf:"fixed64,2,opt,name=fraction,proto3" json:"fraction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestMirror) Reset() {
+	*x = RequestMirror{}
+	mi := &file_routing_v1_routing_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestMirror) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestMirror) ProtoMessage() {}
+
+func (x *RequestMirror) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestMirror.ProtoReflect.Descriptor instead.
+func (*RequestMirror) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RequestMirror) GetBackend() *Backend {
+	if x != nil {
+		return x.Backend
+	}
+	return nil
+}
+
+func (x *RequestMirror) GetFraction() float64 {
+	if x != nil {
+		return x.Fraction
+	}
+	return 0
+}
+
+// GRPCWebConfig defines how gRPC-Web requests are bridged to gRPC.
+type GRPCWebConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the policy the config comes from, for logs and metrics.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Also accept the base64 encoded application/grpc-web-text content type
+	// in addition to application/grpc-web.
+	AllowText     bool `protobuf:"varint,2,opt,name=allow_text,json=allowText,proto3" json:"allow_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GRPCWebConfig) Reset() {
+	*x = GRPCWebConfig{}
+	mi := &file_routing_v1_routing_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GRPCWebConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCWebConfig) ProtoMessage() {}
+
+func (x *GRPCWebConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCWebConfig.ProtoReflect.Descriptor instead.
+func (*GRPCWebConfig) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GRPCWebConfig) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GRPCWebConfig) GetAllowText() bool {
+	if x != nil {
+		return x.AllowText
+	}
+	return false
+}
+
+// GRPCRouteMatch defines conditions for matching a gRPC request.
+type GRPCRouteMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// gRPC service name to match.
+	Method *GRPCMethodMatch `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	// Header match conditions.
+	Headers []*HeaderMatch `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
+	// Precedence of this match among the matches of all gRPC routes, 1 being
+	// the highest. Hostnames are narrowed first, as for HTTPRouteMatch.
+	// Zero means unset.
+	Priority      uint32 `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GRPCRouteMatch) Reset() {
+	*x = GRPCRouteMatch{}
+	mi := &file_routing_v1_routing_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GRPCRouteMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCRouteMatch) ProtoMessage() {}
+
+func (x *GRPCRouteMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCRouteMatch.ProtoReflect.Descriptor instead.
+func (*GRPCRouteMatch) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GRPCRouteMatch) GetMethod() *GRPCMethodMatch {
+	if x != nil {
+		return x.Method
+	}
+	return nil
+}
+
+func (x *GRPCRouteMatch) GetHeaders() []*HeaderMatch {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *GRPCRouteMatch) GetPriority() uint32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// GRPCMethodMatch defines how to match gRPC service/method.
+type GRPCMethodMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Type of method match.
+	Type GRPCMethodMatchType `protobuf:"varint,1,opt,name=type,proto3,enum=routing.v1.GRPCMethodMatchType" json:"type,omitempty"`
+	// Service name (e.g., "example.MyService").
+	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
+	// Method name (e.g., "DoSomething").
+	Method        string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GRPCMethodMatch) Reset() {
+	*x = GRPCMethodMatch{}
+	mi := &file_routing_v1_routing_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GRPCMethodMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GRPCMethodMatch) ProtoMessage() {}
+
+func (x *GRPCMethodMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GRPCMethodMatch.ProtoReflect.Descriptor instead.
+func (*GRPCMethodMatch) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GRPCMethodMatch) GetType() GRPCMethodMatchType {
+	if x != nil {
+		return x.Type
+	}
+	return GRPCMethodMatchType_GRPC_METHOD_MATCH_TYPE_UNSPECIFIED
+}
+
+func (x *GRPCMethodMatch) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *GRPCMethodMatch) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+// Backend defines a backend service endpoint.
+type Backend struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Backend address (host:port).
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// Weight for load balancing (1-100).
+	Weight uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	// Protocol to use for this backend.
+	Protocol BackendProtocol `protobuf:"varint,3,opt,name=protocol,proto3,enum=routing.v1.BackendProtocol" json:"protocol,omitempty"`
+	// Outlier detection for the endpoints of this backend.
+	// When set, the proxy must stop sending requests to failing endpoints.
+	CircuitBreaker *CircuitBreaker `protobuf:"bytes,4,opt,name=circuit_breaker,json=circuitBreaker,proto3" json:"circuit_breaker,omitempty"`
+	// Active health checking of the endpoints of this backend.
+	// When set, the proxy must probe the endpoints and send requests only to
+	// healthy ones.
+	HealthCheck *HealthCheck `protobuf:"bytes,5,opt,name=health_check,json=healthCheck,proto3" json:"health_check,omitempty"`
+	// Static endpoints (host:port) outside the cluster, from a PingoraBackend.
+	// When set, the proxy must balance the requests of this backend across
+	// them; address is the first endpoint.
+	Endpoints []string `protobuf:"bytes,6,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// TLS settings for connections to the backend. Set for the HTTPS protocol.
+	Tls *BackendTLS `protobuf:"bytes,7,opt,name=tls,proto3" json:"tls,omitempty"`
+	// Header changes of requests forwarded to this backend, from the
+	// RequestHeaderModifier filter of its backendRef.
+	RequestHeaderModifier *HeaderModifier `protobuf:"bytes,8,opt,name=request_header_modifier,json=requestHeaderModifier,proto3" json:"request_header_modifier,omitempty"`
+	// Header changes of responses from this backend, from the
+	// ResponseHeaderModifier filter of its backendRef.
+	ResponseHeaderModifier *HeaderModifier `protobuf:"bytes,9,opt,name=response_header_modifier,json=responseHeaderModifier,proto3" json:"response_header_modifier,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *Backend) Reset() {
+	*x = Backend{}
+	mi := &file_routing_v1_routing_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Backend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Backend) ProtoMessage() {}
+
+func (x *Backend) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Backend.ProtoReflect.Descriptor instead.
+func (*Backend) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Backend) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Backend) GetWeight() uint32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *Backend) GetProtocol() BackendProtocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return BackendProtocol_BACKEND_PROTOCOL_UNSPECIFIED
+}
+
+func (x *Backend) GetCircuitBreaker() *CircuitBreaker {
+	if x != nil {
+		return x.CircuitBreaker
+	}
+	return nil
+}
+
+func (x *Backend) GetHealthCheck() *HealthCheck {
+	if x != nil {
+		return x.HealthCheck
+	}
+	return nil
+}
+
+func (x *Backend) GetEndpoints() []string {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *Backend) GetTls() *BackendTLS {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *Backend) GetRequestHeaderModifier() *HeaderModifier {
+	if x != nil {
+		return x.RequestHeaderModifier
+	}
+	return nil
+}
+
+func (x *Backend) GetResponseHeaderModifier() *HeaderModifier {
+	if x != nil {
+		return x.ResponseHeaderModifier
+	}
+	return nil
+}
+
+// HeaderModifier changes the headers of a request or response. The proxy
+// applies set, then add, then remove.
+type HeaderModifier struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Headers replacing any header with the same name.
+	Set []*Header `protobuf:"bytes,1,rep,name=set,proto3" json:"set,omitempty"`
+	// Headers appended to the headers with the same name.
+	Add []*Header `protobuf:"bytes,2,rep,name=add,proto3" json:"add,omitempty"`
+	// Names of the headers removed, case-insensitive.
+	Remove        []string `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeaderModifier) Reset() {
+	*x = HeaderModifier{}
+	mi := &file_routing_v1_routing_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeaderModifier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeaderModifier) ProtoMessage() {}
+
+func (x *HeaderModifier) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeaderModifier.ProtoReflect.Descriptor instead.
+func (*HeaderModifier) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *HeaderModifier) GetSet() []*Header {
+	if x != nil {
+		return x.Set
+	}
+	return nil
+}
+
+func (x *HeaderModifier) GetAdd() []*Header {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *HeaderModifier) GetRemove() []string {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+// Header is an HTTP header.
+type Header struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Header name, case-insensitive.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Header value.
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Header) Reset() {
+	*x = Header{}
+	mi := &file_routing_v1_routing_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Header) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Header) ProtoMessage() {}
+
+func (x *Header) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Header.ProtoReflect.Descriptor instead.
+func (*Header) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Header) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Header) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// BackendTLS configures TLS for connections to a backend.
+type BackendTLS struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Server name sent in the handshake and verified against the certificate.
+	// The host of the endpoint is used if empty.
+	Sni string `protobuf:"bytes,1,opt,name=sni,proto3" json:"sni,omitempty"`
+	// Disables the verification of backend certificates.
+	InsecureSkipVerify bool `protobuf:"varint,2,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+	// PEM-encoded CA certificates that backend certificates are verified
+	// against. The system roots are used if empty.
+	CaCertificates []byte `protobuf:"bytes,3,opt,name=ca_certificates,json=caCertificates,proto3" json:"ca_certificates,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BackendTLS) Reset() {
+	*x = BackendTLS{}
+	mi := &file_routing_v1_routing_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackendTLS) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackendTLS) ProtoMessage() {}
+
+func (x *BackendTLS) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackendTLS.ProtoReflect.Descriptor instead.
+func (*BackendTLS) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *BackendTLS) GetSni() string {
+	if x != nil {
+		return x.Sni
+	}
+	return ""
+}
+
+func (x *BackendTLS) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+func (x *BackendTLS) GetCaCertificates() []byte {
+	if x != nil {
+		return x.CaCertificates
+	}
+	return nil
+}
+
+// HealthCheck defines how the endpoints of a backend are probed.
+type HealthCheck struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the policy the settings come from, for logs and metrics.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// HTTP path requested with GET.
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	// Time between probes of an endpoint in milliseconds.
+	IntervalMs uint64 `protobuf:"varint,3,opt,name=interval_ms,json=intervalMs,proto3" json:"interval_ms,omitempty"`
+	// Time a probe waits for a response in milliseconds.
+	TimeoutMs uint64 `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// Consecutive successful probes that make an endpoint healthy.
+	HealthyThreshold uint32 `protobuf:"varint,5,opt,name=healthy_threshold,json=healthyThreshold,proto3" json:"healthy_threshold,omitempty"`
+	// Consecutive failed probes that make an endpoint unhealthy.
+	UnhealthyThreshold uint32 `protobuf:"varint,6,opt,name=unhealthy_threshold,json=unhealthyThreshold,proto3" json:"unhealthy_threshold,omitempty"`
+	// Response statuses of successful probes. Any 2xx status succeeds if empty.
+	ExpectedStatuses []uint32 `protobuf:"varint,7,rep,packed,name=expected_statuses,json=expectedStatuses,proto3" json:"expected_statuses,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	mi := &file_routing_v1_routing_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheck) ProtoMessage() {}
+
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *HealthCheck) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetIntervalMs() uint64 {
+	if x != nil {
+		return x.IntervalMs
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetTimeoutMs() uint64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetHealthyThreshold() uint32 {
+	if x != nil {
+		return x.HealthyThreshold
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetUnhealthyThreshold() uint32 {
+	if x != nil {
+		return x.UnhealthyThreshold
+	}
+	return 0
+}
+
+func (x *HealthCheck) GetExpectedStatuses() []uint32 {
+	if x != nil {
+		return x.ExpectedStatuses
+	}
+	return nil
+}
+
+// CircuitBreaker ejects failing endpoints of a backend from load balancing.
+type CircuitBreaker struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the policy the settings come from, for logs and metrics.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Consecutive 5xx responses or connection errors that eject an endpoint.
+	ConsecutiveFailures uint32 `protobuf:"varint,2,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// How long an ejected endpoint receives no requests, in milliseconds.
+	EjectionDurationMs uint64 `protobuf:"varint,3,opt,name=ejection_duration_ms,json=ejectionDurationMs,proto3" json:"ejection_duration_ms,omitempty"`
+	// Largest share of the backend's endpoints that may be ejected at the
+	// same time, in percent.
+	MaxEjectionPercent uint32 `protobuf:"varint,4,opt,name=max_ejection_percent,json=maxEjectionPercent,proto3" json:"max_ejection_percent,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *CircuitBreaker) Reset() {
+	*x = CircuitBreaker{}
+	mi := &file_routing_v1_routing_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CircuitBreaker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CircuitBreaker) ProtoMessage() {}
+
+func (x *CircuitBreaker) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CircuitBreaker.ProtoReflect.Descriptor instead.
+func (*CircuitBreaker) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CircuitBreaker) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CircuitBreaker) GetConsecutiveFailures() uint32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *CircuitBreaker) GetEjectionDurationMs() uint64 {
+	if x != nil {
+		return x.EjectionDurationMs
+	}
+	return 0
+}
+
+func (x *CircuitBreaker) GetMaxEjectionPercent() uint32 {
+	if x != nil {
+		return x.MaxEjectionPercent
+	}
+	return 0
+}
+
+// FixedResponse defines a response generated by the proxy itself.
+type FixedResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// HTTP status code to return (e.g., 500).
+	StatusCode uint32 `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	// Reason explains why the fixed response is returned.
+	// Intended for proxy logs, not sent to clients.
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FixedResponse) Reset() {
+	*x = FixedResponse{}
+	mi := &file_routing_v1_routing_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FixedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FixedResponse) ProtoMessage() {}
+
+func (x *FixedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FixedResponse.ProtoReflect.Descriptor instead.
+func (*FixedResponse) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *FixedResponse) GetStatusCode() uint32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *FixedResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// RetryConfig defines retry behavior for failed requests.
+// Connection errors (refused, reset, timed out) are always retried.
+type RetryConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of retry attempts.
+	Attempts uint32 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// Backoff duration between retries in milliseconds.
+	BackoffMs uint64 `protobuf:"varint,2,opt,name=backoff_ms,json=backoffMs,proto3" json:"backoff_ms,omitempty"`
+	// HTTP status codes that trigger a retry.
+	RetryOnStatusCodes []uint32 `protobuf:"varint,3,rep,packed,name=retry_on_status_codes,json=retryOnStatusCodes,proto3" json:"retry_on_status_codes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RetryConfig) Reset() {
+	*x = RetryConfig{}
+	mi := &file_routing_v1_routing_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryConfig) ProtoMessage() {}
+
+func (x *RetryConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryConfig.ProtoReflect.Descriptor instead.
+func (*RetryConfig) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *RetryConfig) GetAttempts() uint32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *RetryConfig) GetBackoffMs() uint64 {
+	if x != nil {
+		return x.BackoffMs
+	}
+	return 0
+}
+
+func (x *RetryConfig) GetRetryOnStatusCodes() []uint32 {
+	if x != nil {
+		return x.RetryOnStatusCodes
+	}
+	return nil
+}
+
+// CORSPolicy defines Cross-Origin Resource Sharing behavior for a rule.
+type CORSPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Allowed origins as "scheme://host[:port]". The host may start with a
+	// "*." wildcard label; a single "*" allows any origin.
+	AllowOrigins []string `protobuf:"bytes,1,rep,name=allow_origins,json=allowOrigins,proto3" json:"allow_origins,omitempty"`
+	// Allowed methods in addition to GET, HEAD and POST. "*" allows any method.
+	AllowMethods []string `protobuf:"bytes,2,rep,name=allow_methods,json=allowMethods,proto3" json:"allow_methods,omitempty"`
+	// Allowed request headers. "*" allows any header.
+	AllowHeaders []string `protobuf:"bytes,3,rep,name=allow_headers,json=allowHeaders,proto3" json:"allow_headers,omitempty"`
+	// Response headers exposed to client scripts.
+	ExposeHeaders []string `protobuf:"bytes,4,rep,name=expose_headers,json=exposeHeaders,proto3" json:"expose_headers,omitempty"`
+	// Whether requests may include credentials. When set, the proxy must
+	// answer with the request's origin, method and headers instead of "*".
+	AllowCredentials bool `protobuf:"varint,5,opt,name=allow_credentials,json=allowCredentials,proto3" json:"allow_credentials,omitempty"`
+	// How long browsers may cache a preflight response, in seconds.
+	MaxAgeSeconds uint32 `protobuf:"varint,6,opt,name=max_age_seconds,json=maxAgeSeconds,proto3" json:"max_age_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CORSPolicy) Reset() {
+	*x = CORSPolicy{}
+	mi := &file_routing_v1_routing_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CORSPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CORSPolicy) ProtoMessage() {}
+
+func (x *CORSPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CORSPolicy.ProtoReflect.Descriptor instead.
+func (*CORSPolicy) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CORSPolicy) GetAllowOrigins() []string {
+	if x != nil {
+		return x.AllowOrigins
+	}
+	return nil
+}
+
+func (x *CORSPolicy) GetAllowMethods() []string {
+	if x != nil {
+		return x.AllowMethods
+	}
+	return nil
+}
+
+func (x *CORSPolicy) GetAllowHeaders() []string {
+	if x != nil {
+		return x.AllowHeaders
+	}
+	return nil
+}
+
+func (x *CORSPolicy) GetExposeHeaders() []string {
+	if x != nil {
+		return x.ExposeHeaders
+	}
+	return nil
+}
+
+func (x *CORSPolicy) GetAllowCredentials() bool {
+	if x != nil {
+		return x.AllowCredentials
+	}
+	return false
+}
+
+func (x *CORSPolicy) GetMaxAgeSeconds() uint32 {
+	if x != nil {
+		return x.MaxAgeSeconds
+	}
+	return 0
+}
+
+// RateLimit defines a token bucket rate limit for a rule.
+type RateLimit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the request budget. Rules with the same id share one budget
+	// per key, so a limit declared once applies to all of them together.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Requests allowed per period.
+	Requests uint32 `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	// Period in milliseconds that requests are counted over.
+	PeriodMs uint64 `protobuf:"varint,3,opt,name=period_ms,json=periodMs,proto3" json:"period_ms,omitempty"`
+	// Requests allowed above the rate in a short burst.
+	Burst uint32 `protobuf:"varint,4,opt,name=burst,proto3" json:"burst,omitempty"`
+	// What requests are grouped by when counted.
+	KeyType RateLimitKeyType `protobuf:"varint,5,opt,name=key_type,json=keyType,proto3,enum=routing.v1.RateLimitKeyType" json:"key_type,omitempty"`
+	// Request header used as the key when key_type is HEADER.
+	KeyHeader     string `protobuf:"bytes,6,opt,name=key_header,json=keyHeader,proto3" json:"key_header,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimit) Reset() {
+	*x = RateLimit{}
+	mi := &file_routing_v1_routing_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimit) ProtoMessage() {}
+
+func (x *RateLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimit.ProtoReflect.Descriptor instead.
+func (*RateLimit) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RateLimit) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RateLimit) GetRequests() uint32 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *RateLimit) GetPeriodMs() uint64 {
+	if x != nil {
+		return x.PeriodMs
+	}
+	return 0
+}
+
+func (x *RateLimit) GetBurst() uint32 {
+	if x != nil {
+		return x.Burst
+	}
+	return 0
+}
+
+func (x *RateLimit) GetKeyType() RateLimitKeyType {
+	if x != nil {
+		return x.KeyType
+	}
+	return RateLimitKeyType_RATE_LIMIT_KEY_TYPE_UNSPECIFIED
+}
+
+func (x *RateLimit) GetKeyHeader() string {
+	if x != nil {
+		return x.KeyHeader
+	}
+	return ""
+}
+
+// AuthConfig defines how requests to a rule are authenticated.
+type AuthConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the policy the config comes from, for logs and metrics.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Validation of a JSON Web Token sent as a bearer token in the
+	// Authorization header.
+	Jwt *JWTAuth `protobuf:"bytes,2,opt,name=jwt,proto3" json:"jwt,omitempty"`
+	// External authorization service consulted before forwarding. When jwt
+	// is also set, only requests with a valid token are sent to the service.
+	External      *ExternalAuth `protobuf:"bytes,3,opt,name=external,proto3" json:"external,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthConfig) Reset() {
+	*x = AuthConfig{}
+	mi := &file_routing_v1_routing_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthConfig) ProtoMessage() {}
+
+func (x *AuthConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthConfig.ProtoReflect.Descriptor instead.
+func (*AuthConfig) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *AuthConfig) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuthConfig) GetJwt() *JWTAuth {
+	if x != nil {
+		return x.Jwt
+	}
+	return nil
+}
+
+func (x *AuthConfig) GetExternal() *ExternalAuth {
+	if x != nil {
+		return x.External
+	}
+	return nil
+}
+
+// ExternalAuth defines an external authorization service. The proxy
+// forwards a request only if the service allows it, and otherwise answers
+// with the service's denial.
+type ExternalAuth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Protocol spoken with the service.
+	Protocol ExternalAuthProtocol `protobuf:"varint,1,opt,name=protocol,proto3,enum=routing.v1.ExternalAuthProtocol" json:"protocol,omitempty"`
+	// Service address in host:port format.
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// Prefix prepended to the request path in HTTP auth requests.
+	PathPrefix string `protobuf:"bytes,3,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
+	// Time in milliseconds the proxy waits for the service.
+	// 0 means the proxy default.
+	TimeoutMs uint64 `protobuf:"varint,4,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	// Forward requests when the service cannot be reached or times out.
+	// Otherwise such requests are answered with 503.
+	FailOpen bool `protobuf:"varint,5,opt,name=fail_open,json=failOpen,proto3" json:"fail_open,omitempty"`
+	// Headers of an allowing response added to the forwarded request.
+	HeadersToBackend []string `protobuf:"bytes,6,rep,name=headers_to_backend,json=headersToBackend,proto3" json:"headers_to_backend,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ExternalAuth) Reset() {
+	*x = ExternalAuth{}
+	mi := &file_routing_v1_routing_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExternalAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalAuth) ProtoMessage() {}
+
+func (x *ExternalAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalAuth.ProtoReflect.Descriptor instead.
+func (*ExternalAuth) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ExternalAuth) GetProtocol() ExternalAuthProtocol {
+	if x != nil {
+		return x.Protocol
+	}
+	return ExternalAuthProtocol_EXTERNAL_AUTH_PROTOCOL_UNSPECIFIED
+}
+
+func (x *ExternalAuth) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ExternalAuth) GetPathPrefix() string {
+	if x != nil {
+		return x.PathPrefix
+	}
+	return ""
+}
+
+func (x *ExternalAuth) GetTimeoutMs() uint64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+func (x *ExternalAuth) GetFailOpen() bool {
+	if x != nil {
+		return x.FailOpen
+	}
+	return false
+}
+
+func (x *ExternalAuth) GetHeadersToBackend() []string {
+	if x != nil {
+		return x.HeadersToBackend
+	}
+	return nil
+}
+
+// JWTAuth defines how bearer tokens are validated. The proxy must also
+// reject tokens that are expired or not yet valid.
+type JWTAuth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Accepted values of the iss claim. Any issuer is accepted if empty.
+	Issuers []string `protobuf:"bytes,1,rep,name=issuers,proto3" json:"issuers,omitempty"`
+	// Accepted values of the aud claim. A token is accepted if its audience
+	// contains one of them. Any audience is accepted if empty.
+	Audiences []string `protobuf:"bytes,2,rep,name=audiences,proto3" json:"audiences,omitempty"`
+	// HTTPS URL that the proxy fetches the JSON Web Key Set from.
+	// Exactly one of jwks_uri and jwks is set.
+	JwksUri string `protobuf:"bytes,3,opt,name=jwks_uri,json=jwksUri,proto3" json:"jwks_uri,omitempty"`
+	// JSON Web Key Set resolved by the controller.
+	Jwks string `protobuf:"bytes,4,opt,name=jwks,proto3" json:"jwks,omitempty"`
+	// Claims of valid tokens copied to request headers.
+	ClaimsToHeaders []*ClaimToHeader `protobuf:"bytes,5,rep,name=claims_to_headers,json=claimsToHeaders,proto3" json:"claims_to_headers,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *JWTAuth) Reset() {
+	*x = JWTAuth{}
+	mi := &file_routing_v1_routing_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JWTAuth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JWTAuth) ProtoMessage() {}
+
+func (x *JWTAuth) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JWTAuth.ProtoReflect.Descriptor instead.
+func (*JWTAuth) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *JWTAuth) GetIssuers() []string {
+	if x != nil {
+		return x.Issuers
+	}
+	return nil
+}
+
+func (x *JWTAuth) GetAudiences() []string {
+	if x != nil {
+		return x.Audiences
+	}
+	return nil
 }
 
-func (x *HeaderMatch) GetName() string {
+func (x *JWTAuth) GetJwksUri() string {
 	if x != nil {
-		return x.Name
+		return x.JwksUri
 	}
 	return ""
 }
 
-func (x *HeaderMatch) GetValue() string {
+func (x *JWTAuth) GetJwks() string {
 	if x != nil {
-		return x.Value
+		return x.Jwks
 	}
 	return ""
 }
 
-func (x *HeaderMatch) GetType() HeaderMatchType {
+func (x *JWTAuth) GetClaimsToHeaders() []*ClaimToHeader {
 	if x != nil {
-		return x.Type
+		return x.ClaimsToHeaders
 	}
-	return HeaderMatchType_HEADER_MATCH_TYPE_UNSPECIFIED
+	return nil
 }
 
-// QueryParamMatch defines a query parameter matching condition.
-type QueryParamMatch struct {
+// ClaimToHeader copies a token claim to a request header. The proxy must
+// remove the header from requests whose token lacks the claim.
+type ClaimToHeader struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Parameter name.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Parameter value to match.
-	Value string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	// Type of match.
-	Type          QueryParamMatchType `protobuf:"varint,3,opt,name=type,proto3,enum=routing.v1.QueryParamMatchType" json:"type,omitempty"`
+	// Name of a top-level claim.
+	Claim string `protobuf:"bytes,1,opt,name=claim,proto3" json:"claim,omitempty"`
+	// Request header the claim is sent in.
+	Header        string `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryParamMatch) Reset() {
-	*x = QueryParamMatch{}
-	mi := &file_routing_v1_routing_proto_msgTypes[11]
+func (x *ClaimToHeader) Reset() {
+	*x = ClaimToHeader{}
+	mi := &file_routing_v1_routing_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryParamMatch) String() string {
+func (x *ClaimToHeader) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryParamMatch) ProtoMessage() {}
+func (*ClaimToHeader) ProtoMessage() {}
 
-func (x *QueryParamMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[11]
+func (x *ClaimToHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1010,60 +5417,58 @@ func (x *QueryParamMatch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryParamMatch.ProtoReflect.Descriptor instead.
-func (*QueryParamMatch) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ClaimToHeader.ProtoReflect.Descriptor instead.
+func (*ClaimToHeader) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *QueryParamMatch) GetName() string {
+func (x *ClaimToHeader) GetClaim() string {
 	if x != nil {
-		return x.Name
+		return x.Claim
 	}
 	return ""
 }
 
-func (x *QueryParamMatch) GetValue() string {
+func (x *ClaimToHeader) GetHeader() string {
 	if x != nil {
-		return x.Value
+		return x.Header
 	}
 	return ""
 }
 
-func (x *QueryParamMatch) GetType() QueryParamMatchType {
-	if x != nil {
-		return x.Type
-	}
-	return QueryParamMatchType_QUERY_PARAM_MATCH_TYPE_UNSPECIFIED
-}
-
-// GRPCRoute defines a gRPC routing rule.
-type GRPCRoute struct {
+// AccessControl restricts the client addresses that may send requests.
+// A request from an address in deny_cidrs is denied. Otherwise a request
+// from an address in allow_cidrs is allowed, and any other request gets
+// default_action. Denied requests are answered with 403.
+type AccessControl struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Unique identifier for this route (namespace/name).
+	// Identifies the policy the restrictions come from, for logs and metrics.
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Hostnames this route matches.
-	Hostnames []string `protobuf:"bytes,2,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
-	// Routing rules for this GRPCRoute.
-	Rules         []*GRPCRouteRule `protobuf:"bytes,3,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Allowed client networks in CIDR notation.
+	AllowCidrs []string `protobuf:"bytes,2,rep,name=allow_cidrs,json=allowCidrs,proto3" json:"allow_cidrs,omitempty"`
+	// Denied client networks in CIDR notation.
+	DenyCidrs []string `protobuf:"bytes,3,rep,name=deny_cidrs,json=denyCidrs,proto3" json:"deny_cidrs,omitempty"`
+	// Action for requests from addresses in neither list.
+	DefaultAction AccessAction `protobuf:"varint,4,opt,name=default_action,json=defaultAction,proto3,enum=routing.v1.AccessAction" json:"default_action,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GRPCRoute) Reset() {
-	*x = GRPCRoute{}
-	mi := &file_routing_v1_routing_proto_msgTypes[12]
+func (x *AccessControl) Reset() {
+	*x = AccessControl{}
+	mi := &file_routing_v1_routing_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GRPCRoute) String() string {
+func (x *AccessControl) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GRPCRoute) ProtoMessage() {}
+func (*AccessControl) ProtoMessage() {}
 
-func (x *GRPCRoute) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[12]
+func (x *AccessControl) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1074,58 +5479,75 @@ func (x *GRPCRoute) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GRPCRoute.ProtoReflect.Descriptor instead.
-func (*GRPCRoute) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use AccessControl.ProtoReflect.Descriptor instead.
+func (*AccessControl) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *GRPCRoute) GetId() string {
+func (x *AccessControl) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *GRPCRoute) GetHostnames() []string {
+func (x *AccessControl) GetAllowCidrs() []string {
 	if x != nil {
-		return x.Hostnames
+		return x.AllowCidrs
 	}
 	return nil
 }
 
-func (x *GRPCRoute) GetRules() []*GRPCRouteRule {
+func (x *AccessControl) GetDenyCidrs() []string {
 	if x != nil {
-		return x.Rules
+		return x.DenyCidrs
 	}
 	return nil
 }
 
-// GRPCRouteRule defines a single gRPC routing rule.
-type GRPCRouteRule struct {
+func (x *AccessControl) GetDefaultAction() AccessAction {
+	if x != nil {
+		return x.DefaultAction
+	}
+	return AccessAction_ACCESS_ACTION_UNSPECIFIED
+}
+
+// CacheConfig defines how responses are cached.
+// Responses with Cache-Control no-store or private must not be cached.
+type CacheConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Matchers for this rule.
-	Matches []*GRPCRouteMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
-	// Backend references for this rule.
-	Backends      []*Backend `protobuf:"bytes,2,rep,name=backends,proto3" json:"backends,omitempty"`
+	// Identifies the policy the config comes from, for logs and metrics.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// How long a response is served from the cache in milliseconds. A shorter
+	// max-age or s-maxage of the response takes precedence.
+	TtlMs uint64 `protobuf:"varint,2,opt,name=ttl_ms,json=ttlMs,proto3" json:"ttl_ms,omitempty"`
+	// Parts of the request that identify a cached response.
+	Key *CacheKey `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	// Request headers that select between variants of a cached response, in
+	// addition to those in the Vary header of the response.
+	VaryHeaders []string `protobuf:"bytes,4,rep,name=vary_headers,json=varyHeaders,proto3" json:"vary_headers,omitempty"`
+	// Requests matching any of these rules are neither served from nor stored
+	// in the cache.
+	Bypass        []*CacheBypass `protobuf:"bytes,5,rep,name=bypass,proto3" json:"bypass,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GRPCRouteRule) Reset() {
-	*x = GRPCRouteRule{}
-	mi := &file_routing_v1_routing_proto_msgTypes[13]
+func (x *CacheConfig) Reset() {
+	*x = CacheConfig{}
+	mi := &file_routing_v1_routing_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GRPCRouteRule) String() string {
+func (x *CacheConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GRPCRouteRule) ProtoMessage() {}
+func (*CacheConfig) ProtoMessage() {}
 
-func (x *GRPCRouteRule) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[13]
+func (x *CacheConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1136,108 +5558,78 @@ func (x *GRPCRouteRule) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GRPCRouteRule.ProtoReflect.Descriptor instead.
-func (*GRPCRouteRule) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use CacheConfig.ProtoReflect.Descriptor instead.
+func (*CacheConfig) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *GRPCRouteRule) GetMatches() []*GRPCRouteMatch {
+func (x *CacheConfig) GetId() string {
 	if x != nil {
-		return x.Matches
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *GRPCRouteRule) GetBackends() []*Backend {
+func (x *CacheConfig) GetTtlMs() uint64 {
 	if x != nil {
-		return x.Backends
+		return x.TtlMs
 	}
-	return nil
-}
-
-// GRPCRouteMatch defines conditions for matching a gRPC request.
-type GRPCRouteMatch struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// gRPC service name to match.
-	Method *GRPCMethodMatch `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
-	// Header match conditions.
-	Headers       []*HeaderMatch `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GRPCRouteMatch) Reset() {
-	*x = GRPCRouteMatch{}
-	mi := &file_routing_v1_routing_proto_msgTypes[14]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GRPCRouteMatch) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return 0
 }
 
-func (*GRPCRouteMatch) ProtoMessage() {}
-
-func (x *GRPCRouteMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[14]
+func (x *CacheConfig) GetKey() *CacheKey {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Key
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GRPCRouteMatch.ProtoReflect.Descriptor instead.
-func (*GRPCRouteMatch) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{14}
+	return nil
 }
 
-func (x *GRPCRouteMatch) GetMethod() *GRPCMethodMatch {
+func (x *CacheConfig) GetVaryHeaders() []string {
 	if x != nil {
-		return x.Method
+		return x.VaryHeaders
 	}
 	return nil
 }
 
-func (x *GRPCRouteMatch) GetHeaders() []*HeaderMatch {
+func (x *CacheConfig) GetBypass() []*CacheBypass {
 	if x != nil {
-		return x.Headers
+		return x.Bypass
 	}
 	return nil
 }
 
-// GRPCMethodMatch defines how to match gRPC service/method.
-type GRPCMethodMatch struct {
+// CacheKey defines the parts of a request that identify a cached response.
+// The method, host and path are always part of the key.
+type CacheKey struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Type of method match.
-	Type GRPCMethodMatchType `protobuf:"varint,1,opt,name=type,proto3,enum=routing.v1.GRPCMethodMatchType" json:"type,omitempty"`
-	// Service name (e.g., "example.MyService").
-	Service string `protobuf:"bytes,2,opt,name=service,proto3" json:"service,omitempty"`
-	// Method name (e.g., "DoSomething").
-	Method        string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	// Leave the query string out of the key.
+	IgnoreQuery bool `protobuf:"varint,1,opt,name=ignore_query,json=ignoreQuery,proto3" json:"ignore_query,omitempty"`
+	// Limit the query string in the key to these parameters.
+	// The whole query string is used if empty and ignore_query is false.
+	QueryParameters []string `protobuf:"bytes,2,rep,name=query_parameters,json=queryParameters,proto3" json:"query_parameters,omitempty"`
+	// Request headers whose values are added to the key.
+	Headers []string `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty"`
+	// Request cookies whose values are added to the key.
+	Cookies       []string `protobuf:"bytes,4,rep,name=cookies,proto3" json:"cookies,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GRPCMethodMatch) Reset() {
-	*x = GRPCMethodMatch{}
-	mi := &file_routing_v1_routing_proto_msgTypes[15]
+func (x *CacheKey) Reset() {
+	*x = CacheKey{}
+	mi := &file_routing_v1_routing_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GRPCMethodMatch) String() string {
+func (x *CacheKey) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GRPCMethodMatch) ProtoMessage() {}
+func (*CacheKey) ProtoMessage() {}
 
-func (x *GRPCMethodMatch) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[15]
+func (x *CacheKey) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1248,60 +5640,68 @@ func (x *GRPCMethodMatch) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GRPCMethodMatch.ProtoReflect.Descriptor instead.
-func (*GRPCMethodMatch) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use CacheKey.ProtoReflect.Descriptor instead.
+func (*CacheKey) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *GRPCMethodMatch) GetType() GRPCMethodMatchType {
+func (x *CacheKey) GetIgnoreQuery() bool {
 	if x != nil {
-		return x.Type
+		return x.IgnoreQuery
 	}
-	return GRPCMethodMatchType_GRPC_METHOD_MATCH_TYPE_UNSPECIFIED
+	return false
 }
 
-func (x *GRPCMethodMatch) GetService() string {
+func (x *CacheKey) GetQueryParameters() []string {
 	if x != nil {
-		return x.Service
+		return x.QueryParameters
 	}
-	return ""
+	return nil
 }
 
-func (x *GRPCMethodMatch) GetMethod() string {
+func (x *CacheKey) GetHeaders() []string {
 	if x != nil {
-		return x.Method
+		return x.Headers
 	}
-	return ""
+	return nil
 }
 
-// Backend defines a backend service endpoint.
-type Backend struct {
+func (x *CacheKey) GetCookies() []string {
+	if x != nil {
+		return x.Cookies
+	}
+	return nil
+}
+
+// CacheBypass matches requests that skip the cache.
+type CacheBypass struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Backend address (host:port).
-	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	// Weight for load balancing (1-100).
-	Weight uint32 `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
-	// Protocol to use for this backend.
-	Protocol      BackendProtocol `protobuf:"varint,3,opt,name=protocol,proto3,enum=routing.v1.BackendProtocol" json:"protocol,omitempty"`
+	// Part of the request that is matched.
+	Type CacheBypassType `protobuf:"varint,1,opt,name=type,proto3,enum=routing.v1.CacheBypassType" json:"type,omitempty"`
+	// Name of the header or cookie.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Exact value to match. Any request with the header or cookie matches if
+	// empty.
+	Value         string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Backend) Reset() {
-	*x = Backend{}
-	mi := &file_routing_v1_routing_proto_msgTypes[16]
+func (x *CacheBypass) Reset() {
+	*x = CacheBypass{}
+	mi := &file_routing_v1_routing_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Backend) String() string {
+func (x *CacheBypass) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Backend) ProtoMessage() {}
+func (*CacheBypass) ProtoMessage() {}
 
-func (x *Backend) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[16]
+func (x *CacheBypass) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1312,60 +5712,64 @@ func (x *Backend) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Backend.ProtoReflect.Descriptor instead.
-func (*Backend) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use CacheBypass.ProtoReflect.Descriptor instead.
+func (*CacheBypass) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *Backend) GetAddress() string {
+func (x *CacheBypass) GetType() CacheBypassType {
 	if x != nil {
-		return x.Address
+		return x.Type
 	}
-	return ""
+	return CacheBypassType_CACHE_BYPASS_TYPE_UNSPECIFIED
 }
 
-func (x *Backend) GetWeight() uint32 {
+func (x *CacheBypass) GetName() string {
 	if x != nil {
-		return x.Weight
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *Backend) GetProtocol() BackendProtocol {
+func (x *CacheBypass) GetValue() string {
 	if x != nil {
-		return x.Protocol
+		return x.Value
 	}
-	return BackendProtocol_BACKEND_PROTOCOL_UNSPECIFIED
+	return ""
 }
 
-// RetryConfig defines retry behavior for failed requests.
-type RetryConfig struct {
+// SessionPersistence defines sticky session behavior for a rule.
+type SessionPersistence struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Number of retry attempts.
-	Attempts uint32 `protobuf:"varint,1,opt,name=attempts,proto3" json:"attempts,omitempty"`
-	// Backoff duration between retries in milliseconds.
-	BackoffMs uint64 `protobuf:"varint,2,opt,name=backoff_ms,json=backoffMs,proto3" json:"backoff_ms,omitempty"`
-	// HTTP status codes that trigger a retry.
-	RetryOnStatusCodes []uint32 `protobuf:"varint,3,rep,packed,name=retry_on_status_codes,json=retryOnStatusCodes,proto3" json:"retry_on_status_codes,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// How the session token is carried.
+	Type SessionPersistenceType `protobuf:"varint,1,opt,name=type,proto3,enum=routing.v1.SessionPersistenceType" json:"type,omitempty"`
+	// Name of the cookie or header carrying the session token.
+	SessionName string `protobuf:"bytes,2,opt,name=session_name,json=sessionName,proto3" json:"session_name,omitempty"`
+	// Absolute session lifetime in milliseconds. 0 means unlimited.
+	AbsoluteTimeoutMs uint64 `protobuf:"varint,3,opt,name=absolute_timeout_ms,json=absoluteTimeoutMs,proto3" json:"absolute_timeout_ms,omitempty"`
+	// Idle session timeout in milliseconds. 0 means unlimited.
+	IdleTimeoutMs uint64 `protobuf:"varint,4,opt,name=idle_timeout_ms,json=idleTimeoutMs,proto3" json:"idle_timeout_ms,omitempty"`
+	// Lifetime of the session cookie. Only used for cookie-based persistence.
+	CookieLifetime CookieLifetimeType `protobuf:"varint,5,opt,name=cookie_lifetime,json=cookieLifetime,proto3,enum=routing.v1.CookieLifetimeType" json:"cookie_lifetime,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *RetryConfig) Reset() {
-	*x = RetryConfig{}
-	mi := &file_routing_v1_routing_proto_msgTypes[17]
+func (x *SessionPersistence) Reset() {
+	*x = SessionPersistence{}
+	mi := &file_routing_v1_routing_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RetryConfig) String() string {
+func (x *SessionPersistence) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RetryConfig) ProtoMessage() {}
+func (*SessionPersistence) ProtoMessage() {}
 
-func (x *RetryConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_routing_v1_routing_proto_msgTypes[17]
+func (x *SessionPersistence) ProtoReflect() protoreflect.Message {
+	mi := &file_routing_v1_routing_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1376,30 +5780,44 @@ func (x *RetryConfig) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RetryConfig.ProtoReflect.Descriptor instead.
-func (*RetryConfig) Descriptor() ([]byte, []int) {
-	return file_routing_v1_routing_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use SessionPersistence.ProtoReflect.Descriptor instead.
+func (*SessionPersistence) Descriptor() ([]byte, []int) {
+	return file_routing_v1_routing_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *RetryConfig) GetAttempts() uint32 {
+func (x *SessionPersistence) GetType() SessionPersistenceType {
 	if x != nil {
-		return x.Attempts
+		return x.Type
+	}
+	return SessionPersistenceType_SESSION_PERSISTENCE_TYPE_UNSPECIFIED
+}
+
+func (x *SessionPersistence) GetSessionName() string {
+	if x != nil {
+		return x.SessionName
+	}
+	return ""
+}
+
+func (x *SessionPersistence) GetAbsoluteTimeoutMs() uint64 {
+	if x != nil {
+		return x.AbsoluteTimeoutMs
 	}
 	return 0
 }
 
-func (x *RetryConfig) GetBackoffMs() uint64 {
+func (x *SessionPersistence) GetIdleTimeoutMs() uint64 {
 	if x != nil {
-		return x.BackoffMs
+		return x.IdleTimeoutMs
 	}
 	return 0
 }
 
-func (x *RetryConfig) GetRetryOnStatusCodes() []uint32 {
+func (x *SessionPersistence) GetCookieLifetime() CookieLifetimeType {
 	if x != nil {
-		return x.RetryOnStatusCodes
+		return x.CookieLifetime
 	}
-	return nil
+	return CookieLifetimeType_COOKIE_LIFETIME_TYPE_UNSPECIFIED
 }
 
 var File_routing_v1_routing_proto protoreflect.FileDescriptor
@@ -1407,47 +5825,201 @@ var File_routing_v1_routing_proto protoreflect.FileDescriptor
 const file_routing_v1_routing_proto_rawDesc = "" +
 	"\n" +
 	"\x18routing/v1/routing.proto\x12\n" +
-	"routing.v1\"\x9f\x01\n" +
+	"routing.v1\"\x98\x02\n" +
 	"\x13UpdateRoutesRequest\x126\n" +
 	"\vhttp_routes\x18\x01 \x03(\v2\x15.routing.v1.HTTPRouteR\n" +
 	"httpRoutes\x126\n" +
 	"\vgrpc_routes\x18\x02 \x03(\v2\x15.routing.v1.GRPCRouteR\n" +
 	"grpcRoutes\x12\x18\n" +
-	"\aversion\x18\x03 \x01(\x04R\aversion\"\xc3\x01\n" +
+	"\aversion\x18\x03 \x01(\x04R\aversion\x122\n" +
+	"\tlisteners\x18\x04 \x03(\v2\x14.routing.v1.ListenerR\tlisteners\x12.\n" +
+	"\x10expected_version\x18\x05 \x01(\x04H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"\xee\x01\n" +
 	"\x14UpdateRoutesResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12'\n" +
 	"\x0fapplied_version\x18\x03 \x01(\x04R\x0eappliedVersion\x12(\n" +
 	"\x10http_route_count\x18\x04 \x01(\rR\x0ehttpRouteCount\x12(\n" +
-	"\x10grpc_route_count\x18\x05 \x01(\rR\x0egrpcRouteCount\"\x12\n" +
-	"\x10GetRoutesRequest\"\x9d\x01\n" +
+	"\x10grpc_route_count\x18\x05 \x01(\rR\x0egrpcRouteCount\x12)\n" +
+	"\x10version_conflict\x18\x06 \x01(\bR\x0fversionConflict\"\x12\n" +
+	"\x10GetRoutesRequest\"\xd1\x01\n" +
 	"\x11GetRoutesResponse\x126\n" +
 	"\vhttp_routes\x18\x01 \x03(\v2\x15.routing.v1.HTTPRouteR\n" +
 	"httpRoutes\x126\n" +
 	"\vgrpc_routes\x18\x02 \x03(\v2\x15.routing.v1.GRPCRouteR\n" +
 	"grpcRoutes\x12\x18\n" +
-	"\aversion\x18\x03 \x01(\x04R\aversion\"\x0f\n" +
+	"\aversion\x18\x03 \x01(\x04R\aversion\x122\n" +
+	"\tlisteners\x18\x04 \x03(\v2\x14.routing.v1.ListenerR\tlisteners\"\x0f\n" +
 	"\rHealthRequest\"\x98\x01\n" +
 	"\x0eHealthResponse\x12\x18\n" +
 	"\ahealthy\x18\x01 \x01(\bR\ahealthy\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12-\n" +
 	"\x12active_connections\x18\x03 \x01(\x04R\x11activeConnections\x12%\n" +
-	"\x0econfig_version\x18\x04 \x01(\x04R\rconfigVersion\"j\n" +
+	"\x0econfig_version\x18\x04 \x01(\x04R\rconfigVersion\"\x19\n" +
+	"\x17GetBackendHealthRequest\"Q\n" +
+	"\x18GetBackendHealthResponse\x125\n" +
+	"\bbackends\x18\x01 \x03(\v2\x19.routing.v1.BackendHealthR\bbackends\"\x87\x01\n" +
+	"\rBackendHealth\x12\x18\n" +
+	"\aaddress\x18\x01 \x01(\tR\aaddress\x12+\n" +
+	"\x11healthy_endpoints\x18\x02 \x01(\rR\x10healthyEndpoints\x12/\n" +
+	"\x13unhealthy_endpoints\x18\x03 \x01(\rR\x12unhealthyEndpoints\"\x16\n" +
+	"\x14GetRouteStatsRequest\"\xab\x01\n" +
+	"\x15GetRouteStatsResponse\x12.\n" +
+	"\x06routes\x18\x01 \x03(\v2\x16.routing.v1.RouteStatsR\x06routes\x127\n" +
+	"\tlisteners\x18\x02 \x03(\v2\x19.routing.v1.ListenerStatsR\tlisteners\x12)\n" +
+	"\x10duration_buckets\x18\x03 \x03(\x01R\x0fdurationBuckets\"\x9f\x01\n" +
+	"\n" +
+	"RouteStats\x12\x19\n" +
+	"\broute_id\x18\x01 \x01(\tR\arouteId\x12:\n" +
+	"\tresponses\x18\x02 \x03(\v2\x1c.routing.v1.StatusClassCountR\tresponses\x12:\n" +
+	"\tdurations\x18\x03 \x01(\v2\x1c.routing.v1.RequestDurationsR\tdurations\"\x9b\x01\n" +
+	"\rListenerStats\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\rR\x04port\x12:\n" +
+	"\tresponses\x18\x02 \x03(\v2\x1c.routing.v1.StatusClassCountR\tresponses\x12:\n" +
+	"\tdurations\x18\x03 \x01(\v2\x1c.routing.v1.RequestDurationsR\tdurations\"K\n" +
+	"\x10StatusClassCount\x12!\n" +
+	"\fstatus_class\x18\x01 \x01(\rR\vstatusClass\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\"n\n" +
+	"\x10RequestDurations\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\x12\x1f\n" +
+	"\vsum_seconds\x18\x02 \x01(\x01R\n" +
+	"sumSeconds\x12#\n" +
+	"\rbucket_counts\x18\x03 \x03(\x04R\fbucketCounts\"M\n" +
+	"\x19UpdateGlobalConfigRequest\x120\n" +
+	"\x06config\x18\x01 \x01(\v2\x18.routing.v1.GlobalConfigR\x06config\"L\n" +
+	"\x1aUpdateGlobalConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x8b\x03\n" +
+	"\fGlobalConfig\x12;\n" +
+	"\x1adefault_request_timeout_ms\x18\x01 \x01(\x04R\x17defaultRequestTimeoutMs\x123\n" +
+	"\x16max_request_body_bytes\x18\x02 \x01(\x04R\x13maxRequestBodyBytes\x12G\n" +
+	"\x11access_log_format\x18\x03 \x01(\x0e2\x1b.routing.v1.AccessLogFormatR\x0faccessLogFormat\x12(\n" +
+	"\rhttp2_enabled\x18\x04 \x01(\bH\x00R\fhttp2Enabled\x88\x01\x01\x12.\n" +
+	"\x13trusted_proxy_cidrs\x18\x05 \x03(\tR\x11trustedProxyCidrs\x12.\n" +
+	"\x13forwarded_for_depth\x18\x06 \x01(\rR\x11forwardedForDepth\x12$\n" +
+	"\x0ereal_ip_header\x18\a \x01(\tR\frealIpHeaderB\x10\n" +
+	"\x0e_http2_enabled\"O\n" +
+	"\x1aUpdateLoggingConfigRequest\x121\n" +
+	"\x06config\x18\x01 \x01(\v2\x19.routing.v1.LoggingConfigR\x06config\"M\n" +
+	"\x1bUpdateLoggingConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x83\x02\n" +
+	"\rLoggingConfig\x123\n" +
+	"\x06format\x18\x01 \x01(\x0e2\x1b.routing.v1.AccessLogFormatR\x06format\x12\x16\n" +
+	"\x06fields\x18\x02 \x03(\tR\x06fields\x12*\n" +
+	"\x0esample_percent\x18\x03 \x01(\rH\x00R\rsamplePercent\x88\x01\x01\x122\n" +
+	"\x15include_path_prefixes\x18\x04 \x03(\tR\x13includePathPrefixes\x122\n" +
+	"\x15exclude_path_prefixes\x18\x05 \x03(\tR\x13excludePathPrefixesB\x11\n" +
+	"\x0f_sample_percent\"\xd0\x01\n" +
+	"\x19UpdateCertificatesRequest\x12>\n" +
+	"\tlisteners\x18\x01 \x03(\v2 .routing.v1.ListenerCertificatesR\tlisteners\x123\n" +
+	"\bfallback\x18\x02 \x01(\v2\x17.routing.v1.CertificateR\bfallback\x12>\n" +
+	"\tselection\x18\x03 \x01(\x0e2 .routing.v1.CertificateSelectionR\tselection\"L\n" +
+	"\x1aUpdateCertificatesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"g\n" +
+	"\x14ListenerCertificates\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\rR\x04port\x12;\n" +
+	"\fcertificates\x18\x02 \x03(\v2\x17.routing.v1.CertificateR\fcertificates\"\xd8\x01\n" +
+	"\vCertificate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\thostnames\x18\x02 \x03(\tR\thostnames\x12+\n" +
+	"\x11certificate_chain\x18\x03 \x01(\fR\x10certificateChain\x12\x1f\n" +
+	"\vprivate_key\x18\x04 \x01(\fR\n" +
+	"privateKey\x12I\n" +
+	"\x11client_validation\x18\x05 \x01(\v2\x1c.routing.v1.ClientValidationR\x10clientValidation\"q\n" +
+	"\x10ClientValidation\x12'\n" +
+	"\x0fca_certificates\x18\x01 \x01(\fR\x0ecaCertificates\x124\n" +
+	"\x04mode\x18\x02 \x01(\x0e2 .routing.v1.ClientValidationModeR\x04mode\"\x87\x01\n" +
+	"\x13StreamRoutesRequest\x125\n" +
+	"\x04full\x18\x01 \x01(\v2\x1f.routing.v1.UpdateRoutesRequestH\x00R\x04full\x12/\n" +
+	"\x05delta\x18\x02 \x01(\v2\x17.routing.v1.RoutesDeltaH\x00R\x05deltaB\b\n" +
+	"\x06update\"\xba\x02\n" +
+	"\vRoutesDelta\x12!\n" +
+	"\fbase_version\x18\x01 \x01(\x04R\vbaseVersion\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\x12C\n" +
+	"\x12upsert_http_routes\x18\x03 \x03(\v2\x15.routing.v1.HTTPRouteR\x10upsertHttpRoutes\x12C\n" +
+	"\x12upsert_grpc_routes\x18\x04 \x03(\v2\x15.routing.v1.GRPCRouteR\x10upsertGrpcRoutes\x121\n" +
+	"\x15remove_http_route_ids\x18\x05 \x03(\tR\x12removeHttpRouteIds\x121\n" +
+	"\x15remove_grpc_route_ids\x18\x06 \x03(\tR\x12removeGrpcRouteIds\"\x8d\x01\n" +
+	"\x14StreamRoutesResponse\x124\n" +
+	"\x03ack\x18\x01 \x01(\v2 .routing.v1.UpdateRoutesResponseH\x00R\x03ack\x124\n" +
+	"\x06health\x18\x02 \x01(\v2\x1a.routing.v1.HealthResponseH\x00R\x06healthB\t\n" +
+	"\amessage\"{\n" +
+	"\x13DeleteRoutesRequest\x12$\n" +
+	"\x0ehttp_route_ids\x18\x01 \x03(\tR\fhttpRouteIds\x12$\n" +
+	"\x0egrpc_route_ids\x18\x02 \x03(\tR\fgrpcRouteIds\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x04R\aversion\"\x81\x03\n" +
+	"\bListener\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\rR\x04port\x122\n" +
+	"\x06limits\x18\x02 \x01(\v2\x1a.routing.v1.ListenerLimitsR\x06limits\x12B\n" +
+	"\x0faccess_controls\x18\x03 \x03(\v2\x19.routing.v1.AccessControlR\x0eaccessControls\x12'\n" +
+	"\x05http3\x18\x04 \x01(\v2\x11.routing.v1.HTTP3R\x05http3\x12@\n" +
+	"\x0eproxy_protocol\x18\x05 \x01(\v2\x19.routing.v1.ProxyProtocolR\rproxyProtocol\x12:\n" +
+	"\tclient_ip\x18\x06 \x01(\v2\x1d.routing.v1.ClientIPDetectionR\bclientIp\x12B\n" +
+	"\x0fhttps_redirects\x18\a \x03(\v2\x19.routing.v1.HTTPSRedirectR\x0ehttpsRedirects\"\x94\x01\n" +
+	"\rHTTPSRedirect\x12\x1c\n" +
+	"\thostnames\x18\x01 \x03(\tR\thostnames\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\rR\x04port\x12\x1f\n" +
+	"\vstatus_code\x18\x03 \x01(\rR\n" +
+	"statusCode\x120\n" +
+	"\x14exempt_path_prefixes\x18\x04 \x03(\tR\x12exemptPathPrefixes\"\x99\x01\n" +
+	"\x11ClientIPDetection\x12.\n" +
+	"\x13trusted_proxy_cidrs\x18\x01 \x03(\tR\x11trustedProxyCidrs\x12.\n" +
+	"\x13forwarded_for_depth\x18\x02 \x01(\rR\x11forwardedForDepth\x12$\n" +
+	"\x0ereal_ip_header\x18\x03 \x01(\tR\frealIpHeader\"K\n" +
+	"\rProxyProtocol\x12:\n" +
+	"\aversion\x18\x01 \x01(\x0e2 .routing.v1.ProxyProtocolVersionR\aversion\"z\n" +
+	"\x05HTTP3\x12\x19\n" +
+	"\budp_port\x18\x01 \x01(\rR\audpPort\x12 \n" +
+	"\falt_svc_port\x18\x02 \x01(\rR\n" +
+	"altSvcPort\x124\n" +
+	"\x17alt_svc_max_age_seconds\x18\x03 \x01(\x04R\x13altSvcMaxAgeSeconds\"\xca\x02\n" +
+	"\x0eListenerLimits\x123\n" +
+	"\x16max_request_body_bytes\x18\x01 \x01(\x04R\x13maxRequestBodyBytes\x129\n" +
+	"\x19max_request_headers_bytes\x18\x02 \x01(\x04R\x16maxRequestHeadersBytes\x12.\n" +
+	"\x13max_request_headers\x18\x03 \x01(\rR\x11maxRequestHeaders\x129\n" +
+	"\x19request_header_timeout_ms\x18\x04 \x01(\x04R\x16requestHeaderTimeoutMs\x125\n" +
+	"\x17request_body_timeout_ms\x18\x05 \x01(\x04R\x14requestBodyTimeoutMs\x12&\n" +
+	"\x0fidle_timeout_ms\x18\x06 \x01(\x04R\ridleTimeoutMs\"p\n" +
+	"\rRouteListener\x12\x12\n" +
+	"\x04port\x18\x01 \x01(\rR\x04port\x12\x1c\n" +
+	"\thostnames\x18\x02 \x03(\tR\thostnames\x12-\n" +
+	"\x12excluded_hostnames\x18\x03 \x03(\tR\x11excludedHostnames\"\xa3\x01\n" +
 	"\tHTTPRoute\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
 	"\thostnames\x18\x02 \x03(\tR\thostnames\x12/\n" +
-	"\x05rules\x18\x03 \x03(\v2\x19.routing.v1.HTTPRouteRuleR\x05rules\"\xc4\x01\n" +
+	"\x05rules\x18\x03 \x03(\v2\x19.routing.v1.HTTPRouteRuleR\x05rules\x127\n" +
+	"\tlisteners\x18\x04 \x03(\v2\x19.routing.v1.RouteListenerR\tlisteners\"\xb2\x06\n" +
 	"\rHTTPRouteRule\x124\n" +
 	"\amatches\x18\x01 \x03(\v2\x1a.routing.v1.HTTPRouteMatchR\amatches\x12/\n" +
 	"\bbackends\x18\x02 \x03(\v2\x13.routing.v1.BackendR\bbackends\x12\x1d\n" +
 	"\n" +
 	"timeout_ms\x18\x03 \x01(\x04R\ttimeoutMs\x12-\n" +
-	"\x05retry\x18\x04 \x01(\v2\x17.routing.v1.RetryConfigR\x05retry\"\xc6\x01\n" +
+	"\x05retry\x18\x04 \x01(\v2\x17.routing.v1.RetryConfigR\x05retry\x12@\n" +
+	"\x0efixed_response\x18\x05 \x01(\v2\x19.routing.v1.FixedResponseR\rfixedResponse\x124\n" +
+	"\x16invalid_backend_weight\x18\x06 \x01(\rR\x14invalidBackendWeight\x12O\n" +
+	"\x13session_persistence\x18\a \x01(\v2\x1e.routing.v1.SessionPersistenceR\x12sessionPersistence\x12,\n" +
+	"\x12backend_timeout_ms\x18\b \x01(\x04R\x10backendTimeoutMs\x12\x12\n" +
+	"\x04name\x18\t \x01(\tR\x04name\x12'\n" +
+	"\x0fdisable_retries\x18\n" +
+	" \x01(\bR\x0edisableRetries\x12*\n" +
+	"\x04cors\x18\v \x01(\v2\x16.routing.v1.CORSPolicyR\x04cors\x124\n" +
+	"\n" +
+	"rate_limit\x18\f \x01(\v2\x15.routing.v1.RateLimitR\trateLimit\x12*\n" +
+	"\x04auth\x18\r \x01(\v2\x16.routing.v1.AuthConfigR\x04auth\x12@\n" +
+	"\x0eaccess_control\x18\x0e \x01(\v2\x19.routing.v1.AccessControlR\raccessControl\x12-\n" +
+	"\x05cache\x18\x0f \x01(\v2\x17.routing.v1.CacheConfigR\x05cache\x129\n" +
+	"\n" +
+	"access_log\x18\x10 \x01(\v2\x1a.routing.v1.RouteAccessLogR\taccessLog\"7\n" +
+	"\x0eRouteAccessLog\x12%\n" +
+	"\x0esample_percent\x18\x01 \x01(\rR\rsamplePercent\"\xe2\x01\n" +
 	"\x0eHTTPRouteMatch\x12)\n" +
 	"\x04path\x18\x01 \x01(\v2\x15.routing.v1.PathMatchR\x04path\x121\n" +
 	"\aheaders\x18\x02 \x03(\v2\x17.routing.v1.HeaderMatchR\aheaders\x12>\n" +
 	"\fquery_params\x18\x03 \x03(\v2\x1b.routing.v1.QueryParamMatchR\vqueryParams\x12\x16\n" +
-	"\x06method\x18\x04 \x01(\tR\x06method\"P\n" +
+	"\x06method\x18\x04 \x01(\tR\x06method\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\rR\bpriority\"P\n" +
 	"\tPathMatch\x12-\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x19.routing.v1.PathMatchTypeR\x04type\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value\"h\n" +
@@ -1458,30 +6030,170 @@ const file_routing_v1_routing_proto_rawDesc = "" +
 	"\x0fQueryParamMatch\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value\x123\n" +
-	"\x04type\x18\x03 \x01(\x0e2\x1f.routing.v1.QueryParamMatchTypeR\x04type\"j\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1f.routing.v1.QueryParamMatchTypeR\x04type\"\xa3\x01\n" +
 	"\tGRPCRoute\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1c\n" +
 	"\thostnames\x18\x02 \x03(\tR\thostnames\x12/\n" +
-	"\x05rules\x18\x03 \x03(\v2\x19.routing.v1.GRPCRouteRuleR\x05rules\"v\n" +
+	"\x05rules\x18\x03 \x03(\v2\x19.routing.v1.GRPCRouteRuleR\x05rules\x127\n" +
+	"\tlisteners\x18\x04 \x03(\v2\x19.routing.v1.RouteListenerR\tlisteners\"\x8b\x05\n" +
 	"\rGRPCRouteRule\x124\n" +
 	"\amatches\x18\x01 \x03(\v2\x1a.routing.v1.GRPCRouteMatchR\amatches\x12/\n" +
-	"\bbackends\x18\x02 \x03(\v2\x13.routing.v1.BackendR\bbackends\"x\n" +
+	"\bbackends\x18\x02 \x03(\v2\x13.routing.v1.BackendR\bbackends\x12@\n" +
+	"\x0efixed_response\x18\x03 \x01(\v2\x19.routing.v1.FixedResponseR\rfixedResponse\x124\n" +
+	"\x16invalid_backend_weight\x18\x04 \x01(\rR\x14invalidBackendWeight\x12\x12\n" +
+	"\x04name\x18\x05 \x01(\tR\x04name\x12'\n" +
+	"\x0fdisable_retries\x18\x06 \x01(\bR\x0edisableRetries\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\a \x01(\x04R\ttimeoutMs\x12*\n" +
+	"\x11header_timeout_ms\x18\b \x01(\x04R\x0fheaderTimeoutMs\x124\n" +
+	"\bgrpc_web\x18\t \x01(\v2\x19.routing.v1.GRPCWebConfigR\agrpcWeb\x12R\n" +
+	"\x17request_header_modifier\x18\n" +
+	" \x01(\v2\x1a.routing.v1.HeaderModifierR\x15requestHeaderModifier\x12T\n" +
+	"\x18response_header_modifier\x18\v \x01(\v2\x1a.routing.v1.HeaderModifierR\x16responseHeaderModifier\x123\n" +
+	"\amirrors\x18\f \x03(\v2\x19.routing.v1.RequestMirrorR\amirrors\"Z\n" +
+	"\rRequestMirror\x12-\n" +
+	"\abackend\x18\x01 \x01(\v2\x13.routing.v1.BackendR\abackend\x12\x1a\n" +
+	"\bfraction\x18\x02 \x01(\x01R\bfraction\">\n" +
+	"\rGRPCWebConfig\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"allow_text\x18\x02 \x01(\bR\tallowText\"\x94\x01\n" +
 	"\x0eGRPCRouteMatch\x123\n" +
 	"\x06method\x18\x01 \x01(\v2\x1b.routing.v1.GRPCMethodMatchR\x06method\x121\n" +
-	"\aheaders\x18\x02 \x03(\v2\x17.routing.v1.HeaderMatchR\aheaders\"x\n" +
+	"\aheaders\x18\x02 \x03(\v2\x17.routing.v1.HeaderMatchR\aheaders\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\rR\bpriority\"x\n" +
 	"\x0fGRPCMethodMatch\x123\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x1f.routing.v1.GRPCMethodMatchTypeR\x04type\x12\x18\n" +
 	"\aservice\x18\x02 \x01(\tR\aservice\x12\x16\n" +
-	"\x06method\x18\x03 \x01(\tR\x06method\"t\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\"\xe7\x03\n" +
 	"\aBackend\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
 	"\x06weight\x18\x02 \x01(\rR\x06weight\x127\n" +
-	"\bprotocol\x18\x03 \x01(\x0e2\x1b.routing.v1.BackendProtocolR\bprotocol\"{\n" +
+	"\bprotocol\x18\x03 \x01(\x0e2\x1b.routing.v1.BackendProtocolR\bprotocol\x12C\n" +
+	"\x0fcircuit_breaker\x18\x04 \x01(\v2\x1a.routing.v1.CircuitBreakerR\x0ecircuitBreaker\x12:\n" +
+	"\fhealth_check\x18\x05 \x01(\v2\x17.routing.v1.HealthCheckR\vhealthCheck\x12\x1c\n" +
+	"\tendpoints\x18\x06 \x03(\tR\tendpoints\x12(\n" +
+	"\x03tls\x18\a \x01(\v2\x16.routing.v1.BackendTLSR\x03tls\x12R\n" +
+	"\x17request_header_modifier\x18\b \x01(\v2\x1a.routing.v1.HeaderModifierR\x15requestHeaderModifier\x12T\n" +
+	"\x18response_header_modifier\x18\t \x01(\v2\x1a.routing.v1.HeaderModifierR\x16responseHeaderModifier\"t\n" +
+	"\x0eHeaderModifier\x12$\n" +
+	"\x03set\x18\x01 \x03(\v2\x12.routing.v1.HeaderR\x03set\x12$\n" +
+	"\x03add\x18\x02 \x03(\v2\x12.routing.v1.HeaderR\x03add\x12\x16\n" +
+	"\x06remove\x18\x03 \x03(\tR\x06remove\"2\n" +
+	"\x06Header\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"y\n" +
+	"\n" +
+	"BackendTLS\x12\x10\n" +
+	"\x03sni\x18\x01 \x01(\tR\x03sni\x120\n" +
+	"\x14insecure_skip_verify\x18\x02 \x01(\bR\x12insecureSkipVerify\x12'\n" +
+	"\x0fca_certificates\x18\x03 \x01(\fR\x0ecaCertificates\"\xfc\x01\n" +
+	"\vHealthCheck\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x1f\n" +
+	"\vinterval_ms\x18\x03 \x01(\x04R\n" +
+	"intervalMs\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x04 \x01(\x04R\ttimeoutMs\x12+\n" +
+	"\x11healthy_threshold\x18\x05 \x01(\rR\x10healthyThreshold\x12/\n" +
+	"\x13unhealthy_threshold\x18\x06 \x01(\rR\x12unhealthyThreshold\x12+\n" +
+	"\x11expected_statuses\x18\a \x03(\rR\x10expectedStatuses\"\xb7\x01\n" +
+	"\x0eCircuitBreaker\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x121\n" +
+	"\x14consecutive_failures\x18\x02 \x01(\rR\x13consecutiveFailures\x120\n" +
+	"\x14ejection_duration_ms\x18\x03 \x01(\x04R\x12ejectionDurationMs\x120\n" +
+	"\x14max_ejection_percent\x18\x04 \x01(\rR\x12maxEjectionPercent\"H\n" +
+	"\rFixedResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\rR\n" +
+	"statusCode\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"{\n" +
 	"\vRetryConfig\x12\x1a\n" +
 	"\battempts\x18\x01 \x01(\rR\battempts\x12\x1d\n" +
 	"\n" +
 	"backoff_ms\x18\x02 \x01(\x04R\tbackoffMs\x121\n" +
-	"\x15retry_on_status_codes\x18\x03 \x03(\rR\x12retryOnStatusCodes*\x82\x01\n" +
+	"\x15retry_on_status_codes\x18\x03 \x03(\rR\x12retryOnStatusCodes\"\xf7\x01\n" +
+	"\n" +
+	"CORSPolicy\x12#\n" +
+	"\rallow_origins\x18\x01 \x03(\tR\fallowOrigins\x12#\n" +
+	"\rallow_methods\x18\x02 \x03(\tR\fallowMethods\x12#\n" +
+	"\rallow_headers\x18\x03 \x03(\tR\fallowHeaders\x12%\n" +
+	"\x0eexpose_headers\x18\x04 \x03(\tR\rexposeHeaders\x12+\n" +
+	"\x11allow_credentials\x18\x05 \x01(\bR\x10allowCredentials\x12&\n" +
+	"\x0fmax_age_seconds\x18\x06 \x01(\rR\rmaxAgeSeconds\"\xc2\x01\n" +
+	"\tRateLimit\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\brequests\x18\x02 \x01(\rR\brequests\x12\x1b\n" +
+	"\tperiod_ms\x18\x03 \x01(\x04R\bperiodMs\x12\x14\n" +
+	"\x05burst\x18\x04 \x01(\rR\x05burst\x127\n" +
+	"\bkey_type\x18\x05 \x01(\x0e2\x1c.routing.v1.RateLimitKeyTypeR\akeyType\x12\x1d\n" +
+	"\n" +
+	"key_header\x18\x06 \x01(\tR\tkeyHeader\"y\n" +
+	"\n" +
+	"AuthConfig\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
+	"\x03jwt\x18\x02 \x01(\v2\x13.routing.v1.JWTAuthR\x03jwt\x124\n" +
+	"\bexternal\x18\x03 \x01(\v2\x18.routing.v1.ExternalAuthR\bexternal\"\xf1\x01\n" +
+	"\fExternalAuth\x12<\n" +
+	"\bprotocol\x18\x01 \x01(\x0e2 .routing.v1.ExternalAuthProtocolR\bprotocol\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1f\n" +
+	"\vpath_prefix\x18\x03 \x01(\tR\n" +
+	"pathPrefix\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x04 \x01(\x04R\ttimeoutMs\x12\x1b\n" +
+	"\tfail_open\x18\x05 \x01(\bR\bfailOpen\x12,\n" +
+	"\x12headers_to_backend\x18\x06 \x03(\tR\x10headersToBackend\"\xb7\x01\n" +
+	"\aJWTAuth\x12\x18\n" +
+	"\aissuers\x18\x01 \x03(\tR\aissuers\x12\x1c\n" +
+	"\taudiences\x18\x02 \x03(\tR\taudiences\x12\x19\n" +
+	"\bjwks_uri\x18\x03 \x01(\tR\ajwksUri\x12\x12\n" +
+	"\x04jwks\x18\x04 \x01(\tR\x04jwks\x12E\n" +
+	"\x11claims_to_headers\x18\x05 \x03(\v2\x19.routing.v1.ClaimToHeaderR\x0fclaimsToHeaders\"=\n" +
+	"\rClaimToHeader\x12\x14\n" +
+	"\x05claim\x18\x01 \x01(\tR\x05claim\x12\x16\n" +
+	"\x06header\x18\x02 \x01(\tR\x06header\"\xa0\x01\n" +
+	"\rAccessControl\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vallow_cidrs\x18\x02 \x03(\tR\n" +
+	"allowCidrs\x12\x1d\n" +
+	"\n" +
+	"deny_cidrs\x18\x03 \x03(\tR\tdenyCidrs\x12?\n" +
+	"\x0edefault_action\x18\x04 \x01(\x0e2\x18.routing.v1.AccessActionR\rdefaultAction\"\xb0\x01\n" +
+	"\vCacheConfig\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x15\n" +
+	"\x06ttl_ms\x18\x02 \x01(\x04R\x05ttlMs\x12&\n" +
+	"\x03key\x18\x03 \x01(\v2\x14.routing.v1.CacheKeyR\x03key\x12!\n" +
+	"\fvary_headers\x18\x04 \x03(\tR\vvaryHeaders\x12/\n" +
+	"\x06bypass\x18\x05 \x03(\v2\x17.routing.v1.CacheBypassR\x06bypass\"\x8c\x01\n" +
+	"\bCacheKey\x12!\n" +
+	"\fignore_query\x18\x01 \x01(\bR\vignoreQuery\x12)\n" +
+	"\x10query_parameters\x18\x02 \x03(\tR\x0fqueryParameters\x12\x18\n" +
+	"\aheaders\x18\x03 \x03(\tR\aheaders\x12\x18\n" +
+	"\acookies\x18\x04 \x03(\tR\acookies\"h\n" +
+	"\vCacheBypass\x12/\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1b.routing.v1.CacheBypassTypeR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"\x90\x02\n" +
+	"\x12SessionPersistence\x126\n" +
+	"\x04type\x18\x01 \x01(\x0e2\".routing.v1.SessionPersistenceTypeR\x04type\x12!\n" +
+	"\fsession_name\x18\x02 \x01(\tR\vsessionName\x12.\n" +
+	"\x13absolute_timeout_ms\x18\x03 \x01(\x04R\x11absoluteTimeoutMs\x12&\n" +
+	"\x0fidle_timeout_ms\x18\x04 \x01(\x04R\ridleTimeoutMs\x12G\n" +
+	"\x0fcookie_lifetime\x18\x05 \x01(\x0e2\x1e.routing.v1.CookieLifetimeTypeR\x0ecookieLifetime*\x8c\x01\n" +
+	"\x0fAccessLogFormat\x12!\n" +
+	"\x1dACCESS_LOG_FORMAT_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16ACCESS_LOG_FORMAT_TEXT\x10\x01\x12\x1a\n" +
+	"\x16ACCESS_LOG_FORMAT_JSON\x10\x02\x12\x1e\n" +
+	"\x1aACCESS_LOG_FORMAT_DISABLED\x10\x03*w\n" +
+	"\x14ClientValidationMode\x12+\n" +
+	"'CLIENT_VALIDATION_MODE_ALLOW_VALID_ONLY\x10\x00\x122\n" +
+	".CLIENT_VALIDATION_MODE_ALLOW_INSECURE_FALLBACK\x10\x01*i\n" +
+	"\x14CertificateSelection\x12'\n" +
+	"#CERTIFICATE_SELECTION_MOST_SPECIFIC\x10\x00\x12(\n" +
+	"$CERTIFICATE_SELECTION_LISTENER_ORDER\x10\x01*|\n" +
+	"\x14ProxyProtocolVersion\x12&\n" +
+	"\"PROXY_PROTOCOL_VERSION_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19PROXY_PROTOCOL_VERSION_V1\x10\x01\x12\x1d\n" +
+	"\x19PROXY_PROTOCOL_VERSION_V2\x10\x02*\x82\x01\n" +
 	"\rPathMatchType\x12\x1f\n" +
 	"\x1bPATH_MATCH_TYPE_UNSPECIFIED\x10\x00\x12\x19\n" +
 	"\x15PATH_MATCH_TYPE_EXACT\x10\x01\x12\x1a\n" +
@@ -1504,11 +6216,42 @@ const file_routing_v1_routing_proto_rawDesc = "" +
 	"\x15BACKEND_PROTOCOL_HTTP\x10\x01\x12\x1a\n" +
 	"\x16BACKEND_PROTOCOL_HTTPS\x10\x02\x12\x18\n" +
 	"\x14BACKEND_PROTOCOL_H2C\x10\x03\x12\x17\n" +
-	"\x13BACKEND_PROTOCOL_H2\x10\x042\xee\x01\n" +
+	"\x13BACKEND_PROTOCOL_H2\x10\x04*z\n" +
+	"\x10RateLimitKeyType\x12#\n" +
+	"\x1fRATE_LIMIT_KEY_TYPE_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dRATE_LIMIT_KEY_TYPE_CLIENT_IP\x10\x01\x12\x1e\n" +
+	"\x1aRATE_LIMIT_KEY_TYPE_HEADER\x10\x02*\x80\x01\n" +
+	"\x14ExternalAuthProtocol\x12&\n" +
+	"\"EXTERNAL_AUTH_PROTOCOL_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bEXTERNAL_AUTH_PROTOCOL_GRPC\x10\x01\x12\x1f\n" +
+	"\x1bEXTERNAL_AUTH_PROTOCOL_HTTP\x10\x02*^\n" +
+	"\fAccessAction\x12\x1d\n" +
+	"\x19ACCESS_ACTION_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13ACCESS_ACTION_ALLOW\x10\x01\x12\x16\n" +
+	"\x12ACCESS_ACTION_DENY\x10\x02*p\n" +
+	"\x0fCacheBypassType\x12!\n" +
+	"\x1dCACHE_BYPASS_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18CACHE_BYPASS_TYPE_HEADER\x10\x01\x12\x1c\n" +
+	"\x18CACHE_BYPASS_TYPE_COOKIE\x10\x02*\x8c\x01\n" +
+	"\x16SessionPersistenceType\x12(\n" +
+	"$SESSION_PERSISTENCE_TYPE_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fSESSION_PERSISTENCE_TYPE_COOKIE\x10\x01\x12#\n" +
+	"\x1fSESSION_PERSISTENCE_TYPE_HEADER\x10\x02*\x80\x01\n" +
+	"\x12CookieLifetimeType\x12$\n" +
+	" COOKIE_LIFETIME_TYPE_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cCOOKIE_LIFETIME_TYPE_SESSION\x10\x01\x12\"\n" +
+	"\x1eCOOKIE_LIFETIME_TYPE_PERMANENT\x10\x022\xff\x06\n" +
 	"\x0eRoutingService\x12Q\n" +
 	"\fUpdateRoutes\x12\x1f.routing.v1.UpdateRoutesRequest\x1a .routing.v1.UpdateRoutesResponse\x12H\n" +
 	"\tGetRoutes\x12\x1c.routing.v1.GetRoutesRequest\x1a\x1d.routing.v1.GetRoutesResponse\x12?\n" +
-	"\x06Health\x12\x19.routing.v1.HealthRequest\x1a\x1a.routing.v1.HealthResponseB\xb3\x01\n" +
+	"\x06Health\x12\x19.routing.v1.HealthRequest\x1a\x1a.routing.v1.HealthResponse\x12U\n" +
+	"\fStreamRoutes\x12\x1f.routing.v1.StreamRoutesRequest\x1a .routing.v1.StreamRoutesResponse(\x010\x01\x12]\n" +
+	"\x10GetBackendHealth\x12#.routing.v1.GetBackendHealthRequest\x1a$.routing.v1.GetBackendHealthResponse\x12c\n" +
+	"\x12UpdateGlobalConfig\x12%.routing.v1.UpdateGlobalConfigRequest\x1a&.routing.v1.UpdateGlobalConfigResponse\x12f\n" +
+	"\x13UpdateLoggingConfig\x12&.routing.v1.UpdateLoggingConfigRequest\x1a'.routing.v1.UpdateLoggingConfigResponse\x12T\n" +
+	"\rGetRouteStats\x12 .routing.v1.GetRouteStatsRequest\x1a!.routing.v1.GetRouteStatsResponse\x12c\n" +
+	"\x12UpdateCertificates\x12%.routing.v1.UpdateCertificatesRequest\x1a&.routing.v1.UpdateCertificatesResponse\x12Q\n" +
+	"\fDeleteRoutes\x12\x1f.routing.v1.DeleteRoutesRequest\x1a .routing.v1.UpdateRoutesResponseB\xb3\x01\n" +
 	"\x0ecom.routing.v1B\fRoutingProtoP\x01ZJgithub.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1;routingv1\xa2\x02\x03RXX\xaa\x02\n" +
 	"Routing.V1\xca\x02\n" +
 	"Routing\\V1\xe2\x02\x16Routing\\V1\\GPBMetadata\xea\x02\vRouting::V1b\x06proto3"
@@ -1525,66 +6268,207 @@ func file_routing_v1_routing_proto_rawDescGZIP() []byte {
 	return file_routing_v1_routing_proto_rawDescData
 }
 
-var file_routing_v1_routing_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_routing_v1_routing_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_routing_v1_routing_proto_enumTypes = make([]protoimpl.EnumInfo, 15)
+var file_routing_v1_routing_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
 var file_routing_v1_routing_proto_goTypes = []any{
-	(PathMatchType)(0),           // 0: routing.v1.PathMatchType
-	(HeaderMatchType)(0),         // 1: routing.v1.HeaderMatchType
-	(QueryParamMatchType)(0),     // 2: routing.v1.QueryParamMatchType
-	(GRPCMethodMatchType)(0),     // 3: routing.v1.GRPCMethodMatchType
-	(BackendProtocol)(0),         // 4: routing.v1.BackendProtocol
-	(*UpdateRoutesRequest)(nil),  // 5: routing.v1.UpdateRoutesRequest
-	(*UpdateRoutesResponse)(nil), // 6: routing.v1.UpdateRoutesResponse
-	(*GetRoutesRequest)(nil),     // 7: routing.v1.GetRoutesRequest
-	(*GetRoutesResponse)(nil),    // 8: routing.v1.GetRoutesResponse
-	(*HealthRequest)(nil),        // 9: routing.v1.HealthRequest
-	(*HealthResponse)(nil),       // 10: routing.v1.HealthResponse
-	(*HTTPRoute)(nil),            // 11: routing.v1.HTTPRoute
-	(*HTTPRouteRule)(nil),        // 12: routing.v1.HTTPRouteRule
-	(*HTTPRouteMatch)(nil),       // 13: routing.v1.HTTPRouteMatch
-	(*PathMatch)(nil),            // 14: routing.v1.PathMatch
-	(*HeaderMatch)(nil),          // 15: routing.v1.HeaderMatch
-	(*QueryParamMatch)(nil),      // 16: routing.v1.QueryParamMatch
-	(*GRPCRoute)(nil),            // 17: routing.v1.GRPCRoute
-	(*GRPCRouteRule)(nil),        // 18: routing.v1.GRPCRouteRule
-	(*GRPCRouteMatch)(nil),       // 19: routing.v1.GRPCRouteMatch
-	(*GRPCMethodMatch)(nil),      // 20: routing.v1.GRPCMethodMatch
-	(*Backend)(nil),              // 21: routing.v1.Backend
-	(*RetryConfig)(nil),          // 22: routing.v1.RetryConfig
+	(AccessLogFormat)(0),                // 0: routing.v1.AccessLogFormat
+	(ClientValidationMode)(0),           // 1: routing.v1.ClientValidationMode
+	(CertificateSelection)(0),           // 2: routing.v1.CertificateSelection
+	(ProxyProtocolVersion)(0),           // 3: routing.v1.ProxyProtocolVersion
+	(PathMatchType)(0),                  // 4: routing.v1.PathMatchType
+	(HeaderMatchType)(0),                // 5: routing.v1.HeaderMatchType
+	(QueryParamMatchType)(0),            // 6: routing.v1.QueryParamMatchType
+	(GRPCMethodMatchType)(0),            // 7: routing.v1.GRPCMethodMatchType
+	(BackendProtocol)(0),                // 8: routing.v1.BackendProtocol
+	(RateLimitKeyType)(0),               // 9: routing.v1.RateLimitKeyType
+	(ExternalAuthProtocol)(0),           // 10: routing.v1.ExternalAuthProtocol
+	(AccessAction)(0),                   // 11: routing.v1.AccessAction
+	(CacheBypassType)(0),                // 12: routing.v1.CacheBypassType
+	(SessionPersistenceType)(0),         // 13: routing.v1.SessionPersistenceType
+	(CookieLifetimeType)(0),             // 14: routing.v1.CookieLifetimeType
+	(*UpdateRoutesRequest)(nil),         // 15: routing.v1.UpdateRoutesRequest
+	(*UpdateRoutesResponse)(nil),        // 16: routing.v1.UpdateRoutesResponse
+	(*GetRoutesRequest)(nil),            // 17: routing.v1.GetRoutesRequest
+	(*GetRoutesResponse)(nil),           // 18: routing.v1.GetRoutesResponse
+	(*HealthRequest)(nil),               // 19: routing.v1.HealthRequest
+	(*HealthResponse)(nil),              // 20: routing.v1.HealthResponse
+	(*GetBackendHealthRequest)(nil),     // 21: routing.v1.GetBackendHealthRequest
+	(*GetBackendHealthResponse)(nil),    // 22: routing.v1.GetBackendHealthResponse
+	(*BackendHealth)(nil),               // 23: routing.v1.BackendHealth
+	(*GetRouteStatsRequest)(nil),        // 24: routing.v1.GetRouteStatsRequest
+	(*GetRouteStatsResponse)(nil),       // 25: routing.v1.GetRouteStatsResponse
+	(*RouteStats)(nil),                  // 26: routing.v1.RouteStats
+	(*ListenerStats)(nil),               // 27: routing.v1.ListenerStats
+	(*StatusClassCount)(nil),            // 28: routing.v1.StatusClassCount
+	(*RequestDurations)(nil),            // 29: routing.v1.RequestDurations
+	(*UpdateGlobalConfigRequest)(nil),   // 30: routing.v1.UpdateGlobalConfigRequest
+	(*UpdateGlobalConfigResponse)(nil),  // 31: routing.v1.UpdateGlobalConfigResponse
+	(*GlobalConfig)(nil),                // 32: routing.v1.GlobalConfig
+	(*UpdateLoggingConfigRequest)(nil),  // 33: routing.v1.UpdateLoggingConfigRequest
+	(*UpdateLoggingConfigResponse)(nil), // 34: routing.v1.UpdateLoggingConfigResponse
+	(*LoggingConfig)(nil),               // 35: routing.v1.LoggingConfig
+	(*UpdateCertificatesRequest)(nil),   // 36: routing.v1.UpdateCertificatesRequest
+	(*UpdateCertificatesResponse)(nil),  // 37: routing.v1.UpdateCertificatesResponse
+	(*ListenerCertificates)(nil),        // 38: routing.v1.ListenerCertificates
+	(*Certificate)(nil),                 // 39: routing.v1.Certificate
+	(*ClientValidation)(nil),            // 40: routing.v1.ClientValidation
+	(*StreamRoutesRequest)(nil),         // 41: routing.v1.StreamRoutesRequest
+	(*RoutesDelta)(nil),                 // 42: routing.v1.RoutesDelta
+	(*StreamRoutesResponse)(nil),        // 43: routing.v1.StreamRoutesResponse
+	(*DeleteRoutesRequest)(nil),         // 44: routing.v1.DeleteRoutesRequest
+	(*Listener)(nil),                    // 45: routing.v1.Listener
+	(*HTTPSRedirect)(nil),               // 46: routing.v1.HTTPSRedirect
+	(*ClientIPDetection)(nil),           // 47: routing.v1.ClientIPDetection
+	(*ProxyProtocol)(nil),               // 48: routing.v1.ProxyProtocol
+	(*HTTP3)(nil),                       // 49: routing.v1.HTTP3
+	(*ListenerLimits)(nil),              // 50: routing.v1.ListenerLimits
+	(*RouteListener)(nil),               // 51: routing.v1.RouteListener
+	(*HTTPRoute)(nil),                   // 52: routing.v1.HTTPRoute
+	(*HTTPRouteRule)(nil),               // 53: routing.v1.HTTPRouteRule
+	(*RouteAccessLog)(nil),              // 54: routing.v1.RouteAccessLog
+	(*HTTPRouteMatch)(nil),              // 55: routing.v1.HTTPRouteMatch
+	(*PathMatch)(nil),                   // 56: routing.v1.PathMatch
+	(*HeaderMatch)(nil),                 // 57: routing.v1.HeaderMatch
+	(*QueryParamMatch)(nil),             // 58: routing.v1.QueryParamMatch
+	(*GRPCRoute)(nil),                   // 59: routing.v1.GRPCRoute
+	(*GRPCRouteRule)(nil),               // 60: routing.v1.GRPCRouteRule
+	(*RequestMirror)(nil),               // 61: routing.v1.RequestMirror
+	(*GRPCWebConfig)(nil),               // 62: routing.v1.GRPCWebConfig
+	(*GRPCRouteMatch)(nil),              // 63: routing.v1.GRPCRouteMatch
+	(*GRPCMethodMatch)(nil),             // 64: routing.v1.GRPCMethodMatch
+	(*Backend)(nil),                     // 65: routing.v1.Backend
+	(*HeaderModifier)(nil),              // 66: routing.v1.HeaderModifier
+	(*Header)(nil),                      // 67: routing.v1.Header
+	(*BackendTLS)(nil),                  // 68: routing.v1.BackendTLS
+	(*HealthCheck)(nil),                 // 69: routing.v1.HealthCheck
+	(*CircuitBreaker)(nil),              // 70: routing.v1.CircuitBreaker
+	(*FixedResponse)(nil),               // 71: routing.v1.FixedResponse
+	(*RetryConfig)(nil),                 // 72: routing.v1.RetryConfig
+	(*CORSPolicy)(nil),                  // 73: routing.v1.CORSPolicy
+	(*RateLimit)(nil),                   // 74: routing.v1.RateLimit
+	(*AuthConfig)(nil),                  // 75: routing.v1.AuthConfig
+	(*ExternalAuth)(nil),                // 76: routing.v1.ExternalAuth
+	(*JWTAuth)(nil),                     // 77: routing.v1.JWTAuth
+	(*ClaimToHeader)(nil),               // 78: routing.v1.ClaimToHeader
+	(*AccessControl)(nil),               // 79: routing.v1.AccessControl
+	(*CacheConfig)(nil),                 // 80: routing.v1.CacheConfig
+	(*CacheKey)(nil),                    // 81: routing.v1.CacheKey
+	(*CacheBypass)(nil),                 // 82: routing.v1.CacheBypass
+	(*SessionPersistence)(nil),          // 83: routing.v1.SessionPersistence
 }
 var file_routing_v1_routing_proto_depIdxs = []int32{
-	11, // 0: routing.v1.UpdateRoutesRequest.http_routes:type_name -> routing.v1.HTTPRoute
-	17, // 1: routing.v1.UpdateRoutesRequest.grpc_routes:type_name -> routing.v1.GRPCRoute
-	11, // 2: routing.v1.GetRoutesResponse.http_routes:type_name -> routing.v1.HTTPRoute
-	17, // 3: routing.v1.GetRoutesResponse.grpc_routes:type_name -> routing.v1.GRPCRoute
-	12, // 4: routing.v1.HTTPRoute.rules:type_name -> routing.v1.HTTPRouteRule
-	13, // 5: routing.v1.HTTPRouteRule.matches:type_name -> routing.v1.HTTPRouteMatch
-	21, // 6: routing.v1.HTTPRouteRule.backends:type_name -> routing.v1.Backend
-	22, // 7: routing.v1.HTTPRouteRule.retry:type_name -> routing.v1.RetryConfig
-	14, // 8: routing.v1.HTTPRouteMatch.path:type_name -> routing.v1.PathMatch
-	15, // 9: routing.v1.HTTPRouteMatch.headers:type_name -> routing.v1.HeaderMatch
-	16, // 10: routing.v1.HTTPRouteMatch.query_params:type_name -> routing.v1.QueryParamMatch
-	0,  // 11: routing.v1.PathMatch.type:type_name -> routing.v1.PathMatchType
-	1,  // 12: routing.v1.HeaderMatch.type:type_name -> routing.v1.HeaderMatchType
-	2,  // 13: routing.v1.QueryParamMatch.type:type_name -> routing.v1.QueryParamMatchType
-	18, // 14: routing.v1.GRPCRoute.rules:type_name -> routing.v1.GRPCRouteRule
-	19, // 15: routing.v1.GRPCRouteRule.matches:type_name -> routing.v1.GRPCRouteMatch
-	21, // 16: routing.v1.GRPCRouteRule.backends:type_name -> routing.v1.Backend
-	20, // 17: routing.v1.GRPCRouteMatch.method:type_name -> routing.v1.GRPCMethodMatch
-	15, // 18: routing.v1.GRPCRouteMatch.headers:type_name -> routing.v1.HeaderMatch
-	3,  // 19: routing.v1.GRPCMethodMatch.type:type_name -> routing.v1.GRPCMethodMatchType
-	4,  // 20: routing.v1.Backend.protocol:type_name -> routing.v1.BackendProtocol
-	5,  // 21: routing.v1.RoutingService.UpdateRoutes:input_type -> routing.v1.UpdateRoutesRequest
-	7,  // 22: routing.v1.RoutingService.GetRoutes:input_type -> routing.v1.GetRoutesRequest
-	9,  // 23: routing.v1.RoutingService.Health:input_type -> routing.v1.HealthRequest
-	6,  // 24: routing.v1.RoutingService.UpdateRoutes:output_type -> routing.v1.UpdateRoutesResponse
-	8,  // 25: routing.v1.RoutingService.GetRoutes:output_type -> routing.v1.GetRoutesResponse
-	10, // 26: routing.v1.RoutingService.Health:output_type -> routing.v1.HealthResponse
-	24, // [24:27] is the sub-list for method output_type
-	21, // [21:24] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	52, // 0: routing.v1.UpdateRoutesRequest.http_routes:type_name -> routing.v1.HTTPRoute
+	59, // 1: routing.v1.UpdateRoutesRequest.grpc_routes:type_name -> routing.v1.GRPCRoute
+	45, // 2: routing.v1.UpdateRoutesRequest.listeners:type_name -> routing.v1.Listener
+	52, // 3: routing.v1.GetRoutesResponse.http_routes:type_name -> routing.v1.HTTPRoute
+	59, // 4: routing.v1.GetRoutesResponse.grpc_routes:type_name -> routing.v1.GRPCRoute
+	45, // 5: routing.v1.GetRoutesResponse.listeners:type_name -> routing.v1.Listener
+	23, // 6: routing.v1.GetBackendHealthResponse.backends:type_name -> routing.v1.BackendHealth
+	26, // 7: routing.v1.GetRouteStatsResponse.routes:type_name -> routing.v1.RouteStats
+	27, // 8: routing.v1.GetRouteStatsResponse.listeners:type_name -> routing.v1.ListenerStats
+	28, // 9: routing.v1.RouteStats.responses:type_name -> routing.v1.StatusClassCount
+	29, // 10: routing.v1.RouteStats.durations:type_name -> routing.v1.RequestDurations
+	28, // 11: routing.v1.ListenerStats.responses:type_name -> routing.v1.StatusClassCount
+	29, // 12: routing.v1.ListenerStats.durations:type_name -> routing.v1.RequestDurations
+	32, // 13: routing.v1.UpdateGlobalConfigRequest.config:type_name -> routing.v1.GlobalConfig
+	0,  // 14: routing.v1.GlobalConfig.access_log_format:type_name -> routing.v1.AccessLogFormat
+	35, // 15: routing.v1.UpdateLoggingConfigRequest.config:type_name -> routing.v1.LoggingConfig
+	0,  // 16: routing.v1.LoggingConfig.format:type_name -> routing.v1.AccessLogFormat
+	38, // 17: routing.v1.UpdateCertificatesRequest.listeners:type_name -> routing.v1.ListenerCertificates
+	39, // 18: routing.v1.UpdateCertificatesRequest.fallback:type_name -> routing.v1.Certificate
+	2,  // 19: routing.v1.UpdateCertificatesRequest.selection:type_name -> routing.v1.CertificateSelection
+	39, // 20: routing.v1.ListenerCertificates.certificates:type_name -> routing.v1.Certificate
+	40, // 21: routing.v1.Certificate.client_validation:type_name -> routing.v1.ClientValidation
+	1,  // 22: routing.v1.ClientValidation.mode:type_name -> routing.v1.ClientValidationMode
+	15, // 23: routing.v1.StreamRoutesRequest.full:type_name -> routing.v1.UpdateRoutesRequest
+	42, // 24: routing.v1.StreamRoutesRequest.delta:type_name -> routing.v1.RoutesDelta
+	52, // 25: routing.v1.RoutesDelta.upsert_http_routes:type_name -> routing.v1.HTTPRoute
+	59, // 26: routing.v1.RoutesDelta.upsert_grpc_routes:type_name -> routing.v1.GRPCRoute
+	16, // 27: routing.v1.StreamRoutesResponse.ack:type_name -> routing.v1.UpdateRoutesResponse
+	20, // 28: routing.v1.StreamRoutesResponse.health:type_name -> routing.v1.HealthResponse
+	50, // 29: routing.v1.Listener.limits:type_name -> routing.v1.ListenerLimits
+	79, // 30: routing.v1.Listener.access_controls:type_name -> routing.v1.AccessControl
+	49, // 31: routing.v1.Listener.http3:type_name -> routing.v1.HTTP3
+	48, // 32: routing.v1.Listener.proxy_protocol:type_name -> routing.v1.ProxyProtocol
+	47, // 33: routing.v1.Listener.client_ip:type_name -> routing.v1.ClientIPDetection
+	46, // 34: routing.v1.Listener.https_redirects:type_name -> routing.v1.HTTPSRedirect
+	3,  // 35: routing.v1.ProxyProtocol.version:type_name -> routing.v1.ProxyProtocolVersion
+	53, // 36: routing.v1.HTTPRoute.rules:type_name -> routing.v1.HTTPRouteRule
+	51, // 37: routing.v1.HTTPRoute.listeners:type_name -> routing.v1.RouteListener
+	55, // 38: routing.v1.HTTPRouteRule.matches:type_name -> routing.v1.HTTPRouteMatch
+	65, // 39: routing.v1.HTTPRouteRule.backends:type_name -> routing.v1.Backend
+	72, // 40: routing.v1.HTTPRouteRule.retry:type_name -> routing.v1.RetryConfig
+	71, // 41: routing.v1.HTTPRouteRule.fixed_response:type_name -> routing.v1.FixedResponse
+	83, // 42: routing.v1.HTTPRouteRule.session_persistence:type_name -> routing.v1.SessionPersistence
+	73, // 43: routing.v1.HTTPRouteRule.cors:type_name -> routing.v1.CORSPolicy
+	74, // 44: routing.v1.HTTPRouteRule.rate_limit:type_name -> routing.v1.RateLimit
+	75, // 45: routing.v1.HTTPRouteRule.auth:type_name -> routing.v1.AuthConfig
+	79, // 46: routing.v1.HTTPRouteRule.access_control:type_name -> routing.v1.AccessControl
+	80, // 47: routing.v1.HTTPRouteRule.cache:type_name -> routing.v1.CacheConfig
+	54, // 48: routing.v1.HTTPRouteRule.access_log:type_name -> routing.v1.RouteAccessLog
+	56, // 49: routing.v1.HTTPRouteMatch.path:type_name -> routing.v1.PathMatch
+	57, // 50: routing.v1.HTTPRouteMatch.headers:type_name -> routing.v1.HeaderMatch
+	58, // 51: routing.v1.HTTPRouteMatch.query_params:type_name -> routing.v1.QueryParamMatch
+	4,  // 52: routing.v1.PathMatch.type:type_name -> routing.v1.PathMatchType
+	5,  // 53: routing.v1.HeaderMatch.type:type_name -> routing.v1.HeaderMatchType
+	6,  // 54: routing.v1.QueryParamMatch.type:type_name -> routing.v1.QueryParamMatchType
+	60, // 55: routing.v1.GRPCRoute.rules:type_name -> routing.v1.GRPCRouteRule
+	51, // 56: routing.v1.GRPCRoute.listeners:type_name -> routing.v1.RouteListener
+	63, // 57: routing.v1.GRPCRouteRule.matches:type_name -> routing.v1.GRPCRouteMatch
+	65, // 58: routing.v1.GRPCRouteRule.backends:type_name -> routing.v1.Backend
+	71, // 59: routing.v1.GRPCRouteRule.fixed_response:type_name -> routing.v1.FixedResponse
+	62, // 60: routing.v1.GRPCRouteRule.grpc_web:type_name -> routing.v1.GRPCWebConfig
+	66, // 61: routing.v1.GRPCRouteRule.request_header_modifier:type_name -> routing.v1.HeaderModifier
+	66, // 62: routing.v1.GRPCRouteRule.response_header_modifier:type_name -> routing.v1.HeaderModifier
+	61, // 63: routing.v1.GRPCRouteRule.mirrors:type_name -> routing.v1.RequestMirror
+	65, // 64: routing.v1.RequestMirror.backend:type_name -> routing.v1.Backend
+	64, // 65: routing.v1.GRPCRouteMatch.method:type_name -> routing.v1.GRPCMethodMatch
+	57, // 66: routing.v1.GRPCRouteMatch.headers:type_name -> routing.v1.HeaderMatch
+	7,  // 67: routing.v1.GRPCMethodMatch.type:type_name -> routing.v1.GRPCMethodMatchType
+	8,  // 68: routing.v1.Backend.protocol:type_name -> routing.v1.BackendProtocol
+	70, // 69: routing.v1.Backend.circuit_breaker:type_name -> routing.v1.CircuitBreaker
+	69, // 70: routing.v1.Backend.health_check:type_name -> routing.v1.HealthCheck
+	68, // 71: routing.v1.Backend.tls:type_name -> routing.v1.BackendTLS
+	66, // 72: routing.v1.Backend.request_header_modifier:type_name -> routing.v1.HeaderModifier
+	66, // 73: routing.v1.Backend.response_header_modifier:type_name -> routing.v1.HeaderModifier
+	67, // 74: routing.v1.HeaderModifier.set:type_name -> routing.v1.Header
+	67, // 75: routing.v1.HeaderModifier.add:type_name -> routing.v1.Header
+	9,  // 76: routing.v1.RateLimit.key_type:type_name -> routing.v1.RateLimitKeyType
+	77, // 77: routing.v1.AuthConfig.jwt:type_name -> routing.v1.JWTAuth
+	76, // 78: routing.v1.AuthConfig.external:type_name -> routing.v1.ExternalAuth
+	10, // 79: routing.v1.ExternalAuth.protocol:type_name -> routing.v1.ExternalAuthProtocol
+	78, // 80: routing.v1.JWTAuth.claims_to_headers:type_name -> routing.v1.ClaimToHeader
+	11, // 81: routing.v1.AccessControl.default_action:type_name -> routing.v1.AccessAction
+	81, // 82: routing.v1.CacheConfig.key:type_name -> routing.v1.CacheKey
+	82, // 83: routing.v1.CacheConfig.bypass:type_name -> routing.v1.CacheBypass
+	12, // 84: routing.v1.CacheBypass.type:type_name -> routing.v1.CacheBypassType
+	13, // 85: routing.v1.SessionPersistence.type:type_name -> routing.v1.SessionPersistenceType
+	14, // 86: routing.v1.SessionPersistence.cookie_lifetime:type_name -> routing.v1.CookieLifetimeType
+	15, // 87: routing.v1.RoutingService.UpdateRoutes:input_type -> routing.v1.UpdateRoutesRequest
+	17, // 88: routing.v1.RoutingService.GetRoutes:input_type -> routing.v1.GetRoutesRequest
+	19, // 89: routing.v1.RoutingService.Health:input_type -> routing.v1.HealthRequest
+	41, // 90: routing.v1.RoutingService.StreamRoutes:input_type -> routing.v1.StreamRoutesRequest
+	21, // 91: routing.v1.RoutingService.GetBackendHealth:input_type -> routing.v1.GetBackendHealthRequest
+	30, // 92: routing.v1.RoutingService.UpdateGlobalConfig:input_type -> routing.v1.UpdateGlobalConfigRequest
+	33, // 93: routing.v1.RoutingService.UpdateLoggingConfig:input_type -> routing.v1.UpdateLoggingConfigRequest
+	24, // 94: routing.v1.RoutingService.GetRouteStats:input_type -> routing.v1.GetRouteStatsRequest
+	36, // 95: routing.v1.RoutingService.UpdateCertificates:input_type -> routing.v1.UpdateCertificatesRequest
+	44, // 96: routing.v1.RoutingService.DeleteRoutes:input_type -> routing.v1.DeleteRoutesRequest
+	16, // 97: routing.v1.RoutingService.UpdateRoutes:output_type -> routing.v1.UpdateRoutesResponse
+	18, // 98: routing.v1.RoutingService.GetRoutes:output_type -> routing.v1.GetRoutesResponse
+	20, // 99: routing.v1.RoutingService.Health:output_type -> routing.v1.HealthResponse
+	43, // 100: routing.v1.RoutingService.StreamRoutes:output_type -> routing.v1.StreamRoutesResponse
+	22, // 101: routing.v1.RoutingService.GetBackendHealth:output_type -> routing.v1.GetBackendHealthResponse
+	31, // 102: routing.v1.RoutingService.UpdateGlobalConfig:output_type -> routing.v1.UpdateGlobalConfigResponse
+	34, // 103: routing.v1.RoutingService.UpdateLoggingConfig:output_type -> routing.v1.UpdateLoggingConfigResponse
+	25, // 104: routing.v1.RoutingService.GetRouteStats:output_type -> routing.v1.GetRouteStatsResponse
+	37, // 105: routing.v1.RoutingService.UpdateCertificates:output_type -> routing.v1.UpdateCertificatesResponse
+	16, // 106: routing.v1.RoutingService.DeleteRoutes:output_type -> routing.v1.UpdateRoutesResponse
+	97, // [97:107] is the sub-list for method output_type
+	87, // [87:97] is the sub-list for method input_type
+	87, // [87:87] is the sub-list for extension type_name
+	87, // [87:87] is the sub-list for extension extendee
+	0,  // [0:87] is the sub-list for field type_name
 }
 
 func init() { file_routing_v1_routing_proto_init() }
@@ -1592,13 +6476,24 @@ func file_routing_v1_routing_proto_init() {
 	if File_routing_v1_routing_proto != nil {
 		return
 	}
+	file_routing_v1_routing_proto_msgTypes[0].OneofWrappers = []any{}
+	file_routing_v1_routing_proto_msgTypes[17].OneofWrappers = []any{}
+	file_routing_v1_routing_proto_msgTypes[20].OneofWrappers = []any{}
+	file_routing_v1_routing_proto_msgTypes[26].OneofWrappers = []any{
+		(*StreamRoutesRequest_Full)(nil),
+		(*StreamRoutesRequest_Delta)(nil),
+	}
+	file_routing_v1_routing_proto_msgTypes[28].OneofWrappers = []any{
+		(*StreamRoutesResponse_Ack)(nil),
+		(*StreamRoutesResponse_Health)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_routing_v1_routing_proto_rawDesc), len(file_routing_v1_routing_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   18,
+			NumEnums:      15,
+			NumMessages:   69,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
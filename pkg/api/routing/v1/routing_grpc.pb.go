@@ -24,9 +24,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RoutingService_UpdateRoutes_FullMethodName = "/routing.v1.RoutingService/UpdateRoutes"
-	RoutingService_GetRoutes_FullMethodName    = "/routing.v1.RoutingService/GetRoutes"
-	RoutingService_Health_FullMethodName       = "/routing.v1.RoutingService/Health"
+	RoutingService_UpdateRoutes_FullMethodName        = "/routing.v1.RoutingService/UpdateRoutes"
+	RoutingService_GetRoutes_FullMethodName           = "/routing.v1.RoutingService/GetRoutes"
+	RoutingService_Health_FullMethodName              = "/routing.v1.RoutingService/Health"
+	RoutingService_StreamRoutes_FullMethodName        = "/routing.v1.RoutingService/StreamRoutes"
+	RoutingService_GetBackendHealth_FullMethodName    = "/routing.v1.RoutingService/GetBackendHealth"
+	RoutingService_UpdateGlobalConfig_FullMethodName  = "/routing.v1.RoutingService/UpdateGlobalConfig"
+	RoutingService_UpdateLoggingConfig_FullMethodName = "/routing.v1.RoutingService/UpdateLoggingConfig"
+	RoutingService_GetRouteStats_FullMethodName       = "/routing.v1.RoutingService/GetRouteStats"
+	RoutingService_UpdateCertificates_FullMethodName  = "/routing.v1.RoutingService/UpdateCertificates"
+	RoutingService_DeleteRoutes_FullMethodName        = "/routing.v1.RoutingService/DeleteRoutes"
 )
 
 // RoutingServiceClient is the client API for RoutingService service.
@@ -42,6 +49,30 @@ type RoutingServiceClient interface {
 	GetRoutes(ctx context.Context, in *GetRoutesRequest, opts ...grpc.CallOption) (*GetRoutesResponse, error)
 	// Health returns the health status of the proxy.
 	Health(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthResponse, error)
+	// StreamRoutes keeps a persistent stream between controller and proxy.
+	// The controller sends a full snapshot first and deltas afterwards.
+	// The proxy acknowledges every update and may report its health at any time.
+	StreamRoutes(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamRoutesRequest, StreamRoutesResponse], error)
+	// GetBackendHealth returns the health of backends with active health
+	// checking.
+	GetBackendHealth(ctx context.Context, in *GetBackendHealthRequest, opts ...grpc.CallOption) (*GetBackendHealthResponse, error)
+	// UpdateGlobalConfig replaces the gateway-wide proxy settings. Settings
+	// that are not set use the proxy defaults.
+	UpdateGlobalConfig(ctx context.Context, in *UpdateGlobalConfigRequest, opts ...grpc.CallOption) (*UpdateGlobalConfigResponse, error)
+	// UpdateLoggingConfig replaces the access log settings. Settings that are
+	// not set use the proxy defaults.
+	UpdateLoggingConfig(ctx context.Context, in *UpdateLoggingConfigRequest, opts ...grpc.CallOption) (*UpdateLoggingConfigResponse, error)
+	// GetRouteStats returns the traffic statistics of routes and listeners,
+	// counted since the proxy started.
+	GetRouteStats(ctx context.Context, in *GetRouteStatsRequest, opts ...grpc.CallOption) (*GetRouteStatsResponse, error)
+	// UpdateCertificates replaces the certificates of the HTTPS listeners.
+	// They are sent apart from the routes, so that private keys never show
+	// up in route configs.
+	UpdateCertificates(ctx context.Context, in *UpdateCertificatesRequest, opts ...grpc.CallOption) (*UpdateCertificatesResponse, error)
+	// DeleteRoutes removes the listed routes from the applied configuration,
+	// so that the controller can remove a route before its object is deleted.
+	// It is answered like an update.
+	DeleteRoutes(ctx context.Context, in *DeleteRoutesRequest, opts ...grpc.CallOption) (*UpdateRoutesResponse, error)
 }
 
 type routingServiceClient struct {
@@ -82,6 +113,79 @@ func (c *routingServiceClient) Health(ctx context.Context, in *HealthRequest, op
 	return out, nil
 }
 
+func (c *routingServiceClient) StreamRoutes(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamRoutesRequest, StreamRoutesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoutingService_ServiceDesc.Streams[0], RoutingService_StreamRoutes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRoutesRequest, StreamRoutesResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoutingService_StreamRoutesClient = grpc.BidiStreamingClient[StreamRoutesRequest, StreamRoutesResponse]
+
+func (c *routingServiceClient) GetBackendHealth(ctx context.Context, in *GetBackendHealthRequest, opts ...grpc.CallOption) (*GetBackendHealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBackendHealthResponse)
+	err := c.cc.Invoke(ctx, RoutingService_GetBackendHealth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) UpdateGlobalConfig(ctx context.Context, in *UpdateGlobalConfigRequest, opts ...grpc.CallOption) (*UpdateGlobalConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateGlobalConfigResponse)
+	err := c.cc.Invoke(ctx, RoutingService_UpdateGlobalConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) UpdateLoggingConfig(ctx context.Context, in *UpdateLoggingConfigRequest, opts ...grpc.CallOption) (*UpdateLoggingConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateLoggingConfigResponse)
+	err := c.cc.Invoke(ctx, RoutingService_UpdateLoggingConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) GetRouteStats(ctx context.Context, in *GetRouteStatsRequest, opts ...grpc.CallOption) (*GetRouteStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRouteStatsResponse)
+	err := c.cc.Invoke(ctx, RoutingService_GetRouteStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) UpdateCertificates(ctx context.Context, in *UpdateCertificatesRequest, opts ...grpc.CallOption) (*UpdateCertificatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCertificatesResponse)
+	err := c.cc.Invoke(ctx, RoutingService_UpdateCertificates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingServiceClient) DeleteRoutes(ctx context.Context, in *DeleteRoutesRequest, opts ...grpc.CallOption) (*UpdateRoutesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRoutesResponse)
+	err := c.cc.Invoke(ctx, RoutingService_DeleteRoutes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoutingServiceServer is the server API for RoutingService service.
 // All implementations must embed UnimplementedRoutingServiceServer
 // for forward compatibility.
@@ -95,6 +199,30 @@ type RoutingServiceServer interface {
 	GetRoutes(context.Context, *GetRoutesRequest) (*GetRoutesResponse, error)
 	// Health returns the health status of the proxy.
 	Health(context.Context, *HealthRequest) (*HealthResponse, error)
+	// StreamRoutes keeps a persistent stream between controller and proxy.
+	// The controller sends a full snapshot first and deltas afterwards.
+	// The proxy acknowledges every update and may report its health at any time.
+	StreamRoutes(grpc.BidiStreamingServer[StreamRoutesRequest, StreamRoutesResponse]) error
+	// GetBackendHealth returns the health of backends with active health
+	// checking.
+	GetBackendHealth(context.Context, *GetBackendHealthRequest) (*GetBackendHealthResponse, error)
+	// UpdateGlobalConfig replaces the gateway-wide proxy settings. Settings
+	// that are not set use the proxy defaults.
+	UpdateGlobalConfig(context.Context, *UpdateGlobalConfigRequest) (*UpdateGlobalConfigResponse, error)
+	// UpdateLoggingConfig replaces the access log settings. Settings that are
+	// not set use the proxy defaults.
+	UpdateLoggingConfig(context.Context, *UpdateLoggingConfigRequest) (*UpdateLoggingConfigResponse, error)
+	// GetRouteStats returns the traffic statistics of routes and listeners,
+	// counted since the proxy started.
+	GetRouteStats(context.Context, *GetRouteStatsRequest) (*GetRouteStatsResponse, error)
+	// UpdateCertificates replaces the certificates of the HTTPS listeners.
+	// They are sent apart from the routes, so that private keys never show
+	// up in route configs.
+	UpdateCertificates(context.Context, *UpdateCertificatesRequest) (*UpdateCertificatesResponse, error)
+	// DeleteRoutes removes the listed routes from the applied configuration,
+	// so that the controller can remove a route before its object is deleted.
+	// It is answered like an update.
+	DeleteRoutes(context.Context, *DeleteRoutesRequest) (*UpdateRoutesResponse, error)
 	mustEmbedUnimplementedRoutingServiceServer()
 }
 
@@ -114,6 +242,27 @@ func (UnimplementedRoutingServiceServer) GetRoutes(context.Context, *GetRoutesRe
 func (UnimplementedRoutingServiceServer) Health(context.Context, *HealthRequest) (*HealthResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Health not implemented")
 }
+func (UnimplementedRoutingServiceServer) StreamRoutes(grpc.BidiStreamingServer[StreamRoutesRequest, StreamRoutesResponse]) error {
+	return status.Error(codes.Unimplemented, "method StreamRoutes not implemented")
+}
+func (UnimplementedRoutingServiceServer) GetBackendHealth(context.Context, *GetBackendHealthRequest) (*GetBackendHealthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBackendHealth not implemented")
+}
+func (UnimplementedRoutingServiceServer) UpdateGlobalConfig(context.Context, *UpdateGlobalConfigRequest) (*UpdateGlobalConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateGlobalConfig not implemented")
+}
+func (UnimplementedRoutingServiceServer) UpdateLoggingConfig(context.Context, *UpdateLoggingConfigRequest) (*UpdateLoggingConfigResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateLoggingConfig not implemented")
+}
+func (UnimplementedRoutingServiceServer) GetRouteStats(context.Context, *GetRouteStatsRequest) (*GetRouteStatsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRouteStats not implemented")
+}
+func (UnimplementedRoutingServiceServer) UpdateCertificates(context.Context, *UpdateCertificatesRequest) (*UpdateCertificatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCertificates not implemented")
+}
+func (UnimplementedRoutingServiceServer) DeleteRoutes(context.Context, *DeleteRoutesRequest) (*UpdateRoutesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteRoutes not implemented")
+}
 func (UnimplementedRoutingServiceServer) mustEmbedUnimplementedRoutingServiceServer() {}
 func (UnimplementedRoutingServiceServer) testEmbeddedByValue()                        {}
 
@@ -189,6 +338,121 @@ func _RoutingService_Health_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RoutingService_StreamRoutes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RoutingServiceServer).StreamRoutes(&grpc.GenericServerStream[StreamRoutesRequest, StreamRoutesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoutingService_StreamRoutesServer = grpc.BidiStreamingServer[StreamRoutesRequest, StreamRoutesResponse]
+
+func _RoutingService_GetBackendHealth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBackendHealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).GetBackendHealth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_GetBackendHealth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).GetBackendHealth(ctx, req.(*GetBackendHealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_UpdateGlobalConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateGlobalConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).UpdateGlobalConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_UpdateGlobalConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).UpdateGlobalConfig(ctx, req.(*UpdateGlobalConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_UpdateLoggingConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLoggingConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).UpdateLoggingConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_UpdateLoggingConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).UpdateLoggingConfig(ctx, req.(*UpdateLoggingConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_GetRouteStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRouteStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).GetRouteStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_GetRouteStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).GetRouteStats(ctx, req.(*GetRouteStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_UpdateCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCertificatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).UpdateCertificates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_UpdateCertificates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).UpdateCertificates(ctx, req.(*UpdateCertificatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingService_DeleteRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingServiceServer).DeleteRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoutingService_DeleteRoutes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingServiceServer).DeleteRoutes(ctx, req.(*DeleteRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoutingService_ServiceDesc is the grpc.ServiceDesc for RoutingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -208,7 +472,38 @@ var RoutingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Health",
 			Handler:    _RoutingService_Health_Handler,
 		},
+		{
+			MethodName: "GetBackendHealth",
+			Handler:    _RoutingService_GetBackendHealth_Handler,
+		},
+		{
+			MethodName: "UpdateGlobalConfig",
+			Handler:    _RoutingService_UpdateGlobalConfig_Handler,
+		},
+		{
+			MethodName: "UpdateLoggingConfig",
+			Handler:    _RoutingService_UpdateLoggingConfig_Handler,
+		},
+		{
+			MethodName: "GetRouteStats",
+			Handler:    _RoutingService_GetRouteStats_Handler,
+		},
+		{
+			MethodName: "UpdateCertificates",
+			Handler:    _RoutingService_UpdateCertificates_Handler,
+		},
+		{
+			MethodName: "DeleteRoutes",
+			Handler:    _RoutingService_DeleteRoutes_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRoutes",
+			Handler:       _RoutingService_StreamRoutes_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "routing/v1/routing.proto",
 }
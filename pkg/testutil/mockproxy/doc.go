@@ -0,0 +1,17 @@
+// Package mockproxy provides an in-memory Pingora proxy for tests.
+//
+// Server implements the RoutingService of the proxy in Go, so that
+// controller logic can be tested against a real gRPC endpoint without
+// building the Rust proxy container:
+//
+//	proxy := mockproxy.New()
+//	addr, err := proxy.Start()
+//	...
+//	defer proxy.Stop()
+//
+// Route updates, sent with UpdateRoutes or over the StreamRoutes stream,
+// replace or patch an in-memory route store and are acknowledged with the
+// requested version, like the real proxy does. Tests inspect the store with
+// HTTPRoutes, GRPCRoutes and AppliedVersions, inject failures with SetError
+// and RejectUpdates, and simulate a proxy restart with Restart.
+package mockproxy
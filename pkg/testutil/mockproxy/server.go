@@ -0,0 +1,646 @@
+package mockproxy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+// Names of the RoutingService methods, for SetError.
+const (
+	MethodUpdateRoutes        = "UpdateRoutes"
+	MethodGetRoutes           = "GetRoutes"
+	MethodHealth              = "Health"
+	MethodStreamRoutes        = "StreamRoutes"
+	MethodGetBackendHealth    = "GetBackendHealth"
+	MethodUpdateGlobalConfig  = "UpdateGlobalConfig"
+	MethodUpdateLoggingConfig = "UpdateLoggingConfig"
+	MethodGetRouteStats       = "GetRouteStats"
+	MethodUpdateCertificates  = "UpdateCertificates"
+	MethodDeleteRoutes        = "DeleteRoutes"
+)
+
+// Server is an in-memory implementation of the RoutingService of the
+// Pingora proxy. The zero value is not usable; create it with New.
+type Server struct {
+	routingv1.UnimplementedRoutingServiceServer
+
+	mu sync.Mutex
+
+	// Applied configuration. appliedVersion is zero until the first update.
+	appliedVersion uint64
+	httpRoutes     map[string]*routingv1.HTTPRoute
+	grpcRoutes     map[string]*routingv1.GRPCRoute
+	listeners      []*routingv1.Listener
+	globalConfig   *routingv1.GlobalConfig
+	loggingConfig  *routingv1.LoggingConfig
+	certificates   *routingv1.UpdateCertificatesRequest
+
+	// history lists every applied version in order.
+	history []uint64
+
+	// changed is closed and replaced whenever an update is applied.
+	changed chan struct{}
+
+	// Injected behavior.
+	errs          map[string]error
+	rejection     string
+	unhealthy     bool
+	backendHealth []*routingv1.BackendHealth
+	routeStats    *routingv1.GetRouteStatsResponse
+
+	grpcServer *grpc.Server
+	listener   net.Listener
+}
+
+// New creates a healthy Server without routes.
+func New() *Server {
+	return &Server{
+		httpRoutes: make(map[string]*routingv1.HTTPRoute),
+		grpcRoutes: make(map[string]*routingv1.GRPCRoute),
+		changed:    make(chan struct{}),
+		errs:       make(map[string]error),
+	}
+}
+
+// Start serves the RoutingService on a random local TCP port and returns
+// its address.
+func (s *Server) Start() (string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to listen")
+	}
+
+	grpcServer := grpc.NewServer()
+	routingv1.RegisterRoutingServiceServer(grpcServer, s)
+
+	s.mu.Lock()
+	s.grpcServer = grpcServer
+	s.listener = listener
+	s.mu.Unlock()
+
+	go func() {
+		// Serve only returns once Stop is called
+		_ = grpcServer.Serve(listener)
+	}()
+
+	return listener.Addr().String(), nil
+}
+
+// Stop stops serving and closes all connections. It is a no-op if the
+// server was not started.
+func (s *Server) Stop() {
+	s.mu.Lock()
+	grpcServer := s.grpcServer
+	s.grpcServer = nil
+	s.listener = nil
+	s.mu.Unlock()
+
+	if grpcServer != nil {
+		grpcServer.Stop()
+	}
+}
+
+// Addr returns the address the server listens on, or an empty string if
+// it was not started.
+func (s *Server) Addr() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener == nil {
+		return ""
+	}
+
+	return s.listener.Addr().String()
+}
+
+// SetError makes every call of method fail with err until it is cleared
+// with a nil err. For MethodStreamRoutes, opening a stream fails; return a
+// codes.Unimplemented status to emulate a proxy without streaming support.
+func (s *Server) SetError(method string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		delete(s.errs, method)
+
+		return
+	}
+
+	s.errs[method] = err
+}
+
+// RejectUpdates makes the server answer every route update with
+// success=false and reason as the error, without applying it. An empty
+// reason accepts updates again.
+func (s *Server) RejectUpdates(reason string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rejection = reason
+}
+
+// SetHealthy sets the health reported by the Health RPC.
+func (s *Server) SetHealthy(healthy bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.unhealthy = !healthy
+}
+
+// SetBackendHealth sets the backends reported by the GetBackendHealth RPC.
+func (s *Server) SetBackendHealth(backends []*routingv1.BackendHealth) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.backendHealth = cloneAll(backends)
+}
+
+// SetRouteStats sets the traffic statistics reported by the GetRouteStats
+// RPC.
+func (s *Server) SetRouteStats(stats *routingv1.GetRouteStatsResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.routeStats = proto.CloneOf(stats)
+}
+
+// Restart forgets the applied configuration, like a restarted proxy that
+// reports config version 0. Open connections and injected behavior are kept.
+func (s *Server) Restart() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.appliedVersion = 0
+	s.httpRoutes = make(map[string]*routingv1.HTTPRoute)
+	s.grpcRoutes = make(map[string]*routingv1.GRPCRoute)
+	s.listeners = nil
+	s.globalConfig = nil
+	s.loggingConfig = nil
+	s.certificates = nil
+}
+
+// AppliedVersion returns the version of the applied configuration, zero
+// before the first update.
+func (s *Server) AppliedVersion() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.appliedVersion
+}
+
+// AppliedVersions returns every applied version in the order the updates
+// were applied, including updates before a Restart.
+func (s *Server) AppliedVersions() []uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.history)
+}
+
+// HTTPRoutes returns copies of the applied HTTP routes, sorted by ID.
+func (s *Server) HTTPRoutes() []*routingv1.HTTPRoute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sortedRoutes(s.httpRoutes)
+}
+
+// GRPCRoutes returns copies of the applied gRPC routes, sorted by ID.
+func (s *Server) GRPCRoutes() []*routingv1.GRPCRoute {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return sortedRoutes(s.grpcRoutes)
+}
+
+// Listeners returns copies of the applied listener settings.
+func (s *Server) Listeners() []*routingv1.Listener {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return cloneAll(s.listeners)
+}
+
+// GlobalConfig returns a copy of the applied global config, or nil before
+// the first update.
+func (s *Server) GlobalConfig() *routingv1.GlobalConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.globalConfig == nil {
+		return nil
+	}
+
+	return proto.Clone(s.globalConfig).(*routingv1.GlobalConfig)
+}
+
+// LoggingConfig returns a copy of the applied access log settings, or nil
+// before the first update.
+func (s *Server) LoggingConfig() *routingv1.LoggingConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loggingConfig == nil {
+		return nil
+	}
+
+	return proto.Clone(s.loggingConfig).(*routingv1.LoggingConfig)
+}
+
+// Certificates returns a copy of the applied certificates, or nil before
+// the first update.
+func (s *Server) Certificates() *routingv1.UpdateCertificatesRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.certificates == nil {
+		return nil
+	}
+
+	return proto.CloneOf(s.certificates)
+}
+
+// WaitForVersion blocks until an update with at least version is applied
+// or ctx is done.
+func (s *Server) WaitForVersion(ctx context.Context, version uint64) error {
+	for {
+		s.mu.Lock()
+		applied := s.appliedVersion
+		changed := s.changed
+		s.mu.Unlock()
+
+		if applied >= version {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "version %d not applied, proxy is at %d", version, applied)
+		case <-changed:
+		}
+	}
+}
+
+// UpdateRoutes implements routingv1.RoutingServiceServer.
+func (s *Server) UpdateRoutes(
+	_ context.Context,
+	req *routingv1.UpdateRoutesRequest,
+) (*routingv1.UpdateRoutesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodUpdateRoutes]; err != nil {
+		return nil, err
+	}
+
+	return s.applyFull(req), nil
+}
+
+// GetRoutes implements routingv1.RoutingServiceServer.
+func (s *Server) GetRoutes(context.Context, *routingv1.GetRoutesRequest) (*routingv1.GetRoutesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodGetRoutes]; err != nil {
+		return nil, err
+	}
+
+	return &routingv1.GetRoutesResponse{
+		HttpRoutes: sortedRoutes(s.httpRoutes),
+		GrpcRoutes: sortedRoutes(s.grpcRoutes),
+		Version:    s.appliedVersion,
+		Listeners:  cloneAll(s.listeners),
+	}, nil
+}
+
+// Health implements routingv1.RoutingServiceServer.
+func (s *Server) Health(context.Context, *routingv1.HealthRequest) (*routingv1.HealthResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodHealth]; err != nil {
+		return nil, err
+	}
+
+	return s.health(), nil
+}
+
+// GetBackendHealth implements routingv1.RoutingServiceServer.
+func (s *Server) GetBackendHealth(
+	context.Context,
+	*routingv1.GetBackendHealthRequest,
+) (*routingv1.GetBackendHealthResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodGetBackendHealth]; err != nil {
+		return nil, err
+	}
+
+	return &routingv1.GetBackendHealthResponse{Backends: cloneAll(s.backendHealth)}, nil
+}
+
+// GetRouteStats implements routingv1.RoutingServiceServer.
+func (s *Server) GetRouteStats(
+	context.Context,
+	*routingv1.GetRouteStatsRequest,
+) (*routingv1.GetRouteStatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodGetRouteStats]; err != nil {
+		return nil, err
+	}
+
+	if s.routeStats == nil {
+		return &routingv1.GetRouteStatsResponse{}, nil
+	}
+
+	return proto.CloneOf(s.routeStats), nil
+}
+
+// UpdateGlobalConfig implements routingv1.RoutingServiceServer.
+func (s *Server) UpdateGlobalConfig(
+	_ context.Context,
+	req *routingv1.UpdateGlobalConfigRequest,
+) (*routingv1.UpdateGlobalConfigResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodUpdateGlobalConfig]; err != nil {
+		return nil, err
+	}
+
+	// A request without config resets the proxy defaults
+	config := req.GetConfig()
+	if config == nil {
+		config = &routingv1.GlobalConfig{}
+	}
+
+	s.globalConfig = proto.Clone(config).(*routingv1.GlobalConfig)
+
+	return &routingv1.UpdateGlobalConfigResponse{Success: true}, nil
+}
+
+// UpdateLoggingConfig implements routingv1.RoutingServiceServer.
+func (s *Server) UpdateLoggingConfig(
+	_ context.Context,
+	req *routingv1.UpdateLoggingConfigRequest,
+) (*routingv1.UpdateLoggingConfigResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodUpdateLoggingConfig]; err != nil {
+		return nil, err
+	}
+
+	// A request without config resets the proxy defaults
+	config := req.GetConfig()
+	if config == nil {
+		config = &routingv1.LoggingConfig{}
+	}
+
+	s.loggingConfig = proto.Clone(config).(*routingv1.LoggingConfig)
+
+	return &routingv1.UpdateLoggingConfigResponse{Success: true}, nil
+}
+
+// UpdateCertificates implements routingv1.RoutingServiceServer.
+func (s *Server) UpdateCertificates(
+	_ context.Context,
+	req *routingv1.UpdateCertificatesRequest,
+) (*routingv1.UpdateCertificatesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodUpdateCertificates]; err != nil {
+		return nil, err
+	}
+
+	s.certificates = proto.CloneOf(req)
+
+	return &routingv1.UpdateCertificatesResponse{Success: true}, nil
+}
+
+// DeleteRoutes implements routingv1.RoutingServiceServer. Unknown route IDs
+// are ignored.
+func (s *Server) DeleteRoutes(
+	_ context.Context,
+	req *routingv1.DeleteRoutesRequest,
+) (*routingv1.UpdateRoutesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.errs[MethodDeleteRoutes]; err != nil {
+		return nil, err
+	}
+
+	if s.rejection != "" {
+		return s.reject(s.rejection), nil
+	}
+
+	for _, id := range req.GetHttpRouteIds() {
+		delete(s.httpRoutes, id)
+	}
+
+	for _, id := range req.GetGrpcRouteIds() {
+		delete(s.grpcRoutes, id)
+	}
+
+	return s.applied(req.GetVersion()), nil
+}
+
+// StreamRoutes implements routingv1.RoutingServiceServer. Every update is
+// acknowledged; a delta whose base version differs from the applied version
+// is rejected without being applied.
+func (s *Server) StreamRoutes(
+	stream grpc.BidiStreamingServer[routingv1.StreamRoutesRequest, routingv1.StreamRoutesResponse],
+) error {
+	s.mu.Lock()
+	err := s.errs[MethodStreamRoutes]
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck // stream status is returned to the client as is
+		}
+
+		ack := s.applyStreamUpdate(req)
+
+		sendErr := stream.Send(&routingv1.StreamRoutesResponse{
+			Message: &routingv1.StreamRoutesResponse_Ack{Ack: ack},
+		})
+		if sendErr != nil {
+			return sendErr //nolint:wrapcheck // stream status is returned to the client as is
+		}
+	}
+}
+
+// applyStreamUpdate applies a single update received over the stream.
+func (s *Server) applyStreamUpdate(req *routingv1.StreamRoutesRequest) *routingv1.UpdateRoutesResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if full := req.GetFull(); full != nil {
+		return s.applyFull(full)
+	}
+
+	delta := req.GetDelta()
+	if delta == nil {
+		return &routingv1.UpdateRoutesResponse{Error: "update carries neither a snapshot nor a delta"}
+	}
+
+	return s.applyDelta(delta)
+}
+
+// applyFull replaces the applied configuration, unless the request expects
+// another applied version. s.mu must be held.
+func (s *Server) applyFull(req *routingv1.UpdateRoutesRequest) *routingv1.UpdateRoutesResponse {
+	if s.rejection != "" {
+		return s.reject(s.rejection)
+	}
+
+	if req.ExpectedVersion != nil && req.GetExpectedVersion() != s.appliedVersion {
+		resp := s.reject(fmt.Sprintf(
+			"expected version %d does not match applied version %d",
+			req.GetExpectedVersion(), s.appliedVersion,
+		))
+		resp.VersionConflict = true
+
+		return resp
+	}
+
+	s.httpRoutes = make(map[string]*routingv1.HTTPRoute, len(req.GetHttpRoutes()))
+	for _, route := range req.GetHttpRoutes() {
+		s.httpRoutes[route.GetId()] = proto.Clone(route).(*routingv1.HTTPRoute)
+	}
+
+	s.grpcRoutes = make(map[string]*routingv1.GRPCRoute, len(req.GetGrpcRoutes()))
+	for _, route := range req.GetGrpcRoutes() {
+		s.grpcRoutes[route.GetId()] = proto.Clone(route).(*routingv1.GRPCRoute)
+	}
+
+	s.listeners = cloneAll(req.GetListeners())
+
+	return s.applied(req.GetVersion())
+}
+
+// applyDelta patches the applied configuration. s.mu must be held.
+func (s *Server) applyDelta(delta *routingv1.RoutesDelta) *routingv1.UpdateRoutesResponse {
+	if s.rejection != "" {
+		return s.reject(s.rejection)
+	}
+
+	if delta.GetBaseVersion() != s.appliedVersion {
+		return s.reject(fmt.Sprintf(
+			"delta base version %d does not match applied version %d",
+			delta.GetBaseVersion(), s.appliedVersion,
+		))
+	}
+
+	for _, id := range delta.GetRemoveHttpRouteIds() {
+		delete(s.httpRoutes, id)
+	}
+
+	for _, id := range delta.GetRemoveGrpcRouteIds() {
+		delete(s.grpcRoutes, id)
+	}
+
+	for _, route := range delta.GetUpsertHttpRoutes() {
+		s.httpRoutes[route.GetId()] = proto.Clone(route).(*routingv1.HTTPRoute)
+	}
+
+	for _, route := range delta.GetUpsertGrpcRoutes() {
+		s.grpcRoutes[route.GetId()] = proto.Clone(route).(*routingv1.GRPCRoute)
+	}
+
+	return s.applied(delta.GetVersion())
+}
+
+// applied records version as applied and acknowledges it. s.mu must be held.
+func (s *Server) applied(version uint64) *routingv1.UpdateRoutesResponse {
+	s.appliedVersion = version
+	s.history = append(s.history, version)
+
+	close(s.changed)
+	s.changed = make(chan struct{})
+
+	return &routingv1.UpdateRoutesResponse{
+		Success:        true,
+		AppliedVersion: version,
+		HttpRouteCount: uint32(len(s.httpRoutes)), //nolint:gosec // route counts fit into uint32
+		GrpcRouteCount: uint32(len(s.grpcRoutes)), //nolint:gosec // route counts fit into uint32
+	}
+}
+
+// reject answers an update that was not applied. s.mu must be held.
+func (s *Server) reject(reason string) *routingv1.UpdateRoutesResponse {
+	return &routingv1.UpdateRoutesResponse{
+		Error:          reason,
+		AppliedVersion: s.appliedVersion,
+	}
+}
+
+// health builds the health report. s.mu must be held.
+func (s *Server) health() *routingv1.HealthResponse {
+	status := "ok"
+	if s.unhealthy {
+		status = "unhealthy"
+	}
+
+	return &routingv1.HealthResponse{
+		Healthy:       !s.unhealthy,
+		Status:        status,
+		ConfigVersion: s.appliedVersion,
+	}
+}
+
+// identified is a route message with an ID.
+type identified interface {
+	proto.Message
+	GetId() string
+}
+
+// sortedRoutes returns copies of routes sorted by ID.
+func sortedRoutes[T identified](routes map[string]T) []T {
+	sorted := make([]T, 0, len(routes))
+	for _, id := range slices.Sorted(maps.Keys(routes)) {
+		sorted = append(sorted, proto.Clone(routes[id]).(T))
+	}
+
+	return sorted
+}
+
+// cloneAll returns deep copies of messages, nil for an empty slice.
+func cloneAll[T proto.Message](messages []T) []T {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	clones := make([]T, len(messages))
+	for i, message := range messages {
+		clones[i] = proto.Clone(message).(T)
+	}
+
+	return clones
+}
@@ -0,0 +1,375 @@
+package mockproxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	routingv1 "github.com/lexfrei/pingora-gateway-controller/pkg/api/routing/v1"
+)
+
+func startServer(t *testing.T) (*Server, routingv1.RoutingServiceClient) {
+	t.Helper()
+
+	server := New()
+
+	addr, err := server.Start()
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return server, routingv1.NewRoutingServiceClient(conn)
+}
+
+func httpRoute(id, address string) *routingv1.HTTPRoute {
+	return &routingv1.HTTPRoute{
+		Id: id,
+		Rules: []*routingv1.HTTPRouteRule{
+			{Backends: []*routingv1.Backend{{Address: address, Weight: 1}}},
+		},
+	}
+}
+
+func routeIDs(routes []*routingv1.HTTPRoute) []string {
+	ids := make([]string, 0, len(routes))
+	for _, route := range routes {
+		ids = append(ids, route.GetId())
+	}
+
+	return ids
+}
+
+func TestServer_UpdateRoutes(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+	ctx := context.Background()
+
+	health, err := client.Health(ctx, &routingv1.HealthRequest{})
+	require.NoError(t, err)
+	assert.True(t, health.GetHealthy())
+	assert.Zero(t, health.GetConfigVersion())
+
+	resp, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+		Version:    3,
+		HttpRoutes: []*routingv1.HTTPRoute{httpRoute("default/b", "b:80"), httpRoute("default/a", "a:80")},
+		GrpcRoutes: []*routingv1.GRPCRoute{{Id: "default/grpc"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	assert.Equal(t, uint64(3), resp.GetAppliedVersion())
+	assert.Equal(t, uint32(2), resp.GetHttpRouteCount())
+	assert.Equal(t, uint32(1), resp.GetGrpcRouteCount())
+
+	assert.Equal(t, []string{"default/a", "default/b"}, routeIDs(server.HTTPRoutes()))
+	require.Len(t, server.GRPCRoutes(), 1)
+	assert.Equal(t, []uint64{3}, server.AppliedVersions())
+
+	routes, err := client.GetRoutes(ctx, &routingv1.GetRoutesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), routes.GetVersion())
+	assert.Equal(t, []string{"default/a", "default/b"}, routeIDs(routes.GetHttpRoutes()))
+
+	health, err = client.Health(ctx, &routingv1.HealthRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), health.GetConfigVersion())
+
+	server.Restart()
+	assert.Zero(t, server.AppliedVersion())
+	assert.Empty(t, server.HTTPRoutes())
+}
+
+func TestServer_StreamRoutes(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.StreamRoutes(ctx)
+	require.NoError(t, err)
+
+	send := func(req *routingv1.StreamRoutesRequest) *routingv1.UpdateRoutesResponse {
+		t.Helper()
+
+		require.NoError(t, stream.Send(req))
+
+		resp, recvErr := stream.Recv()
+		require.NoError(t, recvErr)
+		require.NotNil(t, resp.GetAck())
+
+		return resp.GetAck()
+	}
+
+	ack := send(&routingv1.StreamRoutesRequest{Update: &routingv1.StreamRoutesRequest_Full{
+		Full: &routingv1.UpdateRoutesRequest{
+			Version:    1,
+			HttpRoutes: []*routingv1.HTTPRoute{httpRoute("default/a", "a:80"), httpRoute("default/b", "b:80")},
+		},
+	}})
+	assert.True(t, ack.GetSuccess())
+
+	ack = send(&routingv1.StreamRoutesRequest{Update: &routingv1.StreamRoutesRequest_Delta{
+		Delta: &routingv1.RoutesDelta{
+			BaseVersion:        1,
+			Version:            2,
+			UpsertHttpRoutes:   []*routingv1.HTTPRoute{httpRoute("default/a", "a:8080"), httpRoute("default/c", "c:80")},
+			RemoveHttpRouteIds: []string{"default/b"},
+		},
+	}})
+	assert.True(t, ack.GetSuccess())
+	assert.Equal(t, uint64(2), ack.GetAppliedVersion())
+
+	routes := server.HTTPRoutes()
+	assert.Equal(t, []string{"default/a", "default/c"}, routeIDs(routes))
+	assert.Equal(t, "a:8080", routes[0].GetRules()[0].GetBackends()[0].GetAddress())
+
+	// A delta based on another version is rejected
+	ack = send(&routingv1.StreamRoutesRequest{Update: &routingv1.StreamRoutesRequest_Delta{
+		Delta: &routingv1.RoutesDelta{BaseVersion: 1, Version: 3, RemoveHttpRouteIds: []string{"default/a"}},
+	}})
+	assert.False(t, ack.GetSuccess())
+	assert.NotEmpty(t, ack.GetError())
+	assert.Equal(t, uint64(2), server.AppliedVersion())
+	assert.Len(t, server.HTTPRoutes(), 2)
+
+	assert.Equal(t, []uint64{1, 2}, server.AppliedVersions())
+}
+
+func TestServer_DeleteRoutes(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+	ctx := context.Background()
+
+	_, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+		Version:    1,
+		HttpRoutes: []*routingv1.HTTPRoute{httpRoute("default/a", "a:80"), httpRoute("default/b", "b:80")},
+		GrpcRoutes: []*routingv1.GRPCRoute{{Id: "default/grpc"}},
+	})
+	require.NoError(t, err)
+
+	resp, err := client.DeleteRoutes(ctx, &routingv1.DeleteRoutesRequest{
+		Version:      2,
+		HttpRouteIds: []string{"default/a", "default/unknown"},
+		GrpcRouteIds: []string{"default/grpc"},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	assert.Equal(t, uint64(2), resp.GetAppliedVersion())
+	assert.Equal(t, []string{"default/b"}, routeIDs(server.HTTPRoutes()))
+	assert.Empty(t, server.GRPCRoutes())
+
+	server.RejectUpdates("read-only")
+
+	resp, err = client.DeleteRoutes(ctx, &routingv1.DeleteRoutesRequest{Version: 3, HttpRouteIds: []string{"default/b"}})
+	require.NoError(t, err)
+	assert.False(t, resp.GetSuccess())
+	assert.Len(t, server.HTTPRoutes(), 1)
+	assert.Equal(t, []uint64{1, 2}, server.AppliedVersions())
+}
+
+func TestServer_ExpectedVersion(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+	ctx := context.Background()
+
+	resp, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 1, ExpectedVersion: proto.Uint64(0)})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+
+	resp, err = client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+		Version:         2,
+		HttpRoutes:      []*routingv1.HTTPRoute{httpRoute("default/a", "a:80")},
+		ExpectedVersion: proto.Uint64(0),
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.GetSuccess())
+	assert.True(t, resp.GetVersionConflict())
+	assert.Equal(t, uint64(1), resp.GetAppliedVersion())
+	assert.Empty(t, server.HTTPRoutes())
+
+	// Updates without an expected version are applied regardless
+	resp, err = client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 3})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	assert.False(t, resp.GetVersionConflict())
+	assert.Equal(t, []uint64{1, 3}, server.AppliedVersions())
+}
+
+func TestServer_ErrorInjection(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+	ctx := context.Background()
+
+	server.SetError(MethodUpdateRoutes, status.Error(codes.Unavailable, "proxy down"))
+
+	_, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 1})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	server.SetError(MethodUpdateRoutes, nil)
+	server.RejectUpdates("invalid route")
+
+	resp, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{Version: 1})
+	require.NoError(t, err)
+	assert.False(t, resp.GetSuccess())
+	assert.Equal(t, "invalid route", resp.GetError())
+	assert.Empty(t, server.AppliedVersions())
+
+	server.RejectUpdates("")
+	server.SetHealthy(false)
+
+	health, err := client.Health(ctx, &routingv1.HealthRequest{})
+	require.NoError(t, err)
+	assert.False(t, health.GetHealthy())
+
+	server.SetError(MethodStreamRoutes, status.Error(codes.Unimplemented, "no streaming"))
+
+	stream, err := client.StreamRoutes(ctx)
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestServer_UpdateGlobalConfig(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+	ctx := context.Background()
+
+	assert.Nil(t, server.GlobalConfig())
+
+	resp, err := client.UpdateGlobalConfig(ctx, &routingv1.UpdateGlobalConfigRequest{
+		Config: &routingv1.GlobalConfig{DefaultRequestTimeoutMs: 30000, TrustedProxyCidrs: []string{"10.0.0.0/8"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	assert.Equal(t, uint64(30000), server.GlobalConfig().GetDefaultRequestTimeoutMs())
+	assert.Equal(t, []string{"10.0.0.0/8"}, server.GlobalConfig().GetTrustedProxyCidrs())
+
+	server.Restart()
+	assert.Nil(t, server.GlobalConfig())
+
+	server.SetError(MethodUpdateGlobalConfig, status.Error(codes.Unimplemented, "unknown method"))
+
+	_, err = client.UpdateGlobalConfig(ctx, &routingv1.UpdateGlobalConfigRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestServer_UpdateLoggingConfig(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+	ctx := context.Background()
+
+	assert.Nil(t, server.LoggingConfig())
+
+	resp, err := client.UpdateLoggingConfig(ctx, &routingv1.UpdateLoggingConfigRequest{
+		Config: &routingv1.LoggingConfig{ExcludePathPrefixes: []string{"/healthz"}},
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	assert.Equal(t, []string{"/healthz"}, server.LoggingConfig().GetExcludePathPrefixes())
+
+	server.Restart()
+	assert.Nil(t, server.LoggingConfig())
+
+	server.SetError(MethodUpdateLoggingConfig, status.Error(codes.Unimplemented, "unknown method"))
+
+	_, err = client.UpdateLoggingConfig(ctx, &routingv1.UpdateLoggingConfigRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestServer_UpdateCertificates(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+	ctx := context.Background()
+
+	assert.Nil(t, server.Certificates())
+
+	resp, err := client.UpdateCertificates(ctx, &routingv1.UpdateCertificatesRequest{
+		Listeners: []*routingv1.ListenerCertificates{{
+			Port:         443,
+			Certificates: []*routingv1.Certificate{{Name: "default/app-tls", Hostnames: []string{"app.example.com"}}},
+		}},
+		Selection: routingv1.CertificateSelection_CERTIFICATE_SELECTION_LISTENER_ORDER,
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+	require.Len(t, server.Certificates().GetListeners(), 1)
+	assert.Equal(t, "default/app-tls", server.Certificates().GetListeners()[0].GetCertificates()[0].GetName())
+	assert.Equal(t, routingv1.CertificateSelection_CERTIFICATE_SELECTION_LISTENER_ORDER, server.Certificates().GetSelection())
+
+	server.Restart()
+	assert.Nil(t, server.Certificates())
+
+	server.SetError(MethodUpdateCertificates, status.Error(codes.Unimplemented, "unknown method"))
+
+	_, err = client.UpdateCertificates(ctx, &routingv1.UpdateCertificatesRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestServer_GetRouteStats(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+	ctx := context.Background()
+
+	resp, err := client.GetRouteStats(ctx, &routingv1.GetRouteStatsRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.GetRoutes())
+
+	server.SetRouteStats(&routingv1.GetRouteStatsResponse{
+		Routes: []*routingv1.RouteStats{{
+			RouteId:   "default/app",
+			Responses: []*routingv1.StatusClassCount{{StatusClass: 2, Count: 10}},
+		}},
+	})
+
+	resp, err = client.GetRouteStats(ctx, &routingv1.GetRouteStatsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetRoutes(), 1)
+	assert.Equal(t, "default/app", resp.GetRoutes()[0].GetRouteId())
+	assert.Equal(t, uint64(10), resp.GetRoutes()[0].GetResponses()[0].GetCount())
+
+	server.SetError(MethodGetRouteStats, status.Error(codes.Unimplemented, "unknown method"))
+
+	_, err = client.GetRouteStats(ctx, &routingv1.GetRouteStatsRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestServer_WaitForVersion(t *testing.T) {
+	t.Parallel()
+
+	server, client := startServer(t)
+
+	go func() {
+		_, _ = client.UpdateRoutes(context.Background(), &routingv1.UpdateRoutesRequest{Version: 5})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, server.WaitForVersion(ctx, 5))
+
+	short, cancelShort := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelShort()
+
+	require.Error(t, server.WaitForVersion(short, 6))
+}
@@ -0,0 +1,95 @@
+//go:build conformance
+
+package conformance
+
+import (
+	"flag"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/gateway-api/conformance"
+	"sigs.k8s.io/gateway-api/conformance/utils/flags"
+	"sigs.k8s.io/gateway-api/conformance/utils/suite"
+	"sigs.k8s.io/gateway-api/pkg/features"
+)
+
+const (
+	defaultGatewayClass = "pingora"
+
+	implementationOrganization = "lexfrei"
+	implementationProject      = "pingora-gateway-controller"
+	implementationURL          = "https://github.com/lexfrei/pingora-gateway-controller"
+	implementationContact      = implementationURL + "/issues"
+)
+
+// supportedFeatures are the Gateway API features the controller implements.
+// Keep in sync with the filters and matches handled by internal/ingress and
+// the features the controller reports in the GatewayClass status.
+var supportedFeatures = []features.FeatureName{
+	features.SupportGateway,
+	features.SupportReferenceGrant,
+	features.SupportHTTPRoute,
+	features.SupportHTTPRouteQueryParamMatching,
+	features.SupportHTTPRouteMethodMatching,
+	features.SupportHTTPRouteRequestTimeout,
+	features.SupportHTTPRouteBackendTimeout,
+	features.SupportHTTPRouteNamedRouteRule,
+	features.SupportHTTPRouteCORS,
+	features.SupportGRPCRoute,
+	features.SupportGRPCRouteNamedRouteRule,
+}
+
+func TestGatewayAPIConformance(t *testing.T) {
+	opts := conformance.DefaultOptions(t)
+
+	// The flag package default is the upstream "gateway-conformance" class
+	if !isFlagSet("gateway-class") {
+		opts.GatewayClassName = defaultGatewayClass
+	}
+
+	if *flags.SupportedFeatures == "" && !opts.EnableAllSupportedFeatures {
+		opts.SupportedFeatures = sets.New(supportedFeatures...)
+	}
+
+	if len(opts.ConformanceProfiles) == 0 {
+		opts.ConformanceProfiles = sets.New(
+			suite.GatewayHTTPConformanceProfileName,
+			suite.GatewayGRPCConformanceProfileName,
+		)
+	}
+
+	if opts.Implementation.Organization == "" {
+		opts.Implementation.Organization = implementationOrganization
+	}
+
+	if opts.Implementation.Project == "" {
+		opts.Implementation.Project = implementationProject
+	}
+
+	if opts.Implementation.URL == "" {
+		opts.Implementation.URL = implementationURL
+	}
+
+	if opts.Implementation.Version == "" {
+		opts.Implementation.Version = "dev"
+	}
+
+	if len(opts.Implementation.Contact) == 0 {
+		opts.Implementation.Contact = []string{implementationContact}
+	}
+
+	conformance.RunConformanceWithOptions(t, opts)
+}
+
+// isFlagSet reports whether the named command line flag was passed.
+func isFlagSet(name string) bool {
+	set := false
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
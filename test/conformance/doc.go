@@ -0,0 +1,14 @@
+//go:build conformance
+
+// Package conformance runs the official Gateway API conformance suite
+// against a cluster where the pingora-gateway-controller is installed.
+// The suite uses the current kubeconfig context and expects a GatewayClass
+// managed by the controller.
+//
+// Run with: go test -v -tags=conformance -timeout=30m ./test/conformance/...
+//
+// The conformance flags of sigs.k8s.io/gateway-api/conformance/utils/flags
+// are accepted after -args. Unset flags default to the GatewayClass
+// "pingora", the GATEWAY-HTTP and GATEWAY-GRPC profiles and the features
+// the controller supports. Pass -report-output to write a ConformanceReport.
+package conformance
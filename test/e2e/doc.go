@@ -0,0 +1,22 @@
+//go:build e2e
+
+// Package e2e provides end-to-end tests that run the pingora-gateway-controller
+// and the Pingora proxy in a kind cluster. The suite installs the Gateway API
+// CRDs and the Helm chart, applies Gateway and HTTPRoute resources, and
+// asserts their status conditions and the traffic served by the proxy.
+//
+// Run with: make test-e2e
+//
+// Or, with pre-built images: go test -v -tags=e2e -timeout=30m ./test/e2e/...
+//
+// Requires kind, kubectl, helm and a container runtime (Docker/Podman).
+//
+// Environment variables:
+//   - CONTROLLER_IMAGE: Controller image to load into the cluster (required)
+//   - PINGORA_PROXY_IMAGE: Proxy image to load into the cluster (required)
+//   - CONTAINER_RUNTIME: Runtime holding the images (default: podman)
+//   - E2E_CLUSTER_NAME: Name of the kind cluster (default: pingora-e2e)
+//   - E2E_KEEP_CLUSTER: Keep the cluster after the run when set to "true"
+//   - GATEWAY_API_CRDS: Gateway API CRD manifest (default: v1.4.1 standard channel)
+//   - E2E_BACKEND_IMAGE: Backend image serving /hostname (default: agnhost)
+package e2e
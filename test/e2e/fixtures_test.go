@@ -0,0 +1,276 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	// statusTimeout bounds the wait for status conditions and traffic.
+	statusTimeout = 2 * time.Minute
+	pollInterval  = time.Second
+
+	backendPort = 8080
+)
+
+// createNamespace creates a namespace for a single test and deletes it on cleanup.
+func createNamespace(t *testing.T, prefix string) string {
+	t.Helper()
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: prefix + "-"}}
+	require.NoError(t, k8sClient.Create(context.Background(), namespace))
+
+	t.Cleanup(func() {
+		_ = k8sClient.Delete(context.Background(), namespace)
+	})
+
+	return namespace.Name
+}
+
+// deployBackend creates a Deployment and a Service answering HTTP requests
+// on backendPort; GET /hostname returns the name of the pod.
+func deployBackend(t *testing.T, namespace, name string) {
+	t.Helper()
+
+	ctx := context.Background()
+	labels := map[string]string{"app": name}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "backend",
+						Image: envOrDefault("E2E_BACKEND_IMAGE", defaultBackendImage),
+						Args:  []string{"netexec", fmt.Sprintf("--http-port=%d", backendPort)},
+						Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: backendPort}},
+						ReadinessProbe: &corev1.Probe{
+							ProbeHandler: corev1.ProbeHandler{
+								HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")},
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, deployment))
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: corev1.ServiceSpec{
+			Selector: labels,
+			Ports: []corev1.ServicePort{{
+				Name:       "http",
+				Port:       backendPort,
+				TargetPort: intstr.FromString("http"),
+			}},
+		},
+	}
+	require.NoError(t, k8sClient.Create(ctx, service))
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		current := &appsv1.Deployment{}
+		if !assert.NoError(c, k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), current)) {
+			return
+		}
+
+		assert.Equal(c, int32(1), current.Status.ReadyReplicas)
+	}, statusTimeout, pollInterval, "backend %s/%s not ready", namespace, name)
+}
+
+// createGateway creates a Gateway of the controller with one HTTP listener
+// on the proxy port.
+func createGateway(t *testing.T, namespace, name string) *gatewayv1.Gateway {
+	t.Helper()
+
+	gateway := &gatewayv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1.GatewaySpec{
+			GatewayClassName: gatewayClassName,
+			Listeners: []gatewayv1.Listener{{
+				Name:     "http",
+				Port:     proxyListenerPort,
+				Protocol: gatewayv1.HTTPProtocolType,
+			}},
+		},
+	}
+	require.NoError(t, k8sClient.Create(context.Background(), gateway))
+
+	return gateway
+}
+
+// newHTTPRoute returns an HTTPRoute for hostname, attached to the Gateway,
+// that forwards all paths to the backend Service.
+func newHTTPRoute(namespace, name, gatewayName, hostname, backend string) *gatewayv1.HTTPRoute {
+	return &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{
+				ParentRefs: []gatewayv1.ParentReference{{Name: gatewayv1.ObjectName(gatewayName)}},
+			},
+			Hostnames: []gatewayv1.Hostname{gatewayv1.Hostname(hostname)},
+			Rules: []gatewayv1.HTTPRouteRule{{
+				BackendRefs: []gatewayv1.HTTPBackendRef{{
+					BackendRef: gatewayv1.BackendRef{
+						BackendObjectReference: gatewayv1.BackendObjectReference{
+							Name: gatewayv1.ObjectName(backend),
+							Port: ptr.To(gatewayv1.PortNumber(backendPort)),
+						},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+// requireGatewayCondition waits until the Gateway has the condition with the status.
+func requireGatewayCondition(
+	t *testing.T,
+	gateway *gatewayv1.Gateway,
+	conditionType string,
+	status metav1.ConditionStatus,
+) {
+	t.Helper()
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		current := &gatewayv1.Gateway{}
+		if !assert.NoError(c, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(gateway), current)) {
+			return
+		}
+
+		condition := meta.FindStatusCondition(current.Status.Conditions, conditionType)
+		if assert.NotNil(c, condition, "condition %s not set", conditionType) {
+			assert.Equal(c, status, condition.Status, "%s: %s", condition.Reason, condition.Message)
+		}
+	}, statusTimeout, pollInterval, "Gateway %s/%s condition %s", gateway.Namespace, gateway.Name, conditionType)
+}
+
+// requireRouteCondition waits until the HTTPRoute reports the condition with
+// the status and, if set, the reason for its first parent.
+func requireRouteCondition(
+	t *testing.T,
+	route *gatewayv1.HTTPRoute,
+	conditionType string,
+	status metav1.ConditionStatus,
+	reason string,
+) {
+	t.Helper()
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		current := &gatewayv1.HTTPRoute{}
+		if !assert.NoError(c, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(route), current)) {
+			return
+		}
+
+		if !assert.NotEmpty(c, current.Status.Parents, "no parent status") {
+			return
+		}
+
+		condition := meta.FindStatusCondition(current.Status.Parents[0].Conditions, conditionType)
+		if !assert.NotNil(c, condition, "condition %s not set", conditionType) {
+			return
+		}
+
+		assert.Equal(c, status, condition.Status, "%s: %s", condition.Reason, condition.Message)
+		assert.Equal(c, route.Generation, condition.ObservedGeneration)
+
+		if reason != "" {
+			assert.Equal(c, reason, condition.Reason)
+		}
+	}, statusTimeout, pollInterval, "HTTPRoute %s/%s condition %s", route.Namespace, route.Name, conditionType)
+}
+
+// portForwardProxy forwards a local port to the HTTP port of the proxy
+// Service and returns the base URL.
+func portForwardProxy(t *testing.T) string {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	//nolint:gosec // arguments are constants
+	cmd := exec.CommandContext(ctx, "kubectl", "port-forward",
+		"--namespace", releaseNamespace,
+		"service/"+proxyServiceName,
+		fmt.Sprintf(":%d", proxyListenerPort),
+	)
+	cmd.Env = append(os.Environ(), "KUBECONFIG="+kubeconfig)
+
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+
+	t.Cleanup(func() {
+		cancel()
+		_ = cmd.Wait()
+	})
+
+	// kubectl prints "Forwarding from 127.0.0.1:<port> -> 8080" once ready
+	forwarding := regexp.MustCompile(`Forwarding from 127\.0\.0\.1:(\d+)`)
+	scanner := bufio.NewScanner(stdout)
+
+	for scanner.Scan() {
+		if match := forwarding.FindStringSubmatch(scanner.Text()); match != nil {
+			// Drain the remaining output so that kubectl does not block
+			go func() { _, _ = io.Copy(io.Discard, stdout) }()
+
+			return "http://127.0.0.1:" + match[1]
+		}
+	}
+
+	t.Fatalf("kubectl port-forward exited: %v", scanner.Err())
+
+	return ""
+}
+
+// get sends a GET request for path with the Host header and returns the
+// status code and body.
+func get(baseURL, host, path string) (int, string, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, baseURL+path, http.NoBody)
+	if err != nil {
+		return 0, "", err
+	}
+
+	req.Host = host
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return resp.StatusCode, strings.TrimSpace(string(body)), nil
+}
@@ -0,0 +1,97 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// requireServedBy waits until requests for host are answered by a pod of the backend.
+func requireServedBy(t *testing.T, baseURL, host, backend string) {
+	t.Helper()
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		code, body, err := get(baseURL, host, "/hostname")
+		if !assert.NoError(c, err) {
+			return
+		}
+
+		assert.Equal(c, http.StatusOK, code)
+		assert.True(c, strings.HasPrefix(body, backend+"-"), "served by %q", body)
+	}, statusTimeout, pollInterval, "%s not served by %s", host, backend)
+}
+
+func TestGateway_Programmed(t *testing.T) {
+	t.Parallel()
+
+	namespace := createNamespace(t, "e2e-gateway")
+	gateway := createGateway(t, namespace, "gateway")
+
+	requireGatewayCondition(t, gateway, string(gatewayv1.GatewayConditionAccepted), metav1.ConditionTrue)
+	requireGatewayCondition(t, gateway, string(gatewayv1.GatewayConditionProgrammed), metav1.ConditionTrue)
+}
+
+func TestHTTPRoute_Traffic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	namespace := createNamespace(t, "e2e-traffic")
+	host := namespace + ".e2e.example.com"
+
+	deployBackend(t, namespace, "blue")
+	deployBackend(t, namespace, "green")
+	createGateway(t, namespace, "gateway")
+
+	route := newHTTPRoute(namespace, "app", "gateway", host, "blue")
+	require.NoError(t, k8sClient.Create(ctx, route))
+
+	requireRouteCondition(t, route, string(gatewayv1.RouteConditionAccepted), metav1.ConditionTrue, "")
+	requireRouteCondition(t, route, string(gatewayv1.RouteConditionResolvedRefs), metav1.ConditionTrue, "")
+
+	baseURL := portForwardProxy(t)
+	requireServedBy(t, baseURL, host, "blue")
+
+	// Switching the backend is synced to the proxy
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(route), route))
+
+	route.Spec.Rules[0].BackendRefs[0].Name = "green"
+	require.NoError(t, k8sClient.Update(ctx, route))
+
+	requireRouteCondition(t, route, string(gatewayv1.RouteConditionAccepted), metav1.ConditionTrue, "")
+	requireServedBy(t, baseURL, host, "green")
+
+	// Deleted routes are removed from the proxy
+	require.NoError(t, k8sClient.Delete(ctx, route))
+
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		code, _, err := get(baseURL, host, "/hostname")
+		if assert.NoError(c, err) {
+			assert.NotEqual(c, http.StatusOK, code)
+		}
+	}, statusTimeout, pollInterval, "deleted route still served")
+}
+
+func TestHTTPRoute_UnsupportedBackendKind(t *testing.T) {
+	t.Parallel()
+
+	namespace := createNamespace(t, "e2e-unresolved")
+	createGateway(t, namespace, "gateway")
+
+	route := newHTTPRoute(namespace, "bucket", "gateway", namespace+".e2e.example.com", "bucket")
+	route.Spec.Rules[0].BackendRefs[0].Kind = ptr.To(gatewayv1.Kind("S3Bucket"))
+	require.NoError(t, k8sClient.Create(context.Background(), route))
+
+	requireRouteCondition(t, route, string(gatewayv1.RouteConditionAccepted), metav1.ConditionTrue, "")
+	requireRouteCondition(t, route, string(gatewayv1.RouteConditionResolvedRefs), metav1.ConditionFalse,
+		string(gatewayv1.RouteReasonInvalidKind))
+}
@@ -0,0 +1,300 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+const (
+	defaultClusterName     = "pingora-e2e"
+	defaultGatewayAPICRDs  = "https://github.com/kubernetes-sigs/gateway-api/releases/download/v1.4.1/standard-install.yaml"
+	defaultBackendImage    = "registry.k8s.io/e2e-test-images/agnhost:2.39"
+	defaultContainerEngine = "podman"
+
+	releaseName       = "pingora-gateway-controller"
+	releaseNamespace  = "pingora-system"
+	proxyServiceName  = releaseName + "-proxy"
+	gatewayClassName  = "pingora"
+	proxyListenerPort = 8080
+)
+
+//nolint:gochecknoglobals // Required for TestMain setup shared across tests
+var (
+	kubeconfig string
+	k8sClient  client.Client
+)
+
+// TestMain creates the kind cluster, installs the controller and runs the tests.
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	clusterName := envOrDefault("E2E_CLUSTER_NAME", defaultClusterName)
+
+	created, err := ensureCluster(ctx, clusterName)
+	if err != nil {
+		log.Printf("Failed to create kind cluster: %v", err)
+
+		return 1
+	}
+
+	if created && os.Getenv("E2E_KEEP_CLUSTER") != "true" {
+		defer func() {
+			if _, deleteErr := command(context.Background(), "kind", "delete", "cluster", "--name", clusterName); deleteErr != nil {
+				log.Printf("Warning: failed to delete kind cluster: %v", deleteErr)
+			}
+		}()
+	}
+
+	defer func() {
+		if kubeconfig != "" {
+			_ = os.Remove(kubeconfig)
+		}
+	}()
+
+	if err := setupCluster(ctx, clusterName); err != nil {
+		log.Printf("Failed to set up cluster: %v", err)
+
+		return 1
+	}
+
+	return m.Run()
+}
+
+// ensureCluster creates the kind cluster unless it exists and reports
+// whether it was created.
+func ensureCluster(ctx context.Context, name string) (bool, error) {
+	clusters, err := command(ctx, "kind", "get", "clusters")
+	if err != nil {
+		return false, err
+	}
+
+	if slices.Contains(strings.Fields(clusters), name) {
+		log.Printf("Reusing kind cluster %s", name)
+
+		return false, nil
+	}
+
+	log.Printf("Creating kind cluster %s", name)
+
+	if _, err := command(ctx, "kind", "create", "cluster", "--name", name, "--wait", "2m"); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// setupCluster loads the images, installs the Gateway API CRDs and the chart,
+// and creates the client used by the tests.
+func setupCluster(ctx context.Context, clusterName string) error {
+	controllerImage := os.Getenv("CONTROLLER_IMAGE")
+	proxyImage := os.Getenv("PINGORA_PROXY_IMAGE")
+
+	if controllerImage == "" || proxyImage == "" {
+		return fmt.Errorf("CONTROLLER_IMAGE and PINGORA_PROXY_IMAGE must be set")
+	}
+
+	if err := writeKubeconfig(ctx, clusterName); err != nil {
+		return err
+	}
+
+	for _, image := range []string{controllerImage, proxyImage} {
+		if err := loadImage(ctx, clusterName, image); err != nil {
+			return err
+		}
+	}
+
+	log.Println("Installing Gateway API CRDs...")
+
+	crds := envOrDefault("GATEWAY_API_CRDS", defaultGatewayAPICRDs)
+	if _, err := command(ctx, "kubectl", "apply", "--server-side", "--filename", crds); err != nil {
+		return fmt.Errorf("failed to install Gateway API CRDs: %w", err)
+	}
+
+	if err := installChart(ctx, controllerImage, proxyImage); err != nil {
+		return err
+	}
+
+	return newClient()
+}
+
+// writeKubeconfig stores the kubeconfig of the cluster in a temporary file
+// used by kubectl, helm and the test client.
+func writeKubeconfig(ctx context.Context, clusterName string) error {
+	config, err := command(ctx, "kind", "get", "kubeconfig", "--name", clusterName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp("", "pingora-e2e-kubeconfig-*")
+	if err != nil {
+		return fmt.Errorf("failed to create kubeconfig file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(config); err != nil {
+		return fmt.Errorf("failed to write kubeconfig: %w", err)
+	}
+
+	kubeconfig = file.Name()
+
+	return nil
+}
+
+// loadImage loads a local image into the kind nodes. The image is exported
+// to an archive so that both Docker and Podman images can be loaded.
+func loadImage(ctx context.Context, clusterName, image string) error {
+	log.Printf("Loading image %s into kind", image)
+
+	archive := filepath.Join(os.TempDir(), "pingora-e2e-image.tar")
+	defer os.Remove(archive)
+
+	engine := envOrDefault("CONTAINER_RUNTIME", defaultContainerEngine)
+	if _, err := command(ctx, engine, "save", "--output", archive, image); err != nil {
+		return fmt.Errorf("failed to export image %s: %w", image, err)
+	}
+
+	if _, err := command(ctx, "kind", "load", "image-archive", archive, "--name", clusterName); err != nil {
+		return fmt.Errorf("failed to load image %s: %w", image, err)
+	}
+
+	return nil
+}
+
+// installChart installs the Helm chart with the loaded images and waits for
+// the controller and the proxy to become ready.
+func installChart(ctx context.Context, controllerImage, proxyImage string) error {
+	log.Println("Installing Helm chart...")
+
+	root, err := findProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	controllerRepo, controllerTag := splitImage(controllerImage)
+	proxyRepo, proxyTag := splitImage(proxyImage)
+
+	_, err = command(ctx, "helm", "upgrade", "--install", releaseName,
+		filepath.Join(root, "charts", "pingora-gateway-controller"),
+		"--namespace", releaseNamespace, "--create-namespace",
+		"--set", "image.repository="+controllerRepo,
+		"--set", "image.tag="+controllerTag,
+		"--set", "image.pullPolicy=Never",
+		"--set", "proxy.image.repository="+proxyRepo,
+		"--set", "proxy.image.tag="+proxyTag,
+		"--set", "proxy.image.pullPolicy=Never",
+		"--set", "proxy.replicaCount=1",
+		"--wait", "--timeout", "5m",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to install chart: %w", err)
+	}
+
+	return nil
+}
+
+// newClient creates the Kubernetes client used by the tests.
+func newClient() error {
+	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed to register core types: %w", err)
+	}
+
+	if err := gatewayv1.Install(scheme); err != nil {
+		return fmt.Errorf("failed to register Gateway API types: %w", err)
+	}
+
+	k8sClient, err = client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return nil
+}
+
+// command runs a command with the kubeconfig of the cluster and returns its
+// standard output.
+func command(ctx context.Context, name string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	if kubeconfig != "" {
+		cmd.Env = append(os.Environ(), "KUBECONFIG="+kubeconfig)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// splitImage splits an image reference into repository and tag.
+func splitImage(image string) (string, string) {
+	idx := strings.LastIndex(image, ":")
+	if idx < 0 || strings.Contains(image[idx:], "/") {
+		return image, "latest"
+	}
+
+	return image[:idx], image[idx+1:]
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+// findProjectRoot walks up from the current directory to find the project root.
+func findProjectRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	for {
+		// Check for go.mod as marker for project root
+		_, statErr := os.Stat(filepath.Join(dir, "go.mod"))
+		if statErr == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", os.ErrNotExist
+		}
+
+		dir = parent
+	}
+}
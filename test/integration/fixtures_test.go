@@ -60,6 +60,29 @@ func NewHTTPRouteExact(id string, hostnames []string, exactPath, backendAddr str
 	}
 }
 
+// NewHTTPRouteHeaderMatch creates a test HTTPRoute that matches all paths
+// of requests carrying the given header.
+func NewHTTPRouteHeaderMatch(id string, hostnames []string, header *routingv1.HeaderMatch, backendAddr string) *routingv1.HTTPRoute {
+	route := NewHTTPRoute(id, hostnames, "/", backendAddr)
+	route.Rules[0].Matches[0].Headers = []*routingv1.HeaderMatch{header}
+
+	return route
+}
+
+// NewHTTPRouteQueryParamMatch creates a test HTTPRoute that matches all paths
+// of requests carrying the given query parameter.
+func NewHTTPRouteQueryParamMatch(
+	id string,
+	hostnames []string,
+	param *routingv1.QueryParamMatch,
+	backendAddr string,
+) *routingv1.HTTPRoute {
+	route := NewHTTPRoute(id, hostnames, "/", backendAddr)
+	route.Rules[0].Matches[0].QueryParams = []*routingv1.QueryParamMatch{param}
+
+	return route
+}
+
 // NewGRPCRoute creates a test GRPCRoute.
 func NewGRPCRoute(id string, hostnames []string, service, method, backendAddr string) *routingv1.GRPCRoute {
 	return &routingv1.GRPCRoute{
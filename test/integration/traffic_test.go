@@ -304,6 +304,182 @@ func TestTraffic_HostRouting(t *testing.T) {
 	assert.Equal(t, 1, backendB.RequestCount())
 }
 
+// matchCase is a request that a route match must accept or reject.
+type matchCase struct {
+	name    string
+	path    string
+	headers map[string]string
+	matched bool
+}
+
+// assertMatches sends the requests of the cases through the proxy and checks
+// that only matching requests reach the backend.
+func assertMatches(ctx context.Context, t *testing.T, proxyAddr, host string, backend *MockBackend, cases []matchCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		backend.Reset()
+
+		resp, err := sendHTTPRequest(ctx, proxyAddr, tc.path, host, tc.headers)
+		require.NoError(t, err, tc.name)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		if tc.matched {
+			assert.Equal(t, http.StatusOK, resp.StatusCode, tc.name)
+			assert.Equal(t, 1, backend.RequestCount(), tc.name)
+		} else {
+			assert.Equal(t, 0, backend.RequestCount(), "%s: request must not match", tc.name)
+		}
+	}
+}
+
+func TestTraffic_HeaderMatch(t *testing.T) {
+	t.Parallel()
+	skipTrafficTestsIfNeeded(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	backend := StartMockBackend()
+	defer backend.Close()
+
+	container, err := StartPingoraContainer(ctx)
+	require.NoError(t, err)
+	defer container.Terminate(ctx)
+
+	client, conn, err := createGRPCClient(ctx, container.GRPCAddr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, container.WaitForReady(ctx, 30*time.Second))
+
+	backendAddr := getContainerAccessibleAddress(backend.URL())
+
+	tests := []struct {
+		name   string
+		header *routingv1.HeaderMatch
+		cases  []matchCase
+	}{
+		{
+			name: "exact",
+			header: &routingv1.HeaderMatch{
+				Name:  "X-Env",
+				Value: "canary",
+				Type:  routingv1.HeaderMatchType_HEADER_MATCH_TYPE_EXACT,
+			},
+			cases: []matchCase{
+				{name: "same value", path: "/", headers: map[string]string{"X-Env": "canary"}, matched: true},
+				{name: "header name is case-insensitive", path: "/", headers: map[string]string{"x-env": "canary"}, matched: true},
+				{name: "other value", path: "/", headers: map[string]string{"X-Env": "stable"}, matched: false},
+				{name: "value prefix", path: "/", headers: map[string]string{"X-Env": "canary-1"}, matched: false},
+				{name: "header missing", path: "/", matched: false},
+			},
+		},
+		{
+			name: "regex",
+			header: &routingv1.HeaderMatch{
+				Name:  "X-Version",
+				Value: "^v[0-9]+$",
+				Type:  routingv1.HeaderMatchType_HEADER_MATCH_TYPE_REGEX,
+			},
+			cases: []matchCase{
+				{name: "matching value", path: "/", headers: map[string]string{"X-Version": "v42"}, matched: true},
+				{name: "non-matching value", path: "/", headers: map[string]string{"X-Version": "v4x"}, matched: false},
+				{name: "header missing", path: "/", matched: false},
+			},
+		},
+	}
+
+	// Subtests share the container, so they run one after another
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := NewHTTPRouteHeaderMatch("default/header-"+tt.name, []string{"headers.example.com"}, tt.header, backendAddr)
+
+			_, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+				HttpRoutes: []*routingv1.HTTPRoute{route},
+				Version:    uint64(i + 1),
+			})
+			require.NoError(t, err)
+
+			assertMatches(ctx, t, container.HTTPAddr, "headers.example.com", backend, tt.cases)
+		})
+	}
+}
+
+func TestTraffic_QueryParamMatch(t *testing.T) {
+	t.Parallel()
+	skipTrafficTestsIfNeeded(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	backend := StartMockBackend()
+	defer backend.Close()
+
+	container, err := StartPingoraContainer(ctx)
+	require.NoError(t, err)
+	defer container.Terminate(ctx)
+
+	client, conn, err := createGRPCClient(ctx, container.GRPCAddr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, container.WaitForReady(ctx, 30*time.Second))
+
+	backendAddr := getContainerAccessibleAddress(backend.URL())
+
+	tests := []struct {
+		name  string
+		param *routingv1.QueryParamMatch
+		cases []matchCase
+	}{
+		{
+			name: "exact",
+			param: &routingv1.QueryParamMatch{
+				Name:  "variant",
+				Value: "beta",
+				Type:  routingv1.QueryParamMatchType_QUERY_PARAM_MATCH_TYPE_EXACT,
+			},
+			cases: []matchCase{
+				{name: "same value", path: "/search?variant=beta", matched: true},
+				{name: "among other parameters", path: "/search?q=pingora&variant=beta", matched: true},
+				{name: "other value", path: "/search?variant=stable", matched: false},
+				{name: "parameter name is case-sensitive", path: "/search?Variant=beta", matched: false},
+				{name: "parameter missing", path: "/search", matched: false},
+			},
+		},
+		{
+			name: "regex",
+			param: &routingv1.QueryParamMatch{
+				Name:  "page",
+				Value: "^[0-9]+$",
+				Type:  routingv1.QueryParamMatchType_QUERY_PARAM_MATCH_TYPE_REGEX,
+			},
+			cases: []matchCase{
+				{name: "matching value", path: "/list?page=12", matched: true},
+				{name: "non-matching value", path: "/list?page=last", matched: false},
+				{name: "parameter missing", path: "/list", matched: false},
+			},
+		},
+	}
+
+	// Subtests share the container, so they run one after another
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := NewHTTPRouteQueryParamMatch("default/query-"+tt.name, []string{"query.example.com"}, tt.param, backendAddr)
+
+			_, err := client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+				HttpRoutes: []*routingv1.HTTPRoute{route},
+				Version:    uint64(i + 1),
+			})
+			require.NoError(t, err)
+
+			assertMatches(ctx, t, container.HTTPAddr, "query.example.com", backend, tt.cases)
+		})
+	}
+}
+
 func TestTraffic_NoRoute404(t *testing.T) {
 	t.Parallel()
 	skipTrafficTestsIfNeeded(t)
@@ -343,3 +519,81 @@ func TestTraffic_NoRoute404(t *testing.T) {
 	assert.True(t, resp.StatusCode >= 400, "Expected 4xx or 5xx status, got %d", resp.StatusCode)
 	assert.Equal(t, 0, backend.RequestCount(), "Backend should not receive request for unknown host")
 }
+
+func TestTraffic_SessionPersistenceCookie(t *testing.T) {
+	t.Parallel()
+	skipTrafficTestsIfNeeded(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	backendA := StartMockBackend()
+	defer backendA.Close()
+
+	backendB := StartMockBackend()
+	defer backendB.Close()
+
+	container, err := StartPingoraContainer(ctx)
+	require.NoError(t, err)
+	defer container.Terminate(ctx)
+
+	client, conn, err := createGRPCClient(ctx, container.GRPCAddr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, container.WaitForReady(ctx, 30*time.Second))
+
+	// Route splitting traffic evenly between two backends with sticky sessions
+	route := NewHTTPRoute("default/sticky", []string{"sticky.example.com"}, "/", getContainerAccessibleAddress(backendA.URL()))
+	route.Rules[0].Backends = append(route.Rules[0].Backends, NewBackend(getContainerAccessibleAddress(backendB.URL()), 1))
+	route.Rules[0].SessionPersistence = &routingv1.SessionPersistence{
+		Type:           routingv1.SessionPersistenceType_SESSION_PERSISTENCE_TYPE_COOKIE,
+		SessionName:    "sticky-session",
+		CookieLifetime: routingv1.CookieLifetimeType_COOKIE_LIFETIME_TYPE_SESSION,
+	}
+
+	_, err = client.UpdateRoutes(ctx, &routingv1.UpdateRoutesRequest{
+		HttpRoutes: []*routingv1.HTTPRoute{route},
+		Version:    1,
+	})
+	require.NoError(t, err)
+
+	// The first request establishes the session
+	resp, err := sendHTTPRequest(ctx, container.HTTPAddr, "/", "sticky.example.com", nil)
+	require.NoError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var sessionCookie *http.Cookie
+
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "sticky-session" {
+			sessionCookie = cookie
+		}
+	}
+
+	require.NotNil(t, sessionCookie, "proxy must set the session cookie")
+
+	pinned, other := backendA, backendB
+	if backendB.RequestCount() == 1 {
+		pinned, other = backendB, backendA
+	}
+
+	// Requests carrying the cookie must stick to the same backend
+	const stickyRequests = 10
+
+	for range stickyRequests {
+		resp, err := sendHTTPRequest(ctx, container.HTTPAddr, "/", "sticky.example.com",
+			map[string]string{"Cookie": sessionCookie.String()})
+		require.NoError(t, err)
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	assert.Equal(t, stickyRequests+1, pinned.RequestCount())
+	assert.Equal(t, 0, other.RequestCount())
+}